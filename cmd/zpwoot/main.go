@@ -26,11 +26,10 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
-	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server"
 	"zpwoot/internal/services"
 	"zpwoot/platform/config"
 	"zpwoot/platform/container"
@@ -64,6 +63,11 @@ func main() {
 		"version": appVersion,
 	})
 
+	shutdownTracing, err := logger.SetupTracing(ctx, cfg.Telemetry, appVersion)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("Failed to initialize tracing: %v", err))
+	}
+
 	db, err := database.NewFromAppConfig(cfg, log)
 	if err != nil {
 		log.Fatal(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -91,13 +95,7 @@ func main() {
 		log.Fatal(fmt.Sprintf("Failed to start container components: %v", err))
 	}
 
-	server := &http.Server{
-		Addr:         cfg.GetServerAddress(),
-		Handler:      diContainer.Handler(),
-		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
-	}
+	httpServer := server.NewHTTPServer(cfg, diContainer.Handler())
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -108,14 +106,32 @@ func main() {
 		log.InfoWithFields("Server started", map[string]interface{}{
 			"module": "server",
 			"port":   cfg.Server.Port,
+			"tls":    cfg.Server.TLSEnabled(),
+			"http2":  cfg.Server.HTTP2,
 		})
 
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := server.ListenAndServe(httpServer, cfg); err != nil && err != http.ErrServerClosed {
 			errChan <- fmt.Errorf("HTTP server error: %w", err)
 		}
 	}()
 
-	go connectOnStartup(diContainer, log)
+	grpcServer := diContainer.GRPCServer()
+	if grpcServer != nil {
+		go func() {
+			log.InfoWithFields("gRPC server started", map[string]interface{}{
+				"module": "grpc",
+				"port":   cfg.Server.GRPCPort,
+			})
+
+			if err := grpcServer.ListenAndServe(); err != nil {
+				errChan <- fmt.Errorf("gRPC server error: %w", err)
+			}
+		}()
+	}
+
+	go connectOnStartup(ctx, diContainer, cfg.WhatsApp.StartupReconnect, log)
+
+	go reloadOnSignal(diContainer.GetConfigReloader(), log)
 
 	select {
 	case sig := <-sigChan:
@@ -132,194 +148,79 @@ func main() {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		log.ErrorWithFields("Error shutting down HTTP server", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+			log.ErrorWithFields("Error shutting down gRPC server", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	if err := diContainer.Stop(shutdownCtx); err != nil {
 		log.ErrorWithFields("Error stopping container components", map[string]interface{}{
 			"error": err.Error(),
 		})
 	}
 
-	log.Info("Application shutdown completed successfully")
-}
-
-func connectOnStartup(container *container.Container, logger *logger.Logger) {
-	const (
-		startupDelay     = 1 * time.Second
-		operationTimeout = 90 * time.Second
-		sessionLimit     = 100
-		reconnectDelay   = 500 * time.Millisecond
-	)
-
-	time.Sleep(startupDelay)
-
-	sessionService := container.GetSessionService()
-	if sessionService == nil {
-		logger.Warn("Session service not available, skipping auto-reconnect")
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
-	defer cancel()
-
-	logger.Info("Starting session restoration and auto-reconnect process...")
-
-	if err := sessionService.RestoreAllSessions(ctx); err != nil {
-		logger.ErrorWithFields("Failed to restore sessions", map[string]interface{}{
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.ErrorWithFields("Error flushing traces", map[string]interface{}{
 			"error": err.Error(),
 		})
-		return
 	}
 
-	// Auto-reconnection is now handled by the gateway during restoration
-	logger.Info("Auto-reconnection is handled automatically during session restoration")
+	log.Info("Application shutdown completed successfully")
 }
 
-func getExistingSessions(ctx context.Context, sessionService *services.SessionService, limit int, logger *logger.Logger) []sessionInfo {
-	req := &contracts.ListSessionsRequest{
-		Limit:  limit,
-		Offset: 0,
-	}
+func reloadOnSignal(reloader *config.Reloader, log *logger.Logger) {
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
 
-	response, err := sessionService.ListSessions(ctx, req)
-	if err != nil {
-		logger.ErrorWithFields("Failed to list sessions", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return nil
-	}
+	for range hupChan {
+		log.Info("Received SIGHUP, reloading configuration")
 
-	sessionsWithCredentials := make([]sessionInfo, 0, len(response.Sessions))
-
-	for _, sessionResponse := range response.Sessions {
-		session := sessionResponse.Session
-
-		if session.DeviceJID != "" {
-			sessionsWithCredentials = append(sessionsWithCredentials, sessionInfo{
-				ID:        session.ID,
-				Name:      session.Name,
-				DeviceJID: session.DeviceJID,
+		result, err := reloader.Reload()
+		if err != nil {
+			log.ErrorWithFields("Failed to reload configuration", map[string]interface{}{
+				"error": err.Error(),
 			})
+			continue
 		}
-	}
-
-	logger.InfoWithFields("Found sessions for reconnection", map[string]interface{}{
-		"total_sessions": len(response.Sessions),
-		"reconnectable":  len(sessionsWithCredentials),
-		"needs_pairing":  len(response.Sessions) - len(sessionsWithCredentials),
-	})
-
-	return sessionsWithCredentials
-}
-
-func reconnectSessions(ctx context.Context, sessions []sessionInfo, sessionService *services.SessionService, logger *logger.Logger, delay time.Duration) reconnectStats {
-	if len(sessions) == 0 {
-		return reconnectStats{}
-	}
 
-	if len(sessions) <= 3 {
-		return reconnectSessionsSequential(ctx, sessions, sessionService, logger, delay)
+		log.InfoWithFields("Configuration reloaded", map[string]interface{}{
+			"changed": result.Changed,
+		})
 	}
-
-	return reconnectSessionsParallel(ctx, sessions, sessionService, logger)
 }
 
-func reconnectSessionsSequential(ctx context.Context, sessions []sessionInfo, sessionService *services.SessionService, logger *logger.Logger, delay time.Duration) reconnectStats {
-	stats := reconnectStats{}
-
-	for _, session := range sessions {
-		select {
-		case <-ctx.Done():
-			logger.Warn("Auto-reconnect cancelled due to timeout")
-			return stats
-		default:
-		}
-
-		// Create timeout context for individual session connection
-		sessionCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		result, err := sessionService.ConnectSession(sessionCtx, session.ID)
-		cancel()
-		if err != nil {
-			stats.failed++
-		} else if result.Success {
-			if result.QRCode != "" {
-				stats.skipped++
-			} else {
-				stats.connected++
-			}
-		} else {
-			stats.failed++
-		}
+func connectOnStartup(ctx context.Context, container *container.Container, cfg config.StartupReconnectConfig, logger *logger.Logger) {
+	time.Sleep(time.Duration(cfg.Delay) * time.Second)
 
-		if delay > 0 {
-			time.Sleep(delay)
-		}
+	sessionService := container.GetSessionService()
+	if sessionService == nil {
+		logger.Warn("Session service not available, skipping auto-reconnect")
+		return
 	}
 
-	return stats
-}
-
-func reconnectSessionsParallel(ctx context.Context, sessions []sessionInfo, sessionService *services.SessionService, logger *logger.Logger) reconnectStats {
-	const maxConcurrency = 5
-
-	semaphore := make(chan struct{}, maxConcurrency)
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	stats := reconnectStats{}
-
-	for _, session := range sessions {
-		wg.Add(1)
-		go func(sess sessionInfo) {
-			defer wg.Done()
-
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			select {
-			case <-ctx.Done():
-				mu.Lock()
-				stats.failed++
-				mu.Unlock()
-				return
-			default:
-			}
+	logger.Info("Starting session restoration and auto-reconnect process...")
 
-			result, err := sessionService.ConnectSession(ctx, sess.ID)
-
-			mu.Lock()
-			if err != nil {
-				stats.failed++
-			} else if result.Success {
-				if result.QRCode != "" {
-					stats.skipped++
-				} else {
-					stats.connected++
-				}
-			} else {
-				stats.failed++
-			}
-			mu.Unlock()
-		}(session)
+	opts := services.ReconnectOptions{
+		MaxSessions: cfg.MaxSessions,
+		Workers:     cfg.Workers,
+		Spacing:     time.Duration(cfg.SpacingMs) * time.Millisecond,
+		Timeout:     time.Duration(cfg.Timeout) * time.Second,
 	}
 
-	wg.Wait()
-	return stats
-}
-
-type sessionInfo struct {
-	ID        string
-	Name      string
-	DeviceJID string
-}
-
-type reconnectStats struct {
-	connected int
-	skipped   int
-	failed    int
+	if err := sessionService.RestoreAllSessions(ctx, opts); err != nil {
+		logger.ErrorWithFields("Failed to restore sessions", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
 }
 
 func runMigrations(db *database.Database, log *logger.Logger) error {
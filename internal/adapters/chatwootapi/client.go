@@ -0,0 +1,75 @@
+package chatwootapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/platform/config"
+)
+
+// Client calls the Chatwoot REST API with an agent or bot access token
+// that has access to every account routed to this instance.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// New returns nil when no URL or token is configured, which the chatwoot
+// service reads as "API not available".
+func New(cfg config.ChatwootConfig) chatwoot.API {
+	if cfg.URL == "" || cfg.APIToken == "" {
+		return nil
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(cfg.URL, "/"),
+		token:   cfg.APIToken,
+		http:    &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+	}
+}
+
+func (c *Client) ReopenConversation(ctx context.Context, accountID, conversationID int) error {
+	path := fmt.Sprintf("/api/v1/accounts/%d/conversations/%d/toggle_status", accountID, conversationID)
+	return c.send(ctx, http.MethodPost, path, map[string]string{"status": "open"})
+}
+
+func (c *Client) UpdateContactName(ctx context.Context, accountID, contactID int, name string) error {
+	path := fmt.Sprintf("/api/v1/accounts/%d/contacts/%d", accountID, contactID)
+	return c.send(ctx, http.MethodPut, path, map[string]string{"name": name})
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api_access_token", c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("chatwoot responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return nil
+}
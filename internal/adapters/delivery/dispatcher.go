@@ -0,0 +1,319 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/adapters/waclient"
+	"zpwoot/internal/core/webhook"
+	"zpwoot/platform/config"
+	"zpwoot/platform/logger"
+	"zpwoot/platform/metrics"
+)
+
+const (
+	signatureHeader = "X-Zpwoot-Signature"
+	eventHeader     = "X-Zpwoot-Event"
+	schemaHeader    = "X-Zpwoot-Schema-Version"
+)
+
+// Dispatcher turns WhatsApp events into webhook deliveries. Every event goes
+// to the global URL from the environment, if set, and to each of the
+// session's webhooks that subscribes to the event's category.
+type Dispatcher struct {
+	service *webhook.Service
+	logger  *logger.Logger
+	stream  *Stream
+	zones   Timezones
+	sli     *metrics.SLI
+
+	mu     sync.RWMutex
+	cfg    config.WebhookConfig
+	client *http.Client
+}
+
+func NewDispatcher(service *webhook.Service, cfg config.WebhookConfig, logger *logger.Logger) *Dispatcher {
+	d := &Dispatcher{
+		service: service,
+		logger:  logger,
+	}
+	d.UpdateConfig(cfg)
+
+	return d
+}
+
+// UpdateConfig swaps in a reloaded configuration. Deliveries already in
+// flight finish with the settings they started with.
+func (d *Dispatcher) UpdateConfig(cfg config.WebhookConfig) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.VerifySSL {
+		// Opt-in through WEBHOOK_VERIFY_SSL=false for receivers with
+		// self-signed certificates.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.cfg = cfg
+	d.client = &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+	}
+}
+
+// Timezones gives the timezone of a session, or nil when it has none.
+type Timezones interface {
+	SessionLocation(sessionID string) *time.Location
+}
+
+// SetTimezones localizes event timestamps to their session's timezone. It
+// must be set before events start flowing.
+func (d *Dispatcher) SetTimezones(zones Timezones) {
+	d.zones = zones
+}
+
+// SetStream publishes every dispatched event to stream too. It must be set
+// before events start flowing.
+func (d *Dispatcher) SetStream(stream *Stream) {
+	d.stream = stream
+}
+
+// SetSLI records the outcome of every dispatched delivery as a webhook
+// SLI. It must be set before events start flowing.
+func (d *Dispatcher) SetSLI(sli *metrics.SLI) {
+	d.sli = sli
+}
+
+func (d *Dispatcher) settings() (config.WebhookConfig, *http.Client) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.cfg, d.client
+}
+
+// HandleWhatsmeowEvent implements waclient.WebhookEventHandler.
+func (d *Dispatcher) HandleWhatsmeowEvent(evt interface{}, sessionID string) error {
+	data := evt
+	switch message := evt.(type) {
+	case *events.Message:
+		data = waclient.NewMessageEvent(message)
+	case *waclient.MessageEvent:
+		// Built by the gateway already, with the link to its media.
+		evt = message.Message
+	}
+
+	name, category, ok := classify(evt)
+	if !ok {
+		return nil
+	}
+
+	return d.Dispatch(context.Background(), &webhook.Event{
+		ID:        uuid.NewString(),
+		Event:     name,
+		Category:  category,
+		SessionID: sessionID,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
+// Dispatch delivers one event to every webhook that wants it, concurrently,
+// so a slow or failing endpoint does not hold back the others. Each receives
+// it in its own schema version, under the same event ID, and the outcome is
+// recorded per session webhook.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *webhook.Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Location == nil && d.zones != nil {
+		event.Location = d.zones.SessionLocation(event.SessionID)
+	}
+	if d.stream != nil {
+		d.stream.Publish(event)
+	}
+
+	var targets []*webhook.Target
+	var errs []error
+
+	cfg, _ := d.settings()
+	if cfg.GlobalURL != "" {
+		targets = append(targets, &webhook.Target{Webhook: &webhook.Webhook{
+			URL:           cfg.GlobalURL,
+			Secret:        cfg.Secret,
+			SchemaVersion: cfg.SchemaVersion,
+			Format:        cfg.Format,
+			Enabled:       true,
+		}})
+	}
+
+	if sessionID, err := uuid.Parse(event.SessionID); err == nil {
+		routed, err := d.service.Route(ctx, sessionID, event.Category)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve webhooks: %w", err))
+		}
+		targets = append(targets, routed...)
+	}
+
+	results := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target *webhook.Target) {
+			defer wg.Done()
+
+			delivery, err := d.Send(ctx, target.Webhook, target.Template, event)
+			if target.Webhook.ID != uuid.Nil {
+				d.service.RecordDelivery(context.WithoutCancel(ctx), target.Webhook, delivery, err)
+			}
+			if d.sli != nil {
+				d.sli.ObserveWebhook(err == nil)
+			}
+			results[i] = err
+		}(i, target)
+	}
+	wg.Wait()
+
+	return errors.Join(append(errs, results...)...)
+}
+
+// Send posts one event, retrying network errors, 429 and 5xx responses up to
+// WEBHOOK_RETRY_MAX times with a linearly growing delay.
+func (d *Dispatcher) Send(ctx context.Context, target *webhook.Webhook, template *webhook.Template, event *webhook.Event) (*webhook.Delivery, error) {
+	body, err := render(event, target, template)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, client := d.settings()
+	delivery := &webhook.Delivery{URL: target.URL}
+	start := time.Now()
+	defer func() { delivery.Duration = time.Since(start) }()
+
+	retryDelay := time.Duration(cfg.RetryDelay) * time.Second
+	var lastErr error
+
+	for attempt := 0; attempt <= cfg.RetryMax; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return delivery, ctx.Err()
+			case <-time.After(retryDelay * time.Duration(attempt)):
+			}
+		}
+
+		delivery.Attempts = attempt + 1
+		status, err := post(ctx, client, cfg.UserAgent, target, event.Event, body)
+		delivery.StatusCode = status
+
+		if err == nil && status < 300 {
+			return delivery, nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("webhook responded with status %d", status)
+			if status != http.StatusTooManyRequests && status < 500 {
+				break
+			}
+		}
+	}
+
+	d.logger.WarnWithFields("Webhook delivery failed", map[string]interface{}{
+		"url":        target.URL,
+		"event":      event.Event,
+		"session_id": event.SessionID,
+		"attempts":   delivery.Attempts,
+		"error":      lastErr.Error(),
+	})
+
+	return delivery, fmt.Errorf("failed to deliver %s to %s: %w", event.Event, target.URL, lastErr)
+}
+
+func post(ctx context.Context, client *http.Client, userAgent string, target *webhook.Webhook, eventName string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(eventHeader, eventName)
+	req.Header.Set(schemaHeader, strconv.Itoa(schemaVersion(target)))
+	if target.Secret != "" {
+		req.Header.Set(signatureHeader, "sha256="+sign(target.Secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
+
+func schemaVersion(target *webhook.Webhook) int {
+	if target.SchemaVersion == 0 {
+		return webhook.DefaultSchemaVersion
+	}
+	return target.SchemaVersion
+}
+
+// render marshals the event in the webhook's format: the envelope of its
+// schema version, or the flat payload of the simple format. A template then
+// reshapes it; templates work on the JSON form of the payload, so field
+// names are the ones receivers see without a template.
+func render(event *webhook.Event, target *webhook.Webhook, template *webhook.Template) ([]byte, error) {
+	var payload interface{}
+	if target.Format == webhook.FormatSimple {
+		payload = simplify(event)
+	} else {
+		envelope, err := webhook.Envelope(event, target.SchemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		payload = envelope
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	if template == nil {
+		return body, nil
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(body, &generic); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	body, err = json.Marshal(template.Apply(generic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal templated webhook event: %w", err)
+	}
+
+	return body, nil
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
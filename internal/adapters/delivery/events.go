@@ -0,0 +1,103 @@
+package delivery
+
+import (
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/adapters/waclient"
+	"zpwoot/internal/core/webhook"
+)
+
+// classify names an event and assigns the category registered for that name
+// in webhook.EventTypes. Events without a name are internal plumbing
+// (history sync, app state, keep-alives) and are not delivered.
+func classify(evt interface{}) (string, webhook.EventCategory, bool) {
+	name := eventName(evt)
+	if name == "" {
+		return "", "", false
+	}
+	category, ok := webhook.CategoryOf(name)
+	return name, category, ok
+}
+
+// eventName names an event. Raw reaction, poll vote, edit, revoke, pin,
+// call, QR, connection, logout and temporary ban events are skipped because
+// the gateway emits its own message.reaction, poll.vote, message.edited,
+// message.revoked, message.pinned, message.unpinned, call.received,
+// qr.updated, session.connected, session.disconnected, session.logged_out,
+// session.throttled and session.banned events with the outcome of handling
+// them.
+func eventName(evt interface{}) string {
+	switch v := evt.(type) {
+	case *events.Message:
+		if v.Message.GetReactionMessage() != nil || v.Message.GetPollUpdateMessage() != nil || waclient.IsEditOrRevoke(v) || waclient.IsPin(v) {
+			return ""
+		}
+		return "message"
+	case *waclient.ReactionEvent:
+		return v.Event
+	case *waclient.MessageEditedEvent:
+		return v.Event
+	case *waclient.MessageRevokedEvent:
+		return v.Event
+	case *waclient.MessagePinnedEvent:
+		return v.Event
+	case *waclient.PollVoteEvent:
+		return v.Event
+	case *waclient.LiveLocationEvent:
+		return v.Event
+	case *waclient.SendConfirmedEvent:
+		return v.Event
+	case *events.UndecryptableMessage:
+		return "message.undecryptable"
+	case *waclient.OrderEvent:
+		return "order"
+	case *waclient.PaymentEvent:
+		return "payment"
+
+	case *events.Receipt:
+		return "receipt"
+
+	case *events.Presence:
+		return "presence"
+	case *events.ChatPresence:
+		return "chat_presence"
+
+	case *events.GroupInfo:
+		return "group.updated"
+	case *events.JoinedGroup:
+		return "group.joined"
+	case *waclient.GroupMetadataChangedEvent:
+		return v.Event
+
+	case *waclient.CallEvent:
+		return v.Event
+
+	case *waclient.AvatarChangedEvent:
+		return v.Event
+
+	case *waclient.SessionConnectedEvent:
+		return v.Event
+	case *waclient.SessionDisconnectedEvent:
+		return v.Event
+	case *waclient.SessionLoggedOutEvent:
+		return v.Event
+	case *events.ConnectFailure:
+		return "connect_failure"
+	case *waclient.SessionThrottledEvent:
+		return v.Event
+	case *waclient.SessionBannedEvent:
+		return v.Event
+	case *waclient.DeviceAddedEvent:
+		return v.Event
+	case *events.PairSuccess:
+		return "pair_success"
+	case *events.PairError:
+		return "pair_error"
+	case *waclient.QRUpdatedEvent:
+		return v.Event
+	case *waclient.PairingEndedEvent:
+		return v.Event
+	}
+
+	return ""
+}
@@ -0,0 +1,109 @@
+package delivery
+
+import (
+	"strings"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"zpwoot/internal/adapters/waclient"
+	"zpwoot/internal/core/webhook"
+)
+
+var mapper = waclient.NewMessageMapper()
+
+// simplify flattens an event into the simple webhook format. Messages and
+// the gateway's message and call events map onto the flat fields; anything
+// else keeps its full payload under data.
+func simplify(event *webhook.Event) *webhook.SimpleEvent {
+	simple := &webhook.SimpleEvent{
+		ID:        event.ID,
+		Event:     event.Event,
+		SessionID: event.SessionID,
+		Type:      event.Event,
+		Timestamp: event.Timestamp,
+	}
+
+	switch v := event.Data.(type) {
+	case *waclient.MessageEvent:
+		message := mapper.EventToWhatsAppMessage(v.Message)
+		setParties(simple, v.Info.Sender.ToNonAD().String(), v.Info.Chat.String())
+		simple.MessageID = message.ID
+		simple.Name = v.Info.PushName
+		simple.FromMe = message.FromMe
+		simple.Type = message.Type
+		simple.Text = message.Content
+		simple.MediaURL = v.MediaURL
+		if v.State != nil {
+			simple.State = v.State.Data
+			simple.StateVersion = v.State.Version
+		}
+		simple.Timestamp = message.Timestamp
+	case *waclient.ReactionEvent:
+		setParties(simple, v.Sender, v.Chat)
+		simple.MessageID = v.MessageID
+		simple.FromMe = v.FromMe
+		simple.Type = "reaction"
+		simple.Text = v.Emoji
+		simple.Timestamp = v.Timestamp
+	case *waclient.MessageEditedEvent:
+		setParties(simple, v.Sender, v.Chat)
+		simple.MessageID = v.MessageID
+		simple.FromMe = v.FromMe
+		simple.Type = v.Type
+		simple.Text = v.Content
+		simple.Timestamp = v.Timestamp
+	case *waclient.MessageRevokedEvent:
+		setParties(simple, v.Sender, v.Chat)
+		simple.MessageID = v.MessageID
+		simple.FromMe = v.FromMe
+		simple.Type = "revoke"
+		simple.Timestamp = v.Timestamp
+	case *waclient.MessagePinnedEvent:
+		setParties(simple, v.Sender, v.Chat)
+		simple.MessageID = v.MessageID
+		simple.FromMe = v.FromMe
+		simple.Type = "pin"
+		if !v.Pinned {
+			simple.Type = "unpin"
+		}
+		simple.Timestamp = v.Timestamp
+	case *waclient.PollVoteEvent:
+		setParties(simple, v.Voter, v.Chat)
+		simple.MessageID = v.PollID
+		simple.FromMe = v.FromMe
+		simple.Type = "poll_vote"
+		simple.Text = strings.Join(v.Options, ", ")
+		simple.Timestamp = v.Timestamp
+	case *waclient.CallEvent:
+		chat := v.From
+		if v.GroupJID != "" {
+			chat = v.GroupJID
+		}
+		setParties(simple, v.CallCreator, chat)
+		if v.CallerPhone != "" {
+			simple.From = v.CallerPhone
+		}
+		simple.Type = "call"
+		simple.Text = v.Media
+		simple.Timestamp = v.Timestamp
+	default:
+		simple.Data = event.Data
+	}
+
+	simple.Timezone = event.Timezone()
+	simple.LocalTimestamp = event.LocalTime(simple.Timestamp)
+
+	return simple
+}
+
+// setParties fills the sender and chat from their JIDs. Senders on the
+// WhatsApp user server are given as bare phone numbers, which is what
+// low-code flows compare and reply to; others (LIDs) keep the JID.
+func setParties(simple *webhook.SimpleEvent, sender, chat string) {
+	simple.From = sender
+	if jid, err := types.ParseJID(sender); err == nil && jid.Server == types.DefaultUserServer {
+		simple.From = jid.User
+	}
+	simple.Chat = chat
+	simple.IsGroup = strings.HasSuffix(chat, "@"+types.GroupServer)
+}
@@ -0,0 +1,94 @@
+package delivery
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"zpwoot/internal/core/webhook"
+)
+
+// streamBuffer is how far a subscriber may fall behind before it starts
+// missing events.
+const streamBuffer = 256
+
+// Stream fans events out to in-process subscribers, such as gRPC event
+// streams, alongside the webhook deliveries. Publishing never blocks on a
+// subscriber: one whose buffer is full misses the event, which is counted.
+type Stream struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewStream() *Stream {
+	return &Stream{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the events of one session, or of every session
+// when sessionID is empty, whose type or category is in events, or every
+// event when events is empty.
+type Subscription struct {
+	events    chan *webhook.Event
+	sessionID string
+	filter    map[string]bool
+	dropped   atomic.Int64
+}
+
+// Events is closed when the subscription is cancelled.
+func (s *Subscription) Events() <-chan *webhook.Event {
+	return s.events
+}
+
+// Dropped is the number of events the subscriber missed for falling behind.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+func (s *Subscription) wants(event *webhook.Event) bool {
+	if s.sessionID != "" && s.sessionID != event.SessionID {
+		return false
+	}
+	return len(s.filter) == 0 || s.filter[event.Event] || s.filter[string(event.Category)]
+}
+
+func (s *Stream) Subscribe(sessionID string, events []string) *Subscription {
+	sub := &Subscription{
+		events:    make(chan *webhook.Event, streamBuffer),
+		sessionID: sessionID,
+		filter:    make(map[string]bool, len(events)),
+	}
+	for _, event := range events {
+		sub.filter[event] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs[sub] = struct{}{}
+
+	return sub
+}
+
+func (s *Stream) Unsubscribe(sub *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[sub]; ok {
+		delete(s.subs, sub)
+		close(sub.events)
+	}
+}
+
+func (s *Stream) Publish(event *webhook.Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subs {
+		if !sub.wants(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package fakewa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/adapters/waclient"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/session"
+)
+
+// Event types accepted in scripts.
+const (
+	EventMessage      = "message"
+	EventReceipt      = "receipt"
+	EventConnected    = "connected"
+	EventDisconnected = "disconnected"
+	EventLoggedOut    = "logged_out"
+)
+
+// Event is one step of a script injected into a session. Delay is waited
+// before the step runs, counted from the previous step.
+type Event struct {
+	Type  string
+	Delay time.Duration
+
+	// message and receipt
+	From     string
+	Chat     string
+	FromMe   bool
+	ID       string
+	Text     string
+	PushName string
+
+	// receipt: delivered, read or played
+	ReceiptType string
+	MessageIDs  []string
+
+	// disconnected and logged_out
+	Reason string
+}
+
+// Inject validates a script and plays it in the background. It returns the
+// ID of each message step, generated when the step has none, so callers can
+// refer to those messages afterwards.
+func (g *Gateway) Inject(sessionName string, script []Event) ([]string, error) {
+	if _, err := g.session(sessionName); err != nil {
+		return nil, err
+	}
+
+	steps := make([]Event, len(script))
+	var ids []string
+	for i, evt := range script {
+		if err := g.prepare(&evt); err != nil {
+			return nil, fmt.Errorf("validation failed: event %d: %w", i, err)
+		}
+		if evt.Type == EventMessage {
+			ids = append(ids, evt.ID)
+		}
+		steps[i] = evt
+	}
+
+	go func() {
+		for _, evt := range steps {
+			if evt.Delay > 0 {
+				time.Sleep(evt.Delay)
+			}
+			if err := g.play(sessionName, evt); err != nil {
+				g.logger.WarnWithFields("Scripted event failed", map[string]interface{}{
+					"session_name": sessionName,
+					"type":         evt.Type,
+					"error":        err.Error(),
+				})
+			}
+		}
+	}()
+
+	return ids, nil
+}
+
+func (g *Gateway) prepare(evt *Event) error {
+	switch evt.Type {
+	case EventMessage:
+		if evt.From == "" && !evt.FromMe {
+			return fmt.Errorf("message needs from")
+		}
+		if evt.Text == "" {
+			return fmt.Errorf("message needs text")
+		}
+		if evt.ID == "" {
+			evt.ID = g.messageID()
+		}
+	case EventReceipt:
+		if evt.From == "" || len(evt.MessageIDs) == 0 {
+			return fmt.Errorf("receipt needs from and messageIds")
+		}
+		switch evt.ReceiptType {
+		case "", "delivered", "read", "played":
+		default:
+			return fmt.Errorf("unknown receipt type %q", evt.ReceiptType)
+		}
+	case EventConnected, EventDisconnected, EventLoggedOut:
+	default:
+		return fmt.Errorf("unknown event type %q", evt.Type)
+	}
+
+	for _, value := range []string{evt.From, evt.Chat} {
+		if value == "" {
+			continue
+		}
+		if _, err := normalizeJID(value); err != nil {
+			return fmt.Errorf("invalid JID %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+func (g *Gateway) play(sessionName string, evt Event) error {
+	switch evt.Type {
+	case EventConnected:
+		g.markConnected(sessionName)
+		return nil
+	case EventDisconnected:
+		reason := evt.Reason
+		if reason == "" {
+			reason = waclient.DisconnectConnectionLost
+		}
+		return g.disconnect(sessionName, reason)
+	case EventLoggedOut:
+		return g.LogOut(sessionName, evt.Reason)
+	}
+
+	source, err := g.messageSource(sessionName, evt)
+	if err != nil {
+		return err
+	}
+
+	if evt.Type == EventReceipt {
+		ids := make([]types.MessageID, len(evt.MessageIDs))
+		copy(ids, evt.MessageIDs)
+		g.emit(sessionName, &events.Receipt{
+			MessageSource: source,
+			MessageIDs:    ids,
+			Timestamp:     time.Now(),
+			Type:          receiptType(evt.ReceiptType),
+		})
+		return nil
+	}
+
+	text := evt.Text
+	g.emit(sessionName, &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: source,
+			ID:            evt.ID,
+			PushName:      evt.PushName,
+			Timestamp:     time.Now(),
+			Type:          "text",
+		},
+		Message: &waE2E.Message{Conversation: &text},
+	})
+	return nil
+}
+
+func (g *Gateway) messageSource(sessionName string, evt Event) (types.MessageSource, error) {
+	var source types.MessageSource
+
+	g.mu.RLock()
+	own := ""
+	if sess, ok := g.sessions[sessionName]; ok {
+		own = sess.deviceJID
+	}
+	g.mu.RUnlock()
+
+	sender := evt.From
+	if evt.FromMe {
+		if own == "" {
+			return source, fmt.Errorf("session %s is not paired", sessionName)
+		}
+		sender = own
+	}
+
+	senderJID, err := normalizeJID(sender)
+	if err != nil {
+		return source, err
+	}
+	source.Sender = senderJID.ToNonAD()
+	source.IsFromMe = evt.FromMe
+	source.Chat = source.Sender
+
+	if evt.Chat != "" {
+		chatJID, err := normalizeJID(evt.Chat)
+		if err != nil {
+			return source, err
+		}
+		source.Chat = chatJID
+	}
+	source.IsGroup = source.Chat.Server == types.GroupServer
+	return source, nil
+}
+
+func receiptType(value string) types.ReceiptType {
+	switch value {
+	case "read":
+		return types.ReceiptTypeRead
+	case "played":
+		return types.ReceiptTypePlayed
+	default:
+		return types.ReceiptTypeDelivered
+	}
+}
+
+// newInboundPipeline builds a pipeline with the state and webhook stages of
+// the real gateway, under the same names, so plugin stages see fake events
+// exactly where they would see real ones.
+func newInboundPipeline(g *Gateway) *inbound.Pipeline {
+	pipeline := inbound.NewPipeline(g.logger)
+
+	_ = pipeline.Use(inbound.NewStage(waclient.StageState, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+		g.updateState(evt.SessionName, evt.Payload)
+		return true, nil
+	}))
+	_ = pipeline.Use(inbound.NewStage(waclient.StageWebhook, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+		if g.webhookHandler == nil {
+			return true, nil
+		}
+		return true, g.webhookHandler.HandleWhatsmeowEvent(evt.Payload, evt.SessionID)
+	}))
+
+	return pipeline
+}
+
+// Pipeline is the inbound event pipeline of the fake sessions.
+func (g *Gateway) Pipeline() *inbound.Pipeline {
+	return g.pipeline
+}
+
+func (g *Gateway) emit(sessionName string, payload interface{}) {
+	g.pipeline.Run(context.Background(), &inbound.Event{
+		SessionID:   g.sessionUUID(sessionName),
+		SessionName: sessionName,
+		Payload:     payload,
+		ReceivedAt:  time.Now(),
+	})
+}
+
+func (g *Gateway) updateState(sessionName string, payload interface{}) {
+	if g.eventHandler == nil {
+		return
+	}
+
+	switch v := payload.(type) {
+	case *events.Connected:
+		info, _ := g.GetSessionInfo(context.Background(), sessionName)
+		g.eventHandler.OnSessionConnected(sessionName, info)
+	case *events.Disconnected:
+		g.eventHandler.OnSessionDisconnected(sessionName, "")
+	case *waclient.SessionLoggedOutEvent:
+		g.eventHandler.OnSessionLoggedOut(sessionName, v.Reason)
+	case *waclient.QRCodeEvent:
+		g.eventHandler.OnQRCodeGenerated(sessionName, v.QRCode, v.ExpiresAt)
+	case *events.Message:
+		g.eventHandler.OnMessageReceived(sessionName, &session.WhatsAppMessage{
+			ID:        v.Info.ID,
+			From:      v.Info.Sender.String(),
+			Chat:      v.Info.Chat.String(),
+			Type:      "text",
+			Content:   v.Message.GetConversation(),
+			Timestamp: v.Info.Timestamp,
+			FromMe:    v.Info.IsFromMe,
+		})
+	}
+}
@@ -0,0 +1,629 @@
+// Package fakewa is an in-memory stand-in for the WhatsApp gateway. It lets
+// the whole HTTP API run without a WhatsApp connection: sessions pair on
+// demand, sends are recorded instead of delivered and inbound events are
+// injected by scripts. It is wired in when WA_TEST_MODE is enabled.
+package fakewa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/adapters/waclient"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+const (
+	qrTimeout = 60 * time.Second
+
+	// fakePushName is the display name fake sessions report for themselves.
+	fakePushName = "zpwoot test"
+)
+
+// WebhookEventHandler receives every event the fake emits, as the real
+// gateway hands them to the webhook dispatcher.
+type WebhookEventHandler interface {
+	HandleWhatsmeowEvent(evt interface{}, sessionID string) error
+}
+
+// DeviceStore persists the device JID a session gets when it pairs.
+type DeviceStore interface {
+	UpdateDeviceJID(ctx context.Context, id uuid.UUID, deviceJID string) error
+}
+
+// SentMessage is one send recorded by the fake gateway.
+type SentMessage struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	To        string      `json:"to"`
+	Content   string      `json:"content,omitempty"`
+	Payload   interface{} `json:"payload,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+type fakeSession struct {
+	name      string
+	deviceJID string
+	connected bool
+	qrCode    string
+	qrExpires time.Time
+	proxy     *session.ProxyConfig
+	settings  session.Settings
+	sent      []SentMessage
+}
+
+type Gateway struct {
+	logger      *logger.Logger
+	autoPair    bool
+	maxSessions int
+
+	mu       sync.RWMutex
+	sessions map[string]*fakeSession
+	uuids    map[string]string
+
+	eventHandler   session.EventHandler
+	webhookHandler WebhookEventHandler
+	deviceStore    DeviceStore
+	pipeline       *inbound.Pipeline
+
+	seq atomic.Uint64
+}
+
+// NewGateway creates the fake gateway. With autoPair, connecting an unpaired
+// session pairs it right after the QR code is emitted; otherwise it waits
+// for Pair.
+func NewGateway(autoPair bool, logger *logger.Logger) *Gateway {
+	g := &Gateway{
+		logger:   logger,
+		autoPair: autoPair,
+		sessions: make(map[string]*fakeSession),
+		uuids:    make(map[string]string),
+	}
+	g.pipeline = newInboundPipeline(g)
+	return g
+}
+
+func (g *Gateway) SetEventHandler(handler session.EventHandler) {
+	g.eventHandler = handler
+}
+
+func (g *Gateway) SetWebhookHandler(handler WebhookEventHandler) {
+	g.webhookHandler = handler
+}
+
+func (g *Gateway) SetDeviceStore(store DeviceStore) {
+	g.deviceStore = store
+}
+
+func (g *Gateway) SetMaxSessions(limit int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxSessions = limit
+}
+
+// Capacity counts sessions that are connected or showing a QR code as
+// connected. The fake delivers events inline, so none are ever pending.
+func (g *Gateway) Capacity() session.Capacity {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return session.Capacity{
+		MaxSessions: g.maxSessions,
+		Connected:   g.connectedLocked(""),
+		Loaded:      len(g.sessions),
+	}
+}
+
+func (g *Gateway) connectedLocked(except string) int {
+	connected := 0
+	now := time.Now()
+	for name, sess := range g.sessions {
+		if name == except {
+			continue
+		}
+		if sess.connected || (sess.qrCode != "" && now.Before(sess.qrExpires)) {
+			connected++
+		}
+	}
+	return connected
+}
+
+func (g *Gateway) CreateSession(ctx context.Context, sessionName string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.sessions[sessionName]; ok {
+		return fmt.Errorf("session %s already exists", sessionName)
+	}
+	g.sessions[sessionName] = &fakeSession{name: sessionName}
+	return nil
+}
+
+func (g *Gateway) RestoreSession(ctx context.Context, sessionName string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.sessions[sessionName]; !ok {
+		g.sessions[sessionName] = &fakeSession{name: sessionName}
+	}
+	return nil
+}
+
+// RestoreAllSessions only knows the sessions paired since the process
+// started; the fake keeps no device store across restarts.
+func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string) ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var paired []string
+	for _, name := range sessionNames {
+		sess, ok := g.sessions[name]
+		if !ok {
+			sess = &fakeSession{name: name}
+			g.sessions[name] = sess
+		}
+		if sess.deviceJID != "" {
+			paired = append(paired, name)
+		}
+	}
+	return paired, nil
+}
+
+func (g *Gateway) RegisterSessionUUID(sessionName, sessionUUID string) {
+	g.mu.Lock()
+	g.uuids[sessionName] = sessionUUID
+	g.mu.Unlock()
+}
+
+func (g *Gateway) SessionExists(sessionName string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, ok := g.sessions[sessionName]
+	return ok
+}
+
+func (g *Gateway) ConnectSession(ctx context.Context, sessionName string) error {
+	g.mu.Lock()
+	sess, ok := g.sessions[sessionName]
+	if !ok {
+		sess = &fakeSession{name: sessionName}
+		g.sessions[sessionName] = sess
+	}
+	if sess.connected {
+		g.mu.Unlock()
+		return nil
+	}
+	if err := session.CheckCapacity(g.maxSessions, g.connectedLocked(sessionName)); err != nil {
+		g.mu.Unlock()
+		return err
+	}
+	paired := sess.deviceJID != ""
+	g.mu.Unlock()
+
+	if paired {
+		g.markConnected(sessionName)
+		return nil
+	}
+
+	g.emitQRCode(sessionName)
+	if g.autoPair {
+		go func() {
+			if _, err := g.Pair(sessionName, ""); err != nil {
+				g.logger.WarnWithFields("Fake auto-pair failed", map[string]interface{}{
+					"session_name": sessionName,
+					"error":        err.Error(),
+				})
+			}
+		}()
+	}
+	return nil
+}
+
+func (g *Gateway) DisconnectSession(ctx context.Context, sessionName string) error {
+	return g.disconnect(sessionName, waclient.DisconnectRequested)
+}
+
+func (g *Gateway) disconnect(sessionName, reason string) error {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	wasConnected := sess.connected
+	sess.connected = false
+	g.mu.Unlock()
+
+	if wasConnected {
+		g.emit(sessionName, &events.Disconnected{})
+		g.emit(sessionName, waclient.NewSessionDisconnectedEvent(sessionName, reason))
+	}
+	return nil
+}
+
+func (g *Gateway) CancelPairing(ctx context.Context, sessionName string) error {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	if sess.connected || sess.qrCode == "" {
+		g.mu.Unlock()
+		return session.ErrNoPairingPending
+	}
+	sess.qrCode = ""
+	sess.qrExpires = time.Time{}
+	g.mu.Unlock()
+
+	g.emit(sessionName, &waclient.PairingEndedEvent{
+		Event:       "pairing_ended",
+		SessionName: sessionName,
+		Reason:      waclient.PairingCancelled,
+	})
+	return nil
+}
+
+func (g *Gateway) DeleteSession(ctx context.Context, sessionName string) error {
+	g.mu.Lock()
+	delete(g.sessions, sessionName)
+	delete(g.uuids, sessionName)
+	g.mu.Unlock()
+	return nil
+}
+
+func (g *Gateway) IsSessionConnected(ctx context.Context, sessionName string) (bool, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return false, err
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return sess.connected, nil
+}
+
+func (g *Gateway) GetSessionInfo(ctx context.Context, sessionName string) (*session.DeviceInfo, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	info := &session.DeviceInfo{
+		JID:             sess.deviceJID,
+		PushName:        "zpwoot test mode",
+		Platform:        "fake",
+		WhatsAppVersion: "0",
+		Connection: session.ConnectionInfo{
+			State:          "disconnected",
+			LastActivityAt: time.Now(),
+		},
+	}
+	if sess.connected {
+		info.Connection.State = "connected"
+	}
+	return info, nil
+}
+
+func (g *Gateway) GenerateQRCode(ctx context.Context, sessionName string) (*session.QRCodeResponse, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.RLock()
+	qrCode, expiresAt := sess.qrCode, sess.qrExpires
+	g.mu.RUnlock()
+
+	if qrCode == "" || time.Now().After(expiresAt) {
+		qrCode, expiresAt = g.emitQRCode(sessionName)
+	}
+
+	return &session.QRCodeResponse{
+		QRCode:    qrCode,
+		ExpiresAt: expiresAt,
+		Timeout:   int(qrTimeout.Seconds()),
+	}, nil
+}
+
+func (g *Gateway) CurrentQRCode(sessionName string) (*session.QRCodeResponse, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	sess, ok := g.sessions[sessionName]
+	if !ok || sess.qrCode == "" || time.Now().After(sess.qrExpires) {
+		return nil, false
+	}
+
+	return &session.QRCodeResponse{
+		QRCode:    sess.qrCode,
+		ExpiresAt: sess.qrExpires,
+		Timeout:   int(time.Until(sess.qrExpires).Seconds()),
+	}, true
+}
+
+func (g *Gateway) SetProxy(ctx context.Context, sessionName string, proxy *session.ProxyConfig) error {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	sess.proxy = proxy
+	g.mu.Unlock()
+	return nil
+}
+
+func (g *Gateway) ApplySettings(sessionName string, settings session.Settings) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if sess, ok := g.sessions[sessionName]; ok {
+		sess.settings = settings
+	}
+}
+
+func (g *Gateway) ExportDeviceCredentials(ctx context.Context, sessionName string) (*session.DeviceCredentials, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if sess.deviceJID == "" {
+		return nil, fmt.Errorf("session %s is not paired", sessionName)
+	}
+	return &session.DeviceCredentials{DeviceJID: sess.deviceJID}, nil
+}
+
+func (g *Gateway) ImportDeviceCredentials(ctx context.Context, sessionName string, credentials *session.DeviceCredentials) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.sessions[sessionName]; ok {
+		return fmt.Errorf("session %s already exists", sessionName)
+	}
+	g.sessions[sessionName] = &fakeSession{name: sessionName, deviceJID: credentials.DeviceJID}
+	return nil
+}
+
+// Pair completes the pairing of a session as if its QR code had been
+// scanned, then connects it. An empty phone gets a generated number.
+func (g *Gateway) Pair(sessionName, phone string) (string, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return "", err
+	}
+
+	if phone == "" {
+		phone = fmt.Sprintf("5500%09d", g.seq.Add(1))
+	}
+	jid := types.NewADJID(phone, 0, 1)
+
+	g.mu.Lock()
+	sess.deviceJID = jid.String()
+	sess.qrCode = ""
+	sessionUUID := g.uuids[sessionName]
+	g.mu.Unlock()
+
+	if g.deviceStore != nil {
+		if id, err := uuid.Parse(sessionUUID); err == nil {
+			if err := g.deviceStore.UpdateDeviceJID(context.Background(), id, jid.String()); err != nil {
+				return "", fmt.Errorf("failed to store device JID: %w", err)
+			}
+		}
+	}
+
+	g.emit(sessionName, &events.PairSuccess{ID: jid, Platform: "fake"})
+	g.markConnected(sessionName)
+	return jid.String(), nil
+}
+
+// LogOut drops the session's pairing as if it had been removed from the
+// phone.
+func (g *Gateway) LogOut(sessionName, reason string) error {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	deviceJID := sess.deviceJID
+	sess.deviceJID = ""
+	sess.connected = false
+	g.mu.Unlock()
+
+	if reason == "" {
+		reason = "logged out"
+	}
+	g.emit(sessionName, &waclient.SessionLoggedOutEvent{
+		Event:       "session.logged_out",
+		SessionName: sessionName,
+		DeviceJID:   deviceJID,
+		Reason:      reason,
+		Timestamp:   time.Now(),
+	})
+	return nil
+}
+
+// Sent returns the messages sent through a session, oldest first.
+func (g *Gateway) Sent(sessionName string) ([]SentMessage, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]SentMessage(nil), sess.sent...), nil
+}
+
+// ClearSent forgets the messages recorded for a session.
+func (g *Gateway) ClearSent(sessionName string) error {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	sess.sent = nil
+	g.mu.Unlock()
+	return nil
+}
+
+func (g *Gateway) SendTextMessage(ctx context.Context, sessionName, to, content string) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "text", Content: content})
+}
+
+func (g *Gateway) SendMediaMessage(ctx context.Context, sessionName, to, mediaURL, caption, mediaType string) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{
+		Type:    mediaType,
+		Content: caption,
+		Payload: map[string]string{"mediaUrl": mediaURL},
+	})
+}
+
+func (g *Gateway) SendLocationMessage(ctx context.Context, sessionName, to string, latitude, longitude float64, address string) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{
+		Type:    "location",
+		Content: address,
+		Payload: map[string]float64{"latitude": latitude, "longitude": longitude},
+	})
+}
+
+func (g *Gateway) SendLiveLocationMessage(ctx context.Context, sessionName, to string, location *session.LiveLocation) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "live_location", Content: location.Caption, Payload: location})
+}
+
+func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to string, card *session.ContactCard) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "contact", Payload: card})
+}
+
+func (g *Gateway) SendButtonMessage(ctx context.Context, sessionName, to string, message *session.ButtonMessage) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "buttons", Payload: message})
+}
+
+func (g *Gateway) SendPollMessage(ctx context.Context, sessionName, to string, message *session.PollMessage) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "poll", Payload: message})
+}
+
+func (g *Gateway) SendPaymentRequest(ctx context.Context, sessionName, to string, request *session.PaymentRequest) (*session.MessageSendResult, error) {
+	return g.record(sessionName, to, SentMessage{Type: "payment", Content: request.Note, Payload: request})
+}
+
+// ResolveWhatsAppNumbers answers every number as registered, so number
+// checks and bulk adds can be exercised in test mode.
+func (g *Gateway) ResolveWhatsAppNumbers(ctx context.Context, sessionName string, phoneNumbers []string) (map[string]string, error) {
+	if _, err := g.session(sessionName); err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]string, len(phoneNumbers))
+	for _, phone := range phoneNumbers {
+		resolved[phone] = strings.TrimPrefix(phone, "+") + "@" + types.DefaultUserServer
+	}
+	return resolved, nil
+}
+
+func (g *Gateway) record(sessionName, to string, msg SentMessage) (*session.MessageSendResult, error) {
+	sess, err := g.session(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !sess.connected {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	msg.ID = g.messageID()
+	msg.To = to
+	msg.Timestamp = time.Now()
+	sess.sent = append(sess.sent, msg)
+
+	return &session.MessageSendResult{
+		MessageID: msg.ID,
+		Status:    "sent",
+		Timestamp: msg.Timestamp,
+		To:        to,
+	}, nil
+}
+
+func (g *Gateway) session(sessionName string) (*fakeSession, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	sess, ok := g.sessions[sessionName]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+	return sess, nil
+}
+
+func (g *Gateway) markConnected(sessionName string) {
+	g.mu.Lock()
+	deviceJID := ""
+	if sess, ok := g.sessions[sessionName]; ok {
+		sess.connected = true
+		deviceJID = sess.deviceJID
+	}
+	g.mu.Unlock()
+
+	g.emit(sessionName, &events.Connected{})
+	g.emit(sessionName, waclient.NewSessionConnectedEvent(sessionName, deviceJID, fakePushName))
+}
+
+func (g *Gateway) emitQRCode(sessionName string) (string, time.Time) {
+	qrCode := fmt.Sprintf("2@fake-%s-%d", sessionName, g.seq.Add(1))
+	expiresAt := time.Now().Add(qrTimeout)
+
+	g.mu.Lock()
+	if sess, ok := g.sessions[sessionName]; ok {
+		sess.qrCode = qrCode
+		sess.qrExpires = expiresAt
+	}
+	g.mu.Unlock()
+
+	g.emit(sessionName, &waclient.QRCodeEvent{
+		SessionName: sessionName,
+		QRCode:      qrCode,
+		ExpiresAt:   expiresAt,
+	})
+	g.emit(sessionName, waclient.NewQRUpdatedEvent(sessionName, qrCode, expiresAt))
+	return qrCode, expiresAt
+}
+
+// messageID mimics the shape of WhatsApp message IDs so clients parsing
+// them keep working.
+func (g *Gateway) messageID() string {
+	return fmt.Sprintf("3EB0%016X", g.seq.Add(1))
+}
+
+func (g *Gateway) sessionUUID(sessionName string) string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.uuids[sessionName]
+}
+
+func normalizeJID(value string) (types.JID, error) {
+	if !strings.Contains(value, "@") {
+		value = strings.TrimPrefix(value, "+") + "@" + types.DefaultUserServer
+	}
+	return types.ParseJID(value)
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/schedule"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/platform/logger"
+)
+
+// toStatus maps service errors to gRPC codes, following the HTTP status
+// each gets from the REST API. Errors without a match are logged and
+// answered with Internal.
+func toStatus(err error, operation string, log *logger.Logger) error {
+	var quiet *session.QuietHoursError
+	var warmUp *session.WarmUpLimitError
+	var queueFull *session.SendQueueFullError
+	var throttled *session.SessionThrottledError
+	var quota *tenant.QuotaError
+	var recipient *messaging.RecipientError
+	var failed *schedule.FailedSendError
+	var denied *session.PolicyError
+	var banned *session.SessionBannedError
+	var disabled *session.FeatureDisabledError
+	var payments *session.PaymentsNotSupportedError
+	var groupRule *session.GroupRuleError
+	var mediaQueue *messaging.MediaQueueFullError
+	var unconfirmed *session.SendUnconfirmedError
+	var capacity *session.CapacityError
+
+	switch {
+	case errors.Is(err, session.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "Session not found")
+	case errors.Is(err, session.ErrSessionAlreadyExists):
+		return status.Error(codes.AlreadyExists, "Session already exists")
+	case errors.Is(err, session.ErrSessionAlreadyConnected):
+		return status.Error(codes.FailedPrecondition, "Session is already connected")
+	case errors.Is(err, session.ErrSessionNotConnected):
+		return status.Error(codes.FailedPrecondition, "Session is not connected")
+	case errors.Is(err, session.ErrInvalidSessionName), errors.Is(err, session.ErrInvalidProxyConfig):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, session.ErrQRCodeNotAvailable):
+		return status.Error(codes.NotFound, "QR code not available")
+	case errors.Is(err, session.ErrQRCodeExpired):
+		return status.Error(codes.FailedPrecondition, "QR code expired")
+	case errors.As(err, &quiet):
+		return status.Errorf(codes.FailedPrecondition, "Session is in quiet hours until %s", quiet.ResumeAt.Format(time.RFC3339))
+	case errors.As(err, &warmUp):
+		return status.Error(codes.ResourceExhausted, "Session reached its warm-up daily limit")
+	case errors.As(err, &throttled):
+		return status.Error(codes.Unavailable, "Session is cooling down after a WhatsApp rate limit")
+	case errors.As(err, &banned):
+		return status.Errorf(codes.PermissionDenied, "Session account is %s by WhatsApp: %s", banned.Ban.Kind, banned.Ban.Reason)
+	case errors.As(err, &queueFull):
+		return status.Error(codes.ResourceExhausted, "Session send queue is full")
+	case errors.As(err, &mediaQueue):
+		return status.Error(codes.ResourceExhausted, "Session media processing queue is full")
+	case errors.As(err, &capacity):
+		return status.Errorf(codes.Unavailable, "Instance reached its maximum of connected sessions (%d of %d)", capacity.Connected, capacity.Limit)
+	case errors.As(err, &quota):
+		return status.Error(codes.ResourceExhausted, "Tenant reached its daily message limit")
+	case errors.As(err, &recipient) && errors.Is(err, messaging.ErrRecipientNotOnWhatsApp):
+		return status.Errorf(codes.FailedPrecondition, "Recipient %s is not on WhatsApp", recipient.Recipient)
+	case errors.As(err, &recipient):
+		return status.Errorf(codes.InvalidArgument, "Recipient %s is not a valid WhatsApp JID or phone number", recipient.Recipient)
+	case errors.As(err, &denied):
+		return status.Errorf(codes.PermissionDenied, "%s sessions cannot send to %s", denied.Mode, denied.Recipient)
+	case errors.As(err, &disabled):
+		return status.Errorf(codes.PermissionDenied, "Feature %s is disabled for this session", disabled.Feature)
+	case errors.As(err, &groupRule) && groupRule.Rule == session.GroupRuleRate:
+		return status.Error(codes.ResourceExhausted, groupRule.Error())
+	case errors.As(err, &groupRule):
+		return status.Error(codes.PermissionDenied, groupRule.Error())
+	case errors.As(err, &payments):
+		return status.Error(codes.FailedPrecondition, payments.Error())
+	case errors.As(err, &failed):
+		return status.Errorf(codes.Unavailable, "Send failed and was kept for retry as failed message %s", failed.ID)
+	case errors.As(err, &unconfirmed):
+		return status.Errorf(codes.DeadlineExceeded, "Send was not confirmed by WhatsApp and may still be delivered as message %s", unconfirmed.MessageID)
+	case errors.Is(err, session.ErrMediaTooLarge), errors.Is(err, session.ErrMediaTypeNotAllowed):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, session.ErrOperationTimeout), errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Operation timed out")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Call cancelled")
+	case strings.Contains(err.Error(), "validation"):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	log.ErrorWithFields("gRPC call failed", map[string]interface{}{
+		"module":    "grpc",
+		"operation": operation,
+		"error":     err.Error(),
+	})
+	return status.Errorf(codes.Internal, "Failed to %s", operation)
+}
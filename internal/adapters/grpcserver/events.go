@@ -0,0 +1,80 @@
+package grpcserver
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"zpwoot/internal/adapters/delivery"
+	"zpwoot/internal/services"
+	"zpwoot/pkg/zpwootpb"
+	"zpwoot/platform/logger"
+)
+
+type eventServer struct {
+	zpwootpb.UnimplementedEventServiceServer
+
+	sessions *services.SessionService
+	stream   *delivery.Stream
+	logger   *logger.Logger
+	done     <-chan struct{}
+}
+
+// StreamEvents relays the events dispatched to webhooks until the client
+// cancels or the server shuts down.
+func (s *eventServer) StreamEvents(req *zpwootpb.StreamEventsRequest, stream grpc.ServerStreamingServer[zpwootpb.Event]) error {
+	ctx := stream.Context()
+
+	sessionID := ""
+	if req.GetSession() != "" {
+		id, err := s.sessions.ResolveSessionID(ctx, req.GetSession())
+		if err != nil {
+			return toStatus(err, "stream events", s.logger)
+		}
+		sessionID = id.String()
+	}
+
+	sub := s.stream.Subscribe(sessionID, req.GetEvents())
+	defer func() {
+		s.stream.Unsubscribe(sub)
+		if dropped := sub.Dropped(); dropped > 0 {
+			s.logger.WarnWithFields("Event stream fell behind and missed events", map[string]interface{}{
+				"module":     "grpc",
+				"session_id": sessionID,
+				"dropped":    dropped,
+			})
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "Server is shutting down")
+		case event := <-sub.Events():
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				s.logger.ErrorWithFields("Failed to encode streamed event", map[string]interface{}{
+					"module": "grpc",
+					"event":  event.Event,
+					"error":  err.Error(),
+				})
+				continue
+			}
+
+			if err := stream.Send(&zpwootpb.Event{
+				Id:        event.ID,
+				Type:      event.Event,
+				SessionId: event.SessionID,
+				Timestamp: timestamppb.New(event.Timestamp),
+				Data:      data,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
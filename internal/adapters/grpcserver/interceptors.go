@@ -0,0 +1,83 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthService is left open so load balancers can probe without a key.
+const healthService = "/grpc.health.v1.Health/"
+
+func (s *Server) authUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.authenticate(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) authStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authenticate(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authenticate checks the API key sent in the "authorization" metadata,
+// optionally as a Bearer token, or in "x-api-key", like the HTTP headers.
+func (s *Server) authenticate(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthService) {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	apiKey := ""
+	if values := md.Get("authorization"); len(values) > 0 {
+		apiKey = strings.TrimPrefix(values[0], "Bearer ")
+	} else if values := md.Get("x-api-key"); len(values) > 0 {
+		apiKey = values[0]
+	}
+
+	if apiKey == "" {
+		return status.Error(codes.Unauthenticated, "API key is required. Provide it via authorization or x-api-key metadata")
+	}
+	if apiKey != s.config.Security.APIKey {
+		s.logger.WarnWithFields("Invalid API key", map[string]interface{}{
+			"module": "grpc",
+			"method": method,
+		})
+		return status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	return nil
+}
+
+func (s *Server) recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer s.recoverCall(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func (s *Server) recoverStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer s.recoverCall(info.FullMethod, &err)
+	return handler(srv, stream)
+}
+
+// recoverCall answers a panicking call with Internal instead of taking the
+// whole process down, as the HTTP recoverer does for requests.
+func (s *Server) recoverCall(method string, err *error) {
+	if r := recover(); r != nil {
+		s.logger.ErrorWithFields("Panic in gRPC call", map[string]interface{}{
+			"module": "grpc",
+			"method": method,
+			"panic":  fmt.Sprint(r),
+			"stack":  string(debug.Stack()),
+		})
+		*err = status.Error(codes.Internal, "Internal server error")
+	}
+}
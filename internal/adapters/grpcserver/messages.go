@@ -0,0 +1,121 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/services"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/pkg/zpwootpb"
+	"zpwoot/platform/logger"
+)
+
+// messageServer sends through the same path as the REST send routes: the
+// request is checked against the REST contract, takes a slot in the
+// session's send queue and may be held back by quiet hours or the warm-up
+// limit, which is answered with the scheduled send instead of an error. A
+// media send whose upload fails is kept for the REST retry route.
+type messageServer struct {
+	zpwootpb.UnimplementedMessageServiceServer
+
+	messages  *services.MessageService
+	validator *validation.Validator
+	logger    *logger.Logger
+}
+
+func (s *messageServer) SendText(ctx context.Context, req *zpwootpb.SendTextRequest) (*zpwootpb.SendMessageResponse, error) {
+	send := &contracts.SendTextMessageRequest{
+		RemoteJID:  req.GetTo(),
+		Body:       req.GetBody(),
+		ReplyTo:    req.GetReplyTo(),
+		QuietHours: req.GetQuietHours(),
+	}
+
+	return s.send(ctx, req.GetSession(), services.SendKindText, send.QuietHours, send, func(ctx context.Context) (*contracts.SendMessageResponse, error) {
+		ctx = services.WithReplyTo(ctx, send.ReplyTo, "")
+		return s.messages.SendTextMessage(ctx, req.GetSession(), send.RemoteJID, send.Body)
+	})
+}
+
+func (s *messageServer) SendMedia(ctx context.Context, req *zpwootpb.SendMediaRequest) (*zpwootpb.SendMessageResponse, error) {
+	send := &contracts.SendMediaMessageRequest{
+		To:         req.GetTo(),
+		MediaURL:   req.GetMediaUrl(),
+		Type:       req.GetType(),
+		Caption:    req.GetCaption(),
+		Filename:   req.GetFilename(),
+		ReplyTo:    req.GetReplyTo(),
+		QuietHours: req.GetQuietHours(),
+	}
+
+	return s.send(ctx, req.GetSession(), services.SendKindMedia, send.QuietHours, send, func(ctx context.Context) (*contracts.SendMessageResponse, error) {
+		ctx = services.WithReplyTo(ctx, send.ReplyTo, "")
+		return s.messages.SendMediaMessage(ctx, req.GetSession(), send.To, send.MediaURL, send.Caption, send.Type)
+	})
+}
+
+func (s *messageServer) SendLocation(ctx context.Context, req *zpwootpb.SendLocationRequest) (*zpwootpb.SendMessageResponse, error) {
+	send := &contracts.SendLocationMessageRequest{
+		To:         req.GetTo(),
+		Latitude:   req.GetLatitude(),
+		Longitude:  req.GetLongitude(),
+		Name:       req.GetName(),
+		Address:    req.GetAddress(),
+		ReplyTo:    req.GetReplyTo(),
+		QuietHours: req.GetQuietHours(),
+	}
+
+	return s.send(ctx, req.GetSession(), services.SendKindLocation, send.QuietHours, send, func(ctx context.Context) (*contracts.SendMessageResponse, error) {
+		ctx = services.WithReplyTo(ctx, send.ReplyTo, "")
+		return s.messages.SendLocationMessage(ctx, req.GetSession(), send.To, send.Latitude, send.Longitude, send.Address)
+	})
+}
+
+func (s *messageServer) send(ctx context.Context, sessionID, kind, policy string, req interface{}, deliver func(context.Context) (*contracts.SendMessageResponse, error)) (*zpwootpb.SendMessageResponse, error) {
+	operation := "send " + kind + " message"
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("validation failed: %v", err))
+	}
+
+	release, err := s.messages.EnterSendQueue(ctx, sessionID)
+	if err != nil {
+		return nil, toStatus(err, operation, s.logger)
+	}
+	defer release()
+
+	scheduled, err := s.messages.HoldForQuietHours(ctx, sessionID, policy, kind, req)
+	if err == nil && scheduled == nil {
+		scheduled, err = s.messages.HoldForWarmUp(ctx, sessionID, kind, req)
+	}
+	if err != nil {
+		return nil, toStatus(err, operation, s.logger)
+	}
+	if scheduled != nil {
+		return &zpwootpb.SendMessageResponse{
+			Status: "scheduled",
+			Scheduled: &zpwootpb.ScheduledSend{
+				Id:     scheduled.ID,
+				SendAt: timestamppb.New(scheduled.SendAt),
+				Reason: scheduled.Reason,
+			},
+		}, nil
+	}
+
+	sent, err := deliver(ctx)
+	if err != nil {
+		return nil, toStatus(s.messages.KeepFailedSend(ctx, sessionID, kind, req, err), operation, s.logger)
+	}
+
+	return &zpwootpb.SendMessageResponse{
+		MessageId: sent.MessageID,
+		To:        sent.To,
+		Status:    sent.Status,
+		Timestamp: timestamppb.New(sent.Timestamp),
+	}, nil
+}
@@ -0,0 +1,103 @@
+// Package grpcserver serves the gRPC API defined in api/proto: session
+// management, sends and a stream of the events webhooks receive, over the
+// same services as the HTTP handlers. It is meant for internal services,
+// so it only accepts the global API key.
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"zpwoot/internal/adapters/delivery"
+	"zpwoot/internal/services"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/pkg/zpwootpb"
+	"zpwoot/platform/config"
+	"zpwoot/platform/logger"
+)
+
+type Server struct {
+	config *config.Config
+	logger *logger.Logger
+	grpc   *grpc.Server
+	health *health.Server
+
+	// done ends the event streams on shutdown, which would otherwise keep
+	// GracefulStop waiting until their clients hang up.
+	done chan struct{}
+}
+
+type Config struct {
+	Config         *config.Config
+	Logger         *logger.Logger
+	SessionService *services.SessionService
+	MessageService *services.MessageService
+	Stream         *delivery.Stream
+}
+
+func New(cfg *Config) *Server {
+	s := &Server{
+		config: cfg.Config,
+		logger: cfg.Logger,
+		health: health.NewServer(),
+		done:   make(chan struct{}),
+	}
+
+	s.grpc = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.recoverUnary, s.authUnary),
+		grpc.ChainStreamInterceptor(s.recoverStream, s.authStream),
+	)
+
+	zpwootpb.RegisterSessionServiceServer(s.grpc, &sessionServer{
+		sessions: cfg.SessionService,
+		logger:   cfg.Logger,
+	})
+	zpwootpb.RegisterMessageServiceServer(s.grpc, &messageServer{
+		messages:  cfg.MessageService,
+		validator: validation.New(),
+		logger:    cfg.Logger,
+	})
+	zpwootpb.RegisterEventServiceServer(s.grpc, &eventServer{
+		sessions: cfg.SessionService,
+		stream:   cfg.Stream,
+		logger:   cfg.Logger,
+		done:     s.done,
+	})
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+
+	return s
+}
+
+// ListenAndServe serves on the configured gRPC address until Shutdown.
+func (s *Server) ListenAndServe() error {
+	listener, err := net.Listen("tcp", s.config.GetGRPCAddress())
+	if err != nil {
+		return err
+	}
+	return s.grpc.Serve(listener)
+}
+
+// Shutdown stops accepting calls, ends the event streams and waits for the
+// calls in flight, or stops them outright once ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	close(s.done)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
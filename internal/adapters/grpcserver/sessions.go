@@ -0,0 +1,203 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/services"
+	"zpwoot/pkg/zpwootpb"
+	"zpwoot/platform/logger"
+)
+
+type sessionServer struct {
+	zpwootpb.UnimplementedSessionServiceServer
+
+	sessions *services.SessionService
+	logger   *logger.Logger
+}
+
+func (s *sessionServer) CreateSession(ctx context.Context, req *zpwootpb.CreateSessionRequest) (*zpwootpb.CreateSessionResponse, error) {
+	create := &contracts.CreateSessionRequest{
+		Name:   req.GetName(),
+		QRCode: req.GetQrCode(),
+	}
+	if proxy := req.GetProxy(); proxy != nil {
+		create.ProxyConfig = &contracts.ProxyConfig{
+			Type:     proxy.GetType(),
+			Host:     proxy.GetHost(),
+			Port:     int(proxy.GetPort()),
+			Username: proxy.GetUsername(),
+			Password: proxy.GetPassword(),
+		}
+	}
+
+	created, err := s.sessions.CreateSession(ctx, create)
+	if err != nil {
+		return nil, toStatus(err, "create session", s.logger)
+	}
+
+	return &zpwootpb.CreateSessionResponse{
+		Id:          created.ID,
+		Name:        created.Name,
+		IsConnected: created.IsConnected,
+		QrCode:      created.QRCode,
+		QrCodeImage: created.QRCodeImage,
+		CreatedAt:   timestamppb.New(created.CreatedAt),
+	}, nil
+}
+
+func (s *sessionServer) GetSession(ctx context.Context, req *zpwootpb.SessionRequest) (*zpwootpb.Session, error) {
+	sessionID, err := s.sessions.ResolveSessionID(ctx, req.GetSession())
+	if err != nil {
+		return nil, toStatus(err, "get session", s.logger)
+	}
+
+	info, err := s.sessions.GetSession(ctx, sessionID.String())
+	if err != nil {
+		return nil, toStatus(err, "get session", s.logger)
+	}
+
+	return sessionToProto(info), nil
+}
+
+func (s *sessionServer) ListSessions(ctx context.Context, req *zpwootpb.ListSessionsRequest) (*zpwootpb.ListSessionsResponse, error) {
+	list := &contracts.ListSessionsRequest{
+		Limit:  int(req.GetLimit()),
+		Cursor: req.GetCursor(),
+	}
+	switch req.GetConnection() {
+	case zpwootpb.ConnectionFilter_CONNECTION_FILTER_CONNECTED:
+		connected := true
+		list.IsConnected = &connected
+	case zpwootpb.ConnectionFilter_CONNECTION_FILTER_DISCONNECTED:
+		connected := false
+		list.IsConnected = &connected
+	}
+
+	page, err := s.sessions.ListSessions(ctx, list)
+	if err != nil {
+		return nil, toStatus(err, "list sessions", s.logger)
+	}
+
+	response := &zpwootpb.ListSessionsResponse{
+		Sessions:   make([]*zpwootpb.Session, len(page.Sessions)),
+		Total:      int32(page.Total),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for i := range page.Sessions {
+		response.Sessions[i] = sessionToProto(&page.Sessions[i])
+	}
+
+	return response, nil
+}
+
+func (s *sessionServer) ConnectSession(ctx context.Context, req *zpwootpb.SessionRequest) (*zpwootpb.ConnectSessionResponse, error) {
+	sessionID, err := s.sessions.ResolveSessionID(ctx, req.GetSession())
+	if err != nil {
+		return nil, toStatus(err, "connect session", s.logger)
+	}
+
+	connected, err := s.sessions.ConnectSession(ctx, sessionID.String())
+	if err != nil {
+		return nil, toStatus(err, "connect session", s.logger)
+	}
+
+	return &zpwootpb.ConnectSessionResponse{
+		Success:     connected.Success,
+		Message:     connected.Message,
+		QrCode:      connected.QRCode,
+		QrCodeImage: connected.QRCodeImage,
+	}, nil
+}
+
+func (s *sessionServer) DisconnectSession(ctx context.Context, req *zpwootpb.SessionRequest) (*zpwootpb.DisconnectSessionResponse, error) {
+	sessionID, err := s.sessions.ResolveSessionID(ctx, req.GetSession())
+	if err != nil {
+		return nil, toStatus(err, "disconnect session", s.logger)
+	}
+
+	if err := s.sessions.DisconnectSession(ctx, sessionID.String()); err != nil {
+		return nil, toStatus(err, "disconnect session", s.logger)
+	}
+
+	return &zpwootpb.DisconnectSessionResponse{}, nil
+}
+
+func (s *sessionServer) DeleteSession(ctx context.Context, req *zpwootpb.SessionRequest) (*zpwootpb.DeleteSessionResponse, error) {
+	if err := s.sessions.DeleteSessionByNameOrID(ctx, req.GetSession()); err != nil {
+		return nil, toStatus(err, "delete session", s.logger)
+	}
+
+	return &zpwootpb.DeleteSessionResponse{}, nil
+}
+
+func (s *sessionServer) GetQRCode(ctx context.Context, req *zpwootpb.SessionRequest) (*zpwootpb.QRCode, error) {
+	sessionID, err := s.sessions.ResolveSessionID(ctx, req.GetSession())
+	if err != nil {
+		return nil, toStatus(err, "get QR code", s.logger)
+	}
+
+	qr, err := s.sessions.GetQRCode(ctx, sessionID.String())
+	if err != nil {
+		return nil, toStatus(err, "get QR code", s.logger)
+	}
+
+	return &zpwootpb.QRCode{
+		QrCode:         qr.QRCode,
+		QrCodeImage:    qr.QRCodeImage,
+		ExpiresAt:      timestamppb.New(qr.ExpiresAt),
+		TimeoutSeconds: int32(qr.Timeout),
+	}, nil
+}
+
+func sessionToProto(info *contracts.SessionInfoResponse) *zpwootpb.Session {
+	sess := info.Session
+	out := &zpwootpb.Session{
+		Id:             sess.ID,
+		Name:           sess.Name,
+		DeviceJid:      sess.DeviceJID,
+		IsConnected:    sess.IsConnected,
+		Status:         sess.Status,
+		SendQueueDepth: int32(sess.SendQueueDepth),
+		CreatedAt:      timestamppb.New(sess.CreatedAt),
+		UpdatedAt:      timestamppb.New(sess.UpdatedAt),
+		ConnectedAt:    optionalTimestamp(sess.ConnectedAt),
+		LoggedOutAt:    optionalTimestamp(sess.LoggedOutAt),
+	}
+	if sess.ConnectionError != nil {
+		out.ConnectionError = *sess.ConnectionError
+	}
+	if proxy := sess.ProxyConfig; proxy != nil {
+		out.Proxy = &zpwootpb.ProxyConfig{
+			Type:     proxy.Type,
+			Host:     proxy.Host,
+			Port:     int32(proxy.Port),
+			Username: proxy.Username,
+		}
+	}
+	if device := info.DeviceInfo; device != nil {
+		out.Device = &zpwootpb.DeviceInfo{
+			Jid:             device.JID,
+			Lid:             device.LID,
+			PushName:        device.PushName,
+			BusinessName:    device.BusinessName,
+			Platform:        device.Platform,
+			IsBusiness:      device.IsBusiness,
+			WaVersion:       device.WAVersion,
+			ConnectionState: device.Connection.State,
+			Reconnects:      int32(device.Connection.Reconnects),
+		}
+	}
+	return out
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,37 @@
+package mediahost
+
+import (
+	"fmt"
+	"time"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/config"
+)
+
+// Storage backends for hosted media.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// New returns the configured media host, or nil when no backend is set,
+// which the gateway reads as "send no links". baseURL is where this
+// instance is reachable and mediaDir where downloaded media is kept.
+func New(cfg config.MediaHostConfig, baseURL, mediaDir string) (messaging.MediaHost, error) {
+	ttl := time.Duration(cfg.TTLMinutes) * time.Minute
+
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case BackendLocal:
+		return NewLocal(baseURL, mediaDir, cfg.Secret, ttl), nil
+	case BackendS3:
+		host, err := NewS3(cfg.S3, ttl)
+		if err != nil {
+			return nil, err
+		}
+		return host, nil
+	default:
+		return nil, fmt.Errorf("unknown media host backend %q", cfg.Backend)
+	}
+}
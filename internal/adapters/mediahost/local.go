@@ -0,0 +1,96 @@
+package mediahost
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/core/messaging"
+)
+
+// PublicPath is the route, relative to the base URL, that serves local
+// links.
+const PublicPath = "/media/public/"
+
+// Local serves media from the media directory itself. Links carry the
+// file's path and expiry, signed so they cannot be altered or forged; no
+// state is kept, so links survive restarts as long as the secret does.
+type Local struct {
+	baseURL  string
+	mediaDir string
+	secret   []byte
+	ttl      time.Duration
+}
+
+func NewLocal(baseURL, mediaDir, secret string, ttl time.Duration) *Local {
+	return &Local{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		mediaDir: mediaDir,
+		secret:   []byte(secret),
+		ttl:      ttl,
+	}
+}
+
+func (l *Local) Publish(_ context.Context, _ uuid.UUID, path, _ string) (*messaging.HostedMedia, error) {
+	rel, err := filepath.Rel(l.mediaDir, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("media file %s is outside the media directory", path)
+	}
+
+	expiresAt := time.Now().Add(l.ttl).Truncate(time.Second)
+	payload := filepath.ToSlash(rel) + "\n" + strconv.FormatInt(expiresAt.Unix(), 10)
+	token := encode([]byte(payload)) + "." + encode(l.sign(payload))
+
+	return &messaging.HostedMedia{
+		URL:       l.baseURL + PublicPath + token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (l *Local) Open(token string) (string, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, l.sign(string(payload))) {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+
+	rel, expiry, ok := strings.Cut(string(payload), "\n")
+	if !ok {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+
+	rel = filepath.FromSlash(rel)
+	if !filepath.IsLocal(rel) {
+		return "", messaging.ErrHostedMediaNotFound
+	}
+	return filepath.Join(l.mediaDir, rel), nil
+}
+
+func (l *Local) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
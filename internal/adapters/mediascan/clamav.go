@@ -0,0 +1,95 @@
+package mediascan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"zpwoot/internal/core/messaging"
+)
+
+// ClamAV posts files to a ClamAV REST front end (clamav-rest and
+// compatibles) as the multipart field "file". The service answers 200 for
+// clean files and 406 for infected ones, naming the signature in the
+// Description of its JSON result.
+type ClamAV struct {
+	endpoint string
+	http     *http.Client
+}
+
+func NewClamAV(endpoint *url.URL, timeout time.Duration) *ClamAV {
+	return &ClamAV{
+		endpoint: endpoint.String(),
+		http:     &http.Client{Timeout: timeout},
+	}
+}
+
+type clamAVResult struct {
+	Status      string `json:"Status"`
+	Description string `json:"Description"`
+}
+
+func (c *ClamAV) Scan(ctx context.Context, fileName, _ string, data []byte) (*messaging.ScanVerdict, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach scanner: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &messaging.ScanVerdict{}, nil
+	case http.StatusNotAcceptable:
+		return &messaging.ScanVerdict{Infected: true, Signature: clamAVSignature(result)}, nil
+	default:
+		return nil, fmt.Errorf("scanner responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(result)))
+	}
+}
+
+// clamAVSignature reads the signature from either a single result or a
+// list of them, one per file.
+func clamAVSignature(body []byte) string {
+	var results []clamAVResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		var single clamAVResult
+		if err := json.Unmarshal(body, &single); err != nil {
+			return ""
+		}
+		results = []clamAVResult{single}
+	}
+
+	for _, result := range results {
+		if result.Description != "" {
+			return result.Description
+		}
+	}
+	return ""
+}
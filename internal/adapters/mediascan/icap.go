@@ -0,0 +1,130 @@
+package mediascan
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"zpwoot/internal/core/messaging"
+)
+
+const defaultICAPPort = "1344"
+
+// ICAP sends files to an ICAP service (c-icap, Kaspersky, Sophos...) as the
+// body of a RESPMOD request, one connection per scan. The service answers
+// 204 for clean files; an X-Infection-Found, X-Violations-Found or
+// X-Virus-ID header marks infected ones.
+type ICAP struct {
+	endpoint *url.URL
+	address  string
+	timeout  time.Duration
+}
+
+func NewICAP(endpoint *url.URL, timeout time.Duration) *ICAP {
+	address := endpoint.Host
+	if endpoint.Port() == "" {
+		address = net.JoinHostPort(endpoint.Hostname(), defaultICAPPort)
+	}
+
+	return &ICAP{
+		endpoint: endpoint,
+		address:  address,
+		timeout:  timeout,
+	}
+}
+
+func (c *ICAP) Scan(ctx context.Context, fileName, mimeType string, data []byte) (*messaging.ScanVerdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach scanner: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	resHeader := "HTTP/1.1 200 OK\r\n" +
+		"Content-Type: " + mimeType + "\r\n" +
+		"Content-Disposition: attachment; filename=" + strconv.Quote(fileName) + "\r\n" +
+		"Content-Length: " + strconv.Itoa(len(data)) + "\r\n\r\n"
+
+	w := bufio.NewWriter(conn)
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", c.endpoint.String())
+	fmt.Fprintf(w, "Host: %s\r\n", c.endpoint.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Connection: close\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(resHeader))
+	w.WriteString(resHeader)
+	if len(data) > 0 {
+		fmt.Fprintf(w, "%x\r\n", len(data))
+		w.Write(data)
+		w.WriteString("\r\n")
+	}
+	w.WriteString("0\r\n\r\n")
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to send file to scanner: %w", err)
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scanner response: %w", err)
+	}
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scanner response: %w", err)
+	}
+
+	fields := strings.Fields(statusLine)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return nil, fmt.Errorf("invalid scanner response %q", statusLine)
+	}
+
+	switch fields[1] {
+	case "204":
+		return &messaging.ScanVerdict{}, nil
+	case "200":
+		if signature, infected := icapInfection(header); infected {
+			return &messaging.ScanVerdict{Infected: true, Signature: signature}, nil
+		}
+		// The service sent the file back unmodified.
+		return &messaging.ScanVerdict{}, nil
+	default:
+		return nil, fmt.Errorf("scanner responded with %q", statusLine)
+	}
+}
+
+// icapInfection reads the threat name from the headers vendors use to
+// report one.
+func icapInfection(header textproto.MIMEHeader) (string, bool) {
+	if found := header.Get("X-Infection-Found"); found != "" {
+		for _, param := range strings.Split(found, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(name, "Threat") {
+				return strings.TrimSpace(value), true
+			}
+		}
+		return "", true
+	}
+	if found := header.Get("X-Violations-Found"); found != "" {
+		return "", true
+	}
+	if virus := header.Get("X-Virus-ID"); virus != "" {
+		return virus, true
+	}
+	return "", false
+}
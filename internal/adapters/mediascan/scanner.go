@@ -0,0 +1,34 @@
+package mediascan
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/config"
+)
+
+// New returns the configured scanner, or nil when no URL is set, which the
+// gateway reads as "scan nothing". The URL scheme picks the protocol.
+func New(cfg config.MediaScanConfig) (messaging.MediaScanner, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid media scan URL %q", cfg.URL)
+	}
+
+	timeout := time.Duration(cfg.Timeout) * time.Second
+
+	switch parsed.Scheme {
+	case "http", "https":
+		return NewClamAV(parsed, timeout), nil
+	case "icap":
+		return NewICAP(parsed, timeout), nil
+	default:
+		return nil, fmt.Errorf("media scan URL must be http, https or icap, got %q", parsed.Scheme)
+	}
+}
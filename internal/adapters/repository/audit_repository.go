@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/audit"
+	"zpwoot/platform/logger"
+)
+
+// AuditRepository lists and counts entries on the read pool; entries are
+// written and pruned on the primary.
+type AuditRepository struct {
+	db     *sqlx.DB
+	reads  ReadPool
+	logger *logger.Logger
+}
+
+func NewAuditRepository(db *sqlx.DB, reads ReadPool, logger *logger.Logger) audit.Repository {
+	return &AuditRepository{
+		db:     db,
+		reads:  reads,
+		logger: logger,
+	}
+}
+
+type auditModel struct {
+	ID             string         `db:"id"`
+	APIKey         string         `db:"apiKey"`
+	ClientIP       string         `db:"clientIp"`
+	UserAgent      sql.NullString `db:"userAgent"`
+	SessionRef     sql.NullString `db:"sessionRef"`
+	Method         string         `db:"method"`
+	Path           string         `db:"path"`
+	Route          sql.NullString `db:"route"`
+	StatusCode     int            `db:"statusCode"`
+	Success        bool           `db:"success"`
+	DurationMs     int64          `db:"durationMs"`
+	PayloadSummary []byte         `db:"payloadSummary"`
+	CreatedAt      time.Time      `db:"createdAt"`
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *audit.Entry) error {
+	model, err := r.toModel(entry)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "zpAuditLog" (
+			id, "apiKey", "clientIp", "userAgent", "sessionRef", method, path, route,
+			"statusCode", success, "durationMs", "payloadSummary", "createdAt"
+		) VALUES (
+			:id, :apiKey, :clientIp, :userAgent, :sessionRef, :method, :path, :route,
+			:statusCode, :success, :durationMs, :payloadSummary, :createdAt
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, error) {
+	where, args := r.buildWhere(filter)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM "zpAuditLog"
+		%s
+		ORDER BY "createdAt" DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	var models []auditModel
+	if err := r.reads.Reader().SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries := make([]*audit.Entry, len(models))
+	for i := range models {
+		entry, err := r.fromModel(&models[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to audit entry: %w", err)
+		}
+		entries[i] = entry
+	}
+
+	return entries, nil
+}
+
+func (r *AuditRepository) Count(ctx context.Context, filter *audit.Filter) (int64, error) {
+	where, args := r.buildWhere(filter)
+
+	var count int64
+	query := `SELECT COUNT(*) FROM "zpAuditLog" ` + where
+	if err := r.reads.Reader().GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *AuditRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM "zpAuditLog" WHERE "createdAt" < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete audit entries: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (r *AuditRepository) buildWhere(filter *audit.Filter) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.APIKey != "" {
+		add(`"apiKey" = $%d`, filter.APIKey)
+	}
+	if filter.SessionRef != "" {
+		add(`"sessionRef" = $%d`, filter.SessionRef)
+	}
+	if filter.Method != "" {
+		add(`method = $%d`, strings.ToUpper(filter.Method))
+	}
+	if filter.Path != "" {
+		add(`path LIKE $%d`, filter.Path+"%")
+	}
+	if filter.Success != nil {
+		add(`success = $%d`, *filter.Success)
+	}
+	if filter.From != nil {
+		add(`"createdAt" >= $%d`, *filter.From)
+	}
+	if filter.To != nil {
+		add(`"createdAt" <= $%d`, *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *AuditRepository) toModel(entry *audit.Entry) (*auditModel, error) {
+	model := &auditModel{
+		ID:         entry.ID.String(),
+		APIKey:     entry.APIKey,
+		ClientIP:   entry.ClientIP,
+		UserAgent:  sql.NullString{String: entry.UserAgent, Valid: entry.UserAgent != ""},
+		SessionRef: sql.NullString{String: entry.SessionRef, Valid: entry.SessionRef != ""},
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Route:      sql.NullString{String: entry.Route, Valid: entry.Route != ""},
+		StatusCode: entry.StatusCode,
+		Success:    entry.Success,
+		DurationMs: entry.DurationMs,
+		CreatedAt:  entry.CreatedAt,
+	}
+
+	if entry.PayloadSummary != nil {
+		payload, err := json.Marshal(entry.PayloadSummary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode payload summary: %w", err)
+		}
+		model.PayloadSummary = payload
+	}
+
+	return model, nil
+}
+
+func (r *AuditRepository) fromModel(model *auditModel) (*audit.Entry, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit entry ID: %w", err)
+	}
+
+	entry := &audit.Entry{
+		ID:         id,
+		APIKey:     model.APIKey,
+		ClientIP:   model.ClientIP,
+		UserAgent:  model.UserAgent.String,
+		SessionRef: model.SessionRef.String,
+		Method:     model.Method,
+		Path:       model.Path,
+		Route:      model.Route.String,
+		StatusCode: model.StatusCode,
+		Success:    model.Success,
+		DurationMs: model.DurationMs,
+		CreatedAt:  model.CreatedAt,
+	}
+
+	if len(model.PayloadSummary) > 0 {
+		if err := json.Unmarshal(model.PayloadSummary, &entry.PayloadSummary); err != nil {
+			return nil, fmt.Errorf("invalid payload summary: %w", err)
+		}
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/contact"
+	"zpwoot/platform/logger"
+)
+
+type AvatarRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewAvatarRepository(db *sqlx.DB, logger *logger.Logger) contact.AvatarRepository {
+	return &AvatarRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type avatarModel struct {
+	SessionID string    `db:"sessionId"`
+	JID       string    `db:"jid"`
+	PictureID string    `db:"pictureId"`
+	URL       string    `db:"url"`
+	LocalPath string    `db:"localPath"`
+	CheckedAt time.Time `db:"checkedAt"`
+	ChangedAt time.Time `db:"changedAt"`
+}
+
+func (r *AvatarRepository) Get(ctx context.Context, sessionID uuid.UUID, jid string) (*contact.Avatar, error) {
+	var model avatarModel
+	query := `SELECT * FROM "zpContactAvatars" WHERE "sessionId" = $1 AND "jid" = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), jid); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, contact.ErrAvatarNotFound
+		}
+		return nil, fmt.Errorf("failed to get avatar: %w", err)
+	}
+
+	return &contact.Avatar{
+		SessionID: sessionID,
+		JID:       model.JID,
+		PictureID: model.PictureID,
+		URL:       model.URL,
+		LocalPath: model.LocalPath,
+		CheckedAt: model.CheckedAt,
+		ChangedAt: model.ChangedAt,
+	}, nil
+}
+
+func (r *AvatarRepository) Save(ctx context.Context, avatar *contact.Avatar) error {
+	model := avatarModel{
+		SessionID: avatar.SessionID.String(),
+		JID:       avatar.JID,
+		PictureID: avatar.PictureID,
+		URL:       avatar.URL,
+		LocalPath: avatar.LocalPath,
+		CheckedAt: avatar.CheckedAt,
+		ChangedAt: avatar.ChangedAt,
+	}
+
+	query := `
+		INSERT INTO "zpContactAvatars" ("sessionId", "jid", "pictureId", "url", "localPath", "checkedAt", "changedAt")
+		VALUES (:sessionId, :jid, :pictureId, :url, :localPath, :checkedAt, :changedAt)
+		ON CONFLICT ("sessionId", "jid") DO UPDATE
+		SET "pictureId" = EXCLUDED."pictureId", "url" = EXCLUDED."url", "localPath" = EXCLUDED."localPath",
+			"checkedAt" = EXCLUDED."checkedAt", "changedAt" = EXCLUDED."changedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save avatar: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/platform/logger"
+)
+
+type ChatwootGroupRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewChatwootGroupRepository(db *sqlx.DB, logger *logger.Logger) chatwoot.GroupContactRepository {
+	return &ChatwootGroupRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type chatwootGroupContactModel struct {
+	AccountID int       `db:"accountId"`
+	ContactID int       `db:"contactId"`
+	SessionID string    `db:"sessionId"`
+	GroupJID  string    `db:"groupJid"`
+	UpdatedAt time.Time `db:"updatedAt"`
+}
+
+func (r *ChatwootGroupRepository) Save(ctx context.Context, contact *chatwoot.GroupContact) error {
+	model := chatwootGroupContactModel{
+		AccountID: contact.AccountID,
+		ContactID: contact.ContactID,
+		SessionID: contact.SessionID.String(),
+		GroupJID:  contact.GroupJID,
+		UpdatedAt: contact.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpChatwootGroupContacts" ("accountId", "contactId", "sessionId", "groupJid", "updatedAt")
+		VALUES (:accountId, :contactId, :sessionId, :groupJid, :updatedAt)
+		ON CONFLICT ("accountId", "contactId") DO UPDATE
+		SET "sessionId" = EXCLUDED."sessionId", "groupJid" = EXCLUDED."groupJid", "updatedAt" = EXCLUDED."updatedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save chatwoot group contact: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChatwootGroupRepository) ListByGroup(ctx context.Context, sessionID uuid.UUID, groupJID string) ([]*chatwoot.GroupContact, error) {
+	query := `SELECT * FROM "zpChatwootGroupContacts" WHERE "sessionId" = $1 AND "groupJid" = $2`
+
+	var models []chatwootGroupContactModel
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), groupJID); err != nil {
+		return nil, fmt.Errorf("failed to list chatwoot group contacts: %w", err)
+	}
+
+	contacts := make([]*chatwoot.GroupContact, len(models))
+	for i, model := range models {
+		contacts[i] = &chatwoot.GroupContact{
+			AccountID: model.AccountID,
+			ContactID: model.ContactID,
+			SessionID: sessionID,
+			GroupJID:  model.GroupJID,
+			UpdatedAt: model.UpdatedAt,
+		}
+	}
+
+	return contacts, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/platform/logger"
+)
+
+type ChatwootRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewChatwootRepository(db *sqlx.DB, logger *logger.Logger) chatwoot.Repository {
+	return &ChatwootRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type chatwootInboxModel struct {
+	AccountID int       `db:"accountId"`
+	InboxID   int       `db:"inboxId"`
+	SessionID string    `db:"sessionId"`
+	CreatedAt time.Time `db:"createdAt"`
+	UpdatedAt time.Time `db:"updatedAt"`
+}
+
+func (r *ChatwootRepository) List(ctx context.Context, accountID int) ([]*chatwoot.InboxRoute, error) {
+	var models []chatwootInboxModel
+
+	query := `SELECT * FROM "zpChatwootInboxes"`
+	args := []interface{}{}
+	if accountID > 0 {
+		query += ` WHERE "accountId" = $1`
+		args = append(args, accountID)
+	}
+	query += ` ORDER BY "accountId", "inboxId"`
+
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list chatwoot inboxes: %w", err)
+	}
+
+	routes := make([]*chatwoot.InboxRoute, 0, len(models))
+	for _, model := range models {
+		route, err := r.fromModel(model)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+func (r *ChatwootRepository) GetByInbox(ctx context.Context, accountID, inboxID int) (*chatwoot.InboxRoute, error) {
+	query := `SELECT * FROM "zpChatwootInboxes" WHERE "accountId" = $1 AND "inboxId" = $2`
+	return r.get(ctx, query, accountID, inboxID)
+}
+
+func (r *ChatwootRepository) GetBySession(ctx context.Context, accountID int, sessionID uuid.UUID) (*chatwoot.InboxRoute, error) {
+	query := `SELECT * FROM "zpChatwootInboxes" WHERE "accountId" = $1 AND "sessionId" = $2`
+	return r.get(ctx, query, accountID, sessionID.String())
+}
+
+func (r *ChatwootRepository) Save(ctx context.Context, route *chatwoot.InboxRoute) error {
+	model := chatwootInboxModel{
+		AccountID: route.AccountID,
+		InboxID:   route.InboxID,
+		SessionID: route.SessionID.String(),
+		CreatedAt: route.CreatedAt,
+		UpdatedAt: route.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpChatwootInboxes" ("accountId", "inboxId", "sessionId", "createdAt", "updatedAt")
+		VALUES (:accountId, :inboxId, :sessionId, :createdAt, :updatedAt)
+		ON CONFLICT ("accountId", "inboxId") DO UPDATE
+		SET "sessionId" = EXCLUDED."sessionId", "updatedAt" = EXCLUDED."updatedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return chatwoot.ErrInboxConflict
+		}
+		return fmt.Errorf("failed to save chatwoot inbox: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChatwootRepository) Delete(ctx context.Context, accountID, inboxID int) error {
+	query := `DELETE FROM "zpChatwootInboxes" WHERE "accountId" = $1 AND "inboxId" = $2`
+
+	result, err := r.db.ExecContext(ctx, query, accountID, inboxID)
+	if err != nil {
+		return fmt.Errorf("failed to delete chatwoot inbox: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete chatwoot inbox: %w", err)
+	}
+	if rows == 0 {
+		return chatwoot.ErrInboxNotMapped
+	}
+
+	return nil
+}
+
+func (r *ChatwootRepository) get(ctx context.Context, query string, args ...interface{}) (*chatwoot.InboxRoute, error) {
+	var model chatwootInboxModel
+	if err := r.db.GetContext(ctx, &model, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, chatwoot.ErrInboxNotMapped
+		}
+		return nil, fmt.Errorf("failed to get chatwoot inbox: %w", err)
+	}
+
+	return r.fromModel(model)
+}
+
+func (r *ChatwootRepository) fromModel(model chatwootInboxModel) (*chatwoot.InboxRoute, error) {
+	sessionID, err := uuid.Parse(model.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID in chatwoot inbox: %w", err)
+	}
+
+	return &chatwoot.InboxRoute{
+		AccountID: model.AccountID,
+		InboxID:   model.InboxID,
+		SessionID: sessionID,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
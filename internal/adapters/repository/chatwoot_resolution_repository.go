@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/platform/logger"
+)
+
+type ChatwootResolutionRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewChatwootResolutionRepository(db *sqlx.DB, logger *logger.Logger) chatwoot.ResolutionRepository {
+	return &ChatwootResolutionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type chatwootResolutionModel struct {
+	AccountID      int       `db:"accountId"`
+	ConversationID int       `db:"conversationId"`
+	SessionID      string    `db:"sessionId"`
+	ContactJID     string    `db:"contactJid"`
+	ResolvedAt     time.Time `db:"resolvedAt"`
+}
+
+func (r *ChatwootResolutionRepository) Save(ctx context.Context, resolution *chatwoot.Resolution) error {
+	model := chatwootResolutionModel{
+		AccountID:      resolution.AccountID,
+		ConversationID: resolution.ConversationID,
+		SessionID:      resolution.SessionID.String(),
+		ContactJID:     resolution.ContactJID,
+		ResolvedAt:     resolution.ResolvedAt,
+	}
+
+	query := `
+		INSERT INTO "zpChatwootResolutions" ("accountId", "conversationId", "sessionId", "contactJid", "resolvedAt")
+		VALUES (:accountId, :conversationId, :sessionId, :contactJid, :resolvedAt)
+		ON CONFLICT ("accountId", "conversationId") DO UPDATE
+		SET "sessionId" = EXCLUDED."sessionId", "contactJid" = EXCLUDED."contactJid", "resolvedAt" = EXCLUDED."resolvedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save chatwoot resolution: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ChatwootResolutionRepository) Latest(ctx context.Context, sessionID uuid.UUID, contactJID string) (*chatwoot.Resolution, error) {
+	var model chatwootResolutionModel
+	query := `
+		SELECT * FROM "zpChatwootResolutions"
+		WHERE "sessionId" = $1 AND "contactJid" = $2
+		ORDER BY "resolvedAt" DESC
+		LIMIT 1
+	`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), contactJID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, chatwoot.ErrNoResolution
+		}
+		return nil, fmt.Errorf("failed to get chatwoot resolution: %w", err)
+	}
+
+	return &chatwoot.Resolution{
+		AccountID:      model.AccountID,
+		ConversationID: model.ConversationID,
+		SessionID:      sessionID,
+		ContactJID:     model.ContactJID,
+		ResolvedAt:     model.ResolvedAt,
+	}, nil
+}
+
+func (r *ChatwootResolutionRepository) Delete(ctx context.Context, accountID, conversationID int) error {
+	query := `DELETE FROM "zpChatwootResolutions" WHERE "accountId" = $1 AND "conversationId" = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, accountID, conversationID); err != nil {
+		return fmt.Errorf("failed to delete chatwoot resolution: %w", err)
+	}
+
+	return nil
+}
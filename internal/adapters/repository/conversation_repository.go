@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/conversation"
+	"zpwoot/platform/logger"
+)
+
+type ConversationRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewConversationRepository(db *sqlx.DB, logger *logger.Logger) conversation.Repository {
+	return &ConversationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type conversationStateModel struct {
+	SessionID string       `db:"sessionId"`
+	ChatJID   string       `db:"chatJid"`
+	Data      []byte       `db:"data"`
+	Version   int64        `db:"version"`
+	ExpiresAt sql.NullTime `db:"expiresAt"`
+	UpdatedAt time.Time    `db:"updatedAt"`
+}
+
+// liveState matches state that has not expired.
+const liveState = `("expiresAt" IS NULL OR "expiresAt" > NOW())`
+
+func (r *ConversationRepository) Get(ctx context.Context, sessionID uuid.UUID, chatJID string) (*conversation.State, error) {
+	var model conversationStateModel
+	query := `SELECT * FROM "zpConversationStates" WHERE "sessionId" = $1 AND "chatJid" = $2 AND ` + liveState
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), chatJID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, conversation.ErrStateNotFound
+		}
+		return nil, fmt.Errorf("failed to get conversation state: %w", err)
+	}
+
+	state := &conversation.State{
+		SessionID: sessionID,
+		ChatJID:   model.ChatJID,
+		Data:      model.Data,
+		Version:   model.Version,
+		UpdatedAt: model.UpdatedAt,
+	}
+	if model.ExpiresAt.Valid {
+		state.ExpiresAt = &model.ExpiresAt.Time
+	}
+
+	return state, nil
+}
+
+// Put relies on the row lock the upsert or update takes, so of two writes
+// expecting the same version only the first one matches.
+func (r *ConversationRepository) Put(ctx context.Context, state *conversation.State, expected *int64) (bool, error) {
+	var expiresAt sql.NullTime
+	if state.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *state.ExpiresAt, Valid: true}
+	}
+	args := []interface{}{state.SessionID.String(), state.ChatJID, []byte(state.Data), expiresAt}
+
+	// Expired state counts as none: writing over it starts again at 1.
+	var query string
+	switch {
+	case expected == nil || *expected == 0:
+		query = `
+			INSERT INTO "zpConversationStates" AS s ("sessionId", "chatJid", data, version, "expiresAt", "updatedAt")
+			VALUES ($1, $2, $3, 1, $4, NOW())
+			ON CONFLICT ("sessionId", "chatJid") DO UPDATE
+			SET data = EXCLUDED.data,
+				version = CASE WHEN s."expiresAt" IS NOT NULL AND s."expiresAt" <= NOW() THEN 1 ELSE s.version + 1 END,
+				"expiresAt" = EXCLUDED."expiresAt",
+				"updatedAt" = NOW()
+		`
+		if expected != nil {
+			query += ` WHERE s."expiresAt" IS NOT NULL AND s."expiresAt" <= NOW()`
+		}
+		query += ` RETURNING version, "updatedAt"`
+	default:
+		query = `
+			UPDATE "zpConversationStates"
+			SET data = $3, version = version + 1, "expiresAt" = $4, "updatedAt" = NOW()
+			WHERE "sessionId" = $1 AND "chatJid" = $2 AND version = $5 AND ` + liveState + `
+			RETURNING version, "updatedAt"
+		`
+		args = append(args, *expected)
+	}
+
+	var row struct {
+		Version   int64     `db:"version"`
+		UpdatedAt time.Time `db:"updatedAt"`
+	}
+	err := r.db.GetContext(ctx, &row, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to save conversation state: %w", err)
+	}
+
+	state.Version = row.Version
+	state.UpdatedAt = row.UpdatedAt
+	return true, nil
+}
+
+func (r *ConversationRepository) Delete(ctx context.Context, sessionID uuid.UUID, chatJID string, expected *int64) (bool, error) {
+	query := `DELETE FROM "zpConversationStates" WHERE "sessionId" = $1 AND "chatJid" = $2 AND ` + liveState
+	args := []interface{}{sessionID.String(), chatJID}
+	if expected != nil {
+		query += ` AND version = $3`
+		args = append(args, *expected)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete conversation state: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete conversation state: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *ConversationRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM "zpConversationStates" WHERE "expiresAt" IS NOT NULL AND "expiresAt" <= $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired conversation state: %w", err)
+	}
+
+	return result.RowsAffected()
+}
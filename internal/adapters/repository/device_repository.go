@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+type DeviceRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewDeviceRepository(db *sqlx.DB, logger *logger.Logger) session.DeviceRepository {
+	return &DeviceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type deviceModel struct {
+	SessionID   string     `db:"sessionId"`
+	DeviceID    int        `db:"deviceId"`
+	JID         string     `db:"jid"`
+	Platform    string     `db:"platform"`
+	FirstSeenAt time.Time  `db:"firstSeenAt"`
+	LastSeenAt  *time.Time `db:"lastSeenAt"`
+}
+
+func (r *DeviceRepository) List(ctx context.Context, sessionID uuid.UUID) ([]*session.Device, error) {
+	query := `SELECT * FROM "zpSessionDevices" WHERE "sessionId" = $1 ORDER BY "deviceId"`
+
+	var models []deviceModel
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String()); err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	devices := make([]*session.Device, len(models))
+	for i, model := range models {
+		devices[i] = &session.Device{
+			SessionID:   sessionID,
+			ID:          model.DeviceID,
+			JID:         model.JID,
+			Platform:    model.Platform,
+			FirstSeenAt: model.FirstSeenAt,
+			LastSeenAt:  model.LastSeenAt,
+		}
+	}
+
+	return devices, nil
+}
+
+func (r *DeviceRepository) Add(ctx context.Context, device *session.Device) error {
+	model := deviceModel{
+		SessionID:   device.SessionID.String(),
+		DeviceID:    device.ID,
+		JID:         device.JID,
+		Platform:    device.Platform,
+		FirstSeenAt: device.FirstSeenAt,
+		LastSeenAt:  device.LastSeenAt,
+	}
+
+	query := `
+		INSERT INTO "zpSessionDevices" ("sessionId", "deviceId", "jid", "platform", "firstSeenAt", "lastSeenAt")
+		VALUES (:sessionId, :deviceId, :jid, :platform, :firstSeenAt, :lastSeenAt)
+		ON CONFLICT ("sessionId", "deviceId") DO NOTHING
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to add device: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DeviceRepository) Touch(ctx context.Context, sessionID uuid.UUID, deviceID int, platform string, at time.Time) (bool, error) {
+	query := `
+		UPDATE "zpSessionDevices"
+		SET "lastSeenAt" = GREATEST(COALESCE("lastSeenAt", $3), $3),
+			"platform" = CASE WHEN $4 = '' THEN "platform" ELSE $4 END
+		WHERE "sessionId" = $1 AND "deviceId" = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID.String(), deviceID, at, platform)
+	if err != nil {
+		return false, fmt.Errorf("failed to record device activity: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record device activity: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *DeviceRepository) Remove(ctx context.Context, sessionID uuid.UUID, deviceIDs []int) error {
+	query := `DELETE FROM "zpSessionDevices" WHERE "sessionId" = $1 AND "deviceId" = ANY($2)`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), pq.Array(deviceIDs)); err != nil {
+		return fmt.Errorf("failed to remove devices: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/shared/pagination"
+	"zpwoot/platform/logger"
+)
+
+type FilterLogRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewFilterLogRepository(db *sqlx.DB, logger *logger.Logger) session.FilterLog {
+	return &FilterLogRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type filteredMessageModel struct {
+	ID           string         `db:"id"`
+	SessionID    string         `db:"sessionId"`
+	MessageID    string         `db:"messageId"`
+	ChatJID      string         `db:"chatJid"`
+	SenderJID    string         `db:"senderJid"`
+	Text         string         `db:"text"`
+	Rule         string         `db:"rule"`
+	Conditions   []byte         `db:"conditions"`
+	Keyword      sql.NullString `db:"keyword"`
+	Actions      []byte         `db:"actions"`
+	ActionErrors []byte         `db:"actionErrors"`
+	CreatedAt    time.Time      `db:"createdAt"`
+}
+
+func (r *FilterLogRepository) Record(ctx context.Context, message *session.FilteredMessage) error {
+	conditions, err := json.Marshal(message.Match.Conditions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal filter conditions: %w", err)
+	}
+	actions, err := json.Marshal(message.Match.Actions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal filter actions: %w", err)
+	}
+	var actionErrors sql.NullString
+	if len(message.ActionErrors) > 0 {
+		data, err := json.Marshal(message.ActionErrors)
+		if err != nil {
+			return fmt.Errorf("failed to marshal filter action errors: %w", err)
+		}
+		actionErrors = sql.NullString{String: string(data), Valid: true}
+	}
+
+	query := `
+		INSERT INTO "zpFilteredMessages" (id, "sessionId", "messageId", "chatJid", "senderJid", text, rule, conditions, keyword, actions, "actionErrors", "createdAt")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	keyword := sql.NullString{String: message.Match.Keyword, Valid: message.Match.Keyword != ""}
+	_, err = r.db.ExecContext(ctx, query,
+		message.ID.String(), message.SessionID.String(), message.MessageID, message.ChatJID, message.SenderJID,
+		message.Text, message.Match.Rule, string(conditions), keyword, string(actions), actionErrors, message.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record filtered message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FilterLogRepository) List(ctx context.Context, sessionID uuid.UUID, page pagination.Request) ([]*session.FilteredMessage, error) {
+	var models []filteredMessageModel
+
+	conditions := []string{`"sessionId" = $1`}
+	args := []interface{}{sessionID.String()}
+
+	if page.After != nil {
+		args = append(args, page.After.Time, page.After.ID)
+		conditions = append(conditions, fmt.Sprintf(`("createdAt", "id") < ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	}
+	args = append(args, page.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM "zpFilteredMessages"
+		WHERE %s
+		ORDER BY "createdAt" DESC, "id" DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list filtered messages: %w", err)
+	}
+
+	messages := make([]*session.FilteredMessage, len(models))
+	for i := range models {
+		message, err := filteredMessageFromModel(sessionID, &models[i])
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+func filteredMessageFromModel(sessionID uuid.UUID, model *filteredMessageModel) (*session.FilteredMessage, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filtered message ID: %w", err)
+	}
+
+	message := &session.FilteredMessage{
+		ID:        id,
+		SessionID: sessionID,
+		MessageID: model.MessageID,
+		ChatJID:   model.ChatJID,
+		SenderJID: model.SenderJID,
+		Text:      model.Text,
+		Match: session.FilterMatch{
+			Rule:    model.Rule,
+			Keyword: model.Keyword.String,
+		},
+		CreatedAt: model.CreatedAt,
+	}
+
+	if err := json.Unmarshal(model.Conditions, &message.Match.Conditions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal filter conditions: %w", err)
+	}
+	if err := json.Unmarshal(model.Actions, &message.Match.Actions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal filter actions: %w", err)
+	}
+	if len(model.ActionErrors) > 0 {
+		if err := json.Unmarshal(model.ActionErrors, &message.ActionErrors); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal filter action errors: %w", err)
+		}
+	}
+
+	return message, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/group"
+	"zpwoot/platform/logger"
+)
+
+type GroupMetadataRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewGroupMetadataRepository(db *sqlx.DB, logger *logger.Logger) group.MetadataRepository {
+	return &GroupMetadataRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type groupMetadataModel struct {
+	SessionID        string    `db:"sessionId"`
+	GroupJID         string    `db:"groupJid"`
+	Name             string    `db:"name"`
+	Topic            string    `db:"topic"`
+	ParticipantCount int       `db:"participantCount"`
+	CheckedAt        time.Time `db:"checkedAt"`
+	ChangedAt        time.Time `db:"changedAt"`
+}
+
+type activeGroupModel struct {
+	SessionID   string `db:"sessionId"`
+	SessionName string `db:"sessionName"`
+	GroupJID    string `db:"groupJid"`
+}
+
+func (r *GroupMetadataRepository) Get(ctx context.Context, sessionID uuid.UUID, groupJID string) (*group.Metadata, error) {
+	var model groupMetadataModel
+	query := `SELECT * FROM "zpGroupMetadata" WHERE "sessionId" = $1 AND "groupJid" = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), groupJID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, group.ErrMetadataNotFound
+		}
+		return nil, fmt.Errorf("failed to get group metadata: %w", err)
+	}
+
+	return &group.Metadata{
+		SessionID:        sessionID,
+		GroupJID:         model.GroupJID,
+		Name:             model.Name,
+		Topic:            model.Topic,
+		ParticipantCount: model.ParticipantCount,
+		CheckedAt:        model.CheckedAt,
+		ChangedAt:        model.ChangedAt,
+	}, nil
+}
+
+func (r *GroupMetadataRepository) Save(ctx context.Context, metadata *group.Metadata) error {
+	model := groupMetadataModel{
+		SessionID:        metadata.SessionID.String(),
+		GroupJID:         metadata.GroupJID,
+		Name:             metadata.Name,
+		Topic:            metadata.Topic,
+		ParticipantCount: metadata.ParticipantCount,
+		CheckedAt:        metadata.CheckedAt,
+		ChangedAt:        metadata.ChangedAt,
+	}
+
+	query := `
+		INSERT INTO "zpGroupMetadata" ("sessionId", "groupJid", "name", "topic", "participantCount", "checkedAt", "changedAt")
+		VALUES (:sessionId, :groupJid, :name, :topic, :participantCount, :checkedAt, :changedAt)
+		ON CONFLICT ("sessionId", "groupJid") DO UPDATE
+		SET "name" = EXCLUDED."name", "topic" = EXCLUDED."topic", "participantCount" = EXCLUDED."participantCount",
+			"checkedAt" = EXCLUDED."checkedAt", "changedAt" = EXCLUDED."changedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save group metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GroupMetadataRepository) Delete(ctx context.Context, sessionID uuid.UUID, groupJID string) error {
+	query := `DELETE FROM "zpGroupMetadata" WHERE "sessionId" = $1 AND "groupJid" = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), groupJID); err != nil {
+		return fmt.Errorf("failed to delete group metadata: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GroupMetadataRepository) ListStale(ctx context.Context, activeSince, checkedBefore time.Time, limit int) ([]*group.ActiveGroup, error) {
+	query := `
+		SELECT c."sessionId", s."name" AS "sessionName", c."chatJid" AS "groupJid"
+		FROM "zpChats" c
+		JOIN "zpSessions" s ON s."id" = c."sessionId" AND s."isConnected"
+		LEFT JOIN "zpGroupMetadata" m ON m."sessionId" = c."sessionId" AND m."groupJid" = c."chatJid"
+		WHERE c."chatJid" LIKE '%@g.us' AND c."lastMessageAt" >= $1
+			AND (m."checkedAt" IS NULL OR m."checkedAt" < $2)
+		ORDER BY m."checkedAt" ASC NULLS FIRST
+		LIMIT $3
+	`
+
+	var models []activeGroupModel
+	if err := r.db.SelectContext(ctx, &models, query, activeSince, checkedBefore, limit); err != nil {
+		return nil, fmt.Errorf("failed to list stale groups: %w", err)
+	}
+
+	groups := make([]*group.ActiveGroup, 0, len(models))
+	for _, model := range models {
+		sessionID, err := uuid.Parse(model.SessionID)
+		if err != nil {
+			continue
+		}
+		groups = append(groups, &group.ActiveGroup{
+			SessionID:   sessionID,
+			SessionName: model.SessionName,
+			GroupJID:    model.GroupJID,
+		})
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/label"
+	"zpwoot/platform/logger"
+)
+
+type LabelRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewLabelRepository(db *sqlx.DB, logger *logger.Logger) label.Repository {
+	return &LabelRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type labelModel struct {
+	SessionID string    `db:"sessionId"`
+	LabelID   string    `db:"labelId"`
+	Name      string    `db:"name"`
+	Color     int32     `db:"color"`
+	Deleted   bool      `db:"deleted"`
+	CreatedAt time.Time `db:"createdAt"`
+	UpdatedAt time.Time `db:"updatedAt"`
+}
+
+type labelAssociationModel struct {
+	SessionID string    `db:"sessionId"`
+	LabelID   string    `db:"labelId"`
+	ChatJID   string    `db:"chatJid"`
+	MessageID string    `db:"messageId"`
+	CreatedAt time.Time `db:"createdAt"`
+}
+
+func (r *LabelRepository) List(ctx context.Context, sessionID uuid.UUID, includeDeleted bool) ([]*label.Label, error) {
+	var models []labelModel
+
+	query := `SELECT * FROM "zpLabels" WHERE "sessionId" = $1`
+	if !includeDeleted {
+		query += ` AND NOT "deleted"`
+	}
+	query += ` ORDER BY "createdAt", "labelId"`
+
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String()); err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	labels := make([]*label.Label, len(models))
+	for i := range models {
+		labels[i] = labelFromModel(sessionID, &models[i])
+	}
+
+	return labels, nil
+}
+
+func (r *LabelRepository) Get(ctx context.Context, sessionID uuid.UUID, labelID string) (*label.Label, error) {
+	var model labelModel
+	query := `SELECT * FROM "zpLabels" WHERE "sessionId" = $1 AND "labelId" = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), labelID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, label.ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("failed to get label: %w", err)
+	}
+
+	return labelFromModel(sessionID, &model), nil
+}
+
+func (r *LabelRepository) Upsert(ctx context.Context, l *label.Label) error {
+	model := labelModel{
+		SessionID: l.SessionID.String(),
+		LabelID:   l.LabelID,
+		Name:      l.Name,
+		Color:     l.Color,
+		Deleted:   l.Deleted,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpLabels" ("sessionId", "labelId", name, color, deleted, "createdAt", "updatedAt")
+		VALUES (:sessionId, :labelId, :name, :color, :deleted, :createdAt, :updatedAt)
+		ON CONFLICT ("sessionId", "labelId") DO UPDATE SET
+			name = EXCLUDED.name,
+			color = EXCLUDED.color,
+			deleted = EXCLUDED.deleted,
+			"updatedAt" = EXCLUDED."updatedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save label: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) UpsertAssociation(ctx context.Context, association *label.Association) error {
+	model := labelAssociationModel{
+		SessionID: association.SessionID.String(),
+		LabelID:   association.LabelID,
+		ChatJID:   association.ChatJID,
+		MessageID: association.MessageID,
+		CreatedAt: association.CreatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpLabelAssociations" ("sessionId", "labelId", "chatJid", "messageId", "createdAt")
+		VALUES (:sessionId, :labelId, :chatJid, :messageId, :createdAt)
+		ON CONFLICT ("sessionId", "labelId", "chatJid", "messageId") DO NOTHING
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save label association: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) DeleteAssociation(ctx context.Context, association *label.Association) error {
+	query := `
+		DELETE FROM "zpLabelAssociations"
+		WHERE "sessionId" = $1 AND "labelId" = $2 AND "chatJid" = $3 AND "messageId" = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		association.SessionID.String(), association.LabelID, association.ChatJID, association.MessageID)
+	if err != nil {
+		return fmt.Errorf("failed to delete label association: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) ListAssociations(ctx context.Context, sessionID uuid.UUID, labelID string) ([]*label.Association, error) {
+	var models []labelAssociationModel
+
+	query := `
+		SELECT * FROM "zpLabelAssociations"
+		WHERE "sessionId" = $1 AND "labelId" = $2
+		ORDER BY "createdAt" DESC, "chatJid", "messageId"
+	`
+
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), labelID); err != nil {
+		return nil, fmt.Errorf("failed to list label associations: %w", err)
+	}
+
+	associations := make([]*label.Association, len(models))
+	for i, model := range models {
+		associations[i] = &label.Association{
+			SessionID: sessionID,
+			LabelID:   model.LabelID,
+			ChatJID:   model.ChatJID,
+			MessageID: model.MessageID,
+			CreatedAt: model.CreatedAt,
+		}
+	}
+
+	return associations, nil
+}
+
+func labelFromModel(sessionID uuid.UUID, model *labelModel) *label.Label {
+	return &label.Label{
+		SessionID: sessionID,
+		LabelID:   model.LabelID,
+		Name:      model.Name,
+		Color:     model.Color,
+		Deleted:   model.Deleted,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/linktrack"
+	"zpwoot/platform/logger"
+)
+
+type LinkRepository struct {
+	db     *sqlx.DB
+	reads  ReadPool
+	logger *logger.Logger
+}
+
+func NewLinkRepository(db *sqlx.DB, reads ReadPool, logger *logger.Logger) linktrack.Repository {
+	return &LinkRepository{
+		db:     db,
+		reads:  reads,
+		logger: logger,
+	}
+}
+
+type trackedLinkModel struct {
+	Code      string    `db:"code"`
+	SessionID string    `db:"sessionId"`
+	MessageID string    `db:"messageId"`
+	ChatJID   string    `db:"chatJid"`
+	Campaign  string    `db:"campaign"`
+	URL       string    `db:"url"`
+	CreatedAt time.Time `db:"createdAt"`
+}
+
+type linkStatsModel struct {
+	trackedLinkModel
+	Clicks       int          `db:"clicks"`
+	FirstClickAt sql.NullTime `db:"firstClickAt"`
+	LastClickAt  sql.NullTime `db:"lastClickAt"`
+}
+
+type linkClickModel struct {
+	Code      string    `db:"code"`
+	MessageID string    `db:"messageId"`
+	ChatJID   string    `db:"chatJid"`
+	Campaign  string    `db:"campaign"`
+	URL       string    `db:"url"`
+	ClickedAt time.Time `db:"clickedAt"`
+	IP        string    `db:"ip"`
+	UserAgent string    `db:"userAgent"`
+}
+
+func (r *LinkRepository) CreateLinks(ctx context.Context, links []*linktrack.Link) error {
+	models := make([]trackedLinkModel, len(links))
+	for i, link := range links {
+		models[i] = trackedLinkModel{
+			Code:      link.Code,
+			SessionID: link.SessionID.String(),
+			MessageID: link.MessageID,
+			ChatJID:   link.ChatJID,
+			Campaign:  link.Campaign,
+			URL:       link.URL,
+			CreatedAt: link.CreatedAt,
+		}
+	}
+
+	query := `
+		INSERT INTO "zpTrackedLinks" (code, "sessionId", "messageId", "chatJid", campaign, url, "createdAt")
+		VALUES (:code, :sessionId, :messageId, :chatJid, :campaign, :url, :createdAt)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, models); err != nil {
+		return fmt.Errorf("failed to create tracked links: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LinkRepository) AttachMessage(ctx context.Context, sessionID uuid.UUID, codes []string, messageID string) error {
+	query := `UPDATE "zpTrackedLinks" SET "messageId" = $1 WHERE "sessionId" = $2 AND code = ANY($3)`
+
+	if _, err := r.db.ExecContext(ctx, query, messageID, sessionID.String(), pq.Array(codes)); err != nil {
+		return fmt.Errorf("failed to attach tracked links to message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LinkRepository) GetLink(ctx context.Context, code string) (*linktrack.Link, error) {
+	var model trackedLinkModel
+	query := `SELECT * FROM "zpTrackedLinks" WHERE code = $1`
+
+	if err := r.db.GetContext(ctx, &model, query, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, linktrack.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get tracked link: %w", err)
+	}
+
+	return linkFromModel(&model)
+}
+
+func (r *LinkRepository) RecordClick(ctx context.Context, click *linktrack.Click) error {
+	query := `INSERT INTO "zpLinkClicks" (code, "clickedAt", ip, "userAgent") VALUES ($1, $2, $3, $4)`
+
+	if _, err := r.db.ExecContext(ctx, query, click.Code, click.ClickedAt, click.IP, click.UserAgent); err != nil {
+		return fmt.Errorf("failed to record link click: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LinkRepository) Stats(ctx context.Context, filter *linktrack.Filter) ([]*linktrack.LinkStats, error) {
+	where, args := linkFilter(filter)
+	query := fmt.Sprintf(`
+		SELECT l.*, COUNT(c.id) AS clicks, MIN(c."clickedAt") AS "firstClickAt", MAX(c."clickedAt") AS "lastClickAt"
+		FROM "zpTrackedLinks" l
+		LEFT JOIN "zpLinkClicks" c ON c.code = l.code
+		WHERE %s
+		GROUP BY l.code
+		ORDER BY l."createdAt", l.code
+	`, where)
+
+	var models []linkStatsModel
+	if err := r.reads.Reader().SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get link stats: %w", err)
+	}
+
+	stats := make([]*linktrack.LinkStats, 0, len(models))
+	for i := range models {
+		link, err := linkFromModel(&models[i].trackedLinkModel)
+		if err != nil {
+			return nil, err
+		}
+		entry := &linktrack.LinkStats{Link: *link, Clicks: models[i].Clicks}
+		if models[i].FirstClickAt.Valid {
+			entry.FirstClickAt = &models[i].FirstClickAt.Time
+		}
+		if models[i].LastClickAt.Valid {
+			entry.LastClickAt = &models[i].LastClickAt.Time
+		}
+		stats = append(stats, entry)
+	}
+
+	return stats, nil
+}
+
+func (r *LinkRepository) ListClicks(ctx context.Context, filter *linktrack.Filter, limit int) ([]*linktrack.Click, error) {
+	where, args := linkFilter(filter)
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT c.code, l."messageId", l."chatJid", l.campaign, l.url, c."clickedAt", c.ip, c."userAgent"
+		FROM "zpLinkClicks" c
+		JOIN "zpTrackedLinks" l ON l.code = c.code
+		WHERE %s
+		ORDER BY c."clickedAt" DESC, c.id DESC
+		LIMIT $%d
+	`, where, len(args))
+
+	var models []linkClickModel
+	if err := r.reads.Reader().SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list link clicks: %w", err)
+	}
+
+	clicks := make([]*linktrack.Click, len(models))
+	for i, model := range models {
+		clicks[i] = &linktrack.Click{
+			Code:      model.Code,
+			MessageID: model.MessageID,
+			ChatJID:   model.ChatJID,
+			Campaign:  model.Campaign,
+			URL:       model.URL,
+			ClickedAt: model.ClickedAt,
+			IP:        model.IP,
+			UserAgent: model.UserAgent,
+		}
+	}
+
+	return clicks, nil
+}
+
+// linkFilter builds the conditions on the tracked links "l" a filter
+// selects.
+func linkFilter(filter *linktrack.Filter) (string, []interface{}) {
+	conditions := []string{`l."sessionId" = $1`}
+	args := []interface{}{filter.SessionID.String()}
+
+	if filter.MessageID != "" {
+		args = append(args, filter.MessageID)
+		conditions = append(conditions, fmt.Sprintf(`l."messageId" = $%d`, len(args)))
+	}
+	if filter.Campaign != "" {
+		args = append(args, filter.Campaign)
+		conditions = append(conditions, fmt.Sprintf(`l.campaign = $%d`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func linkFromModel(model *trackedLinkModel) (*linktrack.Link, error) {
+	sessionID, err := uuid.Parse(model.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID in tracked link: %w", err)
+	}
+
+	return &linktrack.Link{
+		Code:      model.Code,
+		SessionID: sessionID,
+		MessageID: model.MessageID,
+		ChatJID:   model.ChatJID,
+		Campaign:  model.Campaign,
+		URL:       model.URL,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,17 +14,23 @@ import (
 
 	"zpwoot/internal/core/messaging"
 	"zpwoot/internal/core/shared/errors"
+	"zpwoot/internal/core/shared/pagination"
 	"zpwoot/platform/logger"
 )
 
+// MessageRepository runs history, search and statistics queries on the
+// read pool, which may be a replica a little behind the primary; lookups
+// that must see a message just written stay on the primary.
 type MessageRepository struct {
 	db     *sqlx.DB
+	reads  ReadPool
 	logger *logger.Logger
 }
 
-func NewMessageRepository(db *sqlx.DB, logger *logger.Logger) messaging.Repository {
+func NewMessageRepository(db *sqlx.DB, reads ReadPool, logger *logger.Logger) messaging.Repository {
 	return &MessageRepository{
 		db:     db,
+		reads:  reads,
 		logger: logger,
 	}
 }
@@ -37,14 +45,22 @@ type messageModel struct {
 	ZpFromMe         bool           `db:"zpFromMe"`
 	ZpType           string         `db:"zpType"`
 	Content          sql.NullString `db:"content"`
+	QuotedMessageID  sql.NullString `db:"quotedMessageId"`
+	MediaMetadata    []byte         `db:"mediaMetadata"`
 	CwMessageID      sql.NullInt64  `db:"cwMessageId"`
 	CwConversationID sql.NullInt64  `db:"cwConversationId"`
 	SyncStatus       string         `db:"syncStatus"`
 	SyncedAt         pq.NullTime    `db:"syncedAt"`
+	EditedAt         pq.NullTime    `db:"editedAt"`
+	RevokedAt        pq.NullTime    `db:"revokedAt"`
 	CreatedAt        time.Time      `db:"createdAt"`
 	UpdatedAt        time.Time      `db:"updatedAt"`
 }
 
+// Create stores a message once per session. The unique index also covers
+// "zpTimestamp", which partitioning requires, so it would let a redelivery
+// with a different timestamp through; the insert instead checks for the
+// message ID under a transaction lock keyed by session and message ID.
 func (r *MessageRepository) Create(ctx context.Context, message *messaging.Message) error {
 	r.logger.DebugWithFields("Creating message", map[string]interface{}{
 		"message_id":    message.ID.String(),
@@ -54,24 +70,45 @@ func (r *MessageRepository) Create(ctx context.Context, message *messaging.Messa
 
 	model := r.messageToModel(message)
 
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, model.SessionID, model.ZpMessageID); err != nil {
+		return fmt.Errorf("failed to lock message id: %w", err)
+	}
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `
+		SELECT EXISTS (SELECT 1 FROM "zpMessage" WHERE "sessionId" = $1 AND "zpMessageId" = $2)
+	`, model.SessionID, model.ZpMessageID); err != nil {
+		return fmt.Errorf("failed to check message: %w", err)
+	}
+	if exists {
+		return errors.ErrAlreadyExists
+	}
+
 	query := `
 		INSERT INTO "zpMessage" (
 			id, "sessionId", "zpMessageId", "zpSender", "zpChat", "zpTimestamp",
-			"zpFromMe", "zpType", content, "cwMessageId", "cwConversationId",
-			"syncStatus", "syncedAt", "createdAt", "updatedAt"
+			"zpFromMe", "zpType", content, "quotedMessageId", "mediaMetadata",
+			"cwMessageId", "cwConversationId", "syncStatus", "syncedAt", "createdAt", "updatedAt"
 		) VALUES (
 			:id, :sessionId, :zpMessageId, :zpSender, :zpChat, :zpTimestamp,
-			:zpFromMe, :zpType, :content, :cwMessageId, :cwConversationId,
-			:syncStatus, :syncedAt, :createdAt, :updatedAt
+			:zpFromMe, :zpType, :content, :quotedMessageId, :mediaMetadata,
+			:cwMessageId, :cwConversationId, :syncStatus, :syncedAt, :createdAt, :updatedAt
 		)
 	`
 
-	_, err := r.db.NamedExecContext(ctx, query, model)
-	if err != nil {
+	if _, err := tx.NamedExecContext(ctx, query, model); err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505":
-				if pqErr.Constraint == "idx_zp_message_unique_zp" {
+				// The table is partitioned, so the violated index is the
+				// partition's copy of idx_zp_message_unique_zp.
+				if pqErr.Constraint == "idx_zp_message_unique_zp" || strings.Contains(pqErr.Constraint, "zpMessageId") {
 					return errors.ErrAlreadyExists
 				}
 			case "23503":
@@ -81,6 +118,10 @@ func (r *MessageRepository) Create(ctx context.Context, message *messaging.Messa
 		return fmt.Errorf("failed to create message: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit message: %w", err)
+	}
+
 	r.logger.InfoWithFields("Message created successfully", map[string]interface{}{
 		"message_id":    message.ID.String(),
 		"zp_message_id": message.ZpMessageID,
@@ -143,10 +184,14 @@ func (r *MessageRepository) Update(ctx context.Context, message *messaging.Messa
 			"zpFromMe" = :zpFromMe,
 			"zpType" = :zpType,
 			content = :content,
+			"quotedMessageId" = :quotedMessageId,
+			"mediaMetadata" = :mediaMetadata,
 			"cwMessageId" = :cwMessageId,
 			"cwConversationId" = :cwConversationId,
 			"syncStatus" = :syncStatus,
 			"syncedAt" = :syncedAt,
+			"editedAt" = :editedAt,
+			"revokedAt" = :revokedAt,
 			"updatedAt" = :updatedAt
 		WHERE id = :id
 	`
@@ -192,10 +237,10 @@ func (r *MessageRepository) List(ctx context.Context, limit, offset int) ([]*mes
 
 	query := `
 		SELECT * FROM "zpMessage" 
-		ORDER BY "zpTimestamp" DESC 
+		ORDER BY "zpTimestamp" DESC, "id" DESC
 		LIMIT $1 OFFSET $2
 	`
-	err := r.db.SelectContext(ctx, &models, query, limit, offset)
+	err := r.reads.Reader().SelectContext(ctx, &models, query, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list messages: %w", err)
 	}
@@ -218,10 +263,10 @@ func (r *MessageRepository) ListBySession(ctx context.Context, sessionID uuid.UU
 	query := `
 		SELECT * FROM "zpMessage" 
 		WHERE "sessionId" = $1 
-		ORDER BY "zpTimestamp" DESC 
+		ORDER BY "zpTimestamp" DESC, "id" DESC
 		LIMIT $2 OFFSET $3
 	`
-	err := r.db.SelectContext(ctx, &models, query, sessionID.String(), limit, offset)
+	err := r.reads.Reader().SelectContext(ctx, &models, query, sessionID.String(), limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list messages by session: %w", err)
 	}
@@ -244,10 +289,10 @@ func (r *MessageRepository) ListByChat(ctx context.Context, sessionID uuid.UUID,
 	query := `
 		SELECT * FROM "zpMessage" 
 		WHERE "sessionId" = $1 AND "zpChat" = $2 
-		ORDER BY "zpTimestamp" DESC 
+		ORDER BY "zpTimestamp" DESC, "id" DESC
 		LIMIT $3 OFFSET $4
 	`
-	err := r.db.SelectContext(ctx, &models, query, sessionID.String(), chatJID, limit, offset)
+	err := r.reads.Reader().SelectContext(ctx, &models, query, sessionID.String(), chatJID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list messages by chat: %w", err)
 	}
@@ -264,6 +309,133 @@ func (r *MessageRepository) ListByChat(ctx context.Context, sessionID uuid.UUID,
 	return messages, nil
 }
 
+// ListAfter pages through a session's messages, optionally restricted to a
+// chat, newest first using the keyset ("zpTimestamp", "id").
+func (r *MessageRepository) ListAfter(ctx context.Context, sessionID uuid.UUID, chatJID string, after *pagination.Cursor, limit int) ([]*messaging.Message, error) {
+	var models []messageModel
+
+	conditions := []string{`"sessionId" = $1`}
+	args := []interface{}{sessionID.String()}
+
+	if chatJID != "" {
+		args = append(args, chatJID)
+		conditions = append(conditions, fmt.Sprintf(`"zpChat" = $%d`, len(args)))
+	}
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`("zpTimestamp", "id") < ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM "zpMessage"
+		WHERE %s
+		ORDER BY "zpTimestamp" DESC, "id" DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	err := r.reads.Reader().SelectContext(ctx, &models, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	messages := make([]*messaging.Message, len(models))
+	for i, model := range models {
+		message, err := r.modelToMessage(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to message: %w", err)
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+type messageSearchModel struct {
+	messageModel
+	Snippet sql.NullString `db:"snippet"`
+	Rank    float64        `db:"rank"`
+}
+
+// messageSearchVector must match the expression indexed by
+// idx_zp_message_content_search, otherwise the GIN index is not used.
+const messageSearchVector = `to_tsvector('simple', coalesce(m."content", ''))`
+
+func (r *MessageRepository) Search(ctx context.Context, req *messaging.SearchMessagesRequest) ([]*messaging.SearchResult, int64, error) {
+	db := r.reads.Reader()
+
+	conditions := []string{
+		`m."sessionId" = $1`,
+		messageSearchVector + ` @@ websearch_to_tsquery('simple', $2)`,
+	}
+	args := []interface{}{req.SessionID.String(), req.Query}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if req.ChatJID != "" {
+		addCondition(`m."zpChat" = $%d`, req.ChatJID)
+	}
+	if req.Type != "" {
+		addCondition(`m."zpType" = $%d`, string(req.Type))
+	}
+	if req.Direction != "" {
+		addCondition(`m."zpFromMe" = $%d`, req.Direction == messaging.DirectionOutbound)
+	}
+	if req.From != nil {
+		addCondition(`m."zpTimestamp" >= $%d`, *req.From)
+	}
+	if req.To != nil {
+		addCondition(`m."zpTimestamp" <= $%d`, *req.To)
+	}
+
+	where := strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM "zpMessage" m WHERE ` + where
+	if err := db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	if total == 0 {
+		return []*messaging.SearchResult{}, 0, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT m.*,
+			ts_headline('simple', coalesce(m."content", ''), websearch_to_tsquery('simple', $2),
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2') AS snippet,
+			ts_rank(%s, websearch_to_tsquery('simple', $2)) AS rank
+		FROM "zpMessage" m
+		WHERE %s
+		ORDER BY rank DESC, m."zpTimestamp" DESC
+		LIMIT $%d OFFSET $%d
+	`, messageSearchVector, where, len(args)+1, len(args)+2)
+	args = append(args, req.Limit, req.Offset)
+
+	var models []messageSearchModel
+	if err := db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	results := make([]*messaging.SearchResult, len(models))
+	for i := range models {
+		message, err := r.modelToMessage(&models[i].messageModel)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to convert model to message: %w", err)
+		}
+		results[i] = &messaging.SearchResult{
+			Message: message,
+			Snippet: models[i].Snippet.String,
+			Rank:    models[i].Rank,
+		}
+	}
+
+	return results, total, nil
+}
+
 func (r *MessageRepository) GetByCwMessageID(ctx context.Context, cwMessageID int) (*messaging.Message, error) {
 	var model messageModel
 
@@ -331,6 +503,570 @@ func (r *MessageRepository) ListBySyncStatus(ctx context.Context, status messagi
 	return messages, nil
 }
 
+type reactionModel struct {
+	ID          string    `db:"id"`
+	SessionID   string    `db:"sessionId"`
+	ZpMessageID string    `db:"zpMessageId"`
+	ReactorJID  string    `db:"reactorJid"`
+	Emoji       string    `db:"emoji"`
+	FromMe      bool      `db:"fromMe"`
+	ReactedAt   time.Time `db:"reactedAt"`
+	CreatedAt   time.Time `db:"createdAt"`
+	UpdatedAt   time.Time `db:"updatedAt"`
+}
+
+func (r *MessageRepository) UpsertReaction(ctx context.Context, reaction *messaging.Reaction) error {
+	now := time.Now()
+	model := reactionModel{
+		ID:          reaction.ID.String(),
+		SessionID:   reaction.SessionID.String(),
+		ZpMessageID: reaction.ZpMessageID,
+		ReactorJID:  reaction.ReactorJID,
+		Emoji:       reaction.Emoji,
+		FromMe:      reaction.FromMe,
+		ReactedAt:   reaction.ReactedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	query := `
+		INSERT INTO "zpMessageReactions" (
+			id, "sessionId", "zpMessageId", "reactorJid", emoji, "fromMe", "reactedAt", "createdAt", "updatedAt"
+		) VALUES (
+			:id, :sessionId, :zpMessageId, :reactorJid, :emoji, :fromMe, :reactedAt, :createdAt, :updatedAt
+		)
+		ON CONFLICT ("sessionId", "zpMessageId", "reactorJid") DO UPDATE SET
+			emoji = EXCLUDED.emoji,
+			"reactedAt" = EXCLUDED."reactedAt",
+			"updatedAt" = EXCLUDED."updatedAt"
+		WHERE "zpMessageReactions"."reactedAt" <= EXCLUDED."reactedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to upsert reaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) DeleteReaction(ctx context.Context, sessionID uuid.UUID, zpMessageID, reactorJID string) error {
+	query := `DELETE FROM "zpMessageReactions" WHERE "sessionId" = $1 AND "zpMessageId" = $2 AND "reactorJid" = $3`
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), zpMessageID, reactorJID); err != nil {
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) ListReactions(ctx context.Context, sessionID uuid.UUID, zpMessageID string) ([]*messaging.Reaction, error) {
+	var models []reactionModel
+
+	query := `
+		SELECT * FROM "zpMessageReactions"
+		WHERE "sessionId" = $1 AND "zpMessageId" = $2
+		ORDER BY "reactedAt" ASC
+	`
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), zpMessageID); err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+
+	reactions := make([]*messaging.Reaction, len(models))
+	for i, model := range models {
+		id, err := uuid.Parse(model.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reaction ID: %w", err)
+		}
+		reactions[i] = &messaging.Reaction{
+			ID:          id,
+			SessionID:   sessionID,
+			ZpMessageID: model.ZpMessageID,
+			ReactorJID:  model.ReactorJID,
+			Emoji:       model.Emoji,
+			FromMe:      model.FromMe,
+			ReactedAt:   model.ReactedAt,
+		}
+	}
+
+	return reactions, nil
+}
+
+type pollModel struct {
+	ID              string         `db:"id"`
+	SessionID       string         `db:"sessionId"`
+	ZpMessageID     string         `db:"zpMessageId"`
+	ChatJID         string         `db:"chatJid"`
+	Question        string         `db:"question"`
+	Options         pq.StringArray `db:"options"`
+	SelectableCount int            `db:"selectableCount"`
+	FromMe          bool           `db:"fromMe"`
+	CreatedAt       time.Time      `db:"createdAt"`
+}
+
+type pollVoteModel struct {
+	ID          string         `db:"id"`
+	SessionID   string         `db:"sessionId"`
+	ZpMessageID string         `db:"zpMessageId"`
+	VoterJID    string         `db:"voterJid"`
+	Options     pq.StringArray `db:"options"`
+	VotedAt     time.Time      `db:"votedAt"`
+	CreatedAt   time.Time      `db:"createdAt"`
+	UpdatedAt   time.Time      `db:"updatedAt"`
+}
+
+func (r *MessageRepository) UpsertPoll(ctx context.Context, poll *messaging.Poll) error {
+	model := pollModel{
+		ID:              poll.ID.String(),
+		SessionID:       poll.SessionID.String(),
+		ZpMessageID:     poll.ZpMessageID,
+		ChatJID:         poll.ChatJID,
+		Question:        poll.Question,
+		Options:         pq.StringArray(poll.Options),
+		SelectableCount: poll.SelectableCount,
+		FromMe:          poll.FromMe,
+		CreatedAt:       poll.CreatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpPolls" (
+			id, "sessionId", "zpMessageId", "chatJid", question, options, "selectableCount", "fromMe", "createdAt"
+		) VALUES (
+			:id, :sessionId, :zpMessageId, :chatJid, :question, :options, :selectableCount, :fromMe, :createdAt
+		)
+		ON CONFLICT ("sessionId", "zpMessageId") DO NOTHING
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to upsert poll: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) GetPoll(ctx context.Context, sessionID uuid.UUID, zpMessageID string) (*messaging.Poll, error) {
+	var model pollModel
+
+	query := `SELECT * FROM "zpPolls" WHERE "sessionId" = $1 AND "zpMessageId" = $2`
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), zpMessageID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse poll ID: %w", err)
+	}
+
+	return &messaging.Poll{
+		ID:              id,
+		SessionID:       sessionID,
+		ZpMessageID:     model.ZpMessageID,
+		ChatJID:         model.ChatJID,
+		Question:        model.Question,
+		Options:         []string(model.Options),
+		SelectableCount: model.SelectableCount,
+		FromMe:          model.FromMe,
+		CreatedAt:       model.CreatedAt,
+	}, nil
+}
+
+func (r *MessageRepository) UpsertPollVote(ctx context.Context, vote *messaging.PollVote) error {
+	now := time.Now()
+	options := vote.Options
+	if options == nil {
+		options = []string{}
+	}
+	model := pollVoteModel{
+		ID:          vote.ID.String(),
+		SessionID:   vote.SessionID.String(),
+		ZpMessageID: vote.ZpMessageID,
+		VoterJID:    vote.VoterJID,
+		Options:     pq.StringArray(options),
+		VotedAt:     vote.VotedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	query := `
+		INSERT INTO "zpPollVotes" (
+			id, "sessionId", "zpMessageId", "voterJid", options, "votedAt", "createdAt", "updatedAt"
+		) VALUES (
+			:id, :sessionId, :zpMessageId, :voterJid, :options, :votedAt, :createdAt, :updatedAt
+		)
+		ON CONFLICT ("sessionId", "zpMessageId", "voterJid") DO UPDATE SET
+			options = EXCLUDED.options,
+			"votedAt" = EXCLUDED."votedAt",
+			"updatedAt" = EXCLUDED."updatedAt"
+		WHERE "zpPollVotes"."votedAt" <= EXCLUDED."votedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to upsert poll vote: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) ListPollVotes(ctx context.Context, sessionID uuid.UUID, zpMessageID string) ([]*messaging.PollVote, error) {
+	var models []pollVoteModel
+
+	query := `
+		SELECT * FROM "zpPollVotes"
+		WHERE "sessionId" = $1 AND "zpMessageId" = $2
+		ORDER BY "votedAt" ASC
+	`
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), zpMessageID); err != nil {
+		return nil, fmt.Errorf("failed to list poll votes: %w", err)
+	}
+
+	votes := make([]*messaging.PollVote, len(models))
+	for i, model := range models {
+		id, err := uuid.Parse(model.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse poll vote ID: %w", err)
+		}
+		votes[i] = &messaging.PollVote{
+			ID:          id,
+			SessionID:   sessionID,
+			ZpMessageID: model.ZpMessageID,
+			VoterJID:    model.VoterJID,
+			Options:     []string(model.Options),
+			VotedAt:     model.VotedAt,
+		}
+	}
+
+	return votes, nil
+}
+
+type liveLocationModel struct {
+	ID             string    `db:"id"`
+	SessionID      string    `db:"sessionId"`
+	ChatJID        string    `db:"chatJid"`
+	SharerJID      string    `db:"sharerJid"`
+	ZpMessageID    string    `db:"zpMessageId"`
+	Latitude       float64   `db:"latitude"`
+	Longitude      float64   `db:"longitude"`
+	AccuracyMeters int64     `db:"accuracyMeters"`
+	SpeedMps       float32   `db:"speedMps"`
+	Heading        int64     `db:"heading"`
+	Caption        string    `db:"caption"`
+	Sequence       int64     `db:"sequence"`
+	FromMe         bool      `db:"fromMe"`
+	ReportedAt     time.Time `db:"reportedAt"`
+	CreatedAt      time.Time `db:"createdAt"`
+}
+
+func (r *MessageRepository) InsertLiveLocation(ctx context.Context, point *messaging.LiveLocationPoint) error {
+	model := liveLocationModel{
+		ID:             point.ID.String(),
+		SessionID:      point.SessionID.String(),
+		ChatJID:        point.ChatJID,
+		SharerJID:      point.SharerJID,
+		ZpMessageID:    point.ZpMessageID,
+		Latitude:       point.Latitude,
+		Longitude:      point.Longitude,
+		AccuracyMeters: int64(point.AccuracyMeters),
+		SpeedMps:       point.SpeedMps,
+		Heading:        int64(point.Heading),
+		Caption:        point.Caption,
+		Sequence:       point.Sequence,
+		FromMe:         point.FromMe,
+		ReportedAt:     point.ReportedAt,
+		CreatedAt:      time.Now(),
+	}
+
+	query := `
+		INSERT INTO "zpLiveLocations" (
+			id, "sessionId", "chatJid", "sharerJid", "zpMessageId", latitude, longitude,
+			"accuracyMeters", "speedMps", heading, caption, sequence, "fromMe", "reportedAt", "createdAt"
+		) VALUES (
+			:id, :sessionId, :chatJid, :sharerJid, :zpMessageId, :latitude, :longitude,
+			:accuracyMeters, :speedMps, :heading, :caption, :sequence, :fromMe, :reportedAt, :createdAt
+		)
+		ON CONFLICT ("sessionId", "zpMessageId") DO NOTHING
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to insert live location: %w", err)
+	}
+
+	return nil
+}
+
+// ListLiveLocations returns up to perSharer positions of each sharer in the
+// chat, newest first, with the sharers ordered by their latest update.
+func (r *MessageRepository) ListLiveLocations(ctx context.Context, sessionID uuid.UUID, chatJID string, perSharer int) ([]*messaging.LiveLocationPoint, error) {
+	var models []liveLocationModel
+
+	query := `
+		SELECT id, "sessionId", "chatJid", "sharerJid", "zpMessageId", latitude, longitude,
+			"accuracyMeters", "speedMps", heading, caption, sequence, "fromMe", "reportedAt", "createdAt"
+		FROM (
+			SELECT *,
+				ROW_NUMBER() OVER (PARTITION BY "sharerJid" ORDER BY "reportedAt" DESC, sequence DESC) AS rank,
+				MAX("reportedAt") OVER (PARTITION BY "sharerJid") AS "latestAt"
+			FROM "zpLiveLocations"
+			WHERE "sessionId" = $1 AND "chatJid" = $2
+		) ranked
+		WHERE rank <= $3
+		ORDER BY "latestAt" DESC, "sharerJid", rank
+	`
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), chatJID, perSharer); err != nil {
+		return nil, fmt.Errorf("failed to list live locations: %w", err)
+	}
+
+	points := make([]*messaging.LiveLocationPoint, len(models))
+	for i, model := range models {
+		id, err := uuid.Parse(model.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse live location ID: %w", err)
+		}
+		points[i] = &messaging.LiveLocationPoint{
+			ID:             id,
+			SessionID:      sessionID,
+			ChatJID:        model.ChatJID,
+			SharerJID:      model.SharerJID,
+			ZpMessageID:    model.ZpMessageID,
+			Latitude:       model.Latitude,
+			Longitude:      model.Longitude,
+			AccuracyMeters: uint32(model.AccuracyMeters),
+			SpeedMps:       model.SpeedMps,
+			Heading:        uint32(model.Heading),
+			Caption:        model.Caption,
+			Sequence:       model.Sequence,
+			FromMe:         model.FromMe,
+			ReportedAt:     model.ReportedAt,
+		}
+	}
+
+	return points, nil
+}
+
+type starModel struct {
+	ID          string         `db:"id"`
+	SessionID   string         `db:"sessionId"`
+	ZpMessageID string         `db:"zpMessageId"`
+	ChatJID     string         `db:"chatJid"`
+	SenderJID   sql.NullString `db:"senderJid"`
+	FromMe      bool           `db:"fromMe"`
+	StarredAt   time.Time      `db:"starredAt"`
+	CreatedAt   time.Time      `db:"createdAt"`
+}
+
+func (r *MessageRepository) UpsertStar(ctx context.Context, star *messaging.StarredMessage) error {
+	model := starModel{
+		ID:          star.ID.String(),
+		SessionID:   star.SessionID.String(),
+		ZpMessageID: star.ZpMessageID,
+		ChatJID:     star.ChatJID,
+		SenderJID:   sql.NullString{String: star.SenderJID, Valid: star.SenderJID != ""},
+		FromMe:      star.FromMe,
+		StarredAt:   star.StarredAt,
+		CreatedAt:   time.Now(),
+	}
+
+	query := `
+		INSERT INTO "zpStarredMessages" (
+			id, "sessionId", "zpMessageId", "chatJid", "senderJid", "fromMe", "starredAt", "createdAt"
+		) VALUES (
+			:id, :sessionId, :zpMessageId, :chatJid, :senderJid, :fromMe, :starredAt, :createdAt
+		)
+		ON CONFLICT ("sessionId", "zpMessageId") DO NOTHING
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to upsert star: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) DeleteStar(ctx context.Context, sessionID uuid.UUID, zpMessageID string) error {
+	query := `DELETE FROM "zpStarredMessages" WHERE "sessionId" = $1 AND "zpMessageId" = $2`
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), zpMessageID); err != nil {
+		return fmt.Errorf("failed to delete star: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) ListStarred(ctx context.Context, sessionID uuid.UUID, after *pagination.Cursor, limit int) ([]*messaging.StarredMessage, error) {
+	var models []starModel
+
+	conditions := []string{`"sessionId" = $1`}
+	args := []interface{}{sessionID.String()}
+
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`("starredAt", "id") < ($%d, $%d::uuid)`, len(args)-1, len(args)))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM "zpStarredMessages"
+		WHERE %s
+		ORDER BY "starredAt" DESC, "id" DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	if err := r.reads.Reader().SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list starred messages: %w", err)
+	}
+
+	stars := make([]*messaging.StarredMessage, len(models))
+	for i, model := range models {
+		id, err := uuid.Parse(model.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse star ID: %w", err)
+		}
+		stars[i] = &messaging.StarredMessage{
+			ID:          id,
+			SessionID:   sessionID,
+			ZpMessageID: model.ZpMessageID,
+			ChatJID:     model.ChatJID,
+			SenderJID:   model.SenderJID.String,
+			FromMe:      model.FromMe,
+			StarredAt:   model.StarredAt,
+		}
+	}
+
+	return stars, nil
+}
+
+type chatModel struct {
+	SessionID     string      `db:"sessionId"`
+	ChatJID       string      `db:"chatJid"`
+	UnreadCount   int         `db:"unreadCount"`
+	LastMessageID string      `db:"lastMessageId"`
+	LastMessageAt time.Time   `db:"lastMessageAt"`
+	LastFromMe    bool        `db:"lastFromMe"`
+	LastReadAt    pq.NullTime `db:"lastReadAt"`
+	UpdatedAt     time.Time   `db:"updatedAt"`
+}
+
+// RecordChatMessage moves the chat's last message forward and counts messages
+// from contacts as unread. Messages older than the last read, delivered late,
+// are not counted.
+func (r *MessageRepository) RecordChatMessage(ctx context.Context, message *messaging.Message) error {
+	unread := 1
+	if message.ZpFromMe {
+		unread = 0
+	}
+
+	query := `
+		INSERT INTO "zpChats" (
+			"sessionId", "chatJid", "unreadCount", "lastMessageId", "lastMessageAt", "lastFromMe", "updatedAt"
+		) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT ("sessionId", "chatJid") DO UPDATE SET
+			"unreadCount" = CASE
+				WHEN "zpChats"."lastReadAt" IS NULL OR EXCLUDED."lastMessageAt" > "zpChats"."lastReadAt"
+				THEN "zpChats"."unreadCount" + EXCLUDED."unreadCount"
+				ELSE "zpChats"."unreadCount"
+			END,
+			"lastMessageId" = CASE
+				WHEN EXCLUDED."lastMessageAt" >= "zpChats"."lastMessageAt" THEN EXCLUDED."lastMessageId"
+				ELSE "zpChats"."lastMessageId"
+			END,
+			"lastFromMe" = CASE
+				WHEN EXCLUDED."lastMessageAt" >= "zpChats"."lastMessageAt" THEN EXCLUDED."lastFromMe"
+				ELSE "zpChats"."lastFromMe"
+			END,
+			"lastMessageAt" = GREATEST("zpChats"."lastMessageAt", EXCLUDED."lastMessageAt"),
+			"updatedAt" = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, message.SessionID.String(), message.ZpChat, unread,
+		message.ZpMessageID, message.ZpTimestamp, message.ZpFromMe)
+	if err != nil {
+		return fmt.Errorf("failed to record chat message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) MarkChatRead(ctx context.Context, sessionID uuid.UUID, chatJID string, at time.Time) error {
+	query := `
+		UPDATE "zpChats"
+		SET "unreadCount" = 0, "lastReadAt" = GREATEST(COALESCE("lastReadAt", $3), $3), "updatedAt" = NOW()
+		WHERE "sessionId" = $1 AND "chatJid" = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), chatJID, at); err != nil {
+		return fmt.Errorf("failed to mark chat as read: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MessageRepository) ListChats(ctx context.Context, sessionID uuid.UUID, sort messaging.ChatSort, limit, offset int) ([]*messaging.Chat, error) {
+	var models []chatModel
+
+	order := `"lastMessageAt" DESC, "chatJid"`
+	if sort == messaging.ChatSortUnread {
+		order = `"unreadCount" DESC, ` + order
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM "zpChats"
+		WHERE "sessionId" = $1
+		ORDER BY %s
+		LIMIT $2 OFFSET $3
+	`, order)
+
+	if err := r.reads.Reader().SelectContext(ctx, &models, query, sessionID.String(), limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list chats: %w", err)
+	}
+
+	chats := make([]*messaging.Chat, len(models))
+	for i, model := range models {
+		chats[i] = &messaging.Chat{
+			SessionID:     sessionID,
+			ChatJID:       model.ChatJID,
+			UnreadCount:   model.UnreadCount,
+			LastMessageID: model.LastMessageID,
+			LastMessageAt: model.LastMessageAt,
+			LastFromMe:    model.LastFromMe,
+		}
+		if model.LastReadAt.Valid {
+			readAt := model.LastReadAt.Time
+			chats[i].LastReadAt = &readAt
+		}
+	}
+
+	return chats, nil
+}
+
+func (r *MessageRepository) CountChats(ctx context.Context, sessionID uuid.UUID) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM "zpChats" WHERE "sessionId" = $1`
+	if err := r.reads.Reader().GetContext(ctx, &count, query, sessionID.String()); err != nil {
+		return 0, fmt.Errorf("failed to count chats: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *MessageRepository) ListByZpMessageIDs(ctx context.Context, sessionID uuid.UUID, zpMessageIDs []string) ([]*messaging.Message, error) {
+	var models []messageModel
+
+	query := `SELECT * FROM "zpMessage" WHERE "sessionId" = $1 AND "zpMessageId" = ANY($2)`
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), pq.Array(zpMessageIDs)); err != nil {
+		return nil, fmt.Errorf("failed to list messages by zp message ID: %w", err)
+	}
+
+	messages := make([]*messaging.Message, len(models))
+	for i, model := range models {
+		message, err := r.modelToMessage(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to message: %w", err)
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
 func (r *MessageRepository) UpdateSyncStatus(ctx context.Context, id uuid.UUID, status messaging.SyncStatus, cwMessageID, cwConversationID *int) error {
 	now := time.Now()
 
@@ -479,7 +1215,7 @@ func (r *MessageRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
 
 	query := `SELECT COUNT(*) FROM "zpMessage"`
-	err := r.db.GetContext(ctx, &count, query)
+	err := r.reads.Reader().GetContext(ctx, &count, query)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages: %w", err)
 	}
@@ -491,7 +1227,7 @@ func (r *MessageRepository) CountBySession(ctx context.Context, sessionID uuid.U
 	var count int64
 
 	query := `SELECT COUNT(*) FROM "zpMessage" WHERE "sessionId" = $1`
-	err := r.db.GetContext(ctx, &count, query, sessionID.String())
+	err := r.reads.Reader().GetContext(ctx, &count, query, sessionID.String())
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages by session: %w", err)
 	}
@@ -503,7 +1239,7 @@ func (r *MessageRepository) CountByChat(ctx context.Context, sessionID uuid.UUID
 	var count int64
 
 	query := `SELECT COUNT(*) FROM "zpMessage" WHERE "sessionId" = $1 AND "zpChat" = $2`
-	err := r.db.GetContext(ctx, &count, query, sessionID.String(), chatJID)
+	err := r.reads.Reader().GetContext(ctx, &count, query, sessionID.String(), chatJID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages by chat: %w", err)
 	}
@@ -515,7 +1251,7 @@ func (r *MessageRepository) CountBySyncStatus(ctx context.Context, status messag
 	var count int64
 
 	query := `SELECT COUNT(*) FROM "zpMessage" WHERE "syncStatus" = $1`
-	err := r.db.GetContext(ctx, &count, query, string(status))
+	err := r.reads.Reader().GetContext(ctx, &count, query, string(status))
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages by sync status: %w", err)
 	}
@@ -527,7 +1263,7 @@ func (r *MessageRepository) CountByType(ctx context.Context, messageType messagi
 	var count int64
 
 	query := `SELECT COUNT(*) FROM "zpMessage" WHERE "zpType" = $1`
-	err := r.db.GetContext(ctx, &count, query, string(messageType))
+	err := r.reads.Reader().GetContext(ctx, &count, query, string(messageType))
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages by type: %w", err)
 	}
@@ -536,6 +1272,8 @@ func (r *MessageRepository) CountByType(ctx context.Context, messageType messagi
 }
 
 func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageStats, error) {
+	db := r.reads.Reader()
+
 	stats := &messaging.MessageStats{
 		MessagesByType:   make(map[string]int64),
 		MessagesByStatus: make(map[string]int64),
@@ -552,7 +1290,7 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 		FROM "zpMessage"
 		GROUP BY "zpType"
 	`
-	typeRows, err := r.db.QueryContext(ctx, typeQuery)
+	typeRows, err := db.QueryContext(ctx, typeQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by type: %w", err)
 	}
@@ -572,7 +1310,7 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 		FROM "zpMessage"
 		GROUP BY "syncStatus"
 	`
-	statusRows, err := r.db.QueryContext(ctx, statusQuery)
+	statusRows, err := db.QueryContext(ctx, statusQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by status: %w", err)
 	}
@@ -603,7 +1341,7 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 
 	var todayCount int64
 	todayQuery := `SELECT COUNT(*) FROM "zpMessage" WHERE "createdAt" >= $1`
-	err = r.db.GetContext(ctx, &todayCount, todayQuery, today)
+	err = db.GetContext(ctx, &todayCount, todayQuery, today)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get today count: %w", err)
 	}
@@ -611,7 +1349,7 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 
 	var weekCount int64
 	weekQuery := `SELECT COUNT(*) FROM "zpMessage" WHERE "createdAt" >= $1`
-	err = r.db.GetContext(ctx, &weekCount, weekQuery, weekStart)
+	err = db.GetContext(ctx, &weekCount, weekQuery, weekStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get week count: %w", err)
 	}
@@ -619,7 +1357,7 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 
 	var monthCount int64
 	monthQuery := `SELECT COUNT(*) FROM "zpMessage" WHERE "createdAt" >= $1`
-	err = r.db.GetContext(ctx, &monthCount, monthQuery, monthStart)
+	err = db.GetContext(ctx, &monthCount, monthQuery, monthStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get month count: %w", err)
 	}
@@ -629,6 +1367,8 @@ func (r *MessageRepository) GetStats(ctx context.Context) (*messaging.MessageSta
 }
 
 func (r *MessageRepository) GetStatsBySession(ctx context.Context, sessionID uuid.UUID) (*messaging.MessageStats, error) {
+	db := r.reads.Reader()
+
 	stats := &messaging.MessageStats{
 		MessagesByType:   make(map[string]int64),
 		MessagesByStatus: make(map[string]int64),
@@ -648,7 +1388,7 @@ func (r *MessageRepository) GetStatsBySession(ctx context.Context, sessionID uui
 		WHERE "sessionId" = $1
 		GROUP BY "zpType"
 	`
-	typeRows, err := r.db.QueryContext(ctx, typeQuery, sessionIDStr)
+	typeRows, err := db.QueryContext(ctx, typeQuery, sessionIDStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by type for session: %w", err)
 	}
@@ -669,7 +1409,7 @@ func (r *MessageRepository) GetStatsBySession(ctx context.Context, sessionID uui
 		WHERE "sessionId" = $1
 		GROUP BY "syncStatus"
 	`
-	statusRows, err := r.db.QueryContext(ctx, statusQuery, sessionIDStr)
+	statusRows, err := db.QueryContext(ctx, statusQuery, sessionIDStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by status for session: %w", err)
 	}
@@ -697,6 +1437,8 @@ func (r *MessageRepository) GetStatsBySession(ctx context.Context, sessionID uui
 }
 
 func (r *MessageRepository) GetStatsForPeriod(ctx context.Context, sessionID uuid.UUID, from, to int64) (*messaging.MessageStats, error) {
+	db := r.reads.Reader()
+
 	stats := &messaging.MessageStats{
 		MessagesByType:   make(map[string]int64),
 		MessagesByStatus: make(map[string]int64),
@@ -711,7 +1453,7 @@ func (r *MessageRepository) GetStatsForPeriod(ctx context.Context, sessionID uui
 		SELECT COUNT(*) FROM "zpMessage"
 		WHERE "sessionId" = $1 AND "createdAt" BETWEEN $2 AND $3
 	`
-	err := r.db.GetContext(ctx, &totalCount, totalQuery, sessionIDStr, fromTime, toTime)
+	err := db.GetContext(ctx, &totalCount, totalQuery, sessionIDStr, fromTime, toTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total count for period: %w", err)
 	}
@@ -723,7 +1465,7 @@ func (r *MessageRepository) GetStatsForPeriod(ctx context.Context, sessionID uui
 		WHERE "sessionId" = $1 AND "createdAt" BETWEEN $2 AND $3
 		GROUP BY "zpType"
 	`
-	typeRows, err := r.db.QueryContext(ctx, typeQuery, sessionIDStr, fromTime, toTime)
+	typeRows, err := db.QueryContext(ctx, typeQuery, sessionIDStr, fromTime, toTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by type for period: %w", err)
 	}
@@ -744,7 +1486,7 @@ func (r *MessageRepository) GetStatsForPeriod(ctx context.Context, sessionID uui
 		WHERE "sessionId" = $1 AND "createdAt" BETWEEN $2 AND $3
 		GROUP BY "syncStatus"
 	`
-	statusRows, err := r.db.QueryContext(ctx, statusQuery, sessionIDStr, fromTime, toTime)
+	statusRows, err := db.QueryContext(ctx, statusQuery, sessionIDStr, fromTime, toTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get messages by status for period: %w", err)
 	}
@@ -839,6 +1581,16 @@ func (r *MessageRepository) messageToModel(message *messaging.Message) *messageM
 		model.Content = sql.NullString{String: message.Content, Valid: true}
 	}
 
+	if message.QuotedMessageID != "" {
+		model.QuotedMessageID = sql.NullString{String: message.QuotedMessageID, Valid: true}
+	}
+
+	if message.Media != nil {
+		if data, err := json.Marshal(message.Media); err == nil {
+			model.MediaMetadata = data
+		}
+	}
+
 	if message.CwMessageID != nil {
 		model.CwMessageID = sql.NullInt64{Int64: int64(*message.CwMessageID), Valid: true}
 	}
@@ -851,6 +1603,14 @@ func (r *MessageRepository) messageToModel(message *messaging.Message) *messageM
 		model.SyncedAt = pq.NullTime{Time: *message.SyncedAt, Valid: true}
 	}
 
+	if message.EditedAt != nil {
+		model.EditedAt = pq.NullTime{Time: *message.EditedAt, Valid: true}
+	}
+
+	if message.RevokedAt != nil {
+		model.RevokedAt = pq.NullTime{Time: *message.RevokedAt, Valid: true}
+	}
+
 	return model
 }
 
@@ -884,6 +1644,18 @@ func (r *MessageRepository) modelToMessage(model *messageModel) (*messaging.Mess
 		message.Content = model.Content.String
 	}
 
+	if model.QuotedMessageID.Valid {
+		message.QuotedMessageID = model.QuotedMessageID.String
+	}
+
+	if len(model.MediaMetadata) > 0 {
+		var media messaging.MediaMetadata
+		if err := json.Unmarshal(model.MediaMetadata, &media); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal media metadata: %w", err)
+		}
+		message.Media = &media
+	}
+
 	if model.CwMessageID.Valid {
 		cwMessageID := int(model.CwMessageID.Int64)
 		message.CwMessageID = &cwMessageID
@@ -898,5 +1670,13 @@ func (r *MessageRepository) modelToMessage(model *messageModel) (*messaging.Mess
 		message.SyncedAt = &model.SyncedAt.Time
 	}
 
+	if model.EditedAt.Valid {
+		message.EditedAt = &model.EditedAt.Time
+	}
+
+	if model.RevokedAt.Valid {
+		message.RevokedAt = &model.RevokedAt.Time
+	}
+
 	return message, nil
 }
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+// partitionNamePattern matches the monthly partitions created by
+// zp_ensure_message_partitions; the default partition never matches.
+var partitionNamePattern = regexp.MustCompile(`^zpMessage_(\d{4})_(\d{2})$`)
+
+type MessageRetentionRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewMessageRetentionRepository(db *sqlx.DB, logger *logger.Logger) messaging.RetentionRepository {
+	return &MessageRetentionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *MessageRetentionRepository) EnsurePartitions(ctx context.Context, from time.Time, months int) (int, error) {
+	var created int
+	month := from.UTC().Format("2006-01-02")
+	if err := r.db.GetContext(ctx, &created, `SELECT zp_ensure_message_partitions($1::date, $2)`, month, months); err != nil {
+		return 0, fmt.Errorf("failed to create message partitions: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *MessageRetentionRepository) ListPartitions(ctx context.Context) ([]messaging.Partition, error) {
+	query := `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = '"zpMessage"'::regclass
+		ORDER BY c.relname
+	`
+
+	var names []string
+	if err := r.db.SelectContext(ctx, &names, query); err != nil {
+		return nil, fmt.Errorf("failed to list message partitions: %w", err)
+	}
+
+	partitions := make([]messaging.Partition, 0, len(names))
+	for _, name := range names {
+		match := partitionNamePattern.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+
+		from, err := time.Parse("2006_01", match[1]+"_"+match[2])
+		if err != nil {
+			continue
+		}
+
+		partitions = append(partitions, messaging.Partition{
+			Name: name,
+			From: from,
+			To:   from.AddDate(0, 1, 0),
+		})
+	}
+
+	return partitions, nil
+}
+
+func (r *MessageRetentionRepository) DropPartition(ctx context.Context, name string) ([]string, error) {
+	if !partitionNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid message partition name %q", name)
+	}
+	table := pq.QuoteIdentifier(name)
+
+	var paths []string
+	query := fmt.Sprintf(`
+		SELECT "mediaMetadata"->>'local_path'
+		FROM %s
+		WHERE "mediaMetadata"->>'local_path' IS NOT NULL
+	`, table)
+	if err := r.db.SelectContext(ctx, &paths, query); err != nil {
+		return nil, fmt.Errorf("failed to list media of partition %s: %w", name, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(`DROP TABLE %s`, table)); err != nil {
+		return nil, fmt.Errorf("failed to drop partition %s: %w", name, err)
+	}
+
+	r.logger.InfoWithFields("Message partition dropped", map[string]interface{}{
+		"partition": name,
+		"media":     len(paths),
+	})
+
+	return paths, nil
+}
+
+func (r *MessageRetentionRepository) PurgeBefore(ctx context.Context, cutoff time.Time, filter messaging.PurgeFilter, limit int) (int64, []string, error) {
+	var (
+		scope string
+		arg   interface{}
+	)
+	switch {
+	case filter.SessionID != nil:
+		scope = `"sessionId" = $2`
+		arg = filter.SessionID.String()
+	case len(filter.Exclude) > 0:
+		scope = `NOT ("sessionId" = ANY($2::uuid[]))`
+		arg = pq.Array(uuidStrings(filter.Exclude))
+	default:
+		scope = `$2::boolean`
+		arg = true
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM "zpMessage"
+		WHERE ("id", "zpTimestamp") IN (
+			SELECT "id", "zpTimestamp"
+			FROM "zpMessage"
+			WHERE "zpTimestamp" < $1 AND %s
+			LIMIT $3
+		)
+		RETURNING "mediaMetadata"->>'local_path'
+	`, scope)
+
+	rows, err := r.db.QueryContext(ctx, query, cutoff, arg, limit)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to purge messages: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		deleted int64
+		paths   []string
+	)
+	for rows.Next() {
+		var path sql.NullString
+		if err := rows.Scan(&path); err != nil {
+			return deleted, paths, fmt.Errorf("failed to purge messages: %w", err)
+		}
+		deleted++
+		if path.Valid && path.String != "" {
+			paths = append(paths, path.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return deleted, paths, fmt.Errorf("failed to purge messages: %w", err)
+	}
+
+	return deleted, paths, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = id.String()
+	}
+	return out
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/note"
+	"zpwoot/platform/logger"
+)
+
+type NoteRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewNoteRepository(db *sqlx.DB, logger *logger.Logger) note.Repository {
+	return &NoteRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type noteModel struct {
+	ID        string    `db:"id"`
+	SessionID string    `db:"sessionId"`
+	ChatJID   string    `db:"chatJid"`
+	Kind      string    `db:"kind"`
+	Author    string    `db:"author"`
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"createdAt"`
+	UpdatedAt time.Time `db:"updatedAt"`
+}
+
+func (r *NoteRepository) Create(ctx context.Context, n *note.Note) error {
+	query := `
+		INSERT INTO "zpChatNotes" (id, "sessionId", "chatJid", kind, author, body, "createdAt", "updatedAt")
+		VALUES (:id, :sessionId, :chatJid, :kind, :author, :body, :createdAt, :updatedAt)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, noteToModel(n)); err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) Get(ctx context.Context, sessionID, id uuid.UUID) (*note.Note, error) {
+	var model noteModel
+	query := `SELECT * FROM "zpChatNotes" WHERE "sessionId" = $1 AND id = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, note.ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	return noteFromModel(sessionID, &model)
+}
+
+func (r *NoteRepository) Update(ctx context.Context, n *note.Note) error {
+	query := `
+		UPDATE "zpChatNotes"
+		SET kind = :kind, body = :body, "updatedAt" = :updatedAt
+		WHERE "sessionId" = :sessionId AND id = :id
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, noteToModel(n))
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return note.ErrNoteNotFound
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) Delete(ctx context.Context, sessionID, id uuid.UUID) error {
+	query := `DELETE FROM "zpChatNotes" WHERE "sessionId" = $1 AND id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return note.ErrNoteNotFound
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) List(ctx context.Context, filter *note.ListFilter) ([]*note.Note, error) {
+	conditions := []string{`"sessionId" = $1`, `"chatJid" = $2`}
+	args := []interface{}{filter.SessionID.String(), filter.ChatJID}
+
+	if filter.Kind != "" {
+		args = append(args, string(filter.Kind))
+		conditions = append(conditions, fmt.Sprintf(`kind = $%d`, len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf(`"createdAt" >= $%d`, len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf(`"createdAt" < $%d`, len(args)))
+	}
+
+	query := `SELECT * FROM "zpChatNotes" WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY "createdAt" DESC, id DESC`
+
+	var models []noteModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	notes := make([]*note.Note, len(models))
+	for i := range models {
+		n, err := noteFromModel(filter.SessionID, &models[i])
+		if err != nil {
+			return nil, err
+		}
+		notes[i] = n
+	}
+
+	return notes, nil
+}
+
+func noteToModel(n *note.Note) noteModel {
+	return noteModel{
+		ID:        n.ID.String(),
+		SessionID: n.SessionID.String(),
+		ChatJID:   n.ChatJID,
+		Kind:      string(n.Kind),
+		Author:    n.Author,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+func noteFromModel(sessionID uuid.UUID, model *noteModel) (*note.Note, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid note ID: %w", err)
+	}
+
+	return &note.Note{
+		ID:        id,
+		SessionID: sessionID,
+		ChatJID:   model.ChatJID,
+		Kind:      note.Kind(model.Kind),
+		Author:    model.Author,
+		Body:      model.Body,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
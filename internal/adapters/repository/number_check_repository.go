@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/contact"
+	"zpwoot/platform/logger"
+)
+
+type NumberCheckRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewNumberCheckRepository(db *sqlx.DB, logger *logger.Logger) contact.NumberCheckRepository {
+	return &NumberCheckRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type numberCheckModel struct {
+	Phone      string    `db:"phone"`
+	JID        string    `db:"jid"`
+	OnWhatsApp bool      `db:"onWhatsApp"`
+	CheckedAt  time.Time `db:"checkedAt"`
+}
+
+func (r *NumberCheckRepository) GetMany(ctx context.Context, phones []string, since time.Time) (map[string]*contact.NumberCheck, error) {
+	checks := make(map[string]*contact.NumberCheck, len(phones))
+	if len(phones) == 0 {
+		return checks, nil
+	}
+
+	var models []numberCheckModel
+	query := `SELECT * FROM "zpNumberChecks" WHERE "phone" = ANY($1) AND "checkedAt" >= $2`
+
+	if err := r.db.SelectContext(ctx, &models, query, pq.Array(phones), since); err != nil {
+		return nil, fmt.Errorf("failed to get number checks: %w", err)
+	}
+
+	for _, model := range models {
+		checks[model.Phone] = &contact.NumberCheck{
+			Phone:      model.Phone,
+			JID:        model.JID,
+			OnWhatsApp: model.OnWhatsApp,
+			CheckedAt:  model.CheckedAt,
+		}
+	}
+
+	return checks, nil
+}
+
+func (r *NumberCheckRepository) SaveMany(ctx context.Context, checks []*contact.NumberCheck) error {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	phones := make([]string, len(checks))
+	jids := make([]string, len(checks))
+	registered := make([]bool, len(checks))
+	checkedAt := make([]time.Time, len(checks))
+	for i, check := range checks {
+		phones[i] = check.Phone
+		jids[i] = check.JID
+		registered[i] = check.OnWhatsApp
+		checkedAt[i] = check.CheckedAt
+	}
+
+	query := `
+		INSERT INTO "zpNumberChecks" ("phone", "jid", "onWhatsApp", "checkedAt")
+		SELECT * FROM UNNEST($1::varchar[], $2::varchar[], $3::boolean[], $4::timestamptz[])
+		ON CONFLICT ("phone") DO UPDATE
+		SET "jid" = EXCLUDED."jid", "onWhatsApp" = EXCLUDED."onWhatsApp", "checkedAt" = EXCLUDED."checkedAt"
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(phones), pq.Array(jids), pq.Array(registered), pq.Array(checkedAt)); err != nil {
+		return fmt.Errorf("failed to save number checks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/jmoiron/sqlx"
+
+// ReadPool hands out the connection pool heavy read-only queries run on: a
+// read replica while one is configured and healthy, the primary otherwise.
+type ReadPool interface {
+	Reader() *sqlx.DB
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/internal/core/contact"
+	"zpwoot/platform/logger"
+)
+
+type RegistryRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewRegistryRepository(db *sqlx.DB, logger *logger.Logger) contact.RegistryRepository {
+	return &RegistryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type registryEntryModel struct {
+	ID           string    `db:"id"`
+	JID          string    `db:"jid"`
+	PhoneNumber  string    `db:"phoneNumber"`
+	Name         string    `db:"name"`
+	PushName     string    `db:"pushName"`
+	BusinessName string    `db:"businessName"`
+	CreatedAt    time.Time `db:"createdAt"`
+	UpdatedAt    time.Time `db:"updatedAt"`
+}
+
+type registryOverlayModel struct {
+	ContactID       string       `db:"contactId"`
+	SessionID       string       `db:"sessionId"`
+	SessionName     string       `db:"sessionName"`
+	Name            string       `db:"name"`
+	PushName        string       `db:"pushName"`
+	BusinessName    string       `db:"businessName"`
+	IsContact       bool         `db:"isContact"`
+	AvatarPictureID string       `db:"avatarPictureId"`
+	AvatarURL       string       `db:"avatarUrl"`
+	AvatarCheckedAt sql.NullTime `db:"avatarCheckedAt"`
+	FirstSeenAt     time.Time    `db:"firstSeenAt"`
+	LastSeenAt      time.Time    `db:"lastSeenAt"`
+}
+
+// Record upserts the canonical entry and the session's overlay in one
+// statement. Empty values never overwrite known ones, except the address
+// book name of a sighting read from the address book. xmax is zero only for
+// rows the statement inserted.
+func (r *RegistryRepository) Record(ctx context.Context, sighting *contact.Sighting) (bool, error) {
+	phone, _, _ := strings.Cut(sighting.JID, "@")
+
+	query := `
+		WITH entry AS (
+			INSERT INTO "zpContacts" ("id", "jid", "phoneNumber", "name", "pushName", "businessName", "createdAt", "updatedAt")
+			VALUES ($1, $2, $3, $4, $5, $6, $8, $8)
+			ON CONFLICT ("jid") DO UPDATE
+			SET "name" = COALESCE(NULLIF(EXCLUDED."name", ''), "zpContacts"."name"),
+				"pushName" = COALESCE(NULLIF(EXCLUDED."pushName", ''), "zpContacts"."pushName"),
+				"businessName" = COALESCE(NULLIF(EXCLUDED."businessName", ''), "zpContacts"."businessName"),
+				"updatedAt" = GREATEST("zpContacts"."updatedAt", EXCLUDED."updatedAt")
+			RETURNING "id"
+		)
+		INSERT INTO "zpContactSessions" ("contactId", "sessionId", "name", "pushName", "businessName", "isContact", "firstSeenAt", "lastSeenAt")
+		SELECT "id", $7::uuid, $4, $5, $6, $9::boolean AND $4 <> '', $8, $8 FROM entry
+		ON CONFLICT ("contactId", "sessionId") DO UPDATE
+		SET "name" = CASE WHEN $9 THEN EXCLUDED."name" ELSE COALESCE(NULLIF(EXCLUDED."name", ''), "zpContactSessions"."name") END,
+			"pushName" = COALESCE(NULLIF(EXCLUDED."pushName", ''), "zpContactSessions"."pushName"),
+			"businessName" = COALESCE(NULLIF(EXCLUDED."businessName", ''), "zpContactSessions"."businessName"),
+			"isContact" = CASE WHEN $9 THEN EXCLUDED."isContact" ELSE "zpContactSessions"."isContact" END,
+			"lastSeenAt" = GREATEST("zpContactSessions"."lastSeenAt", EXCLUDED."lastSeenAt")
+		RETURNING (xmax = 0) AS "created"
+	`
+
+	var created bool
+	err := r.db.QueryRowxContext(ctx, query,
+		uuid.New().String(), sighting.JID, phone,
+		sighting.Name, sighting.PushName, sighting.BusinessName,
+		sighting.SessionID.String(), sighting.SeenAt, sighting.FromAddressBook,
+	).Scan(&created)
+	if err != nil {
+		return false, fmt.Errorf("failed to record registry contact: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *RegistryRepository) Get(ctx context.Context, id uuid.UUID) (*contact.RegistryEntry, error) {
+	return r.getOne(ctx, `SELECT * FROM "zpContacts" WHERE "id" = $1`, id.String())
+}
+
+func (r *RegistryRepository) GetByJID(ctx context.Context, jid string) (*contact.RegistryEntry, error) {
+	return r.getOne(ctx, `SELECT * FROM "zpContacts" WHERE "jid" = $1`, jid)
+}
+
+func (r *RegistryRepository) getOne(ctx context.Context, query string, arg interface{}) (*contact.RegistryEntry, error) {
+	var model registryEntryModel
+	if err := r.db.GetContext(ctx, &model, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, contact.ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get registry contact: %w", err)
+	}
+
+	entries, err := r.withOverlays(ctx, []registryEntryModel{model}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+func (r *RegistryRepository) List(ctx context.Context, filter *contact.RegistryFilter) ([]*contact.RegistryEntry, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+
+	if filter.SessionID != nil {
+		args = append(args, filter.SessionID.String())
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (SELECT 1 FROM "zpContactSessions" s WHERE s."contactId" = c."id" AND s."sessionId" = $%d)`, len(args)))
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+search+"%")
+		conditions = append(conditions, fmt.Sprintf(`(c."name" ILIKE $%[1]d OR c."pushName" ILIKE $%[1]d OR c."businessName" ILIKE $%[1]d OR c."phoneNumber" LIKE $%[1]d)`, len(args)))
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.Key)
+		conditions = append(conditions, fmt.Sprintf(`c."jid" > $%d`, len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT c.* FROM "zpContacts" c
+		WHERE %s
+		ORDER BY c."jid"
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	var models []registryEntryModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list registry contacts: %w", err)
+	}
+
+	return r.withOverlays(ctx, models, filter.SessionID)
+}
+
+// withOverlays loads the session overlays of the entries, only the given
+// session's when sessionID is set, along with the avatar each session saw.
+func (r *RegistryRepository) withOverlays(ctx context.Context, models []registryEntryModel, sessionID *uuid.UUID) ([]*contact.RegistryEntry, error) {
+	entries := make([]*contact.RegistryEntry, len(models))
+	byID := make(map[string]*contact.RegistryEntry, len(models))
+	ids := make([]string, len(models))
+	for i, model := range models {
+		id, _ := uuid.Parse(model.ID)
+		entries[i] = &contact.RegistryEntry{
+			ID:           id,
+			JID:          model.JID,
+			PhoneNumber:  model.PhoneNumber,
+			Name:         model.Name,
+			PushName:     model.PushName,
+			BusinessName: model.BusinessName,
+			Sessions:     []*contact.RegistryOverlay{},
+			CreatedAt:    model.CreatedAt,
+			UpdatedAt:    model.UpdatedAt,
+		}
+		byID[model.ID] = entries[i]
+		ids[i] = model.ID
+	}
+	if len(models) == 0 {
+		return entries, nil
+	}
+
+	query := `
+		SELECT o."contactId", o."sessionId", s."name" AS "sessionName",
+			o."name", o."pushName", o."businessName", o."isContact",
+			COALESCE(a."pictureId", '') AS "avatarPictureId", COALESCE(a."url", '') AS "avatarUrl",
+			a."checkedAt" AS "avatarCheckedAt", o."firstSeenAt", o."lastSeenAt"
+		FROM "zpContactSessions" o
+		JOIN "zpContacts" c ON c."id" = o."contactId"
+		JOIN "zpSessions" s ON s."id" = o."sessionId"
+		LEFT JOIN "zpContactAvatars" a ON a."sessionId" = o."sessionId" AND a."jid" = c."jid"
+		WHERE o."contactId" = ANY($1::uuid[]) AND ($2::uuid IS NULL OR o."sessionId" = $2::uuid)
+		ORDER BY o."lastSeenAt" DESC
+	`
+
+	var session interface{}
+	if sessionID != nil {
+		session = sessionID.String()
+	}
+
+	var overlays []registryOverlayModel
+	if err := r.db.SelectContext(ctx, &overlays, query, pq.Array(ids), session); err != nil {
+		return nil, fmt.Errorf("failed to get registry contact sessions: %w", err)
+	}
+
+	for _, model := range overlays {
+		entry := byID[model.ContactID]
+		if entry == nil {
+			continue
+		}
+		sessionUUID, _ := uuid.Parse(model.SessionID)
+		overlay := &contact.RegistryOverlay{
+			SessionID:       sessionUUID,
+			SessionName:     model.SessionName,
+			Name:            model.Name,
+			PushName:        model.PushName,
+			BusinessName:    model.BusinessName,
+			IsContact:       model.IsContact,
+			AvatarPictureID: model.AvatarPictureID,
+			AvatarURL:       model.AvatarURL,
+			FirstSeenAt:     model.FirstSeenAt,
+			LastSeenAt:      model.LastSeenAt,
+		}
+		if model.AvatarCheckedAt.Valid {
+			checkedAt := model.AvatarCheckedAt.Time
+			overlay.AvatarCheckedAt = &checkedAt
+		}
+		entry.Sessions = append(entry.Sessions, overlay)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/schedule"
+	"zpwoot/platform/logger"
+)
+
+type ScheduleRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewScheduleRepository(db *sqlx.DB, logger *logger.Logger) schedule.Repository {
+	return &ScheduleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type scheduledMessageModel struct {
+	ID        string    `db:"id"`
+	SessionID string    `db:"sessionId"`
+	Kind      string    `db:"kind"`
+	Payload   []byte    `db:"payload"`
+	SendAt    time.Time `db:"sendAt"`
+	Reason    string    `db:"reason"`
+	Status    string    `db:"status"`
+	Attempts  int       `db:"attempts"`
+	LastError string    `db:"lastError"`
+	MessageID string    `db:"messageId"`
+	CreatedAt time.Time `db:"createdAt"`
+	UpdatedAt time.Time `db:"updatedAt"`
+}
+
+func (r *ScheduleRepository) Create(ctx context.Context, message *schedule.Message) error {
+	model := scheduledMessageModel{
+		ID:        message.ID.String(),
+		SessionID: message.SessionID.String(),
+		Kind:      message.Kind,
+		Payload:   message.Payload,
+		SendAt:    message.SendAt,
+		Reason:    message.Reason,
+		Status:    string(message.Status),
+		Attempts:  message.Attempts,
+		LastError: message.LastError,
+		MessageID: message.MessageID,
+		CreatedAt: message.CreatedAt,
+		UpdatedAt: message.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpScheduledMessages" (id, "sessionId", kind, payload, "sendAt", reason, status, attempts, "lastError", "messageId", "createdAt", "updatedAt")
+		VALUES (:id, :sessionId, :kind, :payload, :sendAt, :reason, :status, :attempts, :lastError, :messageId, :createdAt, :updatedAt)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to create scheduled message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ScheduleRepository) Get(ctx context.Context, sessionID, id uuid.UUID) (*schedule.Message, error) {
+	var model scheduledMessageModel
+	query := `SELECT * FROM "zpScheduledMessages" WHERE "sessionId" = $1 AND id = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, schedule.ErrScheduledMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get scheduled message: %w", err)
+	}
+
+	return scheduledMessageFromModel(&model), nil
+}
+
+func (r *ScheduleRepository) List(ctx context.Context, sessionID uuid.UUID, status schedule.Status) ([]*schedule.Message, error) {
+	var models []scheduledMessageModel
+
+	query := `SELECT * FROM "zpScheduledMessages" WHERE "sessionId" = $1`
+	args := []interface{}{sessionID.String()}
+	if status != "" {
+		query += ` AND status = $2`
+		args = append(args, string(status))
+	}
+	query += ` ORDER BY "sendAt", "createdAt"`
+
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list scheduled messages: %w", err)
+	}
+
+	return scheduledMessagesFromModels(models), nil
+}
+
+func (r *ScheduleRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*schedule.Message, error) {
+	var models []scheduledMessageModel
+
+	query := `
+		UPDATE "zpScheduledMessages" SET status = 'sending', "updatedAt" = NOW()
+		WHERE id IN (
+			SELECT id FROM "zpScheduledMessages"
+			WHERE status = 'pending' AND "sendAt" <= $1
+			ORDER BY "sendAt"
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`
+
+	if err := r.db.SelectContext(ctx, &models, query, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to claim scheduled messages: %w", err)
+	}
+
+	return scheduledMessagesFromModels(models), nil
+}
+
+func (r *ScheduleRepository) Release(ctx context.Context) (int64, error) {
+	query := `UPDATE "zpScheduledMessages" SET status = 'pending', "updatedAt" = NOW() WHERE status = 'sending'`
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release scheduled messages: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *ScheduleRepository) ClaimFailed(ctx context.Context, sessionID, id uuid.UUID) (*schedule.Message, error) {
+	var model scheduledMessageModel
+	query := `
+		UPDATE "zpScheduledMessages" SET status = 'sending', "updatedAt" = NOW()
+		WHERE "sessionId" = $1 AND id = $2 AND status = 'failed'
+		RETURNING *
+	`
+
+	if err := r.db.GetContext(ctx, &model, query, sessionID.String(), id.String()); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to claim failed message: %w", err)
+		}
+		if _, err := r.Get(ctx, sessionID, id); err != nil {
+			return nil, err
+		}
+		return nil, schedule.ErrNotRetryable
+	}
+
+	return scheduledMessageFromModel(&model), nil
+}
+
+func (r *ScheduleRepository) Finish(ctx context.Context, message *schedule.Message) error {
+	query := `
+		UPDATE "zpScheduledMessages"
+		SET status = $2, attempts = $3, "lastError" = $4, "messageId" = $5, "sendAt" = $6, "updatedAt" = $7
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, message.ID.String(), string(message.Status),
+		message.Attempts, message.LastError, message.MessageID, message.SendAt, message.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ScheduleRepository) Cancel(ctx context.Context, sessionID, id uuid.UUID) error {
+	query := `
+		UPDATE "zpScheduledMessages" SET status = 'cancelled', "updatedAt" = NOW()
+		WHERE "sessionId" = $1 AND id = $2 AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled message: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return schedule.ErrNotCancellable
+	}
+
+	return nil
+}
+
+func scheduledMessagesFromModels(models []scheduledMessageModel) []*schedule.Message {
+	messages := make([]*schedule.Message, len(models))
+	for i := range models {
+		messages[i] = scheduledMessageFromModel(&models[i])
+	}
+	return messages
+}
+
+func scheduledMessageFromModel(model *scheduledMessageModel) *schedule.Message {
+	id, _ := uuid.Parse(model.ID)
+	sessionID, _ := uuid.Parse(model.SessionID)
+
+	return &schedule.Message{
+		ID:        id,
+		SessionID: sessionID,
+		Kind:      model.Kind,
+		Payload:   json.RawMessage(model.Payload),
+		SendAt:    model.SendAt,
+		Reason:    model.Reason,
+		Status:    schedule.Status(model.Status),
+		Attempts:  model.Attempts,
+		LastError: model.LastError,
+		MessageID: model.MessageID,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+	"zpwoot/platform/secrets"
+)
+
+// Sealed values are bound to the row they are stored in, so one copied to
+// another row or column does not open.
+func webhookSecretLocation(webhookID string) string {
+	return "zpWebhooks.secret:" + webhookID
+}
+
+func proxyCredentialsLocation(sessionID string) string {
+	return "zpSessions.proxyConfig:" + sessionID
+}
+
+// sealProxyConfig returns a copy of proxy with its credentials sealed; the
+// host and port stay readable.
+func sealProxyConfig(keyring *secrets.Keyring, proxy *session.ProxyConfig, sessionID string) (*session.ProxyConfig, error) {
+	sealed := *proxy
+	location := proxyCredentialsLocation(sessionID)
+
+	var err error
+	if sealed.Username, err = keyring.Seal(proxy.Username, location); err != nil {
+		return nil, fmt.Errorf("failed to encrypt proxy credentials: %w", err)
+	}
+	if sealed.Password, err = keyring.Seal(proxy.Password, location); err != nil {
+		return nil, fmt.Errorf("failed to encrypt proxy credentials: %w", err)
+	}
+	return &sealed, nil
+}
+
+func openProxyConfig(keyring *secrets.Keyring, proxy *session.ProxyConfig, sessionID string) error {
+	location := proxyCredentialsLocation(sessionID)
+
+	var err error
+	if proxy.Username, err = keyring.Open(proxy.Username, location); err != nil {
+		return fmt.Errorf("failed to decrypt proxy credentials of session %s: %w", sessionID, err)
+	}
+	if proxy.Password, err = keyring.Open(proxy.Password, location); err != nil {
+		return fmt.Errorf("failed to decrypt proxy credentials of session %s: %w", sessionID, err)
+	}
+	return nil
+}
+
+// SecretsRepository re-encrypts the secrets stored in the database under
+// the keyring's primary key: plaintext written before encryption was turned
+// on, and values sealed with a key rotated out.
+type SecretsRepository struct {
+	db      *sqlx.DB
+	secrets *secrets.Keyring
+	logger  *logger.Logger
+}
+
+func NewSecretsRepository(db *sqlx.DB, keyring *secrets.Keyring, logger *logger.Logger) *SecretsRepository {
+	return &SecretsRepository{
+		db:      db,
+		secrets: keyring,
+		logger:  logger,
+	}
+}
+
+// Rotate re-encrypts every stored secret that needs it. Each row is written
+// only if it still holds the value read, so a concurrent update wins over
+// the rotation. Rows that do not open are counted as failed and left as
+// they are.
+func (r *SecretsRepository) Rotate(ctx context.Context) (*secrets.Rotation, error) {
+	rotation := &secrets.Rotation{KeyID: r.secrets.PrimaryID()}
+	if rotation.KeyID == "" {
+		return rotation, nil
+	}
+
+	if err := r.rotateWebhookSecrets(ctx, rotation); err != nil {
+		return nil, err
+	}
+	if err := r.rotateProxyCredentials(ctx, rotation); err != nil {
+		return nil, err
+	}
+
+	return rotation, nil
+}
+
+func (r *SecretsRepository) rotateWebhookSecrets(ctx context.Context, rotation *secrets.Rotation) error {
+	var rows []struct {
+		ID     string `db:"id"`
+		Secret string `db:"secret"`
+	}
+	query := `SELECT id, secret FROM "zpWebhooks" WHERE secret IS NOT NULL AND secret <> ''`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return fmt.Errorf("failed to list webhook secrets: %w", err)
+	}
+
+	for _, row := range rows {
+		if !r.secrets.NeedsRotation(row.Secret) {
+			rotation.Current++
+			continue
+		}
+
+		location := webhookSecretLocation(row.ID)
+		plaintext, err := r.secrets.Open(row.Secret, location)
+		if err != nil {
+			r.rotationFailed(rotation, "webhook", row.ID, err)
+			continue
+		}
+		sealed, err := r.secrets.Seal(plaintext, location)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+
+		query := `UPDATE "zpWebhooks" SET secret = $2 WHERE id = $1 AND secret = $3`
+		if _, err := r.db.ExecContext(ctx, query, row.ID, sealed, row.Secret); err != nil {
+			return fmt.Errorf("failed to store webhook secret: %w", err)
+		}
+		rotation.Rotated++
+	}
+
+	return nil
+}
+
+func (r *SecretsRepository) rotateProxyCredentials(ctx context.Context, rotation *secrets.Rotation) error {
+	var rows []struct {
+		ID          string `db:"id"`
+		ProxyConfig string `db:"proxyConfig"`
+	}
+	query := `SELECT id, "proxyConfig" FROM "zpSessions" WHERE "proxyConfig" IS NOT NULL`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return fmt.Errorf("failed to list proxy credentials: %w", err)
+	}
+
+	for _, row := range rows {
+		var proxy session.ProxyConfig
+		if err := json.Unmarshal([]byte(row.ProxyConfig), &proxy); err != nil {
+			r.rotationFailed(rotation, "session", row.ID, err)
+			continue
+		}
+		if proxy.Username == "" && proxy.Password == "" {
+			continue
+		}
+		if !r.secrets.NeedsRotation(proxy.Username) && !r.secrets.NeedsRotation(proxy.Password) {
+			rotation.Current++
+			continue
+		}
+
+		if err := openProxyConfig(r.secrets, &proxy, row.ID); err != nil {
+			r.rotationFailed(rotation, "session", row.ID, err)
+			continue
+		}
+		sealed, err := sealProxyConfig(r.secrets, &proxy, row.ID)
+		if err != nil {
+			return err
+		}
+		proxyJSON, err := json.Marshal(sealed)
+		if err != nil {
+			return fmt.Errorf("failed to marshal proxy config: %w", err)
+		}
+
+		query := `UPDATE "zpSessions" SET "proxyConfig" = $2 WHERE id = $1 AND "proxyConfig" = $3::jsonb`
+		if _, err := r.db.ExecContext(ctx, query, row.ID, string(proxyJSON), row.ProxyConfig); err != nil {
+			return fmt.Errorf("failed to store proxy credentials: %w", err)
+		}
+		rotation.Rotated++
+	}
+
+	return nil
+}
+
+func (r *SecretsRepository) rotationFailed(rotation *secrets.Rotation, kind, id string, err error) {
+	rotation.Failed++
+	r.logger.WarnWithFields("Stored secret could not be re-encrypted", map[string]interface{}{
+		"kind":  kind,
+		"id":    id,
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+type SeenRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewSeenRepository(db *sqlx.DB, logger *logger.Logger) messaging.SeenRepository {
+	return &SeenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SeenRepository) MarkSeen(ctx context.Context, sessionID uuid.UUID, zpMessageID string, seenAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO "zpInboundSeen" ("sessionId", "zpMessageId", "seenAt")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("sessionId", "zpMessageId") DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID.String(), zpMessageID, seenAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record inbound message: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record inbound message: %w", err)
+	}
+
+	return rows == 0, nil
+}
+
+func (r *SeenRepository) DeleteSeenBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM "zpInboundSeen" WHERE "seenAt" < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge inbound message IDs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
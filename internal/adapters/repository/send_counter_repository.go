@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+type SendCounterRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewSendCounterRepository(db *sqlx.DB, logger *logger.Logger) session.SendCounter {
+	return &SendCounterRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Reserve increments the day's counter only while it is below limit, so
+// concurrent sends can never push a session past its allowance.
+func (r *SendCounterRepository) Reserve(ctx context.Context, sessionID uuid.UUID, day string, limit int) (bool, error) {
+	if limit <= 0 {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO "zpSendCounters" ("sessionId", day, count, "updatedAt")
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT ("sessionId", day) DO UPDATE
+		SET count = "zpSendCounters".count + 1, "updatedAt" = NOW()
+		WHERE "zpSendCounters".count < $3
+		RETURNING count
+	`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, sessionID.String(), day, limit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve send: %w", err)
+	}
+
+	return true, nil
+}
+
+// Release gives back a send Reserve took, never taking the count below zero.
+func (r *SendCounterRepository) Release(ctx context.Context, sessionID uuid.UUID, day string) error {
+	query := `
+		UPDATE "zpSendCounters" SET count = count - 1, "updatedAt" = NOW()
+		WHERE "sessionId" = $1 AND day = $2 AND count > 0
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID.String(), day); err != nil {
+		return fmt.Errorf("failed to release send: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SendCounterRepository) Count(ctx context.Context, sessionID uuid.UUID, day string) (int, error) {
+	var count int
+	query := `SELECT count FROM "zpSendCounters" WHERE "sessionId" = $1 AND day = $2`
+
+	err := r.db.GetContext(ctx, &count, query, sessionID.String(), day)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count sends: %w", err)
+	}
+
+	return count, nil
+}
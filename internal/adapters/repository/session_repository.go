@@ -13,15 +13,19 @@ import (
 
 	"zpwoot/internal/core/session"
 	"zpwoot/internal/core/shared/errors"
+	"zpwoot/internal/core/shared/pagination"
+	"zpwoot/platform/secrets"
 )
 
 type SessionRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	secrets *secrets.Keyring
 }
 
-func NewSessionRepository(db *sqlx.DB) session.Repository {
+func NewSessionRepository(db *sqlx.DB, keyring *secrets.Keyring) session.Repository {
 	return &SessionRepository{
-		db: db,
+		db:      db,
+		secrets: keyring,
 	}
 }
 
@@ -34,10 +38,15 @@ type sessionModel struct {
 	QRCode          sql.NullString `db:"qrCode"`
 	QRCodeExpiresAt sql.NullTime   `db:"qrCodeExpiresAt"`
 	ProxyConfig     sql.NullString `db:"proxyConfig"`
+	Settings        []byte         `db:"settings"`
 	CreatedAt       time.Time      `db:"createdAt"`
 	UpdatedAt       time.Time      `db:"updatedAt"`
 	ConnectedAt     sql.NullTime   `db:"connectedAt"`
 	LastSeen        sql.NullTime   `db:"lastSeen"`
+	LoggedOutAt     sql.NullTime   `db:"loggedOutAt"`
+	QRTimeoutAt     sql.NullTime   `db:"qrTimeoutAt"`
+	Ban             []byte         `db:"ban"`
+	TenantID        sql.NullString `db:"tenantId"`
 }
 
 func (r *SessionRepository) Create(ctx context.Context, sess *session.Session) error {
@@ -49,12 +58,12 @@ func (r *SessionRepository) Create(ctx context.Context, sess *session.Session) e
 	query := `
 		INSERT INTO "zpSessions" (
 			id, name, "deviceJid", "isConnected", "connectionError",
-			"qrCode", "qrCodeExpiresAt", "proxyConfig", "createdAt",
-			"updatedAt", "connectedAt", "lastSeen"
+			"qrCode", "qrCodeExpiresAt", "proxyConfig", "settings", "createdAt",
+			"updatedAt", "connectedAt", "lastSeen", "loggedOutAt", "qrTimeoutAt", "ban", "tenantId"
 		) VALUES (
 			:id, :name, :deviceJid, :isConnected, :connectionError,
-			:qrCode, :qrCodeExpiresAt, :proxyConfig, :createdAt,
-			:updatedAt, :connectedAt, :lastSeen
+			:qrCode, :qrCodeExpiresAt, :proxyConfig, :settings, :createdAt,
+			:updatedAt, :connectedAt, :lastSeen, :loggedOutAt, :qrTimeoutAt, :ban, :tenantId
 		)
 	`
 
@@ -119,9 +128,13 @@ func (r *SessionRepository) Update(ctx context.Context, sess *session.Session) e
 			"qrCode" = :qrCode,
 			"qrCodeExpiresAt" = :qrCodeExpiresAt,
 			"proxyConfig" = :proxyConfig,
+			"settings" = :settings,
 			"updatedAt" = :updatedAt,
 			"connectedAt" = :connectedAt,
-			"lastSeen" = :lastSeen
+			"lastSeen" = :lastSeen,
+			"loggedOutAt" = :loggedOutAt,
+			"qrTimeoutAt" = :qrTimeoutAt,
+			"ban" = :ban
 		WHERE id = :id
 	`
 
@@ -174,7 +187,7 @@ func (r *SessionRepository) List(ctx context.Context, limit, offset int) ([]*ses
 	var models []sessionModel
 	query := `
 		SELECT * FROM "zpSessions"
-		ORDER BY "createdAt" DESC
+		ORDER BY "createdAt" DESC, "id" DESC
 		LIMIT $1 OFFSET $2
 	`
 
@@ -195,6 +208,79 @@ func (r *SessionRepository) List(ctx context.Context, limit, offset int) ([]*ses
 	return sessions, nil
 }
 
+func (r *SessionRepository) ListAfter(ctx context.Context, after *pagination.Cursor, limit int) ([]*session.Session, error) {
+	var models []sessionModel
+	var err error
+
+	if after == nil {
+		query := `
+			SELECT * FROM "zpSessions"
+			ORDER BY "createdAt" DESC, "id" DESC
+			LIMIT $1
+		`
+		err = r.db.SelectContext(ctx, &models, query, limit)
+	} else {
+		query := `
+			SELECT * FROM "zpSessions"
+			WHERE ("createdAt", "id") < ($1, $2::uuid)
+			ORDER BY "createdAt" DESC, "id" DESC
+			LIMIT $3
+		`
+		err = r.db.SelectContext(ctx, &models, query, after.Time, after.ID, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*session.Session, len(models))
+	for i, model := range models {
+		sess, err := r.fromModel(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to session: %w", err)
+		}
+		sessions[i] = sess
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, after *pagination.Cursor, limit, offset int) ([]*session.Session, error) {
+	var models []sessionModel
+	var err error
+
+	if after == nil {
+		query := `
+			SELECT * FROM "zpSessions"
+			WHERE "tenantId" = $1
+			ORDER BY "createdAt" DESC, "id" DESC
+			LIMIT $2 OFFSET $3
+		`
+		err = r.db.SelectContext(ctx, &models, query, tenantID.String(), limit, offset)
+	} else {
+		query := `
+			SELECT * FROM "zpSessions"
+			WHERE "tenantId" = $1 AND ("createdAt", "id") < ($2, $3::uuid)
+			ORDER BY "createdAt" DESC, "id" DESC
+			LIMIT $4
+		`
+		err = r.db.SelectContext(ctx, &models, query, tenantID.String(), after.Time, after.ID, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant sessions: %w", err)
+	}
+
+	sessions := make([]*session.Session, len(models))
+	for i, model := range models {
+		sess, err := r.fromModel(&model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert model to session: %w", err)
+		}
+		sessions[i] = sess
+	}
+
+	return sessions, nil
+}
+
 func (r *SessionRepository) ListConnected(ctx context.Context) ([]*session.Session, error) {
 	var models []sessionModel
 	query := `SELECT * FROM "zpSessions" WHERE "isConnected" = true ORDER BY "connectedAt" DESC`
@@ -416,13 +502,23 @@ func (r *SessionRepository) toModel(sess *session.Session) (*sessionModel, error
 	}
 
 	if sess.ProxyConfig != nil {
-		proxyJSON, err := json.Marshal(sess.ProxyConfig)
+		proxy, err := sealProxyConfig(r.secrets, sess.ProxyConfig, model.ID)
+		if err != nil {
+			return nil, err
+		}
+		proxyJSON, err := json.Marshal(proxy)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal proxy config: %w", err)
 		}
 		model.ProxyConfig = sql.NullString{String: string(proxyJSON), Valid: true}
 	}
 
+	settingsJSON, err := json.Marshal(sess.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session settings: %w", err)
+	}
+	model.Settings = settingsJSON
+
 	if sess.ConnectedAt != nil {
 		model.ConnectedAt = sql.NullTime{Time: *sess.ConnectedAt, Valid: true}
 	}
@@ -431,6 +527,26 @@ func (r *SessionRepository) toModel(sess *session.Session) (*sessionModel, error
 		model.LastSeen = sql.NullTime{Time: *sess.LastSeen, Valid: true}
 	}
 
+	if sess.LoggedOutAt != nil {
+		model.LoggedOutAt = sql.NullTime{Time: *sess.LoggedOutAt, Valid: true}
+	}
+
+	if sess.QRTimeoutAt != nil {
+		model.QRTimeoutAt = sql.NullTime{Time: *sess.QRTimeoutAt, Valid: true}
+	}
+
+	if sess.Ban != nil {
+		banJSON, err := json.Marshal(sess.Ban)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal session ban: %w", err)
+		}
+		model.Ban = banJSON
+	}
+
+	if sess.TenantID != nil {
+		model.TenantID = sql.NullString{String: sess.TenantID.String(), Valid: true}
+	}
+
 	return model, nil
 }
 
@@ -469,9 +585,18 @@ func (r *SessionRepository) fromModel(model *sessionModel) (*session.Session, er
 		if err := json.Unmarshal([]byte(model.ProxyConfig.String), &proxyConfig); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal proxy config: %w", err)
 		}
+		if err := openProxyConfig(r.secrets, &proxyConfig, model.ID); err != nil {
+			return nil, err
+		}
 		sess.ProxyConfig = &proxyConfig
 	}
 
+	if len(model.Settings) > 0 {
+		if err := json.Unmarshal(model.Settings, &sess.Settings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session settings: %w", err)
+		}
+	}
+
 	if model.ConnectedAt.Valid {
 		sess.ConnectedAt = &model.ConnectedAt.Time
 	}
@@ -480,5 +605,29 @@ func (r *SessionRepository) fromModel(model *sessionModel) (*session.Session, er
 		sess.LastSeen = &model.LastSeen.Time
 	}
 
+	if model.LoggedOutAt.Valid {
+		sess.LoggedOutAt = &model.LoggedOutAt.Time
+	}
+
+	if model.QRTimeoutAt.Valid {
+		sess.QRTimeoutAt = &model.QRTimeoutAt.Time
+	}
+
+	if len(model.Ban) > 0 {
+		var ban session.Ban
+		if err := json.Unmarshal(model.Ban, &ban); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session ban: %w", err)
+		}
+		sess.Ban = &ban
+	}
+
+	if model.TenantID.Valid {
+		tenantID, err := uuid.Parse(model.TenantID.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse session tenant ID: %w", err)
+		}
+		sess.TenantID = &tenantID
+	}
+
 	return sess, nil
 }
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+type StatusHistoryRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewStatusHistoryRepository(db *sqlx.DB, logger *logger.Logger) session.StatusHistory {
+	return &StatusHistoryRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type statusTransitionModel struct {
+	SessionID  string         `db:"sessionId"`
+	FromStatus sql.NullString `db:"fromStatus"`
+	ToStatus   string         `db:"toStatus"`
+	Reason     string         `db:"reason"`
+	CreatedAt  time.Time      `db:"createdAt"`
+}
+
+func (r *StatusHistoryRepository) Record(ctx context.Context, transition *session.StatusTransition) error {
+	query := `
+		INSERT INTO "zpSessionStatusHistory" ("sessionId", "fromStatus", "toStatus", reason, "createdAt")
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	from := sql.NullString{String: string(transition.From), Valid: transition.From != ""}
+	_, err := r.db.ExecContext(ctx, query,
+		transition.SessionID.String(), from, string(transition.To), transition.Reason, transition.At)
+	if err != nil {
+		return fmt.Errorf("failed to record status transition: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatusHistoryRepository) Last(ctx context.Context, sessionID uuid.UUID, before time.Time) (*session.StatusTransition, error) {
+	var model statusTransitionModel
+	query := `
+		SELECT "sessionId", "fromStatus", "toStatus", reason, "createdAt"
+		FROM "zpSessionStatusHistory"
+		WHERE "sessionId" = $1 AND "createdAt" < $2
+		ORDER BY "createdAt" DESC, id DESC
+		LIMIT 1
+	`
+
+	err := r.db.GetContext(ctx, &model, query, sessionID.String(), before)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last status transition: %w", err)
+	}
+
+	return statusTransitionFromModel(sessionID, &model), nil
+}
+
+func (r *StatusHistoryRepository) List(ctx context.Context, sessionID uuid.UUID, from, to time.Time) ([]*session.StatusTransition, error) {
+	var models []statusTransitionModel
+	query := `
+		SELECT "sessionId", "fromStatus", "toStatus", reason, "createdAt"
+		FROM "zpSessionStatusHistory"
+		WHERE "sessionId" = $1 AND "createdAt" >= $2 AND "createdAt" < $3
+		ORDER BY "createdAt", id
+	`
+
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String(), from, to); err != nil {
+		return nil, fmt.Errorf("failed to list status transitions: %w", err)
+	}
+
+	transitions := make([]*session.StatusTransition, len(models))
+	for i := range models {
+		transitions[i] = statusTransitionFromModel(sessionID, &models[i])
+	}
+
+	return transitions, nil
+}
+
+func statusTransitionFromModel(sessionID uuid.UUID, model *statusTransitionModel) *session.StatusTransition {
+	return &session.StatusTransition{
+		SessionID: sessionID,
+		From:      session.SessionStatus(model.FromStatus.String),
+		To:        session.SessionStatus(model.ToStatus),
+		Reason:    model.Reason,
+		At:        model.CreatedAt,
+	}
+}
@@ -0,0 +1,379 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	shared "zpwoot/internal/core/shared/errors"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/platform/logger"
+)
+
+type TenantRepository struct {
+	db     *sqlx.DB
+	logger *logger.Logger
+}
+
+func NewTenantRepository(db *sqlx.DB, logger *logger.Logger) tenant.Repository {
+	return &TenantRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+type tenantModel struct {
+	ID                string    `db:"id"`
+	Name              string    `db:"name"`
+	MaxSessions       int       `db:"maxSessions"`
+	MaxMessagesPerDay int       `db:"maxMessagesPerDay"`
+	TrackingDomain    string    `db:"trackingDomain"`
+	CreatedAt         time.Time `db:"createdAt"`
+	UpdatedAt         time.Time `db:"updatedAt"`
+}
+
+type tenantKeyModel struct {
+	ID         string       `db:"id"`
+	TenantID   string       `db:"tenantId"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"prefix"`
+	KeyHash    string       `db:"keyHash"`
+	CreatedAt  time.Time    `db:"createdAt"`
+	LastUsedAt sql.NullTime `db:"lastUsedAt"`
+}
+
+func (r *TenantRepository) Create(ctx context.Context, t *tenant.Tenant) error {
+	query := `
+		INSERT INTO "zpTenants" (id, name, "maxSessions", "maxMessagesPerDay", "trackingDomain", "createdAt", "updatedAt")
+		VALUES (:id, :name, :maxSessions, :maxMessagesPerDay, :trackingDomain, :createdAt, :updatedAt)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, toTenantModel(t)); err != nil {
+		if isUniqueViolation(err) {
+			return tenant.ErrTenantNameTaken
+		}
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*tenant.Tenant, error) {
+	var model tenantModel
+	query := `SELECT * FROM "zpTenants" WHERE id = $1`
+
+	if err := r.db.GetContext(ctx, &model, query, id.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tenant.ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return fromTenantModel(model)
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]*tenant.Tenant, error) {
+	var models []tenantModel
+	query := `SELECT * FROM "zpTenants" ORDER BY name`
+
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	tenants := make([]*tenant.Tenant, 0, len(models))
+	for _, model := range models {
+		t, err := fromTenantModel(model)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+
+	return tenants, nil
+}
+
+func (r *TenantRepository) Update(ctx context.Context, t *tenant.Tenant) error {
+	query := `
+		UPDATE "zpTenants"
+		SET name = :name, "maxSessions" = :maxSessions, "maxMessagesPerDay" = :maxMessagesPerDay,
+			"trackingDomain" = :trackingDomain, "updatedAt" = :updatedAt
+		WHERE id = :id
+	`
+
+	result, err := r.db.NamedExecContext(ctx, query, toTenantModel(t))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return tenant.ErrTenantNameTaken
+		}
+		return fmt.Errorf("failed to update tenant: %w", err)
+	}
+
+	return requireRow(result, tenant.ErrTenantNotFound, "update tenant")
+}
+
+func (r *TenantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM "zpTenants" WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete tenant: %w", err)
+	}
+
+	return requireRow(result, tenant.ErrTenantNotFound, "delete tenant")
+}
+
+func (r *TenantRepository) CreateKey(ctx context.Context, key *tenant.APIKey) error {
+	model := tenantKeyModel{
+		ID:        key.ID.String(),
+		TenantID:  key.TenantID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   key.Hash,
+		CreatedAt: key.CreatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpTenantKeys" (id, "tenantId", name, prefix, "keyHash", "createdAt")
+		VALUES (:id, :tenantId, :name, :prefix, :keyHash, :createdAt)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to create tenant API key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TenantRepository) ListKeys(ctx context.Context, tenantID uuid.UUID) ([]*tenant.APIKey, error) {
+	var models []tenantKeyModel
+	query := `SELECT * FROM "zpTenantKeys" WHERE "tenantId" = $1 ORDER BY "createdAt"`
+
+	if err := r.db.SelectContext(ctx, &models, query, tenantID.String()); err != nil {
+		return nil, fmt.Errorf("failed to list tenant API keys: %w", err)
+	}
+
+	keys := make([]*tenant.APIKey, 0, len(models))
+	for _, model := range models {
+		key, err := fromTenantKeyModel(model)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *TenantRepository) DeleteKey(ctx context.Context, tenantID, keyID uuid.UUID) error {
+	query := `DELETE FROM "zpTenantKeys" WHERE id = $1 AND "tenantId" = $2`
+
+	result, err := r.db.ExecContext(ctx, query, keyID.String(), tenantID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete tenant API key: %w", err)
+	}
+
+	return requireRow(result, tenant.ErrAPIKeyNotFound, "delete tenant API key")
+}
+
+func (r *TenantRepository) GetByKeyHash(ctx context.Context, hash string) (*tenant.APIKey, *tenant.Tenant, error) {
+	var row struct {
+		tenantKeyModel
+		Tenant tenantModel `db:"tenant"`
+	}
+
+	query := `
+		SELECT k.*,
+			t.id AS "tenant.id", t.name AS "tenant.name",
+			t."maxSessions" AS "tenant.maxSessions", t."maxMessagesPerDay" AS "tenant.maxMessagesPerDay",
+			t."trackingDomain" AS "tenant.trackingDomain",
+			t."createdAt" AS "tenant.createdAt", t."updatedAt" AS "tenant.updatedAt"
+		FROM "zpTenantKeys" k
+		JOIN "zpTenants" t ON t.id = k."tenantId"
+		WHERE k."keyHash" = $1
+	`
+
+	if err := r.db.GetContext(ctx, &row, query, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, tenant.ErrAPIKeyNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get tenant API key: %w", err)
+	}
+
+	key, err := fromTenantKeyModel(row.tenantKeyModel)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := fromTenantModel(row.Tenant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, t, nil
+}
+
+func (r *TenantRepository) TouchKey(ctx context.Context, keyID uuid.UUID) error {
+	query := `UPDATE "zpTenantKeys" SET "lastUsedAt" = NOW() WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, keyID.String()); err != nil {
+		return fmt.Errorf("failed to touch tenant API key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TenantRepository) SetSessionTenant(ctx context.Context, sessionID uuid.UUID, tenantID *uuid.UUID) error {
+	var value interface{}
+	if tenantID != nil {
+		value = tenantID.String()
+	}
+
+	query := `UPDATE "zpSessions" SET "tenantId" = $1, "updatedAt" = NOW() WHERE id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, value, sessionID.String())
+	if err != nil {
+		return fmt.Errorf("failed to set session tenant: %w", err)
+	}
+
+	return requireRow(result, shared.ErrSessionNotFound, "set session tenant")
+}
+
+func (r *TenantRepository) CountSessions(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM "zpSessions" WHERE "tenantId" = $1`
+
+	if err := r.db.GetContext(ctx, &count, query, tenantID.String()); err != nil {
+		return 0, fmt.Errorf("failed to count tenant sessions: %w", err)
+	}
+
+	return count, nil
+}
+
+// ReserveMessage mirrors the session send counter: the conditional upsert
+// keeps concurrent sends from pushing a tenant past its daily allowance.
+func (r *TenantRepository) ReserveMessage(ctx context.Context, tenantID uuid.UUID, day string, limit int) (bool, error) {
+	if limit <= 0 {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO "zpTenantCounters" ("tenantId", day, count, "updatedAt")
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT ("tenantId", day) DO UPDATE
+		SET count = "zpTenantCounters".count + 1, "updatedAt" = NOW()
+		WHERE "zpTenantCounters".count < $3
+		RETURNING count
+	`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, tenantID.String(), day, limit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve tenant message: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *TenantRepository) ReleaseMessage(ctx context.Context, tenantID uuid.UUID, day string) error {
+	query := `
+		UPDATE "zpTenantCounters" SET count = count - 1, "updatedAt" = NOW()
+		WHERE "tenantId" = $1 AND day = $2 AND count > 0
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID.String(), day); err != nil {
+		return fmt.Errorf("failed to release tenant message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TenantRepository) CountMessages(ctx context.Context, tenantID uuid.UUID, day string) (int, error) {
+	var count int
+	query := `SELECT count FROM "zpTenantCounters" WHERE "tenantId" = $1 AND day = $2`
+
+	err := r.db.GetContext(ctx, &count, query, tenantID.String(), day)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tenant messages: %w", err)
+	}
+
+	return count, nil
+}
+
+func toTenantModel(t *tenant.Tenant) tenantModel {
+	return tenantModel{
+		ID:                t.ID.String(),
+		Name:              t.Name,
+		MaxSessions:       t.MaxSessions,
+		MaxMessagesPerDay: t.MaxMessagesPerDay,
+		TrackingDomain:    t.TrackingDomain,
+		CreatedAt:         t.CreatedAt,
+		UpdatedAt:         t.UpdatedAt,
+	}
+}
+
+func fromTenantModel(model tenantModel) (*tenant.Tenant, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID: %w", err)
+	}
+
+	return &tenant.Tenant{
+		ID:                id,
+		Name:              model.Name,
+		MaxSessions:       model.MaxSessions,
+		MaxMessagesPerDay: model.MaxMessagesPerDay,
+		TrackingDomain:    model.TrackingDomain,
+		CreatedAt:         model.CreatedAt,
+		UpdatedAt:         model.UpdatedAt,
+	}, nil
+}
+
+func fromTenantKeyModel(model tenantKeyModel) (*tenant.APIKey, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant API key ID: %w", err)
+	}
+	tenantID, err := uuid.Parse(model.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant ID in API key: %w", err)
+	}
+
+	key := &tenant.APIKey{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      model.Name,
+		Prefix:    model.Prefix,
+		Hash:      model.KeyHash,
+		CreatedAt: model.CreatedAt,
+	}
+	if model.LastUsedAt.Valid {
+		key.LastUsedAt = &model.LastUsedAt.Time
+	}
+
+	return key, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func requireRow(result sql.Result, notFound error, operation string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", operation, err)
+	}
+	if rows == 0 {
+		return notFound
+	}
+	return nil
+}
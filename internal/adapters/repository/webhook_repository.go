@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"zpwoot/internal/core/webhook"
+	"zpwoot/platform/logger"
+	"zpwoot/platform/secrets"
+)
+
+type WebhookRepository struct {
+	db      *sqlx.DB
+	secrets *secrets.Keyring
+	logger  *logger.Logger
+}
+
+func NewWebhookRepository(db *sqlx.DB, keyring *secrets.Keyring, logger *logger.Logger) webhook.Repository {
+	return &WebhookRepository{
+		db:      db,
+		secrets: keyring,
+		logger:  logger,
+	}
+}
+
+type webhookModel struct {
+	ID            string         `db:"id"`
+	SessionID     string         `db:"sessionId"`
+	URL           string         `db:"url"`
+	Secret        sql.NullString `db:"secret"`
+	Events        []byte         `db:"events"`
+	Template      []byte         `db:"template"`
+	SchemaVersion int            `db:"schemaVersion"`
+	Format        string         `db:"format"`
+	Priority      int            `db:"priority"`
+	Enabled       bool           `db:"enabled"`
+	LastDelivery  sql.NullTime   `db:"lastDeliveryAt"`
+	LastSuccess   sql.NullTime   `db:"lastSuccessAt"`
+	LastStatus    sql.NullInt64  `db:"lastStatusCode"`
+	LastError     sql.NullString `db:"lastError"`
+	FailureCount  int            `db:"failureCount"`
+	CreatedAt     time.Time      `db:"createdAt"`
+	UpdatedAt     time.Time      `db:"updatedAt"`
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, sessionID, id uuid.UUID) (*webhook.Webhook, error) {
+	var model webhookModel
+	query := `SELECT * FROM "zpWebhooks" WHERE id = $1 AND "sessionId" = $2`
+
+	if err := r.db.GetContext(ctx, &model, query, id.String(), sessionID.String()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, webhook.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return r.fromModel(&model)
+}
+
+func (r *WebhookRepository) ListBySession(ctx context.Context, sessionID uuid.UUID) ([]*webhook.Webhook, error) {
+	var models []webhookModel
+	query := `SELECT * FROM "zpWebhooks" WHERE "sessionId" = $1 ORDER BY priority DESC, "createdAt" ASC`
+
+	if err := r.db.SelectContext(ctx, &models, query, sessionID.String()); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webhooks := make([]*webhook.Webhook, 0, len(models))
+	for i := range models {
+		wh, err := r.fromModel(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, wh)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Upsert(ctx context.Context, wh *webhook.Webhook) error {
+	events, err := json.Marshal(wh.Events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook events: %w", err)
+	}
+
+	var template []byte
+	if len(wh.Template) > 0 {
+		template = wh.Template
+	}
+
+	secret, err := r.secrets.Seal(wh.Secret, webhookSecretLocation(wh.ID.String()))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	model := webhookModel{
+		ID:            wh.ID.String(),
+		SessionID:     wh.SessionID.String(),
+		URL:           wh.URL,
+		Secret:        sql.NullString{String: secret, Valid: secret != ""},
+		Events:        events,
+		Template:      template,
+		SchemaVersion: wh.SchemaVersion,
+		Format:        wh.Format,
+		Priority:      wh.Priority,
+		Enabled:       wh.Enabled,
+		CreatedAt:     wh.CreatedAt,
+		UpdatedAt:     wh.UpdatedAt,
+	}
+
+	query := `
+		INSERT INTO "zpWebhooks" (id, "sessionId", url, secret, events, template, "schemaVersion", format, priority, enabled, "createdAt", "updatedAt")
+		VALUES (:id, :sessionId, :url, :secret, :events, :template, :schemaVersion, :format, :priority, :enabled, :createdAt, :updatedAt)
+		ON CONFLICT (id) DO UPDATE SET
+			url = EXCLUDED.url,
+			secret = EXCLUDED.secret,
+			events = EXCLUDED.events,
+			template = EXCLUDED.template,
+			"schemaVersion" = EXCLUDED."schemaVersion",
+			format = EXCLUDED.format,
+			priority = EXCLUDED.priority,
+			enabled = EXCLUDED.enabled,
+			"updatedAt" = EXCLUDED."updatedAt"
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, model); err != nil {
+		return fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, sessionID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM "zpWebhooks" WHERE id = $1 AND "sessionId" = $2`, id.String(), sessionID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return webhook.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RecordDelivery only touches the delivery columns, which the update
+// trigger ignores, so "updatedAt" keeps tracking configuration changes.
+func (r *WebhookRepository) RecordDelivery(ctx context.Context, id uuid.UUID, at time.Time, statusCode int, deliveryErr string) error {
+	query := `
+		UPDATE "zpWebhooks" SET
+			"lastDeliveryAt" = $2,
+			"lastSuccessAt" = CASE WHEN $4 = '' THEN $2 ELSE "lastSuccessAt" END,
+			"lastStatusCode" = NULLIF($3, 0),
+			"lastError" = NULLIF($4, ''),
+			"failureCount" = CASE WHEN $4 = '' THEN 0 ELSE "failureCount" + 1 END
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id.String(), at, statusCode, deliveryErr); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) fromModel(model *webhookModel) (*webhook.Webhook, error) {
+	id, err := uuid.Parse(model.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook ID: %w", err)
+	}
+	sessionID, err := uuid.Parse(model.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook session ID: %w", err)
+	}
+	secret, err := r.secrets.Open(model.Secret.String, webhookSecretLocation(model.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret of webhook %s: %w", model.ID, err)
+	}
+
+	wh := &webhook.Webhook{
+		ID:            id,
+		SessionID:     sessionID,
+		URL:           model.URL,
+		Secret:        secret,
+		SchemaVersion: model.SchemaVersion,
+		Format:        model.Format,
+		Priority:      model.Priority,
+		Enabled:       model.Enabled,
+		State: webhook.DeliveryState{
+			LastStatusCode: int(model.LastStatus.Int64),
+			LastError:      model.LastError.String,
+			Failures:       model.FailureCount,
+		},
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+	if model.LastDelivery.Valid {
+		wh.State.LastDeliveryAt = &model.LastDelivery.Time
+	}
+	if model.LastSuccess.Valid {
+		wh.State.LastSuccessAt = &model.LastSuccess.Time
+	}
+
+	if len(model.Events) > 0 {
+		if err := json.Unmarshal(model.Events, &wh.Events); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook events: %w", err)
+		}
+	}
+	if len(model.Template) > 0 {
+		wh.Template = json.RawMessage(model.Template)
+	}
+
+	return wh, nil
+}
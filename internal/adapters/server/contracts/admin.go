@@ -0,0 +1,158 @@
+package contracts
+
+import "time"
+
+type ConfigReloadResponse struct {
+	Changed    []string  `json:"changed" example:"log.level"`
+	ReloadedAt time.Time `json:"reloadedAt" example:"2024-01-01T12:00:00Z"`
+} // @name ConfigReloadResponse
+
+type ListAuditLogRequest struct {
+	APIKey  string     `json:"apiKey,omitempty" query:"apiKey" example:"a0b1************d6ba"`
+	Session string     `json:"session,omitempty" query:"session" example:"my-session"`
+	Method  string     `json:"method,omitempty" query:"method" validate:"omitempty,oneof=POST PUT PATCH DELETE post put patch delete" example:"POST"`
+	Path    string     `json:"path,omitempty" query:"path" example:"/sessions/my-session/messages"`
+	Success *bool      `json:"success,omitempty" query:"success" example:"true"`
+	From    *time.Time `json:"from,omitempty" query:"from" example:"2024-01-01T00:00:00Z"`
+	To      *time.Time `json:"to,omitempty" query:"to" example:"2024-01-31T23:59:59Z"`
+	Limit   int        `json:"limit,omitempty" query:"limit" validate:"omitempty,min=1,max=100" example:"20"`
+	Offset  int        `json:"offset,omitempty" query:"offset" validate:"omitempty,min=0" example:"0"`
+} // @name ListAuditLogRequest
+
+type AuditLogEntry struct {
+	ID             string                 `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	APIKey         string                 `json:"apiKey" example:"a0b1************d6ba"`
+	ClientIP       string                 `json:"clientIp" example:"203.0.113.10"`
+	UserAgent      string                 `json:"userAgent,omitempty" example:"curl/8.4.0"`
+	Session        string                 `json:"session,omitempty" example:"my-session"`
+	Method         string                 `json:"method" example:"POST"`
+	Path           string                 `json:"path" example:"/sessions/my-session/messages/send/text"`
+	Route          string                 `json:"route,omitempty" example:"/sessions/{sessionName}/messages/send/text"`
+	StatusCode     int                    `json:"statusCode" example:"200"`
+	Success        bool                   `json:"success" example:"true"`
+	DurationMs     int64                  `json:"durationMs" example:"142"`
+	PayloadSummary map[string]interface{} `json:"payloadSummary,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt" example:"2024-01-01T12:00:00Z"`
+} // @name AuditLogEntry
+
+type ListAuditLogResponse struct {
+	Entries []AuditLogEntry `json:"entries"`
+	Total   int64           `json:"total" example:"42"`
+	Limit   int             `json:"limit" example:"20"`
+	Offset  int             `json:"offset" example:"0"`
+} // @name ListAuditLogResponse
+
+type InboundStageStats struct {
+	Name          string  `json:"name" example:"webhook"`
+	Processed     int64   `json:"processed" example:"1520"`
+	Stopped       int64   `json:"stopped" example:"0"`
+	Failed        int64   `json:"failed" example:"2"`
+	AvgDurationMs float64 `json:"avgDurationMs" example:"0.42"`
+	MaxDurationMs float64 `json:"maxDurationMs" example:"12.7"`
+	LastError     string  `json:"lastError,omitempty"`
+} // @name InboundStageStats
+
+type InboundPipelineResponse struct {
+	Stages []InboundStageStats `json:"stages"`
+} // @name InboundPipelineResponse
+
+type SendKindStats struct {
+	Kind          string  `json:"kind" example:"text"`
+	Count         int64   `json:"count" example:"830"`
+	Errors        int64   `json:"errors" example:"3"`
+	AvgDurationMs float64 `json:"avgDurationMs" example:"412.5"`
+	MaxDurationMs float64 `json:"maxDurationMs" example:"2310.2"`
+	LastError     string  `json:"lastError,omitempty"`
+} // @name SendKindStats
+
+type SendStatsResponse struct {
+	Kinds []SendKindStats `json:"kinds"`
+} // @name SendStatsResponse
+
+type MediaQueueStats struct {
+	Session string `json:"session" example:"my-session"`
+	Queued  int    `json:"queued" example:"3"`
+	Running int    `json:"running" example:"1"`
+} // @name MediaQueueStats
+
+// MediaPoolStatsResponse reports the media processing pool: its workers,
+// what is waiting, and the sessions with work queued or running.
+type MediaPoolStatsResponse struct {
+	Workers    int               `json:"workers" example:"4"`
+	Busy       int               `json:"busy" example:"2"`
+	Queued     int               `json:"queued" example:"5"`
+	QueueDepth int               `json:"queueDepth" example:"20"`
+	Processed  int64             `json:"processed" example:"1830"`
+	Rejected   int64             `json:"rejected" example:"0"`
+	AvgWaitMs  float64           `json:"avgWaitMs" example:"12.4"`
+	MaxWaitMs  float64           `json:"maxWaitMs" example:"950.2"`
+	Sessions   []MediaQueueStats `json:"sessions"`
+} // @name MediaPoolStatsResponse
+
+type SessionLoadStats struct {
+	Session       string `json:"session" example:"my-session"`
+	PendingEvents int    `json:"pendingEvents" example:"12"`
+} // @name SessionLoadStats
+
+// CapacityResponse reports how loaded the instance is, for autoscaling:
+// connected sessions against the cap (availableSessions is -1 without one),
+// process goroutines and heap, and the sessions with webhook deliveries
+// still running.
+type CapacityResponse struct {
+	MaxSessions       int                `json:"maxSessions" example:"200"`
+	ConnectedSessions int                `json:"connectedSessions" example:"148"`
+	LoadedSessions    int                `json:"loadedSessions" example:"160"`
+	AvailableSessions int                `json:"availableSessions" example:"52"`
+	Saturated         bool               `json:"saturated" example:"false"`
+	MaxPendingEvents  int                `json:"maxPendingEvents" example:"256"`
+	Goroutines        int                `json:"goroutines" example:"1830"`
+	HeapAllocBytes    uint64             `json:"heapAllocBytes" example:"412000000"`
+	Sessions          []SessionLoadStats `json:"sessions"`
+} // @name CapacityResponse
+
+// SecretsRotationResponse reports a re-encryption pass: rotated secrets
+// were sealed again under keyId, current ones already were, and failed ones
+// did not open with any configured key.
+type SecretsRotationResponse struct {
+	KeyID   string `json:"keyId" example:"2026-10"`
+	Rotated int    `json:"rotated" example:"12"`
+	Current int    `json:"current" example:"40"`
+	Failed  int    `json:"failed" example:"0"`
+} // @name SecretsRotationResponse
+
+type DatabasePoolStats struct {
+	MaxOpenConnections int     `json:"maxOpenConnections" example:"25"`
+	OpenConnections    int     `json:"openConnections" example:"7"`
+	InUse              int     `json:"inUse" example:"2"`
+	Idle               int     `json:"idle" example:"5"`
+	WaitCount          int64   `json:"waitCount" example:"12"`
+	WaitDurationMs     float64 `json:"waitDurationMs" example:"38.5"`
+	MaxIdleClosed      int64   `json:"maxIdleClosed" example:"40"`
+	MaxIdleTimeClosed  int64   `json:"maxIdleTimeClosed" example:"0"`
+	MaxLifetimeClosed  int64   `json:"maxLifetimeClosed" example:"18"`
+} // @name DatabasePoolStats
+
+type DatabaseOperationStats struct {
+	Operation     string  `json:"operation" example:"query"`
+	Count         int64   `json:"count" example:"48210"`
+	Errors        int64   `json:"errors" example:"3"`
+	AvgDurationMs float64 `json:"avgDurationMs" example:"1.8"`
+	MaxDurationMs float64 `json:"maxDurationMs" example:"212.4"`
+	LastError     string  `json:"lastError,omitempty"`
+} // @name DatabaseOperationStats
+
+// DatabaseReplicaStats is the read replica's last health check. While it
+// is unhealthy heavy reads go to the primary.
+type DatabaseReplicaStats struct {
+	Healthy   bool      `json:"healthy" example:"true"`
+	LagMs     int64     `json:"lagMs" example:"120"`
+	CheckedAt time.Time `json:"checkedAt"`
+	LastError string    `json:"lastError,omitempty"`
+} // @name DatabaseReplicaStats
+
+type DatabaseStatsResponse struct {
+	Pool           DatabasePoolStats        `json:"pool"`
+	Operations     []DatabaseOperationStats `json:"operations"`
+	ConnectRetries int64                    `json:"connectRetries" example:"0"`
+	Replica        *DatabaseReplicaStats    `json:"replica,omitempty"`
+} // @name DatabaseStatsResponse
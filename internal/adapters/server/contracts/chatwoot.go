@@ -0,0 +1,92 @@
+package contracts
+
+import "time"
+
+type ChatwootInboxMapping struct {
+	AccountID   int       `json:"accountId" example:"1"`
+	InboxID     int       `json:"inboxId" example:"7"`
+	SessionID   string    `json:"sessionId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SessionName string    `json:"sessionName,omitempty" example:"sales"`
+	CreatedAt   time.Time `json:"createdAt" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2024-01-01T12:00:00Z"`
+} // @name ChatwootInboxMapping
+
+type ListChatwootInboxesResponse struct {
+	Inboxes []ChatwootInboxMapping `json:"inboxes"`
+	Total   int                    `json:"total" example:"2"`
+} // @name ListChatwootInboxesResponse
+
+type MapChatwootInboxRequest struct {
+	Session string `json:"session" validate:"required" example:"sales"`
+	Force   bool   `json:"force" example:"false"`
+} // @name MapChatwootInboxRequest
+
+// ChatwootWebhookPayload is the subset of a Chatwoot account webhook used
+// to route agent replies back to WhatsApp. Message events nest the
+// conversation; conversation events are the conversation itself, so its
+// fields sit at the top level and the account is only found in Messages.
+type ChatwootWebhookPayload struct {
+	Event        string                `json:"event"`
+	ID           int                   `json:"id"`
+	Content      string                `json:"content"`
+	ContentType  string                `json:"content_type,omitempty"`
+	MessageType  string                `json:"message_type"`
+	Private      bool                  `json:"private"`
+	Account      *ChatwootAccount      `json:"account,omitempty"`
+	Conversation *ChatwootConversation `json:"conversation,omitempty"`
+	Inbox        *ChatwootInbox        `json:"inbox,omitempty"`
+	Sender       *ChatwootContact      `json:"sender,omitempty"`
+
+	UUID         string                    `json:"uuid,omitempty"`
+	Status       string                    `json:"status,omitempty"`
+	InboxID      int                       `json:"inbox_id,omitempty"`
+	Meta         *ChatwootConversationMeta `json:"meta,omitempty"`
+	ContactInbox *ChatwootContactInbox     `json:"contact_inbox,omitempty"`
+	Messages     []ChatwootMessage         `json:"messages,omitempty"`
+} // @name ChatwootWebhookPayload
+
+type ChatwootMessage struct {
+	ID        int `json:"id"`
+	AccountID int `json:"account_id"`
+} // @name ChatwootMessage
+
+type ChatwootAccount struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+} // @name ChatwootAccount
+
+type ChatwootConversation struct {
+	ID           int                       `json:"id"`
+	UUID         string                    `json:"uuid,omitempty"`
+	Status       string                    `json:"status"`
+	InboxID      int                       `json:"inbox_id"`
+	Meta         *ChatwootConversationMeta `json:"meta,omitempty"`
+	ContactInbox *ChatwootContactInbox     `json:"contact_inbox,omitempty"`
+} // @name ChatwootConversation
+
+type ChatwootConversationMeta struct {
+	Sender *ChatwootContact `json:"sender,omitempty"`
+} // @name ChatwootConversationMeta
+
+type ChatwootContactInbox struct {
+	SourceID string `json:"source_id"`
+} // @name ChatwootContactInbox
+
+type ChatwootContact struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phone_number"`
+	Type        string `json:"type,omitempty"`
+} // @name ChatwootContact
+
+type ChatwootInbox struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+} // @name ChatwootInbox
+
+type ChatwootWebhookResponse struct {
+	Routed    bool   `json:"routed" example:"true"`
+	SessionID string `json:"sessionId,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	MessageID string `json:"messageId,omitempty" example:"3EB0C767D71D"`
+	Reason    string `json:"reason,omitempty" example:"private note"`
+} // @name ChatwootWebhookResponse
@@ -4,9 +4,12 @@ import (
 	"time"
 )
 
+// CheckWhatsAppRequest takes phone numbers, with or without formatting, or
+// user JIDs. Refresh skips the cache and asks WhatsApp again.
 type CheckWhatsAppRequest struct {
-	PhoneNumbers []string `json:"phone_numbers" validate:"required,min=1,max=50"`
-}
+	PhoneNumbers []string `json:"phone_numbers" validate:"required,min=1,max=1000"`
+	Refresh      bool     `json:"refresh,omitempty"`
+} // @name CheckWhatsAppRequest
 
 type GetProfilePictureRequest struct {
 	JID     string `json:"jid" validate:"required"`
@@ -27,8 +30,10 @@ type GetDetailedUserInfoRequest struct {
 }
 
 type ListContactsRequest struct {
-	Limit  int `json:"limit,omitempty" validate:"omitempty,min=1,max=1000"`
-	Offset int `json:"offset,omitempty" validate:"omitempty,min=0"`
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=1000"`
+	Offset int    `json:"offset,omitempty" validate:"omitempty,min=0"`
+	Cursor string `json:"cursor,omitempty"`
+	Search string `json:"search,omitempty"`
 }
 
 type SyncContactsRequest struct {
@@ -51,15 +56,23 @@ type CheckWhatsAppResponse struct {
 	Results []WhatsAppCheckResult `json:"results"`
 	Total   int                   `json:"total"`
 	Found   int                   `json:"found"`
+	Cached  int                   `json:"cached"`
+	Invalid int                   `json:"invalid"`
 	Success bool                  `json:"success"`
 	Message string                `json:"message"`
-}
+} // @name CheckWhatsAppResponse
 
+// WhatsAppCheckResult answers one number as given in the request. Normalized
+// is the number's digits; Cached tells a cached answer from a live lookup.
 type WhatsAppCheckResult struct {
-	PhoneNumber  string `json:"phone_number"`
-	IsOnWhatsApp bool   `json:"is_on_whatsapp"`
-	JID          string `json:"jid,omitempty"`
-}
+	PhoneNumber  string     `json:"phone_number"`
+	Normalized   string     `json:"normalized,omitempty"`
+	IsOnWhatsApp bool       `json:"is_on_whatsapp"`
+	JID          string     `json:"jid,omitempty"`
+	Cached       bool       `json:"cached"`
+	CheckedAt    *time.Time `json:"checked_at,omitempty"`
+	Error        string     `json:"error,omitempty"`
+} // @name WhatsAppCheckResult
 
 type GetProfilePictureResponse struct {
 	JID        string `json:"jid"`
@@ -70,6 +83,19 @@ type GetProfilePictureResponse struct {
 	Message    string `json:"message"`
 }
 
+// ContactAvatarResponse is a contact's profile picture as last seen by the
+// session. Cached is set when WhatsApp was not asked for this answer.
+type ContactAvatarResponse struct {
+	JID        string     `json:"jid"`
+	HasPicture bool       `json:"has_picture"`
+	PictureID  string     `json:"picture_id,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Cached     bool       `json:"cached"`
+	CheckedAt  time.Time  `json:"checked_at"`
+	ChangedAt  time.Time  `json:"changed_at"`
+}
+
 type GetProfilePictureInfoResponse struct {
 	JID        string     `json:"jid"`
 	HasPicture bool       `json:"has_picture"`
@@ -104,12 +130,14 @@ type UserInfo struct {
 }
 
 type ListContactsResponse struct {
-	Contacts []ContactDetails `json:"contacts"`
-	Total    int              `json:"total"`
-	Limit    int              `json:"limit"`
-	Offset   int              `json:"offset"`
-	Success  bool             `json:"success"`
-	Message  string           `json:"message"`
+	Contacts   []ContactDetails `json:"contacts"`
+	Total      int              `json:"total"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	HasMore    bool             `json:"hasMore"`
+	Success    bool             `json:"success"`
+	Message    string           `json:"message"`
 }
 
 type ContactDetails struct {
@@ -221,3 +249,40 @@ type ValidateContactResponse struct {
 	Success   bool   `json:"success"`
 	Message   string `json:"message"`
 }
+
+// RegistryContact is the canonical record of a WhatsApp user across all
+// sessions, with what each session knows about them in Sessions.
+type RegistryContact struct {
+	ID              string                   `json:"id" example:"6f1e0b2a-8c4d-4a7e-9b3f-2d5c1e8a7b90"`
+	JID             string                   `json:"jid" example:"5511999999999@s.whatsapp.net"`
+	PhoneNumber     string                   `json:"phone_number" example:"5511999999999"`
+	DisplayName     string                   `json:"display_name,omitempty" example:"John Doe"`
+	Name            string                   `json:"name,omitempty" example:"John Doe"`
+	PushName        string                   `json:"push_name,omitempty" example:"John"`
+	BusinessName    string                   `json:"business_name,omitempty" example:"ACME Inc."`
+	IsBusiness      bool                     `json:"is_business" example:"false"`
+	AvatarPictureID string                   `json:"avatar_picture_id,omitempty" example:"1700000000"`
+	AvatarURL       string                   `json:"avatar_url,omitempty"`
+	Sessions        []RegistryContactSession `json:"sessions"`
+	CreatedAt       time.Time                `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt       time.Time                `json:"updated_at" example:"2024-01-01T12:00:00Z"`
+} // @name RegistryContact
+
+type RegistryContactSession struct {
+	SessionID       string    `json:"session_id" example:"6f1e0b2a-8c4d-4a7e-9b3f-2d5c1e8a7b90"`
+	SessionName     string    `json:"session_name" example:"sales"`
+	Name            string    `json:"name,omitempty" example:"John Doe"`
+	PushName        string    `json:"push_name,omitempty" example:"John"`
+	BusinessName    string    `json:"business_name,omitempty" example:"ACME Inc."`
+	IsContact       bool      `json:"is_contact" example:"true"`
+	AvatarPictureID string    `json:"avatar_picture_id,omitempty" example:"1700000000"`
+	FirstSeenAt     time.Time `json:"first_seen_at" example:"2024-01-01T12:00:00Z"`
+	LastSeenAt      time.Time `json:"last_seen_at" example:"2024-01-01T12:00:00Z"`
+} // @name RegistryContactSession
+
+type ListRegistryContactsResponse struct {
+	Contacts   []RegistryContact `json:"contacts"`
+	Limit      int               `json:"limit" example:"20"`
+	NextCursor string            `json:"nextCursor,omitempty" example:"eyJrIjoiNTUxMTk5OTk5OTk5OUBzLndoYXRzYXBwLm5ldCJ9"`
+	HasMore    bool              `json:"hasMore" example:"false"`
+} // @name ListRegistryContactsResponse
@@ -0,0 +1,23 @@
+package contracts
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PutConversationStateRequest replaces a chat's state. version makes the
+// write conditional: 0 when the chat has no state yet, otherwise the
+// version last read. ttl_seconds of 0 keeps the state until it is deleted.
+type PutConversationStateRequest struct {
+	Data       json.RawMessage `json:"data" swaggertype:"object" validate:"required"`
+	TTLSeconds int             `json:"ttl_seconds,omitempty" validate:"omitempty,min=0,max=2592000" example:"3600"`
+	Version    *int64          `json:"version,omitempty" validate:"omitempty,min=0" example:"3"`
+} // @name PutConversationStateRequest
+
+type ConversationStateResponse struct {
+	ChatJID   string          `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	Version   int64           `json:"version" example:"4"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty" example:"2024-01-01T13:00:00Z"`
+	UpdatedAt time.Time       `json:"updated_at" example:"2024-01-01T12:00:00Z"`
+} // @name ConversationStateResponse
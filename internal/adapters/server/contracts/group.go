@@ -8,29 +8,58 @@ type CreateGroupRequest struct {
 	Name         string   `json:"name" validate:"required,min=1,max=25"`
 	Description  string   `json:"description,omitempty" validate:"max=512"`
 	Participants []string `json:"participants" validate:"required,min=1,max=256"`
-}
 
+	Settings *CreateGroupSettings `json:"settings,omitempty"`
+} // @name CreateGroupRequest
+
+// CreateGroupSettings are applied while the group is being created, so callers
+// don't need follow-up requests. disappearing_timer is in seconds (0, 86400,
+// 604800 or 7776000).
+type CreateGroupSettings struct {
+	DisappearingTimer uint32 `json:"disappearing_timer,omitempty" validate:"omitempty,oneof=0 86400 604800 7776000"`
+	Announce          bool   `json:"announce,omitempty"`
+	MemberAddMode     string `json:"member_add_mode,omitempty" validate:"omitempty,oneof=all_members only_admins"`
+	JoinApprovalMode  string `json:"join_approval_mode,omitempty" validate:"omitempty,oneof=auto admin_approval"`
+} // @name CreateGroupSettings
+
+// UpdateParticipantsRequest changes group participants. With the add
+// action, invite_on_failure sends the group invite link by direct message to
+// participants whose privacy settings keep them from being added, after
+// invite_message when given.
 type UpdateParticipantsRequest struct {
-	GroupJID     string   `json:"group_jid" validate:"required"`
-	Action       string   `json:"action" validate:"required,oneof=add remove promote demote"`
-	Participants []string `json:"participants" validate:"required,min=1"`
-}
+	GroupJID        string   `json:"group_jid" validate:"required"`
+	Action          string   `json:"action" validate:"required,oneof=add remove promote demote"`
+	Participants    []string `json:"participants" validate:"required,min=1"`
+	InviteOnFailure bool     `json:"invite_on_failure,omitempty" example:"true"`
+	InviteMessage   string   `json:"invite_message,omitempty" validate:"max=1000" example:"Join our group:"`
+} // @name UpdateParticipantsRequest
+
+type BulkAddParticipantsRequest struct {
+	PhoneNumbers      []string `json:"phone_numbers" validate:"required,min=1,max=5000"`
+	BatchSize         int      `json:"batch_size,omitempty" validate:"omitempty,min=1,max=50"`
+	BatchDelaySeconds int      `json:"batch_delay_seconds,omitempty" validate:"omitempty,min=1,max=300"`
+	InviteOnFailure   bool     `json:"invite_on_failure,omitempty" example:"true"`
+	InviteMessage     string   `json:"invite_message,omitempty" validate:"max=1000" example:"Join our group:"`
+} // @name BulkAddParticipantsRequest
 
 type SetGroupNameRequest struct {
 	GroupJID string `json:"group_jid" validate:"required"`
 	Name     string `json:"name" validate:"required,min=1,max=25"`
-}
+} // @name SetGroupNameRequest
 
 type SetGroupDescriptionRequest struct {
 	GroupJID    string `json:"group_jid" validate:"required"`
 	Description string `json:"description" validate:"max=512"`
-}
+} // @name SetGroupDescriptionRequest
 
+// SetGroupPhotoRequest carries the picture as base64 (plain or data URI) or a
+// URL to fetch it from. Multipart uploads fill Data directly.
 type SetGroupPhotoRequest struct {
-	GroupJID  string `json:"group_jid" validate:"required"`
-	PhotoData []byte `json:"photo_data" validate:"required"`
-	MimeType  string `json:"mime_type" validate:"required"`
-}
+	GroupJID string `json:"group_jid" validate:"required"`
+	Image    string `json:"image,omitempty" example:"data:image/jpeg;base64,/9j/4AAQSkZJRg..."`
+	URL      string `json:"url,omitempty" validate:"omitempty,url" example:"https://example.com/photo.jpg"`
+	Data     []byte `json:"-"`
+} // @name SetGroupPhotoRequest
 
 type UpdateGroupSettingsRequest struct {
 	GroupJID         string `json:"group_jid" validate:"required"`
@@ -39,131 +68,210 @@ type UpdateGroupSettingsRequest struct {
 	JoinApprovalMode string `json:"join_approval_mode,omitempty" validate:"omitempty,oneof=auto admin_approval"`
 	MemberAddMode    string `json:"member_add_mode,omitempty" validate:"omitempty,oneof=all_members only_admins"`
 	Locked           *bool  `json:"locked,omitempty"`
-}
+} // @name UpdateGroupSettingsRequest
 
 type GetInviteLinkRequest struct {
 	GroupJID string `json:"group_jid" validate:"required"`
-}
+} // @name GetInviteLinkRequest
 
 type JoinGroupViaLinkRequest struct {
 	InviteLink string `json:"invite_link" validate:"required"`
-}
+} // @name JoinGroupViaLinkRequest
 
 type LeaveGroupRequest struct {
 	GroupJID string `json:"group_jid" validate:"required"`
-}
+} // @name LeaveGroupRequest
 
 type GetGroupInfoFromInviteRequest struct {
 	GroupJID string `json:"group_jid" validate:"required"`
 	Code     string `json:"code" validate:"required"`
-}
+} // @name GetGroupInfoFromInviteRequest
 
 type JoinGroupWithInviteRequest struct {
 	GroupJID string `json:"group_jid" validate:"required"`
 	Code     string `json:"code" validate:"required"`
-}
+} // @name JoinGroupWithInviteRequest
 
 type GroupRequestActionRequest struct {
 	GroupJID      string   `json:"group_jid" validate:"required"`
 	RequesterJIDs []string `json:"requester_jids" validate:"required,min=1"`
 	Action        string   `json:"action" validate:"required,oneof=approve reject"`
-}
+} // @name GroupRequestActionRequest
 
 type CreateGroupResponse struct {
-	GroupJID     string    `json:"group_jid"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description,omitempty"`
-	Participants []string  `json:"participants"`
-	CreatedAt    time.Time `json:"created_at"`
-	Success      bool      `json:"success"`
-	Message      string    `json:"message"`
-}
+	GroupJID     string        `json:"group_jid"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	Participants []string      `json:"participants"`
+	Settings     GroupSettings `json:"settings"`
+	CreatedAt    time.Time     `json:"created_at"`
+	Success      bool          `json:"success"`
+	Message      string        `json:"message"`
+} // @name CreateGroupResponse
+
+type ListGroupsRequest struct {
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor string `json:"cursor,omitempty"`
+} // @name ListGroupsRequest
 
 type ListGroupsResponse struct {
-	Groups  []GroupInfo `json:"groups"`
-	Count   int         `json:"count"`
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-}
-
-type GroupInfo struct {
-	GroupJID     string    `json:"group_jid"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description,omitempty"`
-	Owner        string    `json:"owner"`
-	Participants int       `json:"participants"`
-	CreatedAt    time.Time `json:"created_at"`
-}
+	Groups     []GroupSummary `json:"groups"`
+	Count      int            `json:"count"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	HasMore    bool           `json:"hasMore"`
+	Success    bool           `json:"success"`
+	Message    string         `json:"message"`
+} // @name ListGroupsResponse
+
+// GroupSummary is a group in a listing, with its participants counted.
+type GroupSummary struct {
+	GroupJID     string    `json:"group_jid" example:"120363025246125486@g.us"`
+	Name         string    `json:"name" example:"Equipe de vendas"`
+	Description  string    `json:"description,omitempty" example:"Avisos da equipe"`
+	Owner        string    `json:"owner" example:"5511999999999@s.whatsapp.net"`
+	Participants int       `json:"participants" example:"12"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+} // @name GroupSummary
+
+// GroupResponse is a group with its participants and settings. The group
+// DTOs are mapped field by field from the gateway's group types, so a
+// WhatsApp library upgrade cannot change the API schema.
+type GroupResponse struct {
+	GroupJID         string                `json:"group_jid" example:"120363025246125486@g.us"`
+	Name             string                `json:"name" example:"Equipe de vendas"`
+	Description      string                `json:"description,omitempty" example:"Avisos da equipe"`
+	Owner            string                `json:"owner" example:"5511999999999@s.whatsapp.net"`
+	ParticipantCount int                   `json:"participant_count" example:"12"`
+	Participants     []ParticipantResponse `json:"participants"`
+	Settings         GroupSettings         `json:"settings"`
+	CreatedAt        time.Time             `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt        time.Time             `json:"updated_at" example:"2024-01-02T08:30:00Z"`
+} // @name GroupResponse
 
 type GetGroupInfoResponse struct {
-	GroupJID     string            `json:"group_jid"`
-	Name         string            `json:"name"`
-	Description  string            `json:"description,omitempty"`
-	Owner        string            `json:"owner"`
-	Participants []ParticipantInfo `json:"participants"`
-	Settings     GroupSettings     `json:"settings"`
-	CreatedAt    time.Time         `json:"created_at"`
-	UpdatedAt    time.Time         `json:"updated_at"`
-	Success      bool              `json:"success"`
-	Message      string            `json:"message"`
-}
-
-type ParticipantInfo struct {
-	JID      string    `json:"jid"`
-	Role     string    `json:"role"`
-	JoinedAt time.Time `json:"joined_at"`
-	Status   string    `json:"status"`
-}
+	GroupResponse
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+} // @name GetGroupInfoResponse
+
+// ParticipantResponse is a group member. Role is member, admin or owner;
+// IsAdmin holds for admins and the owner, IsSuperAdmin for the owner only.
+type ParticipantResponse struct {
+	JID          string              `json:"jid" example:"5511999999999@s.whatsapp.net"`
+	PhoneJID     string              `json:"phone_jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	Role         string              `json:"role" example:"admin" enums:"member,admin,owner"`
+	IsAdmin      bool                `json:"is_admin" example:"true"`
+	IsSuperAdmin bool                `json:"is_super_admin" example:"false"`
+	JoinedAt     time.Time           `json:"joined_at" example:"2024-01-01T12:00:00Z"`
+	Status       string              `json:"status" example:"active"`
+	Contact      *ParticipantContact `json:"contact,omitempty"`
+} // @name ParticipantResponse
+
+// ParticipantContact is what the session's device knows about a participant,
+// returned with includeContacts=true.
+type ParticipantContact struct {
+	Name         string `json:"name,omitempty"`
+	PushName     string `json:"push_name,omitempty"`
+	BusinessName string `json:"business_name,omitempty"`
+	IsBusiness   bool   `json:"is_business"`
+	IsContact    bool   `json:"is_contact"`
+} // @name ParticipantContact
 
 type GroupSettings struct {
-	Announce         bool   `json:"announce"`
-	Restrict         bool   `json:"restrict"`
-	JoinApprovalMode string `json:"join_approval_mode"`
-	MemberAddMode    string `json:"member_add_mode"`
-	Locked           bool   `json:"locked"`
-}
+	Announce          bool   `json:"announce"`
+	Restrict          bool   `json:"restrict"`
+	JoinApprovalMode  string `json:"join_approval_mode"`
+	MemberAddMode     string `json:"member_add_mode"`
+	Locked            bool   `json:"locked"`
+	DisappearingTimer uint32 `json:"disappearing_timer"`
+} // @name GroupSettings
 
 type UpdateParticipantsResponse struct {
-	GroupJID     string   `json:"group_jid"`
-	Action       string   `json:"action"`
-	Participants []string `json:"participants"`
-	Success      bool     `json:"success"`
-	Message      string   `json:"message"`
-}
+	GroupJID     string               `json:"group_jid"`
+	Action       string               `json:"action"`
+	Participants []string             `json:"participants"`
+	Results      []ParticipantOutcome `json:"results,omitempty"`
+	Success      bool                 `json:"success"`
+	Message      string               `json:"message"`
+} // @name UpdateParticipantsResponse
+
+// ParticipantOutcome is what happened to one participant of an add. Status
+// is added, already_member, needs_invite_link or failed; invited_via_link
+// tells whether the invite link reached a participant who could not be
+// added, and invite_error why it did not.
+type ParticipantOutcome struct {
+	JID              string     `json:"jid"`
+	Status           string     `json:"status"`
+	ErrorCode        int        `json:"error_code,omitempty"`
+	InviteCode       string     `json:"invite_code,omitempty"`
+	InviteExpiration *time.Time `json:"invite_expiration,omitempty"`
+	InvitedViaLink   bool       `json:"invited_via_link"`
+	InviteError      string     `json:"invite_error,omitempty"`
+} // @name ParticipantOutcome
+
+type BulkAddParticipantsResponse struct {
+	JobID       string                   `json:"job_id"`
+	GroupJID    string                   `json:"group_jid"`
+	Status      string                   `json:"status"`
+	Total       int                      `json:"total"`
+	Processed   int                      `json:"processed"`
+	Summary     map[string]int           `json:"summary"`
+	Results     []BulkParticipantOutcome `json:"results"`
+	CreatedAt   time.Time                `json:"created_at"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+	Success     bool                     `json:"success"`
+	Message     string                   `json:"message"`
+} // @name BulkAddParticipantsResponse
+
+type BulkParticipantOutcome struct {
+	PhoneNumber      string     `json:"phone_number"`
+	JID              string     `json:"jid,omitempty"`
+	Status           string     `json:"status"`
+	ErrorCode        int        `json:"error_code,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	InviteCode       string     `json:"invite_code,omitempty"`
+	InviteExpiration *time.Time `json:"invite_expiration,omitempty"`
+	InvitedViaLink   bool       `json:"invited_via_link"`
+	InviteError      string     `json:"invite_error,omitempty"`
+} // @name BulkParticipantOutcome
 
 type SetGroupNameResponse struct {
 	GroupJID string `json:"group_jid"`
 	Name     string `json:"name"`
 	Success  bool   `json:"success"`
 	Message  string `json:"message"`
-}
+} // @name SetGroupNameResponse
 
 type SetGroupDescriptionResponse struct {
 	GroupJID    string `json:"group_jid"`
 	Description string `json:"description"`
 	Success     bool   `json:"success"`
 	Message     string `json:"message"`
-}
+} // @name SetGroupDescriptionResponse
 
 type SetGroupPhotoResponse struct {
-	GroupJID string `json:"group_jid"`
-	Success  bool   `json:"success"`
-	Message  string `json:"message"`
-}
+	GroupJID  string `json:"group_jid"`
+	PictureID string `json:"picture_id"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+} // @name SetGroupPhotoResponse
 
 type UpdateGroupSettingsResponse struct {
 	GroupJID string        `json:"group_jid"`
 	Settings GroupSettings `json:"settings"`
 	Success  bool          `json:"success"`
 	Message  string        `json:"message"`
-}
+} // @name UpdateGroupSettingsResponse
 
-type GetInviteLinkResponse struct {
-	GroupJID   string `json:"group_jid"`
-	InviteLink string `json:"invite_link"`
+// InviteLinkResponse is the group's current invite link and its code.
+type InviteLinkResponse struct {
+	GroupJID   string `json:"group_jid" example:"120363025246125486@g.us"`
+	InviteLink string `json:"invite_link" example:"https://chat.whatsapp.com/AbCdEfGhIjKlMnOpQrStUv"`
+	Code       string `json:"code" example:"AbCdEfGhIjKlMnOpQrStUv"`
 	Success    bool   `json:"success"`
 	Message    string `json:"message"`
-}
+} // @name InviteLinkResponse
 
 type JoinGroupResponse struct {
 	GroupJID string `json:"group_jid"`
@@ -171,14 +279,14 @@ type JoinGroupResponse struct {
 	Status   string `json:"status"`
 	Success  bool   `json:"success"`
 	Message  string `json:"message"`
-}
+} // @name JoinGroupResponse
 
 type LeaveGroupResponse struct {
 	GroupJID string `json:"group_jid"`
 	Status   string `json:"status"`
 	Success  bool   `json:"success"`
 	Message  string `json:"message"`
-}
+} // @name LeaveGroupResponse
 
 type GetGroupRequestParticipantsResponse struct {
 	GroupJID     string             `json:"group_jid"`
@@ -186,13 +294,13 @@ type GetGroupRequestParticipantsResponse struct {
 	Count        int                `json:"count"`
 	Success      bool               `json:"success"`
 	Message      string             `json:"message"`
-}
+} // @name GetGroupRequestParticipantsResponse
 
 type GroupRequestInfo struct {
 	RequesterJID string    `json:"requester_jid"`
 	RequestedAt  time.Time `json:"requested_at"`
 	Status       string    `json:"status"`
-}
+} // @name GroupRequestInfo
 
 type UpdateGroupRequestParticipantsResponse struct {
 	GroupJID      string   `json:"group_jid"`
@@ -200,15 +308,15 @@ type UpdateGroupRequestParticipantsResponse struct {
 	RequesterJIDs []string `json:"requester_jids"`
 	Success       bool     `json:"success"`
 	Message       string   `json:"message"`
-}
+} // @name UpdateGroupRequestParticipantsResponse
 
 type GetGroupInfoFromInviteResponse struct {
-	GroupJID  string    `json:"group_jid"`
-	Code      string    `json:"code"`
-	GroupInfo GroupInfo `json:"group_info"`
-	Success   bool      `json:"success"`
-	Message   string    `json:"message"`
-}
+	GroupJID  string       `json:"group_jid"`
+	Code      string       `json:"code"`
+	GroupInfo GroupSummary `json:"group_info"`
+	Success   bool         `json:"success"`
+	Message   string       `json:"message"`
+} // @name GetGroupInfoFromInviteResponse
 
 type JoinGroupWithInviteResponse struct {
 	GroupJID string `json:"group_jid"`
@@ -216,25 +324,42 @@ type JoinGroupWithInviteResponse struct {
 	Status   string `json:"status"`
 	Success  bool   `json:"success"`
 	Message  string `json:"message"`
-}
+} // @name JoinGroupWithInviteResponse
 
 type SetGroupJoinApprovalModeResponse struct {
 	GroupJID         string `json:"group_jid"`
 	JoinApprovalMode string `json:"join_approval_mode"`
 	Success          bool   `json:"success"`
 	Message          string `json:"message"`
-}
+} // @name SetGroupJoinApprovalModeResponse
 
 type SetGroupMemberAddModeResponse struct {
 	GroupJID      string `json:"group_jid"`
 	MemberAddMode string `json:"member_add_mode"`
 	Success       bool   `json:"success"`
 	Message       string `json:"message"`
-}
+} // @name SetGroupMemberAddModeResponse
+
+// GroupInviteInfoResponse previews a group behind an invite link without
+// joining it.
+type GroupInviteInfoResponse struct {
+	InviteLink       string    `json:"invite_link"`
+	GroupJID         string    `json:"group_jid"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	Owner            string    `json:"owner,omitempty"`
+	Size             int       `json:"size"`
+	CreatedAt        time.Time `json:"created_at"`
+	ApprovalRequired bool      `json:"approval_required"`
+	Announce         bool      `json:"announce"`
+	Locked           bool      `json:"locked"`
+	Success          bool      `json:"success"`
+	Message          string    `json:"message"`
+} // @name GroupInviteInfoResponse
 
 type GetGroupInfoFromLinkResponse struct {
-	InviteLink string    `json:"invite_link"`
-	GroupInfo  GroupInfo `json:"group_info"`
-	Success    bool      `json:"success"`
-	Message    string    `json:"message"`
-}
+	InviteLink string       `json:"invite_link"`
+	GroupInfo  GroupSummary `json:"group_info"`
+	Success    bool         `json:"success"`
+	Message    string       `json:"message"`
+} // @name GetGroupInfoFromLinkResponse
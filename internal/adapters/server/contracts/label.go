@@ -0,0 +1,51 @@
+package contracts
+
+import "time"
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" validate:"required,max=255" example:"Novo cliente"`
+	Color int32  `json:"color" validate:"min=0,max=19" example:"2"`
+} // @name CreateLabelRequest
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,max=255" example:"Pedido pago"`
+	Color *int32  `json:"color,omitempty" validate:"omitempty,min=0,max=19" example:"5"`
+} // @name UpdateLabelRequest
+
+type LabelResponse struct {
+	LabelID   string    `json:"label_id" example:"6"`
+	Name      string    `json:"name" example:"Novo cliente"`
+	Color     int32     `json:"color" example:"2"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T12:00:00Z"`
+} // @name LabelResponse
+
+type ListLabelsResponse struct {
+	Labels []LabelResponse `json:"labels"`
+	Total  int             `json:"total" example:"5"`
+} // @name ListLabelsResponse
+
+type LabelAssociationRequest struct {
+	ChatJID   string `json:"chat_jid" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	MessageID string `json:"message_id,omitempty" example:"3EB0C767D71D"`
+} // @name LabelAssociationRequest
+
+type LabelAssociation struct {
+	LabelID   string    `json:"label_id" example:"6"`
+	ChatJID   string    `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	MessageID string    `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+} // @name LabelAssociation
+
+type LabelAssociationResponse struct {
+	LabelID   string `json:"label_id" example:"6"`
+	ChatJID   string `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	MessageID string `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	Labeled   bool   `json:"labeled" example:"true"`
+} // @name LabelAssociationResponse
+
+type ListLabelAssociationsResponse struct {
+	LabelID      string             `json:"label_id" example:"6"`
+	Associations []LabelAssociation `json:"associations"`
+	Total        int                `json:"total" example:"3"`
+} // @name ListLabelAssociationsResponse
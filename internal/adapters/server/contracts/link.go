@@ -0,0 +1,44 @@
+package contracts
+
+import "time"
+
+type TrackedLinkStats struct {
+	Code         string     `json:"code" example:"9f2c4e1a7b3d"`
+	URL          string     `json:"url" example:"https://acme.com/promo"`
+	MessageID    string     `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	ChatJID      string     `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Campaign     string     `json:"campaign,omitempty" example:"black-friday"`
+	Clicks       int        `json:"clicks" example:"2"`
+	FirstClickAt *time.Time `json:"first_click_at,omitempty" example:"2024-01-01T12:05:00Z"`
+	LastClickAt  *time.Time `json:"last_click_at,omitempty" example:"2024-01-01T18:30:00Z"`
+	CreatedAt    time.Time  `json:"created_at" example:"2024-01-01T12:00:00Z"`
+} // @name TrackedLinkStats
+
+// LinkStatsResponse sums up the tracked links of a message or campaign.
+// Recipients counts the chats the links went to and Clicked those that
+// followed at least one.
+type LinkStatsResponse struct {
+	MessageID  string             `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	Campaign   string             `json:"campaign,omitempty" example:"black-friday"`
+	Links      int                `json:"links" example:"120"`
+	Clicks     int                `json:"clicks" example:"45"`
+	Recipients int                `json:"recipients" example:"120"`
+	Clicked    int                `json:"clicked" example:"38"`
+	PerLink    []TrackedLinkStats `json:"per_link"`
+} // @name LinkStatsResponse
+
+type LinkClick struct {
+	Code      string    `json:"code" example:"9f2c4e1a7b3d"`
+	URL       string    `json:"url" example:"https://acme.com/promo"`
+	MessageID string    `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	ChatJID   string    `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Campaign  string    `json:"campaign,omitempty" example:"black-friday"`
+	ClickedAt time.Time `json:"clicked_at" example:"2024-01-01T12:05:00Z"`
+	IP        string    `json:"ip,omitempty" example:"203.0.113.7"`
+	UserAgent string    `json:"user_agent,omitempty" example:"Mozilla/5.0 (Linux; Android 14)"`
+} // @name LinkClick
+
+type ListLinkClicksResponse struct {
+	Clicks []LinkClick `json:"clicks"`
+	Total  int         `json:"total" example:"45"`
+} // @name ListLinkClicksResponse
@@ -0,0 +1,13 @@
+package contracts
+
+type DownloadMediaRequest struct {
+	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
+} // @name DownloadMediaRequest
+
+type DownloadMediaResponse struct {
+	MessageID string `json:"message_id" example:"3EB0C767D71D"`
+	Type      string `json:"type" example:"image"`
+	MimeType  string `json:"mime_type" example:"image/jpeg"`
+	FileName  string `json:"file_name,omitempty" example:"invoice.pdf"`
+	FileSize  int    `json:"file_size" example:"204800"`
+} // @name DownloadMediaResponse
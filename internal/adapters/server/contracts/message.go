@@ -24,23 +24,43 @@ type ListMessagesRequest struct {
 } // @name ListMessagesRequest
 
 type SendTextMessageRequest struct {
-	RemoteJID   string       `json:"remoteJid" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Body        string       `json:"body" validate:"required" example:"Hello, World!"`
-	ContextInfo *ContextInfo `json:"contextInfo,omitempty"`
+	RemoteJID   string              `json:"remoteJid" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Body        string              `json:"body" validate:"required,max=65536" example:"Hello, World!"`
+	ReplyTo     string              `json:"replyTo,omitempty" example:"3EB0C767D71D"`
+	ContextInfo *ContextInfo        `json:"contextInfo,omitempty"`
+	QuietHours  string              `json:"quietHours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+	Footer      *string             `json:"footer,omitempty" validate:"omitempty,max=512" example:"— Sent via ACME Support"`
+	Formatting  *TextFormatSettings `json:"formatting,omitempty"`
+	TrackLinks  bool                `json:"trackLinks,omitempty" example:"true"`
+	Campaign    string              `json:"campaign,omitempty" validate:"omitempty,max=100" example:"black-friday"`
 } // @name SendTextMessageRequest
 
+// ReplyTarget returns the message the text replies to, from replyTo or else
+// from an explicit contextInfo.
+func (r *SendTextMessageRequest) ReplyTarget() (messageID, participant string) {
+	if r.ReplyTo != "" || r.ContextInfo == nil {
+		return r.ReplyTo, ""
+	}
+	return r.ContextInfo.StanzaID, r.ContextInfo.Participant
+}
+
 type ContextInfo struct {
 	StanzaID    string `json:"stanzaId" validate:"required" example:"ABCD1234abcd"`
 	Participant string `json:"participant,omitempty" example:"5511999999999@s.whatsapp.net"`
 } // @name ContextInfo
 
 type SendMediaMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	MediaURL string `json:"media_url" validate:"required,url" example:"https://example.com/image.jpg"`
-	Type     string `json:"type" validate:"required,oneof=image audio video document" example:"image"`
-	Caption  string `json:"caption,omitempty" example:"Check this out!"`
-	Filename string `json:"filename,omitempty" example:"image.jpg"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string              `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	MediaURL   string              `json:"media_url" validate:"required,url" example:"https://example.com/image.jpg"`
+	Type       string              `json:"type" validate:"required,oneof=image audio video document" example:"image"`
+	Caption    string              `json:"caption,omitempty" validate:"max=1024" example:"Check this out!"`
+	Filename   string              `json:"filename,omitempty" validate:"max=255" example:"image.jpg"`
+	ReplyTo    string              `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string              `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+	Footer     *string             `json:"footer,omitempty" validate:"omitempty,max=512" example:"— Sent via ACME Support"`
+	Formatting *TextFormatSettings `json:"formatting,omitempty"`
+	TrackLinks bool                `json:"track_links,omitempty" example:"true"`
+	Campaign   string              `json:"campaign,omitempty" validate:"omitempty,max=100" example:"black-friday"`
 } // @name SendMediaMessageRequest
 
 type UpdateSyncStatusRequest struct {
@@ -50,63 +70,104 @@ type UpdateSyncStatusRequest struct {
 } // @name UpdateSyncStatusRequest
 
 type SendImageMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	File     string `json:"file" validate:"required" example:"base64_image_data"`
-	Caption  string `json:"caption,omitempty" example:"Check this image!"`
-	Filename string `json:"filename,omitempty" example:"image.jpg"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string              `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	File       string              `json:"file" validate:"required" example:"base64_image_data"`
+	Caption    string              `json:"caption,omitempty" validate:"max=1024" example:"Check this image!"`
+	Filename   string              `json:"filename,omitempty" validate:"max=255" example:"image.jpg"`
+	ReplyTo    string              `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string              `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+	Footer     *string             `json:"footer,omitempty" validate:"omitempty,max=512" example:"— Sent via ACME Support"`
+	Formatting *TextFormatSettings `json:"formatting,omitempty"`
+	TrackLinks bool                `json:"track_links,omitempty" example:"true"`
+	Campaign   string              `json:"campaign,omitempty" validate:"omitempty,max=100" example:"black-friday"`
 } // @name SendImageMessageRequest
 
 type SendAudioMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	File     string `json:"file" validate:"required" example:"base64_audio_data"`
-	Caption  string `json:"caption,omitempty" example:"Audio message"`
-	Filename string `json:"filename,omitempty" example:"audio.mp3"`
-	MimeType string `json:"mime_type,omitempty" example:"audio/mpeg"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	File       string `json:"file" validate:"required" example:"base64_audio_data"`
+	Caption    string `json:"caption,omitempty" validate:"max=1024" example:"Audio message"`
+	Filename   string `json:"filename,omitempty" validate:"max=255" example:"audio.mp3"`
+	MimeType   string `json:"mime_type,omitempty" example:"audio/mpeg"`
+	ReplyTo    string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendAudioMessageRequest
 
 type SendVideoMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	File     string `json:"file" validate:"required" example:"base64_video_data"`
-	Caption  string `json:"caption,omitempty" example:"Check this video!"`
-	Filename string `json:"filename,omitempty" example:"video.mp4"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string              `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	File       string              `json:"file" validate:"required" example:"base64_video_data"`
+	Caption    string              `json:"caption,omitempty" validate:"max=1024" example:"Check this video!"`
+	Filename   string              `json:"filename,omitempty" validate:"max=255" example:"video.mp4"`
+	ReplyTo    string              `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string              `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+	Footer     *string             `json:"footer,omitempty" validate:"omitempty,max=512" example:"— Sent via ACME Support"`
+	Formatting *TextFormatSettings `json:"formatting,omitempty"`
+	TrackLinks bool                `json:"track_links,omitempty" example:"true"`
+	Campaign   string              `json:"campaign,omitempty" validate:"omitempty,max=100" example:"black-friday"`
 } // @name SendVideoMessageRequest
 
 type SendDocumentMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	File     string `json:"file" validate:"required" example:"base64_document_data"`
-	Caption  string `json:"caption,omitempty" example:"Document"`
-	Filename string `json:"filename" validate:"required" example:"document.pdf"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string              `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	File       string              `json:"file" validate:"required" example:"base64_document_data"`
+	Caption    string              `json:"caption,omitempty" validate:"max=1024" example:"Document"`
+	Filename   string              `json:"filename" validate:"required,max=255" example:"document.pdf"`
+	ReplyTo    string              `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string              `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+	Footer     *string             `json:"footer,omitempty" validate:"omitempty,max=512" example:"— Sent via ACME Support"`
+	Formatting *TextFormatSettings `json:"formatting,omitempty"`
+	TrackLinks bool                `json:"track_links,omitempty" example:"true"`
+	Campaign   string              `json:"campaign,omitempty" validate:"omitempty,max=100" example:"black-friday"`
 } // @name SendDocumentMessageRequest
 
 type SendStickerMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	File     string `json:"file" validate:"required" example:"base64_sticker_data"`
-	MimeType string `json:"mime_type,omitempty" example:"image/webp"`
-	ReplyTo  string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	File       string `json:"file" validate:"required" example:"base64_sticker_data"`
+	MimeType   string `json:"mime_type,omitempty" example:"image/webp"`
+	ReplyTo    string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendStickerMessageRequest
 
 type SendLocationMessageRequest struct {
-	To        string  `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Latitude  float64 `json:"latitude" validate:"required" example:"-23.5505"`
-	Longitude float64 `json:"longitude" validate:"required" example:"-46.6333"`
-	Name      string  `json:"name,omitempty" example:"São Paulo"`
-	Address   string  `json:"address,omitempty" example:"São Paulo, SP, Brazil"`
-	ReplyTo   string  `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string  `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Latitude   float64 `json:"latitude" validate:"required,gte=-90,lte=90" example:"-23.5505"`
+	Longitude  float64 `json:"longitude" validate:"required,gte=-180,lte=180" example:"-46.6333"`
+	Name       string  `json:"name,omitempty" example:"São Paulo"`
+	Address    string  `json:"address,omitempty" example:"São Paulo, SP, Brazil"`
+	ReplyTo    string  `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string  `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendLocationMessageRequest
 
+type SendLiveLocationMessageRequest struct {
+	To             string  `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Latitude       float64 `json:"latitude" validate:"required,gte=-90,lte=90" example:"-23.5505"`
+	Longitude      float64 `json:"longitude" validate:"required,gte=-180,lte=180" example:"-46.6333"`
+	AccuracyMeters uint32  `json:"accuracy_meters,omitempty" example:"10"`
+	SpeedMps       float32 `json:"speed_mps,omitempty" validate:"gte=0" example:"1.4"`
+	Heading        uint32  `json:"heading,omitempty" validate:"lt=360" example:"90"`
+	Caption        string  `json:"caption,omitempty" validate:"max=1024" example:"On my way"`
+	Sequence       int64   `json:"sequence" validate:"gte=0" example:"1"`
+	TimeOffset     uint32  `json:"time_offset,omitempty" example:"60"`
+	ReplyTo        string  `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours     string  `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+} // @name SendLiveLocationMessageRequest
+
 type SendContactMessageRequest struct {
-	To           string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Name         string `json:"name" validate:"required" example:"John Doe"`
-	Phone        string `json:"phone" validate:"required" example:"+5511888888888"`
-	ContactName  string `json:"contact_name,omitempty" example:"John Doe"`
-	ContactPhone string `json:"contact_phone,omitempty" example:"+5511888888888"`
-	ReplyTo      string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To           string             `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Name         string             `json:"name" validate:"required,max=255" example:"John Doe"`
+	Phone        string             `json:"phone,omitempty" validate:"required_without_all=Phones ContactPhone,max=32" example:"+55 (11) 98888-8888"`
+	Phones       []ContactPhoneInfo `json:"phones,omitempty" validate:"omitempty,max=10,dive"`
+	Organization string             `json:"organization,omitempty" validate:"max=255" example:"ACME Inc."`
+	Photo        string             `json:"photo,omitempty" example:"https://example.com/avatar.jpg"`
+	ContactName  string             `json:"contact_name,omitempty" example:"John Doe"`
+	ContactPhone string             `json:"contact_phone,omitempty" validate:"max=32" example:"+5511888888888"`
+	ReplyTo      string             `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours   string             `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendContactMessageRequest
 
+type ContactPhoneInfo struct {
+	Number string `json:"number" validate:"required,max=32" example:"+55 11 3333-4444"`
+	Type   string `json:"type,omitempty" validate:"omitempty,oneof=cell home work main other" example:"work"`
+} // @name ContactPhoneInfo
+
 type CreateMessageResponse struct {
 	BaseResponse
 	Message *MessageInfo `json:"message"`
@@ -125,8 +186,19 @@ type SendMessageResponse struct {
 	Status      string     `json:"status" example:"sent"`
 	DeliveredAt *time.Time `json:"delivered_at,omitempty" example:"2024-01-01T12:00:05Z"`
 	ReadAt      *time.Time `json:"read_at,omitempty" example:"2024-01-01T12:00:10Z"`
+	DryRun      *DryRun    `json:"dry_run,omitempty"`
 } // @name SendMessageResponse
 
+// DryRun is what a send would have sent, returned instead of sending it
+// when the request asked for dryRun or the session is in sandbox mode.
+// EstimatedBytes counts the payload and, when it was loaded, the media.
+type DryRun struct {
+	Kind           string      `json:"kind" example:"text" enums:"text,media,location,contact,button,poll"`
+	Sandbox        bool        `json:"sandbox" example:"false"`
+	Payload        interface{} `json:"payload"`
+	EstimatedBytes int         `json:"estimated_bytes" example:"42"`
+} // @name DryRun
+
 type MessageInfo struct {
 	ID               string     `json:"id" example:"1b2e424c-a2a0-41a4-b992-15b7ec06b9bc"`
 	SessionID        string     `json:"session_id" example:"session-123"`
@@ -139,16 +211,123 @@ type MessageInfo struct {
 	Content          string     `json:"content,omitempty" example:"Hello World"`
 	MediaURL         string     `json:"media_url,omitempty" example:"https://example.com/image.jpg"`
 	MediaType        string     `json:"media_type,omitempty" example:"image"`
+	Media            *MediaInfo `json:"media,omitempty"`
+	QuotedMessageID  string     `json:"quoted_message_id,omitempty" example:"3EB0C767D71C"`
 	CwMessageID      *int       `json:"cw_message_id,omitempty" example:"123"`
 	CwConversationID *int       `json:"cw_conversation_id,omitempty" example:"456"`
 	SyncStatus       string     `json:"sync_status" example:"synced"`
 	SyncedAt         *time.Time `json:"synced_at,omitempty" example:"2024-01-01T12:00:05Z"`
+	EditedAt         *time.Time `json:"edited_at,omitempty" example:"2024-01-01T12:03:00Z"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" example:"2024-01-01T12:04:00Z"`
 	CreatedAt        time.Time  `json:"created_at" example:"2024-01-01T12:00:00Z"`
 	UpdatedAt        time.Time  `json:"updated_at" example:"2024-01-01T12:00:00Z"`
 } // @name MessageInfo
 
 type MessageDTO = MessageInfo
 
+type MediaInfo struct {
+	MimeType   string `json:"mime_type,omitempty" example:"image/jpeg"`
+	FileName   string `json:"file_name,omitempty" example:"invoice.pdf"`
+	FileSize   uint64 `json:"file_size,omitempty" example:"204800"`
+	FileSHA256 string `json:"file_sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Duration   uint32 `json:"duration_seconds,omitempty" example:"12"`
+	Width      uint32 `json:"width,omitempty" example:"1280"`
+	Height     uint32 `json:"height,omitempty" example:"720"`
+	Downloaded bool   `json:"downloaded" example:"true"`
+
+	// ScanStatus is clean, infected, quarantined or failed once the media
+	// went through the virus scan.
+	ScanStatus    string `json:"scan_status,omitempty" example:"clean"`
+	ScanSignature string `json:"scan_signature,omitempty" example:"Eicar-Test-Signature"`
+} // @name MediaInfo
+
+type MessageReaction struct {
+	ReactorJID string    `json:"reactor_jid" example:"5511999999999@s.whatsapp.net"`
+	Emoji      string    `json:"emoji" example:"👍"`
+	FromMe     bool      `json:"from_me" example:"false"`
+	ReactedAt  time.Time `json:"reacted_at" example:"2024-01-01T12:00:00Z"`
+} // @name MessageReaction
+
+type ReactionSummary struct {
+	Emoji    string   `json:"emoji" example:"👍"`
+	Count    int      `json:"count" example:"2"`
+	Reactors []string `json:"reactors" example:"5511999999999@s.whatsapp.net,5511888888888@s.whatsapp.net"`
+} // @name ReactionSummary
+
+type MessageDetailResponse struct {
+	Message         *MessageInfo      `json:"message"`
+	QuotedMessage   *MessageInfo      `json:"quoted_message,omitempty"`
+	Reactions       []MessageReaction `json:"reactions"`
+	ReactionSummary []ReactionSummary `json:"reaction_summary"`
+} // @name MessageDetailResponse
+
+type StarMessageRequest struct {
+	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
+	ChatJID   string `json:"chat_jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	SenderJID string `json:"sender_jid,omitempty" example:"5511888888888@s.whatsapp.net"`
+	FromMe    bool   `json:"from_me,omitempty" example:"false"`
+} // @name StarMessageRequest
+
+type StarMessageResponse struct {
+	MessageID string `json:"message_id" example:"3EB0C767D71D"`
+	ChatJID   string `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Starred   bool   `json:"starred" example:"true"`
+} // @name StarMessageResponse
+
+type PinMessageRequest struct {
+	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
+	ChatJID   string `json:"chat_jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	SenderJID string `json:"sender_jid,omitempty" example:"5511888888888@s.whatsapp.net"`
+	FromMe    bool   `json:"from_me,omitempty" example:"false"`
+	Duration  string `json:"duration,omitempty" validate:"omitempty,oneof=24h 7d 30d" example:"7d"`
+} // @name PinMessageRequest
+
+type PinMessageResponse struct {
+	MessageID string     `json:"message_id" example:"3EB0C767D71D"`
+	ChatJID   string     `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Pinned    bool       `json:"pinned" example:"true"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+} // @name PinMessageResponse
+
+type StarredMessage struct {
+	MessageID string       `json:"message_id" example:"3EB0C767D71D"`
+	ChatJID   string       `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	SenderJID string       `json:"sender_jid,omitempty" example:"5511888888888@s.whatsapp.net"`
+	FromMe    bool         `json:"from_me" example:"false"`
+	StarredAt time.Time    `json:"starred_at" example:"2024-01-01T12:00:00Z"`
+	Message   *MessageInfo `json:"message,omitempty"`
+} // @name StarredMessage
+
+type ListStarredMessagesResponse struct {
+	Messages   []StarredMessage `json:"messages"`
+	NextCursor string           `json:"nextCursor,omitempty" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpIjoiLi4uIn0"`
+	HasMore    bool             `json:"hasMore" example:"false"`
+} // @name ListStarredMessagesResponse
+
+type SearchMessagesRequest struct {
+	Query     string     `json:"q" validate:"required,max=256" example:"order 1234"`
+	ChatJID   string     `json:"chat_jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	Type      string     `json:"type,omitempty" example:"text"`
+	Direction string     `json:"direction,omitempty" validate:"omitempty,oneof=inbound outbound" example:"inbound"`
+	From      *time.Time `json:"from,omitempty" example:"2024-01-01T00:00:00Z"`
+	To        *time.Time `json:"to,omitempty" example:"2024-01-31T23:59:59Z"`
+	Limit     int        `json:"limit" example:"20"`
+	Offset    int        `json:"offset" example:"0"`
+} // @name SearchMessagesRequest
+
+type MessageSearchHit struct {
+	Message *MessageInfo `json:"message"`
+	Snippet string       `json:"snippet" example:"status of <mark>order</mark> <mark>1234</mark>"`
+	Rank    float64      `json:"rank" example:"0.0607"`
+} // @name MessageSearchHit
+
+type SearchMessagesResponse struct {
+	Results []MessageSearchHit `json:"results"`
+	Total   int64              `json:"total" example:"42"`
+	Limit   int                `json:"limit" example:"20"`
+	Offset  int                `json:"offset" example:"0"`
+} // @name SearchMessagesResponse
+
 type MessageStats struct {
 	TotalMessages     int64            `json:"total_messages" example:"1000"`
 	MessagesByType    map[string]int64 `json:"messages_by_type"`
@@ -164,14 +343,14 @@ type MessageStats struct {
 } // @name MessageStats
 
 type SendContactListMessageRequest struct {
-	To       string        `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Contacts []ContactInfo `json:"contacts" validate:"required,min=1"`
+	To       string        `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Contacts []ContactInfo `json:"contacts" validate:"required,min=1,dive"`
 	ReplyTo  string        `json:"reply_to,omitempty" example:"3EB0C767D71D"`
 } // @name SendContactListMessageRequest
 
 type ContactInfo struct {
 	Name  string `json:"name" validate:"required" example:"John Doe"`
-	Phone string `json:"phone" validate:"required" example:"+5511888888888"`
+	Phone string `json:"phone" validate:"required,phone" example:"+5511888888888"`
 } // @name ContactInfo
 
 type ContactResult struct {
@@ -195,57 +374,76 @@ type SendContactListResponse struct {
 } // @name SendContactListResponse
 
 type SendBusinessProfileMessageRequest struct {
-	To          string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	To          string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	BusinessJID string `json:"business_jid,omitempty" example:"5511888888888@s.whatsapp.net"`
 	ReplyTo     string `json:"reply_to,omitempty" example:"3EB0C767D71D"`
 } // @name SendBusinessProfileMessageRequest
 
 type SendButtonMessageRequest struct {
-	To      string       `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Text    string       `json:"text" validate:"required" example:"Choose an option:"`
-	Footer  string       `json:"footer,omitempty" example:"Powered by ZPWoot"`
-	Buttons []ButtonInfo `json:"buttons" validate:"required,min=1,max=3"`
-	ReplyTo string       `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	To         string       `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Title      string       `json:"title,omitempty" example:"Order #1234"`
+	Text       string       `json:"text" validate:"required,max=1024" example:"Choose an option:"`
+	Footer     string       `json:"footer,omitempty" example:"Powered by ZPWoot"`
+	Buttons    []ButtonInfo `json:"buttons" validate:"required,min=1,max=3,dive"`
+	ReplyTo    string       `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string       `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendButtonMessageRequest
 
 type SendListMessageRequest struct {
-	To         string            `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Body       string            `json:"body" validate:"required" example:"Choose from the list:"`
+	To         string            `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Body       string            `json:"body" validate:"required,max=65536" example:"Choose from the list:"`
 	ButtonText string            `json:"button_text" validate:"required" example:"View Options"`
-	Sections   []ListSectionInfo `json:"sections" validate:"required,min=1,max=10"`
+	Sections   []ListSectionInfo `json:"sections" validate:"required,min=1,max=10,dive"`
 	ReplyTo    string            `json:"reply_to,omitempty" example:"3EB0C767D71D"`
 } // @name SendListMessageRequest
 
 type SendPollMessageRequest struct {
-	To                string           `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
-	Name              string           `json:"name" validate:"required" example:"Favorite Color Poll"`
-	Question          string           `json:"question" validate:"required" example:"What's your favorite color?"`
-	Options           []PollOptionInfo `json:"options" validate:"required,min=2,max=12"`
-	SelectableCount   int              `json:"selectable_count" validate:"min=1" example:"1"`
+	To                string           `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Name              string           `json:"name,omitempty" validate:"max=255" example:"Favorite Color Poll"`
+	Question          string           `json:"question" validate:"required_without=Name,max=255" example:"What's your favorite color?"`
+	Options           []PollOptionInfo `json:"options" validate:"required,min=2,max=12,dive"`
+	SelectableCount   int              `json:"selectable_count,omitempty" validate:"min=0,max=12" example:"1"`
 	AllowMultipleVote bool             `json:"allow_multiple_vote" example:"false"`
 	ReplyTo           string           `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours        string           `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
 } // @name SendPollMessageRequest
 
+// SendPaymentRequestMessageRequest asks the recipient for a payment. Currency
+// defaults to the one of the session's account region; expires_in, in
+// seconds, leaves expiry to WhatsApp when zero.
+type SendPaymentRequestMessageRequest struct {
+	To         string  `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Amount     float64 `json:"amount" validate:"required,gt=0" example:"49.90"`
+	Currency   string  `json:"currency,omitempty" validate:"omitempty,len=3" example:"BRL"`
+	Note       string  `json:"note,omitempty" validate:"max=1024" example:"Pedido #1234"`
+	ExpiresIn  int     `json:"expires_in,omitempty" validate:"min=0,max=2592000" example:"86400"`
+	ReplyTo    string  `json:"reply_to,omitempty" example:"3EB0C767D71D"`
+	QuietHours string  `json:"quiet_hours,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+} // @name SendPaymentRequestMessageRequest
+
 type SendReactionMessageRequest struct {
-	To        string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	To        string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
 	Reaction  string `json:"reaction" validate:"required" example:"👍"`
 } // @name SendReactionMessageRequest
 
 type SendPresenceMessageRequest struct {
-	To       string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	To       string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	Presence string `json:"presence" validate:"required,oneof=typing recording online offline paused" example:"typing"`
 } // @name SendPresenceMessageRequest
 
 type ButtonInfo struct {
-	ID   string `json:"id" validate:"required" example:"btn-1"`
-	Text string `json:"text" validate:"required" example:"Option 1"`
-	Type string `json:"type,omitempty" example:"reply"`
+	ID          string `json:"id,omitempty" example:"btn-1"`
+	Text        string `json:"text" validate:"required" example:"Option 1"`
+	Type        string `json:"type,omitempty" validate:"omitempty,oneof=reply cta_url cta_call cta_copy" example:"reply"`
+	URL         string `json:"url,omitempty" validate:"omitempty,url" example:"https://example.com/track/1234"`
+	PhoneNumber string `json:"phone_number,omitempty" example:"+5511999999999"`
+	CopyCode    string `json:"copy_code,omitempty" example:"PROMO10"`
 } // @name ButtonInfo
 
 type ListSectionInfo struct {
 	Title string        `json:"title" validate:"required" example:"Section 1"`
-	Rows  []ListRowInfo `json:"rows" validate:"required,min=1,max=10"`
+	Rows  []ListRowInfo `json:"rows" validate:"required,min=1,max=10,dive"`
 } // @name ListSectionInfo
 
 type ListRowInfo struct {
@@ -259,20 +457,21 @@ type PollOptionInfo struct {
 } // @name PollOptionInfo
 
 type EditMessageRequest struct {
-	To        string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	To        string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
-	NewText   string `json:"new_text" validate:"required" example:"Updated message"`
+	NewText   string `json:"new_text" validate:"required,max=65536" example:"Updated message"`
 	NewBody   string `json:"new_body,omitempty" example:"Updated message"`
 } // @name EditMessageRequest
 
 type RevokeMessageRequest struct {
-	To        string `json:"to" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	To        string `json:"to" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	MessageID string `json:"message_id" validate:"required" example:"3EB0C767D71D"`
 } // @name RevokeMessageRequest
 
 type MarkAsReadRequest struct {
-	ChatJID    string   `json:"chat_jid" validate:"required" example:"5511999999999@s.whatsapp.net"`
+	ChatJID    string   `json:"chat_jid" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
 	MessageIDs []string `json:"message_ids" validate:"required,min=1" example:"[\"3EB0C767D71D\"]"`
+	SenderJID  string   `json:"sender_jid,omitempty" validate:"omitempty,jid" example:"5511888888888@s.whatsapp.net"`
 } // @name MarkAsReadRequest
 
 type PollVoteInfo struct {
@@ -281,16 +480,24 @@ type PollVoteInfo struct {
 	VoteCount  int      `json:"vote_count" example:"5"`
 } // @name PollVoteInfo
 
+// PollVote is one participant's current choice on a poll.
+type PollVote struct {
+	Voter   string    `json:"voter" example:"5511888888888@s.whatsapp.net"`
+	Options []string  `json:"options" example:"Option 1"`
+	VotedAt time.Time `json:"voted_at" example:"2024-01-01T12:05:00Z"`
+} // @name PollVote
+
 type GetPollResultsResponse struct {
 	BaseResponse
-	MessageID   string         `json:"message_id" example:"3EB0C767D71D"`
-	PollID      string         `json:"poll_id" example:"3EB0C767D71D"`
-	PollName    string         `json:"poll_name" example:"Favorite Color Poll"`
-	Question    string         `json:"question" example:"What's your favorite color?"`
-	Votes       []PollVoteInfo `json:"votes"`
-	VoteResults []PollVoteInfo `json:"vote_results"`
-	TotalVotes  int            `json:"total_votes" example:"15"`
-	CreatedAt   time.Time      `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	MessageID       string         `json:"message_id" example:"3EB0C767D71D"`
+	PollID          string         `json:"poll_id" example:"3EB0C767D71D"`
+	PollName        string         `json:"poll_name" example:"Favorite Color Poll"`
+	Question        string         `json:"question" example:"What's your favorite color?"`
+	SelectableCount int            `json:"selectable_count" example:"1"`
+	Votes           []PollVote     `json:"votes"`
+	VoteResults     []PollVoteInfo `json:"vote_results"`
+	TotalVotes      int            `json:"total_votes" example:"15"`
+	CreatedAt       time.Time      `json:"created_at" example:"2024-01-01T12:00:00Z"`
 } // @name GetPollResultsResponse
 
 type MarkAsReadResponse struct {
@@ -301,3 +508,62 @@ type MarkAsReadResponse struct {
 	Status       string    `json:"status" example:"success"`
 	LastReadAt   time.Time `json:"last_read_at" example:"2024-01-01T12:00:00Z"`
 } // @name MarkAsReadResponse
+
+type ChatSummary struct {
+	ChatJID       string     `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	IsGroup       bool       `json:"is_group" example:"false"`
+	UnreadCount   int        `json:"unread_count" example:"3"`
+	LastMessageID string     `json:"last_message_id" example:"3EB0C767D71D"`
+	LastMessageAt time.Time  `json:"last_message_at" example:"2024-01-01T12:00:00Z"`
+	LastFromMe    bool       `json:"last_from_me" example:"false"`
+	LastReadAt    *time.Time `json:"last_read_at,omitempty" example:"2024-01-01T11:00:00Z"`
+} // @name ChatSummary
+
+type ListChatsResponse struct {
+	Chats  []ChatSummary `json:"chats"`
+	Sort   string        `json:"sort" example:"unread"`
+	Total  int64         `json:"total" example:"42"`
+	Limit  int           `json:"limit" example:"20"`
+	Offset int           `json:"offset" example:"0"`
+} // @name ListChatsResponse
+
+type LiveLocationPoint struct {
+	MessageID      string    `json:"message_id" example:"3EB0C767D71D"`
+	Latitude       float64   `json:"latitude" example:"-23.5505"`
+	Longitude      float64   `json:"longitude" example:"-46.6333"`
+	AccuracyMeters uint32    `json:"accuracy_meters,omitempty" example:"10"`
+	SpeedMps       float32   `json:"speed_mps,omitempty" example:"1.4"`
+	Heading        uint32    `json:"heading,omitempty" example:"90"`
+	Caption        string    `json:"caption,omitempty" example:"On my way"`
+	Sequence       int64     `json:"sequence" example:"12"`
+	ReportedAt     time.Time `json:"reported_at" example:"2024-01-01T12:00:00Z"`
+} // @name LiveLocationPoint
+
+type LiveLocationSharer struct {
+	SharerJID string              `json:"sharer_jid" example:"5511999999999@s.whatsapp.net"`
+	FromMe    bool                `json:"from_me" example:"false"`
+	Latest    LiveLocationPoint   `json:"latest"`
+	History   []LiveLocationPoint `json:"history"`
+} // @name LiveLocationSharer
+
+type ListLiveLocationsResponse struct {
+	ChatJID string               `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Sharers []LiveLocationSharer `json:"sharers"`
+} // @name ListLiveLocationsResponse
+
+type ScheduledMessageResponse struct {
+	ID        string    `json:"id" example:"6f1e0b2a-8c4d-4a7e-9b3f-2d5c1e8a7b90"`
+	Kind      string    `json:"kind" example:"text"`
+	SendAt    time.Time `json:"send_at" example:"2024-01-02T08:00:00-03:00"`
+	Reason    string    `json:"reason,omitempty" example:"quiet_hours"`
+	Status    string    `json:"status" example:"pending"`
+	Attempts  int       `json:"attempts" example:"0"`
+	LastError string    `json:"last_error,omitempty"`
+	MessageID string    `json:"message_id,omitempty" example:"3EB0C767D71D"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T23:15:00-03:00"`
+} // @name ScheduledMessageResponse
+
+type ListScheduledMessagesResponse struct {
+	Messages []ScheduledMessageResponse `json:"messages"`
+	Total    int                        `json:"total" example:"1"`
+} // @name ListScheduledMessagesResponse
@@ -0,0 +1,51 @@
+package contracts
+
+import "time"
+
+type ForwardToNewsletterRequest struct {
+	NewsletterJID string  `json:"newsletter_jid" validate:"required" example:"120363025246125486@newsletter"`
+	MessageID     string  `json:"message_id" validate:"required" example:"3EB0C767D71D"`
+	Caption       *string `json:"caption,omitempty" validate:"omitempty,max=4096" example:"Aviso da comunidade"`
+} // @name ForwardToNewsletterRequest
+
+type ForwardToNewsletterResponse struct {
+	MessageID       string    `json:"message_id" example:"99"`
+	NewsletterJID   string    `json:"newsletter_jid" example:"120363025246125486@newsletter"`
+	SourceMessageID string    `json:"source_message_id" example:"3EB0C767D71D"`
+	Type            string    `json:"type" example:"image"`
+	Timestamp       time.Time `json:"timestamp" example:"2024-01-01T12:00:00Z"`
+} // @name ForwardToNewsletterResponse
+
+// PostToNewsletterRequest is a new post for a newsletter. Text is the body of
+// text posts and the caption of image and video posts. File takes a URL or
+// base64 data like the send endpoints. With send_at in the future the post is
+// scheduled instead of published right away.
+type PostToNewsletterRequest struct {
+	Type     string     `json:"type,omitempty" validate:"omitempty,oneof=text image video audio document sticker" example:"image"`
+	Text     string     `json:"text,omitempty" validate:"max=4096" example:"Novidades da semana"`
+	File     string     `json:"file,omitempty" example:"https://example.com/banner.jpg"`
+	Filename string     `json:"filename,omitempty" validate:"max=255" example:"banner.jpg"`
+	SendAt   *time.Time `json:"send_at,omitempty" example:"2024-01-02T08:00:00-03:00"`
+} // @name PostToNewsletterRequest
+
+type NewsletterPostResponse struct {
+	MessageID     string    `json:"message_id" example:"99"`
+	NewsletterJID string    `json:"newsletter_jid" example:"120363025246125486@newsletter"`
+	Type          string    `json:"type" example:"image"`
+	Timestamp     time.Time `json:"timestamp" example:"2024-01-01T12:00:00Z"`
+} // @name NewsletterPostResponse
+
+// NewsletterAdminRequest names the user to invite as admin or to hand the
+// newsletter over to, as a phone number or JID.
+type NewsletterAdminRequest struct {
+	User string `json:"user" validate:"required" example:"5511999999999"`
+} // @name NewsletterAdminRequest
+
+// NewsletterAdminResponse reports an administration change. User is empty
+// when the session accepted an invite itself; ExpiresAt is set on invites
+// when WhatsApp tells when they lapse.
+type NewsletterAdminResponse struct {
+	NewsletterJID string     `json:"newsletter_jid" example:"120363025246125486@newsletter"`
+	User          string     `json:"user,omitempty" example:"5511999999999@s.whatsapp.net"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" example:"2024-01-02T12:00:00Z"`
+} // @name NewsletterAdminResponse
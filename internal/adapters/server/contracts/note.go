@@ -0,0 +1,31 @@
+package contracts
+
+import "time"
+
+type CreateNoteRequest struct {
+	ChatJID string `json:"chat_jid" validate:"required,jid" example:"5511999999999@s.whatsapp.net"`
+	Kind    string `json:"kind,omitempty" validate:"omitempty,oneof=note draft" example:"note"`
+	Author  string `json:"author" validate:"required,max=255" example:"maria@acme.com"`
+	Body    string `json:"body" validate:"required,max=8192" example:"Cliente pediu retorno na segunda-feira"`
+} // @name CreateNoteRequest
+
+type UpdateNoteRequest struct {
+	Kind *string `json:"kind,omitempty" validate:"omitempty,oneof=note draft" example:"note"`
+	Body *string `json:"body,omitempty" validate:"omitempty,max=8192" example:"Retorno feito, aguardando pagamento"`
+} // @name UpdateNoteRequest
+
+type NoteResponse struct {
+	ID        string    `json:"id" example:"1b2e424c-a2a0-41a4-b992-15b7ec06b9bc"`
+	ChatJID   string    `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Kind      string    `json:"kind" example:"note"`
+	Author    string    `json:"author" example:"maria@acme.com"`
+	Body      string    `json:"body" example:"Cliente pediu retorno na segunda-feira"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T12:00:00Z"`
+} // @name NoteResponse
+
+type ListNotesResponse struct {
+	ChatJID string         `json:"chat_jid" example:"5511999999999@s.whatsapp.net"`
+	Notes   []NoteResponse `json:"notes"`
+	Total   int            `json:"total" example:"3"`
+} // @name ListNotesResponse
@@ -0,0 +1,37 @@
+package contracts
+
+type BusinessHoursDay struct {
+	Day       string `json:"day" validate:"required,oneof=sun mon tue wed thu fri sat" example:"mon"`
+	Mode      string `json:"mode" validate:"required,oneof=open_24h appointment_only specific_hours" example:"specific_hours"`
+	OpenTime  string `json:"open_time,omitempty" validate:"omitempty,datetime=15:04" example:"09:00"`
+	CloseTime string `json:"close_time,omitempty" validate:"omitempty,datetime=15:04" example:"18:00"`
+} // @name BusinessHoursDay
+
+type BusinessHours struct {
+	Timezone string             `json:"timezone" validate:"required" example:"America/Sao_Paulo"`
+	Days     []BusinessHoursDay `json:"days" validate:"max=7,dive"`
+} // @name BusinessHours
+
+type BusinessCategory struct {
+	ID   string `json:"id" example:"133436743388217"`
+	Name string `json:"name,omitempty" example:"Artes e entretenimento"`
+} // @name BusinessCategory
+
+type UpdateBusinessProfileRequest struct {
+	Description   *string        `json:"description,omitempty" validate:"omitempty,max=512" example:"Atendimento de segunda a sexta"`
+	Address       *string        `json:"address,omitempty" validate:"omitempty,max=256" example:"Av. Paulista, 1000 - São Paulo, SP"`
+	Email         *string        `json:"email,omitempty" validate:"omitempty,max=128" example:"contato@exemplo.com.br"`
+	Websites      []string       `json:"websites,omitempty" validate:"omitempty,max=2,dive,url" example:"https://exemplo.com.br"`
+	Categories    []string       `json:"categories,omitempty" validate:"omitempty,max=3,dive,required" example:"133436743388217"`
+	BusinessHours *BusinessHours `json:"business_hours,omitempty"`
+} // @name UpdateBusinessProfileRequest
+
+type OwnBusinessProfileResponse struct {
+	JID           string             `json:"jid" example:"5511999999999@s.whatsapp.net"`
+	Description   string             `json:"description" example:"Atendimento de segunda a sexta"`
+	Address       string             `json:"address" example:"Av. Paulista, 1000 - São Paulo, SP"`
+	Email         string             `json:"email" example:"contato@exemplo.com.br"`
+	Websites      []string           `json:"websites" example:"https://exemplo.com.br"`
+	Categories    []BusinessCategory `json:"categories"`
+	BusinessHours *BusinessHours     `json:"business_hours,omitempty"`
+} // @name OwnBusinessProfileResponse
@@ -16,6 +16,7 @@ type ListSessionsRequest struct {
 	IsConnected *bool   `json:"isConnected,omitempty" query:"isConnected" example:"true"`
 	DeviceJID   *string `json:"deviceJid,omitempty" query:"deviceJid" example:"5511999999999@s.whatsapp.net"`
 	Limit       int     `json:"limit,omitempty" query:"limit" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor      string  `json:"cursor,omitempty" query:"cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpIjoiLi4uIn0"`
 	Offset      int     `json:"offset,omitempty" query:"offset" validate:"omitempty,min=0" example:"0"`
 } // @name ListSessionsRequest
 
@@ -23,10 +24,315 @@ type SetProxyRequest struct {
 	ProxyConfig ProxyConfig `json:"proxyConfig" validate:"required"`
 } // @name SetProxyRequest
 
+type CallSettings struct {
+	AutoReject    bool   `json:"autoReject" example:"true"`
+	RejectMessage string `json:"rejectMessage,omitempty" validate:"max=1000" example:"Sorry, we can't take calls on this number. Please send a message."`
+} // @name CallSettings
+
+type MediaSettings struct {
+	AutoDownload string `json:"autoDownload" validate:"omitempty,oneof=never images all" example:"images"`
+	MaxSizeMB    int    `json:"maxSizeMB,omitempty" validate:"min=0,max=100" example:"16"`
+	Scan         string `json:"scan,omitempty" validate:"omitempty,oneof=block tag ignore" example:"block"`
+} // @name MediaSettings
+
+type QuietHoursSettings struct {
+	Enabled  bool   `json:"enabled" example:"true"`
+	Start    string `json:"start,omitempty" validate:"omitempty,datetime=15:04" example:"22:00"`
+	End      string `json:"end,omitempty" validate:"omitempty,datetime=15:04" example:"08:00"`
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone" example:"America/Sao_Paulo"`
+	Policy   string `json:"policy,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+} // @name QuietHoursSettings
+
+type MediaPolicy struct {
+	MaxBytes         map[string]int64 `json:"maxBytes,omitempty" validate:"omitempty,dive,keys,oneof=image video audio document sticker,endkeys,min=0,max=104857600" example:"video:16777216,document:10485760"`
+	AllowedMIMETypes []string         `json:"allowedMimeTypes,omitempty" validate:"omitempty,max=50,dive,required,max=100" example:"image/*,video/mp4,application/pdf"`
+} // @name MediaPolicy
+
+type FooterSettings struct {
+	Enabled bool   `json:"enabled" example:"true"`
+	Text    string `json:"text,omitempty" validate:"max=512" example:"— Sent via ACME Support"`
+} // @name FooterSettings
+
+// TextFormatSettings turns on preprocessing of outbound text and captions.
+// markdown rewrites **bold**, *italic*, ~~strike~~ and `code` into WhatsApp
+// formatting; emoji expands shortcodes like :wave:; normalize applies Unicode
+// NFC normalization.
+type TextFormatSettings struct {
+	Markdown  bool `json:"markdown" example:"true"`
+	Emoji     bool `json:"emoji" example:"true"`
+	Normalize bool `json:"normalize" example:"false"`
+} // @name TextFormatSettings
+
+// ChatwootSettings set the WhatsApp messages sent when an agent resolves a
+// Chatwoot conversation or Chatwoot sends its satisfaction survey, and
+// whether a reply from the contact reopens the conversation. Messages accept
+// {{name}}, {{conversation_id}} and {{survey_url}}.
+type ChatwootSettings struct {
+	ResolvedMessage string `json:"resolvedMessage,omitempty" validate:"max=1024" example:"Thanks {{name}}! Your ticket #{{conversation_id}} was closed."`
+	CSATMessage     string `json:"csatMessage,omitempty" validate:"max=1024" example:"How did we do? Rate us at {{survey_url}}"`
+	ReopenOnReply   bool   `json:"reopenOnReply" example:"true"`
+} // @name ChatwootSettings
+
+type WarmUpSettings struct {
+	Enabled    bool       `json:"enabled" example:"true"`
+	StartedAt  *time.Time `json:"startedAt,omitempty" example:"2024-01-01T00:00:00Z"`
+	Days       int        `json:"days,omitempty" validate:"min=0,max=90" example:"14"`
+	StartLimit int        `json:"startLimit,omitempty" validate:"min=0" example:"20"`
+	EndLimit   int        `json:"endLimit,omitempty" validate:"min=0" example:"200"`
+	Timezone   string     `json:"timezone,omitempty" validate:"omitempty,timezone" example:"America/Sao_Paulo"`
+	Policy     string     `json:"policy,omitempty" validate:"omitempty,oneof=reject defer" example:"defer"`
+} // @name WarmUpSettings
+
+type WarmUpStatusResponse struct {
+	Active    bool       `json:"active" example:"true"`
+	Day       int        `json:"day,omitempty" example:"3"`
+	Days      int        `json:"days,omitempty" example:"14"`
+	Limit     int        `json:"limit,omitempty" example:"53"`
+	Sent      int        `json:"sent" example:"12"`
+	Remaining int        `json:"remaining,omitempty" example:"41"`
+	ResumeAt  *time.Time `json:"resumeAt,omitempty" example:"2024-01-04T03:00:00Z"`
+} // @name WarmUpStatusResponse
+
+type UptimeDay struct {
+	Date             string  `json:"date" example:"2024-01-03"`
+	UptimePercent    float64 `json:"uptimePercent" example:"99.3"`
+	ConnectedSeconds int64   `json:"connectedSeconds" example:"85800"`
+	ObservedSeconds  int64   `json:"observedSeconds" example:"86400"`
+} // @name UptimeDay
+
+type StatusTransition struct {
+	From   string    `json:"from,omitempty" example:"connected"`
+	To     string    `json:"to" example:"disconnected"`
+	Reason string    `json:"reason,omitempty" example:"disconnected"`
+	At     time.Time `json:"at" example:"2024-01-03T14:02:11Z"`
+} // @name StatusTransition
+
+type UptimeResponse struct {
+	SessionID     string             `json:"sessionId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From          time.Time          `json:"from" example:"2023-12-28T00:00:00Z"`
+	To            time.Time          `json:"to" example:"2024-01-03T18:30:00Z"`
+	UptimePercent float64            `json:"uptimePercent" example:"98.7"`
+	Days          []UptimeDay        `json:"days"`
+	Transitions   []StatusTransition `json:"transitions"`
+} // @name UptimeResponse
+
+type DeviceInfo struct {
+	ID          int        `json:"id" example:"3"`
+	JID         string     `json:"jid" example:"5511999999999:3@s.whatsapp.net"`
+	Platform    string     `json:"platform,omitempty" example:"web"`
+	Primary     bool       `json:"primary" example:"false"`
+	Current     bool       `json:"current" example:"false"`
+	FirstSeenAt time.Time  `json:"firstSeenAt" example:"2024-01-03T14:02:11Z"`
+	LastSeenAt  *time.Time `json:"lastSeenAt,omitempty" example:"2024-01-03T18:30:00Z"`
+} // @name DeviceInfo
+
+type DeviceListResponse struct {
+	SessionID string       `json:"sessionId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Devices   []DeviceInfo `json:"devices"`
+} // @name DeviceListResponse
+
+// StartDebugCaptureRequest switches protocol debug capture on for a session.
+// Level is the lowest whatsmeow level kept (debug, the default, includes
+// every frame sent and received); capacity bounds the entries kept and
+// minutes how long the capture runs.
+type StartDebugCaptureRequest struct {
+	Level    string `json:"level,omitempty" validate:"omitempty,oneof=debug info warn error" example:"debug"`
+	Capacity int    `json:"capacity,omitempty" validate:"omitempty,min=1,max=20000" example:"2000"`
+	Minutes  int    `json:"minutes,omitempty" validate:"omitempty,min=1,max=1440" example:"30"`
+} // @name StartDebugCaptureRequest
+
+type DebugCaptureResponse struct {
+	SessionID string    `json:"sessionId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Level     string    `json:"level" example:"debug"`
+	Capacity  int       `json:"capacity" example:"2000"`
+	Active    bool      `json:"active" example:"true"`
+	Entries   int       `json:"entries" example:"412"`
+	Dropped   int64     `json:"dropped" example:"0"`
+	StartedAt time.Time `json:"startedAt" example:"2024-01-03T18:00:00Z"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2024-01-03T18:30:00Z"`
+} // @name DebugCaptureResponse
+
+type DebugLogEntry struct {
+	Time    time.Time `json:"time" example:"2024-01-03T18:01:12Z"`
+	Level   string    `json:"level" example:"debug"`
+	Module  string    `json:"module" example:"Client/Recv"`
+	Message string    `json:"message" example:"<success t=\"1704304872\"/>"`
+} // @name DebugLogEntry
+
+type DebugLogsResponse struct {
+	Capture DebugCaptureResponse `json:"capture"`
+	Logs    []DebugLogEntry      `json:"logs"`
+} // @name DebugLogsResponse
+
+// RetentionSettings overrides how long the session's stored messages are
+// kept. Omit messageDays (or send null) to use the instance default; 0 keeps
+// them forever.
+type RetentionSettings struct {
+	MessageDays *int `json:"messageDays" validate:"omitempty,min=0,max=3650" example:"30"`
+} // @name RetentionSettings
+
+// SessionPolicy restricts what the session may send: full (default),
+// dmOnly (no groups), groupOnly (groups only) or readOnly (nothing).
+type SessionPolicy struct {
+	Mode string `json:"mode" validate:"omitempty,oneof=full dmOnly groupOnly readOnly" example:"readOnly"`
+} // @name SessionPolicy
+
+// TimezoneSettings is the session's IANA timezone. Quiet hours and warm-up
+// without a timezone of their own use it, scheduled sends are listed in it
+// and webhooks get their timestamps in it too. Empty means UTC.
+type TimezoneSettings struct {
+	Timezone string `json:"timezone" validate:"omitempty,timezone" example:"America/Sao_Paulo"`
+} // @name TimezoneSettings
+
+// SandboxSettings turn every send of the session into a dry run, as with
+// dryRun=true on each request.
+type SandboxSettings struct {
+	Enabled bool `json:"enabled" example:"true"`
+} // @name SandboxSettings
+
+// PairingSettings limit a QR pairing to maxQRCodes codes, after which it
+// stops and the session moves to qr_timeout. 0 leaves it to WhatsApp.
+type PairingSettings struct {
+	MaxQRCodes int `json:"maxQRCodes" validate:"min=0,max=50" example:"5"`
+} // @name PairingSettings
+
+// FeatureFlags switch session features on or off by name: enableButtons
+// (default on), enableChatwoot (on), enableAutoRead (off) and
+// enableLinkPreview (off). A PATCH changes only the flags it names.
+type FeatureFlags map[string]bool // @name FeatureFlags
+
+// GroupRules limit what the session may post to one group: at most
+// maxPerHour posts over any rolling hour (0 for no cap), only the
+// allowedTypes when given, and no text containing any of the bannedWords,
+// ignoring case.
+type GroupRules struct {
+	MaxPerHour   int      `json:"maxPerHour,omitempty" validate:"min=0,max=3600" example:"20"`
+	AllowedTypes []string `json:"allowedTypes,omitempty" validate:"omitempty,dive,oneof=text image audio video document sticker location live_location contact button poll payment" example:"text,image"`
+	BannedWords  []string `json:"bannedWords,omitempty" validate:"max=200,dive,required" example:"promoção,bit.ly"`
+} // @name GroupRules
+
+// InboundFilterSettings screen the messages contacts send the session. The
+// first rule that matches applies; group messages are screened only with
+// groups.
+type InboundFilterSettings struct {
+	Rules  []FilterRule `json:"rules" validate:"max=50,dive"`
+	Groups bool         `json:"groups" example:"false"`
+} // @name InboundFilterSettings
+
+// FilterRule matches a message when every condition it sets holds: the
+// text contains one of the keywords (ignoring case), matches pattern (a
+// regular expression), the sender matches one of senders (numbers or JIDs,
+// * as wildcard) and, with linkOnly, the text is nothing but links. tag
+// marks the message's webhook, drop keeps it from webhooks and Chatwoot,
+// reply answers the chat at most once an hour and block blocks the sender.
+type FilterRule struct {
+	Name     string   `json:"name,omitempty" validate:"max=100" example:"links from strangers"`
+	Keywords []string `json:"keywords,omitempty" validate:"max=200,dive,required" example:"promoção,ganhe dinheiro"`
+	Pattern  string   `json:"pattern,omitempty" validate:"max=500" example:"(?i)pix gr[aá]tis"`
+	Senders  []string `json:"senders,omitempty" validate:"max=200,dive,required" example:"234*,*@lid"`
+	LinkOnly bool     `json:"linkOnly,omitempty" example:"true"`
+	Actions  []string `json:"actions" validate:"required,min=1,dive,oneof=tag drop reply block" example:"tag,drop"`
+	Reply    string   `json:"reply,omitempty" validate:"max=1000" example:"Não aceitamos links por aqui."`
+} // @name FilterRule
+
+// FilteredMessage is an inbound message a filter rule matched, with the
+// actions that failed and why.
+type FilteredMessage struct {
+	MessageID    string            `json:"messageId" example:"3EB0C767D71D"`
+	ChatJID      string            `json:"chatJid" example:"5511999999999@s.whatsapp.net"`
+	SenderJID    string            `json:"senderJid" example:"5511999999999@s.whatsapp.net"`
+	Text         string            `json:"text" example:"Ganhe dinheiro: https://example.com"`
+	Rule         string            `json:"rule" example:"links from strangers"`
+	Conditions   []string          `json:"conditions" example:"keyword,link_only"`
+	Keyword      string            `json:"keyword,omitempty" example:"ganhe dinheiro"`
+	Actions      []string          `json:"actions" example:"tag,drop"`
+	ActionErrors map[string]string `json:"actionErrors,omitempty"`
+	FilteredAt   time.Time         `json:"filteredAt" example:"2024-01-01T12:00:00Z"`
+} // @name FilteredMessage
+
+type FilteredMessageListResponse struct {
+	Messages   []FilteredMessage `json:"messages"`
+	NextCursor string            `json:"nextCursor,omitempty" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpIjoiLi4uIn0"`
+	HasMore    bool              `json:"hasMore" example:"false"`
+} // @name FilteredMessageListResponse
+
+type SessionSettings struct {
+	Calls         CallSettings          `json:"calls"`
+	Media         MediaSettings         `json:"media"`
+	QuietHours    QuietHoursSettings    `json:"quietHours"`
+	MediaPolicy   MediaPolicy           `json:"mediaPolicy"`
+	Footer        FooterSettings        `json:"footer"`
+	TextFormat    TextFormatSettings    `json:"textFormat"`
+	Chatwoot      ChatwootSettings      `json:"chatwoot"`
+	WarmUp        WarmUpSettings        `json:"warmUp"`
+	Retention     RetentionSettings     `json:"retention"`
+	Policy        SessionPolicy         `json:"policy"`
+	Timezone      string                `json:"timezone,omitempty" example:"America/Sao_Paulo"`
+	Sandbox       SandboxSettings       `json:"sandbox"`
+	Features      FeatureFlags          `json:"features"`
+	GroupRules    map[string]GroupRules `json:"groupRules,omitempty"`
+	InboundFilter InboundFilterSettings `json:"inboundFilter"`
+	Pairing       PairingSettings       `json:"pairing"`
+} // @name SessionSettings
+
 type PairPhoneRequest struct {
 	PhoneNumber string `json:"phoneNumber" validate:"required,e164" example:"+5511999999999"`
 } // @name PairPhoneRequest
 
+type ExportSessionRequest struct {
+	Passphrase string `json:"passphrase" validate:"required,min=8,max=256" example:"correct-horse-battery-staple"`
+} // @name ExportSessionRequest
+
+type ImportSessionRequest struct {
+	Name        string         `json:"name,omitempty" validate:"omitempty,min=3,max=50" example:"my-session"`
+	Passphrase  string         `json:"passphrase" validate:"required,min=8,max=256" example:"correct-horse-battery-staple"`
+	Backup      *SessionBackup `json:"backup" validate:"required"`
+	ProxyConfig *ProxyConfig   `json:"proxyConfig,omitempty"`
+	Connect     bool           `json:"connect" example:"true"`
+} // @name ImportSessionRequest
+
+type SessionBackup struct {
+	Version     int       `json:"version" validate:"required" example:"1"`
+	SessionName string    `json:"sessionName" example:"my-session"`
+	DeviceJID   string    `json:"deviceJid" validate:"required" example:"5511999999999:12@s.whatsapp.net"`
+	Salt        []byte    `json:"salt" validate:"required" swaggertype:"string" format:"base64" example:"q83vEjRWeJCrze8SNFZ4kA=="`
+	Nonce       []byte    `json:"nonce" validate:"required" swaggertype:"string" format:"base64" example:"3q2+7wAAAAAAAAAA"`
+	Ciphertext  []byte    `json:"ciphertext" validate:"required" swaggertype:"string" format:"base64" example:"U2FsdGVkX1..."`
+	ExportedAt  time.Time `json:"exportedAt" example:"2024-01-01T00:00:00Z"`
+} // @name SessionBackup
+
+// BulkCreateSessionsRequest provisions several sessions in one call. The
+// sessions are created in order; a failing one does not stop the others.
+type BulkCreateSessionsRequest struct {
+	Sessions []BulkSessionDefinition `json:"sessions" validate:"required,min=1,max=100,dive"`
+	Connect  bool                    `json:"connect" example:"true"`
+} // @name BulkCreateSessionsRequest
+
+type BulkSessionDefinition struct {
+	Name        string             `json:"name" validate:"required,min=3,max=50" example:"sales-01"`
+	ProxyConfig *ProxyConfig       `json:"proxyConfig,omitempty"`
+	Webhook     *SetWebhookRequest `json:"webhook,omitempty"`
+} // @name BulkSessionDefinition
+
+type BulkCreateSessionsResponse struct {
+	Total   int                 `json:"total" example:"2"`
+	Created int                 `json:"created" example:"1"`
+	Failed  int                 `json:"failed" example:"1"`
+	Results []BulkSessionResult `json:"results"`
+} // @name BulkCreateSessionsResponse
+
+// BulkSessionResult reports one definition. A session whose webhook could
+// not be saved is still created, with WebhookError set.
+type BulkSessionResult struct {
+	Name         string `json:"name" example:"sales-01"`
+	ID           string `json:"id,omitempty" example:"1b2e424c-a2a0-41a4-b992-15b7ec06b9bc"`
+	Status       string `json:"status" example:"created"`
+	Connecting   bool   `json:"connecting" example:"true"`
+	WebhookID    string `json:"webhookId,omitempty" example:"3f1c2b8e-7a44-4d1e-9c65-1b2f0c9d8e7a"`
+	WebhookError string `json:"webhookError,omitempty"`
+	Error        string `json:"error,omitempty" example:"session with this name already exists"`
+} // @name BulkSessionResult
+
 type CreateSessionResponse struct {
 	ID          string       `json:"id" example:"1b2e424c-a2a0-41a4-b992-15b7ec06b9bc"`
 	Name        string       `json:"name" example:"my-session"`
@@ -42,23 +348,52 @@ type SessionResponse struct {
 	Name            string       `json:"name" example:"my-whatsapp-session"`
 	DeviceJID       string       `json:"deviceJid,omitempty" example:"5511999999999@s.whatsapp.net"`
 	IsConnected     bool         `json:"isConnected" example:"false"`
+	Status          string       `json:"status" example:"connected" enums:"created,connecting,connected,disconnected,error,logged_out,banned,restricted,qr_timeout"`
 	ConnectionError *string      `json:"connectionError,omitempty" example:"Connection timeout"`
 	ProxyConfig     *ProxyConfig `json:"proxyConfig,omitempty"`
 	CreatedAt       time.Time    `json:"createdAt" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt       time.Time    `json:"updatedAt" example:"2024-01-01T00:00:00Z"`
 	ConnectedAt     *time.Time   `json:"connectedAt,omitempty" example:"2024-01-01T00:00:30Z"`
+	LoggedOutAt     *time.Time   `json:"loggedOutAt,omitempty" example:"2024-01-02T08:15:00Z"`
+	QRTimeoutAt     *time.Time   `json:"qrTimeoutAt,omitempty" example:"2024-01-02T08:20:00Z"`
+	TenantID        string       `json:"tenantId,omitempty" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	SendQueueDepth  int          `json:"sendQueueDepth" example:"3"`
+	Throttle        *Throttle    `json:"throttle,omitempty"`
+	Ban             *SessionBan  `json:"ban,omitempty"`
 } // @name SessionResponse
 
+// SessionBan is WhatsApp banning (banned) or temporarily restricting
+// (restricted) the session's account. Sends are refused while it lasts.
+type SessionBan struct {
+	Kind   string     `json:"kind" example:"restricted" enums:"banned,restricted"`
+	Reason string     `json:"reason" example:"sent to too many people"`
+	Code   int        `json:"code,omitempty" example:"101"`
+	Since  time.Time  `json:"since" example:"2024-01-01T12:00:00Z"`
+	Until  *time.Time `json:"until,omitempty" example:"2024-01-02T12:00:00Z"`
+} // @name SessionBan
+
+// Throttle is the pause on a session's sends after WhatsApp rate-limited
+// (rate_limited) or temporarily banned (temporary_ban) it.
+type Throttle struct {
+	Reason  string    `json:"reason" example:"rate_limited" enums:"rate_limited,temporary_ban"`
+	Code    int       `json:"code,omitempty" example:"429"`
+	Strikes int       `json:"strikes" example:"2"`
+	Since   time.Time `json:"since" example:"2024-01-01T12:00:00Z"`
+	Until   time.Time `json:"until" example:"2024-01-01T12:02:00Z"`
+} // @name Throttle
+
 type SessionInfoResponse struct {
 	Session    *SessionResponse    `json:"session"`
 	DeviceInfo *DeviceInfoResponse `json:"deviceInfo,omitempty"`
 } // @name SessionInfoResponse
 
 type ListSessionsResponse struct {
-	Sessions []SessionInfoResponse `json:"sessions"`
-	Total    int                   `json:"total" example:"10"`
-	Limit    int                   `json:"limit" example:"20"`
-	Offset   int                   `json:"offset" example:"0"`
+	Sessions   []SessionInfoResponse `json:"sessions"`
+	Total      int                   `json:"total" example:"10"`
+	Limit      int                   `json:"limit" example:"20"`
+	Offset     int                   `json:"offset" example:"0"`
+	NextCursor string                `json:"nextCursor,omitempty" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpIjoiLi4uIn0"`
+	HasMore    bool                  `json:"hasMore" example:"true"`
 } // @name ListSessionsResponse
 
 type ConnectSessionResponse struct {
@@ -75,6 +410,27 @@ type QRCodeResponse struct {
 	Timeout     int       `json:"timeoutSeconds" example:"60"`
 } // @name QRCodeResponse
 
+type QRImageRequest struct {
+	Size       int    `json:"size" example:"256"`
+	Margin     int    `json:"margin" example:"4"`
+	Logo       bool   `json:"logo" example:"false"`
+	Foreground string `json:"fg" example:"000000"`
+	Background string `json:"bg" example:"ffffff"`
+} // @name QRImageRequest
+
+// ChatLinkRequest describes a click-to-chat link. Phone defaults to the
+// session's own number.
+type ChatLinkRequest struct {
+	Phone string `json:"phone,omitempty" example:"+55 11 99999-9999"`
+	Text  string `json:"text,omitempty" validate:"omitempty,max=1000" example:"Olá! Quero saber mais sobre o plano anual"`
+} // @name ChatLinkRequest
+
+type ChatLinkResponse struct {
+	Phone string `json:"phone" example:"5511999999999"`
+	Text  string `json:"text,omitempty" example:"Olá! Quero saber mais sobre o plano anual"`
+	URL   string `json:"url" example:"https://wa.me/5511999999999?text=Ol%C3%A1%21%20Quero%20saber%20mais%20sobre%20o%20plano%20anual"`
+} // @name ChatLinkResponse
+
 type ProxyResponse struct {
 	ProxyConfig *ProxyConfig `json:"proxyConfig,omitempty"`
 } // @name ProxyResponse
@@ -93,13 +449,31 @@ type ProxyConfig struct {
 	Password string `json:"password,omitempty" example:"proxypass123"`
 } // @name ProxyConfig
 
+// DeviceInfoResponse describes the account and phone behind a session.
+// platform is the phone's platform reported at pairing and waVersion the
+// WhatsApp Web version the session connects as.
 type DeviceInfoResponse struct {
-	Platform    string `json:"platform" example:"android"`
-	DeviceModel string `json:"deviceModel" example:"Samsung Galaxy S21"`
-	OSVersion   string `json:"osVersion" example:"11"`
-	AppVersion  string `json:"appVersion" example:"2.21.4.18"`
+	JID          string                 `json:"jid,omitempty" example:"5511999999999@s.whatsapp.net"`
+	LID          string                 `json:"lid,omitempty" example:"123456789012345@lid"`
+	PushName     string                 `json:"pushName,omitempty" example:"Acme Support"`
+	BusinessName string                 `json:"businessName,omitempty" example:"Acme Ltda"`
+	Platform     string                 `json:"platform,omitempty" example:"android"`
+	IsBusiness   bool                   `json:"isBusiness" example:"false"`
+	WAVersion    string                 `json:"waVersion" example:"2.3000.1027868479"`
+	Connection   ConnectionInfoResponse `json:"connection"`
 } // @name DeviceInfoResponse
 
+// ConnectionInfoResponse is the state of the session's socket. reconnects
+// counts reconnections since the client was loaded and keepAliveFailures
+// the consecutive keep-alive timeouts.
+type ConnectionInfoResponse struct {
+	State             string     `json:"state" example:"logged_in" enums:"disconnected,connecting,connected,logged_in,error"`
+	ConnectedAt       *time.Time `json:"connectedAt,omitempty" example:"2024-01-01T00:00:30Z"`
+	LastActivityAt    time.Time  `json:"lastActivityAt" example:"2024-01-01T12:00:00Z"`
+	Reconnects        int        `json:"reconnects" example:"2"`
+	KeepAliveFailures int        `json:"keepAliveFailures" example:"0"`
+} // @name ConnectionInfoResponse
+
 func (r *CreateSessionRequest) ToCreateSessionRequest() *session.CreateSessionRequest {
 	req := &session.CreateSessionRequest{
 		Name:        r.Name,
@@ -124,8 +498,11 @@ func FromSession(s *session.Session) *SessionResponse {
 		ID:          s.ID.String(),
 		Name:        s.Name,
 		IsConnected: s.IsConnected,
+		Status:      string(s.GetStatus()),
 		CreatedAt:   s.CreatedAt,
 		UpdatedAt:   s.UpdatedAt,
+		LoggedOutAt: s.LoggedOutAt,
+		QRTimeoutAt: s.QRTimeoutAt,
 	}
 
 	if s.DeviceJID != nil {
@@ -153,6 +530,30 @@ func FromSession(s *session.Session) *SessionResponse {
 	return response
 }
 
+func FromSessionBackup(b *session.SessionBackup) *SessionBackup {
+	return &SessionBackup{
+		Version:     b.Version,
+		SessionName: b.SessionName,
+		DeviceJID:   b.DeviceJID,
+		Salt:        b.Salt,
+		Nonce:       b.Nonce,
+		Ciphertext:  b.Ciphertext,
+		ExportedAt:  b.ExportedAt,
+	}
+}
+
+func (b *SessionBackup) ToSessionBackup() *session.SessionBackup {
+	return &session.SessionBackup{
+		Version:     b.Version,
+		SessionName: b.SessionName,
+		DeviceJID:   b.DeviceJID,
+		Salt:        b.Salt,
+		Nonce:       b.Nonce,
+		Ciphertext:  b.Ciphertext,
+		ExportedAt:  b.ExportedAt,
+	}
+}
+
 func FromQRCodeResponse(qr *session.QRCodeResponse) *QRCodeResponse {
 	return &QRCodeResponse{
 		QRCode:    qr.QRCode,
@@ -0,0 +1,60 @@
+package contracts
+
+import "time"
+
+type TenantResponse struct {
+	ID                string       `json:"id" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Name              string       `json:"name" example:"acme"`
+	MaxSessions       int          `json:"maxSessions" example:"5"`
+	MaxMessagesPerDay int          `json:"maxMessagesPerDay" example:"10000"`
+	TrackingDomain    string       `json:"trackingDomain,omitempty" example:"https://go.acme.com"`
+	Usage             *TenantUsage `json:"usage,omitempty"`
+	CreatedAt         time.Time    `json:"createdAt" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt         time.Time    `json:"updatedAt" example:"2024-01-01T12:00:00Z"`
+} // @name TenantResponse
+
+type TenantUsage struct {
+	Sessions      int    `json:"sessions" example:"2"`
+	Day           string `json:"day" example:"2024-01-01"`
+	MessagesToday int    `json:"messagesToday" example:"350"`
+} // @name TenantUsage
+
+type ListTenantsResponse struct {
+	Tenants []TenantResponse `json:"tenants"`
+	Total   int              `json:"total" example:"2"`
+} // @name ListTenantsResponse
+
+type CreateTenantRequest struct {
+	Name              string `json:"name" validate:"required,max=100" example:"acme"`
+	MaxSessions       int    `json:"maxSessions" validate:"min=0" example:"5"`
+	MaxMessagesPerDay int    `json:"maxMessagesPerDay" validate:"min=0" example:"10000"`
+	TrackingDomain    string `json:"trackingDomain,omitempty" validate:"omitempty,url,max=255" example:"https://go.acme.com"`
+} // @name CreateTenantRequest
+
+// UpdateTenantRequest changes only the fields that are present.
+type UpdateTenantRequest struct {
+	Name              *string `json:"name,omitempty" validate:"omitempty,max=100" example:"acme"`
+	MaxSessions       *int    `json:"maxSessions,omitempty" validate:"omitempty,min=0" example:"10"`
+	MaxMessagesPerDay *int    `json:"maxMessagesPerDay,omitempty" validate:"omitempty,min=0" example:"20000"`
+	TrackingDomain    *string `json:"trackingDomain,omitempty" validate:"omitempty,max=255" example:"https://go.acme.com"`
+} // @name UpdateTenantRequest
+
+type CreateTenantKeyRequest struct {
+	Name string `json:"name" validate:"max=100" example:"production"`
+} // @name CreateTenantKeyRequest
+
+// TenantKeyResponse describes a tenant API key. Key is only returned when
+// the key is created.
+type TenantKeyResponse struct {
+	ID         string     `json:"id" example:"1b4e28ba-2fa1-11d2-883f-0016d3cca427"`
+	Name       string     `json:"name" example:"production"`
+	Prefix     string     `json:"prefix" example:"zpt_3f9a1c2e"`
+	Key        string     `json:"key,omitempty" example:"zpt_3f9a1c2e5d7b9f1a3c5e7d9b1f3a5c7e9d1b3f5a7c9e1d3b"`
+	CreatedAt  time.Time  `json:"createdAt" example:"2024-01-01T12:00:00Z"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" example:"2024-01-02T08:15:00Z"`
+} // @name TenantKeyResponse
+
+type ListTenantKeysResponse struct {
+	Keys  []TenantKeyResponse `json:"keys"`
+	Total int                 `json:"total" example:"1"`
+} // @name ListTenantKeysResponse
@@ -0,0 +1,51 @@
+package contracts
+
+import "time"
+
+// TestEvent is one step of a script injected into a fake session. DelayMs
+// is waited before the step, counted from the previous one.
+type TestEvent struct {
+	Type        string   `json:"type" validate:"required,oneof=message receipt connected disconnected logged_out" example:"message"`
+	DelayMs     int      `json:"delayMs" validate:"min=0,max=60000" example:"0"`
+	From        string   `json:"from,omitempty" example:"5511999999999"`
+	Chat        string   `json:"chat,omitempty" example:"120363025246125888@g.us"`
+	FromMe      bool     `json:"fromMe,omitempty" example:"false"`
+	ID          string   `json:"id,omitempty" example:"3EB0C767D26A1D8E2F21"`
+	Text        string   `json:"text,omitempty" example:"Olá!"`
+	PushName    string   `json:"pushName,omitempty" example:"Maria"`
+	ReceiptType string   `json:"receiptType,omitempty" validate:"omitempty,oneof=delivered read played" example:"read"`
+	MessageIDs  []string `json:"messageIds,omitempty" example:"3EB0C767D26A1D8E2F21"`
+	Reason      string   `json:"reason,omitempty" example:"removed from phone"`
+} // @name TestEvent
+
+type InjectTestEventsRequest struct {
+	Events []TestEvent `json:"events" validate:"required,min=1,max=100,dive"`
+} // @name InjectTestEventsRequest
+
+// InjectTestEventsResponse lists the IDs of the scripted messages, in
+// script order.
+type InjectTestEventsResponse struct {
+	MessageIDs []string `json:"messageIds"`
+} // @name InjectTestEventsResponse
+
+type PairTestSessionRequest struct {
+	Phone string `json:"phone" validate:"omitempty,numeric,min=8,max=15" example:"5511999999999"`
+} // @name PairTestSessionRequest
+
+type PairTestSessionResponse struct {
+	DeviceJID string `json:"deviceJid" example:"5511999999999:1@s.whatsapp.net"`
+} // @name PairTestSessionResponse
+
+type TestSentMessage struct {
+	ID        string      `json:"id" example:"3EB00000000000000001"`
+	Type      string      `json:"type" example:"text"`
+	To        string      `json:"to" example:"5511999999999@s.whatsapp.net"`
+	Content   string      `json:"content,omitempty" example:"Olá!"`
+	Payload   interface{} `json:"payload,omitempty"`
+	Timestamp time.Time   `json:"timestamp" example:"2024-01-01T12:00:00Z"`
+} // @name TestSentMessage
+
+type ListTestSentMessagesResponse struct {
+	Messages []TestSentMessage `json:"messages"`
+	Total    int               `json:"total" example:"1"`
+} // @name ListTestSentMessagesResponse
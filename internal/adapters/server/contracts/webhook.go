@@ -0,0 +1,57 @@
+package contracts
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SetWebhookRequest struct {
+	URL           string          `json:"url" validate:"required,url" example:"https://crm.example.com/hooks/whatsapp"`
+	Secret        *string         `json:"secret,omitempty" validate:"omitempty,max=255" example:"change-me"`
+	Events        []string        `json:"events,omitempty" validate:"omitempty,dive,oneof=messages receipts presence groups calls connection" example:"messages,calls"`
+	Template      json.RawMessage `json:"template,omitempty" swaggertype:"object"`
+	SchemaVersion *int            `json:"schemaVersion,omitempty" validate:"omitempty,min=1,max=2" example:"2"`
+	Format        *string         `json:"format,omitempty" validate:"omitempty,oneof=full simple" example:"simple"`
+	Priority      *int            `json:"priority,omitempty" validate:"omitempty,min=0,max=100" example:"10"`
+	Enabled       *bool           `json:"enabled,omitempty" example:"true"`
+} // @name SetWebhookRequest
+
+type WebhookResponse struct {
+	ID            string               `json:"id" example:"3f1c2b8e-7a44-4d1e-9c65-1b2f0c9d8e7a"`
+	SessionID     string               `json:"sessionId" example:"0b6c7b6e-2d8a-4c2b-8f1e-5a9d3c7e1f20"`
+	URL           string               `json:"url" example:"https://crm.example.com/hooks/whatsapp"`
+	Events        []string             `json:"events" example:"messages,calls"`
+	Template      json.RawMessage      `json:"template,omitempty" swaggertype:"object"`
+	SchemaVersion int                  `json:"schemaVersion" example:"2"`
+	Format        string               `json:"format" example:"simple"`
+	Priority      int                  `json:"priority" example:"10"`
+	Enabled       bool                 `json:"enabled" example:"true"`
+	HasSecret     bool                 `json:"hasSecret" example:"true"`
+	Delivery      WebhookDeliveryState `json:"delivery"`
+	CreatedAt     time.Time            `json:"createdAt" example:"2024-01-01T12:00:00Z"`
+	UpdatedAt     time.Time            `json:"updatedAt" example:"2024-01-01T12:00:00Z"`
+} // @name WebhookResponse
+
+// WebhookDeliveryState is the outcome of the latest deliveries to one webhook.
+// failures counts consecutive failures and resets on the next success.
+type WebhookDeliveryState struct {
+	LastDeliveryAt *time.Time `json:"lastDeliveryAt,omitempty" example:"2024-01-01T12:00:00Z"`
+	LastSuccessAt  *time.Time `json:"lastSuccessAt,omitempty" example:"2024-01-01T12:00:00Z"`
+	LastStatusCode int        `json:"lastStatusCode,omitempty" example:"200"`
+	LastError      string     `json:"lastError,omitempty"`
+	Failures       int        `json:"failures" example:"0"`
+} // @name WebhookDeliveryState
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Total    int               `json:"total" example:"2"`
+} // @name ListWebhooksResponse
+
+type TestWebhookResponse struct {
+	Delivered  bool   `json:"delivered" example:"true"`
+	URL        string `json:"url" example:"https://crm.example.com/hooks/whatsapp"`
+	StatusCode int    `json:"statusCode,omitempty" example:"200"`
+	Attempts   int    `json:"attempts" example:"1"`
+	DurationMs int64  `json:"durationMs" example:"84"`
+	Error      string `json:"error,omitempty"`
+} // @name TestWebhookResponse
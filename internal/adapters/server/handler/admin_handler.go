@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services"
+	"zpwoot/platform/config"
+	"zpwoot/platform/database"
+	"zpwoot/platform/logger"
+	"zpwoot/platform/secrets"
+)
+
+type AdminHandler struct {
+	*shared.BaseHandler
+	reloader     *config.Reloader
+	auditService *services.AuditService
+	pipeline     *inbound.Pipeline
+	sendMetrics  *session.SendMetrics
+	mediaPool    *messaging.MediaPool
+	secrets      secrets.Rotator
+	database     *database.Database
+}
+
+func NewAdminHandler(reloader *config.Reloader, auditService *services.AuditService, pipeline *inbound.Pipeline, sendMetrics *session.SendMetrics, mediaPool *messaging.MediaPool, secretsRotator secrets.Rotator, db *database.Database, logger *logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		BaseHandler:  shared.NewBaseHandler(logger),
+		reloader:     reloader,
+		auditService: auditService,
+		pipeline:     pipeline,
+		sendMetrics:  sendMetrics,
+		mediaPool:    mediaPool,
+		secrets:      secretsRotator,
+		database:     db,
+	}
+}
+
+// @Summary Reload configuration
+// @Description Re-read reloadable settings (log level, global webhook, rate limits) from the environment and .env file without restarting sessions
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ConfigReloadResponse}
+// @Failure 500 {object} shared.ErrorResponse
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/config/reload [post]
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "reload config")
+
+	if h.reloader == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Configuration reload is not available")
+		return
+	}
+
+	result, err := h.reloader.Reload()
+	if err != nil {
+		h.HandleError(w, err, "reload config")
+		return
+	}
+
+	h.LogSuccess("reload config", map[string]interface{}{
+		"changed": result.Changed,
+	})
+
+	h.GetWriter().WriteSuccess(w, &contracts.ConfigReloadResponse{
+		Changed:    result.Changed,
+		ReloadedAt: result.ReloadedAt,
+	}, "Configuration reloaded successfully")
+}
+
+// @Summary List audit log
+// @Description List recorded mutating API calls, newest first
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param apiKey query string false "Masked API key"
+// @Param session query string false "Session name or ID"
+// @Param method query string false "HTTP method" Enums(POST, PUT, PATCH, DELETE)
+// @Param path query string false "Path prefix"
+// @Param success query bool false "Only successful (true) or failed (false) calls"
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListAuditLogResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/audit [get]
+func (h *AdminHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list audit log")
+
+	if h.auditService == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Audit log is disabled")
+		return
+	}
+
+	limit, offset, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	req := &contracts.ListAuditLogRequest{
+		APIKey:  h.GetQueryString(r, "apiKey"),
+		Session: h.GetQueryString(r, "session"),
+		Method:  h.GetQueryString(r, "method"),
+		Path:    h.GetQueryString(r, "path"),
+		Limit:   limit,
+		Offset:  offset,
+	}
+
+	if r.URL.Query().Has("success") {
+		success, err := h.GetQueryBool(r, "success")
+		if err != nil {
+			h.GetWriter().WriteBadRequest(w, "Invalid success parameter", err.Error())
+			return
+		}
+		req.Success = &success
+	}
+
+	if req.From, err = parseTimeQuery(r, "from"); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid from parameter", err.Error())
+		return
+	}
+	if req.To, err = parseTimeQuery(r, "to"); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid to parameter", err.Error())
+		return
+	}
+
+	response, err := h.auditService.ListEntries(r.Context(), req)
+	if err != nil {
+		h.HandleError(w, err, "list audit log")
+		return
+	}
+
+	h.LogSuccess("list audit log", map[string]interface{}{
+		"total":  response.Total,
+		"limit":  response.Limit,
+		"offset": response.Offset,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Audit log retrieved successfully")
+}
+
+func parseTimeQuery(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+// @Summary Inbound pipeline statistics
+// @Description Per-stage counters of the inbound event pipeline (dedup, state, chatwoot, webhook and any plugin stages), in the order the stages run
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.InboundPipelineResponse}
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/pipeline [get]
+func (h *AdminHandler) GetInboundPipeline(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get inbound pipeline")
+
+	if h.pipeline == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Inbound pipeline is not available")
+		return
+	}
+
+	stats := h.pipeline.Stats()
+	response := &contracts.InboundPipelineResponse{
+		Stages: make([]contracts.InboundStageStats, len(stats)),
+	}
+	for i, stage := range stats {
+		var avg float64
+		if stage.Processed > 0 {
+			avg = float64(stage.TotalDuration.Microseconds()) / float64(stage.Processed) / 1000
+		}
+		response.Stages[i] = contracts.InboundStageStats{
+			Name:          stage.Name,
+			Processed:     stage.Processed,
+			Stopped:       stage.Stopped,
+			Failed:        stage.Failed,
+			AvgDurationMs: avg,
+			MaxDurationMs: float64(stage.MaxDuration.Microseconds()) / 1000,
+			LastError:     stage.LastError,
+		}
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Inbound pipeline statistics retrieved successfully")
+}
+
+// @Summary Send statistics
+// @Description Per-kind counters (count, errors, latency) of the sends that reached WhatsApp since startup
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SendStatsResponse}
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/sends [get]
+func (h *AdminHandler) GetSendStats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get send stats")
+
+	if h.sendMetrics == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Send statistics are not available")
+		return
+	}
+
+	stats := h.sendMetrics.Stats()
+	response := &contracts.SendStatsResponse{
+		Kinds: make([]contracts.SendKindStats, len(stats)),
+	}
+	for i, kind := range stats {
+		var avg float64
+		if kind.Count > 0 {
+			avg = float64(kind.TotalDuration.Microseconds()) / float64(kind.Count) / 1000
+		}
+		response.Kinds[i] = contracts.SendKindStats{
+			Kind:          kind.Kind,
+			Count:         kind.Count,
+			Errors:        kind.Errors,
+			AvgDurationMs: avg,
+			MaxDurationMs: float64(kind.MaxDuration.Microseconds()) / 1000,
+			LastError:     kind.LastError,
+		}
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Send statistics retrieved successfully")
+}
+
+// @Summary Media processing statistics
+// @Description Workers, queue depth and wait times of the pool that runs CPU-heavy media work, with the sessions that have jobs queued or running
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.MediaPoolStatsResponse}
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/media [get]
+func (h *AdminHandler) GetMediaPoolStats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get media pool stats")
+
+	if h.mediaPool == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Media processing statistics are not available")
+		return
+	}
+
+	stats := h.mediaPool.Stats()
+	response := &contracts.MediaPoolStatsResponse{
+		Workers:    stats.Workers,
+		Busy:       stats.Busy,
+		Queued:     stats.Queued,
+		QueueDepth: stats.QueueDepth,
+		Processed:  stats.Processed,
+		Rejected:   stats.Rejected,
+		AvgWaitMs:  float64(stats.AvgWait.Microseconds()) / 1000,
+		MaxWaitMs:  float64(stats.MaxWait.Microseconds()) / 1000,
+		Sessions:   make([]contracts.MediaQueueStats, len(stats.Sessions)),
+	}
+	for i, queue := range stats.Sessions {
+		response.Sessions[i] = contracts.MediaQueueStats{
+			Session: queue.Session,
+			Queued:  queue.Queued,
+			Running: queue.Running,
+		}
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Media processing statistics retrieved successfully")
+}
+
+// @Summary Re-encrypt stored secrets
+// @Description Seal every webhook secret and proxy credential again under the current master key (SECRETS_MASTER_KEY): plaintext stored before encryption was turned on, and values sealed with a previous key. Run it after a key rotation, then drop the old key from SECRETS_PREVIOUS_KEYS once failed is 0. The same pass runs at startup
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SecretsRotationResponse}
+// @Failure 409 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/secrets/rotate [post]
+func (h *AdminHandler) RotateSecrets(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "rotate secrets")
+
+	if h.secrets == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Secret encryption is not available")
+		return
+	}
+
+	rotation, err := h.secrets.Rotate(r.Context())
+	if err != nil {
+		h.HandleError(w, err, "rotate secrets")
+		return
+	}
+	if rotation.KeyID == "" {
+		h.GetWriter().WriteError(w, http.StatusConflict, "No secrets master key is configured")
+		return
+	}
+
+	h.LogSuccess("rotate secrets", map[string]interface{}{
+		"key_id":  rotation.KeyID,
+		"rotated": rotation.Rotated,
+		"failed":  rotation.Failed,
+	})
+
+	h.GetWriter().WriteSuccess(w, &contracts.SecretsRotationResponse{
+		KeyID:   rotation.KeyID,
+		Rotated: rotation.Rotated,
+		Current: rotation.Current,
+		Failed:  rotation.Failed,
+	}, "Secrets re-encrypted successfully")
+}
+
+// @Summary Database statistics
+// @Description Connection pool usage and per-operation query counters (count, errors, latency) since startup, plus the read replica's last health check when one is configured
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DatabaseStatsResponse}
+// @Failure 503 {object} shared.ErrorResponse
+// @Router /admin/database [get]
+func (h *AdminHandler) GetDatabaseStats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get database stats")
+
+	if h.database == nil {
+		h.GetWriter().WriteError(w, http.StatusServiceUnavailable, "Database statistics are not available")
+		return
+	}
+
+	pool := h.database.Stats()
+	operations, retries := h.database.QueryStats()
+
+	response := &contracts.DatabaseStatsResponse{
+		Pool: contracts.DatabasePoolStats{
+			MaxOpenConnections: pool.MaxOpenConnections,
+			OpenConnections:    pool.OpenConnections,
+			InUse:              pool.InUse,
+			Idle:               pool.Idle,
+			WaitCount:          pool.WaitCount,
+			WaitDurationMs:     float64(pool.WaitDuration.Microseconds()) / 1000,
+			MaxIdleClosed:      pool.MaxIdleClosed,
+			MaxIdleTimeClosed:  pool.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  pool.MaxLifetimeClosed,
+		},
+		Operations:     make([]contracts.DatabaseOperationStats, len(operations)),
+		ConnectRetries: retries,
+	}
+	if replica, ok := h.database.ReplicaStatus(); ok {
+		response.Replica = &contracts.DatabaseReplicaStats{
+			Healthy:   replica.Healthy,
+			LagMs:     replica.Lag.Milliseconds(),
+			CheckedAt: replica.CheckedAt,
+			LastError: replica.LastError,
+		}
+	}
+	for i, op := range operations {
+		var avg float64
+		if op.Count > 0 {
+			avg = float64(op.TotalDuration.Microseconds()) / float64(op.Count) / 1000
+		}
+		response.Operations[i] = contracts.DatabaseOperationStats{
+			Operation:     op.Operation,
+			Count:         op.Count,
+			Errors:        op.Errors,
+			AvgDurationMs: avg,
+			MaxDurationMs: float64(op.MaxDuration.Microseconds()) / 1000,
+			LastError:     op.LastError,
+		}
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Database statistics retrieved successfully")
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"zpwoot/internal/adapters/server/contracts"
 	"zpwoot/internal/adapters/server/shared"
 	"zpwoot/internal/services"
 	"zpwoot/platform/logger"
@@ -13,104 +14,179 @@ import (
 
 type ChatwootHandler struct {
 	*shared.BaseHandler
-	messageService *services.MessageService
-	sessionService *services.SessionService
+	messageService  *services.MessageService
+	sessionService  *services.SessionService
+	chatwootService *services.ChatwootService
 }
 
 func NewChatwootHandler(
 	messageService *services.MessageService,
 	sessionService *services.SessionService,
+	chatwootService *services.ChatwootService,
 	logger *logger.Logger,
 ) *ChatwootHandler {
 	return &ChatwootHandler{
-		BaseHandler:    shared.NewBaseHandler(logger),
-		messageService: messageService,
-		sessionService: sessionService,
+		BaseHandler:     shared.NewBaseHandler(logger),
+		messageService:  messageService,
+		sessionService:  sessionService,
+		chatwootService: chatwootService,
 	}
 }
 
-type ChatwootWebhookPayload struct {
-	Event        string                `json:"event"`
-	Account      *ChatwootAccount      `json:"account,omitempty"`
-	Conversation *ChatwootConversation `json:"conversation,omitempty"`
-	Message      *ChatwootMessage      `json:"message,omitempty"`
-	Contact      *ChatwootContact      `json:"contact,omitempty"`
-	Inbox        *ChatwootInbox        `json:"inbox,omitempty"`
-}
+// @Summary Receive Chatwoot webhook
+// @Description Account webhook for Chatwoot. Public outgoing agent messages are sent through the session mapped to the conversation's inbox; other events are acknowledged and ignored.
+// @Tags Chatwoot
+// @Accept json
+// @Produce json
+// @Param request body contracts.ChatwootWebhookPayload true "Chatwoot webhook"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ChatwootWebhookResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse "Inbox not mapped to a session"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /chatwoot/webhook [post]
+func (h *ChatwootHandler) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "receive chatwoot webhook")
 
-type ChatwootAccount struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-}
+	var payload contracts.ChatwootWebhookPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid webhook payload")
+		return
+	}
 
-type ChatwootConversation struct {
-	ID     int    `json:"id"`
-	Status string `json:"status"`
-}
+	response, err := h.chatwootService.HandleWebhook(r.Context(), &payload)
+	if err != nil {
+		h.HandleError(w, err, "route chatwoot webhook")
+		return
+	}
 
-type ChatwootMessage struct {
-	ID          int    `json:"id"`
-	Content     string `json:"content"`
-	MessageType string `json:"message_type"`
-	Private     bool   `json:"private"`
-	SenderType  string `json:"sender_type"`
-}
+	h.LogSuccess("receive chatwoot webhook", map[string]interface{}{
+		"event":      payload.Event,
+		"routed":     response.Routed,
+		"session_id": response.SessionID,
+		"reason":     response.Reason,
+	})
 
-type ChatwootContact struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	PhoneNumber string `json:"phone_number"`
+	h.GetWriter().WriteSuccess(w, response, "Webhook processed successfully")
 }
 
-type ChatwootInbox struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-}
+// @Summary List Chatwoot inbox mappings
+// @Description List which session answers each Chatwoot inbox
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param accountId query int false "Only this Chatwoot account"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListChatwootInboxesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/chatwoot/inboxes [get]
+func (h *ChatwootHandler) ListInboxes(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list chatwoot inboxes")
+
+	accountID, err := h.GetQueryInt(r, "accountId")
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return
+	}
 
-func (h *ChatwootHandler) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
-	h.LogRequest(r, "receive chatwoot webhook")
+	response, err := h.chatwootService.ListInboxes(r.Context(), accountID)
+	if err != nil {
+		h.HandleError(w, err, "list chatwoot inboxes")
+		return
+	}
 
-	sessionID := chi.URLParam(r, "sessionName")
-	if sessionID == "" {
-		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+	h.GetWriter().WriteSuccess(w, response, "Chatwoot inboxes retrieved successfully")
+}
+
+// @Summary Map Chatwoot inbox
+// @Description Route a Chatwoot inbox to a session. Fails with 409 CHATWOOT_INBOX_CONFLICT when the inbox belongs to another session or the session already answers another inbox of the account; set force to re-map.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param accountId path int true "Chatwoot account ID"
+// @Param inboxId path int true "Chatwoot inbox ID"
+// @Param request body contracts.MapChatwootInboxRequest true "Session"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ChatwootInboxMapping}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 409 {object} shared.ErrorResponse "Mapping conflict"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/chatwoot/inboxes/{accountId}/{inboxId} [put]
+func (h *ChatwootHandler) MapInbox(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "map chatwoot inbox")
+
+	accountID, inboxID, ok := h.inboxParams(w, r)
+	if !ok {
 		return
 	}
 
-	var payload ChatwootWebhookPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid webhook payload")
+	var req contracts.MapChatwootInboxRequest
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	_, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	response, err := h.chatwootService.MapInbox(r.Context(), accountID, inboxID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "map chatwoot inbox")
 		return
 	}
 
-	h.GetLogger().InfoWithFields("Chatwoot webhook received", map[string]interface{}{
-		"session_id": sessionID,
-		"event":      payload.Event,
-		"message_id": func() interface{} {
-			if payload.Message != nil {
-				return payload.Message.ID
-			}
-			return nil
-		}(),
-		"conversation_id": func() interface{} {
-			if payload.Conversation != nil {
-				return payload.Conversation.ID
-			}
-			return nil
-		}(),
+	h.LogSuccess("map chatwoot inbox", map[string]interface{}{
+		"account_id": accountID,
+		"inbox_id":   inboxID,
+		"session_id": response.SessionID,
 	})
 
-	h.LogSuccess("receive chatwoot webhook", map[string]interface{}{
-		"session_id": sessionID,
-		"event":      payload.Event,
+	h.GetWriter().WriteSuccess(w, response, "Chatwoot inbox mapped successfully")
+}
+
+// @Summary Unmap Chatwoot inbox
+// @Description Stop routing a Chatwoot inbox to its session
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param accountId path int true "Chatwoot account ID"
+// @Param inboxId path int true "Chatwoot inbox ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/chatwoot/inboxes/{accountId}/{inboxId} [delete]
+func (h *ChatwootHandler) UnmapInbox(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "unmap chatwoot inbox")
+
+	accountID, inboxID, ok := h.inboxParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.chatwootService.UnmapInbox(r.Context(), accountID, inboxID); err != nil {
+		h.HandleError(w, err, "unmap chatwoot inbox")
+		return
+	}
+
+	h.LogSuccess("unmap chatwoot inbox", map[string]interface{}{
+		"account_id": accountID,
+		"inbox_id":   inboxID,
 	})
 
-	h.GetWriter().WriteSuccess(w, nil, "Webhook processed successfully")
+	h.GetWriter().WriteSuccess(w, nil, "Chatwoot inbox unmapped successfully")
+}
+
+func (h *ChatwootHandler) inboxParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	accountID, err := h.GetIntParam(r, "accountId")
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return 0, 0, false
+	}
+
+	inboxID, err := h.GetIntParam(r, "inboxId")
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return 0, 0, false
+	}
+
+	return accountID, inboxID, true
 }
 
 // @Summary Create Chatwoot configuration
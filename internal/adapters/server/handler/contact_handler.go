@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -16,17 +15,20 @@ import (
 type ContactHandler struct {
 	*shared.BaseHandler
 	contactService *contact.Service
+	contacts       *services.ContactService
 	sessionService *services.SessionService
 }
 
 func NewContactHandler(
 	contactService *contact.Service,
+	contacts *services.ContactService,
 	sessionService *services.SessionService,
 	logger *logger.Logger,
 ) *ContactHandler {
 	return &ContactHandler{
 		BaseHandler:    shared.NewBaseHandler(logger),
 		contactService: contactService,
+		contacts:       contacts,
 		sessionService: sessionService,
 	}
 }
@@ -47,13 +49,6 @@ type CheckWhatsAppResult struct {
 	Error        string `json:"error,omitempty"`
 }
 
-type GetProfilePictureResponse struct {
-	JID        string `json:"jid"`
-	PictureURL string `json:"pictureUrl,omitempty"`
-	PictureID  string `json:"pictureId,omitempty"`
-	HasPicture bool   `json:"hasPicture"`
-}
-
 type GetUserInfoRequest struct {
 	JIDs []string `json:"jids" validate:"required,min=1,max=20"`
 }
@@ -110,17 +105,17 @@ type BusinessProfileResponse struct {
 }
 
 // @Summary Check WhatsApp numbers
-// @Description Check if phone numbers are registered on WhatsApp
+// @Description Check which phone numbers are registered on WhatsApp. Answers come from a cache of recent lookups where possible; refresh forces live lookups. Numbers are normalized and registered ones come back with their JID.
 // @Tags Contacts
 // @Security ApiKeyAuth
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param request body CheckWhatsAppRequest true "Phone numbers to check"
-// @Success 200 {object} shared.SuccessResponse{data=CheckWhatsAppResponse}
-// @Failure 400 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Param request body contracts.CheckWhatsAppRequest true "Phone numbers to check"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.CheckWhatsAppResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/contacts/check [post]
 func (h *ContactHandler) CheckWhatsApp(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "check WhatsApp numbers")
@@ -132,50 +127,35 @@ func (h *ContactHandler) CheckWhatsApp(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.CheckWhatsAppRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
-		return
-	}
-
-	session, err := h.sessionService.GetSession(r.Context(), sessionID)
+	response, err := h.contacts.CheckNumbers(r.Context(), sessionID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
-		return
-	}
-
-	response, err := h.contactService.CheckWhatsApp(r.Context(), sessionID, &req)
-	if err != nil {
-		h.GetLogger().ErrorWithFields("Failed to check WhatsApp numbers", map[string]interface{}{
-			"session_id": sessionID,
-			"error":      err.Error(),
-		})
-		h.GetWriter().WriteInternalError(w, "Failed to check WhatsApp numbers")
+		h.HandleError(w, err, "check WhatsApp numbers")
 		return
 	}
 
 	h.LogSuccess("check WhatsApp numbers", map[string]interface{}{
-		"session_id":    sessionID,
-		"session_name":  session.Session.Name,
-		"phone_count":   len(req.PhoneNumbers),
-		"results_count": len(response.Results),
+		"session_id":  sessionID,
+		"phone_count": len(req.PhoneNumbers),
+		"found":       response.Found,
+		"cached":      response.Cached,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
 // @Summary Get profile picture
-// @Description Get profile picture of a contact
+// @Description Get profile picture of a contact. Pictures are cached by ID and kept current by change events, so repeated lookups do not reach WhatsApp; refresh=true asks WhatsApp anyway
 // @Tags Contacts
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param jid query string true "Contact JID"
-// @Success 200 {object} shared.SuccessResponse{data=GetProfilePictureResponse}
+// @Param jid query string true "Contact JID or phone number"
+// @Param refresh query bool false "Ask WhatsApp even when cached"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ContactAvatarResponse}
 // @Failure 400 {object} shared.SuccessResponse
 // @Failure 404 {object} shared.SuccessResponse
 // @Failure 500 {object} shared.SuccessResponse
@@ -195,24 +175,23 @@ func (h *ContactHandler) GetProfilePicture(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	session, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	refresh, err := h.GetQueryBool(r, "refresh", false)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.GetWriter().WriteBadRequest(w, "Invalid refresh parameter")
 		return
 	}
 
-	response := GetProfilePictureResponse{
-		JID:        jid,
-		PictureURL: "https://example.com/profile-picture.jpg",
-		PictureID:  "placeholder-picture-id",
-		HasPicture: true,
+	response, err := h.contacts.GetAvatar(r.Context(), sessionID, jid, refresh)
+	if err != nil {
+		h.HandleError(w, err, "get profile picture")
+		return
 	}
 
 	h.LogSuccess("get profile picture", map[string]interface{}{
-		"session_id":   sessionID,
-		"session_name": session.Session.Name,
-		"jid":          jid,
-		"has_picture":  response.HasPicture,
+		"session_id":  sessionID,
+		"jid":         response.JID,
+		"has_picture": response.HasPicture,
+		"cached":      response.Cached,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, "Profile picture retrieved successfully")
@@ -241,13 +220,7 @@ func (h *ContactHandler) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.GetUserInfoRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -278,15 +251,15 @@ func (h *ContactHandler) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary List contacts
-// @Description List contacts with pagination and filters
+// @Description List the contacts stored on the session's device, ordered by JID. Pass the returned nextCursor as cursor to fetch the following page
 // @Tags Contacts
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param limit query int false "Limit (default: 50, max: 100)"
-// @Param offset query int false "Offset (default: 0)"
-// @Param search query string false "Search term"
-// @Success 200 {object} shared.SuccessResponse{data=ListContactsResponse}
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Param search query string false "Match against name, business name or phone number"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListContactsResponse}
 // @Failure 400 {object} shared.SuccessResponse
 // @Failure 404 {object} shared.SuccessResponse
 // @Failure 500 {object} shared.SuccessResponse
@@ -300,61 +273,42 @@ func (h *ContactHandler) ListContacts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	limit := parseIntQuery(r, "limit", 50)
-	offset := parseIntQuery(r, "offset", 0)
-	search := r.URL.Query().Get("search")
-
-	if limit > 100 {
-		limit = 100
-	}
-	if limit < 1 {
-		limit = 50
-	}
-	if offset < 0 {
-		offset = 0
-	}
-
-	session, err := h.sessionService.GetSession(r.Context(), sessionID)
+	limit, _, err := h.GetPaginationParams(r)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
 		return
 	}
 
 	req := &contracts.ListContactsRequest{
 		Limit:  limit,
-		Offset: offset,
+		Cursor: h.GetQueryString(r, "cursor"),
+		Search: h.GetQueryString(r, "search"),
 	}
 
-	response, err := h.contactService.ListContacts(r.Context(), sessionID, req)
+	response, err := h.contacts.ListContacts(r.Context(), sessionID, req)
 	if err != nil {
-		h.GetLogger().ErrorWithFields("Failed to list contacts", map[string]interface{}{
-			"session_id": sessionID,
-			"error":      err.Error(),
-		})
-		h.GetWriter().WriteInternalError(w, "Failed to list contacts")
+		h.HandleError(w, err, "list contacts")
 		return
 	}
 
 	h.LogSuccess("list contacts", map[string]interface{}{
-		"session_id":   sessionID,
-		"session_name": session.Session.Name,
-		"total":        response.Total,
-		"returned":     len(response.Contacts),
-		"limit":        limit,
-		"offset":       offset,
-		"search":       search,
+		"session_id": sessionID,
+		"total":      response.Total,
+		"returned":   len(response.Contacts),
+		"has_more":   response.HasMore,
+		"search":     req.Search,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
 // @Summary Sync contacts
-// @Description Sync contacts from the device
+// @Description Record the contacts stored on the session's device in the contact registry shared by all sessions. Address books are also recorded when WhatsApp finishes syncing them after pairing
 // @Tags Contacts
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse{data=SyncContactsResponse}
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SyncContactsResponse}
 // @Failure 400 {object} shared.SuccessResponse
 // @Failure 404 {object} shared.SuccessResponse
 // @Failure 500 {object} shared.SuccessResponse
@@ -368,34 +322,89 @@ func (h *ContactHandler) SyncContacts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	response, err := h.contacts.SyncContacts(r.Context(), sessionID)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "sync contacts")
 		return
 	}
 
-	req := &contracts.SyncContactsRequest{
-		Force: false,
+	h.LogSuccess("sync contacts", map[string]interface{}{
+		"session_id":      sessionID,
+		"synced_contacts": response.SyncedCount,
+		"new_contacts":    response.NewCount,
+		"total_contacts":  response.TotalContacts,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, response.Message)
+}
+
+// @Summary List registry contacts
+// @Description List the contact registry: one record per WhatsApp user across all sessions, with what each session knows about them. Ordered by JID; pass the returned nextCursor as cursor to fetch the following page. Requires the global API key
+// @Tags Contacts
+// @Security ApiKeyAuth
+// @Produce json
+// @Param session query string false "Only users seen by this session (ID or name)"
+// @Param search query string false "Match against names or phone number"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListRegistryContactsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /contacts [get]
+func (h *ContactHandler) ListRegistryContacts(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list registry contacts")
+
+	limit, _, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
 	}
 
-	response, err := h.contactService.SyncContacts(r.Context(), sessionID, req)
+	sessionID := h.GetQueryString(r, "session")
+	response, err := h.contacts.ListRegistryContacts(r.Context(), sessionID, h.GetQueryString(r, "search"), h.GetQueryString(r, "cursor"), limit)
 	if err != nil {
-		h.GetLogger().ErrorWithFields("Failed to sync contacts", map[string]interface{}{
-			"session_id": sessionID,
-			"error":      err.Error(),
-		})
-		h.GetWriter().WriteInternalError(w, "Failed to sync contacts")
+		h.HandleError(w, err, "list registry contacts")
 		return
 	}
 
-	h.LogSuccess("sync contacts", map[string]interface{}{
-		"session_id":      sessionID,
-		"session_name":    session.Session.Name,
-		"synced_contacts": response.SyncedCount,
-		"total_contacts":  response.TotalContacts,
+	h.LogSuccess("list registry contacts", map[string]interface{}{
+		"session_id": sessionID,
+		"returned":   len(response.Contacts),
+		"has_more":   response.HasMore,
 	})
 
-	h.GetWriter().WriteSuccess(w, response, response.Message)
+	h.GetWriter().WriteSuccess(w, response, "Contacts retrieved successfully")
+}
+
+// @Summary Get registry contact
+// @Description Get one contact from the registry shared by all sessions, by registry ID, JID or phone number. Requires the global API key
+// @Tags Contacts
+// @Security ApiKeyAuth
+// @Produce json
+// @Param contactId path string true "Registry ID, JID or phone number"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.RegistryContact}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /contacts/{contactId} [get]
+func (h *ContactHandler) GetRegistryContact(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get registry contact")
+
+	ref := chi.URLParam(r, "contactId")
+
+	response, err := h.contacts.GetRegistryContact(r.Context(), ref)
+	if err != nil {
+		h.HandleError(w, err, "get registry contact")
+		return
+	}
+
+	h.LogSuccess("get registry contact", map[string]interface{}{
+		"contact_id": response.ID,
+		"sessions":   len(response.Sessions),
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Contact retrieved successfully")
 }
 
 // @Summary Get business profile
@@ -459,11 +468,11 @@ func (h *ContactHandler) GetBusinessProfile(w http.ResponseWriter, r *http.Reque
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param request body CheckWhatsAppRequest true "Phone numbers to check"
-// @Success 200 {object} shared.SuccessResponse{data=CheckWhatsAppResponse}
-// @Failure 400 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Param request body contracts.CheckWhatsAppRequest true "Phone numbers to check"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.CheckWhatsAppResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/contacts/is-on-whatsapp [post]
 func (h *ContactHandler) IsOnWhatsApp(w http.ResponseWriter, r *http.Request) {
 
@@ -532,8 +541,9 @@ func (h *ContactHandler) GetAllContacts(w http.ResponseWriter, r *http.Request)
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param jid query string true "Contact JID"
-// @Success 200 {object} shared.SuccessResponse{data=GetProfilePictureResponse}
+// @Param jid query string true "Contact JID or phone number"
+// @Param refresh query bool false "Ask WhatsApp even when cached"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ContactAvatarResponse}
 // @Failure 400 {object} shared.SuccessResponse
 // @Failure 404 {object} shared.SuccessResponse
 // @Failure 500 {object} shared.SuccessResponse
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type ConversationHandler struct {
+	*shared.BaseHandler
+	conversationService *services.ConversationService
+}
+
+func NewConversationHandler(conversationService *services.ConversationService, logger *logger.Logger) *ConversationHandler {
+	return &ConversationHandler{
+		BaseHandler:         shared.NewBaseHandler(logger),
+		conversationService: conversationService,
+	}
+}
+
+// @Summary Get conversation state
+// @Description Get the state a bot stored for a chat. The same state comes with the chat's inbound message webhooks
+// @Tags Conversations
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chatJid path string true "Chat JID or phone number"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ConversationStateResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse "Session not found, or the chat has no state"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chats/{chatJid}/state [get]
+func (h *ConversationHandler) GetState(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get conversation state")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.conversationService.GetState(r.Context(), sessionID, chi.URLParam(r, "chatJid"))
+	if err != nil {
+		h.HandleError(w, err, "get conversation state")
+		return
+	}
+
+	h.LogSuccess("get conversation state", map[string]interface{}{
+		"session_id": sessionID,
+		"chat_jid":   response.ChatJID,
+		"version":    response.Version,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Conversation state retrieved successfully")
+}
+
+// @Summary Save conversation state
+// @Description Replace the state of a chat with any JSON document, up to 64 KiB. With version the write only happens if the stored state is still at that version (0 for none), and fails with 409 STATE_VERSION_CONFLICT otherwise
+// @Tags Conversations
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chatJid path string true "Chat JID or phone number"
+// @Param request body contracts.PutConversationStateRequest true "State"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ConversationStateResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "State is not at the expected version"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chats/{chatJid}/state [put]
+func (h *ConversationHandler) PutState(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "save conversation state")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.PutConversationStateRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.conversationService.PutState(r.Context(), sessionID, chi.URLParam(r, "chatJid"), &req)
+	if err != nil {
+		h.HandleError(w, err, "save conversation state")
+		return
+	}
+
+	h.LogSuccess("save conversation state", map[string]interface{}{
+		"session_id": sessionID,
+		"chat_jid":   response.ChatJID,
+		"version":    response.Version,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Conversation state saved successfully")
+}
+
+// @Summary Delete conversation state
+// @Description Forget the state of a chat, only at the given version when one is passed
+// @Tags Conversations
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chatJid path string true "Chat JID or phone number"
+// @Param version query int false "Only delete the state at this version"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "State is not at the expected version"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chats/{chatJid}/state [delete]
+func (h *ConversationHandler) DeleteState(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete conversation state")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	chatJID := chi.URLParam(r, "chatJid")
+
+	var version *int64
+	if value := h.GetQueryString(r, "version"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			h.GetWriter().WriteBadRequest(w, "version must be a positive number")
+			return
+		}
+		version = &parsed
+	}
+
+	if err := h.conversationService.DeleteState(r.Context(), sessionID, chatJID, version); err != nil {
+		h.HandleError(w, err, "delete conversation state")
+		return
+	}
+
+	h.LogSuccess("delete conversation state", map[string]interface{}{
+		"session_id": sessionID,
+		"chat_jid":   chatJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Conversation state deleted successfully")
+}
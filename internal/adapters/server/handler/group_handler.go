@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
 	"zpwoot/internal/adapters/server/contracts"
 	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/core/group"
 	"zpwoot/internal/services"
 	"zpwoot/platform/logger"
 )
@@ -30,7 +33,7 @@ func NewGroupHandler(
 }
 
 // @Summary Create new WhatsApp group
-// @Description Create a new WhatsApp group with specified participants
+// @Description Create a new WhatsApp group with specified participants and optional initial settings
 // @Tags Groups
 // @Security ApiKeyAuth
 // @Accept json
@@ -52,8 +55,7 @@ func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.CreateGroupRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -74,12 +76,15 @@ func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary List WhatsApp groups
-// @Description List all WhatsApp groups for a session
+// @Description List the WhatsApp groups a session has joined, ordered by JID. Pass the returned nextCursor as cursor to fetch the following page
 // @Tags Groups
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.ListGroupsResponse}
+// @Failure 400 {object} shared.ErrorResponse
 // @Failure 404 {object} shared.ErrorResponse
 // @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/groups [get]
@@ -92,7 +97,18 @@ func (h *GroupHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.groupService.ListGroups(r.Context(), sessionID)
+	limit, _, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	req := &contracts.ListGroupsRequest{
+		Limit:  limit,
+		Cursor: h.GetQueryString(r, "cursor"),
+	}
+
+	response, err := h.groupService.ListGroups(r.Context(), sessionID, req)
 	if err != nil {
 		h.HandleError(w, err, "list groups")
 		return
@@ -101,18 +117,20 @@ func (h *GroupHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
 	h.LogSuccess("list groups", map[string]interface{}{
 		"session_id":  sessionID,
 		"group_count": response.Count,
+		"has_more":    response.HasMore,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
 // @Summary Get group information
-// @Description Get detailed information about a WhatsApp group
+// @Description Get detailed information about a WhatsApp group. Participants carry their admin status; with includeContacts=true they also carry the names, push names and business flag stored on the session's device
 // @Tags Groups
 // @Security ApiKeyAuth
 // @Produce json
 // @Param sessionId path string true "Session ID"
 // @Param groupJid query string true "Group JID"
+// @Param includeContacts query bool false "Include stored contact details of each participant" default(false)
 // @Success 200 {object} shared.SuccessResponse{data=contracts.GetGroupInfoResponse}
 // @Failure 400 {object} shared.ErrorResponse
 // @Failure 404 {object} shared.ErrorResponse
@@ -133,7 +151,13 @@ func (h *GroupHandler) GetGroupInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := h.groupService.GetGroupInfo(r.Context(), sessionID, groupJID)
+	includeContacts, err := h.GetQueryBool(r, "includeContacts", false)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid includeContacts parameter", err.Error())
+		return
+	}
+
+	response, err := h.groupService.GetGroupInfo(r.Context(), sessionID, groupJID, includeContacts)
 	if err != nil {
 		h.HandleError(w, err, "get group info")
 		return
@@ -172,8 +196,7 @@ func (h *GroupHandler) UpdateGroupParticipants(w http.ResponseWriter, r *http.Re
 	}
 
 	var req contracts.UpdateParticipantsRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -193,6 +216,75 @@ func (h *GroupHandler) UpdateGroupParticipants(w http.ResponseWriter, r *http.Re
 	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
+// @Summary Bulk add group participants
+// @Description Start a background job that checks the numbers with WhatsApp and adds them to the group in throttled batches
+// @Tags Groups
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param groupJid path string true "Group JID"
+// @Param request body contracts.BulkAddParticipantsRequest true "Bulk add request"
+// @Success 202 {object} shared.SuccessResponse{data=contracts.BulkAddParticipantsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/groups/{groupJid}/participants/bulk [post]
+func (h *GroupHandler) BulkAddParticipants(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "bulk add group participants")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	groupJID := chi.URLParam(r, "groupJid")
+	if sessionID == "" || groupJID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID and group JID are required")
+		return
+	}
+
+	var req contracts.BulkAddParticipantsRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.groupService.StartBulkAddParticipants(r.Context(), sessionID, groupJID, &req)
+	if err != nil {
+		h.HandleError(w, err, "bulk add group participants")
+		return
+	}
+
+	h.LogSuccess("bulk add group participants", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  response.GroupJID,
+		"job_id":     response.JobID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteAccepted(w, response, response.Message)
+}
+
+// @Summary Get bulk add job status
+// @Description Get progress and per-number outcomes of a bulk participant import
+// @Tags Groups
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param groupJid path string true "Group JID"
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.BulkAddParticipantsResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/groups/{groupJid}/participants/bulk/{jobId} [get]
+func (h *GroupHandler) GetBulkAddJob(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionName")
+	groupJID := chi.URLParam(r, "groupJid")
+	jobID := chi.URLParam(r, "jobId")
+
+	response, err := h.groupService.GetBulkAddJob(r.Context(), sessionID, groupJID, jobID)
+	if err != nil {
+		h.HandleError(w, err, "get bulk add job")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, response.Message)
+}
+
 // @Summary Set group name
 // @Description Change the name of a WhatsApp group
 // @Tags Groups
@@ -216,8 +308,7 @@ func (h *GroupHandler) SetGroupName(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SetGroupNameRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -242,16 +333,113 @@ func (h *GroupHandler) SetGroupDescription(w http.ResponseWriter, r *http.Reques
 	h.GetWriter().WriteError(w, http.StatusNotImplemented, "Set group description not implemented yet")
 }
 
+// @Summary Set group photo
+// @Description Replace a group's picture. Send the image as a multipart upload (fields group_jid and file) or as JSON with either image (base64 or data URI) or url. The image is centre-cropped to a square, scaled down to 640x640 and converted to JPEG before upload
+// @Tags Groups
+// @Security ApiKeyAuth
+// @Accept json
+// @Accept mpfd
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.SetGroupPhotoRequest false "Group JID and image as base64 or URL"
+// @Param group_jid formData string false "Group JID (multipart)"
+// @Param file formData file false "Image file: JPEG, PNG or GIF, at least 192x192 (multipart)"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SetGroupPhotoResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/groups/photo [put]
 func (h *GroupHandler) SetGroupPhoto(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "set group photo")
 
-	h.GetWriter().WriteError(w, http.StatusNotImplemented, "Set group photo not implemented yet")
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	var req contracts.SetGroupPhotoRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		// Leave headroom over the image limit for the other form fields.
+		r.Body = http.MaxBytesReader(w, r.Body, group.MaxGroupPhotoBytes+(1<<20))
+		if err := r.ParseMultipartForm(group.MaxGroupPhotoBytes); err != nil {
+			h.GetWriter().WriteBadRequest(w, "Invalid multipart form", err.Error())
+			return
+		}
+
+		req.GroupJID = r.FormValue("group_jid")
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			h.GetWriter().WriteBadRequest(w, "Missing file field", err.Error())
+			return
+		}
+		defer file.Close()
+
+		req.Data, err = io.ReadAll(file)
+		if err != nil {
+			h.GetWriter().WriteBadRequest(w, "Failed to read uploaded file", err.Error())
+			return
+		}
+	} else if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.groupService.SetGroupPhoto(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "set group photo")
+		return
+	}
+
+	h.LogSuccess("set group photo", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  req.GroupJID,
+		"picture_id": response.PictureID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
+// @Summary Get group invite link
+// @Description Get the group's current invite link and code. The session must be an admin of the group
+// @Tags Groups
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param groupJid query string true "Group JID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.InviteLinkResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/groups/invite-link [get]
 func (h *GroupHandler) GetGroupInviteLink(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "get group invite link")
 
-	h.GetWriter().WriteError(w, http.StatusNotImplemented, "Get group invite link not implemented yet")
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	groupJID := r.URL.Query().Get("groupJid")
+	if groupJID == "" {
+		h.GetWriter().WriteBadRequest(w, "Group JID is required")
+		return
+	}
+
+	response, err := h.groupService.GetInviteLink(r.Context(), sessionID, groupJID)
+	if err != nil {
+		h.HandleError(w, err, "get group invite link")
+		return
+	}
+
+	h.LogSuccess("get group invite link", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  groupJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, response.Message)
 }
 
 func (h *GroupHandler) JoinGroupViaLink(w http.ResponseWriter, r *http.Request) {
@@ -296,10 +484,48 @@ func (h *GroupHandler) SetGroupMemberAddMode(w http.ResponseWriter, r *http.Requ
 	h.GetWriter().WriteError(w, http.StatusNotImplemented, "Set group member add mode not implemented yet")
 }
 
-func (h *GroupHandler) GetGroupInfoFromLink(w http.ResponseWriter, r *http.Request) {
-	h.LogRequest(r, "get group info from link")
+// @Summary Get group invite info
+// @Description Preview a group from its invite link without joining it
+// @Tags Groups
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param link query string true "Invite link (https://chat.whatsapp.com/...)"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.GroupInviteInfoResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/groups/invite-info [get]
+func (h *GroupHandler) GetGroupInviteInfo(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get group invite info")
 
-	h.GetWriter().WriteError(w, http.StatusNotImplemented, "Get group info from link not implemented yet")
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	link := r.URL.Query().Get("link")
+	if link == "" {
+		h.GetWriter().WriteBadRequest(w, "Invite link is required")
+		return
+	}
+
+	response, err := h.groupService.GetInviteInfo(r.Context(), sessionID, link)
+	if err != nil {
+		h.HandleError(w, err, "get group invite info")
+		return
+	}
+
+	h.LogSuccess("get group invite info", map[string]interface{}{
+		"session_id":        sessionID,
+		"group_jid":         response.GroupJID,
+		"approval_required": response.ApprovalRequired,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, response.Message)
+}
+
+func (h *GroupHandler) GetGroupInfoFromLink(w http.ResponseWriter, r *http.Request) {
+	h.GetGroupInviteInfo(w, r)
 }
 
 func (h *GroupHandler) GetGroupInfoFromInvite(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type LabelHandler struct {
+	*shared.BaseHandler
+	labelService *services.LabelService
+}
+
+func NewLabelHandler(
+	labelService *services.LabelService,
+	logger *logger.Logger,
+) *LabelHandler {
+	return &LabelHandler{
+		BaseHandler:  shared.NewBaseHandler(logger),
+		labelService: labelService,
+	}
+}
+
+// @Summary List labels
+// @Description List the session's WhatsApp Business labels, including the ones created on the phone
+// @Tags Labels
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListLabelsResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels [get]
+func (h *LabelHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list labels")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	response, err := h.labelService.ListLabels(r.Context(), sessionID)
+	if err != nil {
+		h.HandleError(w, err, "list labels")
+		return
+	}
+
+	h.LogSuccess("list labels", map[string]interface{}{
+		"session_id": sessionID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Labels retrieved successfully")
+}
+
+// @Summary Create label
+// @Description Create a WhatsApp Business label. color is the index of one of the 20 colors the WhatsApp apps offer (0-19)
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.CreateLabelRequest true "Label"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.LabelResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels [post]
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "create label")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	var req contracts.CreateLabelRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.labelService.CreateLabel(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "create label")
+		return
+	}
+
+	h.LogSuccess("create label", map[string]interface{}{
+		"session_id": sessionID,
+		"label_id":   response.LabelID,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Label created successfully")
+}
+
+// @Summary Update label
+// @Description Rename or recolor a label. Omitted fields keep their current value
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body contracts.UpdateLabelRequest true "Label changes"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.LabelResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels/{labelId} [put]
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "update label")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	labelID := chi.URLParam(r, "labelId")
+	if sessionID == "" || labelID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID and label ID are required")
+		return
+	}
+
+	var req contracts.UpdateLabelRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.labelService.UpdateLabel(r.Context(), sessionID, labelID, &req)
+	if err != nil {
+		h.HandleError(w, err, "update label")
+		return
+	}
+
+	h.LogSuccess("update label", map[string]interface{}{
+		"session_id": sessionID,
+		"label_id":   labelID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Label updated successfully")
+}
+
+// @Summary Delete label
+// @Description Delete a label on every linked device. Its ID is not reused for new labels
+// @Tags Labels
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels/{labelId} [delete]
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete label")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	labelID := chi.URLParam(r, "labelId")
+	if sessionID == "" || labelID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID and label ID are required")
+		return
+	}
+
+	if err := h.labelService.DeleteLabel(r.Context(), sessionID, labelID); err != nil {
+		h.HandleError(w, err, "delete label")
+		return
+	}
+
+	h.LogSuccess("delete label", map[string]interface{}{
+		"session_id": sessionID,
+		"label_id":   labelID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Label deleted successfully")
+}
+
+// @Summary Assign label
+// @Description Apply a label to a chat, or to a single message of the chat when message_id is given
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body contracts.LabelAssociationRequest true "Chat or message to label"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.LabelAssociationResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels/{labelId}/assign [post]
+func (h *LabelHandler) AssignLabel(w http.ResponseWriter, r *http.Request) {
+	h.setAssociation(w, r, true)
+}
+
+// @Summary Unassign label
+// @Description Remove a label from a chat, or from a single message of the chat when message_id is given
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Param request body contracts.LabelAssociationRequest true "Chat or message to unlabel"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.LabelAssociationResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels/{labelId}/unassign [post]
+func (h *LabelHandler) UnassignLabel(w http.ResponseWriter, r *http.Request) {
+	h.setAssociation(w, r, false)
+}
+
+func (h *LabelHandler) setAssociation(w http.ResponseWriter, r *http.Request, labeled bool) {
+	operation := "assign label"
+	if !labeled {
+		operation = "unassign label"
+	}
+	h.LogRequest(r, operation)
+
+	sessionID := chi.URLParam(r, "sessionName")
+	labelID := chi.URLParam(r, "labelId")
+	if sessionID == "" || labelID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID and label ID are required")
+		return
+	}
+
+	var req contracts.LabelAssociationRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.labelService.SetAssociation(r.Context(), sessionID, labelID, &req, labeled)
+	if err != nil {
+		h.HandleError(w, err, operation)
+		return
+	}
+
+	h.LogSuccess(operation, map[string]interface{}{
+		"session_id": sessionID,
+		"label_id":   labelID,
+		"chat_jid":   response.ChatJID,
+		"message_id": response.MessageID,
+	})
+
+	message := "Label assigned successfully"
+	if !labeled {
+		message = "Label unassigned successfully"
+	}
+
+	h.GetWriter().WriteSuccess(w, response, message)
+}
+
+// @Summary List label associations
+// @Description List the chats and messages a label is applied to
+// @Tags Labels
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param labelId path string true "Label ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListLabelAssociationsResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/labels/{labelId}/associations [get]
+func (h *LabelHandler) ListAssociations(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list label associations")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	labelID := chi.URLParam(r, "labelId")
+	if sessionID == "" || labelID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID and label ID are required")
+		return
+	}
+
+	response, err := h.labelService.ListAssociations(r.Context(), sessionID, labelID)
+	if err != nil {
+		h.HandleError(w, err, "list label associations")
+		return
+	}
+
+	h.LogSuccess("list label associations", map[string]interface{}{
+		"session_id": sessionID,
+		"label_id":   labelID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Label associations retrieved successfully")
+}
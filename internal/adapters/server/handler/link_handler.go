@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type LinkHandler struct {
+	*shared.BaseHandler
+	linkService *services.LinkService
+}
+
+func NewLinkHandler(linkService *services.LinkService, logger *logger.Logger) *LinkHandler {
+	return &LinkHandler{
+		BaseHandler: shared.NewBaseHandler(logger),
+		linkService: linkService,
+	}
+}
+
+// @Summary Follow tracked link
+// @Description Redirect to the URL behind a tracked link sent in a message, recording the click. Recipients open these links, so no API key is needed
+// @Tags Links
+// @Param code path string true "Link code"
+// @Success 302 "Redirect to the original URL"
+// @Failure 404 {object} shared.ErrorResponse "Unknown link"
+// @Router /l/{code} [get]
+func (h *LinkHandler) FollowLink(w http.ResponseWriter, r *http.Request) {
+	target, err := h.linkService.FollowLink(r.Context(), chi.URLParam(r, "code"), h.GetClientIP(r), r.UserAgent())
+	if err != nil {
+		h.HandleError(w, err, "follow link")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// @Summary Get link click stats
+// @Description Count the clicks on the tracked links of a message or a campaign, per link and in total. At least one of message_id and campaign is required
+// @Tags Links
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param message_id query string false "WhatsApp message ID"
+// @Param campaign query string false "Campaign the messages were sent under"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.LinkStatsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/links/stats [get]
+func (h *LinkHandler) GetLinkStats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get link stats")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.linkService.GetLinkStats(r.Context(), sessionID, h.GetQueryString(r, "message_id"), h.GetQueryString(r, "campaign"))
+	if err != nil {
+		h.HandleError(w, err, "get link stats")
+		return
+	}
+
+	h.LogSuccess("get link stats", map[string]interface{}{
+		"session_id": sessionID,
+		"links":      response.Links,
+		"clicks":     response.Clicks,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Link stats retrieved successfully")
+}
+
+// @Summary List link clicks
+// @Description List the latest clicks on the tracked links of a message or a campaign, newest first. chat_jid is the recipient the link was sent to
+// @Tags Links
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param message_id query string false "WhatsApp message ID"
+// @Param campaign query string false "Campaign the messages were sent under"
+// @Param limit query int false "Maximum clicks returned" default(100) maximum(1000)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListLinkClicksResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/links/clicks [get]
+func (h *LinkHandler) ListLinkClicks(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list link clicks")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	limit, err := h.GetQueryInt(r, "limit", 0)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "limit must be a number")
+		return
+	}
+
+	response, err := h.linkService.ListLinkClicks(r.Context(), sessionID, h.GetQueryString(r, "message_id"), h.GetQueryString(r, "campaign"), limit)
+	if err != nil {
+		h.HandleError(w, err, "list link clicks")
+		return
+	}
+
+	h.LogSuccess("list link clicks", map[string]interface{}{
+		"session_id": sessionID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Link clicks retrieved successfully")
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"mime"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"zpwoot/internal/adapters/server/contracts"
 	"zpwoot/internal/adapters/server/shared"
 	"zpwoot/internal/services"
 	"zpwoot/platform/logger"
@@ -13,28 +15,35 @@ import (
 type MediaHandler struct {
 	*shared.BaseHandler
 	sessionService *services.SessionService
+	mediaService   *services.MediaService
 }
 
 func NewMediaHandler(
 	sessionService *services.SessionService,
+	mediaService *services.MediaService,
 	logger *logger.Logger,
 ) *MediaHandler {
 	return &MediaHandler{
 		BaseHandler:    shared.NewBaseHandler(logger),
 		sessionService: sessionService,
+		mediaService:   mediaService,
 	}
 }
 
 // @Summary Download media from WhatsApp
-// @Description Download media file from WhatsApp message
+// @Description Download and store the media of a received message if it was not downloaded automatically
 // @Tags Media
+// @Security ApiKeyAuth
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse
-// @Failure 400 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Param request body contracts.DownloadMediaRequest true "Message to download media from"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DownloadMediaResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse "Media quarantined by the virus scan"
+// @Failure 410 {object} shared.ErrorResponse "Media expired on WhatsApp servers"
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/media/download [post]
 func (h *MediaHandler) DownloadMedia(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "download media")
@@ -45,17 +54,83 @@ func (h *MediaHandler) DownloadMedia(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	var req contracts.DownloadMediaRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.mediaService.DownloadMedia(r.Context(), sessionID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "download media")
 		return
 	}
 
 	h.LogSuccess("download media", map[string]interface{}{
 		"session_id": sessionID,
+		"message_id": req.MessageID,
+		"file_size":  response.FileSize,
 	})
 
-	h.GetWriter().WriteSuccess(w, nil, "Media download initiated successfully")
+	h.GetWriter().WriteSuccess(w, response, "Media downloaded successfully")
+}
+
+// @Summary Get message media
+// @Description Return the media of a received message as a file. Media that was not downloaded automatically is fetched from WhatsApp on first access.
+// @Tags Media
+// @Security ApiKeyAuth
+// @Produce octet-stream
+// @Param sessionId path string true "Session ID"
+// @Param messageId path string true "WhatsApp message ID"
+// @Success 200 {file} binary "Media file"
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse "Media quarantined by the virus scan"
+// @Failure 410 {object} shared.ErrorResponse "Media expired on WhatsApp servers"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/media/{messageId} [get]
+func (h *MediaHandler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get media")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	messageID := chi.URLParam(r, "messageId")
+
+	file, _, err := h.mediaService.GetMessageMedia(r.Context(), sessionID, messageID)
+	if err != nil {
+		h.HandleError(w, err, "get media")
+		return
+	}
+
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if file.FileName != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	}
+
+	h.GetWriter().WriteBinary(w, contentType, file.Data)
+}
+
+// @Summary Get hosted media
+// @Description Serve the media behind a link sent in a message webhook (media_url). Links are signed and expire, so no API key is needed.
+// @Tags Media
+// @Produce octet-stream
+// @Param token path string true "Link token"
+// @Success 200 {file} binary "Media file"
+// @Failure 404 {object} shared.ErrorResponse "Invalid or expired link"
+// @Router /media/public/{token} [get]
+func (h *MediaHandler) GetHostedMedia(w http.ResponseWriter, r *http.Request) {
+	file, err := h.mediaService.OpenHostedMedia(chi.URLParam(r, "token"))
+	if err != nil {
+		h.HandleError(w, err, "get hosted media")
+		return
+	}
+
+	contentType := file.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h.GetWriter().WriteBinary(w, contentType, file.Data)
 }
 
 // @Summary Get media information
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
@@ -40,6 +39,7 @@ func NewMessageHandler(
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendTextMessageRequest true "Text message request"
 // @Success 200 {object} shared.SuccessResponse
 // @Failure 400 {object} shared.SuccessResponse
@@ -54,6 +54,7 @@ func NewMessageHandler(
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendTextMessageRequest true "Text message request"
 // @Success 200 {object} shared.SuccessResponse
 // @Failure 400 {object} shared.SuccessResponse
@@ -70,24 +71,25 @@ func (h *MessageHandler) SendTextMessage(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req contracts.SendTextMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindText, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendTextMessage(r.Context(), sessionID, req.RemoteJID, req.Body)
+	messageID, participant := req.ReplyTarget()
+	ctx := services.WithLinkTracking(services.WithReplyTo(services.WithTextFormat(services.WithFooter(r.Context(), req.Footer), req.Formatting), messageID, participant), req.TrackLinks, req.Campaign)
+	response, err := h.messageService.SendTextMessage(ctx, sessionID, req.RemoteJID, req.Body)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindText, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send text message", map[string]interface{}{
 			"session_id": sessionID,
 			"remote_jid": req.RemoteJID,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send text message")
+		h.WriteServiceError(w, err, "Failed to send text message")
 		return
 	}
 
@@ -108,6 +110,7 @@ func (h *MessageHandler) SendTextMessage(w http.ResponseWriter, r *http.Request)
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendMediaMessageRequest true "Media message request"
 // @Success 200 {object} shared.SuccessResponse
 // @Failure 400 {object} shared.SuccessResponse
@@ -124,25 +127,25 @@ func (h *MessageHandler) SendMediaMessage(w http.ResponseWriter, r *http.Request
 	}
 
 	var req contracts.SendMediaMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindMedia, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendMediaMessage(r.Context(), sessionID, req.To, req.MediaURL, req.Caption, req.Type)
+	ctx := services.WithLinkTracking(services.WithReplyTo(services.WithTextFormat(services.WithFooter(r.Context(), req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign)
+	response, err := h.messageService.SendMediaMessage(ctx, sessionID, req.To, req.MediaURL, req.Caption, req.Type)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindMedia, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send media message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"media_type": req.Type,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send media message")
+		h.WriteServiceError(w, err, "Failed to send media message")
 		return
 	}
 
@@ -164,6 +167,7 @@ func (h *MessageHandler) SendMediaMessage(w http.ResponseWriter, r *http.Request
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendImageMessageRequest true "Image message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -180,24 +184,24 @@ func (h *MessageHandler) SendImage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendImageMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindImage, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendImageMessage(r.Context(), sessionID, req.To, req.File, req.Caption, req.Filename)
+	ctx := services.WithLinkTracking(services.WithReplyTo(services.WithTextFormat(services.WithFooter(r.Context(), req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign)
+	response, err := h.messageService.SendImageMessage(ctx, sessionID, req.To, req.File, req.Caption, req.Filename)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindImage, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send image message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send image message")
+		h.WriteServiceError(w, err, "Failed to send image message")
 		return
 	}
 
@@ -219,6 +223,7 @@ func (h *MessageHandler) SendImage(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendAudioMessageRequest true "Audio message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -235,24 +240,24 @@ func (h *MessageHandler) SendAudio(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendAudioMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindAudio, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendAudioMessage(r.Context(), sessionID, req.To, req.File, req.Caption)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendAudioMessage(ctx, sessionID, req.To, req.File, req.Caption)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindAudio, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send audio message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send audio message")
+		h.WriteServiceError(w, err, "Failed to send audio message")
 		return
 	}
 
@@ -274,6 +279,7 @@ func (h *MessageHandler) SendAudio(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendVideoMessageRequest true "Video message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -290,24 +296,24 @@ func (h *MessageHandler) SendVideo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendVideoMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindVideo, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendVideoMessage(r.Context(), sessionID, req.To, req.File, req.Caption, req.Filename)
+	ctx := services.WithLinkTracking(services.WithReplyTo(services.WithTextFormat(services.WithFooter(r.Context(), req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign)
+	response, err := h.messageService.SendVideoMessage(ctx, sessionID, req.To, req.File, req.Caption, req.Filename)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindVideo, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send video message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send video message")
+		h.WriteServiceError(w, err, "Failed to send video message")
 		return
 	}
 
@@ -329,6 +335,7 @@ func (h *MessageHandler) SendVideo(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendDocumentMessageRequest true "Document message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -345,24 +352,24 @@ func (h *MessageHandler) SendDocument(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendDocumentMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindDocument, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendDocumentMessage(r.Context(), sessionID, req.To, req.File, req.Caption, req.Filename)
+	ctx := services.WithLinkTracking(services.WithReplyTo(services.WithTextFormat(services.WithFooter(r.Context(), req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign)
+	response, err := h.messageService.SendDocumentMessage(ctx, sessionID, req.To, req.File, req.Caption, req.Filename)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindDocument, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send document message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send document message")
+		h.WriteServiceError(w, err, "Failed to send document message")
 		return
 	}
 
@@ -384,6 +391,7 @@ func (h *MessageHandler) SendDocument(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendStickerMessageRequest true "Sticker message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -400,24 +408,24 @@ func (h *MessageHandler) SendSticker(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendStickerMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindSticker, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendStickerMessage(r.Context(), sessionID, req.To, req.File)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendStickerMessage(ctx, sessionID, req.To, req.File)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindSticker, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send sticker message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send sticker message")
+		h.WriteServiceError(w, err, "Failed to send sticker message")
 		return
 	}
 
@@ -438,6 +446,7 @@ func (h *MessageHandler) SendSticker(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendLocationMessageRequest true "Location message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -454,24 +463,24 @@ func (h *MessageHandler) SendLocation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendLocationMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindLocation, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendLocationMessage(r.Context(), sessionID, req.To, req.Latitude, req.Longitude, req.Address)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendLocationMessage(ctx, sessionID, req.To, req.Latitude, req.Longitude, req.Address)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindLocation, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send location message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send location message")
+		h.WriteServiceError(w, err, "Failed to send location message")
 		return
 	}
 
@@ -487,6 +496,61 @@ func (h *MessageHandler) SendLocation(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, response, "Location message sent successfully")
 }
 
+// @Summary Send live location update
+// @Description Send a live location update via WhatsApp. The first update starts the share; send the next ones with an increasing sequence and the seconds since the share started in time_offset
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
+// @Param request body contracts.SendLiveLocationMessageRequest true "Live location message request"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
+// @Failure 400 {object} shared.SuccessResponse
+// @Failure 404 {object} shared.SuccessResponse
+// @Failure 500 {object} shared.SuccessResponse
+// @Router /sessions/{sessionId}/messages/send/live-location [post]
+func (h *MessageHandler) SendLiveLocation(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "send live location message")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	var req contracts.SendLiveLocationMessageRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindLiveLocation, &req) {
+		return
+	}
+
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendLiveLocationMessage(ctx, sessionID, &req)
+	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindLiveLocation, &req, err)
+		h.GetLogger().ErrorWithFields("Failed to send live location message", map[string]interface{}{
+			"session_id": sessionID,
+			"to":         req.To,
+			"error":      err.Error(),
+		})
+		h.WriteServiceError(w, err, "Failed to send live location message")
+		return
+	}
+
+	h.LogSuccess("send live location message", map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": response.MessageID,
+		"to":         req.To,
+		"sequence":   req.Sequence,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Live location message sent successfully")
+}
+
 // @Summary Send contact message
 // @Description Send a contact message via WhatsApp
 // @Tags Messages
@@ -494,6 +558,7 @@ func (h *MessageHandler) SendLocation(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendContactMessageRequest true "Contact message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -510,33 +575,33 @@ func (h *MessageHandler) SendContact(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendContactMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindContact, &req) {
 		return
 	}
 
-	response, err := h.messageService.SendContactMessage(r.Context(), sessionID, req.To, req.ContactName, req.ContactPhone)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendContactMessage(ctx, sessionID, &req)
 	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindContact, &req, err)
 		h.GetLogger().ErrorWithFields("Failed to send contact message", map[string]interface{}{
 			"session_id": sessionID,
 			"to":         req.To,
 			"error":      err.Error(),
 		})
-		h.GetWriter().WriteInternalError(w, "Failed to send contact message")
+		h.WriteServiceError(w, err, "Failed to send contact message")
 		return
 	}
 
 	h.LogSuccess("send contact message", map[string]interface{}{
-		"session_id":    sessionID,
-		"message_id":    response.MessageID,
-		"to":            req.To,
-		"contact_name":  req.ContactName,
-		"contact_phone": req.ContactPhone,
+		"session_id":   sessionID,
+		"message_id":   response.MessageID,
+		"to":           req.To,
+		"contact_name": req.Name,
+		"phone_count":  len(req.Phones),
 	})
 
 	h.GetWriter().WriteSuccess(w, response, "Contact message sent successfully")
@@ -565,13 +630,7 @@ func (h *MessageHandler) SendContactList(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req contracts.SendContactListMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -631,13 +690,7 @@ func (h *MessageHandler) SendBusinessProfile(w http.ResponseWriter, r *http.Requ
 	}
 
 	var req contracts.SendBusinessProfileMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -671,6 +724,7 @@ func (h *MessageHandler) SendBusinessProfile(w http.ResponseWriter, r *http.Requ
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendButtonMessageRequest true "Button message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -687,32 +741,30 @@ func (h *MessageHandler) SendButton(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendButtonMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindButton, &req) {
 		return
 	}
 
-	session, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendButtonMessage(ctx, sessionID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindButton, &req, err)
+		h.GetLogger().ErrorWithFields("Failed to send button message", map[string]interface{}{
+			"session_id": sessionID,
+			"to":         req.To,
+			"error":      err.Error(),
+		})
+		h.HandleError(w, err, "send button message")
 		return
 	}
 
-	response := &contracts.SendMessageResponse{
-		MessageID: uuid.New().String(),
-		To:        req.To,
-		Status:    "sent",
-		Timestamp: time.Now(),
-	}
-
 	h.LogSuccess("send button message", map[string]interface{}{
 		"session_id":   sessionID,
-		"session_name": session.Session.Name,
+		"message_id":   response.MessageID,
 		"to":           req.To,
 		"button_count": len(req.Buttons),
 	})
@@ -743,13 +795,7 @@ func (h *MessageHandler) SendList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendListMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -789,6 +835,7 @@ func (h *MessageHandler) SendList(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
 // @Param request body contracts.SendPollMessageRequest true "Poll message request"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
 // @Failure 400 {object} shared.SuccessResponse
@@ -805,34 +852,31 @@ func (h *MessageHandler) SendPoll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendPollMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindPoll, &req) {
 		return
 	}
 
-	session, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendPollMessage(ctx, sessionID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindPoll, &req, err)
+		h.GetLogger().ErrorWithFields("Failed to send poll message", map[string]interface{}{
+			"session_id": sessionID,
+			"to":         req.To,
+			"error":      err.Error(),
+		})
+		h.WriteServiceError(w, err, "Failed to send poll message")
 		return
 	}
 
-	response := &contracts.SendMessageResponse{
-		MessageID: uuid.New().String(),
-		To:        req.To,
-		Status:    "sent",
-		Timestamp: time.Now(),
-	}
-
 	h.LogSuccess("send poll message", map[string]interface{}{
 		"session_id":       sessionID,
-		"session_name":     session.Session.Name,
+		"message_id":       response.MessageID,
 		"to":               req.To,
-		"poll_name":        req.Name,
 		"option_count":     len(req.Options),
 		"selectable_count": req.SelectableCount,
 		"allow_multiple":   req.AllowMultipleVote,
@@ -841,6 +885,62 @@ func (h *MessageHandler) SendPoll(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, response, "Poll message sent successfully")
 }
 
+// @Summary Send payment request
+// @Description Ask the recipient for a payment through WhatsApp payments (Pix in Brazil, UPI in India). Accounts outside those regions get NOT_SUPPORTED
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param dryRun query bool false "Validate and return the would-be payload without sending it" default(false)
+// @Param request body contracts.SendPaymentRequestMessageRequest true "Payment request"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SendMessageResponse}
+// @Failure 400 {object} shared.SuccessResponse
+// @Failure 404 {object} shared.SuccessResponse
+// @Failure 422 {object} shared.SuccessResponse
+// @Failure 500 {object} shared.SuccessResponse
+// @Router /sessions/{sessionId}/messages/send/payment [post]
+func (h *MessageHandler) SendPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "send payment request")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	if sessionID == "" {
+		h.GetWriter().WriteBadRequest(w, "Session ID is required")
+		return
+	}
+
+	var req contracts.SendPaymentRequestMessageRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if h.holdSend(w, r, sessionID, req.QuietHours, services.SendKindPayment, &req) {
+		return
+	}
+
+	ctx := services.WithReplyTo(r.Context(), req.ReplyTo, "")
+	response, err := h.messageService.SendPaymentRequest(ctx, sessionID, &req)
+	if err != nil {
+		err = h.messageService.KeepFailedSend(r.Context(), sessionID, services.SendKindPayment, &req, err)
+		h.GetLogger().ErrorWithFields("Failed to send payment request", map[string]interface{}{
+			"session_id": sessionID,
+			"to":         req.To,
+			"error":      err.Error(),
+		})
+		h.WriteServiceError(w, err, "Failed to send payment request")
+		return
+	}
+
+	h.LogSuccess("send payment request", map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": response.MessageID,
+		"to":         req.To,
+		"currency":   req.Currency,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Payment request sent successfully")
+}
+
 // @Summary Send reaction message
 // @Description Send a reaction to a message via WhatsApp
 // @Tags Messages
@@ -864,13 +964,7 @@ func (h *MessageHandler) SendReaction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendReactionMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -921,13 +1015,7 @@ func (h *MessageHandler) SendPresence(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SendPresenceMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -977,13 +1065,7 @@ func (h *MessageHandler) EditMessage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.EditMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -1034,13 +1116,7 @@ func (h *MessageHandler) RevokeMessage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.RevokeMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
-		return
-	}
-
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -1067,6 +1143,350 @@ func (h *MessageHandler) RevokeMessage(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, response, "Message revoked successfully")
 }
 
+// @Summary Search messages
+// @Description Full-text search over stored messages of a session, with optional chat, type, direction and date filters. Snippets highlight matches with <mark> tags.
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param q query string true "Search terms (supports quoted phrases, OR and -exclusions)"
+// @Param chat_jid query string false "Restrict to a chat JID"
+// @Param type query string false "Message type (text, image, ...)"
+// @Param direction query string false "inbound or outbound"
+// @Param from query string false "Start of date range (RFC3339)"
+// @Param to query string false "End of date range (RFC3339)"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SearchMessagesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/search [get]
+func (h *MessageHandler) SearchMessages(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "search messages")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	query := r.URL.Query()
+
+	limit, offset, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	req := &contracts.SearchMessagesRequest{
+		Query:     query.Get("q"),
+		ChatJID:   query.Get("chat_jid"),
+		Type:      query.Get("type"),
+		Direction: query.Get("direction"),
+		Limit:     limit,
+		Offset:    offset,
+	}
+
+	if req.From, err = parseTimeParam(query.Get("from")); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid from parameter", err.Error())
+		return
+	}
+	if req.To, err = parseTimeParam(query.Get("to")); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid to parameter", err.Error())
+		return
+	}
+
+	response, err := h.messageService.SearchMessages(r.Context(), sessionID, req)
+	if err != nil {
+		h.HandleError(w, err, "search messages")
+		return
+	}
+
+	h.LogSuccess("search messages", map[string]interface{}{
+		"session_id": sessionID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Messages retrieved successfully")
+}
+
+// @Summary List messages
+// @Description List stored messages of a session, newest first. Pass the returned nextCursor as cursor to fetch the following page
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chat_jid query string false "Restrict to a chat JID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Param offset query int false "Deprecated: page offset, ignored when cursor is set" default(0)
+// @Param include_notes query bool false "Also return the chat notes written in the span the page covers; requires chat_jid" default(false)
+// @Success 200 {object} shared.SuccessResponse{data=services.ListMessagesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages [get]
+func (h *MessageHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list messages")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	limit, offset, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	includeNotes, err := h.GetQueryBool(r, "include_notes", false)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid include_notes parameter", err.Error())
+		return
+	}
+
+	req := &services.ListMessagesRequest{
+		SessionID:    sessionID,
+		ChatJID:      h.GetQueryString(r, "chat_jid"),
+		Limit:        limit,
+		Cursor:       h.GetQueryString(r, "cursor"),
+		Offset:       offset,
+		IncludeNotes: includeNotes,
+	}
+
+	response, err := h.messageService.ListMessages(r.Context(), req)
+	if err != nil {
+		h.HandleError(w, err, "list messages")
+		return
+	}
+
+	h.LogSuccess("list messages", map[string]interface{}{
+		"session_id": sessionID,
+		"returned":   len(response.Messages),
+		"has_more":   response.HasMore,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Messages retrieved successfully")
+}
+
+// @Summary Star message
+// @Description Star a message through the WhatsApp app state so it shows as starred on every linked device. Stored messages need only message_id; otherwise chat_jid (and sender_jid for other people's messages in groups) must be given
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.StarMessageRequest true "Message to star"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.StarMessageResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/star [post]
+func (h *MessageHandler) StarMessage(w http.ResponseWriter, r *http.Request) {
+	h.setStarred(w, r, true)
+}
+
+// @Summary Unstar message
+// @Description Remove the star from a message on every linked device
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.StarMessageRequest true "Message to unstar"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.StarMessageResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/unstar [post]
+func (h *MessageHandler) UnstarMessage(w http.ResponseWriter, r *http.Request) {
+	h.setStarred(w, r, false)
+}
+
+func (h *MessageHandler) setStarred(w http.ResponseWriter, r *http.Request, starred bool) {
+	operation := "star message"
+	if !starred {
+		operation = "unstar message"
+	}
+	h.LogRequest(r, operation)
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.StarMessageRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.messageService.SetStarred(r.Context(), sessionID, &req, starred)
+	if err != nil {
+		h.HandleError(w, err, operation)
+		return
+	}
+
+	h.LogSuccess(operation, map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": response.MessageID,
+		"chat_jid":   response.ChatJID,
+	})
+
+	message := "Message starred successfully"
+	if !starred {
+		message = "Message unstarred successfully"
+	}
+
+	h.GetWriter().WriteSuccess(w, response, message)
+}
+
+// @Summary Pin message
+// @Description Pin a message for everyone in its chat or group for 24h, 7d (default) or 30d, after which WhatsApp unpins it. Stored messages need only message_id; otherwise chat_jid (and sender_jid for other people's messages in groups) must be given. In groups where only admins may edit the group info, the session must be an admin
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.PinMessageRequest true "Message to pin"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.PinMessageResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/pin [post]
+func (h *MessageHandler) PinMessage(w http.ResponseWriter, r *http.Request) {
+	h.setPinned(w, r, true)
+}
+
+// @Summary Unpin message
+// @Description Unpin a message for everyone in its chat or group. The duration field is ignored
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.PinMessageRequest true "Message to unpin"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.PinMessageResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/unpin [post]
+func (h *MessageHandler) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	h.setPinned(w, r, false)
+}
+
+func (h *MessageHandler) setPinned(w http.ResponseWriter, r *http.Request, pinned bool) {
+	operation := "pin message"
+	if !pinned {
+		operation = "unpin message"
+	}
+	h.LogRequest(r, operation)
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.PinMessageRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.messageService.SetPinned(r.Context(), sessionID, &req, pinned)
+	if err != nil {
+		h.HandleError(w, err, operation)
+		return
+	}
+
+	h.LogSuccess(operation, map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": response.MessageID,
+		"chat_jid":   response.ChatJID,
+	})
+
+	message := "Message pinned successfully"
+	if !pinned {
+		message = "Message unpinned successfully"
+	}
+
+	h.GetWriter().WriteSuccess(w, response, message)
+}
+
+// @Summary List starred messages
+// @Description List a session's starred messages, most recently starred first, including stars set from the phone. Stored messages are embedded. Pass the returned nextCursor as cursor to fetch the following page
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListStarredMessagesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/starred [get]
+func (h *MessageHandler) ListStarredMessages(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list starred messages")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	limit, _, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	response, err := h.messageService.ListStarred(r.Context(), sessionID, limit, h.GetQueryString(r, "cursor"))
+	if err != nil {
+		h.HandleError(w, err, "list starred messages")
+		return
+	}
+
+	h.LogSuccess("list starred messages", map[string]interface{}{
+		"session_id": sessionID,
+		"returned":   len(response.Messages),
+		"has_more":   response.HasMore,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Starred messages retrieved successfully")
+}
+
+// @Summary Get message
+// @Description Get a stored message by its WhatsApp ID, including media metadata, the resolved quoted message when it is a reply, and the reactions it received
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param messageId path string true "WhatsApp message ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.MessageDetailResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/{messageId} [get]
+func (h *MessageHandler) GetMessage(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get message")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	messageID := chi.URLParam(r, "messageId")
+
+	response, err := h.messageService.GetMessageDetail(r.Context(), sessionID, messageID)
+	if err != nil {
+		h.HandleError(w, err, "get message")
+		return
+	}
+
+	h.LogSuccess("get message", map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": messageID,
+		"is_reply":   response.Message.QuotedMessageID != "",
+		"reactions":  len(response.Reactions),
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Message retrieved successfully")
+}
+
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
 // @Summary Get poll results
 // @Description Get results of a poll message via WhatsApp
 // @Tags Messages
@@ -1090,55 +1510,33 @@ func (h *MessageHandler) GetPollResults(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	session, err := h.sessionService.GetSession(r.Context(), sessionID)
+	response, err := h.messageService.GetPollResults(r.Context(), sessionID, messageID)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "get poll results")
 		return
 	}
 
-	voteResults := []contracts.PollVoteInfo{
-		{
-			OptionName: "Option 1",
-			Voters:     []string{"5511888888888@s.whatsapp.net", "5511777777777@s.whatsapp.net"},
-			VoteCount:  2,
-		},
-		{
-			OptionName: "Option 2",
-			Voters:     []string{"5511666666666@s.whatsapp.net"},
-			VoteCount:  1,
-		},
-	}
-
-	response := &contracts.GetPollResultsResponse{
-		MessageID:   messageID,
-		PollName:    "Sample Poll",
-		TotalVotes:  3,
-		VoteResults: voteResults,
-		CreatedAt:   time.Now().Add(-24 * time.Hour),
-	}
-
 	h.LogSuccess("get poll results", map[string]interface{}{
-		"session_id":   sessionID,
-		"session_name": session.Session.Name,
-		"message_id":   messageID,
-		"total_votes":  response.TotalVotes,
+		"session_id":  sessionID,
+		"message_id":  messageID,
+		"total_votes": response.TotalVotes,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, "Poll results retrieved successfully")
 }
 
 // @Summary Mark messages as read
-// @Description Mark messages as read in WhatsApp
+// @Description Send read receipts for messages of a chat and clear its unread count. In groups, messages that are not stored need sender_jid
 // @Tags Messages
 // @Security ApiKeyAuth
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
 // @Param request body contracts.MarkAsReadRequest true "Mark as read request"
-// @Success 200 {object} shared.SuccessResponse
-// @Failure 400 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Success 200 {object} shared.SuccessResponse{data=contracts.MarkAsReadResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/messages/mark-read [post]
 func (h *MessageHandler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "mark messages as read")
@@ -1150,39 +1548,98 @@ func (h *MessageHandler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.MarkAsReadRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request body")
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.GetValidator().ValidateStruct(&req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Validation failed", err.Error())
+	response, err := h.messageService.MarkAsRead(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "mark messages as read")
 		return
 	}
 
-	_, err := h.sessionService.GetSession(r.Context(), sessionID)
+	h.LogSuccess("mark messages as read", map[string]interface{}{
+		"session_id": sessionID,
+		"chat_jid":   req.ChatJID,
+		"count":      response.MarkedCount,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Messages marked as read")
+}
+
+// @Summary List chats
+// @Description List the session's chats with their unread count and latest message, for inbox views. Unread counts grow with messages from contacts and are cleared by mark-read or by reading the chat on a linked device
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param sort query string false "recent (latest message first) or unread (most unread first)" default(recent)
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListChatsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chats [get]
+func (h *MessageHandler) ListChats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list chats")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	limit, offset, err := h.GetPaginationParams(r)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
 		return
 	}
 
-	stats, err := h.messageService.GetMessageStats(r.Context(), &sessionID)
+	response, err := h.messageService.ListChats(r.Context(), sessionID, h.GetQueryString(r, "sort"), limit, offset)
 	if err != nil {
-		h.GetLogger().ErrorWithFields("Failed to get message stats", map[string]interface{}{
-			"session_id": sessionID,
-			"error":      err.Error(),
-		})
-		h.GetWriter().WriteInternalError(w, "Failed to get message stats")
+		h.HandleError(w, err, "list chats")
+		return
+	}
+
+	h.LogSuccess("list chats", map[string]interface{}{
+		"session_id": sessionID,
+		"sort":       response.Sort,
+		"returned":   len(response.Chats),
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Chats retrieved successfully")
+}
+
+// @Summary List live locations
+// @Description List everyone sharing a live location in a chat, the session included, with their latest position and the positions reported before it, newest first
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chatJid path string true "Chat JID or phone number"
+// @Param history query int false "Earlier positions to return per sharer (max 500)" default(20)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListLiveLocationsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chats/{chatJid}/live-locations [get]
+func (h *MessageHandler) ListLiveLocations(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list live locations")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	chatJID := chi.URLParam(r, "chatJid")
+	history := parseIntQuery(r, "history", 20)
+
+	response, err := h.messageService.ListLiveLocations(r.Context(), sessionID, chatJID, history)
+	if err != nil {
+		h.HandleError(w, err, "list live locations")
 		return
 	}
 
-	h.LogSuccess("get message stats", map[string]interface{}{
-		"session_id":      sessionID,
-		"total_messages":  stats.TotalMessages,
-		"synced_messages": stats.SyncedMessages,
+	h.LogSuccess("list live locations", map[string]interface{}{
+		"session_id": sessionID,
+		"chat_jid":   response.ChatJID,
+		"sharers":    len(response.Sharers),
 	})
 
-	h.GetWriter().WriteSuccess(w, stats, "Message statistics retrieved successfully")
+	h.GetWriter().WriteSuccess(w, response, "Live locations retrieved successfully")
 }
 
 // @Summary Get pending sync messages
@@ -1274,6 +1731,189 @@ func (h *MessageHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, nil, "Message deleted successfully")
 }
 
+// SendQueue is the middleware of the send routes: it takes a slot in the
+// session's send queue for the whole request, or answers 429 SEND_QUEUE_FULL
+// with a Retry-After estimate when the queue is at its depth.
+func (h *MessageHandler) SendQueue(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := chi.URLParam(r, "sessionName")
+		if sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		release, err := h.messageService.EnterSendQueue(r.Context(), sessionID)
+		if err != nil {
+			h.HandleError(w, err, "queue message send")
+			return
+		}
+		defer release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// DryRun reads the dryRun query parameter of a send. A dry run is
+// validated and normalized like a send and answered with the would-be
+// payload, but never reaches WhatsApp.
+func (h *MessageHandler) DryRun(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dryRun, err := h.GetQueryBool(r, "dryRun", false)
+		if err != nil {
+			h.GetWriter().WriteBadRequest(w, "Invalid dryRun parameter", err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(services.WithDryRun(r.Context(), dryRun)))
+	})
+}
+
+// holdSend answers the request itself when the send cannot go out now. In
+// quiet hours it is rejected with 409 QUIET_HOURS or deferred with 202; past
+// the warm-up daily limit it is rejected with 429 WARMUP_LIMIT or deferred
+// to the next day. It returns false when the send should go ahead.
+func (h *MessageHandler) holdSend(w http.ResponseWriter, r *http.Request, sessionID, policy, kind string, req interface{}) bool {
+	scheduled, err := h.messageService.HoldForQuietHours(r.Context(), sessionID, policy, kind, req)
+	message := "Session is in quiet hours, message scheduled"
+	if err == nil && scheduled == nil {
+		scheduled, err = h.messageService.HoldForWarmUp(r.Context(), sessionID, kind, req)
+		message = "Warm-up daily limit reached, message scheduled"
+	}
+	if err != nil {
+		h.HandleError(w, err, "send "+kind+" message")
+		return true
+	}
+	if scheduled == nil {
+		return false
+	}
+
+	h.LogSuccess("defer "+kind+" message", map[string]interface{}{
+		"session_id":   sessionID,
+		"scheduled_id": scheduled.ID,
+		"send_at":      scheduled.SendAt,
+		"reason":       scheduled.Reason,
+	})
+
+	h.GetWriter().WriteAccepted(w, scheduled, message)
+	return true
+}
+
+// @Summary List scheduled messages
+// @Description List sends held back for later, such as those deferred by quiet hours or the warm-up daily limit
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param status query string false "Filter by status (pending, sending, sent, failed, cancelled)"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListScheduledMessagesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/scheduled [get]
+func (h *MessageHandler) ListScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list scheduled messages")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	status := h.GetQueryString(r, "status")
+	switch status {
+	case "", "pending", "sending", "sent", "failed", "cancelled":
+	default:
+		h.GetWriter().WriteBadRequest(w, "Invalid status filter")
+		return
+	}
+
+	response, err := h.messageService.ListScheduled(r.Context(), sessionID, status)
+	if err != nil {
+		h.HandleError(w, err, "list scheduled messages")
+		return
+	}
+
+	h.LogSuccess("list scheduled messages", map[string]interface{}{
+		"session_id": sessionID,
+		"count":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Scheduled messages retrieved successfully")
+}
+
+// @Summary Cancel scheduled message
+// @Description Cancel a send that is still pending
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param scheduledId path string true "Scheduled message ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Already sent, failed or cancelled"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/scheduled/{scheduledId} [delete]
+func (h *MessageHandler) CancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "cancel scheduled message")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	scheduledID := chi.URLParam(r, "scheduledId")
+
+	if err := h.messageService.CancelScheduled(r.Context(), sessionID, scheduledID); err != nil {
+		h.HandleError(w, err, "cancel scheduled message")
+		return
+	}
+
+	h.LogSuccess("cancel scheduled message", map[string]interface{}{
+		"session_id":   sessionID,
+		"scheduled_id": scheduledID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Scheduled message cancelled successfully")
+}
+
+// @Summary Retry failed send
+// @Description Send again a message that failed, such as a media send whose upload to WhatsApp failed or a scheduled send that failed at its time. The stored request is replayed as submitted
+// @Tags Messages
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param messageId path string true "Failed message ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ScheduledMessageResponse}
+// @Success 202 {object} shared.SuccessResponse{data=contracts.ScheduledMessageResponse} "Deferred by the warm-up or tenant daily limit"
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Not failed"
+// @Failure 502 {object} shared.ErrorResponse "Failed again"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/messages/{messageId}/retry [post]
+func (h *MessageHandler) RetryFailedMessage(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "retry failed message")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	failedID := chi.URLParam(r, "messageId")
+
+	response, err := h.messageService.RetryFailedSend(r.Context(), sessionID, failedID)
+	if err != nil {
+		h.GetLogger().ErrorWithFields("Failed to retry message", map[string]interface{}{
+			"session_id": sessionID,
+			"failed_id":  failedID,
+			"error":      err.Error(),
+		})
+		h.WriteServiceError(w, err, "Failed to retry message")
+		return
+	}
+
+	h.LogSuccess("retry failed message", map[string]interface{}{
+		"session_id": sessionID,
+		"failed_id":  failedID,
+		"status":     response.Status,
+		"message_id": response.MessageID,
+	})
+
+	if response.Status == "pending" {
+		h.GetWriter().WriteAccepted(w, response, "Daily limit reached, message scheduled")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Message sent successfully")
+}
+
 func parseIntQuery(r *http.Request, key string, defaultValue int) int {
 	value := r.URL.Query().Get(key)
 	if value == "" {
@@ -1 +1,305 @@
 package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type NewsletterHandler struct {
+	*shared.BaseHandler
+	newsletterService *services.NewsletterService
+}
+
+func NewNewsletterHandler(
+	newsletterService *services.NewsletterService,
+	logger *logger.Logger,
+) *NewsletterHandler {
+	return &NewsletterHandler{
+		BaseHandler:       shared.NewBaseHandler(logger),
+		newsletterService: newsletterService,
+	}
+}
+
+// @Summary Forward message to newsletter
+// @Description Republish a stored message (text, image, video, audio, document or sticker) in a newsletter the session owns or administers. Media is re-uploaded for the newsletter, downloading it from WhatsApp first when there is no local copy. Image and video captions are kept unless caption is given
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.ForwardToNewsletterRequest true "Message and newsletter"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ForwardToNewsletterResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 410 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/forward [post]
+func (h *NewsletterHandler) ForwardMessage(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "forward message to newsletter")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.ForwardToNewsletterRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.newsletterService.ForwardMessage(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "forward message to newsletter")
+		return
+	}
+
+	h.LogSuccess("forward message to newsletter", map[string]interface{}{
+		"session_id":     sessionID,
+		"message_id":     req.MessageID,
+		"newsletter_jid": req.NewsletterJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Message forwarded to newsletter")
+}
+
+// @Summary Post to newsletter
+// @Description Publish a text, image, video, audio, document or sticker post in a newsletter the session owns or administers. file takes a URL or base64 data. With send_at in the future the post is scheduled and answered with 202; it is listed and cancelled with the scheduled messages
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Param request body contracts.PostToNewsletterRequest true "Post"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterPostResponse}
+// @Success 202 {object} shared.SuccessResponse{data=contracts.ScheduledMessageResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 413 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/messages [post]
+func (h *NewsletterHandler) Post(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "post to newsletter")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	var req contracts.PostToNewsletterRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, scheduled, err := h.newsletterService.Post(r.Context(), sessionID, newsletterJID, &req)
+	if err != nil {
+		h.HandleError(w, err, "post to newsletter")
+		return
+	}
+
+	if scheduled != nil {
+		h.LogSuccess("schedule newsletter post", map[string]interface{}{
+			"session_id":     sessionID,
+			"newsletter_jid": newsletterJID,
+			"scheduled_id":   scheduled.ID,
+			"send_at":        scheduled.SendAt,
+		})
+		h.GetWriter().WriteAccepted(w, scheduled, "Newsletter post scheduled")
+		return
+	}
+
+	h.LogSuccess("post to newsletter", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+		"message_id":     response.MessageID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Posted to newsletter")
+}
+
+// @Summary Invite newsletter admin
+// @Description Invite a user, by phone number or JID, to administer a newsletter the session owns. The invite arrives in the user's chat with the session and makes them an admin once accepted
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Param request body contracts.NewsletterAdminRequest true "User to invite"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterAdminResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/admins/invites [post]
+func (h *NewsletterHandler) InviteAdmin(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "invite newsletter admin")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	var req contracts.NewsletterAdminRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.newsletterService.InviteAdmin(r.Context(), sessionID, newsletterJID, &req)
+	if err != nil {
+		h.HandleError(w, err, "invite newsletter admin")
+		return
+	}
+
+	h.LogSuccess("invite newsletter admin", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+		"user":           response.User,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Newsletter admin invited")
+}
+
+// @Summary Revoke newsletter admin invite
+// @Description Withdraw an admin invite the user has not accepted yet
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Param user path string true "Invited user, as phone number or JID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterAdminResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/admins/invites/{user} [delete]
+func (h *NewsletterHandler) RevokeAdminInvite(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "revoke newsletter admin invite")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	response, err := h.newsletterService.RevokeAdminInvite(r.Context(), sessionID, newsletterJID, chi.URLParam(r, "user"))
+	if err != nil {
+		h.HandleError(w, err, "revoke newsletter admin invite")
+		return
+	}
+
+	h.LogSuccess("revoke newsletter admin invite", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+		"user":           response.User,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Newsletter admin invite revoked")
+}
+
+// @Summary Accept newsletter admin invite
+// @Description Accept an invite for the session to administer a newsletter
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterAdminResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/admins/invites/accept [post]
+func (h *NewsletterHandler) AcceptAdminInvite(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "accept newsletter admin invite")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	response, err := h.newsletterService.AcceptAdminInvite(r.Context(), sessionID, newsletterJID)
+	if err != nil {
+		h.HandleError(w, err, "accept newsletter admin invite")
+		return
+	}
+
+	h.LogSuccess("accept newsletter admin invite", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Newsletter admin invite accepted")
+}
+
+// @Summary Demote newsletter admin
+// @Description Remove an admin from a newsletter the session owns. The user keeps following it
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Param user path string true "Admin, as phone number or JID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterAdminResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/admins/{user} [delete]
+func (h *NewsletterHandler) DemoteAdmin(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "demote newsletter admin")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	response, err := h.newsletterService.DemoteAdmin(r.Context(), sessionID, newsletterJID, chi.URLParam(r, "user"))
+	if err != nil {
+		h.HandleError(w, err, "demote newsletter admin")
+		return
+	}
+
+	h.LogSuccess("demote newsletter admin", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+		"user":           response.User,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Newsletter admin demoted")
+}
+
+// @Summary Transfer newsletter ownership
+// @Description Hand a newsletter the session owns to one of its admins, by phone number or JID. The session stays on as an admin
+// @Tags Newsletters
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param newsletterJid path string true "Newsletter JID"
+// @Param request body contracts.NewsletterAdminRequest true "New owner"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NewsletterAdminResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/newsletters/{newsletterJid}/owner [put]
+func (h *NewsletterHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "transfer newsletter ownership")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	newsletterJID := chi.URLParam(r, "newsletterJid")
+
+	var req contracts.NewsletterAdminRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.newsletterService.TransferOwnership(r.Context(), sessionID, newsletterJID, &req)
+	if err != nil {
+		h.HandleError(w, err, "transfer newsletter ownership")
+		return
+	}
+
+	h.LogSuccess("transfer newsletter ownership", map[string]interface{}{
+		"session_id":     sessionID,
+		"newsletter_jid": newsletterJID,
+		"user":           response.User,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Newsletter ownership transferred")
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type NoteHandler struct {
+	*shared.BaseHandler
+	noteService *services.NoteService
+}
+
+func NewNoteHandler(
+	noteService *services.NoteService,
+	logger *logger.Logger,
+) *NoteHandler {
+	return &NoteHandler{
+		BaseHandler: shared.NewBaseHandler(logger),
+		noteService: noteService,
+	}
+}
+
+// @Summary List chat notes
+// @Description List the private notes and drafts left on a chat, newest first. Notes are never sent to WhatsApp
+// @Tags Notes
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param chat_jid query string true "Chat JID"
+// @Param kind query string false "Only notes or only drafts" Enums(note, draft)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListNotesResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/notes [get]
+func (h *NoteHandler) ListNotes(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list notes")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.noteService.ListNotes(r.Context(), sessionID, h.GetQueryString(r, "chat_jid"), h.GetQueryString(r, "kind"))
+	if err != nil {
+		h.HandleError(w, err, "list notes")
+		return
+	}
+
+	h.LogSuccess("list notes", map[string]interface{}{
+		"session_id": sessionID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Notes retrieved successfully")
+}
+
+// @Summary Create chat note
+// @Description Leave a private note, or a draft reply, on a chat. kind defaults to note
+// @Tags Notes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.CreateNoteRequest true "Note"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.NoteResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/notes [post]
+func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "create note")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.CreateNoteRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.noteService.CreateNote(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "create note")
+		return
+	}
+
+	h.LogSuccess("create note", map[string]interface{}{
+		"session_id": sessionID,
+		"note_id":    response.ID,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Note created successfully")
+}
+
+// @Summary Update chat note
+// @Description Change the body of a note, or turn a draft into a note and back. Omitted fields keep their current value
+// @Tags Notes
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param noteId path string true "Note ID"
+// @Param request body contracts.UpdateNoteRequest true "Note changes"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.NoteResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/notes/{noteId} [put]
+func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "update note")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	noteID := chi.URLParam(r, "noteId")
+
+	var req contracts.UpdateNoteRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.noteService.UpdateNote(r.Context(), sessionID, noteID, &req)
+	if err != nil {
+		h.HandleError(w, err, "update note")
+		return
+	}
+
+	h.LogSuccess("update note", map[string]interface{}{
+		"session_id": sessionID,
+		"note_id":    noteID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Note updated successfully")
+}
+
+// @Summary Delete chat note
+// @Description Delete a note or draft
+// @Tags Notes
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param noteId path string true "Note ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/notes/{noteId} [delete]
+func (h *NoteHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete note")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	noteID := chi.URLParam(r, "noteId")
+
+	if err := h.noteService.DeleteNote(r.Context(), sessionID, noteID); err != nil {
+		h.HandleError(w, err, "delete note")
+		return
+	}
+
+	h.LogSuccess("delete note", map[string]interface{}{
+		"session_id": sessionID,
+		"note_id":    noteID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Note deleted successfully")
+}
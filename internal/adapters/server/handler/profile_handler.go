@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type ProfileHandler struct {
+	*shared.BaseHandler
+	profileService *services.ProfileService
+}
+
+func NewProfileHandler(
+	profileService *services.ProfileService,
+	logger *logger.Logger,
+) *ProfileHandler {
+	return &ProfileHandler{
+		BaseHandler:    shared.NewBaseHandler(logger),
+		profileService: profileService,
+	}
+}
+
+// @Summary Get own business profile
+// @Description Get the WhatsApp Business profile of the session's own account: description, address, email, websites, categories and business hours
+// @Tags Profile
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.OwnBusinessProfileResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/profile/business [get]
+func (h *ProfileHandler) GetBusinessProfile(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.profileService.GetBusinessProfile(r.Context(), sessionID)
+	if err != nil {
+		h.HandleError(w, err, "get business profile")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Business profile retrieved successfully")
+}
+
+// @Summary Update own business profile
+// @Description Edit the WhatsApp Business profile of the session's own account. Only the fields sent change; an empty string clears a text field, and websites and categories replace the current lists
+// @Tags Profile
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param request body contracts.UpdateBusinessProfileRequest true "Fields to change"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.OwnBusinessProfileResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/profile/business [put]
+func (h *ProfileHandler) UpdateBusinessProfile(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "update business profile")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.UpdateBusinessProfileRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.profileService.UpdateBusinessProfile(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "update business profile")
+		return
+	}
+
+	h.LogSuccess("update business profile", map[string]interface{}{
+		"session_id": sessionID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Business profile updated successfully")
+}
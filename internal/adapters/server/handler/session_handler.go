@@ -3,12 +3,14 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"zpwoot/internal/adapters/server/contracts"
 	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/core/session"
 	"zpwoot/internal/services"
 	"zpwoot/platform/logger"
 )
@@ -57,8 +59,7 @@ func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "create session")
 
 	var req contracts.CreateSessionRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -77,6 +78,39 @@ func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteCreated(w, response, "Session created successfully")
 }
 
+// @Summary Create sessions in bulk
+// @Description Create up to 100 sessions, each with an optional proxy and webhook, and optionally start connecting them. Sessions are created in order and a failure only affects its own entry; check each result's status.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body contracts.BulkCreateSessionsRequest true "Session definitions"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.BulkCreateSessionsResponse} "Per-session results"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/bulk [post]
+func (h *SessionHandler) BulkCreateSessions(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "bulk create sessions")
+
+	var req contracts.BulkCreateSessionsRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.sessionService.BulkCreateSessions(r.Context(), &req)
+	if err != nil {
+		h.HandleError(w, err, "bulk create sessions")
+		return
+	}
+
+	h.LogSuccess("bulk create sessions", map[string]interface{}{
+		"created": response.Created,
+		"failed":  response.Failed,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Bulk session creation finished")
+}
+
 // @Summary List sessions
 // @Description Get a list of all WhatsApp sessions with optional filtering
 // @Tags Sessions
@@ -86,7 +120,8 @@ func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
 // @Param isConnected query bool false "Filter by connection status"
 // @Param deviceJid query string false "Filter by device JID"
 // @Param limit query int false "Number of sessions to return (default: 20)"
-// @Param offset query int false "Number of sessions to skip (default: 0)"
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Param offset query int false "Deprecated: number of sessions to skip, ignored when cursor is set"
 // @Success 200 {object} shared.SuccessResponse{data=contracts.ListSessionsResponse} "Sessions retrieved successfully"
 // @Failure 400 {object} shared.ErrorResponse "Bad Request"
 // @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
@@ -110,6 +145,7 @@ func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
 
 	req := &contracts.ListSessionsRequest{
 		Limit:  limit,
+		Cursor: h.GetQueryString(r, "cursor"),
 		Offset: offset,
 	}
 
@@ -130,6 +166,7 @@ func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
 		"total_sessions": response.Total,
 		"limit":          response.Limit,
 		"offset":         response.Offset,
+		"has_more":       response.HasMore,
 	})
 
 	h.GetWriter().WriteSuccess(w, response, "Sessions retrieved successfully")
@@ -299,6 +336,199 @@ func (h *SessionHandler) GetQRCode(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, response, "QR code retrieved successfully")
 }
 
+// @Summary Get QR code as PNG
+// @Description Render the current pairing QR code as a PNG image that can be used directly in an <img> tag
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce png
+// @Param sessionId path string true "Session ID"
+// @Param size query int false "Image size in pixels (128-1024)" default(256)
+// @Param margin query int false "Quiet zone in modules (0-16)" default(4)
+// @Param logo query bool false "Embed the configured logo in the centre" default(false)
+// @Param fg query string false "Foreground hex color" default(000000)
+// @Param bg query string false "Background hex color" default(ffffff)
+// @Success 200 {file} binary "QR code image"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "QR code not available"
+// @Failure 410 {object} shared.ErrorResponse "QR code expired"
+// @Router /sessions/{sessionId}/qr.png [get]
+func (h *SessionHandler) GetQRCodePNG(w http.ResponseWriter, r *http.Request) {
+	h.renderQRCode(w, r, "png", "image/png")
+}
+
+// @Summary Get QR code as SVG
+// @Description Render the current pairing QR code as an SVG image that can be used directly in an <img> tag
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce image/svg+xml
+// @Param sessionId path string true "Session ID"
+// @Param size query int false "Image size in pixels (128-1024)" default(256)
+// @Param margin query int false "Quiet zone in modules (0-16)" default(4)
+// @Param logo query bool false "Embed the configured logo in the centre" default(false)
+// @Param fg query string false "Foreground hex color" default(000000)
+// @Param bg query string false "Background hex color" default(ffffff)
+// @Success 200 {file} binary "QR code image"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "QR code not available"
+// @Failure 410 {object} shared.ErrorResponse "QR code expired"
+// @Router /sessions/{sessionId}/qr.svg [get]
+func (h *SessionHandler) GetQRCodeSVG(w http.ResponseWriter, r *http.Request) {
+	h.renderQRCode(w, r, "svg", "image/svg+xml")
+}
+
+func (h *SessionHandler) renderQRCode(w http.ResponseWriter, r *http.Request, format, contentType string) {
+	operation := "get QR code " + format
+	h.LogRequest(r, operation)
+
+	sessionID, _, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	req, ok := h.qrImageRequest(w, r)
+	if !ok {
+		return
+	}
+
+	image, err := h.sessionService.RenderQRCode(r.Context(), sessionID.String(), format, req)
+	if err != nil {
+		h.HandleError(w, err, operation)
+		return
+	}
+
+	h.GetWriter().WriteBinary(w, contentType, image)
+}
+
+// qrImageRequest reads the image options of the QR code routes, answering
+// 400 itself when one is malformed.
+func (h *SessionHandler) qrImageRequest(w http.ResponseWriter, r *http.Request) (*contracts.QRImageRequest, bool) {
+	req := &contracts.QRImageRequest{
+		Foreground: r.URL.Query().Get("fg"),
+		Background: r.URL.Query().Get("bg"),
+	}
+
+	var err error
+	if req.Size, err = h.GetQueryInt(r, "size", 0); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid size parameter", err.Error())
+		return nil, false
+	}
+	if req.Margin, err = h.GetQueryInt(r, "margin", session.DefaultQRMargin); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid margin parameter", err.Error())
+		return nil, false
+	}
+	if logo := r.URL.Query().Get("logo"); logo != "" {
+		if req.Logo, err = strconv.ParseBool(logo); err != nil {
+			h.GetWriter().WriteBadRequest(w, "Invalid logo parameter", err.Error())
+			return nil, false
+		}
+	}
+
+	return req, true
+}
+
+// @Summary Get click-to-chat link
+// @Description Build the wa.me link that opens a chat with the session's number, prefilled with text. With phone the link points at that number instead, which must be on WhatsApp
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID or name"
+// @Param phone query string false "Phone number, with or without formatting (default: the session's number)"
+// @Param text query string false "Message typed in the chat when the link is opened" maxlength(1000)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ChatLinkResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 422 {object} shared.ErrorResponse "Number is not on WhatsApp"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/chat-link [get]
+func (h *SessionHandler) GetChatLink(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get chat link")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.sessionService.GetChatLink(r.Context(), sessionID, chatLinkRequest(r))
+	if err != nil {
+		h.HandleError(w, err, "get chat link")
+		return
+	}
+
+	h.LogSuccess("get chat link", map[string]interface{}{
+		"session_id": sessionID,
+		"phone":      response.Phone,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Chat link generated successfully")
+}
+
+// @Summary Get click-to-chat link as PNG QR code
+// @Description Render the click-to-chat link as a QR code PNG, for websites and print. Takes the link parameters of /chat-link and the image parameters of /qr.png
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce image/png
+// @Param sessionId path string true "Session ID or name"
+// @Param phone query string false "Phone number (default: the session's number)"
+// @Param text query string false "Message typed in the chat when the link is opened" maxlength(1000)
+// @Param size query int false "Image size in pixels (128-1024)" default(256)
+// @Param margin query int false "Quiet zone in modules (0-16)" default(4)
+// @Param logo query bool false "Embed the configured logo in the centre" default(false)
+// @Param fg query string false "Foreground hex color" default(000000)
+// @Param bg query string false "Background hex color" default(ffffff)
+// @Success 200 {file} binary "QR code image"
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 422 {object} shared.ErrorResponse "Number is not on WhatsApp"
+// @Router /sessions/{sessionId}/chat-link/qr.png [get]
+func (h *SessionHandler) GetChatLinkPNG(w http.ResponseWriter, r *http.Request) {
+	h.renderChatLinkQR(w, r, "png", "image/png")
+}
+
+// @Summary Get click-to-chat link as SVG QR code
+// @Description Render the click-to-chat link as a QR code SVG, for websites and print. Takes the link parameters of /chat-link and the image parameters of /qr.svg
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce image/svg+xml
+// @Param sessionId path string true "Session ID or name"
+// @Param phone query string false "Phone number (default: the session's number)"
+// @Param text query string false "Message typed in the chat when the link is opened" maxlength(1000)
+// @Param size query int false "Image size in pixels (128-1024)" default(256)
+// @Param margin query int false "Quiet zone in modules (0-16)" default(4)
+// @Param logo query bool false "Embed the configured logo in the centre" default(false)
+// @Param fg query string false "Foreground hex color" default(000000)
+// @Param bg query string false "Background hex color" default(ffffff)
+// @Success 200 {file} binary "QR code image"
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 422 {object} shared.ErrorResponse "Number is not on WhatsApp"
+// @Router /sessions/{sessionId}/chat-link/qr.svg [get]
+func (h *SessionHandler) GetChatLinkSVG(w http.ResponseWriter, r *http.Request) {
+	h.renderChatLinkQR(w, r, "svg", "image/svg+xml")
+}
+
+func (h *SessionHandler) renderChatLinkQR(w http.ResponseWriter, r *http.Request, format, contentType string) {
+	operation := "get chat link QR code " + format
+	h.LogRequest(r, operation)
+
+	image, ok := h.qrImageRequest(w, r)
+	if !ok {
+		return
+	}
+
+	qr, err := h.sessionService.RenderChatLinkQR(r.Context(), chi.URLParam(r, "sessionName"), format, chatLinkRequest(r), image)
+	if err != nil {
+		h.HandleError(w, err, operation)
+		return
+	}
+
+	h.GetWriter().WriteBinary(w, contentType, qr)
+}
+
+func chatLinkRequest(r *http.Request) *contracts.ChatLinkRequest {
+	return &contracts.ChatLinkRequest{
+		Phone: r.URL.Query().Get("phone"),
+		Text:  r.URL.Query().Get("text"),
+	}
+}
+
 // @Summary Generate QR code
 // @Description Generate a new QR code for WhatsApp session pairing
 // @Tags Sessions
@@ -355,8 +585,7 @@ func (h *SessionHandler) SetProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req contracts.SetProxyRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -407,113 +636,1238 @@ func (h *SessionHandler) GetProxy(w http.ResponseWriter, r *http.Request) {
 	h.GetWriter().WriteSuccess(w, response, "Proxy configuration retrieved successfully")
 }
 
-// @Summary Get session statistics
-// @Description Get statistics about all sessions
+// @Summary Get session settings
+// @Description Get per-session behaviour settings
 // @Tags Sessions
 // @Security ApiKeyAuth
 // @Produce json
-// @Success 200 {object} shared.SuccessResponse{data=contracts.SessionStatsResponse} "Session statistics retrieved successfully"
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SessionSettings} "Settings retrieved successfully"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
 // @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
-// @Router /sessions/stats [get]
-func (h *SessionHandler) GetSessionStats(w http.ResponseWriter, r *http.Request) {
-	h.LogRequest(r, "get session stats")
+// @Router /sessions/{sessionId}/settings [get]
+func (h *SessionHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get settings")
 
-	response, err := h.sessionService.GetSessionStats(r.Context())
+	sessionID, _, err := h.resolveSessionIdentifier(r)
 	if err != nil {
-		h.HandleError(w, err, "get session stats")
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
 		return
 	}
 
-	h.LogSuccess("get session stats", map[string]interface{}{
-		"total":     response.Total,
-		"connected": response.Connected,
-		"offline":   response.Offline,
+	response, err := h.sessionService.GetSettings(r.Context(), sessionID.String())
+	if err != nil {
+		h.HandleError(w, err, "get settings")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Settings retrieved successfully")
+}
+
+// @Summary Set call settings
+// @Description Configure how incoming calls are handled, optionally rejecting them automatically with a text reply
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.CallSettings true "Call settings"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.CallSettings} "Call settings updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/calls [put]
+func (h *SessionHandler) SetCallSettings(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set call settings")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.CallSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetCallSettings(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set call settings")
+		return
+	}
+
+	h.LogSuccess("set call settings", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"auto_reject":        req.AutoReject,
 	})
 
-	h.GetWriter().WriteSuccess(w, response, "Session statistics retrieved successfully")
+	h.GetWriter().WriteSuccess(w, req, "Call settings updated successfully")
 }
 
-// @Summary Logout session
-// @Description Logout from WhatsApp session and disconnect
+// @Summary Set media settings
+// @Description Configure automatic download of inbound media: never, images only, or all media up to maxSizeMB. Media that is not downloaded automatically can still be fetched on demand through the media endpoint. With a virus scanner configured (MEDIA_SCAN_URL), scan decides what happens to flagged media: block quarantines it (never stored or linked), tag stores it and marks the message, ignore skips the scan; empty uses MEDIA_SCAN_POLICY.
 // @Tags Sessions
 // @Security ApiKeyAuth
+// @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse "Session logout successful"
+// @Param request body contracts.MediaSettings true "Media settings"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.MediaSettings} "Media settings updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
 // @Failure 404 {object} shared.ErrorResponse "Session not found"
 // @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
-// @Router /sessions/{sessionId}/logout [post]
-func (h *SessionHandler) LogoutSession(w http.ResponseWriter, r *http.Request) {
-	h.LogRequest(r, "logout session")
+// @Router /sessions/{sessionId}/settings/media [put]
+func (h *SessionHandler) SetMediaSettings(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set media settings")
 
-	sessionID, err := h.GetSessionIDFromURL(r)
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
 	if err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid session ID", err.Error())
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
 		return
 	}
 
-	err = h.sessionService.DisconnectSession(r.Context(), sessionID.String())
+	var req contracts.MediaSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetMediaSettings(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set media settings")
+		return
+	}
+
+	h.LogSuccess("set media settings", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"auto_download":      req.AutoDownload,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Media settings updated successfully")
+}
+
+// @Summary Set quiet hours
+// @Description Configure a daily window (HH:MM, in the given IANA timezone) during which messages are not sent. A window whose end is before its start wraps past midnight. Sends submitted inside the window are rejected with QUIET_HOURS or scheduled for the end of the window, according to the request's quiet_hours field or, when absent, this policy.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.QuietHoursSettings true "Quiet hours"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.QuietHoursSettings} "Quiet hours updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/quiet-hours [put]
+func (h *SessionHandler) SetQuietHours(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set quiet hours")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
 	if err != nil {
-		h.GetLogger().ErrorWithFields("Failed to logout session", map[string]interface{}{
-			"session_id": sessionID.String(),
-			"error":      err.Error(),
-		})
-		h.GetWriter().WriteInternalError(w, "Failed to logout session")
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
 		return
 	}
 
-	h.LogSuccess("logout session", map[string]interface{}{
-		"session_id": sessionID.String(),
+	var req contracts.QuietHoursSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetQuietHours(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set quiet hours")
+		return
+	}
+
+	h.LogSuccess("set quiet hours", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"enabled":            req.Enabled,
 	})
 
-	response := map[string]interface{}{
-		"session_id": sessionID.String(),
-		"status":     "logged_out",
-		"message":    "Session logged out successfully",
+	h.GetWriter().WriteSuccess(w, req, "Quiet hours updated successfully")
+}
+
+// @Summary Set media policy
+// @Description Limit the media a session sends and downloads: maximum bytes per media type (image, video, audio, document, sticker; up to 100 MB) and the allowed MIME types, exact or as type/* wildcards. Sends that break the policy fail with 413 MEDIA_TOO_LARGE or 415 MEDIA_TYPE_NOT_ALLOWED before anything is uploaded; inbound media that breaks it is not downloaded.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.MediaPolicy true "Media policy"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.MediaPolicy} "Media policy updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/media-policy [put]
+func (h *SessionHandler) SetMediaPolicy(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set media policy")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
 	}
 
-	h.GetWriter().WriteSuccess(w, response, "Session logged out successfully")
+	var req contracts.MediaPolicy
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetMediaPolicy(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set media policy")
+		return
+	}
+
+	h.LogSuccess("set media policy", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"allowed_mime_types": len(req.AllowedMIMETypes),
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Media policy updated successfully")
 }
 
-// @Summary Pair phone number
-// @Description Pair WhatsApp session with phone number
+// @Summary Set footer
+// @Description Set a signature appended to outbound text messages and image, video and document captions. Each send request can override it with its own footer field, or send an empty footer to skip it.
 // @Tags Sessions
 // @Security ApiKeyAuth
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Param request body contracts.PairPhoneRequest true "Phone pairing request"
-// @Success 200 {object} shared.SuccessResponse "Phone pairing initiated successfully"
+// @Param request body contracts.FooterSettings true "Footer"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.FooterSettings} "Footer updated successfully"
 // @Failure 400 {object} shared.ErrorResponse "Bad Request"
 // @Failure 404 {object} shared.ErrorResponse "Session not found"
 // @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
-// @Router /sessions/{sessionId}/pair [post]
-func (h *SessionHandler) PairPhone(w http.ResponseWriter, r *http.Request) {
-	h.LogRequest(r, "pair phone")
+// @Router /sessions/{sessionId}/settings/footer [put]
+func (h *SessionHandler) SetFooter(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set footer")
 
-	sessionID, err := h.GetSessionIDFromURL(r)
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
 	if err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid session ID", err.Error())
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
 		return
 	}
 
-	var req contracts.PairPhoneRequest
-	if err := h.ParseAndValidateJSON(r, &req); err != nil {
-		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+	var req contracts.FooterSettings
+	if !h.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	h.LogSuccess("pair phone", map[string]interface{}{
-		"session_id":   sessionID.String(),
-		"phone_number": req.PhoneNumber,
+	if err := h.sessionService.SetFooter(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set footer")
+		return
+	}
+
+	h.LogSuccess("set footer", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"enabled":            req.Enabled,
 	})
 
-	response := map[string]interface{}{
-		"session_id":   sessionID.String(),
-		"phone_number": req.PhoneNumber,
-		"status":       "pairing_initiated",
-		"message":      "Phone pairing initiated successfully - Implementation pending",
+	h.GetWriter().WriteSuccess(w, req, "Footer updated successfully")
+}
+
+// @Summary Set text format
+// @Description Turn on preprocessing of outbound text messages and image, video and document captions: Markdown to WhatsApp formatting, emoji shortcode expansion and Unicode normalization. Each send request can replace these settings with its own formatting field.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.TextFormatSettings true "Text format"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TextFormatSettings} "Text format updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/text-format [put]
+func (h *SessionHandler) SetTextFormat(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set text format")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
 	}
 
-	h.GetWriter().WriteSuccess(w, response, "Phone pairing initiated successfully")
+	var req contracts.TextFormatSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetTextFormat(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set text format")
+		return
+	}
+
+	h.LogSuccess("set text format", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"markdown":           req.Markdown,
+		"emoji":              req.Emoji,
+		"normalize":          req.Normalize,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Text format updated successfully")
+}
+
+// @Summary Set Chatwoot resolution settings
+// @Description Set the WhatsApp message sent to the contact when an agent resolves a Chatwoot conversation, the message that replaces Chatwoot's satisfaction survey prompt, and whether the contact's next message reopens the conversation. Reopening needs CHATWOOT_URL and CHATWOOT_API_TOKEN.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.ChatwootSettings true "Chatwoot settings"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ChatwootSettings} "Chatwoot settings updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/chatwoot [put]
+func (h *SessionHandler) SetChatwoot(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set chatwoot settings")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.ChatwootSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetChatwoot(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set chatwoot settings")
+		return
+	}
+
+	h.LogSuccess("set chatwoot settings", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"reopen_on_reply":    req.ReopenOnReply,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Chatwoot settings updated successfully")
+}
+
+// @Summary Set message retention
+// @Description Override how many days the session's stored messages and their media files are kept. Omit messageDays to use the instance default (MESSAGE_RETENTION_DAYS); 0 keeps them forever.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.RetentionSettings true "Retention"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.RetentionSettings} "Retention updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/retention [put]
+func (h *SessionHandler) SetRetention(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set retention")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.RetentionSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetRetention(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set retention")
+		return
+	}
+
+	h.LogSuccess("set retention", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Retention updated successfully")
+}
+
+// @Summary Set session policy
+// @Description Restrict what the session may send. dmOnly rejects sends to groups, groupOnly rejects everything but groups, and readOnly rejects all outbound messages, for sessions that only monitor. Rejected sends answer 403 SESSION_POLICY. full (the default) lifts the restriction.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.SessionPolicy true "Policy"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SessionPolicy} "Session policy updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/policy [put]
+func (h *SessionHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set session policy")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.SessionPolicy
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetPolicy(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set session policy")
+		return
+	}
+
+	if req.Mode == "" {
+		req.Mode = string(session.ModeFull)
+	}
+
+	h.LogSuccess("set session policy", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"mode":               req.Mode,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Session policy updated successfully")
+}
+
+// @Summary Set session timezone
+// @Description Set the session's IANA timezone. Quiet hours and warm-up without a timezone of their own follow it, scheduled sends are listed in it, and webhook payloads gain the event time in it (timezone and localTimestamp) for receivers that cannot convert UTC themselves. An empty timezone goes back to UTC.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.TimezoneSettings true "Timezone"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TimezoneSettings} "Timezone updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/timezone [put]
+func (h *SessionHandler) SetTimezone(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set timezone")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.TimezoneSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetTimezone(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set timezone")
+		return
+	}
+
+	h.LogSuccess("set timezone", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"timezone":           req.Timezone,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Timezone updated successfully")
+}
+
+// @Summary Set session sandbox
+// @Description Turn every send of the session into a dry run, as with dryRun=true on each send: messages are validated, normalized and logged, and the response carries the would-be payload, but nothing reaches WhatsApp. Scheduled sends of a sandbox session are simulated too.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.SandboxSettings true "Sandbox"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SandboxSettings} "Sandbox updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/sandbox [put]
+func (h *SessionHandler) SetSandbox(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set sandbox")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.SandboxSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetSandbox(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set sandbox")
+		return
+	}
+
+	h.LogSuccess("set sandbox", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"enabled":            req.Enabled,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Sandbox updated successfully")
+}
+
+// @Summary Set session pairing limits
+// @Description Limit how many QR codes a pairing shows. Once maxQRCodes codes go unscanned the pairing stops, the session's status becomes qr_timeout and the webhook receives pairing_ended with reason qr_limit, instead of the connection being held for codes nobody reads. 0 leaves it to WhatsApp, which stops after a few minutes (reason timeout, also qr_timeout). Every qr.updated carries attempt and max_attempts. Calling connect starts a new pairing. Applies from the next pairing.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.PairingSettings true "Pairing settings"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.PairingSettings} "Pairing settings updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/pairing [put]
+func (h *SessionHandler) SetPairing(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set pairing settings")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.PairingSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetPairing(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set pairing settings")
+		return
+	}
+
+	h.LogSuccess("set pairing settings", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"max_qr_codes":       req.MaxQRCodes,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Pairing settings updated successfully")
+}
+
+// @Summary Set group posting rules
+// @Description Limit what the session may post to one group, so a misconfigured bot cannot flood it: maxPerHour caps posts over any rolling hour, allowedTypes restricts the message types (text, image, audio, video, document, sticker, location, live_location, contact, button, poll, payment) and bannedWords rejects text containing any of them, ignoring case. Sends breaking a rule answer 403 GROUP_RULE, or 429 GROUP_RULE with Retry-After over the hourly cap; scheduled sends over the cap wait for the next slot. The rules replace any the group had.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param groupJid path string true "Group JID"
+// @Param request body contracts.GroupRules true "Group rules"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.GroupRules} "Group rules updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/group-rules/{groupJid} [put]
+func (h *SessionHandler) SetGroupRules(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set group rules")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+	groupJID := chi.URLParam(r, "groupJid")
+
+	var req contracts.GroupRules
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetGroupRules(r.Context(), sessionID.String(), groupJID, &req); err != nil {
+		h.HandleError(w, err, "set group rules")
+		return
+	}
+
+	h.LogSuccess("set group rules", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"group_jid":          groupJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Group rules updated successfully")
+}
+
+// @Summary Remove group posting rules
+// @Description Remove the posting rules of one group, which the session may then post to freely.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param groupJid path string true "Group JID"
+// @Success 200 {object} shared.SuccessResponse "Group rules removed successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/group-rules/{groupJid} [delete]
+func (h *SessionHandler) DeleteGroupRules(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete group rules")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+	groupJID := chi.URLParam(r, "groupJid")
+
+	if err := h.sessionService.SetGroupRules(r.Context(), sessionID.String(), groupJID, nil); err != nil {
+		h.HandleError(w, err, "delete group rules")
+		return
+	}
+
+	h.LogSuccess("delete group rules", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"group_jid":          groupJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Group rules removed successfully")
+}
+
+// @Summary Set inbound filter
+// @Description Screen the messages contacts send the session for spam. Each rule matches when every condition it sets holds: keywords (any of them in the text, ignoring case), pattern (a regular expression), senders (numbers or JIDs, * as wildcard, e.g. "234*" or "*@lid") and linkOnly (text that is nothing but links). The first rule that matches applies its actions: tag adds the match under annotations.filter in the message webhook, drop keeps the message from webhooks and Chatwoot, reply answers the chat at most once an hour and block adds the sender to the account's blocklist. Matched messages are still stored and are listed in the filter log. Group messages are screened only with groups. The rules replace any the session had.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.InboundFilterSettings true "Inbound filter"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.InboundFilterSettings} "Inbound filter updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/inbound-filter [put]
+func (h *SessionHandler) SetInboundFilter(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set inbound filter")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.InboundFilterSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.sessionService.SetInboundFilter(r.Context(), sessionID.String(), &req); err != nil {
+		h.HandleError(w, err, "set inbound filter")
+		return
+	}
+
+	h.LogSuccess("set inbound filter", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"rules":              len(req.Rules),
+	})
+
+	h.GetWriter().WriteSuccess(w, req, "Inbound filter updated successfully")
+}
+
+// @Summary Remove inbound filter
+// @Description Remove every inbound filter rule of the session; the filter log is kept.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse "Inbound filter removed successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/inbound-filter [delete]
+func (h *SessionHandler) DeleteInboundFilter(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete inbound filter")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	if err := h.sessionService.SetInboundFilter(r.Context(), sessionID.String(), nil); err != nil {
+		h.HandleError(w, err, "delete inbound filter")
+		return
+	}
+
+	h.LogSuccess("delete inbound filter", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Inbound filter removed successfully")
+}
+
+// @Summary List filtered messages
+// @Description List the inbound messages the session's filter matched, newest first, with the rule, the conditions met, the actions taken and any that failed. Pass the returned nextCursor as cursor to fetch the following page
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Cursor from a previous page's nextCursor"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.FilteredMessageListResponse} "Filtered messages retrieved successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/filter/log [get]
+func (h *SessionHandler) ListFilteredMessages(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list filtered messages")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	limit, _, err := h.GetPaginationParams(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid pagination parameters", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.ListFilteredMessages(r.Context(), sessionID.String(), limit, h.GetQueryString(r, "cursor"))
+	if err != nil {
+		h.HandleError(w, err, "list filtered messages")
+		return
+	}
+
+	h.LogSuccess("list filtered messages", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"returned":           len(response.Messages),
+		"has_more":           response.HasMore,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Filtered messages retrieved successfully")
+}
+
+// @Summary Toggle session feature flags
+// @Description Switch session features on or off at runtime. Only the flags in the body change; the response has all of them. enableButtons (default on) allows button messages, rejected with 403 FEATURE_DISABLED when off. enableChatwoot (on) forwards messages to Chatwoot and routes agent replies. enableAutoRead (off) marks incoming messages as read on arrival. enableLinkPreview (off) attaches a preview of the first link in sent text.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.FeatureFlags true "Flags to change"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.FeatureFlags} "Feature flags updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/features [patch]
+func (h *SessionHandler) SetFeatures(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set feature flags")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.FeatureFlags
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteErrorWithCode(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request format", err.Error())
+		return
+	}
+
+	features, err := h.sessionService.SetFeatures(r.Context(), sessionID.String(), req)
+	if err != nil {
+		h.HandleError(w, err, "set feature flags")
+		return
+	}
+
+	h.LogSuccess("set feature flags", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"changed":            len(req),
+	})
+
+	h.GetWriter().WriteSuccess(w, features, "Feature flags updated successfully")
+}
+
+// @Summary Set warm-up
+// @Description Ramp the session's daily send limit linearly from startLimit to endLimit over the given days, to reduce ban risk on new numbers. Sends over the day's limit are rejected with 429 WARMUP_LIMIT or, with the defer policy, scheduled for the next day. The ramp starts when first enabled unless startedAt is given.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.WarmUpSettings true "Warm-up"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.WarmUpSettings} "Warm-up updated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/warm-up [put]
+func (h *SessionHandler) SetWarmUp(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "set warm-up")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.WarmUpSettings
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.sessionService.SetWarmUp(r.Context(), sessionID.String(), &req)
+	if err != nil {
+		h.HandleError(w, err, "set warm-up")
+		return
+	}
+
+	h.LogSuccess("set warm-up", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"enabled":            response.Enabled,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Warm-up updated successfully")
+}
+
+// @Summary Get warm-up status
+// @Description Get today's warm-up limit and how many sends it has left
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.WarmUpStatusResponse} "Warm-up status retrieved successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/settings/warm-up [get]
+func (h *SessionHandler) GetWarmUpStatus(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get warm-up status")
+
+	sessionID, _, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.GetWarmUpStatus(r.Context(), sessionID.String())
+	if err != nil {
+		h.HandleError(w, err, "get warm-up status")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Warm-up status retrieved successfully")
+}
+
+// @Summary Get session uptime
+// @Description Get daily uptime percentages (UTC days) and the status transition log of a session
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param days query int false "Number of days, today included (1-90)" default(7)
+// @Success 200 {object} shared.SuccessResponse{data=contracts.UptimeResponse} "Uptime retrieved successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/uptime [get]
+func (h *SessionHandler) GetUptime(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get session uptime")
+
+	sessionID, _, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	days, err := h.GetQueryInt(r, "days", session.DefaultUptimeDays)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid days parameter", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.GetUptime(r.Context(), sessionID.String(), days)
+	if err != nil {
+		h.HandleError(w, err, "get session uptime")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Uptime retrieved successfully")
+}
+
+// @Summary List session devices
+// @Description List the phone and companion devices registered under the session's WhatsApp account, with platform when known and when each was last seen
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DeviceListResponse} "Devices retrieved successfully"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 409 {object} shared.ErrorResponse "Session is not connected"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/devices [get]
+func (h *SessionHandler) ListDevices(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list session devices")
+
+	sessionID, _, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.ListDevices(r.Context(), sessionID.String())
+	if err != nil {
+		h.HandleError(w, err, "list session devices")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Devices retrieved successfully")
+}
+
+// @Summary Instance capacity
+// @Description Connected sessions against WA_MAX_SESSIONS (availableSessions is -1 without a cap), process goroutines and heap, and the sessions with webhook deliveries still running, for autoscaling decisions. Once saturated, connecting another session answers 503 INSTANCE_AT_CAPACITY
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.CapacityResponse} "Instance capacity retrieved successfully"
+// @Router /admin/capacity [get]
+func (h *SessionHandler) GetCapacity(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get instance capacity")
+
+	response := h.sessionService.GetCapacity()
+
+	h.GetWriter().WriteSuccess(w, response, "Instance capacity retrieved successfully")
+}
+
+// @Summary Start protocol debug capture
+// @Description Capture the session's whatsmeow logs in memory, apart from the application log, to look into pairing and connection problems. level (debug by default, which includes every frame sent and received) is the lowest level kept, capacity (default 2000) bounds the entries kept, the oldest going first, and minutes (default 30, at most 1440) is how long the capture runs. Starting again replaces the running capture. Captures are lost on restart
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.StartDebugCaptureRequest false "Capture options"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DebugCaptureResponse} "Debug capture started successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /admin/sessions/{sessionId}/debug [put]
+func (h *SessionHandler) StartDebugCapture(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "start debug capture")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.StartDebugCaptureRequest
+	if r.ContentLength != 0 && !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.sessionService.StartDebugCapture(r.Context(), sessionID.String(), &req)
+	if err != nil {
+		h.HandleError(w, err, "start debug capture")
+		return
+	}
+
+	h.LogSuccess("start debug capture", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"level":              response.Level,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Debug capture started successfully")
+}
+
+// @Summary Stop protocol debug capture
+// @Description Stop the session's protocol debug capture and discard the entries it kept
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DebugCaptureResponse} "Debug capture stopped successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "No capture for this session"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /admin/sessions/{sessionId}/debug [delete]
+func (h *SessionHandler) StopDebugCapture(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "stop debug capture")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.StopDebugCapture(r.Context(), sessionID.String())
+	if err != nil {
+		h.HandleError(w, err, "stop debug capture")
+		return
+	}
+
+	h.LogSuccess("stop debug capture", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"entries":            response.Entries,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Debug capture stopped successfully")
+}
+
+// @Summary Get protocol debug logs
+// @Description Return the whatsmeow log lines the session's debug capture kept, oldest first, with the capture's state. A capture past its end keeps its entries until stopped or started again
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param level query string false "Lowest level returned" Enums(debug, info, warn, error)
+// @Param limit query int false "Return only the most recent entries"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.DebugLogsResponse} "Debug logs retrieved successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "No capture for this session"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/debug/logs [get]
+func (h *SessionHandler) GetDebugLogs(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get debug logs")
+
+	sessionID, _, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	limit, err := h.GetQueryInt(r, "limit", 0)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid limit parameter", err.Error())
+		return
+	}
+
+	response, err := h.sessionService.GetDebugLogs(r.Context(), sessionID.String(), r.URL.Query().Get("level"), limit)
+	if err != nil {
+		h.HandleError(w, err, "get debug logs")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Debug logs retrieved successfully")
+}
+
+// @Summary Get session statistics
+// @Description Get statistics about all sessions
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SessionStatsResponse} "Session statistics retrieved successfully"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/stats [get]
+func (h *SessionHandler) GetSessionStats(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get session stats")
+
+	response, err := h.sessionService.GetSessionStats(r.Context())
+	if err != nil {
+		h.HandleError(w, err, "get session stats")
+		return
+	}
+
+	h.LogSuccess("get session stats", map[string]interface{}{
+		"total":     response.Total,
+		"connected": response.Connected,
+		"offline":   response.Offline,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Session statistics retrieved successfully")
+}
+
+// @Summary Logout session
+// @Description Logout from WhatsApp session and disconnect
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse "Session logout successful"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/logout [post]
+func (h *SessionHandler) LogoutSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "logout session")
+
+	sessionID, err := h.GetSessionIDFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid session ID", err.Error())
+		return
+	}
+
+	err = h.sessionService.DisconnectSession(r.Context(), sessionID.String())
+	if err != nil {
+		h.GetLogger().ErrorWithFields("Failed to logout session", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"error":      err.Error(),
+		})
+		h.GetWriter().WriteInternalError(w, "Failed to logout session")
+		return
+	}
+
+	h.LogSuccess("logout session", map[string]interface{}{
+		"session_id": sessionID.String(),
+	})
+
+	response := map[string]interface{}{
+		"session_id": sessionID.String(),
+		"status":     "logged_out",
+		"message":    "Session logged out successfully",
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Session logged out successfully")
+}
+
+// @Summary Cancel QR pairing
+// @Description Stop the QR loop of a session that has not been scanned yet, drop the pending connection and clear the stored QR code
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse "Pairing cancelled successfully"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 409 {object} shared.ErrorResponse "Session is already connected or no pairing is in progress"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/pairing/cancel [post]
+func (h *SessionHandler) CancelPairing(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "cancel pairing")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	if err := h.sessionService.CancelPairing(r.Context(), sessionID.String()); err != nil {
+		h.HandleError(w, err, "cancel pairing")
+		return
+	}
+
+	h.LogSuccess("cancel pairing", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"session_id":         sessionID.String(),
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Pairing cancelled successfully")
+}
+
+// @Summary Pair phone number
+// @Description Pair WhatsApp session with phone number
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.PairPhoneRequest true "Phone pairing request"
+// @Success 200 {object} shared.SuccessResponse "Phone pairing initiated successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/pair [post]
+func (h *SessionHandler) PairPhone(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "pair phone")
+
+	sessionID, err := h.GetSessionIDFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid session ID", err.Error())
+		return
+	}
+
+	var req contracts.PairPhoneRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	h.LogSuccess("pair phone", map[string]interface{}{
+		"session_id":   sessionID.String(),
+		"phone_number": req.PhoneNumber,
+	})
+
+	response := map[string]interface{}{
+		"session_id":   sessionID.String(),
+		"phone_number": req.PhoneNumber,
+		"status":       "pairing_initiated",
+		"message":      "Phone pairing initiated successfully - Implementation pending",
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Phone pairing initiated successfully")
+}
+
+// @Summary Export session credentials
+// @Description Export the paired device credentials of a session as an encrypted backup. The backup is sealed with AES-256-GCM using a key derived from the supplied passphrase and can be imported on another zpwoot instance without scanning a new QR code.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.ExportSessionRequest true "Backup passphrase"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.SessionBackup} "Session exported successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 404 {object} shared.ErrorResponse "Session not found"
+// @Failure 409 {object} shared.ErrorResponse "Session has no paired device"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/{sessionId}/export [post]
+func (h *SessionHandler) ExportSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "export session")
+
+	sessionID, sessionIdentifier, err := h.resolveSessionIdentifier(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, "Session not found", err.Error())
+		return
+	}
+
+	var req contracts.ExportSessionRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.sessionService.ExportSession(r.Context(), sessionID.String(), &req)
+	if err != nil {
+		h.HandleError(w, err, "export session")
+		return
+	}
+
+	h.LogSuccess("export session", map[string]interface{}{
+		"session_identifier": sessionIdentifier,
+		"session_id":         sessionID.String(),
+		"device_jid":         response.DeviceJID,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Session exported successfully")
+}
+
+// @Summary Import session credentials
+// @Description Create a session from an encrypted backup produced by the export endpoint. The device is restored without re-pairing; set connect to true to connect immediately. If connecting fails the session is still created and connectionError says why.
+// @Tags Sessions
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body contracts.ImportSessionRequest true "Session backup and passphrase"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.SessionResponse} "Session imported successfully"
+// @Failure 400 {object} shared.ErrorResponse "Bad Request"
+// @Failure 401 {object} shared.ErrorResponse "Invalid backup passphrase"
+// @Failure 409 {object} shared.ErrorResponse "Session or device already exists"
+// @Failure 500 {object} shared.ErrorResponse "Internal Server Error"
+// @Router /sessions/import [post]
+func (h *SessionHandler) ImportSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "import session")
+
+	var req contracts.ImportSessionRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.sessionService.ImportSession(r.Context(), &req)
+	if err != nil {
+		h.HandleError(w, err, "import session")
+		return
+	}
+
+	if response.ConnectionError != nil {
+		h.GetWriter().WriteCreated(w, response, "Session imported but failed to connect")
+		return
+	}
+
+	h.LogSuccess("import session", map[string]interface{}{
+		"session_id":   response.ID,
+		"session_name": response.Name,
+		"device_jid":   response.DeviceJID,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Session imported successfully")
 }
@@ -0,0 +1,306 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+type TenantHandler struct {
+	*shared.BaseHandler
+	tenantService *services.TenantService
+}
+
+func NewTenantHandler(tenantService *services.TenantService, logger *logger.Logger) *TenantHandler {
+	return &TenantHandler{
+		BaseHandler:   shared.NewBaseHandler(logger),
+		tenantService: tenantService,
+	}
+}
+
+// @Summary List tenants
+// @Description List tenants and their quotas
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListTenantsResponse}
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants [get]
+func (h *TenantHandler) ListTenants(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list tenants")
+
+	response, err := h.tenantService.ListTenants(r.Context())
+	if err != nil {
+		h.HandleError(w, err, "list tenants")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Tenants retrieved successfully")
+}
+
+// @Summary Create tenant
+// @Description Create a tenant. Quotas of 0 are unlimited.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body contracts.CreateTenantRequest true "Tenant"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.TenantResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Name already in use"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants [post]
+func (h *TenantHandler) CreateTenant(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "create tenant")
+
+	var req contracts.CreateTenantRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.tenantService.CreateTenant(r.Context(), &req)
+	if err != nil {
+		h.HandleError(w, err, "create tenant")
+		return
+	}
+
+	h.LogSuccess("create tenant", map[string]interface{}{
+		"tenant_id": response.ID,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Tenant created successfully")
+}
+
+// @Summary Get tenant
+// @Description Get a tenant with its quota usage for the current UTC day
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TenantResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId} [get]
+func (h *TenantHandler) GetTenant(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "get tenant")
+
+	response, err := h.tenantService.GetTenant(r.Context(), chi.URLParam(r, "tenantId"))
+	if err != nil {
+		h.HandleError(w, err, "get tenant")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Tenant retrieved successfully")
+}
+
+// @Summary Update tenant
+// @Description Rename a tenant or change its quotas. Only the fields sent are changed.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body contracts.UpdateTenantRequest true "Changes"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TenantResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Name already in use"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId} [put]
+func (h *TenantHandler) UpdateTenant(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "update tenant")
+
+	var req contracts.UpdateTenantRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.tenantService.UpdateTenant(r.Context(), chi.URLParam(r, "tenantId"), &req)
+	if err != nil {
+		h.HandleError(w, err, "update tenant")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Tenant updated successfully")
+}
+
+// @Summary Delete tenant
+// @Description Delete a tenant and its API keys. Its sessions are kept and return to the global API key.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId} [delete]
+func (h *TenantHandler) DeleteTenant(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete tenant")
+
+	tenantID := chi.URLParam(r, "tenantId")
+	if err := h.tenantService.DeleteTenant(r.Context(), tenantID); err != nil {
+		h.HandleError(w, err, "delete tenant")
+		return
+	}
+
+	h.LogSuccess("delete tenant", map[string]interface{}{
+		"tenant_id": tenantID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Tenant deleted successfully")
+}
+
+// @Summary List tenant API keys
+// @Description List a tenant's API keys. Keys are shown by prefix only.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListTenantKeysResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId}/keys [get]
+func (h *TenantHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list tenant keys")
+
+	response, err := h.tenantService.ListKeys(r.Context(), chi.URLParam(r, "tenantId"))
+	if err != nil {
+		h.HandleError(w, err, "list tenant keys")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, response, "Tenant API keys retrieved successfully")
+}
+
+// @Summary Create tenant API key
+// @Description Issue an API key for a tenant. The key is only returned in this response.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body contracts.CreateTenantKeyRequest false "Key name"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.TenantKeyResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId}/keys [post]
+func (h *TenantHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "create tenant key")
+
+	var req contracts.CreateTenantKeyRequest
+	if r.ContentLength != 0 && !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	response, err := h.tenantService.CreateKey(r.Context(), chi.URLParam(r, "tenantId"), &req)
+	if err != nil {
+		h.HandleError(w, err, "create tenant key")
+		return
+	}
+
+	h.LogSuccess("create tenant key", map[string]interface{}{
+		"key_id": response.ID,
+		"prefix": response.Prefix,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Tenant API key created successfully")
+}
+
+// @Summary Revoke tenant API key
+// @Description Revoke a tenant API key; requests using it are rejected immediately
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param keyId path string true "Key ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId}/keys/{keyId} [delete]
+func (h *TenantHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "revoke tenant key")
+
+	keyID := chi.URLParam(r, "keyId")
+	if err := h.tenantService.RevokeKey(r.Context(), chi.URLParam(r, "tenantId"), keyID); err != nil {
+		h.HandleError(w, err, "revoke tenant key")
+		return
+	}
+
+	h.LogSuccess("revoke tenant key", map[string]interface{}{
+		"key_id": keyID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Tenant API key revoked successfully")
+}
+
+// @Summary Assign session to tenant
+// @Description Move an existing session to a tenant, within its session quota. Sessions owned by another tenant must be released first.
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param sessionName path string true "Session name or ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 403 {object} shared.ErrorResponse "Session limit reached"
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Session owned by another tenant"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId}/sessions/{sessionName} [put]
+func (h *TenantHandler) AssignSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "assign session to tenant")
+
+	tenantID := chi.URLParam(r, "tenantId")
+	sessionName := chi.URLParam(r, "sessionName")
+	if err := h.tenantService.AssignSession(r.Context(), tenantID, sessionName); err != nil {
+		h.HandleError(w, err, "assign session to tenant")
+		return
+	}
+
+	h.LogSuccess("assign session to tenant", map[string]interface{}{
+		"tenant_id": tenantID,
+		"session":   sessionName,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Session assigned successfully")
+}
+
+// @Summary Release session from tenant
+// @Description Return a tenant's session to the global API key
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param sessionName path string true "Session name or ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /admin/tenants/{tenantId}/sessions/{sessionName} [delete]
+func (h *TenantHandler) ReleaseSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "release session from tenant")
+
+	tenantID := chi.URLParam(r, "tenantId")
+	sessionName := chi.URLParam(r, "sessionName")
+	if err := h.tenantService.ReleaseSession(r.Context(), tenantID, sessionName); err != nil {
+		h.HandleError(w, err, "release session from tenant")
+		return
+	}
+
+	h.LogSuccess("release session from tenant", map[string]interface{}{
+		"tenant_id": tenantID,
+		"session":   sessionName,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Session released successfully")
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"zpwoot/internal/adapters/fakewa"
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/platform/logger"
+)
+
+// TestingHandler scripts the fake WhatsApp gateway. Its routes are only
+// mounted when WA_TEST_MODE is enabled.
+type TestingHandler struct {
+	*shared.BaseHandler
+	gateway *fakewa.Gateway
+}
+
+func NewTestingHandler(gateway *fakewa.Gateway, logger *logger.Logger) *TestingHandler {
+	return &TestingHandler{
+		BaseHandler: shared.NewBaseHandler(logger),
+		gateway:     gateway,
+	}
+}
+
+// @Summary Inject events (test mode)
+// @Description Play a script of inbound events on a fake session: messages, receipts, connection changes and logouts. Steps run in order in the background after their delay.
+// @Tags Testing
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionName path string true "Session name"
+// @Param request body contracts.InjectTestEventsRequest true "Script"
+// @Success 202 {object} shared.SuccessResponse{data=contracts.InjectTestEventsResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Router /test/sessions/{sessionName}/events [post]
+func (h *TestingHandler) InjectEvents(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "inject test events")
+
+	sessionName, err := h.GetSessionNameFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return
+	}
+
+	var req contracts.InjectTestEventsRequest
+	if !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	script := make([]fakewa.Event, len(req.Events))
+	for i, evt := range req.Events {
+		script[i] = fakewa.Event{
+			Type:        evt.Type,
+			Delay:       time.Duration(evt.DelayMs) * time.Millisecond,
+			From:        evt.From,
+			Chat:        evt.Chat,
+			FromMe:      evt.FromMe,
+			ID:          evt.ID,
+			Text:        evt.Text,
+			PushName:    evt.PushName,
+			ReceiptType: evt.ReceiptType,
+			MessageIDs:  evt.MessageIDs,
+			Reason:      evt.Reason,
+		}
+	}
+
+	ids, err := h.gateway.Inject(sessionName, script)
+	if err != nil {
+		h.HandleError(w, err, "inject test events")
+		return
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+
+	h.GetWriter().WriteAccepted(w, &contracts.InjectTestEventsResponse{MessageIDs: ids}, "Events scheduled")
+}
+
+// @Summary Pair session (test mode)
+// @Description Pair a fake session as if its QR code had been scanned and connect it
+// @Tags Testing
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param sessionName path string true "Session name"
+// @Param request body contracts.PairTestSessionRequest false "Phone number"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.PairTestSessionResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Router /test/sessions/{sessionName}/pair [post]
+func (h *TestingHandler) PairSession(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "pair test session")
+
+	sessionName, err := h.GetSessionNameFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return
+	}
+
+	var req contracts.PairTestSessionRequest
+	if r.ContentLength != 0 && !h.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	deviceJID, err := h.gateway.Pair(sessionName, req.Phone)
+	if err != nil {
+		h.HandleError(w, err, "pair test session")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, &contracts.PairTestSessionResponse{DeviceJID: deviceJID}, "Session paired")
+}
+
+// @Summary List sent messages (test mode)
+// @Description List the messages a fake session sent, oldest first
+// @Tags Testing
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionName path string true "Session name"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListTestSentMessagesResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Router /test/sessions/{sessionName}/sent [get]
+func (h *TestingHandler) ListSent(w http.ResponseWriter, r *http.Request) {
+	sessionName, err := h.GetSessionNameFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return
+	}
+
+	sent, err := h.gateway.Sent(sessionName)
+	if err != nil {
+		h.HandleError(w, err, "list sent messages")
+		return
+	}
+
+	messages := make([]contracts.TestSentMessage, len(sent))
+	for i, msg := range sent {
+		messages[i] = contracts.TestSentMessage{
+			ID:        msg.ID,
+			Type:      msg.Type,
+			To:        msg.To,
+			Content:   msg.Content,
+			Payload:   msg.Payload,
+			Timestamp: msg.Timestamp,
+		}
+	}
+
+	h.GetWriter().WriteSuccess(w, &contracts.ListTestSentMessagesResponse{
+		Messages: messages,
+		Total:    len(messages),
+	}, "Sent messages retrieved")
+}
+
+// @Summary Clear sent messages (test mode)
+// @Description Forget the messages recorded for a fake session
+// @Tags Testing
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sessionName path string true "Session name"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Router /test/sessions/{sessionName}/sent [delete]
+func (h *TestingHandler) ClearSent(w http.ResponseWriter, r *http.Request) {
+	sessionName, err := h.GetSessionNameFromURL(r)
+	if err != nil {
+		h.GetWriter().WriteBadRequest(w, err.Error())
+		return
+	}
+
+	if err := h.gateway.ClearSent(sessionName); err != nil {
+		h.HandleError(w, err, "clear sent messages")
+		return
+	}
+
+	h.GetWriter().WriteSuccess(w, nil, "Sent messages cleared")
+}
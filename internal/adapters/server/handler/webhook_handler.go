@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"zpwoot/internal/adapters/server/contracts"
 	"zpwoot/internal/adapters/server/shared"
 	"zpwoot/internal/services"
 	"zpwoot/platform/logger"
@@ -12,29 +13,30 @@ import (
 
 type WebhookHandler struct {
 	*shared.BaseHandler
-	sessionService *services.SessionService
+	webhookService *services.WebhookService
 }
 
 func NewWebhookHandler(
-	sessionService *services.SessionService,
+	webhookService *services.WebhookService,
 	logger *logger.Logger,
 ) *WebhookHandler {
 	return &WebhookHandler{
 		BaseHandler:    shared.NewBaseHandler(logger),
-		sessionService: sessionService,
+		webhookService: webhookService,
 	}
 }
 
 // @Summary Set webhook configuration
-// @Description Configure webhook settings for the session
+// @Description Configure the session's primary webhook, the one with the highest priority, creating it if the session has none; other endpoints are managed under /webhooks. events limits delivery to the listed categories (messages, receipts, presence, groups, calls, connection); leave it empty to receive everything. template reshapes the payload: string values starting with "$" are paths into the event (e.g. "$.data.Info.ID"), anything else is copied as is. Omitted secret and enabled keep their current values
 // @Tags Webhooks
 // @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse
-// @Failure 400 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Param request body contracts.SetWebhookRequest true "Webhook configuration"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.WebhookResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/webhook/set [post]
 func (h *WebhookHandler) SetConfig(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "set webhook config")
@@ -45,27 +47,36 @@ func (h *WebhookHandler) SetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.sessionService.GetSession(r.Context(), sessionID)
+	var req contracts.SetWebhookRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.webhookService.SetWebhook(r.Context(), sessionID, &req)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "set webhook config")
 		return
 	}
 
 	h.LogSuccess("set webhook config", map[string]interface{}{
 		"session_id": sessionID,
+		"url":        response.URL,
+		"events":     response.Events,
+		"enabled":    response.Enabled,
 	})
 
-	h.GetWriter().WriteSuccess(w, nil, "Webhook configuration set successfully")
+	h.GetWriter().WriteSuccess(w, response, "Webhook configuration set successfully")
 }
 
 // @Summary Get webhook configuration
-// @Description Get the current webhook configuration for the session
+// @Description Get the session's primary webhook, the one with the highest priority. The secret is never returned; hasSecret tells whether one is set
 // @Tags Webhooks
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Success 200 {object} shared.SuccessResponse{data=contracts.WebhookResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/webhook/find [get]
 func (h *WebhookHandler) FindConfig(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "find webhook config")
@@ -76,9 +87,9 @@ func (h *WebhookHandler) FindConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.sessionService.GetSessionByNameOrID(r.Context(), sessionID)
+	response, err := h.webhookService.GetWebhook(r.Context(), sessionID)
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "find webhook config")
 		return
 	}
 
@@ -86,18 +97,17 @@ func (h *WebhookHandler) FindConfig(w http.ResponseWriter, r *http.Request) {
 		"session_id": sessionID,
 	})
 
-	h.GetWriter().WriteSuccess(w, nil, "Webhook configuration retrieved successfully")
+	h.GetWriter().WriteSuccess(w, response, "Webhook configuration retrieved successfully")
 }
 
 // @Summary Test webhook configuration
-// @Description Test the webhook configuration by sending a test event
+// @Description Send a webhook.test event to the session's primary webhook, applying its template and signature but ignoring the event filter. A failed delivery is reported in the response body rather than as an error status
 // @Tags Webhooks
-// @Accept json
 // @Produce json
 // @Param sessionId path string true "Session ID"
-// @Success 200 {object} shared.SuccessResponse
-// @Failure 404 {object} shared.SuccessResponse
-// @Failure 500 {object} shared.SuccessResponse
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TestWebhookResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
 // @Router /sessions/{sessionId}/webhook/test [post]
 func (h *WebhookHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
 	h.LogRequest(r, "test webhook")
@@ -108,15 +118,199 @@ func (h *WebhookHandler) TestWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.sessionService.GetSession(r.Context(), sessionID)
+	response, err := h.webhookService.TestWebhook(r.Context(), sessionID, "")
 	if err != nil {
-		h.GetWriter().WriteNotFound(w, "Session not found")
+		h.HandleError(w, err, "test webhook")
 		return
 	}
 
 	h.LogSuccess("test webhook", map[string]interface{}{
+		"session_id":  sessionID,
+		"delivered":   response.Delivered,
+		"status_code": response.StatusCode,
+	})
+
+	message := "Webhook test completed successfully"
+	if !response.Delivered {
+		message = "Webhook test delivery failed"
+	}
+
+	h.GetWriter().WriteSuccess(w, response, message)
+}
+
+// @Summary List webhooks
+// @Description List every webhook endpoint of the session, highest priority first, with the outcome of its latest deliveries
+// @Tags Webhooks
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.ListWebhooksResponse}
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "list webhooks")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	response, err := h.webhookService.ListWebhooks(r.Context(), sessionID)
+	if err != nil {
+		h.HandleError(w, err, "list webhooks")
+		return
+	}
+
+	h.LogSuccess("list webhooks", map[string]interface{}{
+		"session_id": sessionID,
+		"total":      response.Total,
+	})
+
+	h.GetWriter().WriteSuccess(w, response, "Webhooks retrieved successfully")
+}
+
+// @Summary Add webhook
+// @Description Add another webhook endpoint to the session, with its own event filter, secret, template and priority. Every endpoint that subscribes to an event receives it, concurrently. A session can have up to 10 endpoints
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param request body contracts.SetWebhookRequest true "Webhook configuration"
+// @Success 201 {object} shared.SuccessResponse{data=contracts.WebhookResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 409 {object} shared.ErrorResponse "Session already has the maximum number of webhooks"
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "create webhook")
+
+	sessionID := chi.URLParam(r, "sessionName")
+
+	var req contracts.SetWebhookRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.webhookService.CreateWebhook(r.Context(), sessionID, &req)
+	if err != nil {
+		h.HandleError(w, err, "create webhook")
+		return
+	}
+
+	h.LogSuccess("create webhook", map[string]interface{}{
+		"session_id": sessionID,
+		"webhook_id": response.ID,
+		"url":        response.URL,
+		"priority":   response.Priority,
+	})
+
+	h.GetWriter().WriteCreated(w, response, "Webhook created successfully")
+}
+
+// @Summary Update webhook
+// @Description Replace the configuration of one webhook endpoint. Omitted secret, schemaVersion, priority and enabled keep their current values; the delivery state is kept
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param request body contracts.SetWebhookRequest true "Webhook configuration"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.WebhookResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/webhooks/{webhookId} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "update webhook")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	webhookID := chi.URLParam(r, "webhookId")
+
+	var req contracts.SetWebhookRequest
+	if err := h.ParseJSONBody(r, &req); err != nil {
+		h.GetWriter().WriteBadRequest(w, "Invalid request format", err.Error())
+		return
+	}
+
+	response, err := h.webhookService.UpdateWebhook(r.Context(), sessionID, webhookID, &req)
+	if err != nil {
+		h.HandleError(w, err, "update webhook")
+		return
+	}
+
+	h.LogSuccess("update webhook", map[string]interface{}{
 		"session_id": sessionID,
+		"webhook_id": webhookID,
+		"url":        response.URL,
+		"enabled":    response.Enabled,
 	})
 
-	h.GetWriter().WriteSuccess(w, nil, "Webhook test completed successfully")
+	h.GetWriter().WriteSuccess(w, response, "Webhook updated successfully")
+}
+
+// @Summary Delete webhook
+// @Description Remove one webhook endpoint from the session
+// @Tags Webhooks
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} shared.SuccessResponse
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "delete webhook")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	webhookID := chi.URLParam(r, "webhookId")
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), sessionID, webhookID); err != nil {
+		h.HandleError(w, err, "delete webhook")
+		return
+	}
+
+	h.LogSuccess("delete webhook", map[string]interface{}{
+		"session_id": sessionID,
+		"webhook_id": webhookID,
+	})
+
+	h.GetWriter().WriteSuccess(w, nil, "Webhook deleted successfully")
+}
+
+// @Summary Test webhook
+// @Description Send a webhook.test event to one webhook endpoint, applying its template and signature but ignoring the event filter. A failed delivery is reported in the response body rather than as an error status
+// @Tags Webhooks
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} shared.SuccessResponse{data=contracts.TestWebhookResponse}
+// @Failure 400 {object} shared.ErrorResponse
+// @Failure 404 {object} shared.ErrorResponse
+// @Failure 500 {object} shared.ErrorResponse
+// @Router /sessions/{sessionId}/webhooks/{webhookId}/test [post]
+func (h *WebhookHandler) TestWebhookByID(w http.ResponseWriter, r *http.Request) {
+	h.LogRequest(r, "test webhook")
+
+	sessionID := chi.URLParam(r, "sessionName")
+	webhookID := chi.URLParam(r, "webhookId")
+
+	response, err := h.webhookService.TestWebhook(r.Context(), sessionID, webhookID)
+	if err != nil {
+		h.HandleError(w, err, "test webhook")
+		return
+	}
+
+	h.LogSuccess("test webhook", map[string]interface{}{
+		"session_id":  sessionID,
+		"webhook_id":  webhookID,
+		"delivered":   response.Delivered,
+		"status_code": response.StatusCode,
+	})
+
+	message := "Webhook test completed successfully"
+	if !response.Delivered {
+		message = "Webhook test delivery failed"
+	}
+
+	h.GetWriter().WriteSuccess(w, response, message)
 }
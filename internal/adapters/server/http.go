@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"zpwoot/platform/config"
+)
+
+// NewHTTPServer builds the API's HTTP server from the server config, with
+// the protocols it allows: HTTP/1.1 always, and HTTP/2 when enabled, over
+// TLS or as cleartext h2c without it.
+func NewHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	if cfg.Server.HTTP2 {
+		if cfg.Server.TLSEnabled() {
+			protocols.SetHTTP2(true)
+		} else {
+			protocols.SetUnencryptedHTTP2(true)
+		}
+	}
+
+	return &http.Server{
+		Addr:         cfg.GetServerAddress(),
+		Handler:      handler,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
+		Protocols:    protocols,
+	}
+}
+
+// ListenAndServe serves HTTPS with the configured certificate when TLS is
+// on, plain HTTP otherwise.
+func ListenAndServe(httpServer *http.Server, cfg *config.Config) error {
+	if cfg.Server.TLSEnabled() {
+		return httpServer.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+	}
+	return httpServer.ListenAndServe()
+}
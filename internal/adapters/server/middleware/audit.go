@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/core/audit"
+)
+
+const (
+	auditMaxBodyBytes   = 64 * 1024
+	auditMaxValueLength = 256
+	auditWriteTimeout   = 5 * time.Second
+)
+
+var auditSensitiveKeys = []string{
+	"passphrase", "password", "secret", "token", "apikey", "api_key", "authorization",
+}
+
+var auditBinaryKeys = []string{
+	"file", "base64", "data", "media", "ciphertext", "backup",
+}
+
+// AuditLog records mutating requests once the response has been written.
+// Entries are persisted asynchronously so the audit store never slows down
+// the request path.
+func AuditLog(recorder audit.Recorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if recorder == nil || !isMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			summary := readPayloadSummary(r)
+
+			ww := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(ww, r)
+
+			entry := &audit.Entry{
+				APIKey:         maskAPIKey(apiKeyFromContext(r.Context())),
+				ClientIP:       getClientIP(r),
+				UserAgent:      r.Header.Get("User-Agent"),
+				Method:         r.Method,
+				Path:           r.URL.Path,
+				StatusCode:     ww.statusCode,
+				Success:        ww.statusCode < http.StatusBadRequest,
+				DurationMs:     time.Since(start).Milliseconds(),
+				PayloadSummary: summary,
+				CreatedAt:      start,
+			}
+
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				entry.Route = rctx.RoutePattern()
+				entry.SessionRef = rctx.URLParam("sessionName")
+			}
+
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+				defer cancel()
+				recorder.Record(ctx, entry)
+			}()
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func apiKeyFromContext(ctx context.Context) string {
+	if apiKey, ok := ctx.Value(apiKeyContextKey).(string); ok {
+		return apiKey
+	}
+	return ""
+}
+
+func readPayloadSummary(r *http.Request) map[string]interface{} {
+	if r.Body == nil || !strings.Contains(r.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, auditMaxBodyBytes+1))
+	if err != nil {
+		return nil
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	if len(body) > auditMaxBodyBytes {
+		return map[string]interface{}{"_truncated": true, "_size": fmt.Sprintf(">%d bytes", auditMaxBodyBytes)}
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	summary, _ := summarizeValue("", payload).(map[string]interface{})
+	return summary
+}
+
+func summarizeValue(key string, value interface{}) interface{} {
+	lowerKey := strings.ToLower(key)
+	for _, sensitive := range auditSensitiveKeys {
+		if strings.Contains(lowerKey, sensitive) {
+			return "[REDACTED]"
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = summarizeValue(k, item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = summarizeValue(key, item)
+		}
+		return out
+	case string:
+		for _, binary := range auditBinaryKeys {
+			if lowerKey == binary && len(v) > auditMaxValueLength {
+				return fmt.Sprintf("[%d chars omitted]", len(v))
+			}
+		}
+		if len(v) > auditMaxValueLength {
+			return v[:auditMaxValueLength] + "..."
+		}
+		return v
+	default:
+		return v
+	}
+}
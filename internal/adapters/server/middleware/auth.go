@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"zpwoot/internal/adapters/server/shared"
+	"zpwoot/internal/core/tenant"
 	"zpwoot/platform/config"
 	"zpwoot/platform/logger"
 )
@@ -18,7 +21,18 @@ const (
 	authenticatedContextKey contextKey = "authenticated"
 )
 
-func APIKeyAuth(cfg *config.Config, log *logger.Logger) func(http.Handler) http.Handler {
+// TenantAuthenticator resolves tenant API keys and the sessions they may
+// reach. Authenticate returns nil without an error for keys that are not
+// tenant keys.
+type TenantAuthenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error)
+	OwnsSession(ctx context.Context, tenantID uuid.UUID, idOrName string) (bool, error)
+}
+
+// APIKeyAuth accepts the global API key and, when tenants is set, keys
+// issued to tenants. Requests made with a tenant key carry the tenant in
+// their context; TenantScope then limits what they can reach.
+func APIKeyAuth(cfg *config.Config, tenants TenantAuthenticator, log *logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			path := r.URL.Path
@@ -40,7 +54,21 @@ func APIKeyAuth(cfg *config.Config, log *logger.Logger) func(http.Handler) http.
 				return
 			}
 
-			if !isValidAPIKey(apiKey, cfg) {
+			var owner *tenant.Tenant
+			if !isValidAPIKey(apiKey, cfg) && tenants != nil {
+				var err error
+				owner, err = tenants.Authenticate(r.Context(), apiKey)
+				if err != nil {
+					log.ErrorWithFields("Failed to authenticate tenant API key", map[string]interface{}{
+						"path":  path,
+						"error": err.Error(),
+					})
+					writeAuthError(w, http.StatusInternalServerError, "Internal Server Error", "Could not verify API key", "AUTH_UNAVAILABLE")
+					return
+				}
+			}
+
+			if owner == nil && !isValidAPIKey(apiKey, cfg) {
 				log.WarnWithFields("Invalid API key", map[string]interface{}{
 					"path":    path,
 					"method":  r.Method,
@@ -61,6 +89,9 @@ func APIKeyAuth(cfg *config.Config, log *logger.Logger) func(http.Handler) http.
 
 			ctx := context.WithValue(r.Context(), apiKeyContextKey, apiKey)
 			ctx = context.WithValue(ctx, authenticatedContextKey, true)
+			if owner != nil {
+				ctx = tenant.WithTenant(ctx, owner)
+			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -72,6 +103,8 @@ func isPublicRoute(path string) bool {
 		"/health",
 		"/swagger",
 		"/chatwoot/webhook",
+		"/media/public/",
+		"/l/",
 	}
 
 	for _, route := range publicRoutes {
@@ -107,14 +140,19 @@ func isValidAPIKey(apiKey string, cfg *config.Config) bool {
 }
 
 func writeUnauthorizedResponse(w http.ResponseWriter, message, code string) {
+	writeAuthError(w, http.StatusUnauthorized, "Unauthorized", message, code)
+}
+
+func writeAuthError(w http.ResponseWriter, status int, title, message, code string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(status)
 
+	locale := shared.LocaleOf(w)
 	response := shared.ErrorResponse{
 		Success: false,
-		Error:   "Unauthorized",
+		Error:   shared.Localize(locale, title),
 		Code:    code,
-		Details: message,
+		Details: shared.Localize(locale, message),
 	}
 
 	json.NewEncoder(w).Encode(response)
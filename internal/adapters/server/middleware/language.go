@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"zpwoot/internal/adapters/server/shared"
+)
+
+// Language picks the locale of the response messages from Accept-Language.
+// Codes, field names and data are never translated, so clients can keep
+// matching on them whatever the language.
+func Language() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := shared.NegotiateLocale(r.Header.Get("Accept-Language"))
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+
+			next.ServeHTTP(shared.WithLocale(w, locale), r)
+		})
+	}
+}
@@ -25,6 +25,10 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return size, err
 }
 
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func HTTPLogger(logger *logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -58,20 +62,21 @@ func HTTPLogger(logger *logger.Logger) func(http.Handler) http.Handler {
 				fields["referer"] = referer
 			}
 
+			log := logger.WithContext(r.Context())
 			message := "HTTP request processed"
 			switch {
 			case ww.statusCode >= 500:
-				logger.ErrorWithFields(message, fields)
+				log.ErrorWithFields(message, fields)
 			case ww.statusCode >= 400:
-				logger.WarnWithFields(message, fields)
+				log.WarnWithFields(message, fields)
 			case ww.statusCode >= 300:
-				logger.InfoWithFields(message, fields)
+				log.InfoWithFields(message, fields)
 			default:
 
 				if r.URL.Path == "/health" {
-					logger.DebugWithFields(message, fields)
+					log.DebugWithFields(message, fields)
 				} else {
-					logger.InfoWithFields(message, fields)
+					log.InfoWithFields(message, fields)
 				}
 			}
 		})
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/platform/metrics"
+)
+
+// sessionRouteGroups are the areas under /sessions/{sessionName} that get a
+// route group of their own; the rest count as "sessions".
+var sessionRouteGroups = map[string]bool{
+	"messages":    true,
+	"chats":       true,
+	"groups":      true,
+	"contacts":    true,
+	"webhook":     true,
+	"webhooks":    true,
+	"labels":      true,
+	"media":       true,
+	"newsletters": true,
+	"notes":       true,
+	"profile":     true,
+	"chatwoot":    true,
+}
+
+// topRouteGroups are the top-level route groups; anything else counts as
+// "other".
+var topRouteGroups = map[string]bool{
+	"admin":    true,
+	"contacts": true,
+	"chatwoot": true,
+	"media":    true,
+	"tenants":  true,
+	"health":   true,
+	"metrics":  true,
+	"swagger":  true,
+	"webhook":  true,
+	"test":     true,
+}
+
+// SLI records each request's status and latency under its route group.
+func SLI(sli *metrics.SLI) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			ww := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(ww, r)
+
+			pattern := ""
+			if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+				pattern = routeCtx.RoutePattern()
+			}
+			sli.ObserveRequest(routeGroup(pattern), ww.statusCode, time.Since(start))
+		})
+	}
+}
+
+// routeGroup maps a chi route pattern to one of a fixed set of groups, so
+// the SLI series stay few no matter how many routes or sessions exist.
+// Sends are kept apart from the other message routes, being what most
+// clients care about.
+func routeGroup(pattern string) string {
+	if pattern == "" {
+		return "unmatched"
+	}
+
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	if segments[0] == "sessions" {
+		if len(segments) < 3 || !sessionRouteGroups[segments[2]] {
+			return "sessions"
+		}
+		if segments[2] == "messages" && len(segments) > 3 && segments[3] == "send" {
+			return "messages_send"
+		}
+		if segments[2] == "webhook" {
+			return "webhooks"
+		}
+		return segments[2]
+	}
+
+	if topRouteGroups[segments[0]] {
+		return segments[0]
+	}
+	return "other"
+}
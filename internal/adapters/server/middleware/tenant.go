@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"zpwoot/internal/core/tenant"
+	"zpwoot/platform/logger"
+)
+
+// sessionCollectionRoutes are the /sessions routes that do not name a
+// session; tenants may use them and only see their own sessions.
+var sessionCollectionRoutes = map[string]bool{
+	"create": true,
+	"list":   true,
+	"import": true,
+	"bulk":   true,
+}
+
+// TenantScope keeps requests made with a tenant key inside the tenant's own
+// sessions. Routes outside /sessions, such as /admin, answer 403, and
+// sessions of other tenants answer 404 as if they did not exist. Requests
+// made with the global key pass untouched.
+func TenantScope(tenants TenantAuthenticator, log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			owner, ok := tenant.FromContext(r.Context())
+			if !ok || isPublicRoute(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.URL.Path == "/webhook/events" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionName, scoped := sessionFromPath(r.URL.Path)
+			if !scoped {
+				log.WarnWithFields("Tenant key used outside tenant scope", map[string]interface{}{
+					"tenant_id": owner.ID.String(),
+					"path":      r.URL.Path,
+					"method":    r.Method,
+				})
+				writeAuthError(w, http.StatusForbidden, "Forbidden", "This route requires the global API key", "TENANT_FORBIDDEN")
+				return
+			}
+
+			if sessionName != "" {
+				owns, err := tenants.OwnsSession(r.Context(), owner.ID, sessionName)
+				if err != nil {
+					log.ErrorWithFields("Failed to check session tenant", map[string]interface{}{
+						"tenant_id": owner.ID.String(),
+						"session":   sessionName,
+						"error":     err.Error(),
+					})
+					writeAuthError(w, http.StatusInternalServerError, "Internal Server Error", "Could not verify session access", "AUTH_UNAVAILABLE")
+					return
+				}
+				if !owns {
+					writeAuthError(w, http.StatusNotFound, "Not Found", "session not found", "SESSION_NOT_FOUND")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// sessionFromPath reports whether path is under /sessions and, when it
+// addresses a single session, that session's name or ID.
+func sessionFromPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/sessions/")
+	if !ok {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(rest, "/")
+	if name == "" || sessionCollectionRoutes[name] {
+		return "", true
+	}
+
+	return name, true
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"zpwoot/platform/config"
+)
+
+// RequestTimeout bounds the request context so handlers and the WhatsApp
+// gateway stop waiting once the client-facing deadline has passed.
+func RequestTimeout(cfg *config.Config) func(http.Handler) http.Handler {
+	timeout := time.Duration(cfg.Server.RequestTimeout) * time.Second
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
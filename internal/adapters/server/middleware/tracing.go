@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"zpwoot/platform/logger"
+)
+
+// Tracing opens a server span per request, continuing the caller's trace
+// when it sends a traceparent header. The span is named after the chi route
+// pattern once routing is done, so /sessions/a/messages and
+// /sessions/b/messages group together.
+func Tracing() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := logger.Tracer().Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("client.address", getClientIP(r)),
+				),
+			)
+			defer span.End()
+
+			ww := &responseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+				if pattern := routeCtx.RoutePattern(); pattern != "" {
+					span.SetName(fmt.Sprintf("%s %s", r.Method, pattern))
+					span.SetAttributes(attribute.String("http.route", pattern))
+				}
+			}
+
+			span.SetAttributes(attribute.Int("http.response.status_code", ww.statusCode))
+			if ww.statusCode >= 500 {
+				span.SetStatus(codes.Error, http.StatusText(ww.statusCode))
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services"
+	"zpwoot/platform/config"
+	"zpwoot/platform/database"
+	"zpwoot/platform/logger"
+	"zpwoot/platform/secrets"
+)
+
+func setupAdminRoutes(r chi.Router, reloader *config.Reloader, auditService *services.AuditService, pipeline *inbound.Pipeline, sendMetrics *session.SendMetrics, mediaPool *messaging.MediaPool, secretsRotator secrets.Rotator, db *database.Database, chatwootHandler *handler.ChatwootHandler, tenantHandler *handler.TenantHandler, sessionHandler *handler.SessionHandler, appLogger *logger.Logger) {
+	adminHandler := handler.NewAdminHandler(reloader, auditService, pipeline, sendMetrics, mediaPool, secretsRotator, db, appLogger)
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Post("/config/reload", adminHandler.ReloadConfig)
+		r.Get("/audit", adminHandler.ListAuditLog)
+		r.Get("/pipeline", adminHandler.GetInboundPipeline)
+		r.Get("/sends", adminHandler.GetSendStats)
+		r.Get("/media", adminHandler.GetMediaPoolStats)
+		r.Get("/database", adminHandler.GetDatabaseStats)
+		r.Get("/capacity", sessionHandler.GetCapacity)
+		r.Post("/secrets/rotate", adminHandler.RotateSecrets)
+
+		r.Put("/sessions/{sessionName}/debug", sessionHandler.StartDebugCapture)
+		r.Delete("/sessions/{sessionName}/debug", sessionHandler.StopDebugCapture)
+
+		setupChatwootInboxRoutes(r, chatwootHandler)
+
+		setupTenantRoutes(r, tenantHandler)
+	})
+}
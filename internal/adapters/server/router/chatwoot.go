@@ -4,13 +4,9 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"zpwoot/internal/adapters/server/handler"
-	"zpwoot/internal/services"
-	"zpwoot/platform/logger"
 )
 
-func setupChatwootRoutes(r chi.Router, messageService *services.MessageService, sessionService *services.SessionService, appLogger *logger.Logger) {
-	chatwootHandler := handler.NewChatwootHandler(messageService, sessionService, appLogger)
-
+func setupChatwootRoutes(r chi.Router, chatwootHandler *handler.ChatwootHandler) {
 	r.Route("/{sessionName}/chatwoot", func(r chi.Router) {
 
 		r.Post("/set", chatwootHandler.CreateConfig)
@@ -24,3 +20,13 @@ func setupChatwootRoutes(r chi.Router, messageService *services.MessageService,
 		r.Get("/stats", chatwootHandler.GetStats)
 	})
 }
+
+// setupChatwootInboxRoutes maintains the inbox to session routing used by
+// the account-wide webhook.
+func setupChatwootInboxRoutes(r chi.Router, chatwootHandler *handler.ChatwootHandler) {
+	r.Route("/chatwoot/inboxes", func(r chi.Router) {
+		r.Get("/", chatwootHandler.ListInboxes)
+		r.Put("/{accountId}/{inboxId}", chatwootHandler.MapInbox)
+		r.Delete("/{accountId}/{inboxId}", chatwootHandler.UnmapInbox)
+	})
+}
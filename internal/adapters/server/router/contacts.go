@@ -8,9 +8,9 @@ import (
 	"zpwoot/platform/logger"
 )
 
-func setupContactRoutes(r chi.Router, sessionService *services.SessionService, appLogger *logger.Logger) {
+func setupContactRoutes(r chi.Router, contactService *services.ContactService, sessionService *services.SessionService, appLogger *logger.Logger) {
 
-	contactHandler := handler.NewContactHandler(nil, sessionService, appLogger)
+	contactHandler := handler.NewContactHandler(nil, contactService, sessionService, appLogger)
 
 	r.Route("/{sessionName}/contacts", func(r chi.Router) {
 
@@ -30,3 +30,14 @@ func setupContactRoutes(r chi.Router, sessionService *services.SessionService, a
 		r.Get("/business", contactHandler.GetBusinessProfile)
 	})
 }
+
+// setupRegistryRoutes serves the contact registry shared by all sessions,
+// which tenant keys cannot reach.
+func setupRegistryRoutes(r chi.Router, contactService *services.ContactService, appLogger *logger.Logger) {
+	contactHandler := handler.NewContactHandler(nil, contactService, nil, appLogger)
+
+	r.Route("/contacts", func(r chi.Router) {
+		r.Get("/", contactHandler.ListRegistryContacts)
+		r.Get("/{contactId}", contactHandler.GetRegistryContact)
+	})
+}
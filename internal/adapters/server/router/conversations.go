@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+// setupConversationRoutes stores the dialog state bots keep per chat.
+func setupConversationRoutes(r chi.Router, conversationService *services.ConversationService, appLogger *logger.Logger) {
+	conversationHandler := handler.NewConversationHandler(conversationService, appLogger)
+
+	r.Get("/{sessionName}/chats/{chatJid}/state", conversationHandler.GetState)
+	r.Put("/{sessionName}/chats/{chatJid}/state", conversationHandler.PutState)
+	r.Delete("/{sessionName}/chats/{chatJid}/state", conversationHandler.DeleteState)
+}
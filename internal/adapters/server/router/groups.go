@@ -18,12 +18,15 @@ func setupGroupRoutes(r chi.Router, groupService *services.GroupService, session
 		r.Get("/info", groupHandler.GetGroupInfo)
 
 		r.Post("/participants", groupHandler.UpdateGroupParticipants)
+		r.Post("/{groupJid}/participants/bulk", groupHandler.BulkAddParticipants)
+		r.Get("/{groupJid}/participants/bulk/{jobId}", groupHandler.GetBulkAddJob)
 
 		r.Put("/name", groupHandler.SetGroupName)
 		r.Put("/description", groupHandler.SetGroupDescription)
 		r.Put("/photo", groupHandler.SetGroupPhoto)
 
 		r.Get("/invite-link", groupHandler.GetGroupInviteLink)
+		r.Get("/invite-info", groupHandler.GetGroupInviteInfo)
 		r.Post("/join-via-link", groupHandler.JoinGroupViaLink)
 		r.Post("/leave", groupHandler.LeaveGroup)
 
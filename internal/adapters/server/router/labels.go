@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+func setupLabelRoutes(r chi.Router, labelService *services.LabelService, appLogger *logger.Logger) {
+	labelHandler := handler.NewLabelHandler(labelService, appLogger)
+
+	r.Route("/{sessionName}/labels", func(r chi.Router) {
+
+		r.Get("/", labelHandler.ListLabels)
+		r.Post("/", labelHandler.CreateLabel)
+		r.Put("/{labelId}", labelHandler.UpdateLabel)
+		r.Delete("/{labelId}", labelHandler.DeleteLabel)
+
+		r.Post("/{labelId}/assign", labelHandler.AssignLabel)
+		r.Post("/{labelId}/unassign", labelHandler.UnassignLabel)
+		r.Get("/{labelId}/associations", labelHandler.ListAssociations)
+	})
+}
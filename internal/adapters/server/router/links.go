@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+// setupLinkRoutes reports the clicks on tracked links. The redirect itself
+// is public and lives at /l/{code}.
+func setupLinkRoutes(r chi.Router, linkService *services.LinkService, appLogger *logger.Logger) {
+	linkHandler := handler.NewLinkHandler(linkService, appLogger)
+
+	r.Route("/{sessionName}/links", func(r chi.Router) {
+		r.Get("/stats", linkHandler.GetLinkStats)
+		r.Get("/clicks", linkHandler.ListLinkClicks)
+	})
+}
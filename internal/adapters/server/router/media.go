@@ -8,8 +8,8 @@ import (
 	"zpwoot/platform/logger"
 )
 
-func setupMediaRoutes(r chi.Router, sessionService *services.SessionService, appLogger *logger.Logger) {
-	mediaHandler := handler.NewMediaHandler(sessionService, appLogger)
+func setupMediaRoutes(r chi.Router, sessionService *services.SessionService, mediaService *services.MediaService, appLogger *logger.Logger) {
+	mediaHandler := handler.NewMediaHandler(sessionService, mediaService, appLogger)
 
 	r.Route("/{sessionName}/media", func(r chi.Router) {
 
@@ -20,5 +20,7 @@ func setupMediaRoutes(r chi.Router, sessionService *services.SessionService, app
 		r.Post("/clear-cache", mediaHandler.ClearCache)
 
 		r.Get("/stats", mediaHandler.GetStats)
+
+		r.Get("/{messageId}", mediaHandler.GetMedia)
 	})
 }
@@ -16,33 +16,61 @@ func setupMessageRoutes(r chi.Router, messageService *services.MessageService, s
 	)
 
 	r.Route("/{sessionName}/messages", func(r chi.Router) {
+		// Sends that can be simulated with dryRun=true. DryRun goes first so
+		// a simulated send skips the send queue.
+		r.Group(func(r chi.Router) {
+			r.Use(messageHandler.DryRun)
+			r.Use(messageHandler.SendQueue)
 
-		r.Post("/send/text", messageHandler.SendTextMessage)
-		r.Post("/send/media", messageHandler.SendMediaMessage)
+			r.Post("/send/text", messageHandler.SendTextMessage)
+			r.Post("/send/media", messageHandler.SendMediaMessage)
 
-		r.Post("/send/image", messageHandler.SendImage)
-		r.Post("/send/audio", messageHandler.SendAudio)
-		r.Post("/send/video", messageHandler.SendVideo)
-		r.Post("/send/document", messageHandler.SendDocument)
-		r.Post("/send/sticker", messageHandler.SendSticker)
+			r.Post("/send/image", messageHandler.SendImage)
+			r.Post("/send/audio", messageHandler.SendAudio)
+			r.Post("/send/video", messageHandler.SendVideo)
+			r.Post("/send/document", messageHandler.SendDocument)
+			r.Post("/send/sticker", messageHandler.SendSticker)
 
-		r.Post("/send/location", messageHandler.SendLocation)
-		r.Post("/send/contact", messageHandler.SendContact)
-		r.Post("/send/contact-list", messageHandler.SendContactList)
+			r.Post("/send/location", messageHandler.SendLocation)
+			r.Post("/send/live-location", messageHandler.SendLiveLocation)
+			r.Post("/send/contact", messageHandler.SendContact)
 
-		r.Post("/send/button", messageHandler.SendButton)
-		r.Post("/send/list", messageHandler.SendList)
-		r.Post("/send/poll", messageHandler.SendPoll)
+			r.Post("/send/button", messageHandler.SendButton)
+			r.Post("/send/poll", messageHandler.SendPoll)
+			r.Post("/send/payment", messageHandler.SendPaymentRequest)
+		})
 
-		r.Post("/send/reaction", messageHandler.SendReaction)
-		r.Post("/send/presence", messageHandler.SendPresence)
+		r.Group(func(r chi.Router) {
+			r.Use(messageHandler.SendQueue)
 
-		r.Post("/send/profile/business", messageHandler.SendBusinessProfile)
+			r.Post("/send/contact-list", messageHandler.SendContactList)
+			r.Post("/send/list", messageHandler.SendList)
+
+			r.Post("/send/reaction", messageHandler.SendReaction)
+			r.Post("/send/presence", messageHandler.SendPresence)
+
+			r.Post("/send/profile/business", messageHandler.SendBusinessProfile)
+
+			r.Post("/{messageId}/retry", messageHandler.RetryFailedMessage)
+		})
 
 		r.Post("/edit", messageHandler.EditMessage)
 		r.Post("/revoke", messageHandler.RevokeMessage)
 		r.Post("/mark-read", messageHandler.MarkAsRead)
+		r.Post("/star", messageHandler.StarMessage)
+		r.Post("/unstar", messageHandler.UnstarMessage)
+		r.Post("/pin", messageHandler.PinMessage)
+		r.Post("/unpin", messageHandler.UnpinMessage)
 
+		r.Get("/", messageHandler.ListMessages)
+		r.Get("/search", messageHandler.SearchMessages)
+		r.Get("/starred", messageHandler.ListStarredMessages)
+		r.Get("/scheduled", messageHandler.ListScheduledMessages)
+		r.Delete("/scheduled/{scheduledId}", messageHandler.CancelScheduledMessage)
 		r.Get("/poll/{messageId}/results", messageHandler.GetPollResults)
+		r.Get("/{messageId}", messageHandler.GetMessage)
 	})
+
+	r.Get("/{sessionName}/chats", messageHandler.ListChats)
+	r.Get("/{sessionName}/chats/{chatJid}/live-locations", messageHandler.ListLiveLocations)
 }
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+func setupNewsletterRoutes(r chi.Router, newsletterService *services.NewsletterService, appLogger *logger.Logger) {
+	newsletterHandler := handler.NewNewsletterHandler(newsletterService, appLogger)
+
+	r.Route("/{sessionName}/newsletters", func(r chi.Router) {
+
+		r.Post("/forward", newsletterHandler.ForwardMessage)
+		r.Post("/{newsletterJid}/messages", newsletterHandler.Post)
+
+		// Channel administration
+		r.Post("/{newsletterJid}/admins/invites", newsletterHandler.InviteAdmin)
+		r.Post("/{newsletterJid}/admins/invites/accept", newsletterHandler.AcceptAdminInvite)
+		r.Delete("/{newsletterJid}/admins/invites/{user}", newsletterHandler.RevokeAdminInvite)
+		r.Delete("/{newsletterJid}/admins/{user}", newsletterHandler.DemoteAdmin)
+		r.Put("/{newsletterJid}/owner", newsletterHandler.TransferOwnership)
+	})
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+func setupNoteRoutes(r chi.Router, noteService *services.NoteService, appLogger *logger.Logger) {
+	noteHandler := handler.NewNoteHandler(noteService, appLogger)
+
+	r.Route("/{sessionName}/notes", func(r chi.Router) {
+
+		r.Get("/", noteHandler.ListNotes)
+		r.Post("/", noteHandler.CreateNote)
+		r.Put("/{noteId}", noteHandler.UpdateNote)
+		r.Delete("/{noteId}", noteHandler.DeleteNote)
+	})
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+	"zpwoot/internal/services"
+	"zpwoot/platform/logger"
+)
+
+func setupProfileRoutes(r chi.Router, profileService *services.ProfileService, appLogger *logger.Logger) {
+	profileHandler := handler.NewProfileHandler(profileService, appLogger)
+
+	r.Route("/{sessionName}/profile", func(r chi.Router) {
+
+		r.Get("/business", profileHandler.GetBusinessProfile)
+		r.Put("/business", profileHandler.UpdateBusinessProfile)
+	})
+}
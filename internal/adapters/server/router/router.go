@@ -1,32 +1,51 @@
 package router
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"zpwoot/internal/adapters/fakewa"
+	"zpwoot/internal/adapters/server/handler"
 	"zpwoot/internal/adapters/server/middleware"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/webhook"
 	"zpwoot/internal/services"
 	"zpwoot/platform/config"
+	"zpwoot/platform/database"
 	"zpwoot/platform/logger"
+	"zpwoot/platform/metrics"
+	"zpwoot/platform/secrets"
 )
 
-func SetupRoutes(cfg *config.Config, logger *logger.Logger, sessionService *services.SessionService, messageService *services.MessageService, groupService *services.GroupService) http.Handler {
+func SetupRoutes(cfg *config.Config, reloader *config.Reloader, logger *logger.Logger, sessionService *services.SessionService, messageService *services.MessageService, groupService *services.GroupService, contactService *services.ContactService, mediaService *services.MediaService, auditService *services.AuditService, webhookService *services.WebhookService, labelService *services.LabelService, newsletterService *services.NewsletterService, profileService *services.ProfileService, noteService *services.NoteService, linkService *services.LinkService, conversationService *services.ConversationService, chatwootService *services.ChatwootService, tenantService *services.TenantService, pipeline *inbound.Pipeline, sendMetrics *session.SendMetrics, mediaPool *messaging.MediaPool, secretsRotator secrets.Rotator, sli *metrics.SLI, db *database.Database, fakeGateway *fakewa.Gateway) http.Handler {
 	r := chi.NewRouter()
 
-	setupMiddlewares(r, cfg, logger)
+	setupMiddlewares(r, cfg, logger, auditService, tenantService, sli)
 
 	setupSwaggerRoutes(r)
 
 	setupHealthRoutes(r)
 
-	setupAllRoutes(r, logger, sessionService, messageService, groupService)
+	if sli != nil {
+		setupMetricsRoutes(r, sli)
+	}
+
+	setupAllRoutes(r, reloader, logger, sessionService, messageService, groupService, contactService, mediaService, auditService, webhookService, labelService, newsletterService, profileService, noteService, linkService, conversationService, chatwootService, tenantService, pipeline, sendMetrics, mediaPool, secretsRotator, db, fakeGateway)
 
 	return r
 }
 
-func setupAllRoutes(r *chi.Mux, appLogger *logger.Logger, sessionService *services.SessionService, messageService *services.MessageService, groupService *services.GroupService) {
+func setupAllRoutes(r *chi.Mux, reloader *config.Reloader, appLogger *logger.Logger, sessionService *services.SessionService, messageService *services.MessageService, groupService *services.GroupService, contactService *services.ContactService, mediaService *services.MediaService, auditService *services.AuditService, webhookService *services.WebhookService, labelService *services.LabelService, newsletterService *services.NewsletterService, profileService *services.ProfileService, noteService *services.NoteService, linkService *services.LinkService, conversationService *services.ConversationService, chatwootService *services.ChatwootService, tenantService *services.TenantService, pipeline *inbound.Pipeline, sendMetrics *session.SendMetrics, mediaPool *messaging.MediaPool, secretsRotator secrets.Rotator, db *database.Database, fakeGateway *fakewa.Gateway) {
+	chatwootHandler := handler.NewChatwootHandler(messageService, sessionService, chatwootService, appLogger)
+
 	r.Route("/sessions", func(r chi.Router) {
 
 		setupSessionRoutes(r, sessionService, appLogger)
@@ -35,16 +54,41 @@ func setupAllRoutes(r *chi.Mux, appLogger *logger.Logger, sessionService *servic
 
 		setupGroupRoutes(r, groupService, sessionService, appLogger)
 
-		setupContactRoutes(r, sessionService, appLogger)
+		setupContactRoutes(r, contactService, sessionService, appLogger)
+
+		setupWebhookRoutes(r, webhookService, appLogger)
+
+		setupLabelRoutes(r, labelService, appLogger)
+
+		setupNewsletterRoutes(r, newsletterService, appLogger)
+
+		setupProfileRoutes(r, profileService, appLogger)
+
+		setupNoteRoutes(r, noteService, appLogger)
 
-		setupWebhookRoutes(r, sessionService, appLogger)
+		setupLinkRoutes(r, linkService, appLogger)
+		setupConversationRoutes(r, conversationService, appLogger)
 
-		setupMediaRoutes(r, sessionService, appLogger)
+		setupMediaRoutes(r, sessionService, mediaService, appLogger)
 
-		setupChatwootRoutes(r, messageService, sessionService, appLogger)
+		setupChatwootRoutes(r, chatwootHandler)
 	})
 
+	r.Post("/chatwoot/webhook", chatwootHandler.ReceiveWebhook)
+
+	setupRegistryRoutes(r, contactService, appLogger)
+
+	r.Get("/media/public/{token}", handler.NewMediaHandler(sessionService, mediaService, appLogger).GetHostedMedia)
+
+	r.Get("/l/{code}", handler.NewLinkHandler(linkService, appLogger).FollowLink)
+
 	setupGlobalRoutes(r, appLogger)
+
+	setupAdminRoutes(r, reloader, auditService, pipeline, sendMetrics, mediaPool, secretsRotator, db, chatwootHandler, handler.NewTenantHandler(tenantService, appLogger), handler.NewSessionHandler(sessionService, appLogger), appLogger)
+
+	if fakeGateway != nil {
+		setupTestingRoutes(r, handler.NewTestingHandler(fakeGateway, appLogger))
+	}
 }
 
 func setupHealthRoutes(r *chi.Mux) {
@@ -55,30 +99,83 @@ func setupHealthRoutes(r *chi.Mux) {
 	})
 }
 
+// setupMetricsRoutes serves the SLI series for Prometheus to scrape. Like
+// every route outside the public ones, it needs the global API key.
+func setupMetricsRoutes(r *chi.Mux, sli *metrics.SLI) {
+	r.Get("/metrics", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_ = sli.WritePrometheus(w, time.Now())
+	})
+}
+
 func setupGlobalRoutes(r *chi.Mux, appLogger *logger.Logger) {
 
 	r.Get("/webhook/events", func(w http.ResponseWriter, req *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"events":["message","session","contact","group"]}`))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"events": webhook.AllCategories,
+			"types":  webhook.EventTypes,
+		})
 	})
 
 }
 
-func setupMiddlewares(r *chi.Mux, cfg *config.Config, logger *logger.Logger) {
+func setupMiddlewares(r *chi.Mux, cfg *config.Config, logger *logger.Logger, auditService *services.AuditService, tenantService *services.TenantService, sli *metrics.SLI) {
 
 	r.Use(middleware.ErrorLogger(logger))
 
+	r.Use(middleware.Tracing())
+
+	if sli != nil {
+		r.Use(middleware.SLI(sli))
+	}
+
 	r.Use(middleware.HTTPLogger(logger))
 
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: false,
-		MaxAge:           300,
+		AllowedOrigins:   trimAll(cfg.Server.CORS.AllowedOrigins),
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   trimAll(cfg.Server.CORS.AllowedHeaders),
+		ExposedHeaders:   trimAll(cfg.Server.CORS.ExposedHeaders),
+		AllowCredentials: cfg.Server.CORS.AllowCredentials,
+		MaxAge:           cfg.Server.CORS.MaxAge,
 	}))
 
-	r.Use(middleware.APIKeyAuth(cfg, logger))
+	// Only textual responses are compressed: media is compressed already and
+	// event streams must reach the client as each event is written.
+	if cfg.Server.Compression {
+		r.Use(chimiddleware.Compress(cfg.Server.CompressionLevel,
+			"application/json", "text/plain", "text/html", "text/csv", "text/css", "application/javascript", "image/svg+xml"))
+	}
+
+	r.Use(middleware.Language())
+
+	var tenants middleware.TenantAuthenticator
+	if tenantService != nil {
+		tenants = tenantService
+	}
+	r.Use(middleware.APIKeyAuth(cfg, tenants, logger))
+
+	if tenants != nil {
+		r.Use(middleware.TenantScope(tenants, logger))
+	}
+
+	if auditService != nil {
+		r.Use(middleware.AuditLog(auditService))
+	}
+
+	r.Use(middleware.RequestTimeout(cfg))
+}
+
+// trimAll drops the blanks around comma-separated config values.
+func trimAll(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
 }
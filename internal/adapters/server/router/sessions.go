@@ -14,6 +14,8 @@ func setupSessionRoutes(r chi.Router, sessionService *services.SessionService, a
 	// Session management routes
 	r.Post("/create", sessionHandler.CreateSession)
 	r.Get("/list", sessionHandler.ListSessions)
+	r.Post("/import", sessionHandler.ImportSession)
+	r.Post("/bulk", sessionHandler.BulkCreateSessions)
 
 	// Session-specific routes using session name (e.g., /sessions/my-session/info)
 	r.Get("/{sessionName}/info", sessionHandler.GetSessionInfo)
@@ -23,12 +25,55 @@ func setupSessionRoutes(r chi.Router, sessionService *services.SessionService, a
 	r.Post("/{sessionName}/connect", sessionHandler.ConnectSession)
 	r.Post("/{sessionName}/logout", sessionHandler.LogoutSession)
 	r.Get("/{sessionName}/qr", sessionHandler.GetQRCode)
+	r.Get("/{sessionName}/qr.png", sessionHandler.GetQRCodePNG)
+	r.Get("/{sessionName}/qr.svg", sessionHandler.GetQRCodeSVG)
 	r.Post("/{sessionName}/pair", sessionHandler.PairPhone)
+	r.Post("/{sessionName}/pairing/cancel", sessionHandler.CancelPairing)
+
+	// Click-to-chat links to the session's number
+	r.Get("/{sessionName}/chat-link", sessionHandler.GetChatLink)
+	r.Get("/{sessionName}/chat-link/qr.png", sessionHandler.GetChatLinkPNG)
+	r.Get("/{sessionName}/chat-link/qr.svg", sessionHandler.GetChatLinkSVG)
 
 	// Proxy configuration
 	r.Post("/{sessionName}/proxy/set", sessionHandler.SetProxy)
 	r.Get("/{sessionName}/proxy/find", sessionHandler.GetProxy)
 
+	// Session settings
+	r.Get("/{sessionName}/settings", sessionHandler.GetSettings)
+	r.Put("/{sessionName}/settings/calls", sessionHandler.SetCallSettings)
+	r.Put("/{sessionName}/settings/media", sessionHandler.SetMediaSettings)
+	r.Put("/{sessionName}/settings/quiet-hours", sessionHandler.SetQuietHours)
+	r.Put("/{sessionName}/settings/media-policy", sessionHandler.SetMediaPolicy)
+	r.Put("/{sessionName}/settings/footer", sessionHandler.SetFooter)
+	r.Put("/{sessionName}/settings/text-format", sessionHandler.SetTextFormat)
+	r.Put("/{sessionName}/settings/chatwoot", sessionHandler.SetChatwoot)
+	r.Get("/{sessionName}/settings/warm-up", sessionHandler.GetWarmUpStatus)
+	r.Put("/{sessionName}/settings/warm-up", sessionHandler.SetWarmUp)
+	r.Put("/{sessionName}/settings/retention", sessionHandler.SetRetention)
+	r.Put("/{sessionName}/settings/policy", sessionHandler.SetPolicy)
+	r.Put("/{sessionName}/settings/timezone", sessionHandler.SetTimezone)
+	r.Put("/{sessionName}/settings/sandbox", sessionHandler.SetSandbox)
+	r.Put("/{sessionName}/settings/pairing", sessionHandler.SetPairing)
+	r.Patch("/{sessionName}/settings/features", sessionHandler.SetFeatures)
+	r.Put("/{sessionName}/settings/group-rules/{groupJid}", sessionHandler.SetGroupRules)
+	r.Delete("/{sessionName}/settings/group-rules/{groupJid}", sessionHandler.DeleteGroupRules)
+	r.Put("/{sessionName}/settings/inbound-filter", sessionHandler.SetInboundFilter)
+	r.Delete("/{sessionName}/settings/inbound-filter", sessionHandler.DeleteInboundFilter)
+
+	// Credentials backup
+	r.Post("/{sessionName}/export", sessionHandler.ExportSession)
+
 	// Statistics
 	r.Get("/{sessionName}/stats", sessionHandler.GetSessionStats)
+	r.Get("/{sessionName}/uptime", sessionHandler.GetUptime)
+
+	// Messages the inbound filter matched
+	r.Get("/{sessionName}/filter/log", sessionHandler.ListFilteredMessages)
+
+	// Devices under the account
+	r.Get("/{sessionName}/devices", sessionHandler.ListDevices)
+
+	// Protocol debug capture, switched on under /admin
+	r.Get("/{sessionName}/debug/logs", sessionHandler.GetDebugLogs)
 }
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+)
+
+// setupTenantRoutes manages tenants, their API keys and session ownership.
+func setupTenantRoutes(r chi.Router, tenantHandler *handler.TenantHandler) {
+	r.Route("/tenants", func(r chi.Router) {
+		r.Get("/", tenantHandler.ListTenants)
+		r.Post("/", tenantHandler.CreateTenant)
+		r.Get("/{tenantId}", tenantHandler.GetTenant)
+		r.Put("/{tenantId}", tenantHandler.UpdateTenant)
+		r.Delete("/{tenantId}", tenantHandler.DeleteTenant)
+
+		r.Get("/{tenantId}/keys", tenantHandler.ListKeys)
+		r.Post("/{tenantId}/keys", tenantHandler.CreateKey)
+		r.Delete("/{tenantId}/keys/{keyId}", tenantHandler.RevokeKey)
+
+		r.Put("/{tenantId}/sessions/{sessionName}", tenantHandler.AssignSession)
+		r.Delete("/{tenantId}/sessions/{sessionName}", tenantHandler.ReleaseSession)
+	})
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"zpwoot/internal/adapters/server/handler"
+)
+
+// setupTestingRoutes scripts the fake WhatsApp gateway. Only mounted in test
+// mode.
+func setupTestingRoutes(r chi.Router, testingHandler *handler.TestingHandler) {
+	r.Route("/test/sessions/{sessionName}", func(r chi.Router) {
+		r.Post("/events", testingHandler.InjectEvents)
+		r.Post("/pair", testingHandler.PairSession)
+		r.Get("/sent", testingHandler.ListSent)
+		r.Delete("/sent", testingHandler.ClearSent)
+	})
+}
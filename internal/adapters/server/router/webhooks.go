@@ -8,8 +8,8 @@ import (
 	"zpwoot/platform/logger"
 )
 
-func setupWebhookRoutes(r chi.Router, sessionService *services.SessionService, appLogger *logger.Logger) {
-	webhookHandler := handler.NewWebhookHandler(sessionService, appLogger)
+func setupWebhookRoutes(r chi.Router, webhookService *services.WebhookService, appLogger *logger.Logger) {
+	webhookHandler := handler.NewWebhookHandler(webhookService, appLogger)
 
 	r.Route("/{sessionName}/webhook", func(r chi.Router) {
 
@@ -18,4 +18,13 @@ func setupWebhookRoutes(r chi.Router, sessionService *services.SessionService, a
 
 		r.Post("/test", webhookHandler.TestWebhook)
 	})
+
+	r.Route("/{sessionName}/webhooks", func(r chi.Router) {
+
+		r.Get("/", webhookHandler.ListWebhooks)
+		r.Post("/", webhookHandler.CreateWebhook)
+		r.Put("/{webhookId}", webhookHandler.UpdateWebhook)
+		r.Delete("/{webhookId}", webhookHandler.DeleteWebhook)
+		r.Post("/{webhookId}/test", webhookHandler.TestWebhookByID)
+	})
 }
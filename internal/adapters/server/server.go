@@ -6,36 +6,103 @@ import (
 	"net/http"
 	"time"
 
+	"zpwoot/internal/adapters/fakewa"
 	"zpwoot/internal/adapters/server/router"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
 	"zpwoot/internal/services"
 	"zpwoot/platform/config"
+	"zpwoot/platform/database"
 	"zpwoot/platform/logger"
+	"zpwoot/platform/metrics"
+	"zpwoot/platform/secrets"
 )
 
 type Server struct {
-	config         *config.Config
-	logger         *logger.Logger
-	httpServer     *http.Server
-	sessionService *services.SessionService
-	messageService *services.MessageService
-	groupService   *services.GroupService
+	config              *config.Config
+	reloader            *config.Reloader
+	logger              *logger.Logger
+	httpServer          *http.Server
+	sessionService      *services.SessionService
+	messageService      *services.MessageService
+	groupService        *services.GroupService
+	contactService      *services.ContactService
+	mediaService        *services.MediaService
+	auditService        *services.AuditService
+	webhookService      *services.WebhookService
+	labelService        *services.LabelService
+	newsletterService   *services.NewsletterService
+	profileService      *services.ProfileService
+	noteService         *services.NoteService
+	linkService         *services.LinkService
+	conversationService *services.ConversationService
+	chatwootService     *services.ChatwootService
+	tenantService       *services.TenantService
+	pipeline            *inbound.Pipeline
+	sendMetrics         *session.SendMetrics
+	mediaPool           *messaging.MediaPool
+	secretsRotator      secrets.Rotator
+	sli                 *metrics.SLI
+	database            *database.Database
+	fakeGateway         *fakewa.Gateway
 }
 
 type Config struct {
-	Config         *config.Config
-	Logger         *logger.Logger
-	SessionService *services.SessionService
-	MessageService *services.MessageService
-	GroupService   *services.GroupService
+	Config              *config.Config
+	Reloader            *config.Reloader
+	Logger              *logger.Logger
+	SessionService      *services.SessionService
+	MessageService      *services.MessageService
+	GroupService        *services.GroupService
+	ContactService      *services.ContactService
+	MediaService        *services.MediaService
+	AuditService        *services.AuditService
+	WebhookService      *services.WebhookService
+	LabelService        *services.LabelService
+	NewsletterService   *services.NewsletterService
+	ProfileService      *services.ProfileService
+	NoteService         *services.NoteService
+	LinkService         *services.LinkService
+	ConversationService *services.ConversationService
+	ChatwootService     *services.ChatwootService
+	TenantService       *services.TenantService
+	Pipeline            *inbound.Pipeline
+	SendMetrics         *session.SendMetrics
+	MediaPool           *messaging.MediaPool
+	SecretsRotator      secrets.Rotator
+	SLI                 *metrics.SLI
+	Database            *database.Database
+	FakeGateway         *fakewa.Gateway
 }
 
 func New(cfg *Config) *Server {
 	return &Server{
-		config:         cfg.Config,
-		logger:         cfg.Logger,
-		sessionService: cfg.SessionService,
-		messageService: cfg.MessageService,
-		groupService:   cfg.GroupService,
+		config:              cfg.Config,
+		reloader:            cfg.Reloader,
+		logger:              cfg.Logger,
+		sessionService:      cfg.SessionService,
+		messageService:      cfg.MessageService,
+		groupService:        cfg.GroupService,
+		contactService:      cfg.ContactService,
+		mediaService:        cfg.MediaService,
+		auditService:        cfg.AuditService,
+		webhookService:      cfg.WebhookService,
+		labelService:        cfg.LabelService,
+		newsletterService:   cfg.NewsletterService,
+		profileService:      cfg.ProfileService,
+		noteService:         cfg.NoteService,
+		linkService:         cfg.LinkService,
+		conversationService: cfg.ConversationService,
+		chatwootService:     cfg.ChatwootService,
+		tenantService:       cfg.TenantService,
+		pipeline:            cfg.Pipeline,
+		sendMetrics:         cfg.SendMetrics,
+		mediaPool:           cfg.MediaPool,
+		secretsRotator:      cfg.SecretsRotator,
+		sli:                 cfg.SLI,
+		database:            cfg.Database,
+		fakeGateway:         cfg.FakeGateway,
 	}
 }
 
@@ -43,29 +110,45 @@ func (s *Server) Start(ctx context.Context) error {
 
 	handler := router.SetupRoutes(
 		s.config,
+		s.reloader,
 		s.logger,
 		s.sessionService,
 		s.messageService,
 		s.groupService,
+		s.contactService,
+		s.mediaService,
+		s.auditService,
+		s.webhookService,
+		s.labelService,
+		s.newsletterService,
+		s.profileService,
+		s.noteService,
+		s.linkService,
+		s.conversationService,
+		s.chatwootService,
+		s.tenantService,
+		s.pipeline,
+		s.sendMetrics,
+		s.mediaPool,
+		s.secretsRotator,
+		s.sli,
+		s.database,
+		s.fakeGateway,
 	)
 
-	s.httpServer = &http.Server{
-		Addr:         fmt.Sprintf(":%d", s.config.Server.Port),
-		Handler:      handler,
-		ReadTimeout:  time.Duration(s.config.Server.ReadTimeout) * time.Second,
-		WriteTimeout: time.Duration(s.config.Server.WriteTimeout) * time.Second,
-		IdleTimeout:  time.Duration(s.config.Server.IdleTimeout) * time.Second,
-	}
+	s.httpServer = NewHTTPServer(s.config, handler)
 
 	s.logger.InfoWithFields("Starting HTTP server", map[string]interface{}{
 		"port":          s.config.Server.Port,
 		"read_timeout":  s.config.Server.ReadTimeout,
 		"write_timeout": s.config.Server.WriteTimeout,
 		"idle_timeout":  s.config.Server.IdleTimeout,
+		"tls":           s.config.Server.TLSEnabled(),
+		"http2":         s.config.Server.HTTP2,
 	})
 
 	go func() {
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := ListenAndServe(s.httpServer, s.config); err != nil && err != http.ErrServerClosed {
 			s.logger.ErrorWithFields("HTTP server failed", map[string]interface{}{
 				"error": err.Error(),
 			})
@@ -103,10 +186,30 @@ func (s *Server) Stop(ctx context.Context) error {
 func (s *Server) Handler() http.Handler {
 	return router.SetupRoutes(
 		s.config,
+		s.reloader,
 		s.logger,
 		s.sessionService,
 		s.messageService,
 		s.groupService,
+		s.contactService,
+		s.mediaService,
+		s.auditService,
+		s.webhookService,
+		s.labelService,
+		s.newsletterService,
+		s.profileService,
+		s.noteService,
+		s.linkService,
+		s.conversationService,
+		s.chatwootService,
+		s.tenantService,
+		s.pipeline,
+		s.sendMetrics,
+		s.mediaPool,
+		s.secretsRotator,
+		s.sli,
+		s.database,
+		s.fakeGateway,
 	)
 }
 
@@ -1,15 +1,27 @@
 package shared
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/conversation"
+	"zpwoot/internal/core/linktrack"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/schedule"
 	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/internal/core/webhook"
 	"zpwoot/internal/services/shared/validation"
 	"zpwoot/platform/logger"
 )
@@ -141,17 +153,40 @@ func (h *BaseHandler) ParseJSONBody(r *http.Request, dest interface{}) error {
 	return nil
 }
 
-func (h *BaseHandler) ParseAndValidateJSON(r *http.Request, dest interface{}) error {
-
+// DecodeAndValidate decodes the JSON body into dest and checks its validate
+// tags. On failure it writes the 400 response itself, with one entry per
+// rejected field, and returns false.
+func (h *BaseHandler) DecodeAndValidate(w http.ResponseWriter, r *http.Request, dest interface{}) bool {
 	if err := h.ParseJSONBody(r, dest); err != nil {
-		return err
+		h.writer.WriteErrorWithCode(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request format", err.Error())
+		return false
 	}
 
 	if err := h.validator.ValidateStruct(dest); err != nil {
-		return err
+		h.writeValidationError(w, err)
+		return false
 	}
 
-	return nil
+	return true
+}
+
+func (h *BaseHandler) writeValidationError(w http.ResponseWriter, err error) {
+	var fields validation.Errors
+	if !errors.As(err, &fields) {
+		h.writer.WriteErrorWithCode(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	details := make([]ValidationError, len(fields))
+	for i, field := range fields {
+		details[i] = ValidationError{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+		}
+	}
+
+	h.writer.WriteValidationError(w, details)
 }
 
 func (h *BaseHandler) GetPaginationParams(r *http.Request) (limit, offset int, err error) {
@@ -183,24 +218,284 @@ func (h *BaseHandler) HandleError(w http.ResponseWriter, err error, operation st
 		"error": err.Error(),
 	})
 
+	if isTimeoutError(err) {
+		h.writer.WriteGatewayTimeout(w, fmt.Sprintf("Timed out while trying to %s", operation))
+		return
+	}
+
+	var fields validation.Errors
+	if errors.As(err, &fields) {
+		h.writeValidationError(w, err)
+		return
+	}
+
+	if h.writeCodedError(w, err) {
+		return
+	}
+
 	statusCode := h.getStatusCodeFromError(err)
 	message := h.getMessageFromError(err, operation)
 
 	h.writer.WriteError(w, statusCode, message)
 }
 
+// WriteServiceError keeps the handler's own 500 message but still surfaces
+// operation timeouts as 504 so callers can retry, and session policy
+// rejections with their own codes.
+func (h *BaseHandler) WriteServiceError(w http.ResponseWriter, err error, message string) {
+	if isTimeoutError(err) {
+		h.writer.WriteGatewayTimeout(w, message)
+		return
+	}
+
+	if h.writeCodedError(w, err) {
+		return
+	}
+
+	h.writer.WriteInternalError(w, message)
+}
+
+// writeCodedError answers errors from per-session policies, which carry a
+// machine-readable code so clients can tell them apart from failures.
+func (h *BaseHandler) writeCodedError(w http.ResponseWriter, err error) bool {
+	var quiet *session.QuietHoursError
+	var warmUp *session.WarmUpLimitError
+	var queueFull *session.SendQueueFullError
+	var throttled *session.SessionThrottledError
+	var inboxConflict *chatwoot.ConflictError
+	var quota *tenant.QuotaError
+	var recipient *messaging.RecipientError
+	var failed *schedule.FailedSendError
+	var denied *session.PolicyError
+	var banned *session.SessionBannedError
+	var disabled *session.FeatureDisabledError
+	var payments *session.PaymentsNotSupportedError
+	var groupRule *session.GroupRuleError
+	var mediaQueue *messaging.MediaQueueFullError
+	var stateConflict *conversation.VersionConflictError
+	var unconfirmed *session.SendUnconfirmedError
+	var capacity *session.CapacityError
+	switch {
+	case errors.As(err, &quiet):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "QUIET_HOURS", "Session is in quiet hours", map[string]interface{}{
+			"resumeAt": quiet.ResumeAt,
+		})
+	case errors.As(err, &warmUp):
+		if wait := time.Until(warmUp.ResumeAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+		}
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "WARMUP_LIMIT", "Session reached its warm-up daily limit", map[string]interface{}{
+			"limit":    warmUp.Limit,
+			"resumeAt": warmUp.ResumeAt,
+		})
+	case errors.As(err, &throttled):
+		retryAfter := int(time.Until(throttled.Throttle.Until).Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "SESSION_THROTTLED", "Session is cooling down after a WhatsApp rate limit", map[string]interface{}{
+			"reason":  throttled.Throttle.Reason,
+			"strikes": throttled.Throttle.Strikes,
+			"until":   throttled.Throttle.Until,
+		})
+	case errors.As(err, &banned):
+		if banned.Ban.Until != nil {
+			if wait := time.Until(*banned.Ban.Until); wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			}
+		}
+		h.writer.WriteErrorWithCode(w, http.StatusForbidden, "SESSION_BANNED", "Session account is banned or restricted by WhatsApp", map[string]interface{}{
+			"kind":   banned.Ban.Kind,
+			"reason": banned.Ban.Reason,
+			"code":   banned.Ban.Code,
+			"since":  banned.Ban.Since,
+			"until":  banned.Ban.Until,
+		})
+	case errors.As(err, &queueFull):
+		retryAfter := int(queueFull.RetryAfter.Seconds()) + 1
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "SEND_QUEUE_FULL", "Session send queue is full", map[string]interface{}{
+			"depth":             queueFull.Depth,
+			"limit":             queueFull.Limit,
+			"retryAfterSeconds": retryAfter,
+		})
+	case errors.As(err, &capacity):
+		h.writer.WriteErrorWithCode(w, http.StatusServiceUnavailable, "INSTANCE_AT_CAPACITY", "Instance reached its maximum of connected sessions", map[string]interface{}{
+			"limit":     capacity.Limit,
+			"connected": capacity.Connected,
+		})
+	case errors.As(err, &mediaQueue):
+		w.Header().Set("Retry-After", "1")
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "MEDIA_QUEUE_FULL", "Session media processing queue is full", map[string]interface{}{
+			"limit": mediaQueue.Limit,
+		})
+	case errors.As(err, &inboxConflict):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "CHATWOOT_INBOX_CONFLICT", err.Error(), map[string]interface{}{
+			"accountId": inboxConflict.Existing.AccountID,
+			"inboxId":   inboxConflict.Existing.InboxID,
+			"sessionId": inboxConflict.Existing.SessionID,
+		})
+	case errors.Is(err, chatwoot.ErrInboxConflict):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "CHATWOOT_INBOX_CONFLICT", err.Error())
+	case errors.As(err, &quota) && errors.Is(err, tenant.ErrSessionLimit):
+		h.writer.WriteErrorWithCode(w, http.StatusForbidden, "TENANT_SESSION_LIMIT", "Tenant reached its session limit", map[string]interface{}{
+			"limit": quota.Limit,
+		})
+	case errors.As(err, &quota):
+		if wait := time.Until(quota.ResumeAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+		}
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "TENANT_MESSAGE_LIMIT", "Tenant reached its daily message limit", map[string]interface{}{
+			"limit":    quota.Limit,
+			"resumeAt": quota.ResumeAt,
+		})
+	case errors.As(err, &stateConflict):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "STATE_VERSION_CONFLICT", "Conversation state was changed by another write", map[string]interface{}{
+			"expected": stateConflict.Expected,
+			"current":  stateConflict.Current,
+		})
+	case errors.Is(err, tenant.ErrSessionOwned):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "TENANT_SESSION_OWNED", err.Error())
+	case errors.Is(err, tenant.ErrTenantNameTaken):
+		h.writer.WriteErrorWithCode(w, http.StatusConflict, "TENANT_NAME_TAKEN", err.Error())
+	case errors.As(err, &recipient) && errors.Is(err, messaging.ErrRecipientNotOnWhatsApp):
+		h.writer.WriteErrorWithCode(w, http.StatusUnprocessableEntity, "RECIPIENT_NOT_ON_WHATSAPP", "Recipient is not on WhatsApp", map[string]interface{}{
+			"recipient": recipient.Recipient,
+		})
+	case errors.As(err, &recipient):
+		h.writer.WriteErrorWithCode(w, http.StatusBadRequest, "INVALID_RECIPIENT", "Recipient is not a valid WhatsApp JID or phone number", map[string]interface{}{
+			"recipient": recipient.Recipient,
+		})
+	case errors.As(err, &denied):
+		h.writer.WriteErrorWithCode(w, http.StatusForbidden, "SESSION_POLICY", "Send is not allowed by the session policy", map[string]interface{}{
+			"mode":      denied.Mode,
+			"recipient": denied.Recipient,
+		})
+	case errors.As(err, &disabled):
+		h.writer.WriteErrorWithCode(w, http.StatusForbidden, "FEATURE_DISABLED", "Feature is disabled for this session", map[string]interface{}{
+			"feature": disabled.Feature,
+		})
+	case errors.As(err, &groupRule) && groupRule.Rule == session.GroupRuleRate:
+		if wait := time.Until(groupRule.ResumeAt); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+		}
+		h.writer.WriteErrorWithCode(w, http.StatusTooManyRequests, "GROUP_RULE", "Send breaks the group's posting rules", map[string]interface{}{
+			"group":    groupRule.Group,
+			"rule":     groupRule.Rule,
+			"limit":    groupRule.Detail,
+			"resumeAt": groupRule.ResumeAt,
+		})
+	case errors.As(err, &groupRule):
+		h.writer.WriteErrorWithCode(w, http.StatusForbidden, "GROUP_RULE", "Send breaks the group's posting rules", map[string]interface{}{
+			"group":  groupRule.Group,
+			"rule":   groupRule.Rule,
+			"detail": groupRule.Detail,
+		})
+	case errors.As(err, &payments):
+		h.writer.WriteErrorWithCode(w, http.StatusUnprocessableEntity, "NOT_SUPPORTED", "Payment requests are not supported for this account", map[string]interface{}{
+			"currency":            payments.Requested,
+			"accountCurrency":     payments.Currency,
+			"supportedCurrencies": session.PaymentCurrencies(),
+		})
+	case errors.Is(err, session.ErrMediaTooLarge):
+		h.writer.WriteErrorWithCode(w, http.StatusRequestEntityTooLarge, "MEDIA_TOO_LARGE", policyMessage(err))
+	case errors.Is(err, session.ErrMediaTypeNotAllowed):
+		h.writer.WriteErrorWithCode(w, http.StatusUnsupportedMediaType, "MEDIA_TYPE_NOT_ALLOWED", policyMessage(err))
+	case errors.As(err, &failed):
+		details := map[string]interface{}{
+			"failedId": failed.ID,
+			"error":    failed.Err.Error(),
+		}
+		if errors.As(failed.Err, &unconfirmed) {
+			details["messageId"] = unconfirmed.MessageID
+		}
+		h.writer.WriteErrorWithCode(w, http.StatusBadGateway, "SEND_FAILED", "Send failed and was kept for retry", details)
+	case errors.As(err, &unconfirmed):
+		h.writer.WriteErrorWithCode(w, http.StatusGatewayTimeout, "SEND_UNCONFIRMED", "Send was not confirmed by WhatsApp and may still be delivered", map[string]interface{}{
+			"messageId": unconfirmed.MessageID,
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// policyMessage drops the wrapping added by services, keeping the policy
+// error and its detail.
+func policyMessage(err error) string {
+	message := err.Error()
+	for _, target := range []error{session.ErrMediaTooLarge, session.ErrMediaTypeNotAllowed} {
+		if i := strings.Index(message, target.Error()); i >= 0 {
+			return message[i:]
+		}
+	}
+	return message
+}
+
+// isTimeoutError leaves out sends that timed out after they may have gone
+// out: writeCodedError answers those with the IDs to retry them under.
+func isTimeoutError(err error) bool {
+	var failed *schedule.FailedSendError
+	var unconfirmed *session.SendUnconfirmedError
+	if errors.As(err, &failed) || errors.As(err, &unconfirmed) {
+		return false
+	}
+	return errors.Is(err, session.ErrOperationTimeout) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
 func (h *BaseHandler) getStatusCodeFromError(err error) int {
 	switch {
 	case err == session.ErrSessionNotFound:
 		return http.StatusNotFound
 	case err == session.ErrSessionAlreadyExists:
 		return http.StatusConflict
-	case err == session.ErrSessionAlreadyConnected:
+	case errors.Is(err, session.ErrSessionAlreadyConnected):
 		return http.StatusConflict
 	case err == session.ErrInvalidSessionName:
 		return http.StatusBadRequest
 	case err == session.ErrInvalidProxyConfig:
 		return http.StatusBadRequest
+	case errors.Is(err, session.ErrInvalidPassphrase):
+		return http.StatusUnauthorized
+	case errors.Is(err, session.ErrInvalidBackup), errors.Is(err, session.ErrUnsupportedBackup):
+		return http.StatusBadRequest
+	case errors.Is(err, session.ErrSessionNotPaired):
+		return http.StatusConflict
+	case errors.Is(err, session.ErrSessionNotConnected):
+		return http.StatusConflict
+	case errors.Is(err, session.ErrDeviceAlreadyImported):
+		return http.StatusConflict
+	case errors.Is(err, session.ErrQRCodeNotAvailable):
+		return http.StatusNotFound
+	case errors.Is(err, session.ErrQRCodeExpired):
+		return http.StatusGone
+	case errors.Is(err, session.ErrNoPairingPending):
+		return http.StatusConflict
+	case errors.Is(err, session.ErrNoDebugCapture):
+		return http.StatusNotFound
+	case errors.Is(err, linktrack.ErrLinkNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, conversation.ErrStateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, messaging.ErrMessageHasNoMedia):
+		return http.StatusNotFound
+	case errors.Is(err, messaging.ErrMediaExpired):
+		return http.StatusGone
+	case errors.Is(err, messaging.ErrMediaQuarantined):
+		return http.StatusForbidden
+	case errors.Is(err, messaging.ErrHostedMediaNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, messaging.ErrNotNewsletterAdmin), errors.Is(err, messaging.ErrNotNewsletterOwner):
+		return http.StatusForbidden
+	case errors.Is(err, contact.ErrNotBusinessAccount):
+		return http.StatusConflict
+	case errors.Is(err, contact.ErrContactNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, schedule.ErrNotCancellable):
+		return http.StatusConflict
+	case errors.Is(err, schedule.ErrNotRetryable):
+		return http.StatusConflict
+	case errors.Is(err, webhook.ErrTooManyWebhooks):
+		return http.StatusConflict
 	default:
 
 		if contains(err.Error(), "validation") {
@@ -225,12 +520,56 @@ func (h *BaseHandler) getMessageFromError(err error, operation string) string {
 		return "Session not found"
 	case err == session.ErrSessionAlreadyExists:
 		return "Session already exists"
-	case err == session.ErrSessionAlreadyConnected:
+	case errors.Is(err, session.ErrSessionAlreadyConnected):
 		return "Session is already connected"
 	case err == session.ErrInvalidSessionName:
 		return "Invalid session name"
 	case err == session.ErrInvalidProxyConfig:
 		return "Invalid proxy configuration"
+	case errors.Is(err, session.ErrInvalidPassphrase):
+		return "Invalid backup passphrase"
+	case errors.Is(err, session.ErrInvalidBackup):
+		return "Invalid session backup"
+	case errors.Is(err, session.ErrUnsupportedBackup):
+		return "Unsupported session backup version"
+	case errors.Is(err, session.ErrSessionNotPaired):
+		return "Session has no paired device"
+	case errors.Is(err, session.ErrSessionNotConnected):
+		return "Session is not connected"
+	case errors.Is(err, session.ErrDeviceAlreadyImported):
+		return "Device is already registered on this instance"
+	case errors.Is(err, session.ErrQRCodeNotAvailable):
+		return "QR code is not available"
+	case errors.Is(err, session.ErrQRCodeExpired):
+		return "QR code has expired"
+	case errors.Is(err, session.ErrNoPairingPending):
+		return "No QR pairing in progress"
+	case errors.Is(err, session.ErrNoDebugCapture):
+		return "No protocol debug capture for this session"
+	case errors.Is(err, linktrack.ErrLinkNotFound):
+		return "Link not found"
+	case errors.Is(err, conversation.ErrStateNotFound):
+		return "Conversation state not found"
+	case errors.Is(err, session.ErrInvalidQRImage):
+		return err.Error()
+	case errors.Is(err, messaging.ErrMessageHasNoMedia):
+		return "Message has no media"
+	case errors.Is(err, messaging.ErrMediaExpired):
+		return "Media is no longer available on WhatsApp servers"
+	case errors.Is(err, messaging.ErrMediaQuarantined):
+		return "Media was quarantined by the virus scan"
+	case errors.Is(err, messaging.ErrHostedMediaNotFound):
+		return "Media link is invalid or expired"
+	case errors.Is(err, messaging.ErrNotNewsletterAdmin):
+		return "Session is not an admin of the newsletter"
+	case errors.Is(err, messaging.ErrNotNewsletterOwner):
+		return "Session is not the owner of the newsletter"
+	case errors.Is(err, contact.ErrNotBusinessAccount):
+		return "Session is not a WhatsApp Business account"
+	case errors.Is(err, contact.ErrContactNotFound):
+		return "Contact not found"
+	case errors.Is(err, webhook.ErrTooManyWebhooks):
+		return err.Error()
 	default:
 
 		return fmt.Sprintf("Failed to %s", operation)
@@ -238,7 +577,7 @@ func (h *BaseHandler) getMessageFromError(err error, operation string) string {
 }
 
 func (h *BaseHandler) LogRequest(r *http.Request, operation string) {
-	h.logger.InfoWithFields(fmt.Sprintf("Processing %s request", operation), map[string]interface{}{
+	h.logger.WithContext(r.Context()).InfoWithFields(fmt.Sprintf("Processing %s request", operation), map[string]interface{}{
 		"method":     r.Method,
 		"path":       r.URL.Path,
 		"query":      r.URL.RawQuery,
@@ -256,6 +595,13 @@ func (h *BaseHandler) LogSuccess(operation string, details map[string]interface{
 	h.logger.InfoWithFields(fmt.Sprintf("%s completed successfully", operation), details)
 }
 
+// GetClientIP returns the address the request came from, the first one a
+// proxy forwarded when there is one.
+func (h *BaseHandler) GetClientIP(r *http.Request) string {
+	ip, _, _ := strings.Cut(getClientIP(r), ",")
+	return strings.TrimSpace(ip)
+}
+
 func getClientIP(r *http.Request) string {
 
 	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
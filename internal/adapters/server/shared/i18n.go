@@ -0,0 +1,152 @@
+package shared
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locales responses can be written in. English is the language messages
+// are written in throughout the code, so it needs no catalog.
+const (
+	LocaleEnglish    = "en"
+	LocalePortuguese = "pt-BR"
+
+	DefaultLocale = LocaleEnglish
+)
+
+// catalogs maps each locale to its translations, keyed by the English
+// message. Keys may hold %s placeholders for the variable parts, which are
+// carried over to the translation in the same order.
+var catalogs = map[string]map[string]string{
+	LocalePortuguese: ptBRMessages,
+}
+
+type messageTemplate struct {
+	pattern     *regexp.Regexp
+	translation string
+}
+
+// templates holds the catalog keys with placeholders, longest first so the
+// most specific one wins.
+var templates = compileTemplates()
+
+func compileTemplates() map[string][]messageTemplate {
+	compiled := make(map[string][]messageTemplate, len(catalogs))
+	for locale, catalog := range catalogs {
+		keys := make([]string, 0)
+		for key := range catalog {
+			if strings.Contains(key, "%s") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+		for _, key := range keys {
+			parts := strings.Split(key, "%s")
+			for i, part := range parts {
+				parts[i] = regexp.QuoteMeta(part)
+			}
+			compiled[locale] = append(compiled[locale], messageTemplate{
+				pattern:     regexp.MustCompile("^" + strings.Join(parts, "(.+?)") + "$"),
+				translation: catalog[key],
+			})
+		}
+	}
+	return compiled
+}
+
+// Localize translates an English message into locale. Messages missing
+// from the catalog are returned as they are.
+func Localize(locale, message string) string {
+	catalog, ok := catalogs[locale]
+	if !ok || message == "" {
+		return message
+	}
+	if translated, ok := catalog[message]; ok {
+		return translated
+	}
+
+	for _, template := range templates[locale] {
+		match := template.pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		args := make([]interface{}, len(match)-1)
+		for i, arg := range match[1:] {
+			args[i] = arg
+		}
+		return fmt.Sprintf(template.translation, args...)
+	}
+	return message
+}
+
+// NegotiateLocale picks the supported locale the Accept-Language header
+// prefers. Any Portuguese tag is answered in pt-BR and any English one in
+// en; without a match the default locale is used.
+func NegotiateLocale(acceptLanguage string) string {
+	best, bestQuality := DefaultLocale, 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		locale := supportedLocale(tag)
+		if locale != "" && quality > bestQuality {
+			best, bestQuality = locale, quality
+		}
+	}
+	return best
+}
+
+func supportedLocale(tag string) string {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	switch primary {
+	case "pt":
+		return LocalePortuguese
+	case "en":
+		return LocaleEnglish
+	default:
+		return ""
+	}
+}
+
+// localizedWriter carries the request's locale to the ResponseWriter, whose
+// methods only receive the http.ResponseWriter.
+type localizedWriter struct {
+	http.ResponseWriter
+	locale string
+}
+
+func (w *localizedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithLocale makes responses written through w use locale.
+func WithLocale(w http.ResponseWriter, locale string) http.ResponseWriter {
+	return &localizedWriter{ResponseWriter: w, locale: locale}
+}
+
+// LocaleOf returns the locale responses written through w use, looking
+// through writers that middlewares wrapped around it.
+func LocaleOf(w http.ResponseWriter) string {
+	for {
+		switch writer := w.(type) {
+		case *localizedWriter:
+			return writer.locale
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return DefaultLocale
+		}
+	}
+}
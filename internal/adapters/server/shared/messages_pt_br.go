@@ -0,0 +1,326 @@
+package shared
+
+// ptBRMessages is the Brazilian Portuguese catalog. New English messages
+// written to clients should get an entry here.
+var ptBRMessages = map[string]string{
+	// Generic failures
+	"Failed to %s":                 "Falha na operação: %s",
+	"Timed out while trying to %s": "Tempo esgotado na operação: %s",
+	"Invalid request":              "Requisição inválida",
+	"Invalid request format":       "Formato de requisição inválido",
+	"Validation failed":            "Falha na validação",
+	"Internal Server Error":        "Erro interno do servidor",
+	"Not Found":                    "Não encontrado",
+	"Forbidden":                    "Proibido",
+	"Unauthorized":                 "Não autorizado",
+
+	// Validation
+	"%s is required":                                  "%s é obrigatório",
+	"%s must be at least %s characters long":          "%s deve ter pelo menos %s caracteres",
+	"%s must be at most %s characters long":           "%s deve ter no máximo %s caracteres",
+	"%s must be exactly %s characters long":           "%s deve ter exatamente %s caracteres",
+	"%s must contain at least %s items":               "%s deve conter pelo menos %s itens",
+	"%s must contain at most %s items":                "%s deve conter no máximo %s itens",
+	"%s must contain exactly %s items":                "%s deve conter exatamente %s itens",
+	"%s must be at least %s":                          "%s deve ser no mínimo %s",
+	"%s must be at most %s":                           "%s deve ser no máximo %s",
+	"%s must be exactly %s":                           "%s deve ser exatamente %s",
+	"%s must be greater than or equal to %s":          "%s deve ser maior ou igual a %s",
+	"%s must be less than or equal to %s":             "%s deve ser menor ou igual a %s",
+	"%s must be a valid email address":                "%s deve ser um endereço de e-mail válido",
+	"%s must be a valid URL":                          "%s deve ser uma URL válida",
+	"%s must be a valid hostname":                     "%s deve ser um hostname válido",
+	"%s must be a valid phone number in E.164 format": "%s deve ser um telefone válido no formato E.164",
+	"%s must be a phone number with country code, digits only (e.g. 5511999999999)": "%s deve ser um telefone com código do país, apenas dígitos (ex.: 5511999999999)",
+	"%s must be a WhatsApp JID or a phone number with country code":                 "%s deve ser um JID do WhatsApp ou um telefone com código do país",
+	"%s must be one of: %s":                "%s deve ser um de: %s",
+	"%s must be a valid UUID":              "%s deve ser um UUID válido",
+	"%s is invalid":                        "%s é inválido",
+	"%s must be either 'http' or 'socks5'": "%s deve ser 'http' ou 'socks5'",
+	"%s contains invalid characters (only alphanumeric, dash and underscore allowed)": "%s contém caracteres inválidos (apenas letras, números, hífen e sublinhado)",
+
+	// Authentication
+	"API key is required. Provide it via Authorization header or X-API-Key header": "A chave de API é obrigatória. Envie-a no cabeçalho Authorization ou X-API-Key",
+	"Invalid API key":                        "Chave de API inválida",
+	"Could not verify API key":               "Não foi possível verificar a chave de API",
+	"Could not verify session access":        "Não foi possível verificar o acesso à sessão",
+	"This route requires the global API key": "Esta rota exige a chave de API global",
+	"session not found":                      "sessão não encontrada",
+
+	// Sessions
+	"Session not found":                                   "Sessão não encontrada",
+	"Session already exists":                              "A sessão já existe",
+	"Session is already connected":                        "A sessão já está conectada",
+	"Invalid session name":                                "Nome de sessão inválido",
+	"Invalid session ID":                                  "ID de sessão inválido",
+	"Session ID is required":                              "O ID da sessão é obrigatório",
+	"Invalid proxy configuration":                         "Configuração de proxy inválida",
+	"Invalid backup passphrase":                           "Senha do backup inválida",
+	"Invalid session backup":                              "Backup de sessão inválido",
+	"Unsupported session backup version":                  "Versão de backup de sessão não suportada",
+	"Session has no paired device":                        "A sessão não tem dispositivo pareado",
+	"Session is not connected":                            "A sessão não está conectada",
+	"Device is already registered on this instance":       "O dispositivo já está registrado nesta instância",
+	"QR code is not available":                            "O QR Code não está disponível",
+	"QR code has expired":                                 "O QR Code expirou",
+	"No QR pairing in progress":                           "Nenhum pareamento por QR Code em andamento",
+	"Session is in quiet hours":                           "A sessão está em horário de silêncio",
+	"Session reached its warm-up daily limit":             "A sessão atingiu o limite diário do aquecimento",
+	"Instance reached its maximum of connected sessions":  "A instância atingiu o máximo de sessões conectadas",
+	"Session send queue is full":                          "A fila de envio da sessão está cheia",
+	"Session is cooling down after a WhatsApp rate limit": "A sessão está em pausa após um limite de taxa do WhatsApp",
+	"Session account is banned or restricted by WhatsApp": "A conta da sessão está banida ou restrita pelo WhatsApp",
+	"Session created successfully":                        "Sessão criada com sucesso",
+	"Session deleted successfully":                        "Sessão removida com sucesso",
+	"Session disconnected successfully":                   "Sessão desconectada com sucesso",
+	"Session logged out successfully":                     "Logout da sessão realizado com sucesso",
+	"Failed to logout session":                            "Falha ao fazer logout da sessão",
+	"Session exported successfully":                       "Sessão exportada com sucesso",
+	"Session imported successfully":                       "Sessão importada com sucesso",
+	"Session imported but failed to connect":              "Sessão importada, mas falhou ao conectar",
+	"Bulk session creation finished":                      "Criação de sessões em lote concluída",
+	"Session information retrieved successfully":          "Informações da sessão obtidas com sucesso",
+	"Session statistics retrieved successfully":           "Estatísticas da sessão obtidas com sucesso",
+	"Sessions retrieved successfully":                     "Sessões obtidas com sucesso",
+	"Session paired":                                      "Sessão pareada",
+	"Session assigned successfully":                       "Sessão atribuída com sucesso",
+	"Session released successfully":                       "Sessão liberada com sucesso",
+	"Debug capture started successfully":                  "Captura de depuração iniciada com sucesso",
+	"Debug capture stopped successfully":                  "Captura de depuração encerrada com sucesso",
+	"Debug logs retrieved successfully":                   "Logs de depuração obtidos com sucesso",
+	"No protocol debug capture for this session":          "Não há captura de depuração do protocolo para esta sessão",
+	"Pairing cancelled successfully":                      "Pareamento cancelado com sucesso",
+	"Phone pairing initiated successfully":                "Pareamento por telefone iniciado com sucesso",
+	"QR code generated successfully":                      "QR Code gerado com sucesso",
+	"QR code retrieved successfully":                      "QR Code obtido com sucesso",
+	"Chat link generated successfully":                    "Link de conversa gerado com sucesso",
+	"Proxy configuration retrieved successfully":          "Configuração de proxy obtida com sucesso",
+	"Proxy configured successfully":                       "Proxy configurado com sucesso",
+	"Settings retrieved successfully":                     "Configurações obtidas com sucesso",
+	"Call settings updated successfully":                  "Configurações de chamadas atualizadas com sucesso",
+	"Media settings updated successfully":                 "Configurações de mídia atualizadas com sucesso",
+	"Media policy updated successfully":                   "Política de mídia atualizada com sucesso",
+	"Quiet hours updated successfully":                    "Horário de silêncio atualizado com sucesso",
+	"Retention updated successfully":                      "Retenção atualizada com sucesso",
+	"Session policy updated successfully":                 "Política da sessão atualizada com sucesso",
+	"Timezone updated successfully":                       "Fuso horário atualizado com sucesso",
+	"Feature flags updated successfully":                  "Flags de recursos atualizadas com sucesso",
+	"Group rules updated successfully":                    "Regras do grupo atualizadas com sucesso",
+	"Group rules removed successfully":                    "Regras do grupo removidas com sucesso",
+	"Inbound filter updated successfully":                 "Filtro de entrada atualizado com sucesso",
+	"Inbound filter removed successfully":                 "Filtro de entrada removido com sucesso",
+	"Filtered messages retrieved successfully":            "Mensagens filtradas obtidas com sucesso",
+	"Sandbox updated successfully":                        "Sandbox atualizado com sucesso",
+	"Pairing settings updated successfully":               "Configurações de pareamento atualizadas com sucesso",
+	"Text format updated successfully":                    "Formatação de texto atualizada com sucesso",
+	"Footer updated successfully":                         "Rodapé atualizado com sucesso",
+	"Warm-up status retrieved successfully":               "Status do aquecimento obtido com sucesso",
+	"Warm-up updated successfully":                        "Aquecimento atualizado com sucesso",
+	"Uptime retrieved successfully":                       "Tempo de atividade obtido com sucesso",
+	"Devices retrieved successfully":                      "Dispositivos obtidos com sucesso",
+	"Invalid isConnected parameter":                       "Parâmetro isConnected inválido",
+
+	// Messages
+	"Text message sent successfully":                   "Mensagem de texto enviada com sucesso",
+	"Image message sent successfully":                  "Imagem enviada com sucesso",
+	"Audio message sent successfully":                  "Áudio enviado com sucesso",
+	"Video message sent successfully":                  "Vídeo enviado com sucesso",
+	"Document message sent successfully":               "Documento enviado com sucesso",
+	"Sticker message sent successfully":                "Figurinha enviada com sucesso",
+	"Media message sent successfully":                  "Mídia enviada com sucesso",
+	"Location message sent successfully":               "Localização enviada com sucesso",
+	"Live location message sent successfully":          "Localização em tempo real enviada com sucesso",
+	"Contact message sent successfully":                "Contato enviado com sucesso",
+	"Contact list sent successfully":                   "Lista de contatos enviada com sucesso",
+	"Business profile sent successfully":               "Perfil comercial enviado com sucesso",
+	"Button message sent successfully":                 "Mensagem com botões enviada com sucesso",
+	"List message sent successfully":                   "Mensagem de lista enviada com sucesso",
+	"Poll message sent successfully":                   "Enquete enviada com sucesso",
+	"Payment request sent successfully":                "Solicitação de pagamento enviada com sucesso",
+	"Reaction sent successfully":                       "Reação enviada com sucesso",
+	"Presence sent successfully":                       "Presença enviada com sucesso",
+	"Failed to send text message":                      "Falha ao enviar a mensagem de texto",
+	"Failed to send image message":                     "Falha ao enviar a imagem",
+	"Failed to send audio message":                     "Falha ao enviar o áudio",
+	"Failed to send video message":                     "Falha ao enviar o vídeo",
+	"Failed to send document message":                  "Falha ao enviar o documento",
+	"Failed to send sticker message":                   "Falha ao enviar a figurinha",
+	"Failed to send media message":                     "Falha ao enviar a mídia",
+	"Failed to send location message":                  "Falha ao enviar a localização",
+	"Failed to send contact message":                   "Falha ao enviar o contato",
+	"Failed to send poll message":                      "Falha ao enviar a enquete",
+	"Message retrieved successfully":                   "Mensagem obtida com sucesso",
+	"Messages retrieved successfully":                  "Mensagens obtidas com sucesso",
+	"Chats retrieved successfully":                     "Conversas obtidas com sucesso",
+	"Contacts retrieved successfully":                  "Contatos obtidos com sucesso",
+	"Contact retrieved successfully":                   "Contato obtido com sucesso",
+	"Contact not found":                                "Contato não encontrado",
+	"Live locations retrieved successfully":            "Localizações em tempo real obtidas com sucesso",
+	"Messages marked as read":                          "Mensagens marcadas como lidas",
+	"Message deleted successfully":                     "Mensagem apagada com sucesso",
+	"Message edited successfully":                      "Mensagem editada com sucesso",
+	"Message revoked successfully":                     "Mensagem revogada com sucesso",
+	"Message pinned successfully":                      "Mensagem fixada com sucesso",
+	"Message unpinned successfully":                    "Mensagem desafixada com sucesso",
+	"Posted to newsletter":                             "Publicado no canal",
+	"Newsletter post scheduled":                        "Publicação no canal agendada",
+	"Message forwarded to newsletter":                  "Mensagem encaminhada ao canal",
+	"Newsletter admin invited":                         "Convite de administração do canal enviado",
+	"Newsletter admin invite revoked":                  "Convite de administração do canal revogado",
+	"Newsletter admin invite accepted":                 "Convite de administração do canal aceito",
+	"Newsletter admin demoted":                         "Administrador removido do canal",
+	"Newsletter ownership transferred":                 "Propriedade do canal transferida",
+	"Message statistics retrieved successfully":        "Estatísticas de mensagens obtidas com sucesso",
+	"Failed to get message stats":                      "Falha ao obter as estatísticas de mensagens",
+	"Pending sync messages retrieved successfully":     "Mensagens pendentes de sincronização obtidas com sucesso",
+	"Failed to get pending sync messages":              "Falha ao obter as mensagens pendentes de sincronização",
+	"Starred messages retrieved successfully":          "Mensagens favoritas obtidas com sucesso",
+	"Scheduled messages retrieved successfully":        "Mensagens agendadas obtidas com sucesso",
+	"Scheduled message cancelled successfully":         "Mensagem agendada cancelada com sucesso",
+	"Poll results retrieved successfully":              "Resultados da enquete obtidos com sucesso",
+	"Sent messages cleared":                            "Mensagens enviadas removidas",
+	"Session ID and Message ID are required":           "O ID da sessão e o ID da mensagem são obrigatórios",
+	"Message has no media":                             "A mensagem não tem mídia",
+	"Media is no longer available on WhatsApp servers": "A mídia não está mais disponível nos servidores do WhatsApp",
+	"Media was quarantined by the virus scan":          "A mídia foi colocada em quarentena pela verificação de vírus",
+	"Session is not an admin of the newsletter":        "A sessão não é administradora do canal",
+	"Session is not the owner of the newsletter":       "A sessão não é dona do canal",
+
+	"Message sent successfully":                                     "Mensagem enviada com sucesso",
+	"Failed to retry message":                                       "Falha ao reenviar a mensagem",
+	"Send failed and was kept for retry":                            "O envio falhou e foi guardado para nova tentativa",
+	"Send was not confirmed by WhatsApp and may still be delivered": "O envio não foi confirmado pelo WhatsApp e ainda pode ser entregue",
+	"Daily limit reached, message scheduled":                        "Limite diário atingido, mensagem agendada",
+
+	// Recipients
+	"Recipient is not on WhatsApp":                          "O destinatário não está no WhatsApp",
+	"Recipient is not a valid WhatsApp JID or phone number": "O destinatário não é um JID do WhatsApp ou número de telefone válido",
+	"Session media processing queue is full":                "A fila de processamento de mídia da sessão está cheia",
+	"Instance capacity retrieved successfully":              "Capacidade da instância obtida com sucesso",
+	"Secrets re-encrypted successfully":                     "Segredos recriptografados com sucesso",
+	"Secret encryption is not available":                    "A criptografia de segredos não está disponível",
+	"No secrets master key is configured":                   "Nenhuma chave mestra de segredos está configurada",
+	"Media processing statistics retrieved successfully":    "Estatísticas de processamento de mídia obtidas com sucesso",
+	"Media processing statistics are not available":         "Estatísticas de processamento de mídia não estão disponíveis",
+	"Feature is disabled for this session":                  "O recurso está desativado para esta sessão",
+	"Payment requests are not supported for this account":   "Solicitações de pagamento não são suportadas para esta conta",
+	"Send is not allowed by the session policy":             "O envio não é permitido pela política da sessão",
+
+	// Media
+	"Media downloaded successfully":            "Mídia baixada com sucesso",
+	"Media information retrieved successfully": "Informações da mídia obtidas com sucesso",
+	"Media statistics retrieved successfully":  "Estatísticas de mídia obtidas com sucesso",
+	"Cached media listed successfully":         "Mídias em cache listadas com sucesso",
+	"Media cache cleared successfully":         "Cache de mídia limpo com sucesso",
+	"Media link is invalid or expired":         "O link da mídia é inválido ou expirou",
+	"Invalid multipart form":                   "Formulário multipart inválido",
+	"Missing file field":                       "Campo de arquivo ausente",
+	"Failed to read uploaded file":             "Falha ao ler o arquivo enviado",
+
+	// Contacts
+	"All contacts retrieved successfully":             "Todos os contatos obtidos com sucesso",
+	"Failed to sync contacts":                         "Falha ao sincronizar os contatos",
+	"Failed to get user info":                         "Falha ao obter as informações do usuário",
+	"JID is required":                                 "O JID é obrigatório",
+	"Profile picture retrieved successfully":          "Foto de perfil obtida com sucesso",
+	"Business profile retrieved successfully":         "Perfil comercial obtido com sucesso",
+	"Business profile updated successfully":           "Perfil comercial atualizado com sucesso",
+	"Session is not a WhatsApp Business account":      "A sessão não é uma conta do WhatsApp Business",
+	"Label created successfully":                      "Etiqueta criada com sucesso",
+	"Label updated successfully":                      "Etiqueta atualizada com sucesso",
+	"Label deleted successfully":                      "Etiqueta removida com sucesso",
+	"Labels retrieved successfully":                   "Etiquetas obtidas com sucesso",
+	"Label associations retrieved successfully":       "Associações de etiquetas obtidas com sucesso",
+	"Session ID and label ID are required":            "O ID da sessão e o ID da etiqueta são obrigatórios",
+	"Note created successfully":                       "Nota criada com sucesso",
+	"Note updated successfully":                       "Nota atualizada com sucesso",
+	"Note deleted successfully":                       "Nota removida com sucesso",
+	"Notes retrieved successfully":                    "Notas obtidas com sucesso",
+	"Invalid include_notes parameter":                 "Parâmetro include_notes inválido",
+	"Link stats retrieved successfully":               "Estatísticas de links obtidas com sucesso",
+	"Link clicks retrieved successfully":              "Cliques em links obtidos com sucesso",
+	"Link not found":                                  "Link não encontrado",
+	"Conversation state retrieved successfully":       "Estado da conversa obtido com sucesso",
+	"Conversation state saved successfully":           "Estado da conversa salvo com sucesso",
+	"Conversation state deleted successfully":         "Estado da conversa removido com sucesso",
+	"Conversation state not found":                    "Estado da conversa não encontrado",
+	"Conversation state was changed by another write": "O estado da conversa foi alterado por outra escrita",
+	"version must be a positive number":               "version deve ser um número positivo",
+	"limit must be a number":                          "limit deve ser um número",
+	"Invalid refresh parameter":                       "Parâmetro refresh inválido",
+
+	// Groups
+	"Group JID is required":                                 "O JID do grupo é obrigatório",
+	"Session ID and group JID are required":                 "O ID da sessão e o JID do grupo são obrigatórios",
+	"Invalid includeContacts parameter":                     "Parâmetro includeContacts inválido",
+	"Invalid dryRun parameter":                              "Parâmetro dryRun inválido",
+	"Invite link is required":                               "O link de convite é obrigatório",
+	"Get group info from invite not implemented yet":        "Obter informações do grupo pelo convite ainda não foi implementado",
+	"Invite link retrieved successfully":                    "Link de convite obtido com sucesso",
+	"Get group request participants not implemented yet":    "Obter os pedidos de participação do grupo ainda não foi implementado",
+	"Update group request participants not implemented yet": "Atualizar os pedidos de participação do grupo ainda não foi implementado",
+	"Join group via link not implemented yet":               "Entrar no grupo pelo link ainda não foi implementado",
+	"Join group with invite not implemented yet":            "Entrar no grupo com convite ainda não foi implementado",
+	"Leave group not implemented yet":                       "Sair do grupo ainda não foi implementado",
+	"Set group description not implemented yet":             "Alterar a descrição do grupo ainda não foi implementado",
+	"Set group join approval mode not implemented yet":      "Alterar a aprovação de entrada do grupo ainda não foi implementado",
+	"Set group member add mode not implemented yet":         "Alterar quem adiciona membros ao grupo ainda não foi implementado",
+	"Update group settings not implemented yet":             "Atualizar as configurações do grupo ainda não foi implementado",
+
+	// Webhooks
+	"Webhook created successfully":                 "Webhook criado com sucesso",
+	"Webhook updated successfully":                 "Webhook atualizado com sucesso",
+	"Webhook deleted successfully":                 "Webhook removido com sucesso",
+	"Webhooks retrieved successfully":              "Webhooks obtidos com sucesso",
+	"Webhook configuration retrieved successfully": "Configuração de webhook obtida com sucesso",
+	"Webhook configuration set successfully":       "Configuração de webhook definida com sucesso",
+	"Webhook processed successfully":               "Webhook processado com sucesso",
+	"Invalid webhook payload":                      "Payload de webhook inválido",
+	"Events scheduled":                             "Eventos agendados",
+
+	// Chatwoot
+	"Chatwoot configuration created successfully":     "Configuração do Chatwoot criada com sucesso",
+	"Chatwoot configuration updated successfully":     "Configuração do Chatwoot atualizada com sucesso",
+	"Chatwoot configuration deleted successfully":     "Configuração do Chatwoot removida com sucesso",
+	"Chatwoot configuration retrieved successfully":   "Configuração do Chatwoot obtida com sucesso",
+	"Chatwoot connection test completed successfully": "Teste de conexão com o Chatwoot concluído com sucesso",
+	"Chatwoot inbox created successfully":             "Caixa de entrada do Chatwoot criada com sucesso",
+	"Chatwoot inbox mapped successfully":              "Caixa de entrada do Chatwoot vinculada com sucesso",
+	"Chatwoot inbox unmapped successfully":            "Caixa de entrada do Chatwoot desvinculada com sucesso",
+	"Chatwoot inboxes retrieved successfully":         "Caixas de entrada do Chatwoot obtidas com sucesso",
+	"Chatwoot settings updated successfully":          "Configurações do Chatwoot atualizadas com sucesso",
+	"Chatwoot statistics retrieved successfully":      "Estatísticas do Chatwoot obtidas com sucesso",
+
+	// Tenants
+	"Tenant created successfully":            "Tenant criado com sucesso",
+	"Tenant updated successfully":            "Tenant atualizado com sucesso",
+	"Tenant deleted successfully":            "Tenant removido com sucesso",
+	"Tenant retrieved successfully":          "Tenant obtido com sucesso",
+	"Tenants retrieved successfully":         "Tenants obtidos com sucesso",
+	"Tenant API key created successfully":    "Chave de API do tenant criada com sucesso",
+	"Tenant API key revoked successfully":    "Chave de API do tenant revogada com sucesso",
+	"Tenant API keys retrieved successfully": "Chaves de API do tenant obtidas com sucesso",
+	"Tenant reached its session limit":       "O tenant atingiu o limite de sessões",
+	"Tenant reached its daily message limit": "O tenant atingiu o limite diário de mensagens",
+
+	// Admin
+	"Audit log is disabled":                              "O log de auditoria está desativado",
+	"Audit log retrieved successfully":                   "Log de auditoria obtido com sucesso",
+	"Configuration reload is not available":              "A recarga de configuração não está disponível",
+	"Database statistics are not available":              "As estatísticas do banco de dados não estão disponíveis",
+	"Database statistics retrieved successfully":         "Estatísticas do banco de dados obtidas com sucesso",
+	"Inbound pipeline is not available":                  "O pipeline de entrada não está disponível",
+	"Inbound pipeline statistics retrieved successfully": "Estatísticas do pipeline de entrada obtidas com sucesso",
+	"Invalid pagination parameters":                      "Parâmetros de paginação inválidos",
+	"Invalid days parameter":                             "Parâmetro days inválido",
+	"Invalid from parameter":                             "Parâmetro from inválido",
+	"Invalid to parameter":                               "Parâmetro to inválido",
+	"Invalid success parameter":                          "Parâmetro success inválido",
+	"Invalid status filter":                              "Filtro de status inválido",
+	"Invalid logo parameter":                             "Parâmetro logo inválido",
+	"Invalid margin parameter":                           "Parâmetro margin inválido",
+	"Invalid size parameter":                             "Parâmetro size inválido",
+
+	"Send statistics are not available":      "As estatísticas de envio não estão disponíveis",
+	"Send statistics retrieved successfully": "Estatísticas de envio obtidas com sucesso",
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"zpwoot/platform/logger"
 )
@@ -21,16 +22,20 @@ type ErrorResponse struct {
 } // @name ErrorResponse
 
 type ValidationError struct {
-	Field   string `json:"field" example:"name"`
-	Message string `json:"message" example:"Name is required"`
+	Field   string `json:"field" example:"to"`
+	Rule    string `json:"rule,omitempty" example:"required"`
+	Message string `json:"message" example:"to is required"`
 	Value   string `json:"value,omitempty" example:""`
-}
+} // @name ValidationError
 
+// ValidationErrorResponse has the same shape as ErrorResponse, with one
+// entry per rejected field in details.
 type ValidationErrorResponse struct {
-	Error   string            `json:"error" example:"Validation failed"`
 	Details []ValidationError `json:"details"`
+	Error   string            `json:"error" example:"Validation failed"`
+	Code    string            `json:"code" example:"VALIDATION_ERROR"`
 	Success bool              `json:"success" example:"false"`
-}
+} // @name ValidationErrorResponse
 
 type PaginationResponse struct {
 	Total   int  `json:"total" example:"100"`
@@ -69,11 +74,26 @@ func (rw *ResponseWriter) WriteCreated(w http.ResponseWriter, data interface{},
 	rw.writeJSON(w, http.StatusCreated, response)
 }
 
+func (rw *ResponseWriter) WriteAccepted(w http.ResponseWriter, data interface{}, message ...string) {
+	response := NewSuccessResponse(data, message...)
+	rw.writeJSON(w, http.StatusAccepted, response)
+}
+
 func (rw *ResponseWriter) WriteError(w http.ResponseWriter, statusCode int, message string, details ...interface{}) {
 	response := NewErrorResponse(message, details...)
 	rw.writeJSON(w, statusCode, response)
 }
 
+func (rw *ResponseWriter) WriteErrorWithCode(w http.ResponseWriter, statusCode int, code, message string, details ...interface{}) {
+	response := NewErrorResponse(message, details...)
+	response.Code = code
+	rw.writeJSON(w, statusCode, response)
+}
+
+func (rw *ResponseWriter) WriteGatewayTimeout(w http.ResponseWriter, message string) {
+	rw.WriteErrorWithCode(w, http.StatusGatewayTimeout, "OPERATION_TIMEOUT", message)
+}
+
 func (rw *ResponseWriter) WriteBadRequest(w http.ResponseWriter, message string, details ...interface{}) {
 	rw.WriteError(w, http.StatusBadRequest, message, details...)
 }
@@ -99,7 +119,25 @@ func (rw *ResponseWriter) WriteInternalError(w http.ResponseWriter, message stri
 	rw.WriteError(w, http.StatusInternalServerError, message)
 }
 
+// WriteBinary writes a non-JSON payload such as a rendered image. Responses are
+// marked uncacheable since they typically reflect short-lived state.
+func (rw *ResponseWriter) WriteBinary(w http.ResponseWriter, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(data); err != nil {
+		rw.logger.ErrorWithFields("Failed to write binary response", map[string]interface{}{
+			"error":        err.Error(),
+			"content_type": contentType,
+		})
+	}
+}
+
 func (rw *ResponseWriter) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	localize(LocaleOf(w), data)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -111,6 +149,29 @@ func (rw *ResponseWriter) writeJSON(w http.ResponseWriter, statusCode int, data
 	}
 }
 
+// localize translates the messages of a response envelope in place. Data
+// and error details other than validation errors are left as they are.
+func localize(locale string, data interface{}) {
+	if locale == DefaultLocale {
+		return
+	}
+
+	switch response := data.(type) {
+	case *SuccessResponse:
+		response.Message = Localize(locale, response.Message)
+	case *ErrorResponse:
+		response.Error = Localize(locale, response.Error)
+		if message, ok := response.Details.(string); ok {
+			response.Details = Localize(locale, message)
+		}
+	case *ValidationErrorResponse:
+		response.Error = Localize(locale, response.Error)
+		for i := range response.Details {
+			response.Details[i].Message = Localize(locale, response.Details[i].Message)
+		}
+	}
+}
+
 func NewSuccessResponse(data interface{}, message ...string) *SuccessResponse {
 	response := &SuccessResponse{
 		Success: true,
@@ -141,6 +202,7 @@ func NewValidationErrorResponse(errors []ValidationError) *ValidationErrorRespon
 	return &ValidationErrorResponse{
 		Success: false,
 		Error:   "Validation failed",
+		Code:    "VALIDATION_ERROR",
 		Details: errors,
 	}
 }
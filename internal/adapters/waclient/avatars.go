@@ -0,0 +1,224 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/contact"
+)
+
+const (
+	avatarFetchTimeout = 30 * time.Second
+	maxAvatarSize      = 5 << 20
+)
+
+// AvatarChangedEvent is delivered to webhooks when a contact's profile
+// picture changes or is removed. URL links the stored copy when a media
+// host is configured.
+type AvatarChangedEvent struct {
+	Event       string     `json:"event"`
+	SessionName string     `json:"session_name"`
+	JID         string     `json:"jid"`
+	PictureID   string     `json:"picture_id,omitempty"`
+	PreviousID  string     `json:"previous_id,omitempty"`
+	Removed     bool       `json:"removed"`
+	URL         string     `json:"url,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+}
+
+// AvatarObserver is told about profile picture changes WhatsApp announces.
+type AvatarObserver interface {
+	Observe(ctx context.Context, sessionID uuid.UUID, sessionName, jid, pictureID string, removed bool, at time.Time) error
+}
+
+func (g *Gateway) SetAvatarObserver(observer AvatarObserver) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.avatars = observer
+}
+
+func (g *Gateway) getAvatarObserver() AvatarObserver {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.avatars
+}
+
+// FetchAvatar implements contact.AvatarSource. Pictures the contact hides
+// from the session are reported like a missing picture.
+func (g *Gateway) FetchAvatar(ctx context.Context, sessionName, jid, knownID string) (*contact.AvatarPicture, error) {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := types.ParseJID(jid)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var info *types.ProfilePictureInfo
+	err = runWithContext(opCtx, func() error {
+		var err error
+		info, err = client.client.GetProfilePictureInfo(target, &whatsmeow.GetProfilePictureParams{
+			ExistingID: knownID,
+		})
+		return err
+	})
+	switch {
+	case errors.Is(err, whatsmeow.ErrProfilePictureNotSet), errors.Is(err, whatsmeow.ErrProfilePictureUnauthorized):
+		return &contact.AvatarPicture{}, nil
+	case err != nil:
+		return nil, wrapContextError(err)
+	case info == nil:
+		return nil, nil
+	}
+
+	return &contact.AvatarPicture{ID: info.ID, URL: info.URL}, nil
+}
+
+// StoreAvatar implements contact.AvatarSource. Each contact has one file
+// under the session's media directory, overwritten when the picture changes.
+func (g *Gateway) StoreAvatar(ctx context.Context, sessionID uuid.UUID, jid, url string) (string, error) {
+	path, err := g.avatarPath(sessionID, jid)
+	if err != nil {
+		return "", err
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, avatarFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid avatar URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download avatar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download avatar: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAvatarSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to download avatar: %w", err)
+	}
+	if len(data) > maxAvatarSize {
+		return "", fmt.Errorf("avatar exceeds %d bytes", maxAvatarSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create avatar directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		return "", fmt.Errorf("failed to write avatar file: %w", err)
+	}
+
+	return path, nil
+}
+
+// DeleteAvatar implements contact.AvatarSource.
+func (g *Gateway) DeleteAvatar(sessionID uuid.UUID, jid string) error {
+	path, err := g.avatarPath(sessionID, jid)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete avatar file: %w", err)
+	}
+	return nil
+}
+
+func (g *Gateway) avatarPath(sessionID uuid.UUID, jid string) (string, error) {
+	g.mu.RLock()
+	baseDir := g.mediaDir
+	g.mu.RUnlock()
+
+	if baseDir == "" {
+		return "", fmt.Errorf("media directory is not configured")
+	}
+
+	return filepath.Join(baseDir, sessionID.String(), "avatars", filepath.Base(jid)+".jpg"), nil
+}
+
+// EmitAvatarChanged delivers a detected picture change as
+// contact.avatar_changed through the session's inbound pipeline.
+func (g *Gateway) EmitAvatarChanged(ctx context.Context, sessionName string, change *contact.AvatarChange) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return
+	}
+
+	evt := &AvatarChangedEvent{
+		Event:       "contact.avatar_changed",
+		SessionName: sessionName,
+		JID:         change.JID,
+		PictureID:   change.PictureID,
+		PreviousID:  change.PreviousID,
+		Removed:     change.Removed,
+		Timestamp:   change.ChangedAt,
+	}
+
+	if host := g.getMediaHost(); host != nil && change.LocalPath != "" {
+		hosted, err := host.Publish(ctx, change.SessionID, change.LocalPath, "image/jpeg")
+		if err != nil {
+			g.logger.WarnWithFields("Failed to publish avatar", map[string]interface{}{
+				"session_name": sessionName,
+				"jid":          change.JID,
+				"error":        err.Error(),
+			})
+		} else {
+			evt.URL = hosted.URL
+			evt.ExpiresAt = &hosted.ExpiresAt
+		}
+	}
+
+	client.notifyEventHandlers(evt)
+}
+
+// handlePicture passes contacts' picture changes to the avatar cache, which
+// emits contact.avatar_changed. Group photo changes are left to group.updated.
+// The picture is fetched in the background so the pipeline does not wait.
+func (h *EventHandler) handlePicture(evt *events.Picture, sessionID string) {
+	observer := h.gateway.getAvatarObserver()
+	if observer == nil || evt.JID.Server != types.DefaultUserServer {
+		return
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	jid := evt.JID.ToNonAD().String()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*avatarFetchTimeout)
+		defer cancel()
+
+		if err := observer.Observe(ctx, id, h.sessionName, jid, evt.PictureID, evt.Remove, evt.Timestamp); err != nil {
+			h.logger.WarnWithFields("Failed to update changed avatar", map[string]interface{}{
+				"session_id": sessionID,
+				"jid":        jid,
+				"error":      err.Error(),
+			})
+		}
+	}()
+}
@@ -0,0 +1,112 @@
+package waclient
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/session"
+)
+
+// SessionBannedEvent is delivered to webhooks when WhatsApp bans the
+// session's account or restricts it for a while. Until is set for
+// restrictions WhatsApp gave an end for.
+type SessionBannedEvent struct {
+	Event       string     `json:"event"`
+	SessionName string     `json:"session_name"`
+	Kind        string     `json:"kind"`
+	Reason      string     `json:"reason"`
+	Code        int        `json:"code,omitempty"`
+	Until       *time.Time `json:"until,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+}
+
+// handleTemporaryBan records a restriction on top of the throttle, so sends
+// are refused with a ban error instead of piling up in the queue.
+func (h *EventHandler) handleTemporaryBan(evt *events.TemporaryBan, sessionID string) {
+	h.gateway.throttle(h.sessionName, session.ThrottleTemporaryBan, int(evt.Code), evt.Expire)
+
+	now := time.Now()
+	ban := session.Ban{
+		Kind:   session.BanRestricted,
+		Reason: evt.Code.String(),
+		Code:   int(evt.Code),
+		Since:  now,
+	}
+	if evt.Expire > 0 {
+		until := now.Add(evt.Expire)
+		ban.Until = &until
+	}
+
+	h.logger.WarnWithFields("WhatsApp restricted the account", map[string]interface{}{
+		"session_id": sessionID,
+		"reason":     ban.Reason,
+		"code":       ban.Code,
+		"expire":     evt.Expire.String(),
+	})
+
+	h.reportBan(sessionID, ban)
+}
+
+// handleBanned drops the device of a banned account like a logout, but
+// records the ban rather than a plain logout.
+func (h *EventHandler) handleBanned(evt *events.LoggedOut, sessionID string) {
+	reason := evt.Reason.String()
+
+	h.logger.WarnWithFields("WhatsApp banned the account", map[string]interface{}{
+		"session_id": sessionID,
+		"reason":     reason,
+	})
+
+	deviceJID := h.gateway.dropClient(h.sessionName)
+	now := time.Now()
+
+	h.deliverToWebhook(&SessionLoggedOutEvent{
+		Event:       "session.logged_out",
+		SessionName: h.sessionName,
+		DeviceJID:   deviceJID,
+		Reason:      reason,
+		OnConnect:   evt.OnConnect,
+		Timestamp:   now,
+	}, sessionID)
+
+	h.reportBan(sessionID, session.Ban{
+		Kind:   session.BanBanned,
+		Reason: reason,
+		Code:   int(evt.Reason),
+		Since:  now,
+	})
+}
+
+func (h *EventHandler) reportBan(sessionID string, ban session.Ban) {
+	h.notifySessionBanned(sessionID, ban)
+	h.updateSessionStatus(sessionID, string(ban.Status()))
+
+	h.deliverToWebhook(&SessionBannedEvent{
+		Event:       "session.banned",
+		SessionName: h.sessionName,
+		Kind:        string(ban.Kind),
+		Reason:      ban.Reason,
+		Code:        ban.Code,
+		Until:       ban.Until,
+		Timestamp:   ban.Since,
+	}, sessionID)
+}
+
+func (h *EventHandler) notifySessionBanned(sessionID string, ban session.Ban) {
+	handlers := h.gateway.getEventHandlers("global")
+	for _, handler := range handlers {
+		go func(sessionHandler session.EventHandler) {
+			defer func() {
+				if r := recover(); r != nil {
+					h.logger.ErrorWithFields("Session event handler panic", map[string]interface{}{
+						"session_id": sessionID,
+						"event":      "banned",
+						"error":      r,
+					})
+				}
+			}()
+			sessionHandler.OnSessionBanned(h.sessionName, ban)
+		}(handler)
+	}
+}
@@ -0,0 +1,230 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.mau.fi/whatsmeow"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+
+	"zpwoot/internal/core/contact"
+	"zpwoot/platform/logger"
+)
+
+// whatsmeow only reads the business profile of other accounts and leaves out
+// description and websites, so the own profile is queried and edited with the
+// w:biz IQs the WhatsApp Business app uses.
+const (
+	businessNamespace      = "w:biz"
+	businessProfileVersion = "244"
+	businessEditVersion    = "3"
+)
+
+// GetOwnBusinessProfile reads the business profile of the session's account.
+func (g *Gateway) GetOwnBusinessProfile(ctx context.Context, sessionName string) (*contact.OwnBusinessProfile, error) {
+	client, ownJID, err := g.businessClient(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	opCtx, span := startCallSpan(ctx, "GetBusinessProfile", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	resp, err := client.DangerousInternals().SendIQ(whatsmeow.DangerousInfoQuery{
+		Namespace: businessNamespace,
+		Type:      "get",
+		To:        types.ServerJID,
+		Context:   opCtx,
+		Content: []waBinary.Node{{
+			Tag:   "business_profile",
+			Attrs: waBinary.Attrs{"v": businessProfileVersion},
+			Content: []waBinary.Node{{
+				Tag:   "profile",
+				Attrs: waBinary.Attrs{"jid": ownJID},
+			}},
+		}},
+	})
+	logger.EndSpan(span, err)
+	if err != nil {
+		if errors.Is(err, whatsmeow.ErrIQNotFound) {
+			return nil, contact.ErrNotBusinessAccount
+		}
+		return nil, fmt.Errorf("failed to get business profile: %w", wrapContextError(err))
+	}
+
+	businessNode, ok := resp.GetOptionalChildByTag("business_profile")
+	if !ok {
+		return nil, contact.ErrNotBusinessAccount
+	}
+	profileNode, ok := businessNode.GetOptionalChildByTag("profile")
+	if !ok {
+		return nil, contact.ErrNotBusinessAccount
+	}
+
+	return parseOwnBusinessProfile(ownJID, &profileNode), nil
+}
+
+// UpdateBusinessProfile sends a delta edit, so only the fields present in
+// the update change.
+func (g *Gateway) UpdateBusinessProfile(ctx context.Context, sessionName string, update *contact.BusinessProfileUpdate) error {
+	client, _, err := g.businessClient(sessionName)
+	if err != nil {
+		return err
+	}
+
+	fields := businessProfileNodes(update)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	opCtx, span := startCallSpan(ctx, "UpdateBusinessProfile", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	_, err = client.DangerousInternals().SendIQ(whatsmeow.DangerousInfoQuery{
+		Namespace: businessNamespace,
+		Type:      "set",
+		To:        types.ServerJID,
+		Context:   opCtx,
+		Content: []waBinary.Node{{
+			Tag: "business_profile",
+			Attrs: waBinary.Attrs{
+				"v":             businessEditVersion,
+				"mutation_type": "delta",
+			},
+			Content: fields,
+		}},
+	})
+	logger.EndSpan(span, err)
+	if err != nil {
+		if errors.Is(err, whatsmeow.ErrIQNotFound) || errors.Is(err, whatsmeow.ErrIQForbidden) {
+			return contact.ErrNotBusinessAccount
+		}
+		if errors.Is(err, whatsmeow.ErrIQBadRequest) {
+			return fmt.Errorf("%w: rejected by WhatsApp", contact.ErrInvalidBusiness)
+		}
+		return fmt.Errorf("failed to update business profile: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Business profile updated", map[string]interface{}{
+		"session_name": sessionName,
+		"fields":       len(fields),
+	})
+
+	return nil
+}
+
+func (g *Gateway) businessClient(sessionName string) (*whatsmeow.Client, types.JID, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, types.EmptyJID, fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return nil, types.EmptyJID, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	return client.GetClient(), client.GetJID().ToNonAD(), nil
+}
+
+func businessProfileNodes(update *contact.BusinessProfileUpdate) []waBinary.Node {
+	var nodes []waBinary.Node
+
+	text := func(tag string, value *string) {
+		if value != nil {
+			nodes = append(nodes, waBinary.Node{Tag: tag, Content: []byte(*value)})
+		}
+	}
+	text("description", update.Description)
+	text("address", update.Address)
+	text("email", update.Email)
+
+	for _, website := range update.Websites {
+		nodes = append(nodes, waBinary.Node{Tag: "website", Content: []byte(website)})
+	}
+
+	if len(update.Categories) > 0 {
+		categories := make([]waBinary.Node, len(update.Categories))
+		for i, id := range update.Categories {
+			categories[i] = waBinary.Node{Tag: "category", Attrs: waBinary.Attrs{"id": id}}
+		}
+		nodes = append(nodes, waBinary.Node{Tag: "categories", Content: categories})
+	}
+
+	if update.Hours != nil {
+		days := make([]waBinary.Node, len(update.Hours.Days))
+		for i, day := range update.Hours.Days {
+			attrs := waBinary.Attrs{"day_of_week": day.Day, "mode": day.Mode}
+			if day.Mode == contact.HoursSpecific {
+				attrs["open_time"] = strconv.Itoa(day.Open)
+				attrs["close_time"] = strconv.Itoa(day.Close)
+			}
+			days[i] = waBinary.Node{Tag: "business_hours_config", Attrs: attrs}
+		}
+		nodes = append(nodes, waBinary.Node{
+			Tag:     "business_hours",
+			Attrs:   waBinary.Attrs{"timezone": update.Hours.Timezone},
+			Content: days,
+		})
+	}
+
+	return nodes
+}
+
+func parseOwnBusinessProfile(jid types.JID, node *waBinary.Node) *contact.OwnBusinessProfile {
+	profile := &contact.OwnBusinessProfile{
+		JID:         jid.String(),
+		Description: nodeText(node, "description"),
+		Address:     nodeText(node, "address"),
+		Email:       nodeText(node, "email"),
+		Websites:    []string{},
+		Categories:  []contact.BusinessCategory{},
+	}
+
+	for _, website := range node.GetChildrenByTag("website") {
+		if value, ok := website.Content.([]byte); ok && len(value) > 0 {
+			profile.Websites = append(profile.Websites, string(value))
+		}
+	}
+
+	categories := node.GetChildByTag("categories")
+	for _, category := range categories.GetChildrenByTag("category") {
+		name, _ := category.Content.([]byte)
+		profile.Categories = append(profile.Categories, contact.BusinessCategory{
+			ID:   category.AttrGetter().String("id"),
+			Name: string(name),
+		})
+	}
+
+	if hoursNode, ok := node.GetOptionalChildByTag("business_hours"); ok {
+		hours := &contact.BusinessHours{
+			Timezone: hoursNode.AttrGetter().String("timezone"),
+			Days:     []contact.BusinessHoursDay{},
+		}
+		for _, config := range hoursNode.GetChildrenByTag("business_hours_config") {
+			attrs := config.AttrGetter()
+			day := contact.BusinessHoursDay{
+				Day:  attrs.String("day_of_week"),
+				Mode: attrs.String("mode"),
+			}
+			day.Open, _ = strconv.Atoi(attrs.OptionalString("open_time"))
+			day.Close, _ = strconv.Atoi(attrs.OptionalString("close_time"))
+			hours.Days = append(hours.Days, day)
+		}
+		profile.Hours = hours
+	}
+
+	return profile
+}
+
+func nodeText(node *waBinary.Node, tag string) string {
+	child, ok := node.GetOptionalChildByTag(tag)
+	if !ok {
+		return ""
+	}
+	value, _ := child.Content.([]byte)
+	return string(value)
+}
@@ -0,0 +1,119 @@
+package waclient
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/session"
+)
+
+const callRejectTimeout = 15 * time.Second
+
+type CallEvent struct {
+	Event          string    `json:"event"`
+	SessionName    string    `json:"session_name"`
+	CallID         string    `json:"call_id"`
+	From           string    `json:"from"`
+	CallCreator    string    `json:"call_creator"`
+	CallerPhone    string    `json:"caller_phone,omitempty"`
+	GroupJID       string    `json:"group_jid,omitempty"`
+	Media          string    `json:"media"`
+	IsGroup        bool      `json:"is_group"`
+	RemotePlatform string    `json:"remote_platform,omitempty"`
+	RemoteVersion  string    `json:"remote_version,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+	Rejected       bool      `json:"rejected"`
+	RejectError    string    `json:"reject_error,omitempty"`
+	ReplySent      bool      `json:"reply_sent"`
+}
+
+func (g *Gateway) getCallSettings(sessionName string) session.CallSettings {
+	return g.getSettings(sessionName).Calls
+}
+
+func (h *EventHandler) handleCallOffer(evt *events.CallOffer, sessionID string) {
+	callEvent := &CallEvent{
+		Event:          "call.received",
+		SessionName:    h.sessionName,
+		CallID:         evt.CallID,
+		From:           evt.From.String(),
+		CallCreator:    evt.CallCreator.String(),
+		Media:          "audio",
+		RemotePlatform: evt.RemotePlatform,
+		RemoteVersion:  evt.RemoteVersion,
+		Timestamp:      evt.Timestamp,
+	}
+	if !evt.CallCreatorAlt.IsEmpty() {
+		callEvent.CallerPhone = evt.CallCreatorAlt.User
+	} else {
+		callEvent.CallerPhone = evt.CallCreator.User
+	}
+	if !evt.GroupJID.IsEmpty() {
+		callEvent.IsGroup = true
+		callEvent.GroupJID = evt.GroupJID.String()
+	}
+	if evt.Data != nil {
+		if video := evt.Data.GetChildByTag("video"); video.Tag == "video" {
+			callEvent.Media = "video"
+		}
+	}
+
+	h.logger.InfoWithFields("Incoming call", map[string]interface{}{
+		"session_id": sessionID,
+		"call_id":    evt.CallID,
+		"from":       callEvent.CallCreator,
+		"media":      callEvent.Media,
+		"is_group":   callEvent.IsGroup,
+	})
+
+	settings := h.gateway.getCallSettings(h.sessionName)
+	if !settings.AutoReject {
+		h.deliverToWebhook(callEvent, sessionID)
+		return
+	}
+
+	// Rejecting and replying both go through the socket, so keep them off the
+	// event dispatch path.
+	go func() {
+		h.rejectCall(evt, callEvent, settings, sessionID)
+		h.deliverToWebhook(callEvent, sessionID)
+	}()
+}
+
+func (h *EventHandler) rejectCall(evt *events.CallOffer, callEvent *CallEvent, settings session.CallSettings, sessionID string) {
+	client := h.gateway.getClient(h.sessionName)
+	if client == nil {
+		return
+	}
+
+	if err := client.GetClient().RejectCall(evt.CallCreator, evt.CallID); err != nil {
+		callEvent.RejectError = err.Error()
+		h.logger.ErrorWithFields("Failed to reject call", map[string]interface{}{
+			"session_id": sessionID,
+			"call_id":    evt.CallID,
+			"error":      err.Error(),
+		})
+		return
+	}
+	callEvent.Rejected = true
+
+	if settings.RejectMessage == "" || callEvent.IsGroup {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callRejectTimeout)
+	defer cancel()
+
+	to := evt.CallCreator.ToNonAD().String()
+	if _, err := h.gateway.SendTextMessage(ctx, h.sessionName, to, settings.RejectMessage); err != nil {
+		h.logger.WarnWithFields("Failed to send call reject message", map[string]interface{}{
+			"session_id": sessionID,
+			"call_id":    evt.CallID,
+			"error":      err.Error(),
+		})
+		return
+	}
+	callEvent.ReplySent = true
+}
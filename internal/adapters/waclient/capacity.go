@@ -0,0 +1,97 @@
+package waclient
+
+import (
+	"sort"
+
+	"zpwoot/internal/core/session"
+)
+
+// Each event delivered to webhooks runs in its own goroutine and holds the
+// event until it is handed over. A session flooded with events (a large
+// group, a history backlog) could pile up goroutines and memory without
+// bound, so each session runs at most maxPendingEvents deliveries at once;
+// beyond that its event handling waits for one to finish, which slows only
+// that session down.
+
+func (g *Gateway) SetMaxSessions(limit int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxSessions = limit
+}
+
+func (g *Gateway) SetMaxPendingEvents(limit int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxPendingEvents = limit
+}
+
+func (g *Gateway) Capacity() session.Capacity {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	capacity := session.Capacity{
+		MaxSessions:      g.maxSessions,
+		Connected:        g.connectedLocked(""),
+		Loaded:           len(g.clients),
+		MaxPendingEvents: g.maxPendingEvents,
+	}
+	for name, slots := range g.eventSlots {
+		if pending := len(slots); pending > 0 {
+			capacity.Sessions = append(capacity.Sessions, session.SessionLoad{Name: name, PendingEvents: pending})
+		}
+	}
+	sort.Slice(capacity.Sessions, func(i, j int) bool {
+		return capacity.Sessions[i].PendingEvents > capacity.Sessions[j].PendingEvents
+	})
+	return capacity
+}
+
+// checkCapacity refuses to connect sessionName once maxSessions other
+// sessions are connected or connecting. The caller holds connectMu, so two
+// connects cannot both take the last slot.
+func (g *Gateway) checkCapacity(sessionName string) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return session.CheckCapacity(g.maxSessions, g.connectedLocked(sessionName))
+}
+
+// connectedLocked counts the sessions other than except that are connected
+// or on their way to it.
+func (g *Gateway) connectedLocked(except string) int {
+	connected := 0
+	for name, client := range g.clients {
+		if name == except {
+			continue
+		}
+		switch client.GetState() {
+		case StateConnecting, StateConnected, StateLoggedIn:
+			connected++
+		default:
+			if client.GetClient().IsConnected() {
+				connected++
+			}
+		}
+	}
+	return connected
+}
+
+// acquireEventSlot waits until the session may start another webhook
+// delivery and returns the function that frees the slot.
+func (g *Gateway) acquireEventSlot(sessionName string) func() {
+	g.mu.Lock()
+	slots, ok := g.eventSlots[sessionName]
+	if !ok && g.maxPendingEvents > 0 {
+		if g.eventSlots == nil {
+			g.eventSlots = make(map[string]chan struct{})
+		}
+		slots = make(chan struct{}, g.maxPendingEvents)
+		g.eventSlots[sessionName] = slots
+	}
+	g.mu.Unlock()
+
+	if slots == nil {
+		return func() {}
+	}
+	slots <- struct{}{}
+	return func() { <-slots }
+}
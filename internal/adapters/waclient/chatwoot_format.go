@@ -0,0 +1,108 @@
+package waclient
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+const mentionLookupTimeout = 5 * time.Second
+
+var (
+	waCodeRe   = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+	waBoldRe   = regexp.MustCompile(`\*(\S(?:[^*\n]*?\S)?)\*`)
+	waStrikeRe = regexp.MustCompile(`~(\S(?:[^~\n]*?\S)?)~`)
+)
+
+// chatwootContent rewrites a message's text for Chatwoot, so it renders as it
+// does in WhatsApp: emphasis becomes Markdown and mentions show the
+// contact's name rather than the number.
+func (h *EventHandler) chatwootContent(message *waE2E.Message, content string) string {
+	if content == "" {
+		return content
+	}
+
+	content = whatsAppToMarkdown(content)
+
+	mentioned := mentionedJIDs(message)
+	if len(mentioned) == 0 {
+		return content
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mentionLookupTimeout)
+	defer cancel()
+
+	contacts, err := h.gateway.LookupContacts(ctx, h.sessionName, mentioned)
+	if err != nil {
+		h.logger.DebugWithFields("Mentioned contacts not resolved", map[string]interface{}{
+			"session_name": h.sessionName,
+			"error":        err.Error(),
+		})
+	}
+
+	for _, raw := range mentioned {
+		jid, err := types.ParseJID(raw)
+		if err != nil {
+			continue
+		}
+		name := "+" + jid.User
+		if info := contacts[raw]; info != nil && info.Name != "" {
+			name = info.Name
+		}
+		content = strings.ReplaceAll(content, "@"+jid.User, "**@"+name+"**")
+	}
+
+	return content
+}
+
+// whatsAppToMarkdown rewrites WhatsApp emphasis into Markdown: *bold* becomes
+// **bold**, ~strike~ becomes ~~strike~~ and ```monospace``` becomes a code
+// span or, over several lines, a fenced block. _italic_, `code` and
+// "> quotes" read the same in both.
+func whatsAppToMarkdown(text string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range waCodeRe.FindAllStringIndex(text, -1) {
+		out.WriteString(whatsAppProse(text[last:loc[0]]))
+		out.WriteString(whatsAppCode(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	out.WriteString(whatsAppProse(text[last:]))
+	return out.String()
+}
+
+func whatsAppProse(text string) string {
+	text = waBoldRe.ReplaceAllString(text, "**$1**")
+	return waStrikeRe.ReplaceAllString(text, "~~$1~~")
+}
+
+func whatsAppCode(code string) string {
+	if !strings.HasPrefix(code, "```") {
+		return code
+	}
+
+	inner := strings.TrimSuffix(strings.TrimPrefix(code, "```"), "```")
+	if !strings.Contains(inner, "\n") {
+		return "`" + inner + "`"
+	}
+	return "```\n" + strings.Trim(inner, "\n") + "\n```"
+}
+
+// mentionedJIDs returns the users mentioned in a text or caption.
+func mentionedJIDs(message *waE2E.Message) []string {
+	switch {
+	case message.GetExtendedTextMessage() != nil:
+		return message.GetExtendedTextMessage().GetContextInfo().GetMentionedJID()
+	case message.GetImageMessage() != nil:
+		return message.GetImageMessage().GetContextInfo().GetMentionedJID()
+	case message.GetVideoMessage() != nil:
+		return message.GetVideoMessage().GetContextInfo().GetMentionedJID()
+	case message.GetDocumentMessage() != nil:
+		return message.GetDocumentMessage().GetContextInfo().GetMentionedJID()
+	}
+	return nil
+}
@@ -18,40 +18,67 @@ import (
 	"zpwoot/platform/logger"
 )
 
+// WhatsmeowLogger writes whatsmeow's logs to the application log. Loggers
+// of a session's client also hand every line to the session's protocol
+// debug capture, whatever the application log level.
 type WhatsmeowLogger struct {
-	logger *logger.Logger
+	logger      *logger.Logger
+	module      string
+	sessionName string
+	debug       *ProtocolDebug
 }
 
 func NewWhatsmeowLogger(logger *logger.Logger) waLog.Logger {
 	return &WhatsmeowLogger{logger: logger}
 }
 
-func (w *WhatsmeowLogger) Errorf(msg string, args ...interface{}) {
-	w.logger.ErrorWithFields(fmt.Sprintf(msg, args...), map[string]interface{}{
+// newSessionLogger returns the logger for a session's client.
+func newSessionLogger(logger *logger.Logger, sessionName string, debug *ProtocolDebug) waLog.Logger {
+	return &WhatsmeowLogger{logger: logger, module: "Client", sessionName: sessionName, debug: debug}
+}
+
+func (w *WhatsmeowLogger) fields() map[string]interface{} {
+	fields := map[string]interface{}{
 		"module": "whatsmeow",
-	})
+	}
+	if w.sessionName != "" {
+		fields["session_name"] = w.sessionName
+	}
+	return fields
+}
+
+func (w *WhatsmeowLogger) Errorf(msg string, args ...interface{}) {
+	message := fmt.Sprintf(msg, args...)
+	w.debug.record(w.sessionName, "error", w.module, message)
+	w.logger.ErrorWithFields(message, w.fields())
 }
 
 func (w *WhatsmeowLogger) Warnf(msg string, args ...interface{}) {
-	w.logger.WarnWithFields(fmt.Sprintf(msg, args...), map[string]interface{}{
-		"module": "whatsmeow",
-	})
+	message := fmt.Sprintf(msg, args...)
+	w.debug.record(w.sessionName, "warn", w.module, message)
+	w.logger.WarnWithFields(message, w.fields())
 }
 
 func (w *WhatsmeowLogger) Infof(msg string, args ...interface{}) {
-	w.logger.InfoWithFields(fmt.Sprintf(msg, args...), map[string]interface{}{
-		"module": "whatsmeow",
-	})
+	message := fmt.Sprintf(msg, args...)
+	w.debug.record(w.sessionName, "info", w.module, message)
+	w.logger.InfoWithFields(message, w.fields())
 }
 
 func (w *WhatsmeowLogger) Debugf(msg string, args ...interface{}) {
-	w.logger.DebugWithFields(fmt.Sprintf(msg, args...), map[string]interface{}{
-		"module": "whatsmeow",
-	})
+	message := fmt.Sprintf(msg, args...)
+	w.debug.record(w.sessionName, "debug", w.module, message)
+	w.logger.DebugWithFields(message, w.fields())
 }
 
 func (w *WhatsmeowLogger) Sub(module string) waLog.Logger {
-	return &WhatsmeowLogger{logger: w.logger}
+	sub := *w
+	if w.module != "" {
+		sub.module = w.module + "/" + module
+	} else {
+		sub.module = module
+	}
+	return &sub
 }
 
 type ConnectionState int
@@ -81,10 +108,14 @@ func (s ConnectionState) String() string {
 	}
 }
 
+// QRCodeEvent is one code of a pairing. Attempt counts the codes shown so
+// far; MaxAttempts is the limit, when the session sets one.
 type QRCodeEvent struct {
 	SessionName string
 	QRCode      string
 	ExpiresAt   time.Time
+	Attempt     int
+	MaxAttempts int
 }
 
 type ClientConfig struct {
@@ -93,6 +124,10 @@ type ClientConfig struct {
 	Container   *sqlstore.Container
 	Logger      *logger.Logger
 	ProxyConfig *session.ProxyConfig
+	Debug       *ProtocolDebug
+	// MaxQRCodes is read as each pairing starts; zero leaves the number of
+	// codes to WhatsApp.
+	MaxQRCodes func() int
 }
 
 type Client struct {
@@ -106,12 +141,23 @@ type Client struct {
 	lastActivity time.Time
 	errorMessage string
 
+	connectedAt       *time.Time
+	connections       int
+	keepAliveFailures int
+
 	eventHandlers []func(interface{})
 
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	qrCancel    context.CancelFunc
+	qrCode      string
+	qrExpiresAt time.Time
+	maxQRCodes  func() int
+
 	proxyConfig *session.ProxyConfig
+
+	throttle session.Throttle
 }
 
 func validateConfig(config ClientConfig) error {
@@ -141,7 +187,7 @@ func NewClient(config ClientConfig) (*Client, error) {
 		device = deviceStore
 	}
 
-	waLogger := NewWhatsmeowLogger(config.Logger)
+	waLogger := newSessionLogger(config.Logger, config.SessionName, config.Debug)
 	whatsmeowClient := whatsmeow.NewClient(device, waLogger)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -158,6 +204,7 @@ func NewClient(config ClientConfig) (*Client, error) {
 		ctx:           ctx,
 		cancel:        cancel,
 		proxyConfig:   config.ProxyConfig,
+		maxQRCodes:    config.MaxQRCodes,
 	}
 
 	client.setupEventHandlers()
@@ -249,15 +296,27 @@ func (c *Client) connectNewDevice() {
 		"session_name": c.sessionName,
 	})
 
+	qrCtx, qrChan, err := c.startQRLoop()
+	if err != nil {
+		c.logger.ErrorWithFields("Failed to request QR channel", map[string]interface{}{
+			"session_name": c.sessionName,
+			"error":        err.Error(),
+		})
+		c.setError(fmt.Sprintf("connection failed: %v", err))
+		return
+	}
+
 	if err := c.client.Connect(); err != nil {
 		c.logger.ErrorWithFields("Failed to connect new device", map[string]interface{}{
 			"session_name": c.sessionName,
 			"error":        err.Error(),
 		})
+		c.CancelPairing()
 		c.setError(fmt.Sprintf("connection failed: %v", err))
 		return
 	}
 
+	go c.runQRLoop(qrCtx, qrChan)
 }
 
 func (c *Client) waitForAuthentication() {
@@ -330,6 +389,14 @@ func (c *Client) handleEvent(evt interface{}) {
 		c.handlePairSuccessEvent(v)
 	case *events.PairError:
 		c.handlePairErrorEvent(v)
+	case *events.KeepAliveTimeout:
+		c.mu.Lock()
+		c.keepAliveFailures = v.ErrorCount
+		c.mu.Unlock()
+	case *events.KeepAliveRestored:
+		c.mu.Lock()
+		c.keepAliveFailures = 0
+		c.mu.Unlock()
 	default:
 
 		c.logger.DebugWithFields("WhatsApp event received", map[string]interface{}{
@@ -366,7 +433,11 @@ func (c *Client) handleConnectedEvent(_ *events.Connected) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := time.Now()
 	c.setState(StateConnected)
+	c.connectedAt = &now
+	c.connections++
+	c.keepAliveFailures = 0
 	c.logger.InfoWithFields("WhatsApp connected", map[string]interface{}{
 		"session_name": c.sessionName,
 	})
@@ -435,7 +506,7 @@ func (c *Client) Disconnect() error {
 	return nil
 }
 
-func (c *Client) Logout() error {
+func (c *Client) Logout(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -447,7 +518,7 @@ func (c *Client) Logout() error {
 		"session_name": c.sessionName,
 	})
 
-	if err := c.client.Logout(context.Background()); err != nil {
+	if err := c.client.Logout(ctx); err != nil {
 		return fmt.Errorf("failed to logout: %w", err)
 	}
 
@@ -472,16 +543,35 @@ func (c *Client) IsLoggedIn() bool {
 	return c.state == StateLoggedIn
 }
 
-func (c *Client) GetQRCode() (string, error) {
-	if c.IsLoggedIn() {
-		return "", fmt.Errorf("client is already logged in")
-	}
+// ConnectionInfo snapshots the socket's state and history since the client
+// was created.
+func (c *Client) ConnectionInfo() session.ConnectionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if !c.IsConnected() {
-		return "", fmt.Errorf("client is not connected")
+	info := session.ConnectionInfo{
+		State:             c.state.String(),
+		LastActivityAt:    c.lastActivity,
+		Reconnects:        max(c.connections-1, 0),
+		KeepAliveFailures: c.keepAliveFailures,
 	}
+	if c.connectedAt != nil {
+		connectedAt := *c.connectedAt
+		info.ConnectedAt = &connectedAt
+	}
+	return info
+}
 
-	return "", fmt.Errorf("QR code generation handled externally")
+// CurrentQRCode is the QR code of the pairing in progress, if one is being
+// shown and has not expired.
+func (c *Client) CurrentQRCode() (string, time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.qrCode == "" || time.Now().After(c.qrExpiresAt) {
+		return "", time.Time{}, false
+	}
+	return c.qrCode, c.qrExpiresAt, true
 }
 
 func (c *Client) SetProxy(proxy *session.ProxyConfig) error {
@@ -0,0 +1,247 @@
+package waclient
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+type OrderLineItem struct {
+	ProductID string  `json:"product_id,omitempty"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency,omitempty"`
+}
+
+type OrderEvent struct {
+	SessionName      string          `json:"session_name"`
+	MessageID        string          `json:"message_id"`
+	Chat             string          `json:"chat"`
+	Sender           string          `json:"sender"`
+	FromMe           bool            `json:"from_me"`
+	Timestamp        time.Time       `json:"timestamp"`
+	OrderID          string          `json:"order_id"`
+	Title            string          `json:"title,omitempty"`
+	Message          string          `json:"message,omitempty"`
+	Status           string          `json:"status,omitempty"`
+	Surface          string          `json:"surface,omitempty"`
+	SellerJID        string          `json:"seller_jid,omitempty"`
+	Token            string          `json:"token,omitempty"`
+	CatalogType      string          `json:"catalog_type,omitempty"`
+	ItemCount        int             `json:"item_count"`
+	TotalAmount      float64         `json:"total_amount"`
+	Currency         string          `json:"currency,omitempty"`
+	Items            []OrderLineItem `json:"items,omitempty"`
+	RequestMessageID string          `json:"request_message_id,omitempty"`
+}
+
+type PaymentEventKind string
+
+const (
+	PaymentEventRequest PaymentEventKind = "request"
+	PaymentEventSend    PaymentEventKind = "send"
+	PaymentEventCancel  PaymentEventKind = "cancel"
+	PaymentEventDecline PaymentEventKind = "decline"
+	PaymentEventInvite  PaymentEventKind = "invite"
+)
+
+type PaymentEvent struct {
+	SessionName        string           `json:"session_name"`
+	MessageID          string           `json:"message_id"`
+	Chat               string           `json:"chat"`
+	Sender             string           `json:"sender"`
+	FromMe             bool             `json:"from_me"`
+	Timestamp          time.Time        `json:"timestamp"`
+	Kind               PaymentEventKind `json:"kind"`
+	Amount             float64          `json:"amount,omitempty"`
+	Currency           string           `json:"currency,omitempty"`
+	RequestFrom        string           `json:"request_from,omitempty"`
+	Note               string           `json:"note,omitempty"`
+	ReferenceMessageID string           `json:"reference_message_id,omitempty"`
+	TransactionData    string           `json:"transaction_data,omitempty"`
+	ServiceType        string           `json:"service_type,omitempty"`
+	ExpiresAt          *time.Time       `json:"expires_at,omitempty"`
+}
+
+// nativeFlowOrderParams mirrors the subset of the review_and_pay / review_order
+// native flow button params that carries the order line items.
+type nativeFlowOrderParams struct {
+	ReferenceID string `json:"reference_id"`
+	Currency    string `json:"currency"`
+	TotalAmount struct {
+		Value  int64 `json:"value"`
+		Offset int   `json:"offset"`
+	} `json:"total_amount"`
+	Order struct {
+		Status string `json:"status"`
+		Items  []struct {
+			RetailerID string `json:"retailer_id"`
+			ProductID  string `json:"product_id"`
+			Name       string `json:"name"`
+			Quantity   int    `json:"quantity"`
+			Amount     struct {
+				Value  int64 `json:"value"`
+				Offset int   `json:"offset"`
+			} `json:"amount"`
+		} `json:"items"`
+	} `json:"order"`
+}
+
+func (m *MessageMapper) ExtractOrderEvent(sessionName string, evt *events.Message) *OrderEvent {
+	if evt == nil || evt.Message == nil {
+		return nil
+	}
+
+	if order := evt.Message.GetOrderMessage(); order != nil {
+		orderEvent := m.newOrderEvent(sessionName, evt)
+		orderEvent.OrderID = order.GetOrderID()
+		orderEvent.Title = order.GetOrderTitle()
+		orderEvent.Message = order.GetMessage()
+		orderEvent.SellerJID = order.GetSellerJID()
+		orderEvent.Token = order.GetToken()
+		orderEvent.CatalogType = order.GetCatalogType()
+		orderEvent.ItemCount = int(order.GetItemCount())
+		orderEvent.TotalAmount = float64(order.GetTotalAmount1000()) / 1000
+		orderEvent.Currency = order.GetTotalCurrencyCode()
+		if order.Status != nil {
+			orderEvent.Status = strings.ToLower(order.GetStatus().String())
+		}
+		if order.Surface != nil {
+			orderEvent.Surface = strings.ToLower(order.GetSurface().String())
+		}
+		if key := order.GetOrderRequestMessageID(); key != nil {
+			orderEvent.RequestMessageID = key.GetID()
+		}
+		return orderEvent
+	}
+
+	nativeFlow := evt.Message.GetInteractiveMessage().GetNativeFlowMessage()
+	for _, button := range nativeFlow.GetButtons() {
+		if button.GetName() != "review_and_pay" && button.GetName() != "review_order" {
+			continue
+		}
+
+		var params nativeFlowOrderParams
+		if err := json.Unmarshal([]byte(button.GetButtonParamsJSON()), &params); err != nil {
+			return nil
+		}
+
+		orderEvent := m.newOrderEvent(sessionName, evt)
+		orderEvent.OrderID = params.ReferenceID
+		orderEvent.Status = params.Order.Status
+		orderEvent.Currency = params.Currency
+		orderEvent.TotalAmount = scaleAmount(params.TotalAmount.Value, params.TotalAmount.Offset)
+		orderEvent.Message = evt.Message.GetInteractiveMessage().GetBody().GetText()
+
+		for _, item := range params.Order.Items {
+			productID := item.ProductID
+			if productID == "" {
+				productID = item.RetailerID
+			}
+			orderEvent.Items = append(orderEvent.Items, OrderLineItem{
+				ProductID: productID,
+				Name:      item.Name,
+				Quantity:  item.Quantity,
+				Amount:    scaleAmount(item.Amount.Value, item.Amount.Offset),
+				Currency:  params.Currency,
+			})
+			orderEvent.ItemCount += item.Quantity
+		}
+
+		return orderEvent
+	}
+
+	return nil
+}
+
+func (m *MessageMapper) ExtractPaymentEvent(sessionName string, evt *events.Message) *PaymentEvent {
+	if evt == nil || evt.Message == nil {
+		return nil
+	}
+
+	message := evt.Message
+	paymentEvent := &PaymentEvent{
+		SessionName: sessionName,
+		MessageID:   evt.Info.ID,
+		Chat:        evt.Info.Chat.String(),
+		Sender:      evt.Info.Sender.String(),
+		FromMe:      evt.Info.IsFromMe,
+		Timestamp:   evt.Info.Timestamp,
+	}
+
+	switch {
+	case message.GetRequestPaymentMessage() != nil:
+		request := message.GetRequestPaymentMessage()
+		paymentEvent.Kind = PaymentEventRequest
+		paymentEvent.RequestFrom = request.GetRequestFrom()
+		paymentEvent.Note = noteText(request.GetNoteMessage())
+		if amount := request.GetAmount(); amount != nil {
+			paymentEvent.Amount = scaleAmount(amount.GetValue(), int(amount.GetOffset()))
+			paymentEvent.Currency = amount.GetCurrencyCode()
+		} else {
+			paymentEvent.Amount = float64(request.GetAmount1000()) / 1000
+			paymentEvent.Currency = request.GetCurrencyCodeIso4217()
+		}
+		if expiry := request.GetExpiryTimestamp(); expiry > 0 {
+			expiresAt := time.Unix(expiry, 0)
+			paymentEvent.ExpiresAt = &expiresAt
+		}
+	case message.GetSendPaymentMessage() != nil:
+		send := message.GetSendPaymentMessage()
+		paymentEvent.Kind = PaymentEventSend
+		paymentEvent.Note = noteText(send.GetNoteMessage())
+		paymentEvent.TransactionData = send.GetTransactionData()
+		paymentEvent.ReferenceMessageID = send.GetRequestMessageKey().GetID()
+	case message.GetCancelPaymentRequestMessage() != nil:
+		paymentEvent.Kind = PaymentEventCancel
+		paymentEvent.ReferenceMessageID = message.GetCancelPaymentRequestMessage().GetKey().GetID()
+	case message.GetDeclinePaymentRequestMessage() != nil:
+		paymentEvent.Kind = PaymentEventDecline
+		paymentEvent.ReferenceMessageID = message.GetDeclinePaymentRequestMessage().GetKey().GetID()
+	case message.GetPaymentInviteMessage() != nil:
+		invite := message.GetPaymentInviteMessage()
+		paymentEvent.Kind = PaymentEventInvite
+		paymentEvent.ServiceType = strings.ToLower(invite.GetServiceType().String())
+		if expiry := invite.GetExpiryTimestamp(); expiry > 0 {
+			expiresAt := time.Unix(expiry, 0)
+			paymentEvent.ExpiresAt = &expiresAt
+		}
+	default:
+		return nil
+	}
+
+	return paymentEvent
+}
+
+func (m *MessageMapper) newOrderEvent(sessionName string, evt *events.Message) *OrderEvent {
+	return &OrderEvent{
+		SessionName: sessionName,
+		MessageID:   evt.Info.ID,
+		Chat:        evt.Info.Chat.String(),
+		Sender:      evt.Info.Sender.String(),
+		FromMe:      evt.Info.IsFromMe,
+		Timestamp:   evt.Info.Timestamp,
+	}
+}
+
+func noteText(note *waE2E.Message) string {
+	if note == nil {
+		return ""
+	}
+	if text := note.GetExtendedTextMessage().GetText(); text != "" {
+		return text
+	}
+	return note.GetConversation()
+}
+
+func scaleAmount(value int64, offset int) float64 {
+	amount := float64(value)
+	for i := 0; i < offset; i++ {
+		amount /= 10
+	}
+	return amount
+}
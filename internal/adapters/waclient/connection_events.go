@@ -0,0 +1,100 @@
+package waclient
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Reasons carried by session.disconnected.
+const (
+	DisconnectConnectionLost = "connection_lost"
+	DisconnectRequested      = "requested"
+)
+
+// QRUpdatedEvent is delivered to webhooks every time a new pairing QR code
+// is issued. Code is the raw string to encode; QRCode is the same code
+// rendered as a PNG data URI, ready for an <img> tag. Attempt counts the
+// codes of the pairing, up to MaxAttempts when the session limits them.
+type QRUpdatedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	Code        string    `json:"code"`
+	QRCode      string    `json:"qr_code,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Attempt     int       `json:"attempt,omitempty"`
+	MaxAttempts int       `json:"max_attempts,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// SessionConnectedEvent is delivered to webhooks when a session is online
+// and authenticated.
+type SessionConnectedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	DeviceJID   string    `json:"device_jid,omitempty"`
+	PushName    string    `json:"push_name,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// SessionDisconnectedEvent is delivered to webhooks when a session goes
+// offline without being logged out.
+type SessionDisconnectedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	Reason      string    `json:"reason"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// NewQRUpdatedEvent renders a QR code for webhooks. A code that cannot be
+// rendered is still delivered, without the image.
+func NewQRUpdatedEvent(sessionName, code string, expiresAt time.Time) *QRUpdatedEvent {
+	evt := &QRUpdatedEvent{
+		Event:       "qr.updated",
+		SessionName: sessionName,
+		Code:        code,
+		ExpiresAt:   expiresAt,
+		Timestamp:   time.Now(),
+	}
+	if png, err := qrcode.Encode(code, qrcode.Medium, 256); err == nil {
+		evt.QRCode = "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+	}
+	return evt
+}
+
+func NewSessionConnectedEvent(sessionName, deviceJID, pushName string) *SessionConnectedEvent {
+	return &SessionConnectedEvent{
+		Event:       "session.connected",
+		SessionName: sessionName,
+		DeviceJID:   deviceJID,
+		PushName:    pushName,
+		Timestamp:   time.Now(),
+	}
+}
+
+func NewSessionDisconnectedEvent(sessionName, reason string) *SessionDisconnectedEvent {
+	return &SessionDisconnectedEvent{
+		Event:       "session.disconnected",
+		SessionName: sessionName,
+		Reason:      reason,
+		Timestamp:   time.Now(),
+	}
+}
+
+// connectedEvent describes the session's own device, which whatsmeow's
+// Connected event does not carry.
+func (h *EventHandler) connectedEvent() *SessionConnectedEvent {
+	evt := NewSessionConnectedEvent(h.sessionName, "", "")
+
+	client := h.gateway.getClient(h.sessionName)
+	if client == nil || client.GetClient() == nil || client.GetClient().Store == nil {
+		return evt
+	}
+	store := client.GetClient().Store
+	if store.ID != nil {
+		evt.DeviceJID = store.ID.String()
+	}
+	evt.PushName = store.PushName
+	return evt
+}
@@ -0,0 +1,47 @@
+package waclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/conversation"
+)
+
+// ConversationStates gives the dialog state bots keep per chat, attached to
+// the inbound message webhooks of that chat.
+type ConversationStates interface {
+	Lookup(ctx context.Context, sessionID uuid.UUID, chatJID string) *conversation.State
+}
+
+func (g *Gateway) SetConversationStates(states ConversationStates) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.conversationStates = states
+}
+
+func (g *Gateway) getConversationStates() ConversationStates {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.conversationStates
+}
+
+// conversationState returns the state of the chat an inbound message came
+// from, or nil. The session's own messages are left without it.
+func (h *EventHandler) conversationState(msg *events.Message, sessionID string) *conversation.State {
+	states := h.gateway.getConversationStates()
+	if states == nil || msg.Info.IsFromMe {
+		return nil
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return states.Lookup(ctx, id, msg.Info.Chat.ToNonAD().String())
+}
@@ -0,0 +1,183 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/proto/waAdv"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/util/keys"
+
+	"zpwoot/internal/core/session"
+)
+
+func (g *Gateway) ExportDeviceCredentials(ctx context.Context, sessionName string) (*session.DeviceCredentials, error) {
+	g.logger.InfoWithFields("Exporting device credentials", map[string]interface{}{
+		"session_name": sessionName,
+	})
+
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+
+	device := client.GetClient().Store
+	if device == nil || device.ID == nil || device.Account == nil {
+		return nil, session.ErrSessionNotPaired
+	}
+
+	credentials := &session.DeviceCredentials{
+		DeviceJID:             device.ID.String(),
+		RegistrationID:        device.RegistrationID,
+		NoiseKey:              device.NoiseKey.Priv[:],
+		IdentityKey:           device.IdentityKey.Priv[:],
+		SignedPreKey:          device.SignedPreKey.Priv[:],
+		SignedPreKeyID:        device.SignedPreKey.KeyID,
+		SignedPreKeySignature: device.SignedPreKey.Signature[:],
+		AdvSecretKey:          device.AdvSecretKey,
+		AccountDetails:        device.Account.GetDetails(),
+		AccountSignature:      device.Account.GetAccountSignature(),
+		AccountSignatureKey:   device.Account.GetAccountSignatureKey(),
+		DeviceSignature:       device.Account.GetDeviceSignature(),
+		Platform:              device.Platform,
+		BusinessName:          device.BusinessName,
+		PushName:              device.PushName,
+	}
+
+	if !device.LID.IsEmpty() {
+		credentials.LID = device.LID.String()
+	}
+
+	g.logger.InfoWithFields("Device credentials exported", map[string]interface{}{
+		"session_name": sessionName,
+		"device_jid":   credentials.DeviceJID,
+	})
+
+	return credentials, nil
+}
+
+func (g *Gateway) ImportDeviceCredentials(ctx context.Context, sessionName string, credentials *session.DeviceCredentials) error {
+	g.logger.InfoWithFields("Importing device credentials", map[string]interface{}{
+		"session_name": sessionName,
+		"device_jid":   credentials.DeviceJID,
+	})
+
+	jid, err := types.ParseJID(credentials.DeviceJID)
+	if err != nil {
+		return fmt.Errorf("invalid device JID format: %w", err)
+	}
+
+	existing, err := g.container.GetDevice(ctx, jid)
+	if err != nil {
+		return fmt.Errorf("failed to check device store: %w", err)
+	}
+	if existing != nil {
+		return session.ErrDeviceAlreadyImported
+	}
+
+	device, err := g.buildDeviceFromCredentials(jid, credentials)
+	if err != nil {
+		return err
+	}
+
+	if err := device.Save(ctx); err != nil {
+		return fmt.Errorf("failed to save device: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if old, exists := g.clients[sessionName]; exists {
+		if old.IsConnected() {
+			_ = old.Disconnect()
+		}
+		delete(g.clients, sessionName)
+	}
+
+	client, err := NewClient(ClientConfig{
+		SessionName: sessionName,
+		Device:      device,
+		Container:   g.container,
+		Logger:      g.logger,
+		Debug:       g.debug,
+		MaxQRCodes:  g.maxQRCodes(sessionName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create WhatsApp client: %w", err)
+	}
+
+	g.setupEventHandlers(client, sessionName)
+
+	g.clients[sessionName] = client
+
+	g.logger.InfoWithFields("Device credentials imported", map[string]interface{}{
+		"session_name": sessionName,
+		"device_jid":   credentials.DeviceJID,
+	})
+
+	return nil
+}
+
+func (g *Gateway) buildDeviceFromCredentials(jid types.JID, credentials *session.DeviceCredentials) (*store.Device, error) {
+	noiseKey, err := toKey32(credentials.NoiseKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid noise key: %w", err)
+	}
+
+	identityKey, err := toKey32(credentials.IdentityKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+
+	signedPreKey, err := toKey32(credentials.SignedPreKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed pre-key: %w", err)
+	}
+
+	if len(credentials.SignedPreKeySignature) != 64 {
+		return nil, fmt.Errorf("invalid signed pre-key signature length: %d", len(credentials.SignedPreKeySignature))
+	}
+	var signature [64]byte
+	copy(signature[:], credentials.SignedPreKeySignature)
+
+	device := g.container.NewDevice()
+	device.ID = &jid
+	device.RegistrationID = credentials.RegistrationID
+	device.NoiseKey = keys.NewKeyPairFromPrivateKey(noiseKey)
+	device.IdentityKey = keys.NewKeyPairFromPrivateKey(identityKey)
+	device.SignedPreKey = &keys.PreKey{
+		KeyPair:   *keys.NewKeyPairFromPrivateKey(signedPreKey),
+		KeyID:     credentials.SignedPreKeyID,
+		Signature: &signature,
+	}
+	device.AdvSecretKey = credentials.AdvSecretKey
+	device.Account = &waAdv.ADVSignedDeviceIdentity{
+		Details:             credentials.AccountDetails,
+		AccountSignature:    credentials.AccountSignature,
+		AccountSignatureKey: credentials.AccountSignatureKey,
+		DeviceSignature:     credentials.DeviceSignature,
+	}
+	device.Platform = credentials.Platform
+	device.BusinessName = credentials.BusinessName
+	device.PushName = credentials.PushName
+
+	if credentials.LID != "" {
+		lid, err := types.ParseJID(credentials.LID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LID format: %w", err)
+		}
+		device.LID = lid
+	}
+
+	return device, nil
+}
+
+func toKey32(raw []byte) ([32]byte, error) {
+	var key [32]byte
+	if len(raw) != len(key) {
+		return key, fmt.Errorf("expected %d bytes, got %d", len(key), len(raw))
+	}
+	copy(key[:], raw)
+	return key, nil
+}
@@ -0,0 +1,145 @@
+package waclient
+
+import (
+	"sync"
+	"time"
+
+	"zpwoot/internal/core/session"
+)
+
+// ProtocolDebug implements session.ProtocolDebugger. Every whatsmeow logger
+// a session's client owns writes through it, so a capture switched on
+// after the client connected still sees everything the client logs.
+type ProtocolDebug struct {
+	mu       sync.RWMutex
+	captures map[string]*protocolCapture
+}
+
+func NewProtocolDebug() *ProtocolDebug {
+	return &ProtocolDebug{captures: make(map[string]*protocolCapture)}
+}
+
+// protocolCapture is a ring of the last capacity entries of one session.
+type protocolCapture struct {
+	mu      sync.Mutex
+	info    session.DebugCapture
+	rank    int
+	entries []session.DebugLogEntry
+	next    int
+}
+
+// StartCapture starts a new capture for the session, dropping the entries
+// of any earlier one.
+func (d *ProtocolDebug) StartCapture(sessionName string, options session.DebugCaptureOptions) *session.DebugCapture {
+	now := time.Now()
+	capture := &protocolCapture{
+		info: session.DebugCapture{
+			SessionName: sessionName,
+			Level:       options.Level,
+			Capacity:    options.Capacity,
+			StartedAt:   now,
+			ExpiresAt:   now.Add(options.Duration),
+		},
+		rank: session.DebugLevelRank(options.Level),
+	}
+
+	d.mu.Lock()
+	d.captures[sessionName] = capture
+	d.mu.Unlock()
+
+	return capture.snapshot(now)
+}
+
+// StopCapture ends the session's capture and forgets its entries.
+func (d *ProtocolDebug) StopCapture(sessionName string) (*session.DebugCapture, bool) {
+	d.mu.Lock()
+	capture, ok := d.captures[sessionName]
+	delete(d.captures, sessionName)
+	d.mu.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+	return capture.snapshot(time.Now()), true
+}
+
+// CaptureLogs returns the session's captured entries at or above level,
+// oldest first, at most the last limit of them when limit is positive.
+func (d *ProtocolDebug) CaptureLogs(sessionName, level string, limit int) (*session.DebugCapture, []session.DebugLogEntry, bool) {
+	d.mu.RLock()
+	capture, ok := d.captures[sessionName]
+	d.mu.RUnlock()
+
+	if !ok {
+		return nil, nil, false
+	}
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+
+	rank := session.DebugLevelRank(level)
+	logs := make([]session.DebugLogEntry, 0, len(capture.entries))
+	for i := range capture.entries {
+		entry := capture.entries[(capture.next+i)%len(capture.entries)]
+		if session.DebugLevelRank(entry.Level) >= rank {
+			logs = append(logs, entry)
+		}
+	}
+	if limit > 0 && len(logs) > limit {
+		logs = logs[len(logs)-limit:]
+	}
+
+	return capture.snapshotLocked(time.Now()), logs, true
+}
+
+// record keeps a log line for the session when a capture is running for it
+// and the line is at or above the capture's level.
+func (d *ProtocolDebug) record(sessionName, level, module, message string) {
+	if d == nil || sessionName == "" {
+		return
+	}
+
+	d.mu.RLock()
+	capture, ok := d.captures[sessionName]
+	d.mu.RUnlock()
+	if !ok || session.DebugLevelRank(level) < capture.rank {
+		return
+	}
+
+	now := time.Now()
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+
+	if !now.Before(capture.info.ExpiresAt) {
+		return
+	}
+
+	entry := session.DebugLogEntry{Time: now, Level: level, Module: module, Message: message}
+	if len(capture.entries) < capture.info.Capacity {
+		capture.entries = append(capture.entries, entry)
+		return
+	}
+	capture.entries[capture.next] = entry
+	capture.next = (capture.next + 1) % capture.info.Capacity
+	capture.info.Dropped++
+}
+
+func (c *protocolCapture) snapshot(now time.Time) *session.DebugCapture {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.snapshotLocked(now)
+}
+
+func (c *protocolCapture) snapshotLocked(now time.Time) *session.DebugCapture {
+	info := c.info
+	info.Active = now.Before(info.ExpiresAt)
+	info.Entries = len(c.entries)
+	return &info
+}
+
+// ProtocolDebug returns the protocol debug captures of the gateway's
+// sessions.
+func (g *Gateway) ProtocolDebug() *ProtocolDebug {
+	return g.debug
+}
@@ -0,0 +1,62 @@
+package waclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// InboundDeduplicator recognises inbound messages that were already
+// processed, so a replay after a reconnect does not produce a second webhook
+// or Chatwoot message.
+type InboundDeduplicator interface {
+	Seen(ctx context.Context, sessionID uuid.UUID, zpMessageID string) bool
+}
+
+func (g *Gateway) SetDeduplicator(dedup InboundDeduplicator) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.dedup = dedup
+}
+
+func (g *Gateway) getDeduplicator() InboundDeduplicator {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.dedup
+}
+
+// isDuplicate reports whether evt is a message this session already handled.
+// Only decrypted messages are checked: an undecryptable message is followed
+// by a retry with the same ID that must still go through.
+func (h *EventHandler) isDuplicate(evt interface{}, sessionID string) bool {
+	msg, ok := evt.(*events.Message)
+	if !ok {
+		return false
+	}
+
+	dedup := h.gateway.getDeduplicator()
+	if dedup == nil {
+		return false
+	}
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	if !dedup.Seen(ctx, sessionUUID, msg.Info.ID) {
+		return false
+	}
+
+	h.logger.DebugWithFields("Duplicate inbound message dropped", map[string]interface{}{
+		"module":     "events",
+		"session_id": sessionID,
+		"message_id": msg.Info.ID,
+	})
+
+	return true
+}
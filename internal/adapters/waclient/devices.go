@@ -0,0 +1,185 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+
+	"zpwoot/internal/core/session"
+)
+
+const deviceSyncTimeout = 30 * time.Second
+
+// DeviceAddedEvent is delivered to webhooks when a companion device is
+// linked to the session's account after the session first listed its
+// devices.
+type DeviceAddedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	JID         string    `json:"jid"`
+	DeviceID    int       `json:"device_id"`
+	Platform    string    `json:"platform,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// DeviceObserver follows the devices registered under the session's
+// account.
+type DeviceObserver interface {
+	Sync(ctx context.Context, sessionID uuid.UUID, sessionName string)
+	Seen(ctx context.Context, sessionID uuid.UUID, sessionName string, deviceID int, platform string, at time.Time)
+}
+
+func (g *Gateway) SetDeviceObserver(observer DeviceObserver) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.devices = observer
+}
+
+func (g *Gateway) getDeviceObserver() DeviceObserver {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.devices
+}
+
+// FetchDevices implements session.DeviceSource. WhatsApp tells the
+// platform of the phone only; this session's own device reports the OS it
+// was linked as.
+func (g *Gateway) FetchDevices(ctx context.Context, sessionName string) ([]*session.Device, error) {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	device := client.GetClient().Store
+	if device == nil || device.ID == nil {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+	own := *device.ID
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var jids []types.JID
+	err = runWithContext(opCtx, func() error {
+		var err error
+		jids, err = client.GetClient().GetUserDevicesContext(opCtx, []types.JID{own.ToNonAD()})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", wrapContextError(err))
+	}
+
+	devices := make([]*session.Device, 0, len(jids)+1)
+	seenOwn := false
+	for _, jid := range jids {
+		if jid.User != own.User {
+			continue
+		}
+		if jid.Device == own.Device {
+			seenOwn = true
+		}
+		devices = append(devices, newDevice(jid, own, device.Platform))
+	}
+	// whatsmeow may leave the local device out of the list.
+	if !seenOwn {
+		devices = append(devices, newDevice(own, own, device.Platform))
+	}
+
+	return devices, nil
+}
+
+func newDevice(jid, own types.JID, phonePlatform string) *session.Device {
+	device := &session.Device{
+		ID:      int(jid.Device),
+		JID:     jid.String(),
+		Primary: jid.Device == 0,
+		Current: jid.Device == own.Device,
+	}
+	switch {
+	case device.Primary:
+		device.Platform = phonePlatform
+	case device.Current:
+		device.Platform = store.DeviceProps.GetOs()
+	}
+	return device
+}
+
+// EmitDeviceAdded delivers a newly linked companion as device.added through
+// the session's inbound pipeline.
+func (g *Gateway) EmitDeviceAdded(_ context.Context, sessionName string, device *session.Device) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return
+	}
+
+	client.notifyEventHandlers(&DeviceAddedEvent{
+		Event:       "device.added",
+		SessionName: sessionName,
+		JID:         device.JID,
+		DeviceID:    device.ID,
+		Platform:    device.Platform,
+		Timestamp:   device.FirstSeenAt,
+	})
+}
+
+// syncDevices looks at the account's devices in the background once the
+// session connects, catching companions linked while it was offline.
+func (h *EventHandler) syncDevices(sessionID string) {
+	observer := h.gateway.getDeviceObserver()
+	if observer == nil {
+		return
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deviceSyncTimeout)
+		defer cancel()
+		observer.Sync(ctx, id, h.sessionName)
+	}()
+}
+
+// deviceSeen records activity from the account's other devices: messages
+// sent and receipts issued from them reach the session too.
+func (h *EventHandler) deviceSeen(source types.MessageSource, messageID string, at time.Time, sessionID string) {
+	if !source.IsFromMe || (source.Sender.Server != types.DefaultUserServer && source.Sender.Server != types.HiddenUserServer) {
+		return
+	}
+
+	observer := h.gateway.getDeviceObserver()
+	if observer == nil {
+		return
+	}
+
+	client := h.gateway.getClient(h.sessionName)
+	if client == nil {
+		return
+	}
+	device := client.GetClient().Store
+	if device == nil || device.ID == nil || source.Sender.Device == device.ID.Device {
+		return
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	platform := ""
+	if messageID != "" {
+		platform = session.PlatformFromMessageID(messageID)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deviceSyncTimeout)
+		defer cancel()
+		observer.Seen(ctx, id, h.sessionName, int(source.Sender.Device), platform, at)
+	}()
+}
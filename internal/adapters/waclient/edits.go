@@ -0,0 +1,144 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	shared "zpwoot/internal/core/shared/errors"
+)
+
+// MessageEditedEvent is delivered to webhooks when the sender of a message
+// edits it. MessageID is the original message; Content is the new text.
+type MessageEditedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	MessageID   string    `json:"message_id"`
+	EditID      string    `json:"edit_id"`
+	Chat        string    `json:"chat"`
+	Sender      string    `json:"sender"`
+	FromMe      bool      `json:"from_me"`
+	Type        string    `json:"type"`
+	Content     string    `json:"content"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// MessageRevokedEvent is delivered to webhooks when a message is deleted for
+// everyone, by its sender or, in groups, by an admin.
+type MessageRevokedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	MessageID   string    `json:"message_id"`
+	RevokeID    string    `json:"revoke_id"`
+	Chat        string    `json:"chat"`
+	Sender      string    `json:"sender"`
+	FromMe      bool      `json:"from_me"`
+	ByAdmin     bool      `json:"by_admin"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// IsEditOrRevoke reports whether a message only edits or revokes an earlier
+// one, in which case it is not a message of its own.
+func IsEditOrRevoke(evt *events.Message) bool {
+	switch evt.Message.GetProtocolMessage().GetType() {
+	case waE2E.ProtocolMessage_MESSAGE_EDIT, waE2E.ProtocolMessage_REVOKE:
+		return evt.Message.GetProtocolMessage().GetKey().GetID() != ""
+	}
+	return false
+}
+
+// handleEditOrRevoke applies an edit or revoke to the stored message and
+// emits the matching webhook event. An edit or revoke of a message that was
+// never stored is still delivered.
+func (h *EventHandler) handleEditOrRevoke(evt *events.Message, sessionID string) {
+	protocol := evt.Message.GetProtocolMessage()
+	messageID := protocol.GetKey().GetID()
+
+	at := evt.Info.Timestamp
+	if ms := protocol.GetTimestampMS(); ms > 0 {
+		at = time.UnixMilli(ms)
+	}
+
+	var payload interface{}
+	var err error
+	if protocol.GetType() == waE2E.ProtocolMessage_MESSAGE_EDIT {
+		content, messageType := h.extractMessageContentString(protocol.GetEditedMessage())
+		err = h.gateway.applyMessageEdit(sessionID, messageID, content, at)
+		payload = &MessageEditedEvent{
+			Event:       "message.edited",
+			SessionName: h.sessionName,
+			MessageID:   messageID,
+			EditID:      evt.Info.ID,
+			Chat:        evt.Info.Chat.String(),
+			Sender:      evt.Info.Sender.ToNonAD().String(),
+			FromMe:      evt.Info.IsFromMe,
+			Type:        messageType,
+			Content:     content,
+			Timestamp:   at,
+		}
+	} else {
+		err = h.gateway.applyMessageRevoke(sessionID, messageID, at)
+		payload = &MessageRevokedEvent{
+			Event:       "message.revoked",
+			SessionName: h.sessionName,
+			MessageID:   messageID,
+			RevokeID:    evt.Info.ID,
+			Chat:        evt.Info.Chat.String(),
+			Sender:      evt.Info.Sender.ToNonAD().String(),
+			FromMe:      evt.Info.IsFromMe,
+			ByAdmin:     evt.Info.Edit == types.EditAttributeAdminRevoke,
+			Timestamp:   at,
+		}
+	}
+
+	if err != nil && !errors.Is(err, shared.ErrNotFound) {
+		h.logger.ErrorWithFields("Failed to update edited or revoked message", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": messageID,
+			"error":      err.Error(),
+		})
+	}
+
+	h.deliverToWebhook(payload, sessionID)
+}
+
+func (g *Gateway) applyMessageEdit(sessionID, zpMessageID, content string, editedAt time.Time) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	_, err = store.ApplyEdit(ctx, id, zpMessageID, content, editedAt)
+	return err
+}
+
+func (g *Gateway) applyMessageRevoke(sessionID, zpMessageID string, revokedAt time.Time) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	_, err = store.ApplyRevoke(ctx, id, zpMessageID, revokedAt)
+	return err
+}
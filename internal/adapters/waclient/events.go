@@ -9,6 +9,7 @@ import (
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types/events"
 
+	"zpwoot/internal/core/label"
 	"zpwoot/internal/core/messaging"
 	"zpwoot/internal/core/session"
 	"zpwoot/platform/logger"
@@ -50,11 +51,6 @@ func (h *EventHandler) SetChatwootManager(manager ChatwootManager) {
 	h.chatwootManager = manager
 }
 
-func (h *EventHandler) HandleEvent(evt interface{}, sessionID string) {
-	h.deliverToWebhook(evt, sessionID)
-	h.handleEventInternal(evt, sessionID)
-}
-
 func (h *EventHandler) handleEventInternal(evt interface{}, sessionID string) {
 	switch v := evt.(type) {
 	case *events.Connected:
@@ -63,10 +59,18 @@ func (h *EventHandler) handleEventInternal(evt interface{}, sessionID string) {
 		h.handleDisconnected(v, sessionID)
 	case *events.LoggedOut:
 		h.handleLoggedOut(v, sessionID)
+	case *events.StreamReplaced:
+		h.handleStreamReplaced(sessionID)
 	case *events.QR:
 		h.handleQREvent(sessionID)
 	case *QRCodeEvent:
 		h.handleQRCodeEvent(v, sessionID)
+	case *PairingEndedEvent:
+		h.handlePairingEnded(v, sessionID)
+	case *SessionThrottledEvent:
+		h.handleThrottled(v)
+	case *events.TemporaryBan:
+		h.handleTemporaryBan(v, sessionID)
 	case *events.PairSuccess:
 		h.handlePairSuccess(v, sessionID)
 	case *events.PairError:
@@ -75,17 +79,46 @@ func (h *EventHandler) handleEventInternal(evt interface{}, sessionID string) {
 		h.handleMessage(v, sessionID)
 	case *events.Receipt:
 		h.handleReceipt(v, sessionID)
+	case *events.CallOffer:
+		h.handleCallOffer(v, sessionID)
+	case *events.Star:
+		h.handleStar(v, sessionID)
+	case *events.MarkChatAsRead:
+		h.handleMarkChatAsRead(v, sessionID)
+	case *events.LabelEdit:
+		h.handleLabelEdit(v, sessionID)
+	case *events.LabelAssociationChat:
+		h.handleLabelAssociation(sessionID, &label.Association{
+			LabelID:   v.LabelID,
+			ChatJID:   v.JID.String(),
+			CreatedAt: v.Timestamp,
+		}, v.Action.GetLabeled())
+	case *events.LabelAssociationMessage:
+		h.handleLabelAssociation(sessionID, &label.Association{
+			LabelID:   v.LabelID,
+			ChatJID:   v.JID.String(),
+			MessageID: v.MessageID,
+			CreatedAt: v.Timestamp,
+		}, v.Action.GetLabeled())
 	default:
 		h.handleOtherEvents(evt, sessionID)
 	}
 }
 
 func (h *EventHandler) deliverToWebhook(evt interface{}, sessionID string) {
+	h.deliverAnnotated(evt, sessionID, nil)
+}
+
+// deliverAnnotated delivers evt with what inbound stages annotated it with,
+// which message payloads carry.
+func (h *EventHandler) deliverAnnotated(evt interface{}, sessionID string, annotations map[string]interface{}) {
 	if h.webhookHandler == nil {
 		return
 	}
 
+	release := h.gateway.acquireEventSlot(h.sessionName)
 	go func() {
+		defer release()
 		defer func() {
 			if r := recover(); r != nil {
 				h.logger.ErrorWithFields("Webhook handler panic", map[string]interface{}{
@@ -95,6 +128,20 @@ func (h *EventHandler) deliverToWebhook(evt interface{}, sessionID string) {
 			}
 		}()
 
+		if msg, ok := evt.(*events.Message); ok {
+			event := NewMessageEvent(msg).WithState(h.conversationState(msg, sessionID)).WithAnnotations(annotations)
+			wrap := event.State != nil || len(annotations) > 0
+			if outcome := h.gateway.mediaLinks.wait(mediaLinkKey(sessionID, msg.Info.ID), mediaDownloadTimeout); outcome != nil {
+				if outcome.Link != nil || outcome.Scan != nil {
+					event.WithMediaLink(outcome.Link).WithMediaScan(outcome.Scan)
+					wrap = true
+				}
+			}
+			if wrap {
+				evt = event
+			}
+		}
+
 		if err := h.webhookHandler.HandleWhatsmeowEvent(evt, sessionID); err != nil {
 			h.logger.ErrorWithFields("Failed to deliver event to webhook", map[string]interface{}{
 				"session_id": sessionID,
@@ -110,9 +157,19 @@ func (h *EventHandler) handleConnected(_ *events.Connected, sessionID string) {
 		"module":     "events",
 		"session_id": sessionID,
 	})
+	if pending := h.gateway.pendingSends.count(h.sessionName); pending > 0 {
+		h.logger.InfoWithFields("Unconfirmed sends awaiting receipts", map[string]interface{}{
+			"session_id": sessionID,
+			"pending":    pending,
+		})
+	}
 
 	h.notifySessionConnected(sessionID)
 	h.updateSessionStatus(sessionID, "connected")
+
+	h.deliverToWebhook(h.connectedEvent(), sessionID)
+
+	h.syncDevices(sessionID)
 }
 
 func (h *EventHandler) handleDisconnected(_ *events.Disconnected, sessionID string) {
@@ -122,15 +179,8 @@ func (h *EventHandler) handleDisconnected(_ *events.Disconnected, sessionID stri
 
 	h.notifySessionDisconnected(sessionID, "disconnected")
 	h.updateSessionStatus(sessionID, "disconnected")
-}
 
-func (h *EventHandler) handleLoggedOut(evt *events.LoggedOut, sessionID string) {
-	h.logger.WarnWithFields("WhatsApp logged out", map[string]interface{}{
-		"session_id": sessionID,
-		"reason":     evt.Reason,
-	})
-
-	h.updateSessionStatus(sessionID, "logged_out")
+	h.deliverToWebhook(NewSessionDisconnectedEvent(h.sessionName, DisconnectConnectionLost), sessionID)
 }
 
 func (h *EventHandler) handleQREvent(sessionID string) {
@@ -147,6 +197,7 @@ func (h *EventHandler) handleQRCodeEvent(evt *QRCodeEvent, sessionID string) {
 		"session_name": evt.SessionName,
 		"qr_length":    len(evt.QRCode),
 		"expires_at":   evt.ExpiresAt,
+		"attempt":      evt.Attempt,
 	})
 
 	h.updateSessionStatus(sessionID, "qr_code")
@@ -158,6 +209,54 @@ func (h *EventHandler) handleQRCodeEvent(evt *QRCodeEvent, sessionID string) {
 			"error":      err.Error(),
 		})
 	}
+
+	updated := NewQRUpdatedEvent(evt.SessionName, evt.QRCode, evt.ExpiresAt)
+	updated.Attempt = evt.Attempt
+	updated.MaxAttempts = evt.MaxAttempts
+	h.deliverToWebhook(updated, sessionID)
+}
+
+func (h *EventHandler) handlePairingEnded(evt *PairingEndedEvent, sessionID string) {
+	h.logger.InfoWithFields("QR pairing ended", map[string]interface{}{
+		"session_id": sessionID,
+		"reason":     evt.Reason,
+		"attempts":   evt.Attempts,
+	})
+
+	if evt.Reason == PairingTimeout || evt.Reason == PairingQRLimit {
+		h.notifyQRTimeout(sessionID, evt.Reason)
+		h.updateSessionStatus(sessionID, string(session.StatusQRTimeout))
+		return
+	}
+
+	if err := h.gateway.ClearSessionQRCode(sessionID); err != nil {
+		h.logger.ErrorWithFields("Failed to clear QR code in database", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+	}
+
+	h.updateSessionStatus(sessionID, "disconnected")
+}
+
+// notifyQRTimeout has the session handlers persist the timeout, which also
+// drops the stored QR code.
+func (h *EventHandler) notifyQRTimeout(sessionID, reason string) {
+	handlers := h.gateway.getEventHandlers("global")
+	for _, handler := range handlers {
+		go func(sessionHandler session.EventHandler) {
+			defer func() {
+				if r := recover(); r != nil {
+					h.logger.ErrorWithFields("Session event handler panic", map[string]interface{}{
+						"session_id": sessionID,
+						"event":      "qr_timeout",
+						"error":      r,
+					})
+				}
+			}()
+			sessionHandler.OnQRTimeout(h.sessionName, reason)
+		}(handler)
+	}
 }
 
 func (h *EventHandler) handlePairSuccess(evt *events.PairSuccess, sessionID string) {
@@ -193,16 +292,88 @@ func (h *EventHandler) handleMessage(evt *events.Message, sessionID string) {
 		"from_me": evt.Info.IsFromMe,
 	})
 
-	if err := h.saveMessageToDatabase(evt, sessionID); err != nil {
+	if evt.Message.GetReactionMessage() != nil {
+		h.handleReaction(evt, sessionID)
+		return
+	}
+
+	if evt.Message.GetPollUpdateMessage() != nil {
+		h.handlePollVote(evt, sessionID)
+		return
+	}
+
+	if IsEditOrRevoke(evt) {
+		h.handleEditOrRevoke(evt, sessionID)
+		return
+	}
+
+	if IsPin(evt) {
+		h.handlePin(evt, sessionID)
+		return
+	}
+
+	message, err := h.saveMessageToDatabase(evt, sessionID)
+	if err != nil {
 		h.logger.ErrorWithFields("Failed to save message to database", map[string]interface{}{
 			"session_id": sessionID,
 			"message_id": evt.Info.ID,
 			"error":      err.Error(),
 		})
+	} else if message.Media != nil {
+		h.autoDownloadMedia(message)
+	}
+
+	h.handlePollCreation(evt, sessionID)
+	h.handleLiveLocation(evt, sessionID)
+	h.handleCommerceMessage(evt, sessionID)
+	h.autoRead(evt, sessionID)
+	h.deviceSeen(evt.Info.MessageSource, evt.Info.ID, evt.Info.Timestamp, sessionID)
+}
+
+func (h *EventHandler) handleReaction(evt *events.Message, sessionID string) {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	reaction := h.messageMapper.ExtractReaction(sessionUUID, evt)
+	if reaction == nil {
+		return
+	}
+
+	if err := h.gateway.SaveReaction(reaction); err != nil {
+		h.logger.ErrorWithFields("Failed to save reaction", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": reaction.ZpMessageID,
+			"error":      err.Error(),
+		})
 	}
 
-	if h.chatwootManager != nil && h.chatwootManager.IsEnabled(sessionID) {
-		h.processMessageForChatwoot(evt, sessionID)
+	h.deliverToWebhook(newReactionEvent(h.sessionName, evt, reaction), sessionID)
+}
+
+func (h *EventHandler) handleCommerceMessage(evt *events.Message, sessionID string) {
+	if orderEvent := h.messageMapper.ExtractOrderEvent(h.sessionName, evt); orderEvent != nil {
+		h.logger.InfoWithFields("Order message received", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": orderEvent.MessageID,
+			"order_id":   orderEvent.OrderID,
+			"item_count": orderEvent.ItemCount,
+			"total":      orderEvent.TotalAmount,
+			"currency":   orderEvent.Currency,
+		})
+		h.deliverToWebhook(orderEvent, sessionID)
+	}
+
+	if paymentEvent := h.messageMapper.ExtractPaymentEvent(h.sessionName, evt); paymentEvent != nil {
+		h.logger.InfoWithFields("Payment message received", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": paymentEvent.MessageID,
+			"kind":       paymentEvent.Kind,
+			"amount":     paymentEvent.Amount,
+			"currency":   paymentEvent.Currency,
+		})
+		h.deliverToWebhook(paymentEvent, sessionID)
 	}
 }
 
@@ -213,6 +384,9 @@ func (h *EventHandler) handleReceipt(evt *events.Receipt, sessionID string) {
 		"sender":     evt.Sender.String(),
 		"timestamp":  evt.Timestamp,
 	})
+
+	h.deviceSeen(evt.MessageSource, "", evt.Timestamp, sessionID)
+	h.confirmSends(evt, sessionID)
 }
 
 func (h *EventHandler) handleOtherEvents(evt interface{}, sessionID string) {
@@ -239,6 +413,8 @@ func (h *EventHandler) handleOtherEvents(evt interface{}, sessionID string) {
 		h.handlePicture(v, sessionID)
 	case *events.BusinessName:
 		h.handleBusinessName(v, sessionID)
+	case *events.PushName:
+		h.handlePushName(v, sessionID)
 	default:
 		h.logger.DebugWithFields("Unhandled event", map[string]interface{}{
 			"session_id": sessionID,
@@ -254,6 +430,7 @@ func (h *EventHandler) processMessageForChatwoot(evt *events.Message, sessionID
 	fromMe := evt.Info.IsFromMe
 
 	content, messageType := h.extractMessageContentString(evt.Message)
+	content = h.chatwootContent(evt.Message, content)
 
 	contactNumber := h.extractContactNumber(from)
 
@@ -326,6 +503,8 @@ func (h *EventHandler) handleAppStateSyncComplete(evt *events.AppStateSyncComple
 		"session_id": sessionID,
 		"name":       evt.Name,
 	})
+
+	h.syncContactRegistry(evt, sessionID)
 }
 
 func (h *EventHandler) handleKeepAliveTimeout(_ *events.KeepAliveTimeout, sessionID string) {
@@ -340,43 +519,15 @@ func (h *EventHandler) handleKeepAliveRestored(_ *events.KeepAliveRestored, sess
 	})
 }
 
-func (h *EventHandler) handleContact(evt *events.Contact, sessionID string) {
-	h.logger.DebugWithFields("Contact update", map[string]interface{}{
-		"session_id": sessionID,
-		"jid":        evt.JID.String(),
-	})
-}
-
-func (h *EventHandler) handleGroupInfo(evt *events.GroupInfo, sessionID string) {
-	h.logger.DebugWithFields("Group info update", map[string]interface{}{
-		"session_id": sessionID,
-		"jid":        evt.JID.String(),
-	})
-}
-
-func (h *EventHandler) handlePicture(evt *events.Picture, sessionID string) {
-	h.logger.DebugWithFields("Picture update", map[string]interface{}{
-		"session_id": sessionID,
-		"jid":        evt.JID.String(),
-	})
-}
-
-func (h *EventHandler) handleBusinessName(evt *events.BusinessName, sessionID string) {
-	h.logger.DebugWithFields("Business name update", map[string]interface{}{
-		"session_id": sessionID,
-		"jid":        evt.JID.String(),
-	})
-}
-
-func (h *EventHandler) saveMessageToDatabase(evt *events.Message, sessionID string) error {
+func (h *EventHandler) saveMessageToDatabase(evt *events.Message, sessionID string) (*messaging.Message, error) {
 
 	message, err := h.convertWhatsmeowMessage(evt, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to convert message: %w", err)
+		return nil, fmt.Errorf("failed to convert message: %w", err)
 	}
 
 	if err := h.gateway.SaveReceivedMessage(message); err != nil {
-		return fmt.Errorf("failed to save message: %w", err)
+		return nil, fmt.Errorf("failed to save message: %w", err)
 	}
 
 	h.logger.DebugWithFields("Message saved to database", map[string]interface{}{
@@ -385,14 +536,13 @@ func (h *EventHandler) saveMessageToDatabase(evt *events.Message, sessionID stri
 		"zp_message_id": message.ZpMessageID,
 	})
 
-	return nil
+	return message, nil
 }
 
 func (h *EventHandler) convertWhatsmeowMessage(evt *events.Message, sessionID string) (*messaging.Message, error) {
 
-	contentMap := h.extractMessageContent(evt.Message)
-
-	contentStr := fmt.Sprintf("%v", contentMap)
+	content, messageType := h.extractMessageContentString(evt.Message)
+	content = h.chatwootContent(evt.Message, content)
 
 	sessionUUID, err := uuid.Parse(sessionID)
 	if err != nil {
@@ -407,51 +557,17 @@ func (h *EventHandler) convertWhatsmeowMessage(evt *events.Message, sessionID st
 		ZpChat:      evt.Info.Chat.String(),
 		ZpTimestamp: evt.Info.Timestamp,
 		ZpFromMe:    evt.Info.IsFromMe,
-		ZpType:      string(evt.Info.Type),
-		Content:     contentStr,
+		ZpType:      messageType,
+		Content:     content,
 		SyncStatus:  "pending",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
-	}
 
-	return message, nil
-}
-
-func (h *EventHandler) extractMessageContent(message *waE2E.Message) map[string]interface{} {
-	content := make(map[string]interface{})
-
-	contentStr, msgType := h.extractMessageContentString(message)
-	content["type"] = msgType
-
-	switch {
-	case message.GetConversation() != "":
-		content["text"] = message.GetConversation()
-	case message.GetExtendedTextMessage() != nil:
-		content["text"] = message.GetExtendedTextMessage().GetText()
-	case message.GetImageMessage() != nil:
-		img := message.GetImageMessage()
-		content["caption"] = img.GetCaption()
-		content["mimetype"] = img.GetMimetype()
-		content["url"] = img.GetURL()
-	case message.GetVideoMessage() != nil:
-		vid := message.GetVideoMessage()
-		content["caption"] = vid.GetCaption()
-		content["mimetype"] = vid.GetMimetype()
-		content["url"] = vid.GetURL()
-	case message.GetAudioMessage() != nil:
-		aud := message.GetAudioMessage()
-		content["mimetype"] = aud.GetMimetype()
-		content["url"] = aud.GetURL()
-	case message.GetDocumentMessage() != nil:
-		doc := message.GetDocumentMessage()
-		content["filename"] = doc.GetFileName()
-		content["mimetype"] = doc.GetMimetype()
-		content["url"] = doc.GetURL()
-	default:
-		content["content"] = contentStr
+		QuotedMessageID: h.messageMapper.ExtractQuotedMessageID(evt.Message),
+		Media:           h.messageMapper.ExtractMediaMetadata(evt.Message),
 	}
 
-	return content
+	return message, nil
 }
 
 func (h *EventHandler) notifySessionConnected(sessionID string) {
@@ -11,10 +11,14 @@ import (
 	"github.com/google/uuid"
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
 
+	"zpwoot/internal/core/contact"
 	"zpwoot/internal/core/group"
+	"zpwoot/internal/core/inbound"
 	"zpwoot/internal/core/messaging"
 	"zpwoot/internal/core/session"
 	"zpwoot/platform/logger"
@@ -49,15 +53,6 @@ type UserInfo struct {
 	IsOnline     bool       `json:"is_online"`
 }
 
-type ContactInfo struct {
-	JID          string `json:"jid"`
-	PhoneNumber  string `json:"phone_number"`
-	Name         string `json:"name,omitempty"`
-	BusinessName string `json:"business_name,omitempty"`
-	IsBusiness   bool   `json:"is_business"`
-	IsContact    bool   `json:"is_contact"`
-}
-
 type BusinessProfile struct {
 	JID          string `json:"jid"`
 	IsBusiness   bool   `json:"is_business"`
@@ -92,26 +87,80 @@ type Gateway struct {
 	clients       map[string]*Client
 	eventHandlers map[string][]session.EventHandler
 	sessionUUIDs  map[string]string
+	settings      map[string]session.Settings
 	mu            sync.RWMutex
 
 	webhookHandler  WebhookEventHandler
 	chatwootManager ChatwootManager
 
-	sessionService SessionServiceExtended
-}
+	sessionService     SessionServiceExtended
+	messageStore       MessageStore
+	labelStore         LabelStore
+	conversationStates ConversationStates
+	avatars            AvatarObserver
+	registry           ContactRegistry
+	groupMetadata      GroupMetadataObserver
+	devices            DeviceObserver
+	dedup              InboundDeduplicator
+	pipeline           *inbound.Pipeline
+	mediaDir           string
+	mediaHost          messaging.MediaHost
+	mediaLinks         mediaLinks
+	mediaScanner       messaging.MediaScanner
+	mediaPool          *messaging.MediaPool
+	scanPolicy         session.MediaScanPolicy
+
+	operationTimeout time.Duration
+	maxSessions      int
+	maxPendingEvents int
+	eventSlots       map[string]chan struct{}
+	connectMu        sync.Mutex
+	uploadRetries    int
+	uploads          uploadCache
+	pendingSends     pendingSends
+	debug            *ProtocolDebug
+	filterLog        session.FilterLog
+	filterReplies    filterReplies
+}
+
+// MessageStore persists messages and reactions received from WhatsApp.
+type MessageStore interface {
+	SaveReceivedMessage(ctx context.Context, message *messaging.Message) error
+	RecordReaction(ctx context.Context, reaction *messaging.Reaction) error
+	ApplyEdit(ctx context.Context, sessionID uuid.UUID, zpMessageID, content string, editedAt time.Time) (*messaging.Message, error)
+	ApplyRevoke(ctx context.Context, sessionID uuid.UUID, zpMessageID string, revokedAt time.Time) (*messaging.Message, error)
+	SetStarred(ctx context.Context, star *messaging.StarredMessage, starred bool) error
+	MarkChatRead(ctx context.Context, sessionID uuid.UUID, chatJID string, at time.Time) error
+	AttachMediaFile(ctx context.Context, sessionID uuid.UUID, zpMessageID, localPath string) error
+	RecordMediaScan(ctx context.Context, sessionID uuid.UUID, zpMessageID string, scan *messaging.MediaScan) error
+	RecordPoll(ctx context.Context, poll *messaging.Poll) error
+	RecordLiveLocation(ctx context.Context, point *messaging.LiveLocationPoint) error
+	RecordPollVote(ctx context.Context, sessionID uuid.UUID, zpMessageID, voterJID string, hashes [][]byte, votedAt time.Time) (*messaging.Poll, *messaging.PollVote, error)
+}
+
+const messageStoreTimeout = 10 * time.Second
+
+// qrWaitTimeout bounds how long GenerateQRCode waits for WhatsApp to send the
+// first code of a new pairing.
+const qrWaitTimeout = 15 * time.Second
 
 type DatabaseInterface interface {
 	QueryRow(query string, args ...interface{}) *sql.Row
 }
 
 func NewGateway(container *sqlstore.Container, logger *logger.Logger) *Gateway {
-	return &Gateway{
+	g := &Gateway{
 		logger:        logger,
 		container:     container,
 		clients:       make(map[string]*Client),
 		eventHandlers: make(map[string][]session.EventHandler),
 		sessionUUIDs:  make(map[string]string),
+		settings:      make(map[string]session.Settings),
+		debug:         NewProtocolDebug(),
 	}
+	g.pipeline = newInboundPipeline(g)
+
+	return g
 }
 
 func (g *Gateway) SetDatabase(db DatabaseInterface) {
@@ -127,6 +176,41 @@ func (g *Gateway) SetSessionService(service SessionServiceExtended) {
 
 }
 
+// ApplySettings caches the per-session settings consulted by event handlers.
+func (g *Gateway) ApplySettings(sessionName string, settings session.Settings) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.settings[sessionName] = settings
+}
+
+func (g *Gateway) getSettings(sessionName string) session.Settings {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.settings[sessionName]
+}
+
+// SessionLocation implements delivery.Timezones.
+func (g *Gateway) SessionLocation(sessionID string) *time.Location {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for name, id := range g.sessionUUIDs {
+		if id == sessionID {
+			if settings := g.settings[name]; settings.Timezone != "" {
+				return settings.Location()
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func (g *Gateway) SetMessageStore(store MessageStore) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.messageStore = store
+}
+
 func (g *Gateway) RegisterSessionUUID(sessionName, sessionUUID string) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -151,8 +235,6 @@ func (g *Gateway) GetSessionUUID(sessionName string) string {
 	return g.sessionUUIDs[sessionName]
 }
 
-
-
 func (g *Gateway) CreateSession(ctx context.Context, sessionName string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -165,6 +247,8 @@ func (g *Gateway) CreateSession(ctx context.Context, sessionName string) error {
 		SessionName: sessionName,
 		Container:   g.container,
 		Logger:      g.logger,
+		Debug:       g.debug,
+		MaxQRCodes:  g.maxQRCodes(sessionName),
 	}
 	client, err := NewClient(config)
 	if err != nil {
@@ -207,6 +291,16 @@ func (g *Gateway) ConnectSession(ctx context.Context, sessionName string) error
 		return nil
 	}
 
+	g.connectMu.Lock()
+	defer g.connectMu.Unlock()
+	if err := g.checkCapacity(sessionName); err != nil {
+		g.logger.WarnWithFields("Instance at capacity, session not connected", map[string]interface{}{
+			"session_name": sessionName,
+			"error":        err.Error(),
+		})
+		return err
+	}
+
 	if err := client.Connect(); err != nil {
 		g.logger.ErrorWithFields("Failed to connect WhatsApp session", map[string]interface{}{
 			"session_name": sessionName,
@@ -266,6 +360,8 @@ func (g *Gateway) newClientWithExistingDevice(sessionName, sessionUUID string) (
 			SessionName: sessionName,
 			Container:   g.container,
 			Logger:      g.logger,
+			Debug:       g.debug,
+			MaxQRCodes:  g.maxQRCodes(sessionName),
 		}
 		return NewClient(config)
 	}
@@ -278,6 +374,8 @@ func (g *Gateway) newClientWithExistingDevice(sessionName, sessionUUID string) (
 			SessionName: sessionName,
 			Container:   g.container,
 			Logger:      g.logger,
+			Debug:       g.debug,
+			MaxQRCodes:  g.maxQRCodes(sessionName),
 		}
 		return NewClient(config)
 	}
@@ -297,6 +395,8 @@ func (g *Gateway) newClientWithExistingDevice(sessionName, sessionUUID string) (
 			SessionName: sessionName,
 			Container:   g.container,
 			Logger:      g.logger,
+			Debug:       g.debug,
+			MaxQRCodes:  g.maxQRCodes(sessionName),
 		}
 		return NewClient(config)
 	}
@@ -401,13 +501,19 @@ func (g *Gateway) newClientWithDeviceJID(sessionName, deviceJID string) (*Client
 		Device:      deviceStore,
 		Container:   g.container,
 		Logger:      g.logger,
+		Debug:       g.debug,
+		MaxQRCodes:  g.maxQRCodes(sessionName),
 	}
 	return NewClient(config)
 }
 
-func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string) error {
+// RestoreAllSessions loads the WhatsApp clients for the given sessions
+// without connecting them and returns the names that have stored device
+// credentials, in the order given, so the caller can decide how to pace
+// their reconnection.
+func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string) ([]string, error) {
 	if len(sessionNames) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	g.logger.InfoWithFields("Restoring WhatsApp clients for existing sessions", map[string]interface{}{
@@ -427,10 +533,11 @@ func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string)
 			"error": err.Error(),
 		})
 
-		return g.restoreSessionsSequential(ctx, sessionNames)
+		return g.restoreSessionsSequential(ctx, sessionNames), nil
 	}
 
 	successCount := 0
+	reconnectable := make([]string, 0, len(sessionNames))
 	for _, sessionName := range sessionNames {
 		sessionUUID, exists := g.sessionUUIDs[sessionName]
 		if !exists {
@@ -452,21 +559,8 @@ func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string)
 		}
 		successCount++
 
-		// Auto-connect restored sessions that have device JID
 		if deviceJID != "" {
-			go func(sName string) {
-				time.Sleep(2 * time.Second) // Give time for client to initialize
-				if err := g.ConnectSession(ctx, sName); err != nil {
-					g.logger.WarnWithFields("Failed to auto-connect restored session", map[string]interface{}{
-						"session_name": sName,
-						"error":        err.Error(),
-					})
-				} else {
-					g.logger.InfoWithFields("Auto-connected restored session", map[string]interface{}{
-						"session_name": sName,
-					})
-				}
-			}(sessionName)
+			reconnectable = append(reconnectable, sessionName)
 		}
 	}
 
@@ -474,12 +568,14 @@ func (g *Gateway) RestoreAllSessions(ctx context.Context, sessionNames []string)
 		"total_sessions": len(sessionNames),
 		"successful":     successCount,
 		"failed":         len(sessionNames) - successCount,
+		"reconnectable":  len(reconnectable),
 	})
 
-	return nil
+	return reconnectable, nil
 }
 
-func (g *Gateway) restoreSessionsSequential(ctx context.Context, sessionNames []string) error {
+func (g *Gateway) restoreSessionsSequential(ctx context.Context, sessionNames []string) []string {
+	reconnectable := make([]string, 0, len(sessionNames))
 	for _, sessionName := range sessionNames {
 		err := g.RestoreSession(ctx, sessionName)
 		if err != nil {
@@ -489,8 +585,12 @@ func (g *Gateway) restoreSessionsSequential(ctx context.Context, sessionNames []
 			})
 			continue
 		}
+
+		if client := g.getClient(sessionName); client != nil && client.GetClient().Store.ID != nil {
+			reconnectable = append(reconnectable, sessionName)
+		}
 	}
-	return nil
+	return reconnectable
 }
 
 func (g *Gateway) restoreSessionWithDeviceJID(_ context.Context, sessionName, _ /* sessionUUID */, deviceJID string) error {
@@ -518,6 +618,7 @@ func (g *Gateway) restoreSessionWithDeviceJID(_ context.Context, sessionName, _
 				SessionName: sessionName,
 				Container:   g.container,
 				Logger:      g.logger,
+				Debug:       g.debug,
 			}
 			client, err = NewClient(config)
 		}
@@ -527,6 +628,8 @@ func (g *Gateway) restoreSessionWithDeviceJID(_ context.Context, sessionName, _
 			SessionName: sessionName,
 			Container:   g.container,
 			Logger:      g.logger,
+			Debug:       g.debug,
+			MaxQRCodes:  g.maxQRCodes(sessionName),
 		}
 		client, err = NewClient(config)
 	}
@@ -552,6 +655,7 @@ func (g *Gateway) DisconnectSession(ctx context.Context, sessionName string) err
 		"session_name": sessionName,
 	})
 
+	wasConnected := client.IsConnected()
 	if err := client.Disconnect(); err != nil {
 		g.logger.ErrorWithFields("Failed to disconnect WhatsApp session", map[string]interface{}{
 			"session_name": sessionName,
@@ -560,6 +664,11 @@ func (g *Gateway) DisconnectSession(ctx context.Context, sessionName string) err
 		return fmt.Errorf("failed to disconnect session: %w", err)
 	}
 
+	// whatsmeow only reports disconnections it did not ask for.
+	if wasConnected {
+		client.notifyEventHandlers(NewSessionDisconnectedEvent(sessionName, DisconnectRequested))
+	}
+
 	return nil
 }
 
@@ -586,7 +695,10 @@ func (g *Gateway) DeleteSession(ctx context.Context, sessionName string) error {
 	}
 
 	if client.IsLoggedIn() {
-		if err := client.Logout(); err != nil {
+		logoutCtx, cancel := g.withOperationTimeout(ctx)
+		defer cancel()
+
+		if err := client.Logout(logoutCtx); err != nil {
 			g.logger.WarnWithFields("Error logging out session during deletion", map[string]interface{}{
 				"session_name": sessionName,
 				"error":        err.Error(),
@@ -596,6 +708,8 @@ func (g *Gateway) DeleteSession(ctx context.Context, sessionName string) error {
 
 	delete(g.clients, sessionName)
 	delete(g.eventHandlers, sessionName)
+	delete(g.settings, sessionName)
+	delete(g.eventSlots, sessionName)
 
 	g.logger.InfoWithFields("WhatsApp session deleted successfully", map[string]interface{}{
 		"session_name": sessionName,
@@ -650,25 +764,48 @@ func (g *Gateway) GenerateQRCode(ctx context.Context, sessionName string) (*sess
 		}
 	}
 
-	qrCode, err := client.GetQRCode()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get QR code: %w", err)
-	}
+	// WhatsApp sends the first code shortly after the socket opens.
+	waitCtx, cancel := context.WithTimeout(ctx, qrWaitTimeout)
+	defer cancel()
 
-	expiresAt := time.Now().Add(2 * time.Minute)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
 
-	response := &session.QRCodeResponse{
-		QRCode:    qrCode,
-		ExpiresAt: expiresAt,
-		Timeout:   120,
+	for {
+		if response, ok := g.CurrentQRCode(sessionName); ok {
+			g.logger.InfoWithFields("QR code generated successfully", map[string]interface{}{
+				"session_name": sessionName,
+				"expires_at":   response.ExpiresAt,
+			})
+			return response, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return nil, fmt.Errorf("failed to get QR code: %w", session.ErrQRCodeNotAvailable)
+		case <-ticker.C:
+		}
 	}
+}
 
-	g.logger.InfoWithFields("QR code generated successfully", map[string]interface{}{
-		"session_name": sessionName,
-		"expires_at":   expiresAt,
-	})
+// CurrentQRCode returns the QR code of the pairing this process runs for
+// the session, while it is valid.
+func (g *Gateway) CurrentQRCode(sessionName string) (*session.QRCodeResponse, bool) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, false
+	}
+
+	qrCode, expiresAt, ok := client.CurrentQRCode()
+	if !ok {
+		return nil, false
+	}
 
-	return response, nil
+	return &session.QRCodeResponse{
+		QRCode:    qrCode,
+		ExpiresAt: expiresAt,
+		Timeout:   int(time.Until(expiresAt).Seconds()),
+	}, true
 }
 
 func (g *Gateway) SetProxy(ctx context.Context, sessionName string, proxy *session.ProxyConfig) error {
@@ -740,7 +877,7 @@ func (g *Gateway) setupEventHandlers(client *Client, sessionName string) {
 		eventHandler.SetChatwootManager(g.chatwootManager)
 	}
 
-	client.GetClient().AddEventHandler(func(evt interface{}) {
+	handle := func(evt interface{}) {
 
 		sessionUUID := g.GetSessionUUID(sessionName)
 		if sessionUUID == "" {
@@ -751,20 +888,19 @@ func (g *Gateway) setupEventHandlers(client *Client, sessionName string) {
 			})
 		}
 		eventHandler.HandleEvent(evt, sessionUUID)
-	})
-
-	client.AddEventHandler(func(evt interface{}) {
+	}
 
-		sessionUUID := g.GetSessionUUID(sessionName)
-		if sessionUUID == "" {
+	client.GetClient().AddEventHandler(handle)
 
-			sessionUUID = sessionName
-			g.logger.WarnWithFields("Session UUID not found for custom event, using session name", map[string]interface{}{
-				"session_name": sessionName,
-				"event_type":   fmt.Sprintf("%T", evt),
-			})
+	// The QR loop, requested disconnections and throttling run on the
+	// client rather than whatsmeow, so their events are picked up from the
+	// client's own handlers; everything else there is a copy of what
+	// whatsmeow already delivered.
+	client.AddEventHandler(func(evt interface{}) {
+		switch evt.(type) {
+		case *QRCodeEvent, *PairingEndedEvent, *SessionDisconnectedEvent, *SessionThrottledEvent, *AvatarChangedEvent, *GroupMetadataChangedEvent, *DeviceAddedEvent:
+			handle(evt)
 		}
-		eventHandler.HandleEvent(evt, sessionUUID)
 	})
 
 	g.logger.DebugWithFields("Event handlers configured", map[string]interface{}{
@@ -791,16 +927,42 @@ func (g *Gateway) SetChatwootManager(manager ChatwootManager) {
 }
 
 func (g *Gateway) SaveReceivedMessage(message *messaging.Message) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
 
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.SaveReceivedMessage(ctx, message)
+}
+
+func (g *Gateway) SaveReaction(reaction *messaging.Reaction) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.RecordReaction(ctx, reaction)
+}
+
+func (g *Gateway) getMessageStore() MessageStore {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.messageStore
 }
 
-func (g *Gateway) CreateGroup(ctx context.Context, sessionID, name string, participants []string, description string) (*group.GroupInfo, error) {
+func (g *Gateway) CreateGroup(ctx context.Context, sessionID, name string, participants []string, description string, settings *group.CreateGroupSettings) (*group.GroupInfo, error) {
 	g.logger.InfoWithFields("Creating group", map[string]interface{}{
 		"session_id":   sessionID,
 		"name":         name,
 		"participants": len(participants),
 		"description":  description != "",
+		"settings":     settings != nil,
 	})
 
 	client := g.getClient(sessionID)
@@ -827,17 +989,28 @@ func (g *Gateway) CreateGroup(ctx context.Context, sessionID, name string, parti
 		participantJIDs[i] = jid
 	}
 
-	groupInfo, err := client.client.CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	req := whatsmeow.ReqCreateGroup{
 		Name:         name,
 		Participants: participantJIDs,
-	})
+	}
+	if settings != nil {
+		req.IsEphemeral = settings.DisappearingTimer > 0
+		req.DisappearingTimer = settings.DisappearingTimer
+		req.IsAnnounce = settings.Announce
+		req.IsJoinApprovalRequired = settings.JoinApprovalMode == group.JoinApprovalModeAdminApproval
+	}
+
+	groupInfo, err := client.client.CreateGroup(opCtx, req)
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to create group", map[string]interface{}{
 			"session_id": sessionID,
 			"name":       name,
 			"error":      err.Error(),
 		})
-		return nil, err
+		return nil, wrapContextError(err)
 	}
 
 	if description != "" {
@@ -851,6 +1024,23 @@ func (g *Gateway) CreateGroup(ctx context.Context, sessionID, name string, parti
 		}
 	}
 
+	// Member add mode is not part of the create stanza, so it has to be
+	// applied separately once the group exists.
+	if settings != nil && settings.MemberAddMode == group.MemberAddModeOnlyAdmins {
+		err = runWithContext(opCtx, func() error {
+			return client.client.SetGroupMemberAddMode(groupInfo.JID, types.GroupMemberAddModeAdmin)
+		})
+		if err != nil {
+			g.logger.WarnWithFields("Failed to set group member add mode", map[string]interface{}{
+				"session_id": sessionID,
+				"group_jid":  groupInfo.JID.String(),
+				"error":      err.Error(),
+			})
+		} else {
+			groupInfo.MemberAddMode = types.GroupMemberAddModeAdmin
+		}
+	}
+
 	result := g.convertToGroupInfo(groupInfo, description)
 
 	g.logger.InfoWithFields("Group created successfully", map[string]interface{}{
@@ -875,9 +1065,12 @@ func (g *Gateway) ListJoinedGroups(ctx context.Context, sessionID string) ([]*gr
 		return nil, fmt.Errorf("session %s is not logged in", sessionID)
 	}
 
-	groups, err := client.client.GetJoinedGroups(ctx)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	groups, err := client.client.GetJoinedGroups(opCtx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get joined groups: %w", err)
+		return nil, fmt.Errorf("failed to get joined groups: %w", wrapContextError(err))
 	}
 
 	result := make([]*group.GroupInfo, len(groups))
@@ -912,9 +1105,17 @@ func (g *Gateway) GetGroupInfo(ctx context.Context, sessionID, groupJID string)
 		return nil, fmt.Errorf("invalid group JID: %w", err)
 	}
 
-	groupInfo, err := client.client.GetGroupInfo(jid)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var groupInfo *types.GroupInfo
+	err = runWithContext(opCtx, func() error {
+		var infoErr error
+		groupInfo, infoErr = client.client.GetGroupInfo(jid)
+		return infoErr
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get group info: %w", err)
+		return nil, fmt.Errorf("failed to get group info: %w", wrapContextError(err))
 	}
 
 	result := g.convertToGroupInfo(groupInfo, "")
@@ -1076,7 +1277,7 @@ func (g *Gateway) DemoteParticipants(ctx context.Context, sessionID, groupJID st
 	return g.updateGroupParticipants(ctx, sessionID, groupJID, participants, "demote")
 }
 
-func (g *Gateway) updateGroupParticipants(_ context.Context, sessionID, groupJID string, participants []string, action string) error {
+func (g *Gateway) updateGroupParticipants(ctx context.Context, sessionID, groupJID string, participants []string, action string) error {
 	g.logger.InfoWithFields("Updating group participants", map[string]interface{}{
 		"session_id":   sessionID,
 		"group_jid":    groupJID,
@@ -1124,7 +1325,13 @@ func (g *Gateway) updateGroupParticipants(_ context.Context, sessionID, groupJID
 		return fmt.Errorf("invalid action: %s", action)
 	}
 
-	_, err = client.client.UpdateGroupParticipants(jid, participantJIDs, participantAction)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		_, updateErr := client.client.UpdateGroupParticipants(jid, participantJIDs, participantAction)
+		return updateErr
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to update group participants", map[string]interface{}{
 			"session_id": sessionID,
@@ -1132,7 +1339,7 @@ func (g *Gateway) updateGroupParticipants(_ context.Context, sessionID, groupJID
 			"action":     action,
 			"error":      err.Error(),
 		})
-		return err
+		return wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Group participants updated successfully", map[string]interface{}{
@@ -1169,7 +1376,12 @@ func (g *Gateway) SetGroupName(ctx context.Context, sessionID, groupJID, name st
 		return fmt.Errorf("group name is required")
 	}
 
-	err = client.client.SetGroupName(jid, name)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		return client.client.SetGroupName(jid, name)
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to set group name", map[string]interface{}{
 			"session_id": sessionID,
@@ -1177,7 +1389,7 @@ func (g *Gateway) SetGroupName(ctx context.Context, sessionID, groupJID, name st
 			"name":       name,
 			"error":      err.Error(),
 		})
-		return err
+		return wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Group name updated successfully", map[string]interface{}{
@@ -1209,14 +1421,19 @@ func (g *Gateway) SetGroupDescription(ctx context.Context, sessionID, groupJID,
 		return fmt.Errorf("invalid group JID: %w", err)
 	}
 
-	err = client.client.SetGroupTopic(jid, "", "", description)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		return client.client.SetGroupTopic(jid, "", "", description)
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to set group description", map[string]interface{}{
 			"session_id": sessionID,
 			"group_jid":  groupJID,
 			"error":      err.Error(),
 		})
-		return err
+		return wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Group description updated successfully", map[string]interface{}{
@@ -1227,7 +1444,7 @@ func (g *Gateway) SetGroupDescription(ctx context.Context, sessionID, groupJID,
 	return nil
 }
 
-func (g *Gateway) SetGroupPhoto(ctx context.Context, sessionID, groupJID string, photoData []byte) error {
+func (g *Gateway) SetGroupPhoto(ctx context.Context, sessionID, groupJID string, photoData []byte) (string, error) {
 	g.logger.InfoWithFields("Setting group photo", map[string]interface{}{
 		"session_id": sessionID,
 		"group_jid":  groupJID,
@@ -1236,37 +1453,46 @@ func (g *Gateway) SetGroupPhoto(ctx context.Context, sessionID, groupJID string,
 
 	client := g.getClient(sessionID)
 	if client == nil {
-		return fmt.Errorf("session %s not found", sessionID)
+		return "", fmt.Errorf("session %s not found", sessionID)
 	}
 	if !client.IsLoggedIn() {
-		return fmt.Errorf("session %s is not logged in", sessionID)
+		return "", fmt.Errorf("session %s is not logged in", sessionID)
 	}
 
 	jid, err := types.ParseJID(groupJID)
 	if err != nil {
-		return fmt.Errorf("invalid group JID: %w", err)
+		return "", fmt.Errorf("invalid group JID: %w", err)
 	}
 
 	if len(photoData) == 0 {
-		return fmt.Errorf("photo data is required")
+		return "", fmt.Errorf("photo data is required")
 	}
 
-	_, err = client.client.SetGroupPhoto(jid, photoData)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var pictureID string
+	err = runWithContext(opCtx, func() error {
+		var photoErr error
+		pictureID, photoErr = client.client.SetGroupPhoto(jid, photoData)
+		return photoErr
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to set group photo", map[string]interface{}{
 			"session_id": sessionID,
 			"group_jid":  groupJID,
 			"error":      err.Error(),
 		})
-		return err
+		return "", wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Group photo updated successfully", map[string]interface{}{
 		"session_id": sessionID,
 		"group_jid":  groupJID,
+		"picture_id": pictureID,
 	})
 
-	return nil
+	return pictureID, nil
 }
 
 func (g *Gateway) GetGroupInviteLink(ctx context.Context, sessionID, groupJID string) (*group.InviteLink, error) {
@@ -1288,14 +1514,22 @@ func (g *Gateway) GetGroupInviteLink(ctx context.Context, sessionID, groupJID st
 		return nil, fmt.Errorf("invalid group JID: %w", err)
 	}
 
-	inviteLink, err := client.client.GetGroupInviteLink(jid, false)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var inviteLink string
+	err = runWithContext(opCtx, func() error {
+		var linkErr error
+		inviteLink, linkErr = client.client.GetGroupInviteLink(jid, false)
+		return linkErr
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to get group invite link", map[string]interface{}{
 			"session_id": sessionID,
 			"group_jid":  groupJID,
 			"error":      err.Error(),
 		})
-		return nil, err
+		return nil, wrapContextError(err)
 	}
 
 	code := ""
@@ -1342,14 +1576,20 @@ func (g *Gateway) RevokeGroupInviteLink(ctx context.Context, sessionID, groupJID
 		return fmt.Errorf("invalid group JID: %w", err)
 	}
 
-	_, err = client.client.GetGroupInviteLink(jid, true)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		_, linkErr := client.client.GetGroupInviteLink(jid, true)
+		return linkErr
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to revoke group invite link", map[string]interface{}{
 			"session_id": sessionID,
 			"group_jid":  groupJID,
 			"error":      err.Error(),
 		})
-		return err
+		return wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Group invite link revoked successfully", map[string]interface{}{
@@ -1379,14 +1619,19 @@ func (g *Gateway) LeaveGroup(ctx context.Context, sessionID, groupJID string) er
 		return fmt.Errorf("invalid group JID: %w", err)
 	}
 
-	err = client.client.LeaveGroup(jid)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		return client.client.LeaveGroup(jid)
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to leave group", map[string]interface{}{
 			"session_id": sessionID,
 			"group_jid":  groupJID,
 			"error":      err.Error(),
 		})
-		return err
+		return wrapContextError(err)
 	}
 
 	g.logger.InfoWithFields("Left group successfully", map[string]interface{}{
@@ -1415,14 +1660,22 @@ func (g *Gateway) JoinGroupViaLink(ctx context.Context, sessionID, inviteLink st
 		return nil, fmt.Errorf("invite link is required")
 	}
 
-	groupJID, err := client.client.JoinGroupWithLink(inviteLink)
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var groupJID types.JID
+	err := runWithContext(opCtx, func() error {
+		var joinErr error
+		groupJID, joinErr = client.client.JoinGroupWithLink(inviteLink)
+		return joinErr
+	})
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to join group via link", map[string]interface{}{
 			"session_id":  sessionID,
 			"invite_link": inviteLink,
 			"error":       err.Error(),
 		})
-		return nil, err
+		return nil, wrapContextError(err)
 	}
 
 	groupInfo, err := client.client.GetGroupInfo(groupJID)
@@ -1608,7 +1861,7 @@ func (g *Gateway) GetUserInfo(ctx context.Context, sessionID string, jids []stri
 	return results, nil
 }
 
-func (g *Gateway) GetAllContacts(ctx context.Context, sessionID string) ([]*ContactInfo, error) {
+func (g *Gateway) GetAllContacts(ctx context.Context, sessionID string) ([]*contact.ContactInfo, error) {
 	g.logger.InfoWithFields("Getting all contacts", map[string]interface{}{
 		"session_id": sessionID,
 	})
@@ -1621,7 +1874,18 @@ func (g *Gateway) GetAllContacts(ctx context.Context, sessionID string) ([]*Cont
 		return nil, fmt.Errorf("session %s is not logged in", sessionID)
 	}
 
-	results := make([]*ContactInfo, 0)
+	stored, err := client.client.Store.Contacts.GetAllContacts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contact store: %w", err)
+	}
+
+	results := make([]*contact.ContactInfo, 0, len(stored))
+	for jid, info := range stored {
+		if jid.Server != types.DefaultUserServer {
+			continue
+		}
+		results = append(results, storedContactInfo(jid, info))
+	}
 
 	g.logger.InfoWithFields("All contacts retrieved successfully", map[string]interface{}{
 		"session_id":    sessionID,
@@ -1631,6 +1895,54 @@ func (g *Gateway) GetAllContacts(ctx context.Context, sessionID string) ([]*Cont
 	return results, nil
 }
 
+// LookupContacts reads what the session's device stored about the given
+// users: address book names, push names and business names. Users the device
+// knows nothing about are left out.
+func (g *Gateway) LookupContacts(ctx context.Context, sessionName string, jids []string) (map[string]*contact.ContactInfo, error) {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*contact.ContactInfo, len(jids))
+	for _, raw := range jids {
+		jid, err := types.ParseJID(raw)
+		if err != nil {
+			continue
+		}
+
+		info, err := client.client.Store.Contacts.GetContact(ctx, jid.ToNonAD())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read contact store: %w", err)
+		}
+		if info.Found {
+			results[raw] = storedContactInfo(jid, info)
+		}
+	}
+
+	return results, nil
+}
+
+func storedContactInfo(jid types.JID, info types.ContactInfo) *contact.ContactInfo {
+	name := info.FullName
+	if name == "" {
+		name = info.FirstName
+	}
+	if name == "" {
+		name = info.PushName
+	}
+
+	return &contact.ContactInfo{
+		JID:          jid.String(),
+		PhoneNumber:  jid.User,
+		Name:         name,
+		PushName:     info.PushName,
+		BusinessName: info.BusinessName,
+		IsBusiness:   info.BusinessName != "",
+		IsContact:    info.FullName != "" || info.FirstName != "",
+	}
+}
+
 func (g *Gateway) GetBusinessProfile(ctx context.Context, sessionID, jid string) (*BusinessProfile, error) {
 	g.logger.InfoWithFields("Getting business profile", map[string]interface{}{
 		"session_id": sessionID,
@@ -1689,15 +2001,27 @@ func (g *Gateway) convertToGroupInfo(groupInfo *types.GroupInfo, description str
 			JoinedAt: time.Now(),
 			Status:   group.ParticipantStatusActive,
 		}
+		if p.JID.Server == types.HiddenUserServer && !p.PhoneNumber.IsEmpty() {
+			participants[i].PhoneJID = p.PhoneNumber.String()
+		}
 	}
 
 	settings := group.GroupSettings{
 		Announce:         groupInfo.IsAnnounce,
 		Restrict:         groupInfo.IsLocked,
-		JoinApprovalMode: "auto",
-		MemberAddMode:    "all_members",
+		JoinApprovalMode: group.JoinApprovalModeAuto,
+		MemberAddMode:    group.MemberAddModeAllMembers,
 		Locked:           groupInfo.IsLocked,
 	}
+	if groupInfo.IsEphemeral {
+		settings.DisappearingTimer = groupInfo.DisappearingTimer
+	}
+	if groupInfo.IsJoinApprovalRequired {
+		settings.JoinApprovalMode = group.JoinApprovalModeAdminApproval
+	}
+	if groupInfo.MemberAddMode == types.GroupMemberAddModeAdmin {
+		settings.MemberAddMode = group.MemberAddModeOnlyAdmins
+	}
 
 	return &group.GroupInfo{
 		GroupJID:     groupInfo.JID.String(),
@@ -1717,30 +2041,22 @@ func (g *Gateway) GetSessionInfo(ctx context.Context, sessionName string) (*sess
 		return nil, fmt.Errorf("session %s not found", sessionName)
 	}
 
-	whatsmeowClient := client.GetClient()
-	store := whatsmeowClient.Store
-
-	deviceInfo := &session.DeviceInfo{
-		Platform:    "whatsmeow",
-		DeviceModel: "zpwoot-gateway",
-		OSVersion:   "1.0.0",
-		AppVersion:  "2.0.0",
+	device := client.GetClient().Store
+	info := &session.DeviceInfo{
+		PushName:        device.PushName,
+		BusinessName:    device.BusinessName,
+		Platform:        device.Platform,
+		WhatsAppVersion: store.GetWAVersion().String(),
+		Connection:      client.ConnectionInfo(),
 	}
-
-	if store.ID != nil {
-		g.logger.DebugWithFields("Retrieved session info", map[string]interface{}{
-			"session_name":  sessionName,
-			"device_jid":    store.ID.String(),
-			"push_name":     store.PushName,
-			"business_name": store.BusinessName,
-		})
-	} else {
-		g.logger.DebugWithFields("Retrieved session info - no device registered", map[string]interface{}{
-			"session_name": sessionName,
-		})
+	if device.ID != nil {
+		info.JID = device.ID.ToNonAD().String()
+	}
+	if !device.LID.IsEmpty() {
+		info.LID = device.LID.ToNonAD().String()
 	}
 
-	return deviceInfo, nil
+	return info, nil
 }
 
 func (g *Gateway) SendTextMessage(ctx context.Context, sessionName, to, content string) (*session.MessageSendResult, error) {
@@ -1767,16 +2083,26 @@ func (g *Gateway) SendTextMessage(ctx context.Context, sessionName, to, content
 	message := &waE2E.Message{
 		Conversation: &content,
 	}
+	if g.getSettings(sessionName).Features.Enabled(session.FeatureLinkPreview) {
+		withLinkPreview(ctx, message)
+	}
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
 
 	whatsmeowClient := client.GetClient()
-	resp, err := whatsmeowClient.SendMessage(ctx, recipientJID, message)
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to send text message", map[string]interface{}{
 			"session_name": sessionName,
 			"to":           to,
 			"error":        err.Error(),
 		})
-		return nil, fmt.Errorf("failed to send text message: %w", err)
+		return nil, fmt.Errorf("failed to send text message: %w", wrapContextError(err))
 	}
 
 	result := &session.MessageSendResult{
@@ -1818,17 +2144,35 @@ func (g *Gateway) SendMediaMessage(ctx context.Context, sessionName, to, mediaUR
 		return nil, fmt.Errorf("invalid recipient JID: %w", err)
 	}
 
-	content := mediaURL
-	if caption != "" {
-		content = fmt.Sprintf("%s\n\n%s", caption, mediaURL)
+	policy := g.getSettings(sessionName).MediaPolicy
+	data, mimeType, err := loadOutboundMedia(ctx, mediaURL, mediaType, policy.Limit(mediaType))
+	if err != nil {
+		return nil, err
 	}
-
-	message := &waE2E.Message{
-		Conversation: &content,
+	if err := policy.Check(mediaType, mimeType, int64(len(data))); err != nil {
+		return nil, err
 	}
 
 	whatsmeowClient := client.GetClient()
-	resp, err := whatsmeowClient.SendMessage(ctx, recipientJID, message)
+
+	uploaded, err := g.uploadMedia(ctx, whatsmeowClient, sessionName, data, mediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	message := buildMediaMessage(mediaType, mimeType, caption, mediaFileName(mediaURL, mimeType), uploaded)
+	if err := g.processVoiceNote(ctx, sessionName, message, data); err != nil {
+		return nil, err
+	}
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to send media message", map[string]interface{}{
 			"session_name": sessionName,
@@ -1836,7 +2180,7 @@ func (g *Gateway) SendMediaMessage(ctx context.Context, sessionName, to, mediaUR
 			"media_type":   mediaType,
 			"error":        err.Error(),
 		})
-		return nil, fmt.Errorf("failed to send media message: %w", err)
+		return nil, fmt.Errorf("failed to send media message: %w", wrapContextError(err))
 	}
 
 	result := &session.MessageSendResult{
@@ -1891,15 +2235,22 @@ func (g *Gateway) SendLocationMessage(ctx context.Context, sessionName, to strin
 		},
 	}
 
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
 	whatsmeowClient := client.GetClient()
-	resp, err := whatsmeowClient.SendMessage(ctx, recipientJID, message)
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to send location message", map[string]interface{}{
 			"session_name": sessionName,
 			"to":           to,
 			"error":        err.Error(),
 		})
-		return nil, fmt.Errorf("failed to send location message: %w", err)
+		return nil, fmt.Errorf("failed to send location message: %w", wrapContextError(err))
 	}
 
 	result := &session.MessageSendResult{
@@ -1918,7 +2269,7 @@ func (g *Gateway) SendLocationMessage(ctx context.Context, sessionName, to strin
 	return result, nil
 }
 
-func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to, contactName, contactPhone string) (*session.MessageSendResult, error) {
+func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to string, card *session.ContactCard) (*session.MessageSendResult, error) {
 	client := g.getClient(sessionName)
 	if client == nil {
 		return nil, fmt.Errorf("session %s not found", sessionName)
@@ -1929,10 +2280,11 @@ func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to, conta
 	}
 
 	g.logger.InfoWithFields("Sending contact message via WhatsApp", map[string]interface{}{
-		"session_name":  sessionName,
-		"to":            to,
-		"contact_name":  contactName,
-		"contact_phone": contactPhone,
+		"session_name": sessionName,
+		"to":           to,
+		"contact_name": card.Name,
+		"phone_count":  len(card.Phones),
+		"has_photo":    card.PhotoSource != "" || len(card.Photo) > 0,
 	})
 
 	recipientJID, err := types.ParseJID(to)
@@ -1940,7 +2292,18 @@ func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to, conta
 		return nil, fmt.Errorf("invalid recipient JID: %w", err)
 	}
 
-	vcard := fmt.Sprintf("BEGIN:VCARD\nVERSION:3.0\nFN:%s\nTEL:%s\nEND:VCARD", contactName, contactPhone)
+	if card.PhotoSource != "" && len(card.Photo) == 0 {
+		card.Photo, card.PhotoMimeType, err = loadOutboundMedia(ctx, card.PhotoSource, "image", session.MaxContactPhotoBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := card.Validate(); err != nil {
+		return nil, err
+	}
+
+	contactName := card.Name
+	vcard := card.VCard()
 
 	message := &waE2E.Message{
 		ContactMessage: &waE2E.ContactMessage{
@@ -1949,15 +2312,22 @@ func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to, conta
 		},
 	}
 
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
 	whatsmeowClient := client.GetClient()
-	resp, err := whatsmeowClient.SendMessage(ctx, recipientJID, message)
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
 	if err != nil {
 		g.logger.ErrorWithFields("Failed to send contact message", map[string]interface{}{
 			"session_name": sessionName,
 			"to":           to,
 			"error":        err.Error(),
 		})
-		return nil, fmt.Errorf("failed to send contact message: %w", err)
+		return nil, fmt.Errorf("failed to send contact message: %w", wrapContextError(err))
 	}
 
 	result := &session.MessageSendResult{
@@ -1976,6 +2346,66 @@ func (g *Gateway) SendContactMessage(ctx context.Context, sessionName, to, conta
 	return result, nil
 }
 
+func (g *Gateway) SendButtonMessage(ctx context.Context, sessionName, to string, buttonMessage *session.ButtonMessage) (*session.MessageSendResult, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	g.logger.InfoWithFields("Sending button message via WhatsApp", map[string]interface{}{
+		"session_name": sessionName,
+		"to":           to,
+		"button_count": len(buttonMessage.Buttons),
+	})
+
+	recipientJID, err := types.ParseJID(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient JID: %w", err)
+	}
+
+	message, err := buildNativeFlowMessage(buttonMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
+	whatsmeowClient := client.GetClient()
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to send button message", map[string]interface{}{
+			"session_name": sessionName,
+			"to":           to,
+			"error":        err.Error(),
+		})
+		return nil, fmt.Errorf("failed to send button message: %w", wrapContextError(err))
+	}
+
+	result := &session.MessageSendResult{
+		MessageID: resp.ID,
+		Status:    "sent",
+		Timestamp: resp.Timestamp,
+		To:        to,
+	}
+
+	g.logger.InfoWithFields("Button message sent successfully", map[string]interface{}{
+		"session_name": sessionName,
+		"message_id":   resp.ID,
+		"to":           to,
+	})
+
+	return result, nil
+}
+
 func (g *Gateway) SetEventHandler(handler session.EventHandler) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
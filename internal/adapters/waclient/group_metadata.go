@@ -0,0 +1,185 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/group"
+)
+
+const groupRefreshTimeout = 30 * time.Second
+
+// GroupMetadataChangedEvent is delivered to webhooks when a refresh finds a
+// group renamed or its topic changed while the session did not see it
+// happen. Changes seen as they happen arrive as group.updated.
+type GroupMetadataChangedEvent struct {
+	Event         string    `json:"event"`
+	SessionName   string    `json:"session_name"`
+	GroupJID      string    `json:"group_jid"`
+	Name          string    `json:"name"`
+	PreviousName  string    `json:"previous_name"`
+	Topic         string    `json:"topic"`
+	PreviousTopic string    `json:"previous_topic"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// GroupMetadataObserver keeps the group metadata cache current from group
+// info events.
+type GroupMetadataObserver interface {
+	Observe(ctx context.Context, sessionID uuid.UUID, sessionName, groupJID string, name, topic *string, at time.Time)
+	Refresh(ctx context.Context, sessionID uuid.UUID, sessionName, groupJID string) (*group.Metadata, error)
+	Forget(ctx context.Context, sessionID uuid.UUID, groupJID string)
+}
+
+func (g *Gateway) SetGroupMetadata(observer GroupMetadataObserver) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.groupMetadata = observer
+}
+
+func (g *Gateway) getGroupMetadata() GroupMetadataObserver {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.groupMetadata
+}
+
+// FetchGroupMetadata asks WhatsApp for the group's name, topic and size.
+func (g *Gateway) FetchGroupMetadata(ctx context.Context, sessionName, groupJID string) (*group.Metadata, error) {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var info *types.GroupInfo
+	err = runWithContext(opCtx, func() error {
+		var infoErr error
+		info, infoErr = client.client.GetGroupInfo(jid)
+		return infoErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group info: %w", wrapContextError(err))
+	}
+
+	return &group.Metadata{
+		GroupJID:         info.JID.String(),
+		Name:             info.Name,
+		Topic:            info.Topic,
+		ParticipantCount: len(info.Participants),
+	}, nil
+}
+
+// EmitGroupMetadataChanged delivers changes found by a refresh as
+// group.metadata_changed through the session's inbound pipeline.
+func (g *Gateway) EmitGroupMetadataChanged(_ context.Context, sessionName string, change *group.MetadataChange) {
+	if !change.Refreshed {
+		return
+	}
+
+	client := g.getClient(sessionName)
+	if client == nil {
+		return
+	}
+
+	client.notifyEventHandlers(&GroupMetadataChangedEvent{
+		Event:         "group.metadata_changed",
+		SessionName:   sessionName,
+		GroupJID:      change.GroupJID,
+		Name:          change.Name,
+		PreviousName:  change.PreviousName,
+		Topic:         change.Topic,
+		PreviousTopic: change.PreviousTopic,
+		Timestamp:     change.ChangedAt,
+	})
+}
+
+// handleGroupInfo applies name and topic changes to the group metadata
+// cache, forgets groups the session left or that were deleted, and refetches
+// the group in the background when its participants change.
+func (h *EventHandler) handleGroupInfo(evt *events.GroupInfo, sessionID string) {
+	h.logger.DebugWithFields("Group info update", map[string]interface{}{
+		"session_id": sessionID,
+		"jid":        evt.JID.String(),
+	})
+
+	observer := h.gateway.getGroupMetadata()
+	if observer == nil {
+		return
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+	groupJID := evt.JID.String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	if evt.Delete != nil || h.leftGroup(evt.Leave) {
+		observer.Forget(ctx, id, groupJID)
+		return
+	}
+
+	if evt.Name != nil || evt.Topic != nil {
+		var name, topic *string
+		if evt.Name != nil {
+			name = &evt.Name.Name
+		}
+		if evt.Topic != nil {
+			topic = &evt.Topic.Topic
+		}
+		observer.Observe(ctx, id, h.sessionName, groupJID, name, topic, evt.Timestamp)
+	}
+
+	if len(evt.Join) > 0 || len(evt.Leave) > 0 {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), groupRefreshTimeout)
+			defer cancel()
+
+			if _, err := observer.Refresh(ctx, id, h.sessionName, groupJID); err != nil {
+				h.logger.DebugWithFields("Failed to refresh group after participant change", map[string]interface{}{
+					"session_id": sessionID,
+					"group_jid":  groupJID,
+					"error":      err.Error(),
+				})
+			}
+		}()
+	}
+}
+
+// leftGroup reports whether the session's own number or LID is among the
+// participants who left.
+func (h *EventHandler) leftGroup(left []types.JID) bool {
+	if len(left) == 0 {
+		return false
+	}
+
+	client := h.gateway.getClient(h.sessionName)
+	if client == nil {
+		return false
+	}
+	device := client.GetClient().Store
+	if device == nil || device.ID == nil {
+		return false
+	}
+
+	for _, jid := range left {
+		if jid.User == device.ID.User || (!device.LID.IsEmpty() && jid.User == device.LID.User) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,379 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+
+	"zpwoot/internal/core/group"
+)
+
+const inviteLinkPrefix = "https://chat.whatsapp.com/"
+
+func (g *Gateway) loggedInClient(sessionID string) (*Client, error) {
+	client := g.getClient(sessionID)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionID)
+	}
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionID)
+	}
+	return client, nil
+}
+
+func (g *Gateway) SetGroupAnnounce(ctx context.Context, sessionID, groupJID string, announce bool) error {
+	return g.setGroupFlag(ctx, sessionID, groupJID, "announce", announce, func(client *whatsmeow.Client, jid types.JID) error {
+		return client.SetGroupAnnounce(jid, announce)
+	})
+}
+
+// SetGroupRestrict maps to WhatsApp's "locked" flag, which restricts editing
+// group info to admins.
+func (g *Gateway) SetGroupRestrict(ctx context.Context, sessionID, groupJID string, restrict bool) error {
+	return g.setGroupFlag(ctx, sessionID, groupJID, "restrict", restrict, func(client *whatsmeow.Client, jid types.JID) error {
+		return client.SetGroupLocked(jid, restrict)
+	})
+}
+
+func (g *Gateway) SetGroupLocked(ctx context.Context, sessionID, groupJID string, locked bool) error {
+	return g.setGroupFlag(ctx, sessionID, groupJID, "locked", locked, func(client *whatsmeow.Client, jid types.JID) error {
+		return client.SetGroupLocked(jid, locked)
+	})
+}
+
+func (g *Gateway) setGroupFlag(ctx context.Context, sessionID, groupJID, setting string, value bool, apply func(*whatsmeow.Client, types.JID) error) error {
+	g.logger.InfoWithFields("Updating group setting", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  groupJID,
+		"setting":    setting,
+		"value":      value,
+	})
+
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		return apply(client.client, jid)
+	})
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to update group setting", map[string]interface{}{
+			"session_id": sessionID,
+			"group_jid":  groupJID,
+			"setting":    setting,
+			"error":      err.Error(),
+		})
+		return wrapContextError(err)
+	}
+
+	return nil
+}
+
+func (g *Gateway) JoinGroupWithInvite(ctx context.Context, sessionID, groupJID, inviteCode string) (*group.GroupInfo, error) {
+	g.logger.InfoWithFields("Joining group with invite", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  groupJID,
+	})
+
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var info *types.GroupInfo
+	err = runWithContext(opCtx, func() error {
+		if joinErr := client.client.JoinGroupWithInvite(jid, types.EmptyJID, inviteCode, 0); joinErr != nil {
+			return joinErr
+		}
+		var infoErr error
+		info, infoErr = client.client.GetGroupInfo(jid)
+		return infoErr
+	})
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to join group with invite", map[string]interface{}{
+			"session_id": sessionID,
+			"group_jid":  groupJID,
+			"error":      err.Error(),
+		})
+		return nil, wrapContextError(err)
+	}
+
+	return g.convertToGroupInfo(info, info.Topic), nil
+}
+
+func (g *Gateway) GetGroupRequestParticipants(ctx context.Context, sessionID, groupJID string) ([]*group.GroupRequest, error) {
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var requests []types.GroupParticipantRequest
+	err = runWithContext(opCtx, func() error {
+		var reqErr error
+		requests, reqErr = client.client.GetGroupRequestParticipants(jid)
+		return reqErr
+	})
+	if err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	result := make([]*group.GroupRequest, len(requests))
+	for i, req := range requests {
+		result[i] = &group.GroupRequest{
+			GroupJID:     groupJID,
+			RequesterJID: req.JID.String(),
+			RequestedAt:  req.RequestedAt,
+			Status:       "pending",
+		}
+	}
+
+	return result, nil
+}
+
+func (g *Gateway) ApproveGroupRequest(ctx context.Context, sessionID, groupJID string, requesterJIDs []string) error {
+	return g.updateGroupRequests(ctx, sessionID, groupJID, requesterJIDs, whatsmeow.ParticipantChangeApprove)
+}
+
+func (g *Gateway) RejectGroupRequest(ctx context.Context, sessionID, groupJID string, requesterJIDs []string) error {
+	return g.updateGroupRequests(ctx, sessionID, groupJID, requesterJIDs, whatsmeow.ParticipantChangeReject)
+}
+
+func (g *Gateway) updateGroupRequests(ctx context.Context, sessionID, groupJID string, requesterJIDs []string, action whatsmeow.ParticipantRequestChange) error {
+	g.logger.InfoWithFields("Updating group join requests", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  groupJID,
+		"action":     string(action),
+		"requesters": len(requesterJIDs),
+	})
+
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	requesters, err := parseJIDs(requesterJIDs)
+	if err != nil {
+		return err
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		_, updateErr := client.client.UpdateGroupRequestParticipants(jid, requesters, action)
+		return updateErr
+	})
+	return wrapContextError(err)
+}
+
+func (g *Gateway) GetGroupInfoFromInviteLink(ctx context.Context, sessionID, inviteLink string) (*group.GroupInfo, error) {
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.TrimPrefix(inviteLink, inviteLinkPrefix)
+	if code == "" {
+		return nil, fmt.Errorf("invite link is required")
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var info *types.GroupInfo
+	err = runWithContext(opCtx, func() error {
+		var infoErr error
+		info, infoErr = client.client.GetGroupInfoFromLink(code)
+		return infoErr
+	})
+	if err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	return g.convertToGroupInfo(info, info.Topic), nil
+}
+
+func (g *Gateway) GetGroupInfoFromInvite(ctx context.Context, sessionID, groupJID, inviteCode string) (*group.GroupInfo, error) {
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var info *types.GroupInfo
+	err = runWithContext(opCtx, func() error {
+		var infoErr error
+		info, infoErr = client.client.GetGroupInfoFromInvite(jid, types.EmptyJID, inviteCode, 0)
+		return infoErr
+	})
+	if err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	return g.convertToGroupInfo(info, info.Topic), nil
+}
+
+// ResolveWhatsAppNumbers looks up which phone numbers are registered and
+// returns their canonical JIDs keyed by the number as given.
+func (g *Gateway) ResolveWhatsAppNumbers(ctx context.Context, sessionID string, phoneNumbers []string) (map[string]string, error) {
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]string, len(phoneNumbers))
+	byQuery := make(map[string]string, len(phoneNumbers))
+	for i, phone := range phoneNumbers {
+		digits := strings.TrimPrefix(phone, "+")
+		queries[i] = "+" + digits
+		byQuery[digits] = phone
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var responses []types.IsOnWhatsAppResponse
+	err = runWithContext(opCtx, func() error {
+		var checkErr error
+		responses, checkErr = client.client.IsOnWhatsApp(queries)
+		return checkErr
+	})
+	g.noteRateLimit(sessionID, err)
+	if err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	resolved := make(map[string]string, len(responses))
+	for _, resp := range responses {
+		if !resp.IsIn {
+			continue
+		}
+		if phone, ok := byQuery[strings.TrimPrefix(resp.Query, "+")]; ok {
+			resolved[phone] = resp.JID.String()
+		}
+	}
+
+	return resolved, nil
+}
+
+// AddParticipantsWithResult adds participants and reports the per-participant
+// outcome instead of failing the whole batch when some adds are rejected.
+func (g *Gateway) AddParticipantsWithResult(ctx context.Context, sessionID, groupJID string, participants []string) ([]group.ParticipantAddResult, error) {
+	client, err := g.loggedInClient(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	jid, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid group JID: %w", err)
+	}
+
+	participantJIDs, err := parseJIDs(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	opCtx, cancel := g.withOperationTimeout(ctx)
+	defer cancel()
+
+	var changes []types.GroupParticipant
+	err = runWithContext(opCtx, func() error {
+		var updateErr error
+		changes, updateErr = client.client.UpdateGroupParticipants(jid, participantJIDs, whatsmeow.ParticipantChangeAdd)
+		return updateErr
+	})
+	if err != nil {
+		return nil, wrapContextError(err)
+	}
+
+	results := make([]group.ParticipantAddResult, 0, len(changes))
+	for _, change := range changes {
+		result := group.ParticipantAddResult{
+			JID:       change.JID.String(),
+			ErrorCode: change.Error,
+		}
+		if !change.PhoneNumber.IsEmpty() {
+			result.JID = change.PhoneNumber.String()
+		}
+
+		switch change.Error {
+		case 0:
+			result.Status = group.BulkAddStatusAdded
+		case 409:
+			result.Status = group.BulkAddStatusAlreadyMember
+		case 403, 408:
+			result.Status = group.BulkAddStatusNeedsInvite
+			if change.AddRequest != nil {
+				result.InviteCode = change.AddRequest.Code
+				expiration := change.AddRequest.Expiration
+				result.InviteExpiration = &expiration
+			}
+		default:
+			result.Status = group.BulkAddStatusFailed
+		}
+
+		results = append(results, result)
+	}
+
+	g.logger.InfoWithFields("Group participants add processed", map[string]interface{}{
+		"session_id":   sessionID,
+		"group_jid":    groupJID,
+		"participants": len(participants),
+		"results":      len(results),
+	})
+
+	return results, nil
+}
+
+func parseJIDs(values []string) ([]types.JID, error) {
+	jids := make([]types.JID, len(values))
+	for i, value := range values {
+		jid, err := types.ParseJID(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant JID %s: %w", value, err)
+		}
+		jids[i] = jid
+	}
+	return jids, nil
+}
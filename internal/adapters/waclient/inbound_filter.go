@@ -0,0 +1,191 @@
+package waclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/session"
+)
+
+const (
+	// filterReplyInterval is how long the filter waits before auto-replying
+	// in the same chat again, so a flood gets one answer.
+	filterReplyInterval = time.Hour
+	filterActionTimeout = 15 * time.Second
+)
+
+// FilterAnnotation is the key of the filter match on tagged message
+// webhooks.
+const FilterAnnotation = "filter"
+
+func (g *Gateway) SetFilterLog(log session.FilterLog) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.filterLog = log
+}
+
+func (g *Gateway) getFilterLog() session.FilterLog {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.filterLog
+}
+
+// filterReplies remembers when each chat was last auto-replied to.
+type filterReplies struct {
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+// allow reports whether the chat may be replied to now, and if so counts the
+// reply. Expired entries are dropped along the way.
+func (f *filterReplies) allow(sessionName, chatJID string, now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sent == nil {
+		f.sent = make(map[string]time.Time)
+	}
+	for key, at := range f.sent {
+		if now.Sub(at) >= filterReplyInterval {
+			delete(f.sent, key)
+		}
+	}
+
+	key := sessionName + "/" + chatJID
+	if _, ok := f.sent[key]; ok {
+		return false
+	}
+	f.sent[key] = now
+	return true
+}
+
+// filterInbound screens a message a contact sent against the session's
+// inbound filter. It reports whether the event goes on to Chatwoot and
+// webhooks, which the drop action prevents; a tagged match is annotated on
+// the event for the webhook.
+func (h *EventHandler) filterInbound(evt *inbound.Event) bool {
+	msg, ok := evt.Payload.(*events.Message)
+	if !ok || msg.Info.IsFromMe || msg.Info.Chat.Server == types.BroadcastServer {
+		return true
+	}
+	if msg.Message.GetReactionMessage() != nil || msg.Message.GetPollUpdateMessage() != nil || IsEditOrRevoke(msg) || IsPin(msg) {
+		return true
+	}
+
+	filter := h.gateway.getSettings(h.sessionName).InboundFilter
+	if len(filter.Rules) == 0 || (msg.Info.IsGroup && !filter.Groups) {
+		return true
+	}
+
+	match := filter.Match(msg.Info.Sender.ToNonAD().String(), filterText(msg))
+	if match == nil {
+		return true
+	}
+
+	h.logger.InfoWithFields("Inbound message matched filter", map[string]interface{}{
+		"session_id": evt.SessionID,
+		"message_id": msg.Info.ID,
+		"sender":     msg.Info.Sender.ToNonAD().String(),
+		"rule":       match.Rule,
+		"actions":    match.Actions,
+	})
+
+	if match.Has(session.FilterTag) {
+		evt.Annotate(FilterAnnotation, match)
+	}
+
+	// Replying and blocking go through the socket, so keep them off the
+	// event dispatch path.
+	go h.actOnFilterMatch(msg, match, evt.SessionID)
+
+	return !match.Has(session.FilterDrop)
+}
+
+// actOnFilterMatch replies to and blocks the sender as the rule says, then
+// records the message in the filter log with the actions that failed.
+func (h *EventHandler) actOnFilterMatch(msg *events.Message, match *session.FilterMatch, sessionID string) {
+	actionErrors := make(map[session.FilterAction]string)
+	chatJID := msg.Info.Chat.ToNonAD().String()
+	sender := msg.Info.Sender.ToNonAD()
+
+	if match.Has(session.FilterReply) && h.gateway.filterReplies.allow(h.sessionName, chatJID, time.Now()) {
+		ctx, cancel := context.WithTimeout(context.Background(), filterActionTimeout)
+		if _, err := h.gateway.SendTextMessage(ctx, h.sessionName, chatJID, match.Reply); err != nil {
+			actionErrors[session.FilterReply] = err.Error()
+		}
+		cancel()
+	}
+
+	if match.Has(session.FilterBlock) {
+		if client := h.gateway.getClient(h.sessionName); client == nil {
+			actionErrors[session.FilterBlock] = session.ErrSessionNotConnected.Error()
+		} else if _, err := client.GetClient().UpdateBlocklist(sender, events.BlocklistChangeActionBlock); err != nil {
+			actionErrors[session.FilterBlock] = err.Error()
+		}
+	}
+
+	for action, reason := range actionErrors {
+		h.logger.WarnWithFields("Inbound filter action failed", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": msg.Info.ID,
+			"action":     action,
+			"error":      reason,
+		})
+	}
+
+	filterLog := h.gateway.getFilterLog()
+	if filterLog == nil {
+		return
+	}
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	err = filterLog.Record(ctx, &session.FilteredMessage{
+		ID:           uuid.New(),
+		SessionID:    id,
+		MessageID:    msg.Info.ID,
+		ChatJID:      chatJID,
+		SenderJID:    sender.String(),
+		Text:         filterText(msg),
+		Match:        *match,
+		ActionErrors: actionErrors,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		h.logger.ErrorWithFields("Failed to record filtered message", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": msg.Info.ID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// filterText is the text rules are matched against: the message body, or
+// the caption of media.
+func filterText(msg *events.Message) string {
+	m := msg.Message
+	switch {
+	case m.GetConversation() != "":
+		return m.GetConversation()
+	case m.GetExtendedTextMessage() != nil:
+		return m.GetExtendedTextMessage().GetText()
+	case m.GetImageMessage() != nil:
+		return m.GetImageMessage().GetCaption()
+	case m.GetVideoMessage() != nil:
+		return m.GetVideoMessage().GetCaption()
+	case m.GetDocumentMessage() != nil:
+		return m.GetDocumentMessage().GetCaption()
+	}
+	return ""
+}
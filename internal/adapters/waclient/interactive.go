@@ -0,0 +1,105 @@
+package waclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+
+	"zpwoot/internal/core/session"
+)
+
+var nativeFlowButtonNames = map[session.ButtonType]string{
+	session.ButtonTypeReply: "quick_reply",
+	session.ButtonTypeURL:   "cta_url",
+	session.ButtonTypeCall:  "cta_call",
+	session.ButtonTypeCopy:  "cta_copy",
+}
+
+func buildNativeFlowMessage(buttonMessage *session.ButtonMessage) (*waE2E.Message, error) {
+	buttons := make([]*waE2E.InteractiveMessage_NativeFlowMessage_NativeFlowButton, 0, len(buttonMessage.Buttons))
+
+	for _, button := range buttonMessage.Buttons {
+		name, ok := nativeFlowButtonNames[button.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported button type: %s", button.Type)
+		}
+
+		params, err := nativeFlowButtonParams(button)
+		if err != nil {
+			return nil, err
+		}
+
+		buttons = append(buttons, &waE2E.InteractiveMessage_NativeFlowMessage_NativeFlowButton{
+			Name:             &name,
+			ButtonParamsJSON: &params,
+		})
+	}
+
+	text := buttonMessage.Text
+	messageVersion := int32(1)
+	interactive := &waE2E.InteractiveMessage{
+		Body: &waE2E.InteractiveMessage_Body{
+			Text: &text,
+		},
+		InteractiveMessage: &waE2E.InteractiveMessage_NativeFlowMessage_{
+			NativeFlowMessage: &waE2E.InteractiveMessage_NativeFlowMessage{
+				Buttons:        buttons,
+				MessageVersion: &messageVersion,
+			},
+		},
+	}
+
+	if buttonMessage.Title != "" {
+		title := buttonMessage.Title
+		hasMedia := false
+		interactive.Header = &waE2E.InteractiveMessage_Header{
+			Title:              &title,
+			HasMediaAttachment: &hasMedia,
+		}
+	}
+
+	if buttonMessage.Footer != "" {
+		footer := buttonMessage.Footer
+		interactive.Footer = &waE2E.InteractiveMessage_Footer{
+			Text: &footer,
+		}
+	}
+
+	// Native flow messages are only rendered by current clients when wrapped
+	// in a view-once container, which is also what whatsmeow inspects to tag
+	// the outgoing node as interactive.
+	return &waE2E.Message{
+		ViewOnceMessage: &waE2E.FutureProofMessage{
+			Message: &waE2E.Message{
+				InteractiveMessage: interactive,
+			},
+		},
+	}, nil
+}
+
+func nativeFlowButtonParams(button session.InteractiveButton) (string, error) {
+	params := map[string]string{
+		"display_text": button.Text,
+	}
+
+	switch button.Type {
+	case session.ButtonTypeReply:
+		params["id"] = button.ID
+	case session.ButtonTypeURL:
+		params["url"] = button.URL
+		params["merchant_url"] = button.URL
+	case session.ButtonTypeCall:
+		params["phone_number"] = button.PhoneNumber
+	case session.ButtonTypeCopy:
+		params["id"] = button.ID
+		params["copy_code"] = button.CopyCode
+	}
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode button params: %w", err)
+	}
+
+	return string(encoded), nil
+}
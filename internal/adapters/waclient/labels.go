@@ -0,0 +1,173 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/label"
+	"zpwoot/platform/logger"
+)
+
+// LabelStore keeps the local label table in sync with label changes made
+// on the phone or other linked devices.
+type LabelStore interface {
+	Save(ctx context.Context, label *label.Label) error
+	SetAssociation(ctx context.Context, association *label.Association, labeled bool) error
+}
+
+func (g *Gateway) SetLabelStore(store LabelStore) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.labelStore = store
+}
+
+func (g *Gateway) getLabelStore() LabelStore {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.labelStore
+}
+
+// EditLabel creates, renames, recolors or deletes a label. WhatsApp has a
+// single label_edit mutation for all of these.
+func (g *Gateway) EditLabel(ctx context.Context, sessionName string, l *label.Label) error {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	patch := appstate.BuildLabelEdit(l.LabelID, l.Name, l.Color, l.Deleted)
+
+	opCtx, span := startCallSpan(ctx, "SendAppState", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	err := client.GetClient().SendAppState(opCtx, patch)
+	logger.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to edit label: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Label updated", map[string]interface{}{
+		"session_name": sessionName,
+		"label_id":     l.LabelID,
+		"deleted":      l.Deleted,
+	})
+
+	return nil
+}
+
+// SetLabelAssociation applies a label to, or removes it from, a chat or a
+// single message.
+func (g *Gateway) SetLabelAssociation(ctx context.Context, sessionName string, association *label.Association, labeled bool) error {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	chatJID, err := types.ParseJID(association.ChatJID)
+	if err != nil {
+		return fmt.Errorf("invalid chat JID: %w", err)
+	}
+
+	var patch appstate.PatchInfo
+	if association.IsMessage() {
+		patch = appstate.BuildLabelMessage(chatJID.ToNonAD(), association.LabelID, association.MessageID, labeled)
+	} else {
+		patch = appstate.BuildLabelChat(chatJID.ToNonAD(), association.LabelID, labeled)
+	}
+
+	opCtx, span := startCallSpan(ctx, "SendAppState", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	err = client.GetClient().SendAppState(opCtx, patch)
+	logger.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update label association: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Label association updated", map[string]interface{}{
+		"session_name": sessionName,
+		"label_id":     association.LabelID,
+		"chat_jid":     association.ChatJID,
+		"message_id":   association.MessageID,
+		"labeled":      labeled,
+	})
+
+	return nil
+}
+
+func (g *Gateway) saveLabel(l *label.Label) error {
+	store := g.getLabelStore()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.Save(ctx, l)
+}
+
+func (g *Gateway) saveLabelAssociation(association *label.Association, labeled bool) error {
+	store := g.getLabelStore()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.SetAssociation(ctx, association, labeled)
+}
+
+func (h *EventHandler) handleLabelEdit(evt *events.LabelEdit, sessionID string) {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil || evt.Action == nil {
+		return
+	}
+
+	l := &label.Label{
+		SessionID: sessionUUID,
+		LabelID:   evt.LabelID,
+		Name:      evt.Action.GetName(),
+		Color:     evt.Action.GetColor(),
+		Deleted:   evt.Action.GetDeleted(),
+	}
+
+	if err := h.gateway.saveLabel(l); err != nil {
+		h.logger.ErrorWithFields("Failed to save label", map[string]interface{}{
+			"session_id": sessionID,
+			"label_id":   evt.LabelID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (h *EventHandler) handleLabelAssociation(sessionID string, association *label.Association, labeled bool) {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+	association.SessionID = sessionUUID
+
+	if err := h.gateway.saveLabelAssociation(association, labeled); err != nil {
+		h.logger.ErrorWithFields("Failed to save label association", map[string]interface{}{
+			"session_id": sessionID,
+			"label_id":   association.LabelID,
+			"chat_jid":   association.ChatJID,
+			"error":      err.Error(),
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package waclient
+
+import (
+	"context"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+const (
+	linkPreviewTimeout  = 5 * time.Second
+	linkPreviewMaxBytes = 512 * 1024
+)
+
+var (
+	linkURLRe       = regexp.MustCompile(`https?://[^\s<>"']+`)
+	linkMetaRe      = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	linkMetaAttrRe  = regexp.MustCompile(`(?is)(property|name|content)\s*=\s*("[^"]*"|'[^']*')`)
+	linkTitleTagRe  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	linkPreviewHTTP = &http.Client{Timeout: linkPreviewTimeout}
+)
+
+// linkPreview is what WhatsApp shows under a message for its first link.
+type linkPreview struct {
+	URL         string
+	Title       string
+	Description string
+}
+
+// withLinkPreview turns a text message whose text has a link into an
+// extended text message previewing the first one. The page is fetched with a
+// short timeout; a page that cannot be fetched or has no title leaves the
+// message as it is.
+func withLinkPreview(ctx context.Context, message *waE2E.Message) {
+	if message.Conversation == nil {
+		return
+	}
+
+	text := *message.Conversation
+	link := linkURLRe.FindString(text)
+	if link == "" {
+		return
+	}
+	link = strings.TrimRight(link, ".,;:!?)")
+
+	preview := fetchLinkPreview(ctx, link)
+	if preview == nil {
+		return
+	}
+
+	extended := &waE2E.ExtendedTextMessage{
+		Text:        &text,
+		MatchedText: &preview.URL,
+		Title:       &preview.Title,
+	}
+	if preview.Description != "" {
+		extended.Description = &preview.Description
+	}
+	message.Conversation = nil
+	message.ExtendedTextMessage = extended
+}
+
+func fetchLinkPreview(ctx context.Context, link string) *linkPreview {
+	ctx, cancel := context.WithTimeout(ctx, linkPreviewTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := linkPreviewHTTP.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK || !strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, linkPreviewMaxBytes))
+	if err != nil {
+		return nil
+	}
+
+	preview := parseLinkPreview(string(body))
+	if preview.Title == "" {
+		return nil
+	}
+	preview.URL = link
+	return preview
+}
+
+// parseLinkPreview reads the page's Open Graph title and description,
+// falling back to its <title> and meta description.
+func parseLinkPreview(page string) *linkPreview {
+	meta := make(map[string]string)
+	for _, tag := range linkMetaRe.FindAllString(page, -1) {
+		var key, content string
+		for _, attr := range linkMetaAttrRe.FindAllStringSubmatch(tag, -1) {
+			value := attr[2][1 : len(attr[2])-1]
+			if strings.EqualFold(attr[1], "content") {
+				content = value
+			} else {
+				key = strings.ToLower(value)
+			}
+		}
+		if key != "" && content != "" {
+			if _, seen := meta[key]; !seen {
+				meta[key] = html.UnescapeString(strings.TrimSpace(content))
+			}
+		}
+	}
+
+	preview := &linkPreview{
+		Title:       meta["og:title"],
+		Description: meta["og:description"],
+	}
+	if preview.Title == "" {
+		if match := linkTitleTagRe.FindStringSubmatch(page); match != nil {
+			preview.Title = html.UnescapeString(strings.TrimSpace(match[1]))
+		}
+	}
+	if preview.Description == "" {
+		preview.Description = meta["description"]
+	}
+
+	return preview
+}
@@ -0,0 +1,188 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+// LiveLocationEvent is delivered to webhooks for every live location update
+// received, the first one that starts the share included.
+type LiveLocationEvent struct {
+	Event          string    `json:"event"`
+	SessionName    string    `json:"session_name"`
+	MessageID      string    `json:"message_id"`
+	Chat           string    `json:"chat"`
+	Sharer         string    `json:"sharer"`
+	FromMe         bool      `json:"from_me"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	AccuracyMeters uint32    `json:"accuracy_meters,omitempty"`
+	SpeedMps       float32   `json:"speed_mps,omitempty"`
+	Heading        uint32    `json:"heading,omitempty"`
+	Caption        string    `json:"caption,omitempty"`
+	Sequence       int64     `json:"sequence"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+func (g *Gateway) SendLiveLocationMessage(ctx context.Context, sessionName, to string, location *session.LiveLocation) (*session.MessageSendResult, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	recipientJID, err := types.ParseJID(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient JID: %w", err)
+	}
+
+	live := &waE2E.LiveLocationMessage{
+		DegreesLatitude:  &location.Latitude,
+		DegreesLongitude: &location.Longitude,
+		SequenceNumber:   &location.Sequence,
+		TimeOffset:       &location.TimeOffset,
+	}
+	if location.AccuracyMeters > 0 {
+		live.AccuracyInMeters = &location.AccuracyMeters
+	}
+	if location.SpeedMps > 0 {
+		live.SpeedInMps = &location.SpeedMps
+	}
+	if location.Heading > 0 {
+		live.DegreesClockwiseFromMagneticNorth = &location.Heading
+	}
+	if location.Caption != "" {
+		live.Caption = &location.Caption
+	}
+	message := &waE2E.Message{LiveLocationMessage: live}
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
+	whatsmeowClient := client.GetClient()
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to send live location message", map[string]interface{}{
+			"session_name": sessionName,
+			"to":           to,
+			"error":        err.Error(),
+		})
+		return nil, fmt.Errorf("failed to send live location message: %w", wrapContextError(err))
+	}
+
+	if sessionUUID, err := uuid.Parse(g.GetSessionUUID(sessionName)); err == nil {
+		g.saveLiveLocation(&messaging.LiveLocationPoint{
+			SessionID:      sessionUUID,
+			ChatJID:        recipientJID.String(),
+			SharerJID:      client.GetJID().ToNonAD().String(),
+			ZpMessageID:    resp.ID,
+			Latitude:       location.Latitude,
+			Longitude:      location.Longitude,
+			AccuracyMeters: location.AccuracyMeters,
+			SpeedMps:       location.SpeedMps,
+			Heading:        location.Heading,
+			Caption:        location.Caption,
+			Sequence:       location.Sequence,
+			FromMe:         true,
+			ReportedAt:     resp.Timestamp,
+		})
+	}
+
+	g.logger.InfoWithFields("Live location message sent successfully", map[string]interface{}{
+		"session_name": sessionName,
+		"message_id":   resp.ID,
+		"to":           to,
+		"sequence":     location.Sequence,
+	})
+
+	return &session.MessageSendResult{
+		MessageID: resp.ID,
+		Status:    "sent",
+		Timestamp: resp.Timestamp,
+		To:        to,
+	}, nil
+}
+
+func (g *Gateway) saveLiveLocation(point *messaging.LiveLocationPoint) {
+	store := g.getMessageStore()
+	if store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	if err := store.RecordLiveLocation(ctx, point); err != nil {
+		g.logger.ErrorWithFields("Failed to save live location", map[string]interface{}{
+			"session_id": point.SessionID.String(),
+			"message_id": point.ZpMessageID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// handleLiveLocation records a live location update from a contact, or from
+// the session's own phone, and emits a location.live event.
+func (h *EventHandler) handleLiveLocation(evt *events.Message, sessionID string) {
+	live := evt.Message.GetLiveLocationMessage()
+	if live == nil {
+		return
+	}
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	point := &messaging.LiveLocationPoint{
+		SessionID:      sessionUUID,
+		ChatJID:        evt.Info.Chat.String(),
+		SharerJID:      evt.Info.Sender.ToNonAD().String(),
+		ZpMessageID:    evt.Info.ID,
+		Latitude:       live.GetDegreesLatitude(),
+		Longitude:      live.GetDegreesLongitude(),
+		AccuracyMeters: live.GetAccuracyInMeters(),
+		SpeedMps:       live.GetSpeedInMps(),
+		Heading:        live.GetDegreesClockwiseFromMagneticNorth(),
+		Caption:        live.GetCaption(),
+		Sequence:       live.GetSequenceNumber(),
+		FromMe:         evt.Info.IsFromMe,
+		ReportedAt:     evt.Info.Timestamp,
+	}
+	h.gateway.saveLiveLocation(point)
+
+	h.deliverToWebhook(&LiveLocationEvent{
+		Event:          "location.live",
+		SessionName:    h.sessionName,
+		MessageID:      point.ZpMessageID,
+		Chat:           point.ChatJID,
+		Sharer:         point.SharerJID,
+		FromMe:         point.FromMe,
+		Latitude:       point.Latitude,
+		Longitude:      point.Longitude,
+		AccuracyMeters: point.AccuracyMeters,
+		SpeedMps:       point.SpeedMps,
+		Heading:        point.Heading,
+		Caption:        point.Caption,
+		Sequence:       point.Sequence,
+		Timestamp:      point.ReportedAt,
+	}, sessionID)
+}
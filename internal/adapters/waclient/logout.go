@@ -0,0 +1,133 @@
+package waclient
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/session"
+)
+
+const (
+	LogoutReasonStreamReplaced = "stream_replaced"
+)
+
+// SessionLoggedOutEvent is delivered to webhooks once WhatsApp has
+// invalidated a session's device and its credentials were discarded.
+type SessionLoggedOutEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	DeviceJID   string    `json:"device_jid,omitempty"`
+	Reason      string    `json:"reason"`
+	OnConnect   bool      `json:"on_connect"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (h *EventHandler) handleLoggedOut(evt *events.LoggedOut, sessionID string) {
+	if evt.OnConnect && evt.Reason == events.ConnectFailureUnknownLogout {
+		h.handleBanned(evt, sessionID)
+		return
+	}
+
+	reason := "logged_out"
+	if evt.OnConnect {
+		reason = evt.Reason.String()
+	}
+
+	h.logger.WarnWithFields("WhatsApp logged out", map[string]interface{}{
+		"session_id": sessionID,
+		"reason":     reason,
+		"on_connect": evt.OnConnect,
+	})
+
+	h.invalidateDevice(sessionID, reason, evt.OnConnect)
+}
+
+// handleStreamReplaced treats another client connecting with the same keys
+// like a logout: reconnecting would only kick that client off again.
+func (h *EventHandler) handleStreamReplaced(sessionID string) {
+	h.logger.WarnWithFields("WhatsApp stream replaced by another connection", map[string]interface{}{
+		"session_id": sessionID,
+	})
+
+	h.invalidateDevice(sessionID, LogoutReasonStreamReplaced, false)
+}
+
+func (h *EventHandler) invalidateDevice(sessionID, reason string, onConnect bool) {
+	deviceJID := h.gateway.dropClient(h.sessionName)
+
+	h.notifySessionLoggedOut(sessionID, reason)
+	h.updateSessionStatus(sessionID, string(session.StatusLoggedOut))
+
+	h.deliverToWebhook(&SessionLoggedOutEvent{
+		Event:       "session.logged_out",
+		SessionName: h.sessionName,
+		DeviceJID:   deviceJID,
+		Reason:      reason,
+		OnConnect:   onConnect,
+		Timestamp:   time.Now(),
+	}, sessionID)
+}
+
+func (h *EventHandler) notifySessionLoggedOut(sessionID, reason string) {
+	handlers := h.gateway.getEventHandlers("global")
+	for _, handler := range handlers {
+		go func(sessionHandler session.EventHandler) {
+			defer func() {
+				if r := recover(); r != nil {
+					h.logger.ErrorWithFields("Session event handler panic", map[string]interface{}{
+						"session_id": sessionID,
+						"event":      "logged_out",
+						"error":      r,
+					})
+				}
+			}()
+			sessionHandler.OnSessionLoggedOut(h.sessionName, reason)
+		}(handler)
+	}
+}
+
+// dropClient removes a session's client after its device was invalidated and
+// returns the device JID it was using. Auto-reconnect is switched off and the
+// device is deleted from the store, so the next connect starts a new pairing
+// instead of retrying credentials WhatsApp has rejected.
+func (g *Gateway) dropClient(sessionName string) string {
+	g.mu.Lock()
+	client := g.clients[sessionName]
+	delete(g.clients, sessionName)
+	g.mu.Unlock()
+
+	if client == nil {
+		return ""
+	}
+
+	wa := client.GetClient()
+	wa.EnableAutoReconnect = false
+
+	var deviceJID string
+	if wa.Store.ID != nil {
+		deviceJID = wa.Store.ID.String()
+	}
+
+	// The event is dispatched from whatsmeow's socket goroutine, so tear the
+	// connection down from outside it.
+	go func() {
+		_ = client.Disconnect()
+
+		if wa.Store.ID == nil {
+			return
+		}
+		ctx, cancel := g.withOperationTimeout(context.Background())
+		defer cancel()
+		if err := wa.Store.Delete(ctx); err != nil {
+			g.logger.WarnWithFields("Failed to delete invalidated device", map[string]interface{}{
+				"session_name": sessionName,
+				"device_jid":   deviceJID,
+				"error":        err.Error(),
+			})
+		}
+	}()
+
+	return deviceJID
+}
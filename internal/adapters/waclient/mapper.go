@@ -1,13 +1,17 @@
 package waclient
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 
+	"zpwoot/internal/core/messaging"
 	"zpwoot/internal/core/session"
 )
 
@@ -114,6 +118,10 @@ func (m *MessageMapper) extractSpecialContent(message *waE2E.Message) (string, s
 		return "[Location]", "location"
 	}
 
+	if message.LiveLocationMessage != nil {
+		return "[Live location]", "live_location"
+	}
+
 	if message.ContactMessage != nil {
 		name := ""
 		if message.ContactMessage.DisplayName != nil {
@@ -122,6 +130,16 @@ func (m *MessageMapper) extractSpecialContent(message *waE2E.Message) (string, s
 		return fmt.Sprintf("[Contact: %s]", name), "contact"
 	}
 
+	if message.OrderMessage != nil {
+		return fmt.Sprintf("[Order: %s]", message.OrderMessage.GetOrderTitle()), "order"
+	}
+
+	if message.RequestPaymentMessage != nil || message.SendPaymentMessage != nil ||
+		message.CancelPaymentRequestMessage != nil || message.DeclinePaymentRequestMessage != nil ||
+		message.PaymentInviteMessage != nil {
+		return "[Payment]", "payment"
+	}
+
 	return "", ""
 }
 
@@ -185,9 +203,139 @@ func (m *MessageMapper) MessageTypeToString(msgType string) string {
 		return "Sticker"
 	case "location":
 		return "Location"
+	case "live_location":
+		return "Live location"
 	case "contact":
 		return "Contact"
+	case "order":
+		return "Order"
+	case "payment":
+		return "Payment"
 	default:
 		return "Unknown"
 	}
 }
+
+// ExtractQuotedMessageID returns the ID of the message being replied to, or
+// an empty string when the message is not a reply.
+func (m *MessageMapper) ExtractQuotedMessageID(message *waE2E.Message) string {
+	return contextInfoOf(message).GetStanzaID()
+}
+
+func contextInfoOf(message *waE2E.Message) *waE2E.ContextInfo {
+	switch {
+	case message == nil:
+		return nil
+	case message.ExtendedTextMessage != nil:
+		return message.ExtendedTextMessage.GetContextInfo()
+	case message.ImageMessage != nil:
+		return message.ImageMessage.GetContextInfo()
+	case message.VideoMessage != nil:
+		return message.VideoMessage.GetContextInfo()
+	case message.AudioMessage != nil:
+		return message.AudioMessage.GetContextInfo()
+	case message.DocumentMessage != nil:
+		return message.DocumentMessage.GetContextInfo()
+	case message.StickerMessage != nil:
+		return message.StickerMessage.GetContextInfo()
+	case message.LocationMessage != nil:
+		return message.LocationMessage.GetContextInfo()
+	case message.LiveLocationMessage != nil:
+		return message.LiveLocationMessage.GetContextInfo()
+	case message.ContactMessage != nil:
+		return message.ContactMessage.GetContextInfo()
+	default:
+		return nil
+	}
+}
+
+func (m *MessageMapper) ExtractMediaMetadata(message *waE2E.Message) *messaging.MediaMetadata {
+	switch {
+	case message == nil:
+		return nil
+	case message.ImageMessage != nil:
+		img := message.ImageMessage
+		return &messaging.MediaMetadata{
+			MimeType:      img.GetMimetype(),
+			FileSize:      img.GetFileLength(),
+			FileSHA256:    hex.EncodeToString(img.GetFileSHA256()),
+			Width:         img.GetWidth(),
+			Height:        img.GetHeight(),
+			DirectPath:    img.GetDirectPath(),
+			MediaKey:      img.GetMediaKey(),
+			FileEncSHA256: img.GetFileEncSHA256(),
+		}
+	case message.VideoMessage != nil:
+		vid := message.VideoMessage
+		return &messaging.MediaMetadata{
+			MimeType:      vid.GetMimetype(),
+			FileSize:      vid.GetFileLength(),
+			FileSHA256:    hex.EncodeToString(vid.GetFileSHA256()),
+			Duration:      vid.GetSeconds(),
+			Width:         vid.GetWidth(),
+			Height:        vid.GetHeight(),
+			DirectPath:    vid.GetDirectPath(),
+			MediaKey:      vid.GetMediaKey(),
+			FileEncSHA256: vid.GetFileEncSHA256(),
+		}
+	case message.AudioMessage != nil:
+		aud := message.AudioMessage
+		return &messaging.MediaMetadata{
+			MimeType:      aud.GetMimetype(),
+			FileSize:      aud.GetFileLength(),
+			FileSHA256:    hex.EncodeToString(aud.GetFileSHA256()),
+			Duration:      aud.GetSeconds(),
+			DirectPath:    aud.GetDirectPath(),
+			MediaKey:      aud.GetMediaKey(),
+			FileEncSHA256: aud.GetFileEncSHA256(),
+		}
+	case message.DocumentMessage != nil:
+		doc := message.DocumentMessage
+		return &messaging.MediaMetadata{
+			MimeType:      doc.GetMimetype(),
+			FileName:      doc.GetFileName(),
+			FileSize:      doc.GetFileLength(),
+			FileSHA256:    hex.EncodeToString(doc.GetFileSHA256()),
+			DirectPath:    doc.GetDirectPath(),
+			MediaKey:      doc.GetMediaKey(),
+			FileEncSHA256: doc.GetFileEncSHA256(),
+		}
+	case message.StickerMessage != nil:
+		st := message.StickerMessage
+		return &messaging.MediaMetadata{
+			MimeType:      st.GetMimetype(),
+			FileSize:      st.GetFileLength(),
+			FileSHA256:    hex.EncodeToString(st.GetFileSHA256()),
+			Width:         st.GetWidth(),
+			Height:        st.GetHeight(),
+			DirectPath:    st.GetDirectPath(),
+			MediaKey:      st.GetMediaKey(),
+			FileEncSHA256: st.GetFileEncSHA256(),
+		}
+	default:
+		return nil
+	}
+}
+
+// ExtractReaction returns the reaction carried by a message, or nil if it is
+// not a reaction. An empty emoji means the sender removed their reaction.
+func (m *MessageMapper) ExtractReaction(sessionID uuid.UUID, evt *events.Message) *messaging.Reaction {
+	reaction := evt.Message.GetReactionMessage()
+	if reaction == nil || reaction.GetKey().GetID() == "" {
+		return nil
+	}
+
+	reactedAt := evt.Info.Timestamp
+	if ms := reaction.GetSenderTimestampMS(); ms > 0 {
+		reactedAt = time.UnixMilli(ms)
+	}
+
+	return &messaging.Reaction{
+		SessionID:   sessionID,
+		ZpMessageID: reaction.GetKey().GetID(),
+		ReactorJID:  evt.Info.Sender.ToNonAD().String(),
+		Emoji:       reaction.GetText(),
+		FromMe:      evt.Info.IsFromMe,
+		ReactedAt:   reactedAt,
+	}
+}
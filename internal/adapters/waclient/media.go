@@ -0,0 +1,316 @@
+package waclient
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+)
+
+const mediaDownloadTimeout = 2 * time.Minute
+
+func (g *Gateway) SetMediaDir(dir string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mediaDir = dir
+}
+
+// SetMediaHost publishes auto-downloaded media through host and links it in
+// message webhooks. Nil sends no links.
+func (g *Gateway) SetMediaHost(host messaging.MediaHost) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mediaHost = host
+}
+
+func (g *Gateway) getMediaHost() messaging.MediaHost {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.mediaHost
+}
+
+// SetMediaScanner scans inbound media through scanner before it is stored,
+// under each session's scan policy or, when it sets none, policy. Nil scans
+// nothing.
+func (g *Gateway) SetMediaScanner(scanner messaging.MediaScanner, policy session.MediaScanPolicy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mediaScanner = scanner
+	g.scanPolicy = policy
+}
+
+// SetMediaPool moves CPU-heavy media work off the request goroutines. Nil
+// runs it inline.
+func (g *Gateway) SetMediaPool(pool *messaging.MediaPool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mediaPool = pool
+}
+
+func (g *Gateway) getMediaPool() *messaging.MediaPool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.mediaPool
+}
+
+// getMediaScanner returns the scanner for the session's media, or nil when
+// its media is not scanned.
+func (g *Gateway) getMediaScanner(sessionName string) (messaging.MediaScanner, session.MediaScanPolicy) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	policy := g.settings[sessionName].Media.ScanPolicy(g.scanPolicy)
+	if g.mediaScanner == nil || policy == session.MediaScanIgnore {
+		return nil, policy
+	}
+	return g.mediaScanner, policy
+}
+
+// FetchMedia returns the media of a stored message, preferring the local copy
+// and otherwise downloading it from WhatsApp and keeping it under the media
+// directory.
+func (g *Gateway) FetchMedia(ctx context.Context, sessionName string, message *messaging.Message) ([]byte, string, error) {
+	media := message.Media
+	if media == nil {
+		return nil, "", messaging.ErrMessageHasNoMedia
+	}
+
+	if media.IsStored() {
+		data, err := os.ReadFile(media.LocalPath)
+		if err == nil {
+			return data, media.LocalPath, nil
+		}
+		g.logger.WarnWithFields("Stored media missing, downloading again", map[string]interface{}{
+			"session_name": sessionName,
+			"message_id":   message.ZpMessageID,
+			"path":         media.LocalPath,
+			"error":        err.Error(),
+		})
+	}
+
+	if !media.IsDownloadable() {
+		return nil, "", messaging.ErrMediaExpired
+	}
+
+	policy := g.getSettings(sessionName).MediaPolicy
+	if err := policy.Check(message.ZpType, media.MimeType, int64(media.FileSize)); err != nil {
+		return nil, "", err
+	}
+
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fileHash, err := hex.DecodeString(media.FileSHA256)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid media hash: %w", err)
+	}
+
+	data, err := client.client.DownloadMediaWithPath(ctx, media.DirectPath, media.FileEncSHA256, fileHash,
+		media.MediaKey, int(media.FileSize), whatsmeowMediaType(message.ZpType), "")
+	if err != nil {
+		if errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith404) || errors.Is(err, whatsmeow.ErrMediaDownloadFailedWith410) {
+			return nil, "", messaging.ErrMediaExpired
+		}
+		return nil, "", fmt.Errorf("failed to download media: %w", wrapContextError(err))
+	}
+
+	if err := g.scanMedia(ctx, sessionName, message, data); err != nil {
+		return nil, "", err
+	}
+
+	path, err := g.writeMediaFile(message, data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, path, nil
+}
+
+// scanMedia runs downloaded media through the scanner and records the
+// outcome on the message. Under the block policy, media the scanner flags
+// or fails to check is quarantined: it is refused with ErrMediaQuarantined
+// and never stored.
+func (g *Gateway) scanMedia(ctx context.Context, sessionName string, message *messaging.Message, data []byte) error {
+	scanner, policy := g.getMediaScanner(sessionName)
+	if scanner == nil {
+		return nil
+	}
+
+	fileName := message.Media.FileName
+	if fileName == "" {
+		fileName = filepath.Base(message.ZpMessageID) + mediaExtension(message.Media.MimeType)
+	}
+
+	scan := &messaging.MediaScan{Status: messaging.ScanClean, ScannedAt: time.Now()}
+	verdict, err := scanner.Scan(ctx, fileName, message.Media.MimeType, data)
+	switch {
+	case err != nil:
+		scan.Status = messaging.ScanFailed
+		scan.Error = err.Error()
+	case verdict.Infected:
+		scan.Status = messaging.ScanInfected
+		scan.Signature = verdict.Signature
+	}
+	if scan.Status != messaging.ScanClean && policy == session.MediaScanBlock {
+		scan.Status = messaging.ScanQuarantined
+	}
+
+	if scan.Status != messaging.ScanClean {
+		g.logger.WarnWithFields("Media flagged by virus scan", map[string]interface{}{
+			"session_name": sessionName,
+			"message_id":   message.ZpMessageID,
+			"status":       scan.Status,
+			"signature":    scan.Signature,
+			"error":        scan.Error,
+		})
+	}
+
+	message.Media.Scan = scan
+	if store := g.getMessageStore(); store != nil {
+		if err := store.RecordMediaScan(ctx, message.SessionID, message.ZpMessageID, scan); err != nil {
+			g.logger.WarnWithFields("Failed to record media scan", map[string]interface{}{
+				"session_name": sessionName,
+				"message_id":   message.ZpMessageID,
+				"error":        err.Error(),
+			})
+		}
+	}
+
+	if scan.Quarantined() {
+		return messaging.ErrMediaQuarantined
+	}
+	return nil
+}
+
+func (g *Gateway) writeMediaFile(message *messaging.Message, data []byte) (string, error) {
+	g.mu.RLock()
+	baseDir := g.mediaDir
+	g.mu.RUnlock()
+
+	if baseDir == "" {
+		return "", fmt.Errorf("media directory is not configured")
+	}
+
+	dir := filepath.Join(baseDir, message.SessionID.String())
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	name := filepath.Base(message.ZpMessageID) + mediaExtension(message.Media.MimeType)
+	path := filepath.Join(dir, name)
+
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+
+	return path, nil
+}
+
+func (h *EventHandler) autoDownloadMedia(message *messaging.Message) {
+	settings := h.gateway.getSettings(h.sessionName)
+	if !settings.Media.ShouldDownload(message.ZpType, message.Media.FileSize) {
+		return
+	}
+	if err := settings.MediaPolicy.Check(message.ZpType, message.Media.MimeType, int64(message.Media.FileSize)); err != nil {
+		h.logger.DebugWithFields("Media not auto-downloaded", map[string]interface{}{
+			"session_name": h.sessionName,
+			"message_id":   message.ZpMessageID,
+			"reason":       err.Error(),
+		})
+		return
+	}
+
+	// The message webhook is delivered after this returns, so the link and
+	// scan are expected before the download starts.
+	host := h.gateway.getMediaHost()
+	scanner, _ := h.gateway.getMediaScanner(h.sessionName)
+	var done chan<- *mediaOutcome
+	if (host != nil || scanner != nil) && h.webhookHandler != nil {
+		done = h.gateway.mediaLinks.expect(mediaLinkKey(message.SessionID.String(), message.ZpMessageID), 2*mediaDownloadTimeout)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mediaDownloadTimeout)
+		defer cancel()
+
+		var outcome mediaOutcome
+		if done != nil {
+			defer func() { done <- &outcome }()
+		}
+
+		_, path, err := h.gateway.FetchMedia(ctx, h.sessionName, message)
+		outcome.Scan = message.Media.Scan
+		if err == nil {
+			err = h.gateway.attachMediaFile(ctx, message, path)
+		}
+		if err != nil {
+			h.logger.WarnWithFields("Failed to auto-download media", map[string]interface{}{
+				"session_name": h.sessionName,
+				"message_id":   message.ZpMessageID,
+				"type":         message.ZpType,
+				"error":        err.Error(),
+			})
+			return
+		}
+
+		h.logger.DebugWithFields("Media auto-downloaded", map[string]interface{}{
+			"session_name": h.sessionName,
+			"message_id":   message.ZpMessageID,
+			"path":         path,
+		})
+
+		if done == nil || host == nil {
+			return
+		}
+		outcome.Link, err = host.Publish(ctx, message.SessionID, path, message.Media.MimeType)
+		if err != nil {
+			h.logger.WarnWithFields("Failed to publish media link", map[string]interface{}{
+				"session_name": h.sessionName,
+				"message_id":   message.ZpMessageID,
+				"error":        err.Error(),
+			})
+		}
+	}()
+}
+
+func (g *Gateway) attachMediaFile(ctx context.Context, message *messaging.Message, path string) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	return store.AttachMediaFile(ctx, message.SessionID, message.ZpMessageID, path)
+}
+
+func whatsmeowMediaType(messageType string) whatsmeow.MediaType {
+	switch messageType {
+	case "video":
+		return whatsmeow.MediaVideo
+	case "audio":
+		return whatsmeow.MediaAudio
+	case "document":
+		return whatsmeow.MediaDocument
+	default:
+		return whatsmeow.MediaImage
+	}
+}
+
+func mediaExtension(mimeType string) string {
+	base, _, _ := strings.Cut(mimeType, ";")
+	if exts, err := mime.ExtensionsByType(strings.TrimSpace(base)); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
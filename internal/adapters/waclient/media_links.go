@@ -0,0 +1,72 @@
+package waclient
+
+import (
+	"sync"
+	"time"
+
+	"zpwoot/internal/core/messaging"
+)
+
+// mediaLinks hands the link and scan of media being auto-downloaded to the
+// webhook delivery of the same message, which waits for them rather than
+// going out without. Entries are keyed by session ID and message ID.
+type mediaLinks struct {
+	mu      sync.Mutex
+	pending map[string]chan *mediaOutcome
+}
+
+// mediaOutcome is what the download of a message's media adds to its
+// webhook: the link, when one was published, and the virus scan, when the
+// media was scanned.
+type mediaOutcome struct {
+	Link *messaging.HostedMedia
+	Scan *messaging.MediaScan
+}
+
+func mediaLinkKey(sessionID, messageID string) string {
+	return sessionID + "/" + messageID
+}
+
+// expect registers a download in progress. Exactly one value must be sent on
+// the returned channel. Entries nobody
+// waited for, when a pipeline stage stopped the message, are dropped after
+// ttl.
+func (l *mediaLinks) expect(key string, ttl time.Duration) chan<- *mediaOutcome {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.pending == nil {
+		l.pending = make(map[string]chan *mediaOutcome)
+	}
+	done := make(chan *mediaOutcome, 1)
+	l.pending[key] = done
+
+	time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.pending[key] == done {
+			delete(l.pending, key)
+		}
+	})
+
+	return done
+}
+
+// wait returns the outcome of the message's media download, or nil at once
+// when no download is in progress for it.
+func (l *mediaLinks) wait(key string, timeout time.Duration) *mediaOutcome {
+	l.mu.Lock()
+	done, ok := l.pending[key]
+	delete(l.pending, key)
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	select {
+	case outcome := <-done:
+		return outcome
+	case <-time.After(timeout):
+		return nil
+	}
+}
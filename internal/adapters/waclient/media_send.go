@@ -0,0 +1,196 @@
+package waclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+
+	"zpwoot/internal/core/session"
+)
+
+const mediaFetchTimeout = 60 * time.Second
+
+// InspectMedia implements services.MediaInspector. The media is loaded and
+// checked like SendMediaMessage does, but not uploaded.
+func (g *Gateway) InspectMedia(ctx context.Context, sessionName, mediaURL, mediaType string) (string, int64, error) {
+	policy := g.getSettings(sessionName).MediaPolicy
+	data, mimeType, err := loadOutboundMedia(ctx, mediaURL, mediaType, policy.Limit(mediaType))
+	if err != nil {
+		return "", 0, err
+	}
+	if err := policy.Check(mediaType, mimeType, int64(len(data))); err != nil {
+		return "", 0, err
+	}
+
+	return mimeType, int64(len(data)), nil
+}
+
+// loadOutboundMedia reads media given as an http(s) URL, a data URI or plain
+// base64 and returns it with its MIME type. At most limit bytes are read, so
+// oversized media is rejected before it is held in memory in full.
+func loadOutboundMedia(ctx context.Context, source, mediaType string, limit int64) ([]byte, string, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return fetchOutboundMedia(ctx, source, mediaType, limit)
+	}
+
+	mimeType := ""
+	encoded := strings.TrimSpace(source)
+	if strings.HasPrefix(encoded, "data:") {
+		header, body, ok := strings.Cut(encoded, ",")
+		if !ok {
+			return nil, "", fmt.Errorf("validation failed: malformed data URI")
+		}
+		mimeType, _, _ = strings.Cut(strings.TrimPrefix(header, "data:"), ";")
+		encoded = body
+	}
+
+	if int64(base64.StdEncoding.DecodedLen(len(encoded))) > limit+2 {
+		return nil, "", tooLarge(mediaType, limit)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", fmt.Errorf("validation failed: media must be an http(s) URL or base64 data")
+	}
+	if int64(len(data)) > limit {
+		return nil, "", tooLarge(mediaType, limit)
+	}
+
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	return data, mimeType, nil
+}
+
+func fetchOutboundMedia(ctx context.Context, source, mediaType string, limit int64) ([]byte, string, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, mediaFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("validation failed: invalid media URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("validation failed: fetching media returned status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > limit {
+		return nil, "", tooLarge(mediaType, limit)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch media: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, "", tooLarge(mediaType, limit)
+	}
+
+	mimeType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	mimeType = strings.TrimSpace(mimeType)
+	if mimeType == "" || mimeType == "application/octet-stream" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	return data, mimeType, nil
+}
+
+func tooLarge(mediaType string, limit int64) error {
+	return fmt.Errorf("%w: %s exceeds %d bytes", session.ErrMediaTooLarge, mediaType, limit)
+}
+
+// buildMediaMessage wraps an uploaded file in the message type WhatsApp
+// expects for mediaType. Audio and stickers have no caption.
+func buildMediaMessage(mediaType, mimeType, caption, fileName string, uploaded *whatsmeow.UploadResponse) *waE2E.Message {
+	switch mediaType {
+	case "video":
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      &mimeType,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+			Caption:       optionalString(caption),
+		}}
+	case "audio":
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      &mimeType,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+		}}
+	case "document":
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      &mimeType,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+			FileName:      &fileName,
+			Title:         &fileName,
+			Caption:       optionalString(caption),
+		}}
+	case "sticker":
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      &mimeType,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+		}}
+	default:
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      &mimeType,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+			Caption:       optionalString(caption),
+		}}
+	}
+}
+
+// mediaFileName names a document after the last path segment of its URL,
+// falling back to a generic name with an extension for the MIME type.
+func mediaFileName(source, mimeType string) string {
+	if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		if name := path.Base(u.Path); name != "." && name != "/" {
+			return name
+		}
+	}
+	return "document" + mediaExtension(mimeType)
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
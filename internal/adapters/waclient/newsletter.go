@@ -0,0 +1,130 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+// PostToNewsletter publishes a post in a newsletter the session owns or
+// administers. Media is uploaded with UploadNewsletter, which skips
+// encryption, and the upload handle goes along with the send.
+func (g *Gateway) PostToNewsletter(ctx context.Context, sessionName string, post *messaging.NewsletterPost) (*messaging.NewsletterPostResult, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	newsletterJID, err := types.ParseJID(post.NewsletterJID)
+	if err != nil || newsletterJID.Server != types.NewsletterServer {
+		return nil, fmt.Errorf("validation failed: %q is not a newsletter JID", post.NewsletterJID)
+	}
+
+	whatsmeowClient := client.GetClient()
+
+	info, err := whatsmeowClient.GetNewsletterInfo(newsletterJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get newsletter info: %w", err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("newsletter %s not found", post.NewsletterJID)
+	}
+	if info.ViewerMeta == nil ||
+		(info.ViewerMeta.Role != types.NewsletterRoleAdmin && info.ViewerMeta.Role != types.NewsletterRoleOwner) {
+		return nil, messaging.ErrNotNewsletterAdmin
+	}
+
+	opCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", newsletterJID.String()))
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	var message *waE2E.Message
+	var extra whatsmeow.SendRequestExtra
+	if post.Type == messaging.MessageTypeText {
+		text := post.Text
+		message = &waE2E.Message{Conversation: &text}
+	} else {
+		if len(post.Data) == 0 {
+			if err := g.loadNewsletterMedia(opCtx, sessionName, post); err != nil {
+				logger.EndSpan(span, err)
+				return nil, err
+			}
+		}
+
+		uploaded, err := whatsmeowClient.UploadNewsletter(opCtx, post.Data, whatsmeowMediaType(string(post.Type)))
+		if err != nil {
+			logger.EndSpan(span, err)
+			return nil, fmt.Errorf("failed to upload newsletter media: %w", wrapContextError(err))
+		}
+
+		fileName := post.FileName
+		if fileName == "" {
+			fileName = "document" + mediaExtension(post.MimeType)
+		}
+		message = buildMediaMessage(string(post.Type), post.MimeType, post.Text, fileName, &uploaded)
+		if err := g.processVoiceNote(opCtx, sessionName, message, post.Data); err != nil {
+			logger.EndSpan(span, err)
+			return nil, err
+		}
+		extra.MediaHandle = uploaded.Handle
+	}
+
+	resp, err := whatsmeowClient.SendMessage(opCtx, newsletterJID, message, extra)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to post to newsletter", map[string]interface{}{
+			"session_name":   sessionName,
+			"newsletter_jid": post.NewsletterJID,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("failed to post to newsletter: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Posted to newsletter", map[string]interface{}{
+		"session_name":   sessionName,
+		"newsletter_jid": post.NewsletterJID,
+		"type":           post.Type,
+		"message_id":     resp.ID,
+	})
+
+	return &messaging.NewsletterPostResult{
+		MessageID: resp.ID,
+		Timestamp: resp.Timestamp,
+	}, nil
+}
+
+// loadNewsletterMedia fills in the post's media from its source, under the
+// same media policy as a send to a chat.
+func (g *Gateway) loadNewsletterMedia(ctx context.Context, sessionName string, post *messaging.NewsletterPost) error {
+	if post.Source == "" {
+		return fmt.Errorf("validation failed: %s posts need a file", post.Type)
+	}
+
+	mediaType := string(post.Type)
+	policy := g.getSettings(sessionName).MediaPolicy
+	data, mimeType, err := loadOutboundMedia(ctx, post.Source, mediaType, policy.Limit(mediaType))
+	if err != nil {
+		return err
+	}
+	if err := policy.Check(mediaType, mimeType, int64(len(data))); err != nil {
+		return err
+	}
+
+	post.Data = data
+	post.MimeType = mimeType
+	if post.FileName == "" {
+		post.FileName = mediaFileName(post.Source, mimeType)
+	}
+	return nil
+}
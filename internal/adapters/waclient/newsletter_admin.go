@@ -0,0 +1,160 @@
+package waclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+// whatsmeow does not wrap channel administration yet, so these calls go
+// through its GraphQL (mex) queries directly, with the query IDs listed in
+// the argo query map it ships.
+const (
+	mutationNewsletterAdminInvite       = "24943748628557365"
+	mutationNewsletterAdminInviteRevoke = "6550386328343169"
+	mutationNewsletterAcceptAdminInvite = "6179636105471882"
+	mutationNewsletterAdminDemote       = "7220922401252829"
+	mutationNewsletterChangeOwner       = "6951013521615265"
+)
+
+type respNewsletterAdminInvite struct {
+	Invite struct {
+		ExpiresAt json.Number `json:"invite_expiration_time"`
+	} `json:"xwa2_newsletter_admin_invite_create"`
+}
+
+// InviteNewsletterAdmin invites a user to administer a newsletter the
+// session owns. The invite shows up in the user's chat with the session and
+// expires after a while; the expiry is returned when WhatsApp tells it.
+func (g *Gateway) InviteNewsletterAdmin(ctx context.Context, sessionName, newsletterJID, userJID string) (*time.Time, error) {
+	data, err := g.newsletterAdminCall(ctx, sessionName, newsletterJID, userJID, mutationNewsletterAdminInvite, "invite newsletter admin")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp respNewsletterAdminInvite
+	if json.Unmarshal(data, &resp) == nil {
+		if seconds, err := resp.Invite.ExpiresAt.Int64(); err == nil && seconds > 0 {
+			expiresAt := time.Unix(seconds, 0)
+			return &expiresAt, nil
+		}
+	}
+	return nil, nil
+}
+
+// RevokeNewsletterAdminInvite withdraws an invite that was not accepted yet.
+func (g *Gateway) RevokeNewsletterAdminInvite(ctx context.Context, sessionName, newsletterJID, userJID string) error {
+	_, err := g.newsletterAdminCall(ctx, sessionName, newsletterJID, userJID, mutationNewsletterAdminInviteRevoke, "revoke newsletter admin invite")
+	return err
+}
+
+// AcceptNewsletterAdminInvite accepts an invite sent to the session to
+// administer a newsletter.
+func (g *Gateway) AcceptNewsletterAdminInvite(ctx context.Context, sessionName, newsletterJID string) error {
+	_, err := g.newsletterAdminCall(ctx, sessionName, newsletterJID, "", mutationNewsletterAcceptAdminInvite, "accept newsletter admin invite")
+	return err
+}
+
+// DemoteNewsletterAdmin removes an admin from a newsletter the session owns.
+// The user stays a follower.
+func (g *Gateway) DemoteNewsletterAdmin(ctx context.Context, sessionName, newsletterJID, userJID string) error {
+	_, err := g.newsletterAdminCall(ctx, sessionName, newsletterJID, userJID, mutationNewsletterAdminDemote, "demote newsletter admin")
+	return err
+}
+
+// ChangeNewsletterOwner hands a newsletter the session owns to one of its
+// admins. The session stays on as an admin.
+func (g *Gateway) ChangeNewsletterOwner(ctx context.Context, sessionName, newsletterJID, userJID string) error {
+	_, err := g.newsletterAdminCall(ctx, sessionName, newsletterJID, userJID, mutationNewsletterChangeOwner, "change newsletter owner")
+	return err
+}
+
+// newsletterAdminCall runs an administration query on a newsletter. With a
+// user, the session must own the newsletter, and the user is sent by LID
+// when the session knows it, which is how WhatsApp lists channel admins.
+func (g *Gateway) newsletterAdminCall(ctx context.Context, sessionName, newsletterJID, userJID, queryID, operation string) (json.RawMessage, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	newsletter, err := types.ParseJID(newsletterJID)
+	if err != nil || newsletter.Server != types.NewsletterServer {
+		return nil, fmt.Errorf("validation failed: %q is not a newsletter JID", newsletterJID)
+	}
+
+	whatsmeowClient := client.GetClient()
+	variables := map[string]any{"newsletter_id": newsletter.String()}
+
+	opCtx, span := startCallSpan(ctx, "SendMexIQ", sessionName, attribute.String("zpwoot.newsletter", newsletter.String()))
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	var user string
+	if userJID != "" {
+		if err := checkNewsletterOwner(whatsmeowClient, newsletter); err != nil {
+			logger.EndSpan(span, err)
+			return nil, err
+		}
+		user, err = newsletterUser(opCtx, whatsmeowClient, userJID)
+		if err != nil {
+			logger.EndSpan(span, err)
+			return nil, err
+		}
+		variables["user_id"] = user
+	}
+
+	data, err := whatsmeowClient.DangerousInternals().SendMexIQ(opCtx, queryID, variables)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to "+operation, map[string]interface{}{
+			"session_name":   sessionName,
+			"newsletter_jid": newsletterJID,
+			"user":           user,
+			"error":          err.Error(),
+		})
+		return nil, fmt.Errorf("failed to %s: %w", operation, wrapContextError(err))
+	}
+
+	return data, nil
+}
+
+func checkNewsletterOwner(client *whatsmeow.Client, newsletter types.JID) error {
+	info, err := client.GetNewsletterInfo(newsletter)
+	if err != nil {
+		return fmt.Errorf("failed to get newsletter info: %w", err)
+	}
+	if info == nil {
+		return fmt.Errorf("newsletter %s not found", newsletter)
+	}
+	if info.ViewerMeta == nil || info.ViewerMeta.Role != types.NewsletterRoleOwner {
+		return messaging.ErrNotNewsletterOwner
+	}
+	return nil
+}
+
+func newsletterUser(ctx context.Context, client *whatsmeow.Client, userJID string) (string, error) {
+	jid, err := types.ParseJID(userJID)
+	if err != nil || (jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer) {
+		return "", fmt.Errorf("validation failed: %q is not a user JID", userJID)
+	}
+	jid = jid.ToNonAD()
+
+	if jid.Server == types.DefaultUserServer {
+		if lid, err := client.Store.LIDs.GetLIDForPN(ctx, jid); err == nil && !lid.IsEmpty() {
+			jid = lid
+		}
+	}
+	return jid.String(), nil
+}
@@ -0,0 +1,172 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+
+	"zpwoot/internal/core/session"
+)
+
+// Reasons a QR pairing ends without the phone linking the device. Timeout
+// is WhatsApp running out of codes and QRLimit the session's own limit;
+// both leave the session in qr_timeout.
+const (
+	PairingTimeout   = "timeout"
+	PairingQRLimit   = "qr_limit"
+	PairingCancelled = "cancelled"
+	PairingFailed    = "failed"
+)
+
+// PairingEndedEvent is emitted when a QR pairing stops without success, so
+// the stored QR code can be dropped instead of lingering until it expires.
+// Attempts is how many codes were shown.
+type PairingEndedEvent struct {
+	Event       string `json:"event"`
+	SessionName string `json:"session_name"`
+	Reason      string `json:"reason"`
+	Attempts    int    `json:"attempts"`
+}
+
+// maxQRCodes reads the session's QR limit when a pairing starts, so a
+// change applies to the next pairing without recreating the client.
+func (g *Gateway) maxQRCodes(sessionName string) func() int {
+	return func() int {
+		return g.getSettings(sessionName).Pairing.MaxQRCodes
+	}
+}
+
+// startQRLoop must run before the socket connects: whatsmeow only hands out
+// QR codes through a channel requested ahead of Connect.
+func (c *Client) startQRLoop() (context.Context, <-chan whatsmeow.QRChannelItem, error) {
+	ctx, cancel := context.WithCancel(c.ctx)
+
+	qrChan, err := c.client.GetQRChannel(ctx)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	c.qrCancel = cancel
+	c.mu.Unlock()
+
+	return ctx, qrChan, nil
+}
+
+// runQRLoop relays each QR code whatsmeow rotates in as a QRCodeEvent, until
+// the phone scans one, the codes run out or the pairing is cancelled. With a
+// QR limit, the code after the last one allowed ends the pairing instead of
+// being shown.
+func (c *Client) runQRLoop(ctx context.Context, qrChan <-chan whatsmeow.QRChannelItem) {
+	var maxCodes int
+	if c.maxQRCodes != nil {
+		maxCodes = c.maxQRCodes()
+	}
+
+	reason := PairingFailed
+	attempts := 0
+	defer func() {
+		// Cancelling closes the socket, which whatsmeow reports as a
+		// timeout; the context tells the two apart.
+		if reason != "" && reason != PairingQRLimit && ctx.Err() != nil {
+			reason = PairingCancelled
+		}
+
+		c.mu.Lock()
+		if c.qrCancel != nil {
+			c.qrCancel()
+			c.qrCancel = nil
+		}
+		c.qrCode = ""
+		c.qrExpiresAt = time.Time{}
+		c.mu.Unlock()
+
+		if reason != "" {
+			c.logger.InfoWithFields("QR pairing ended", map[string]interface{}{
+				"session_name": c.sessionName,
+				"reason":       reason,
+				"attempts":     attempts,
+			})
+			c.notifyEventHandlers(&PairingEndedEvent{
+				Event:       "pairing_ended",
+				SessionName: c.sessionName,
+				Reason:      reason,
+				Attempts:    attempts,
+			})
+		}
+	}()
+
+	for item := range qrChan {
+		switch item.Event {
+		case whatsmeow.QRChannelEventCode:
+			if maxCodes > 0 && attempts >= maxCodes {
+				reason = PairingQRLimit
+				c.CancelPairing()
+				return
+			}
+			attempts++
+
+			expiresAt := time.Now().Add(item.Timeout)
+			c.mu.Lock()
+			c.qrCode = item.Code
+			c.qrExpiresAt = expiresAt
+			c.mu.Unlock()
+
+			c.notifyEventHandlers(&QRCodeEvent{
+				SessionName: c.sessionName,
+				QRCode:      item.Code,
+				ExpiresAt:   expiresAt,
+				Attempt:     attempts,
+				MaxAttempts: maxCodes,
+			})
+		case whatsmeow.QRChannelSuccess.Event:
+			reason = ""
+			return
+		case whatsmeow.QRChannelTimeout.Event:
+			reason = PairingTimeout
+		}
+	}
+}
+
+// CancelPairing aborts a QR pairing in progress: the QR loop stops and the
+// socket opened for it is closed. It reports whether there was one.
+func (c *Client) CancelPairing() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.qrCancel == nil || c.state == StateLoggedIn {
+		return false
+	}
+
+	c.qrCancel()
+	c.qrCancel = nil
+	c.client.Disconnect()
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.setState(StateDisconnected)
+
+	return true
+}
+
+// CancelPairing stops the QR loop of a session that is still waiting to be
+// scanned.
+func (g *Gateway) CancelPairing(ctx context.Context, sessionName string) error {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return fmt.Errorf("session %s not found", sessionName)
+	}
+
+	if !client.CancelPairing() {
+		return session.ErrNoPairingPending
+	}
+
+	g.logger.InfoWithFields("QR pairing cancelled", map[string]interface{}{
+		"session_name": sessionName,
+	})
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+// SendPaymentRequest asks the recipient to pay through WhatsApp's in-chat
+// payments. The account the session is paired with is checked first, since
+// WhatsApp silently drops requests from accounts outside payment regions.
+func (g *Gateway) SendPaymentRequest(ctx context.Context, sessionName, to string, request *session.PaymentRequest) (*session.MessageSendResult, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	recipientJID, err := types.ParseJID(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient JID: %w", err)
+	}
+
+	if err := session.CheckPaymentSupport(client.GetJID().User, request.Currency); err != nil {
+		return nil, err
+	}
+
+	currency := request.Currency
+	amount1000 := request.Amount1000()
+	value := int64(amount1000)
+	offset := uint32(1000)
+	requestFrom := recipientJID.ToNonAD().String()
+
+	payment := &waE2E.RequestPaymentMessage{
+		CurrencyCodeIso4217: &currency,
+		Amount1000:          &amount1000,
+		RequestFrom:         &requestFrom,
+		Amount: &waE2E.Money{
+			Value:        &value,
+			Offset:       &offset,
+			CurrencyCode: &currency,
+		},
+	}
+	if request.Note != "" {
+		note := request.Note
+		payment.NoteMessage = &waE2E.Message{
+			ExtendedTextMessage: &waE2E.ExtendedTextMessage{Text: &note},
+		}
+	}
+	if request.ExpiresAt != nil {
+		expiry := request.ExpiresAt.Unix()
+		payment.ExpiryTimestamp = &expiry
+	}
+
+	message := &waE2E.Message{RequestPaymentMessage: payment}
+	whatsmeowClient := client.GetClient()
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to send payment request", map[string]interface{}{
+			"session_name": sessionName,
+			"to":           to,
+			"error":        err.Error(),
+		})
+		return nil, fmt.Errorf("failed to send payment request: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Payment request sent successfully", map[string]interface{}{
+		"session_name": sessionName,
+		"message_id":   resp.ID,
+		"to":           to,
+		"currency":     request.Currency,
+	})
+
+	return &session.MessageSendResult{
+		MessageID: resp.ID,
+		Status:    "sent",
+		Timestamp: resp.Timestamp,
+		To:        to,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/session"
+)
+
+// A send that times out or loses its socket while waiting for the server's
+// ack may still have reached WhatsApp, and a retry under a new ID would then
+// deliver it twice. Sends are therefore made under an ID known before the
+// call and tracked until a receipt for it arrives; a retry reuses the ID, so
+// WhatsApp treats it as the same message, and one already confirmed is not
+// sent again. Entries are kept for pendingSendTTL.
+const pendingSendTTL = 24 * time.Hour
+
+// SendConfirmedEvent is delivered to webhooks when a receipt arrives for a
+// send that had failed without an answer from WhatsApp, so the caller knows
+// the message went out after all and must not be retried.
+type SendConfirmedEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	MessageID   string    `json:"message_id"`
+	To          string    `json:"to"`
+	SentAt      time.Time `json:"sent_at"`
+	ConfirmedAt time.Time `json:"confirmed_at"`
+}
+
+type pendingSend struct {
+	to          string
+	sentAt      time.Time
+	unconfirmed bool
+	confirmedAt time.Time
+}
+
+type pendingSends struct {
+	mu      sync.Mutex
+	entries map[string]*pendingSend
+}
+
+func pendingSendKey(sessionName, messageID string) string {
+	return sessionName + "/" + messageID
+}
+
+// begin tracks a send about to be made and drops the expired entries.
+func (p *pendingSends) begin(sessionName, messageID, to string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.entries == nil {
+		p.entries = make(map[string]*pendingSend)
+	}
+	for k, entry := range p.entries {
+		if time.Since(entry.sentAt) > pendingSendTTL {
+			delete(p.entries, k)
+		}
+	}
+	key := pendingSendKey(sessionName, messageID)
+	if entry, ok := p.entries[key]; ok {
+		entry.unconfirmed = false
+		return
+	}
+	p.entries[key] = &pendingSend{to: to, sentAt: time.Now()}
+}
+
+// done stops tracking a send whose outcome WhatsApp answered, either way.
+func (p *pendingSends) done(sessionName, messageID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, pendingSendKey(sessionName, messageID))
+}
+
+// unconfirmed keeps a send that failed without an answer, waiting for a
+// receipt that tells it went out.
+func (p *pendingSends) unconfirmed(sessionName, messageID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if entry, ok := p.entries[pendingSendKey(sessionName, messageID)]; ok {
+		entry.unconfirmed = true
+	}
+}
+
+// confirmed reports when a receipt confirmed the send, if one did.
+func (p *pendingSends) confirmed(sessionName, messageID string) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.entries[pendingSendKey(sessionName, messageID)]
+	if !ok || entry.confirmedAt.IsZero() {
+		return time.Time{}, false
+	}
+	return entry.confirmedAt, true
+}
+
+// confirm marks the tracked sends among messageIDs as delivered and returns
+// the ones that had been reported unconfirmed.
+func (p *pendingSends) confirm(sessionName string, messageIDs []types.MessageID, at time.Time) []SendConfirmedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var confirmed []SendConfirmedEvent
+	for _, id := range messageIDs {
+		entry, ok := p.entries[pendingSendKey(sessionName, id)]
+		if !ok || !entry.confirmedAt.IsZero() {
+			continue
+		}
+		entry.confirmedAt = at
+		if entry.unconfirmed {
+			confirmed = append(confirmed, SendConfirmedEvent{
+				Event:       "message.send_confirmed",
+				SessionName: sessionName,
+				MessageID:   id,
+				To:          entry.to,
+				SentAt:      entry.sentAt,
+				ConfirmedAt: at,
+			})
+		}
+	}
+	return confirmed
+}
+
+// count returns how many of the session's sends are still unconfirmed.
+func (p *pendingSends) count(sessionName string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prefix := sessionName + "/"
+	n := 0
+	for k, entry := range p.entries {
+		if entry.unconfirmed && entry.confirmedAt.IsZero() && len(k) > len(prefix) && k[:len(prefix)] == prefix {
+			n++
+		}
+	}
+	return n
+}
+
+// sendMessage sends message under the ID pinned in ctx (see
+// session.WithMessageID), or a new one. A send already confirmed by a
+// receipt returns without going out again. A failure that leaves open
+// whether the message was sent comes back as *session.SendUnconfirmedError
+// carrying the ID to retry under.
+func (g *Gateway) sendMessage(ctx context.Context, sessionName string, client *whatsmeow.Client, to types.JID, message *waE2E.Message) (whatsmeow.SendResponse, error) {
+	id := session.MessageIDFrom(ctx)
+	if id == "" {
+		id = client.GenerateMessageID()
+	} else if at, ok := g.pendingSends.confirmed(sessionName, id); ok {
+		g.logger.InfoWithFields("Send already confirmed, not sending again", map[string]interface{}{
+			"session_name": sessionName,
+			"message_id":   id,
+		})
+		return whatsmeow.SendResponse{ID: id, Timestamp: at}, nil
+	}
+
+	g.pendingSends.begin(sessionName, id, to.String())
+	resp, err := client.SendMessage(ctx, to, message, whatsmeow.SendRequestExtra{ID: id})
+	if err == nil || !sendUnanswered(err) {
+		g.pendingSends.done(sessionName, id)
+		return resp, err
+	}
+
+	g.pendingSends.unconfirmed(sessionName, id)
+	return resp, &session.SendUnconfirmedError{MessageID: id, Err: wrapContextError(err)}
+}
+
+// sendUnanswered reports whether a failed send may still have reached
+// WhatsApp: it gave up waiting for the server's ack, or the socket dropped
+// under it. A socket that was already down sent nothing.
+func sendUnanswered(err error) bool {
+	var disconnected *whatsmeow.DisconnectedError
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, whatsmeow.ErrMessageTimedOut) ||
+		errors.As(err, &disconnected)
+}
+
+// confirmSends reconciles a receipt against the session's unconfirmed sends,
+// which is how sends cut off by a disconnect are settled after reconnecting.
+func (h *EventHandler) confirmSends(evt *events.Receipt, sessionID string) {
+	if evt.Type == types.ReceiptTypeRetry || evt.Type == types.ReceiptTypeServerError {
+		return
+	}
+
+	for _, sent := range h.gateway.pendingSends.confirm(h.sessionName, evt.MessageIDs, evt.Timestamp) {
+		h.logger.InfoWithFields("Unconfirmed send delivered", map[string]interface{}{
+			"session_name": h.sessionName,
+			"message_id":   sent.MessageID,
+			"to":           sent.To,
+		})
+		h.deliverToWebhook(&sent, sessionID)
+	}
+}
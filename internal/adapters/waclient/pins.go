@@ -0,0 +1,144 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+// MessagePinnedEvent is delivered to webhooks when a message is pinned or
+// unpinned in a chat, from this session or by anyone else in it. MessageID
+// is the pinned message; Sender is who pinned it. ExpiresAt is when WhatsApp
+// unpins it by itself, and is only set on pins.
+type MessagePinnedEvent struct {
+	Event       string     `json:"event"`
+	SessionName string     `json:"session_name"`
+	MessageID   string     `json:"message_id"`
+	PinID       string     `json:"pin_id"`
+	Chat        string     `json:"chat"`
+	Sender      string     `json:"sender"`
+	FromMe      bool       `json:"from_me"`
+	Pinned      bool       `json:"pinned"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Timestamp   time.Time  `json:"timestamp"`
+}
+
+// PinMessage implements messaging.PinGateway with a pin-in-chat message,
+// pinning for everyone in the chat for pin.Duration or unpinning.
+func (g *Gateway) PinMessage(ctx context.Context, sessionName string, pin *messaging.MessagePin, pinned bool) error {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return err
+	}
+
+	chatJID, err := types.ParseJID(pin.ChatJID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid chat JID: %w", err)
+	}
+	chatJID = chatJID.ToNonAD()
+
+	// As with stars, the author is only recorded for messages other people
+	// sent in groups.
+	remoteJID := chatJID.String()
+	fromMe := pin.FromMe
+	id := pin.ZpMessageID
+	key := &waCommon.MessageKey{
+		RemoteJID: &remoteJID,
+		FromMe:    &fromMe,
+		ID:        &id,
+	}
+	if !pin.FromMe && pin.SenderJID != "" && chatJID.Server == types.GroupServer {
+		senderJID, err := types.ParseJID(pin.SenderJID)
+		if err != nil {
+			return fmt.Errorf("validation failed: invalid sender JID: %w", err)
+		}
+		participant := senderJID.ToNonAD().String()
+		key.Participant = &participant
+	}
+
+	pinType := waE2E.PinInChatMessage_UNPIN_FOR_ALL
+	if pinned {
+		pinType = waE2E.PinInChatMessage_PIN_FOR_ALL
+	}
+	sentAt := time.Now().UnixMilli()
+
+	message := &waE2E.Message{
+		PinInChatMessage: &waE2E.PinInChatMessage{
+			Key:               key,
+			Type:              &pinType,
+			SenderTimestampMS: &sentAt,
+		},
+	}
+	if pinned {
+		seconds := uint32(pin.Duration / time.Second)
+		message.MessageContextInfo = &waE2E.MessageContextInfo{
+			MessageAddOnDurationInSecs: &seconds,
+		}
+	}
+
+	opCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", chatJID.String()))
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	_, err = client.GetClient().SendMessage(opCtx, chatJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update pin: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Message pin updated", map[string]interface{}{
+		"session_name": sessionName,
+		"chat_jid":     chatJID.String(),
+		"message_id":   pin.ZpMessageID,
+		"pinned":       pinned,
+	})
+
+	return nil
+}
+
+// IsPin reports whether a message only pins or unpins an earlier one.
+func IsPin(evt *events.Message) bool {
+	return evt.Message.GetPinInChatMessage().GetKey().GetID() != ""
+}
+
+// handlePin emits message.pinned or message.unpinned for a pin-in-chat
+// message. Pins are not messages of their own and are not stored.
+func (h *EventHandler) handlePin(evt *events.Message, sessionID string) {
+	pin := evt.Message.GetPinInChatMessage()
+
+	at := evt.Info.Timestamp
+	if ms := pin.GetSenderTimestampMS(); ms > 0 {
+		at = time.UnixMilli(ms)
+	}
+
+	payload := &MessagePinnedEvent{
+		Event:       "message.unpinned",
+		SessionName: h.sessionName,
+		MessageID:   pin.GetKey().GetID(),
+		PinID:       evt.Info.ID,
+		Chat:        evt.Info.Chat.String(),
+		Sender:      evt.Info.Sender.ToNonAD().String(),
+		FromMe:      evt.Info.IsFromMe,
+		Timestamp:   at,
+	}
+	if pin.GetType() == waE2E.PinInChatMessage_PIN_FOR_ALL {
+		payload.Event = "message.pinned"
+		payload.Pinned = true
+		if seconds := evt.Message.GetMessageContextInfo().GetMessageAddOnDurationInSecs(); seconds > 0 {
+			expiresAt := at.Add(time.Duration(seconds) * time.Second)
+			payload.ExpiresAt = &expiresAt
+		}
+	}
+
+	h.deliverToWebhook(payload, sessionID)
+}
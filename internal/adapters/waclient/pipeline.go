@@ -0,0 +1,120 @@
+package waclient
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/session"
+)
+
+// Built-in stages of the inbound pipeline, in the order they run. Plugins
+// are appended after them or inserted before one of them by name.
+const (
+	StageDedup    = "dedup"
+	StageState    = "state"
+	StageFilter   = "filter"
+	StageChatwoot = "chatwoot"
+	StageWebhook  = "webhook"
+)
+
+// StageChatwootReopen is the optional stage that reopens resolved Chatwoot
+// conversations when the contact writes again. It is inserted right before
+// StageChatwoot.
+const StageChatwootReopen = "chatwoot-reopen"
+
+// ContactReplyHandler is told about every message a contact sends in a
+// private chat.
+type ContactReplyHandler interface {
+	HandleContactReply(ctx context.Context, sessionID, contactJID string) error
+}
+
+// NewContactReplyStage runs handler for incoming private messages. It never
+// stops the event: a failure is counted on the stage and the message goes on.
+func NewContactReplyStage(name string, handler ContactReplyHandler) inbound.Stage {
+	return inbound.NewStage(name, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+		msg, ok := evt.Payload.(*events.Message)
+		if !ok || msg.Info.IsFromMe || msg.Info.IsGroup || msg.Info.Chat.Server == types.BroadcastServer {
+			return true, nil
+		}
+		if msg.Message.GetReactionMessage() != nil || msg.Message.GetPollUpdateMessage() != nil || IsEditOrRevoke(msg) || IsPin(msg) {
+			return true, nil
+		}
+
+		return true, handler.HandleContactReply(ctx, evt.SessionID, msg.Info.Chat.ToNonAD().String())
+	})
+}
+
+type eventHandlerKey struct{}
+
+// newInboundPipeline builds the pipeline with the built-in stages. They find
+// the session's EventHandler in the context, so one pipeline serves every
+// session.
+func newInboundPipeline(g *Gateway) *inbound.Pipeline {
+	pipeline := inbound.NewPipeline(g.logger)
+
+	builtins := []inbound.Stage{
+		inbound.NewStage(StageDedup, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+			return !handlerFrom(ctx).isDuplicate(evt.Payload, evt.SessionID), nil
+		}),
+		inbound.NewStage(StageState, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+			handlerFrom(ctx).handleEventInternal(evt.Payload, evt.SessionID)
+			return true, nil
+		}),
+		inbound.NewStage(StageFilter, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+			return handlerFrom(ctx).filterInbound(evt), nil
+		}),
+		inbound.NewStage(StageChatwoot, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+			handlerFrom(ctx).forwardToChatwoot(evt.Payload, evt.SessionID)
+			return true, nil
+		}),
+		inbound.NewStage(StageWebhook, func(ctx context.Context, evt *inbound.Event) (bool, error) {
+			handlerFrom(ctx).deliverAnnotated(evt.Payload, evt.SessionID, evt.Annotations)
+			return true, nil
+		}),
+	}
+	for _, stage := range builtins {
+		_ = pipeline.Use(stage)
+	}
+
+	return pipeline
+}
+
+func handlerFrom(ctx context.Context) *EventHandler {
+	return ctx.Value(eventHandlerKey{}).(*EventHandler)
+}
+
+// Pipeline is the inbound event pipeline shared by all sessions, for
+// registering plugin stages and reading per-stage counters.
+func (g *Gateway) Pipeline() *inbound.Pipeline {
+	return g.pipeline
+}
+
+func (h *EventHandler) HandleEvent(evt interface{}, sessionID string) {
+	ctx := context.WithValue(context.Background(), eventHandlerKey{}, h)
+
+	h.gateway.pipeline.Run(ctx, &inbound.Event{
+		SessionID:   sessionID,
+		SessionName: h.sessionName,
+		Payload:     evt,
+		ReceivedAt:  time.Now(),
+	})
+}
+
+func (h *EventHandler) forwardToChatwoot(evt interface{}, sessionID string) {
+	msg, ok := evt.(*events.Message)
+	if !ok || msg.Message.GetReactionMessage() != nil || msg.Message.GetPollUpdateMessage() != nil || IsEditOrRevoke(msg) || IsPin(msg) {
+		return
+	}
+
+	if !h.gateway.getSettings(h.sessionName).Features.Enabled(session.FeatureChatwoot) {
+		return
+	}
+
+	if h.chatwootManager != nil && h.chatwootManager.IsEnabled(sessionID) {
+		h.processMessageForChatwoot(msg, sessionID)
+	}
+}
@@ -0,0 +1,229 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	shared "zpwoot/internal/core/shared/errors"
+	"zpwoot/platform/logger"
+)
+
+// PollVoteEvent is delivered to webhooks when someone votes on a poll or
+// changes their vote. Options holds the decrypted names of every option the
+// voter currently picks; it is empty when the vote is withdrawn.
+type PollVoteEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	PollID      string    `json:"poll_id"`
+	VoteID      string    `json:"vote_id"`
+	Chat        string    `json:"chat"`
+	Voter       string    `json:"voter"`
+	FromMe      bool      `json:"from_me"`
+	Question    string    `json:"question"`
+	Options     []string  `json:"options"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (g *Gateway) SendPollMessage(ctx context.Context, sessionName, to string, poll *session.PollMessage) (*session.MessageSendResult, error) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return nil, fmt.Errorf("session %s not found", sessionName)
+	}
+
+	if !client.IsLoggedIn() {
+		return nil, fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	recipientJID, err := types.ParseJID(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient JID: %w", err)
+	}
+
+	whatsmeowClient := client.GetClient()
+	message := whatsmeowClient.BuildPollCreation(poll.Question, poll.Options, poll.SelectableCount)
+
+	sendCtx, span := startCallSpan(ctx, "SendMessage", sessionName, attribute.String("zpwoot.recipient", recipientJID.String()))
+	sendCtx, cancel := g.withOperationTimeout(sendCtx)
+	defer cancel()
+
+	applyQuote(ctx, whatsmeowClient, message)
+	resp, err := g.sendMessage(sendCtx, sessionName, whatsmeowClient, recipientJID, message)
+	g.noteRateLimit(sessionName, err)
+	logger.EndSpan(span, err)
+	if err != nil {
+		g.logger.ErrorWithFields("Failed to send poll message", map[string]interface{}{
+			"session_name": sessionName,
+			"to":           to,
+			"error":        err.Error(),
+		})
+		return nil, fmt.Errorf("failed to send poll message: %w", wrapContextError(err))
+	}
+
+	if sessionUUID, err := uuid.Parse(g.GetSessionUUID(sessionName)); err == nil {
+		g.savePoll(&messaging.Poll{
+			SessionID:       sessionUUID,
+			ZpMessageID:     resp.ID,
+			ChatJID:         recipientJID.String(),
+			Question:        poll.Question,
+			Options:         poll.Options,
+			SelectableCount: poll.SelectableCount,
+			FromMe:          true,
+			CreatedAt:       resp.Timestamp,
+		})
+	}
+
+	g.logger.InfoWithFields("Poll message sent successfully", map[string]interface{}{
+		"session_name":     sessionName,
+		"message_id":       resp.ID,
+		"to":               to,
+		"option_count":     len(poll.Options),
+		"selectable_count": poll.SelectableCount,
+	})
+
+	return &session.MessageSendResult{
+		MessageID: resp.ID,
+		Status:    "sent",
+		Timestamp: resp.Timestamp,
+		To:        to,
+	}, nil
+}
+
+func (g *Gateway) savePoll(poll *messaging.Poll) {
+	store := g.getMessageStore()
+	if store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	if err := store.RecordPoll(ctx, poll); err != nil {
+		g.logger.ErrorWithFields("Failed to save poll", map[string]interface{}{
+			"session_id": poll.SessionID.String(),
+			"message_id": poll.ZpMessageID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// pollCreation returns the poll carried by a message, whichever version of
+// the poll creation message the sender used.
+func pollCreation(message *waE2E.Message) *waE2E.PollCreationMessage {
+	if poll := message.GetPollCreationMessage(); poll != nil {
+		return poll
+	}
+	if poll := message.GetPollCreationMessageV2(); poll != nil {
+		return poll
+	}
+	return message.GetPollCreationMessageV3()
+}
+
+// handlePollCreation records polls created outside this API, such as from
+// the phone or by another participant, so their votes can be decoded.
+func (h *EventHandler) handlePollCreation(evt *events.Message, sessionID string) {
+	creation := pollCreation(evt.Message)
+	if creation == nil {
+		return
+	}
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	options := make([]string, 0, len(creation.GetOptions()))
+	for _, option := range creation.GetOptions() {
+		options = append(options, option.GetOptionName())
+	}
+
+	h.gateway.savePoll(&messaging.Poll{
+		SessionID:       sessionUUID,
+		ZpMessageID:     evt.Info.ID,
+		ChatJID:         evt.Info.Chat.String(),
+		Question:        creation.GetName(),
+		Options:         options,
+		SelectableCount: int(creation.GetSelectableOptionsCount()),
+		FromMe:          evt.Info.IsFromMe,
+		CreatedAt:       evt.Info.Timestamp,
+	})
+}
+
+// handlePollVote decrypts a poll update, stores the voter's choice and emits
+// a poll.vote event with the selected option names.
+func (h *EventHandler) handlePollVote(evt *events.Message, sessionID string) {
+	update := evt.Message.GetPollUpdateMessage()
+	pollID := update.GetPollCreationMessageKey().GetID()
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil || pollID == "" {
+		return
+	}
+
+	client := h.gateway.getClient(h.sessionName)
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	vote, err := client.GetClient().DecryptPollVote(ctx, evt)
+	if err != nil {
+		h.logger.WarnWithFields("Failed to decrypt poll vote", map[string]interface{}{
+			"session_id": sessionID,
+			"poll_id":    pollID,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	store := h.gateway.getMessageStore()
+	if store == nil {
+		return
+	}
+
+	votedAt := evt.Info.Timestamp
+	if ms := update.GetSenderTimestampMS(); ms > 0 {
+		votedAt = time.UnixMilli(ms)
+	}
+
+	poll, recorded, err := store.RecordPollVote(ctx, sessionUUID, pollID, evt.Info.Sender.ToNonAD().String(), vote.GetSelectedOptions(), votedAt)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			h.logger.DebugWithFields("Vote on unknown poll ignored", map[string]interface{}{
+				"session_id": sessionID,
+				"poll_id":    pollID,
+			})
+		} else {
+			h.logger.ErrorWithFields("Failed to save poll vote", map[string]interface{}{
+				"session_id": sessionID,
+				"poll_id":    pollID,
+				"error":      err.Error(),
+			})
+		}
+		return
+	}
+
+	h.deliverToWebhook(&PollVoteEvent{
+		Event:       "poll.vote",
+		SessionName: h.sessionName,
+		PollID:      pollID,
+		VoteID:      evt.Info.ID,
+		Chat:        evt.Info.Chat.String(),
+		Voter:       recorded.VoterJID,
+		FromMe:      evt.Info.IsFromMe,
+		Question:    poll.Question,
+		Options:     recorded.Options,
+		Timestamp:   recorded.VotedAt,
+	}, sessionID)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"image"
 	"image/png"
 	"os"
 	"strings"
@@ -27,6 +28,9 @@ type QRGenerator struct {
 	qrCodeExpires time.Time
 	isActive      bool
 
+	logo    image.Image
+	logoPNG []byte
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -0,0 +1,220 @@
+package waclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	"zpwoot/internal/core/session"
+)
+
+// logoScale is the fraction of the QR width the logo may cover. High error
+// correction tolerates roughly 30% damage, so a centred logo at this size
+// keeps the code scannable.
+const logoScale = 0.22
+
+// LoadLogo reads the image embedded in rendered QR codes when callers ask for
+// it. An empty path disables logos.
+func (g *QRGenerator) LoadLogo(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open QR logo: %w", err)
+	}
+	defer file.Close()
+
+	logo, _, err := image.Decode(file)
+	if err != nil {
+		return fmt.Errorf("failed to decode QR logo: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, logo); err != nil {
+		return fmt.Errorf("failed to encode QR logo: %w", err)
+	}
+
+	g.mu.Lock()
+	g.logo = logo
+	g.logoPNG = buf.Bytes()
+	g.mu.Unlock()
+
+	return nil
+}
+
+func (g *QRGenerator) HasLogo() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.logo != nil
+}
+
+func (g *QRGenerator) RenderPNG(qrCode string, opts session.QRImageOptions) ([]byte, error) {
+	bitmap, err := g.qrBitmap(qrCode, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := len(bitmap)
+	scale := max(opts.Size/modules, 1)
+	offset := (opts.Size - modules*scale) / 2
+	size := max(opts.Size, modules*scale)
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{opts.Background}, image.Point{}, draw.Src)
+	fg := &image.Uniform{opts.Foreground}
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			rect := image.Rect(offset+x*scale, offset+y*scale, offset+(x+1)*scale, offset+(y+1)*scale)
+			draw.Draw(img, rect, fg, image.Point{}, draw.Src)
+		}
+	}
+
+	if opts.Logo {
+		g.drawLogo(img, opts.Background)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code image: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *QRGenerator) RenderSVG(qrCode string, opts session.QRImageOptions) ([]byte, error) {
+	bitmap, err := g.qrBitmap(qrCode, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, modules, modules)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="%s"/>`, modules, modules, hexColor(opts.Background))
+	fmt.Fprintf(&svg, `<path d="%s" fill="%s"/>`, path.String(), hexColor(opts.Foreground))
+
+	if opts.Logo {
+		g.mu.RLock()
+		logoPNG := g.logoPNG
+		g.mu.RUnlock()
+
+		if logoPNG != nil {
+			logoSize := float64(modules) * logoScale
+			pos := (float64(modules) - logoSize) / 2
+			fmt.Fprintf(&svg, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`,
+				pos-0.5, pos-0.5, logoSize+1, logoSize+1, hexColor(opts.Background))
+			fmt.Fprintf(&svg, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" href="data:image/png;base64,%s"/>`,
+				pos, pos, logoSize, logoSize, base64.StdEncoding.EncodeToString(logoPNG))
+		}
+	}
+
+	svg.WriteString(`</svg>`)
+
+	return []byte(svg.String()), nil
+}
+
+// qrBitmap encodes the code and wraps it in the requested quiet zone. The
+// library's own border is fixed at four modules, so it is disabled here.
+func (g *QRGenerator) qrBitmap(qrCode string, opts session.QRImageOptions) ([][]bool, error) {
+	level := qrcode.Medium
+	if opts.Logo {
+		level = qrcode.High
+	}
+
+	qr, err := qrcode.New(qrCode, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create QR code: %w", err)
+	}
+	qr.DisableBorder = true
+
+	inner := qr.Bitmap()
+	size := len(inner) + 2*opts.Margin
+
+	bitmap := make([][]bool, size)
+	for y := range bitmap {
+		bitmap[y] = make([]bool, size)
+	}
+	for y, row := range inner {
+		copy(bitmap[y+opts.Margin][opts.Margin:], row)
+	}
+
+	return bitmap, nil
+}
+
+func (g *QRGenerator) drawLogo(img *image.RGBA, background color.Color) {
+	g.mu.RLock()
+	logo := g.logo
+	g.mu.RUnlock()
+
+	if logo == nil {
+		return
+	}
+
+	width := img.Bounds().Dx()
+	logoSize := int(float64(width) * logoScale)
+	if logoSize <= 0 {
+		return
+	}
+
+	start := (width - logoSize) / 2
+	padding := max(logoSize/10, 2)
+	backdrop := image.Rect(start-padding, start-padding, start+logoSize+padding, start+logoSize+padding)
+	draw.Draw(img, backdrop, &image.Uniform{background}, image.Point{}, draw.Src)
+
+	// Nearest-neighbour scaling keeps this dependency-free; logos are small
+	// enough that the quality difference is not noticeable.
+	src := logo.Bounds()
+	for y := 0; y < logoSize; y++ {
+		for x := 0; x < logoSize; x++ {
+			sx := src.Min.X + x*src.Dx()/logoSize
+			sy := src.Min.Y + y*src.Dy()/logoSize
+			img.Set(start+x, start+y, blend(img.At(start+x, start+y), logo.At(sx, sy)))
+		}
+	}
+}
+
+func blend(dst, src color.Color) color.Color {
+	sr, sg, sb, sa := src.RGBA()
+	if sa == 0xffff {
+		return src
+	}
+	dr, dg, db, _ := dst.RGBA()
+	inv := 0xffff - sa
+	return color.RGBA64{
+		R: uint16(sr + dr*inv/0xffff),
+		G: uint16(sg + dg*inv/0xffff),
+		B: uint16(sb + db*inv/0xffff),
+		A: 0xffff,
+	}
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
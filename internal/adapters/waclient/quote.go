@@ -0,0 +1,64 @@
+package waclient
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+
+	"zpwoot/internal/core/session"
+)
+
+// applyQuote marks message as a reply to the quote carried by ctx. Plain
+// text is promoted to an extended text message, which is the only text
+// form that can carry a context info.
+func applyQuote(ctx context.Context, client *whatsmeow.Client, message *waE2E.Message) {
+	quote := session.QuoteFrom(ctx)
+	if quote == nil || message == nil {
+		return
+	}
+
+	participant := quote.Participant
+	if participant == "" && client != nil && client.Store.ID != nil {
+		participant = client.Store.ID.ToNonAD().String()
+	}
+
+	info := &waE2E.ContextInfo{
+		StanzaID:    &quote.MessageID,
+		Participant: &participant,
+	}
+	if quote.Body != "" {
+		body := quote.Body
+		info.QuotedMessage = &waE2E.Message{Conversation: &body}
+	}
+
+	if message.Conversation != nil {
+		message.ExtendedTextMessage = &waE2E.ExtendedTextMessage{Text: message.Conversation}
+		message.Conversation = nil
+	}
+
+	switch {
+	case message.ExtendedTextMessage != nil:
+		message.ExtendedTextMessage.ContextInfo = info
+	case message.ImageMessage != nil:
+		message.ImageMessage.ContextInfo = info
+	case message.VideoMessage != nil:
+		message.VideoMessage.ContextInfo = info
+	case message.AudioMessage != nil:
+		message.AudioMessage.ContextInfo = info
+	case message.DocumentMessage != nil:
+		message.DocumentMessage.ContextInfo = info
+	case message.StickerMessage != nil:
+		message.StickerMessage.ContextInfo = info
+	case message.LocationMessage != nil:
+		message.LocationMessage.ContextInfo = info
+	case message.ContactMessage != nil:
+		message.ContactMessage.ContextInfo = info
+	case message.InteractiveMessage != nil:
+		message.InteractiveMessage.ContextInfo = info
+	case pollCreation(message) != nil:
+		pollCreation(message).ContextInfo = info
+	case message.ViewOnceMessage.GetMessage() != nil:
+		applyQuote(ctx, client, message.ViewOnceMessage.GetMessage())
+	}
+}
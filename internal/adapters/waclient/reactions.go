@@ -0,0 +1,39 @@
+package waclient
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/messaging"
+)
+
+// ReactionEvent is delivered to webhooks when someone reacts to a message or
+// withdraws a reaction. Removed reactions carry an empty emoji.
+type ReactionEvent struct {
+	Event       string    `json:"event"`
+	SessionName string    `json:"session_name"`
+	MessageID   string    `json:"message_id"`
+	ReactionID  string    `json:"reaction_id"`
+	Chat        string    `json:"chat"`
+	Sender      string    `json:"sender"`
+	FromMe      bool      `json:"from_me"`
+	Emoji       string    `json:"emoji"`
+	Removed     bool      `json:"removed"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func newReactionEvent(sessionName string, evt *events.Message, reaction *messaging.Reaction) *ReactionEvent {
+	return &ReactionEvent{
+		Event:       "message.reaction",
+		SessionName: sessionName,
+		MessageID:   reaction.ZpMessageID,
+		ReactionID:  evt.Info.ID,
+		Chat:        evt.Info.Chat.String(),
+		Sender:      reaction.ReactorJID,
+		FromMe:      reaction.FromMe,
+		Emoji:       reaction.Emoji,
+		Removed:     reaction.Emoji == "",
+		Timestamp:   reaction.ReactedAt,
+	}
+}
@@ -0,0 +1,127 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+// MarkRead implements messaging.ReadGateway. In groups senderJID names the
+// author of the messages; WhatsApp takes one author per receipt.
+func (g *Gateway) MarkRead(ctx context.Context, sessionName, chatJID, senderJID string, messageIDs []string, at time.Time) error {
+	client, err := g.loggedInClient(sessionName)
+	if err != nil {
+		return err
+	}
+
+	chat, err := types.ParseJID(chatJID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid chat JID: %w", err)
+	}
+
+	var sender types.JID
+	if senderJID != "" {
+		sender, err = types.ParseJID(senderJID)
+		if err != nil {
+			return fmt.Errorf("validation failed: invalid sender JID: %w", err)
+		}
+	}
+
+	ids := make([]types.MessageID, len(messageIDs))
+	for i, id := range messageIDs {
+		ids[i] = types.MessageID(id)
+	}
+
+	opCtx, span := startCallSpan(ctx, "MarkRead", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	err = runWithContext(opCtx, func() error {
+		return client.GetClient().MarkRead(ids, at, chat.ToNonAD(), sender.ToNonAD())
+	})
+	logger.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to mark messages as read: %w", wrapContextError(err))
+	}
+
+	return nil
+}
+
+// SaveChatRead clears the unread count of a chat read on another device.
+func (g *Gateway) SaveChatRead(sessionID uuid.UUID, chatJID string, at time.Time) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.MarkChatRead(ctx, sessionID, chatJID, at)
+}
+
+// handleMarkChatAsRead follows chats read on the phone. Marking a chat as
+// unread there is left alone: it carries no message to count.
+func (h *EventHandler) handleMarkChatAsRead(evt *events.MarkChatAsRead, sessionID string) {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil || evt.Action == nil || !evt.Action.GetRead() {
+		return
+	}
+
+	if err := h.gateway.SaveChatRead(sessionUUID, evt.JID.ToNonAD().String(), evt.Timestamp); err != nil {
+		h.logger.ErrorWithFields("Failed to save chat read", map[string]interface{}{
+			"session_id": sessionID,
+			"chat_jid":   evt.JID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+// autoRead marks a contact's message as read on arrival when the session has
+// enableAutoRead on. Status broadcasts are left unread.
+func (h *EventHandler) autoRead(evt *events.Message, sessionID string) {
+	if evt.Info.IsFromMe || evt.Info.Chat.Server == types.BroadcastServer {
+		return
+	}
+	if !h.gateway.getSettings(h.sessionName).Features.Enabled(session.FeatureAutoRead) {
+		return
+	}
+
+	chat := evt.Info.Chat.ToNonAD().String()
+	var sender string
+	if evt.Info.IsGroup {
+		sender = evt.Info.Sender.ToNonAD().String()
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+		defer cancel()
+
+		now := time.Now()
+		if err := h.gateway.MarkRead(ctx, h.sessionName, chat, sender, []string{evt.Info.ID}, now); err != nil {
+			h.logger.WarnWithFields("Failed to auto-read message", map[string]interface{}{
+				"session_id": sessionID,
+				"message_id": evt.Info.ID,
+				"error":      err.Error(),
+			})
+			return
+		}
+
+		if sessionUUID, err := uuid.Parse(sessionID); err == nil {
+			if err := h.gateway.SaveChatRead(sessionUUID, chat, now); err != nil {
+				h.logger.ErrorWithFields("Failed to save chat read", map[string]interface{}{
+					"session_id": sessionID,
+					"chat_jid":   chat,
+					"error":      err.Error(),
+				})
+			}
+		}
+	}()
+}
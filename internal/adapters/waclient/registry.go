@@ -0,0 +1,152 @@
+package waclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/contact"
+)
+
+const registrySyncTimeout = 5 * time.Minute
+
+// ContactRegistry keeps the contact registry shared by all sessions.
+type ContactRegistry interface {
+	Observe(ctx context.Context, sightings ...*contact.Sighting)
+	SyncAddressBook(ctx context.Context, sessionID uuid.UUID, contacts []*contact.ContactInfo) *contact.RegistrySync
+}
+
+func (g *Gateway) SetContactRegistry(registry ContactRegistry) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.registry = registry
+}
+
+func (g *Gateway) getContactRegistry() ContactRegistry {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.registry
+}
+
+// observeContact records a sighting of jid. LIDs are mapped to the phone
+// number when the device knows it, since the registry is keyed by number.
+func (h *EventHandler) observeContact(sessionID string, jid types.JID, sighting *contact.Sighting) {
+	registry := h.gateway.getContactRegistry()
+	if registry == nil {
+		return
+	}
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	if jid.Server == types.HiddenUserServer {
+		client := h.gateway.getClient(h.sessionName)
+		if client == nil {
+			return
+		}
+		pn, err := client.GetClient().Store.LIDs.GetPNForLID(ctx, jid)
+		if err != nil || pn.IsEmpty() {
+			return
+		}
+		jid = pn
+	}
+
+	sighting.SessionID = sessionUUID
+	sighting.JID = jid.ToNonAD().String()
+	registry.Observe(ctx, sighting)
+}
+
+// handleContact records address book changes made on the phone. Changes
+// replayed by a full app state sync are left to the sync that follows it.
+func (h *EventHandler) handleContact(evt *events.Contact, sessionID string) {
+	h.logger.DebugWithFields("Contact update", map[string]interface{}{
+		"session_id": sessionID,
+		"jid":        evt.JID.String(),
+	})
+
+	if evt.FromFullSync {
+		return
+	}
+
+	name := evt.Action.GetFullName()
+	if name == "" {
+		name = evt.Action.GetFirstName()
+	}
+	h.observeContact(sessionID, evt.JID, &contact.Sighting{
+		Name:            name,
+		FromAddressBook: true,
+		SeenAt:          evt.Timestamp,
+	})
+}
+
+func (h *EventHandler) handlePushName(evt *events.PushName, sessionID string) {
+	seenAt := time.Now()
+	if evt.Message != nil {
+		seenAt = evt.Message.Timestamp
+	}
+	h.observeContact(sessionID, evt.JID, &contact.Sighting{
+		PushName: evt.NewPushName,
+		SeenAt:   seenAt,
+	})
+}
+
+func (h *EventHandler) handleBusinessName(evt *events.BusinessName, sessionID string) {
+	h.logger.DebugWithFields("Business name update", map[string]interface{}{
+		"session_id": sessionID,
+		"jid":        evt.JID.String(),
+	})
+
+	seenAt := time.Now()
+	if evt.Message != nil {
+		seenAt = evt.Message.Timestamp
+	}
+	h.observeContact(sessionID, evt.JID, &contact.Sighting{
+		BusinessName: evt.NewBusinessName,
+		SeenAt:       seenAt,
+	})
+}
+
+// syncContactRegistry records the whole address book once WhatsApp finished
+// sending it, which happens with the critical_unblock_low app state.
+func (h *EventHandler) syncContactRegistry(evt *events.AppStateSyncComplete, sessionID string) {
+	registry := h.gateway.getContactRegistry()
+	if registry == nil || evt.Name != appstate.WAPatchCriticalUnblockLow {
+		return
+	}
+
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), registrySyncTimeout)
+		defer cancel()
+
+		contacts, err := h.gateway.GetAllContacts(ctx, h.sessionName)
+		if err != nil {
+			h.logger.WarnWithFields("Failed to read address book for the contact registry", map[string]interface{}{
+				"session_id": sessionID,
+				"error":      err.Error(),
+			})
+			return
+		}
+
+		result := registry.SyncAddressBook(ctx, sessionUUID, contacts)
+		h.logger.InfoWithFields("Address book recorded in the contact registry", map[string]interface{}{
+			"session_id": sessionID,
+			"total":      result.Total,
+			"new":        result.New,
+			"failed":     result.Failed,
+		})
+	}()
+}
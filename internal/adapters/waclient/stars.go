@@ -0,0 +1,100 @@
+package waclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/messaging"
+	"zpwoot/platform/logger"
+)
+
+// StarMessage stars or unstars a message through the regular_high app state
+// collection, the same way the phone does it.
+func (g *Gateway) StarMessage(ctx context.Context, sessionName string, star *messaging.StarredMessage, starred bool) error {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return fmt.Errorf("session %s not found", sessionName)
+	}
+	if !client.IsLoggedIn() {
+		return fmt.Errorf("session %s is not logged in", sessionName)
+	}
+
+	chatJID, err := types.ParseJID(star.ChatJID)
+	if err != nil {
+		return fmt.Errorf("invalid chat JID: %w", err)
+	}
+
+	// WhatsApp only records the author for messages other people sent in
+	// groups; everything else is indexed against the chat itself.
+	senderJID := chatJID
+	if !star.FromMe && star.SenderJID != "" {
+		senderJID, err = types.ParseJID(star.SenderJID)
+		if err != nil {
+			return fmt.Errorf("invalid sender JID: %w", err)
+		}
+	}
+
+	patch := appstate.BuildStar(chatJID.ToNonAD(), senderJID.ToNonAD(), star.ZpMessageID, star.FromMe, starred)
+
+	opCtx, span := startCallSpan(ctx, "SendAppState", sessionName)
+	opCtx, cancel := g.withOperationTimeout(opCtx)
+	defer cancel()
+
+	err = client.GetClient().SendAppState(opCtx, patch)
+	logger.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to update star: %w", wrapContextError(err))
+	}
+
+	g.logger.InfoWithFields("Message star updated", map[string]interface{}{
+		"session_name": sessionName,
+		"message_id":   star.ZpMessageID,
+		"starred":      starred,
+	})
+
+	return nil
+}
+
+// SaveStar keeps the starred list in sync with stars set on other devices.
+func (g *Gateway) SaveStar(star *messaging.StarredMessage, starred bool) error {
+	store := g.getMessageStore()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), messageStoreTimeout)
+	defer cancel()
+
+	return store.SetStarred(ctx, star, starred)
+}
+
+func (h *EventHandler) handleStar(evt *events.Star, sessionID string) {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil || evt.Action == nil {
+		return
+	}
+
+	star := &messaging.StarredMessage{
+		SessionID:   sessionUUID,
+		ZpMessageID: evt.MessageID,
+		ChatJID:     evt.ChatJID.String(),
+		FromMe:      evt.IsFromMe,
+		StarredAt:   evt.Timestamp,
+	}
+	if !evt.SenderJID.IsEmpty() {
+		star.SenderJID = evt.SenderJID.String()
+	}
+
+	if err := h.gateway.SaveStar(star, evt.Action.GetStarred()); err != nil {
+		h.logger.ErrorWithFields("Failed to save star", map[string]interface{}{
+			"session_id": sessionID,
+			"message_id": evt.MessageID,
+			"error":      err.Error(),
+		})
+	}
+}
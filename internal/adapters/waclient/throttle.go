@@ -0,0 +1,126 @@
+package waclient
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+
+	"zpwoot/internal/core/session"
+)
+
+// SessionThrottledEvent is delivered to webhooks when WhatsApp rate-limits
+// or temporarily bans a session and its sends are paused until Until.
+type SessionThrottledEvent struct {
+	Event           string    `json:"event"`
+	SessionName     string    `json:"session_name"`
+	Reason          string    `json:"reason"`
+	Code            int       `json:"code,omitempty"`
+	Strikes         int       `json:"strikes"`
+	Until           time.Time `json:"until"`
+	CooldownSeconds int       `json:"cooldown_seconds"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+func (e *SessionThrottledEvent) throttle() session.Throttle {
+	return session.Throttle{
+		Reason:  e.Reason,
+		Code:    e.Code,
+		Strikes: e.Strikes,
+		Since:   e.Timestamp,
+		Until:   e.Until,
+	}
+}
+
+// IsRateLimitError reports whether WhatsApp refused a request because the
+// session is sending too fast.
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, whatsmeow.ErrIQRateOverLimit) {
+		return true
+	}
+	return errors.Is(err, whatsmeow.ErrServerReturnedError) && strings.HasSuffix(err.Error(), " 429")
+}
+
+// noteRateLimit throttles the session when a send or lookup failed on a
+// rate limit.
+func (g *Gateway) noteRateLimit(sessionName string, err error) {
+	if IsRateLimitError(err) {
+		g.throttle(sessionName, session.ThrottleRateLimited, 429, 0)
+	}
+}
+
+// throttle starts a cool-down for the session, doubling it when the last
+// one ended less than session.ThrottleStrikeWindow ago. Signals during a
+// cool-down, such as the other sends that were already in flight, do not
+// extend it unless WhatsApp asks for a longer pause.
+func (g *Gateway) throttle(sessionName, reason string, code int, minimum time.Duration) {
+	client := g.getClient(sessionName)
+	if client == nil {
+		return
+	}
+
+	now := time.Now()
+
+	client.mu.Lock()
+	last := client.throttle
+	if last.Active(now) && !now.Add(minimum).After(last.Until) {
+		client.mu.Unlock()
+		return
+	}
+
+	strikes := 1
+	if !last.Until.IsZero() && now.Before(last.Until.Add(session.ThrottleStrikeWindow)) {
+		strikes = last.Strikes + 1
+	}
+	cooldown := session.ThrottleCooldown(strikes, minimum)
+	client.throttle = session.Throttle{
+		Reason:  reason,
+		Code:    code,
+		Strikes: strikes,
+		Since:   now,
+		Until:   now.Add(cooldown),
+	}
+	client.mu.Unlock()
+
+	g.logger.WarnWithFields("Session throttled by WhatsApp", map[string]interface{}{
+		"session_name": sessionName,
+		"reason":       reason,
+		"code":         code,
+		"strikes":      strikes,
+		"cooldown":     cooldown.String(),
+	})
+
+	client.notifyEventHandlers(&SessionThrottledEvent{
+		Event:           "session.throttled",
+		SessionName:     sessionName,
+		Reason:          reason,
+		Code:            code,
+		Strikes:         strikes,
+		Until:           now.Add(cooldown),
+		CooldownSeconds: int(cooldown.Seconds()),
+		Timestamp:       now,
+	})
+}
+
+// handleThrottled pauses the session's send queue. The event reaches
+// webhooks through the pipeline like any other.
+func (h *EventHandler) handleThrottled(evt *SessionThrottledEvent) {
+	for _, handler := range h.gateway.getEventHandlers("global") {
+		go func(sessionHandler session.EventHandler) {
+			defer func() {
+				if r := recover(); r != nil {
+					h.logger.ErrorWithFields("Session event handler panic", map[string]interface{}{
+						"session_name": h.sessionName,
+						"event":        "throttled",
+						"error":        r,
+					})
+				}
+			}()
+			sessionHandler.OnSessionThrottled(h.sessionName, evt.throttle())
+		}(handler)
+	}
+}
@@ -0,0 +1,77 @@
+package waclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+
+	"zpwoot/internal/core/session"
+)
+
+func (g *Gateway) SetOperationTimeout(timeout time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.operationTimeout = timeout
+}
+
+// withOperationTimeout derives a context bounded by the configured operation
+// timeout, keeping any earlier deadline already set by the caller.
+func (g *Gateway) withOperationTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	g.mu.RLock()
+	timeout := g.operationTimeout
+	g.mu.RUnlock()
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// runWithContext runs a whatsmeow call that does not accept a context and
+// stops waiting for it once ctx is done. The call itself keeps running in the
+// background, but the caller is released with a timeout error.
+func runWithContext(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func wrapContextError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Already classified by sendMessage, which kept the cause.
+	var unconfirmed *session.SendUnconfirmedError
+	if errors.As(err, &unconfirmed) {
+		return err
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", session.ErrOperationTimeout, err)
+	}
+
+	// The socket was down, so nothing reached WhatsApp and the call can be
+	// tried again once the session reconnects.
+	if errors.Is(err, whatsmeow.ErrNotConnected) {
+		return fmt.Errorf("%w: %v", session.ErrSessionNotConnected, err)
+	}
+
+	return err
+}
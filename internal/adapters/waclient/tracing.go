@@ -0,0 +1,20 @@
+package waclient
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"zpwoot/platform/logger"
+)
+
+// startCallSpan opens a client span around a call to the WhatsApp servers,
+// so a slow send shows how much of it was spent waiting on whatsmeow.
+func startCallSpan(ctx context.Context, method, sessionName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("zpwoot.session", sessionName))
+	return logger.Tracer().Start(ctx, "whatsmeow."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
@@ -0,0 +1,121 @@
+package waclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.opentelemetry.io/otel/attribute"
+
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+// Uploads to WhatsApp's media servers now and then fail mid-transfer. Nothing
+// has been sent at that point, so they are retried; and a finished upload is
+// reused for the same file from the same session for uploadCacheTTL, so a
+// send retried after its upload or its message failed resumes from there
+// instead of uploading again.
+const (
+	uploadBackoff  = time.Second
+	uploadCacheTTL = time.Hour
+)
+
+func (g *Gateway) SetUploadRetries(retries int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.uploadRetries = retries
+}
+
+type uploadKey struct {
+	sessionName string
+	mediaType   string
+	sum         [sha256.Size]byte
+}
+
+type cachedUpload struct {
+	response   whatsmeow.UploadResponse
+	uploadedAt time.Time
+}
+
+type uploadCache struct {
+	mu      sync.Mutex
+	entries map[uploadKey]cachedUpload
+}
+
+func (c *uploadCache) get(key uploadKey) (whatsmeow.UploadResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Since(entry.uploadedAt) > uploadCacheTTL {
+		return whatsmeow.UploadResponse{}, false
+	}
+	return entry.response, true
+}
+
+// put remembers an upload and drops the expired ones, which keeps the cache
+// to the uploads of the last hour.
+func (c *uploadCache) put(key uploadKey, response whatsmeow.UploadResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[uploadKey]cachedUpload)
+	}
+	for k, entry := range c.entries {
+		if time.Since(entry.uploadedAt) > uploadCacheTTL {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedUpload{response: response, uploadedAt: time.Now()}
+}
+
+// uploadMedia uploads data for a send, retrying failed uploads up to the
+// configured count with a doubling backoff. When every attempt fails the
+// error wraps session.ErrMediaUploadFailed.
+func (g *Gateway) uploadMedia(ctx context.Context, client *whatsmeow.Client, sessionName string, data []byte, mediaType string) (*whatsmeow.UploadResponse, error) {
+	key := uploadKey{sessionName: sessionName, mediaType: mediaType, sum: sha256.Sum256(data)}
+	if uploaded, ok := g.uploads.get(key); ok {
+		return &uploaded, nil
+	}
+
+	g.mu.RLock()
+	retries := g.uploadRetries
+	g.mu.RUnlock()
+
+	wait := uploadBackoff
+	for attempt := 1; ; attempt++ {
+		uploadCtx, span := startCallSpan(ctx, "Upload", sessionName,
+			attribute.String("zpwoot.media_type", mediaType),
+			attribute.Int("zpwoot.attempt", attempt),
+		)
+		uploaded, err := client.Upload(uploadCtx, data, whatsmeowMediaType(mediaType))
+		logger.EndSpan(span, err)
+		if err == nil {
+			g.uploads.put(key, uploaded)
+			return &uploaded, nil
+		}
+
+		if attempt > retries || ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %v", session.ErrMediaUploadFailed, wrapContextError(err))
+		}
+
+		g.logger.WarnWithFields("Media upload failed, retrying", map[string]interface{}{
+			"session_name": sessionName,
+			"media_type":   mediaType,
+			"attempt":      attempt,
+			"error":        err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", session.ErrMediaUploadFailed, wrapContextError(err))
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
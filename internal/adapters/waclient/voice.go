@@ -0,0 +1,240 @@
+package waclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"zpwoot/internal/core/conversation"
+	"zpwoot/internal/core/messaging"
+)
+
+// WhatsApp draws voice notes from a 64-point waveform with values 0-100.
+const (
+	waveformPoints = 64
+	waveformMax    = 100
+
+	opusMimeType   = "audio/ogg; codecs=opus"
+	opusSampleRate = 48000
+)
+
+// AudioDetails is what a voice note carries besides the file: its length
+// and the waveform the apps draw in the bubble.
+type AudioDetails struct {
+	Seconds  uint32 `json:"seconds"`
+	PTT      bool   `json:"ptt"`
+	Waveform []int  `json:"waveform,omitempty"`
+}
+
+// MessageEvent is the message webhook payload: the whatsmeow event as before,
+// plus the audio details decoded for audio messages, since the raw protobuf
+// only has the waveform as base64, the link to the downloaded media when
+// media hosting is enabled, its virus scan when scanning is, the chat's
+// conversation state when a bot has stored one and what inbound pipeline
+// stages annotated it with (such as the filter rule it matched).
+type MessageEvent struct {
+	*events.Message
+	Audio *AudioDetails `json:"audio,omitempty"`
+
+	MediaURL          string     `json:"media_url,omitempty"`
+	MediaURLExpiresAt *time.Time `json:"media_url_expires_at,omitempty"`
+
+	MediaScan *messaging.MediaScan `json:"media_scan,omitempty"`
+
+	State *conversation.State `json:"state,omitempty"`
+
+	Annotations map[string]interface{} `json:"annotations,omitempty"`
+}
+
+func NewMessageEvent(evt *events.Message) *MessageEvent {
+	return &MessageEvent{
+		Message: evt,
+		Audio:   ExtractAudioDetails(evt.Message),
+	}
+}
+
+func (e *MessageEvent) WithMediaLink(link *messaging.HostedMedia) *MessageEvent {
+	if link == nil {
+		return e
+	}
+	e.MediaURL = link.URL
+	e.MediaURLExpiresAt = &link.ExpiresAt
+	return e
+}
+
+func (e *MessageEvent) WithMediaScan(scan *messaging.MediaScan) *MessageEvent {
+	e.MediaScan = scan
+	return e
+}
+
+func (e *MessageEvent) WithState(state *conversation.State) *MessageEvent {
+	e.State = state
+	return e
+}
+
+func (e *MessageEvent) WithAnnotations(annotations map[string]interface{}) *MessageEvent {
+	e.Annotations = annotations
+	return e
+}
+
+func ExtractAudioDetails(message *waE2E.Message) *AudioDetails {
+	audio := message.GetAudioMessage()
+	if audio == nil {
+		return nil
+	}
+
+	details := &AudioDetails{
+		Seconds: audio.GetSeconds(),
+		PTT:     audio.GetPTT(),
+	}
+	if waveform := audio.GetWaveform(); len(waveform) > 0 {
+		details.Waveform = make([]int, len(waveform))
+		for i, value := range waveform {
+			details.Waveform[i] = int(value)
+		}
+	}
+
+	return details
+}
+
+// applyVoiceNote turns an outgoing Ogg/Opus audio into a voice note, with
+// the duration and waveform the apps need to draw it. Other formats are
+// left as plain audio files, which the apps show without a waveform.
+func applyVoiceNote(message *waE2E.Message, data []byte) {
+	audio := message.GetAudioMessage()
+	if audio == nil {
+		return
+	}
+
+	seconds, waveform, ok := inspectOpus(data)
+	if !ok {
+		return
+	}
+
+	mimeType := opusMimeType
+	ptt := true
+	audio.Mimetype = &mimeType
+	audio.PTT = &ptt
+	audio.Seconds = &seconds
+	audio.Waveform = waveform
+}
+
+// processVoiceNote runs applyVoiceNote on the media pool, where reading a
+// long recording does not hold up other sessions' requests.
+func (g *Gateway) processVoiceNote(ctx context.Context, sessionName string, message *waE2E.Message, data []byte) error {
+	if message.GetAudioMessage() == nil {
+		return nil
+	}
+
+	return g.getMediaPool().Run(ctx, sessionName, func() error {
+		applyVoiceNote(message, data)
+		return nil
+	})
+}
+
+// inspectOpus reads an Ogg/Opus file without decoding it. The duration
+// comes from the last granule position; the waveform from the size of each
+// Opus packet, which with the variable bitrate WhatsApp and most encoders
+// use grows with loudness and drops to a few bytes on silence.
+func inspectOpus(data []byte) (uint32, []byte, bool) {
+	var packets [][]byte
+	var pending []byte
+	var serial uint32
+	var lastGranule uint64
+
+	for offset := 0; offset+27 <= len(data); {
+		page := data[offset:]
+		if !bytes.HasPrefix(page, []byte("OggS")) {
+			return 0, nil, false
+		}
+
+		segments := int(page[26])
+		if 27+segments > len(page) {
+			return 0, nil, false
+		}
+		table := page[27 : 27+segments]
+		bodySize := 0
+		for _, size := range table {
+			bodySize += int(size)
+		}
+		if 27+segments+bodySize > len(page) {
+			return 0, nil, false
+		}
+
+		pageSerial := binary.LittleEndian.Uint32(page[14:18])
+		if offset == 0 {
+			serial = pageSerial
+		}
+		if pageSerial == serial {
+			if granule := binary.LittleEndian.Uint64(page[6:14]); granule != ^uint64(0) {
+				lastGranule = granule
+			}
+
+			body := page[27+segments:]
+			for _, size := range table {
+				pending = append(pending, body[:size]...)
+				body = body[size:]
+				if size < 255 {
+					packets = append(packets, pending)
+					pending = nil
+				}
+			}
+		}
+
+		offset += 27 + segments + bodySize
+	}
+
+	if len(packets) < 3 || !bytes.HasPrefix(packets[0], []byte("OpusHead")) || len(packets[0]) < 19 {
+		return 0, nil, false
+	}
+
+	preSkip := uint64(binary.LittleEndian.Uint16(packets[0][10:12]))
+	samples := uint64(0)
+	if lastGranule > preSkip {
+		samples = lastGranule - preSkip
+	}
+	seconds := uint32((samples + opusSampleRate/2) / opusSampleRate)
+	if seconds == 0 && samples > 0 {
+		seconds = 1
+	}
+
+	// The first two packets are the OpusHead and OpusTags headers.
+	return seconds, waveform(packets[2:]), true
+}
+
+func waveform(packets [][]byte) []byte {
+	levels := make([]float64, waveformPoints)
+	for i := range levels {
+		start := i * len(packets) / waveformPoints
+		end := (i + 1) * len(packets) / waveformPoints
+		if end <= start {
+			end = start + 1
+		}
+
+		total := 0
+		for _, packet := range packets[start:end] {
+			total += len(packet)
+		}
+		levels[i] = float64(total) / float64(end-start)
+	}
+
+	low, high := levels[0], levels[0]
+	for _, level := range levels {
+		low = min(low, level)
+		high = max(high, level)
+	}
+
+	points := make([]byte, waveformPoints)
+	if high == low {
+		return points
+	}
+	for i, level := range levels {
+		points[i] = byte((level - low) / (high - low) * waveformMax)
+	}
+
+	return points
+}
@@ -0,0 +1,17 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, entry *Entry) error
+	List(ctx context.Context, filter *Filter) ([]*Entry, error)
+	Count(ctx context.Context, filter *Filter) (int64, error)
+	DeleteOlderThan(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Recorder interface {
+	Record(ctx context.Context, entry *Entry)
+}
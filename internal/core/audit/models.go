@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Entry struct {
+	ID             uuid.UUID              `json:"id"`
+	APIKey         string                 `json:"api_key"`
+	ClientIP       string                 `json:"client_ip"`
+	UserAgent      string                 `json:"user_agent,omitempty"`
+	SessionRef     string                 `json:"session_ref,omitempty"`
+	Method         string                 `json:"method"`
+	Path           string                 `json:"path"`
+	Route          string                 `json:"route,omitempty"`
+	StatusCode     int                    `json:"status_code"`
+	Success        bool                   `json:"success"`
+	DurationMs     int64                  `json:"duration_ms"`
+	PayloadSummary map[string]interface{} `json:"payload_summary,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+type Filter struct {
+	APIKey     string
+	SessionRef string
+	Method     string
+	Path       string
+	Success    *bool
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const retentionInterval = 1 * time.Hour
+
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+	retention  time.Duration
+}
+
+func NewService(repo Repository, retention time.Duration, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+		retention:  retention,
+	}
+}
+
+func (s *Service) Record(ctx context.Context, entry *Entry) {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := s.repository.Create(ctx, entry); err != nil {
+		s.logger.ErrorWithFields("Failed to record audit entry", map[string]interface{}{
+			"method": entry.Method,
+			"path":   entry.Path,
+			"error":  err.Error(),
+		})
+	}
+}
+
+func (s *Service) List(ctx context.Context, filter *Filter) ([]*Entry, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 500 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	entries, err := s.repository.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	total, err := s.repository.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func (s *Service) PurgeExpired(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := s.repository.DeleteOlderThan(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge audit entries: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// StartRetention purges expired entries periodically until ctx is cancelled.
+func (s *Service) StartRetention(ctx context.Context) {
+	if s.retention <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(retentionInterval)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := s.PurgeExpired(ctx)
+			if err != nil {
+				s.logger.ErrorWithFields("Audit retention run failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else if deleted > 0 {
+				s.logger.InfoWithFields("Expired audit entries purged", map[string]interface{}{
+					"deleted": deleted,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,37 @@
+package chatwoot
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	List(ctx context.Context, accountID int) ([]*InboxRoute, error)
+	GetByInbox(ctx context.Context, accountID, inboxID int) (*InboxRoute, error)
+	GetBySession(ctx context.Context, accountID int, sessionID uuid.UUID) (*InboxRoute, error)
+
+	// Save inserts the route or moves an existing inbox to route.SessionID.
+	Save(ctx context.Context, route *InboxRoute) error
+	Delete(ctx context.Context, accountID, inboxID int) error
+}
+
+// ResolutionRepository keeps the resolved conversations of each contact.
+// Latest returns the most recently resolved one, or ErrNoResolution.
+type ResolutionRepository interface {
+	Save(ctx context.Context, resolution *Resolution) error
+	Latest(ctx context.Context, sessionID uuid.UUID, contactJID string) (*Resolution, error)
+	Delete(ctx context.Context, accountID, conversationID int) error
+}
+
+// GroupContactRepository keeps the Chatwoot contacts of each group.
+type GroupContactRepository interface {
+	Save(ctx context.Context, contact *GroupContact) error
+	ListByGroup(ctx context.Context, sessionID uuid.UUID, groupJID string) ([]*GroupContact, error)
+}
+
+// API is the part of the Chatwoot REST API the integration calls.
+type API interface {
+	ReopenConversation(ctx context.Context, accountID, conversationID int) error
+	UpdateContactName(ctx context.Context, accountID, contactID int, name string) error
+}
@@ -0,0 +1,37 @@
+package chatwoot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInboxNotMapped = errors.New("chatwoot inbox not found")
+	ErrInboxConflict  = errors.New("chatwoot inbox mapping conflict")
+	ErrInvalidInbox   = errors.New("validation failed: invalid chatwoot inbox")
+	ErrNoResolution   = errors.New("no resolved chatwoot conversation")
+)
+
+// ConflictError rejects a mapping that would take an inbox or a session
+// away from its current route. Existing is the route in the way.
+type ConflictError struct {
+	Existing *InboxRoute
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: inbox %d of account %d is mapped to session %s",
+		ErrInboxConflict, e.Existing.InboxID, e.Existing.AccountID, e.Existing.SessionID)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrInboxConflict
+}
+
+func conflict(existing *InboxRoute, sessionID uuid.UUID, inboxID int) error {
+	if existing == nil || (existing.SessionID == sessionID && existing.InboxID == inboxID) {
+		return nil
+	}
+	return &ConflictError{Existing: existing}
+}
@@ -0,0 +1,55 @@
+package chatwoot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// LinkGroup remembers the Chatwoot contact of a group conversation. Links
+// are only kept when renaming is possible.
+func (s *Service) LinkGroup(ctx context.Context, contact *GroupContact) error {
+	if s.api == nil {
+		return nil
+	}
+	if err := s.groups.Save(ctx, contact); err != nil {
+		return fmt.Errorf("failed to link chatwoot group contact: %w", err)
+	}
+	return nil
+}
+
+// RenameGroup renames every Chatwoot contact linked to the session's group,
+// which is the name Chatwoot shows for the group's conversations. It returns
+// how many were renamed; contacts that fail are reported together.
+func (s *Service) RenameGroup(ctx context.Context, sessionID uuid.UUID, groupJID, name string) (int, error) {
+	if s.api == nil || name == "" {
+		return 0, nil
+	}
+
+	contacts, err := s.groups.ListByGroup(ctx, sessionID, groupJID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list chatwoot group contacts: %w", err)
+	}
+
+	renamed := 0
+	var errs []error
+	for _, contact := range contacts {
+		if err := s.api.UpdateContactName(ctx, contact.AccountID, contact.ContactID, name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to rename chatwoot contact %d: %w", contact.ContactID, err))
+			continue
+		}
+		renamed++
+	}
+
+	if renamed > 0 {
+		s.logger.InfoWithFields("Chatwoot group contacts renamed", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"group_jid":  groupJID,
+			"contacts":   renamed,
+		})
+	}
+
+	return renamed, errors.Join(errs...)
+}
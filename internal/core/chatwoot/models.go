@@ -0,0 +1,39 @@
+package chatwoot
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxRoute ties a Chatwoot inbox to the session that owns its number, so
+// agent replies posted in that inbox go out through the right session.
+// An inbox has one session and a session has one inbox per account.
+type InboxRoute struct {
+	AccountID int       `json:"accountId"`
+	InboxID   int       `json:"inboxId"`
+	SessionID uuid.UUID `json:"sessionId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Resolution remembers a resolved conversation so that the contact's next
+// message can reopen it.
+type Resolution struct {
+	AccountID      int       `json:"accountId"`
+	ConversationID int       `json:"conversationId"`
+	SessionID      uuid.UUID `json:"sessionId"`
+	ContactJID     string    `json:"contactJid"`
+	ResolvedAt     time.Time `json:"resolvedAt"`
+}
+
+// GroupContact is the Chatwoot contact standing for a WhatsApp group in a
+// session's inbox, learned from the webhooks of its conversations so that
+// renaming the group renames the contact.
+type GroupContact struct {
+	AccountID int       `json:"accountId"`
+	ContactID int       `json:"contactId"`
+	SessionID uuid.UUID `json:"sessionId"`
+	GroupJID  string    `json:"groupJid"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
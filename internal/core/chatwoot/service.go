@@ -0,0 +1,178 @@
+package chatwoot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// Service routes Chatwoot inboxes to sessions when several numbers feed the
+// same Chatwoot account, reopens resolved conversations when the contact
+// writes again and renames group contacts when the group is renamed. api is
+// nil when no Chatwoot API token is configured, which turns reopening and
+// renaming off.
+type Service struct {
+	repository  Repository
+	resolutions ResolutionRepository
+	groups      GroupContactRepository
+	api         API
+	logger      *logger.Logger
+}
+
+func NewService(repo Repository, resolutions ResolutionRepository, groups GroupContactRepository, api API, logger *logger.Logger) *Service {
+	return &Service{
+		repository:  repo,
+		resolutions: resolutions,
+		groups:      groups,
+		api:         api,
+		logger:      logger,
+	}
+}
+
+func (s *Service) List(ctx context.Context, accountID int) ([]*InboxRoute, error) {
+	routes, err := s.repository.List(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chatwoot inboxes: %w", err)
+	}
+
+	return routes, nil
+}
+
+// Route returns the session that owns an inbox.
+func (s *Service) Route(ctx context.Context, accountID, inboxID int) (uuid.UUID, error) {
+	route, err := s.repository.GetByInbox(ctx, accountID, inboxID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return route.SessionID, nil
+}
+
+// Map routes an inbox to a session. It fails with a *ConflictError when the
+// inbox already belongs to another session or the session already has
+// another inbox in the account; force re-maps, dropping the session's
+// previous inbox.
+func (s *Service) Map(ctx context.Context, accountID, inboxID int, sessionID uuid.UUID, force bool) (*InboxRoute, error) {
+	if accountID <= 0 || inboxID <= 0 {
+		return nil, fmt.Errorf("%w: account and inbox IDs must be positive", ErrInvalidInbox)
+	}
+
+	byInbox, err := optional(s.repository.GetByInbox(ctx, accountID, inboxID))
+	if err != nil {
+		return nil, err
+	}
+	bySession, err := optional(s.repository.GetBySession(ctx, accountID, sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		if err := conflict(byInbox, sessionID, inboxID); err != nil {
+			return nil, err
+		}
+		if err := conflict(bySession, sessionID, inboxID); err != nil {
+			return nil, err
+		}
+	}
+
+	if bySession != nil && bySession.InboxID != inboxID {
+		if err := s.repository.Delete(ctx, accountID, bySession.InboxID); err != nil {
+			return nil, fmt.Errorf("failed to unmap previous inbox: %w", err)
+		}
+	}
+
+	now := time.Now()
+	route := &InboxRoute{
+		AccountID: accountID,
+		InboxID:   inboxID,
+		SessionID: sessionID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if byInbox != nil {
+		route.CreatedAt = byInbox.CreatedAt
+	}
+
+	if err := s.repository.Save(ctx, route); err != nil {
+		return nil, err
+	}
+
+	if byInbox != nil && byInbox.SessionID != sessionID {
+		s.logger.InfoWithFields("Chatwoot inbox re-mapped", map[string]interface{}{
+			"account_id":       accountID,
+			"inbox_id":         inboxID,
+			"session_id":       sessionID.String(),
+			"previous_session": byInbox.SessionID.String(),
+		})
+	}
+
+	return route, nil
+}
+
+func (s *Service) Unmap(ctx context.Context, accountID, inboxID int) error {
+	return s.repository.Delete(ctx, accountID, inboxID)
+}
+
+// CanReopen reports whether resolved conversations can be reopened, which
+// needs the Chatwoot API.
+func (s *Service) CanReopen() bool {
+	return s.api != nil
+}
+
+// Resolved records a resolved conversation for Reopen.
+func (s *Service) Resolved(ctx context.Context, resolution *Resolution) error {
+	if err := s.resolutions.Save(ctx, resolution); err != nil {
+		return fmt.Errorf("failed to record chatwoot resolution: %w", err)
+	}
+	return nil
+}
+
+// Reopened forgets a conversation that is open again, whoever reopened it.
+func (s *Service) Reopened(ctx context.Context, accountID, conversationID int) error {
+	return s.resolutions.Delete(ctx, accountID, conversationID)
+}
+
+// Reopen reopens the contact's latest resolved conversation in the
+// session's inbox. It returns nil when there is nothing to reopen.
+func (s *Service) Reopen(ctx context.Context, sessionID uuid.UUID, contactJID string) (*Resolution, error) {
+	if s.api == nil {
+		return nil, nil
+	}
+
+	resolution, err := s.resolutions.Latest(ctx, sessionID, contactJID)
+	if errors.Is(err, ErrNoResolution) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.api.ReopenConversation(ctx, resolution.AccountID, resolution.ConversationID); err != nil {
+		return nil, fmt.Errorf("failed to reopen chatwoot conversation %d: %w", resolution.ConversationID, err)
+	}
+	if err := s.resolutions.Delete(ctx, resolution.AccountID, resolution.ConversationID); err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Chatwoot conversation reopened", map[string]interface{}{
+		"account_id":      resolution.AccountID,
+		"conversation_id": resolution.ConversationID,
+		"session_id":      sessionID.String(),
+		"contact":         contactJID,
+	})
+
+	return resolution, nil
+}
+
+// optional treats a missing route as no route.
+func optional(route *InboxRoute, err error) (*InboxRoute, error) {
+	if errors.Is(err, ErrInboxNotMapped) {
+		return nil, nil
+	}
+	return route, err
+}
@@ -0,0 +1,252 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+var ErrAvatarNotFound = errors.New("avatar not found")
+
+// Avatar is the latest profile picture a session saw for a contact.
+// PictureID is empty when the contact has no picture or hides it from the
+// session; LocalPath is the copy kept under the media directory.
+type Avatar struct {
+	SessionID uuid.UUID
+	JID       string
+	PictureID string
+	URL       string
+	LocalPath string
+	CheckedAt time.Time
+	ChangedAt time.Time
+}
+
+// AvatarResult is an avatar together with where it came from.
+type AvatarResult struct {
+	Avatar
+	Cached bool
+}
+
+// AvatarChange is a contact's picture changing from PreviousID to PictureID.
+type AvatarChange struct {
+	SessionID  uuid.UUID
+	JID        string
+	PreviousID string
+	PictureID  string
+	Removed    bool
+	LocalPath  string
+	ChangedAt  time.Time
+}
+
+// AvatarPicture is WhatsApp's answer for a contact's picture, with an empty
+// ID when there is none to show.
+type AvatarPicture struct {
+	ID  string
+	URL string
+}
+
+// AvatarRepository stores the latest avatar of each contact per session.
+// Get returns ErrAvatarNotFound for contacts never looked up.
+type AvatarRepository interface {
+	Get(ctx context.Context, sessionID uuid.UUID, jid string) (*Avatar, error)
+	Save(ctx context.Context, avatar *Avatar) error
+}
+
+// AvatarSource reaches WhatsApp and the media directory for avatars.
+type AvatarSource interface {
+	// FetchAvatar asks WhatsApp for the contact's picture. It returns nil
+	// when knownID is still the current picture.
+	FetchAvatar(ctx context.Context, sessionName, jid, knownID string) (*AvatarPicture, error)
+	// StoreAvatar downloads the picture and keeps it under the media
+	// directory, replacing the contact's previous one.
+	StoreAvatar(ctx context.Context, sessionID uuid.UUID, jid, url string) (string, error)
+	DeleteAvatar(sessionID uuid.UUID, jid string) error
+}
+
+// AvatarChangeHandler is told about every picture change detected.
+type AvatarChangeHandler func(ctx context.Context, sessionName string, change *AvatarChange)
+
+// AvatarCache answers avatar lookups from the picture IDs already seen, so
+// CRM syncs asking for the same contacts again do not each cost a WhatsApp
+// query and a download. Picture change events keep it current; lookups older
+// than ttl ask WhatsApp again, passing the known ID so an unchanged picture
+// is not downloaded twice.
+type AvatarCache struct {
+	source   AvatarSource
+	repo     AvatarRepository
+	ttl      time.Duration
+	onChange AvatarChangeHandler
+	logger   *logger.Logger
+}
+
+// NewAvatarCache keeps lookups for ttl. A zero ttl asks WhatsApp every time,
+// still skipping the download of unchanged pictures.
+func NewAvatarCache(source AvatarSource, repo AvatarRepository, ttl time.Duration, logger *logger.Logger) *AvatarCache {
+	return &AvatarCache{
+		source: source,
+		repo:   repo,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+func (c *AvatarCache) OnChange(handler AvatarChangeHandler) {
+	c.onChange = handler
+}
+
+// Get returns the contact's avatar, from the cache when it was checked
+// within the ttl unless refresh is set. A lookup finding a different picture
+// than the cached one reports the change.
+func (c *AvatarCache) Get(ctx context.Context, sessionID uuid.UUID, sessionName, jid string, refresh bool) (*AvatarResult, error) {
+	previous := c.cached(ctx, sessionID, jid)
+	if previous != nil && !refresh && c.ttl > 0 && time.Since(previous.CheckedAt) < c.ttl {
+		return &AvatarResult{Avatar: *previous, Cached: true}, nil
+	}
+
+	knownID := ""
+	if previous != nil && (previous.PictureID == "" || previous.LocalPath != "") {
+		knownID = previous.PictureID
+	}
+
+	picture, err := c.source.FetchAvatar(ctx, sessionName, jid, knownID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get avatar: %w", err)
+	}
+
+	now := time.Now()
+	if picture == nil {
+		avatar := *previous
+		avatar.CheckedAt = now
+		c.save(ctx, &avatar)
+		return &AvatarResult{Avatar: avatar}, nil
+	}
+
+	avatar := c.apply(ctx, sessionName, sessionID, jid, previous, picture, now, previous != nil)
+	return &AvatarResult{Avatar: *avatar}, nil
+}
+
+// Observe applies a picture change WhatsApp announced for the contact. The
+// change is reported even for contacts never looked up, but not when the
+// cache already has that picture.
+func (c *AvatarCache) Observe(ctx context.Context, sessionID uuid.UUID, sessionName, jid, pictureID string, removed bool, at time.Time) error {
+	if removed {
+		pictureID = ""
+	}
+
+	previous := c.cached(ctx, sessionID, jid)
+	if previous != nil && previous.PictureID == pictureID {
+		return nil
+	}
+
+	picture := &AvatarPicture{}
+	if !removed {
+		fetched, err := c.source.FetchAvatar(ctx, sessionName, jid, "")
+		if err != nil {
+			return fmt.Errorf("failed to get changed avatar: %w", err)
+		}
+		if fetched != nil {
+			picture = fetched
+		}
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+	c.apply(ctx, sessionName, sessionID, jid, previous, picture, at, true)
+	return nil
+}
+
+func (c *AvatarCache) cached(ctx context.Context, sessionID uuid.UUID, jid string) *Avatar {
+	avatar, err := c.repo.Get(ctx, sessionID, jid)
+	if err != nil {
+		if !errors.Is(err, ErrAvatarNotFound) {
+			// The cache only saves lookups; without it WhatsApp is asked.
+			c.logger.WarnWithFields("Failed to read avatar cache", map[string]interface{}{
+				"session_id": sessionID.String(),
+				"jid":        jid,
+				"error":      err.Error(),
+			})
+		}
+		return nil
+	}
+	return avatar
+}
+
+// apply records the picture WhatsApp returned, downloading it when it is new
+// or was never stored, and reports the change when notify is set.
+func (c *AvatarCache) apply(ctx context.Context, sessionName string, sessionID uuid.UUID, jid string, previous *Avatar, picture *AvatarPicture, at time.Time, notify bool) *Avatar {
+	avatar := &Avatar{
+		SessionID: sessionID,
+		JID:       jid,
+		PictureID: picture.ID,
+		URL:       picture.URL,
+		CheckedAt: time.Now(),
+		ChangedAt: at,
+	}
+
+	changed := previous == nil || previous.PictureID != picture.ID
+	if !changed {
+		avatar.ChangedAt = previous.ChangedAt
+		avatar.LocalPath = previous.LocalPath
+	}
+
+	switch {
+	case picture.ID == "":
+		if previous != nil && previous.LocalPath != "" {
+			if err := c.source.DeleteAvatar(sessionID, jid); err != nil {
+				c.logger.WarnWithFields("Failed to delete stored avatar", map[string]interface{}{
+					"session_id": sessionID.String(),
+					"jid":        jid,
+					"error":      err.Error(),
+				})
+			}
+		}
+		avatar.LocalPath = ""
+	case avatar.LocalPath == "" || changed:
+		path, err := c.source.StoreAvatar(ctx, sessionID, jid, picture.URL)
+		if err != nil {
+			c.logger.WarnWithFields("Failed to store avatar", map[string]interface{}{
+				"session_id": sessionID.String(),
+				"jid":        jid,
+				"error":      err.Error(),
+			})
+			avatar.LocalPath = ""
+		} else {
+			avatar.LocalPath = path
+		}
+	}
+
+	c.save(ctx, avatar)
+
+	if changed && notify && c.onChange != nil {
+		change := &AvatarChange{
+			SessionID: sessionID,
+			JID:       jid,
+			PictureID: avatar.PictureID,
+			Removed:   avatar.PictureID == "",
+			LocalPath: avatar.LocalPath,
+			ChangedAt: avatar.ChangedAt,
+		}
+		if previous != nil {
+			change.PreviousID = previous.PictureID
+		}
+		c.onChange(ctx, sessionName, change)
+	}
+
+	return avatar
+}
+
+func (c *AvatarCache) save(ctx context.Context, avatar *Avatar) {
+	if err := c.repo.Save(ctx, avatar); err != nil {
+		c.logger.WarnWithFields("Failed to cache avatar", map[string]interface{}{
+			"session_id": avatar.SessionID.String(),
+			"jid":        avatar.JID,
+			"error":      err.Error(),
+		})
+	}
+}
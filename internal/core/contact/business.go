@@ -0,0 +1,129 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+var (
+	ErrNotBusinessAccount = errors.New("session is not a WhatsApp Business account")
+	ErrInvalidBusiness    = errors.New("validation failed: invalid business profile")
+)
+
+// Business hours modes, as WhatsApp names them.
+const (
+	HoursOpen24h         = "open_24h"
+	HoursAppointmentOnly = "appointment_only"
+	HoursSpecific        = "specific_hours"
+)
+
+// WhatsApp accepts at most this many websites and categories on a profile.
+const (
+	MaxBusinessWebsites   = 2
+	MaxBusinessCategories = 3
+)
+
+var businessDays = map[string]bool{
+	"sun": true, "mon": true, "tue": true, "wed": true, "thu": true, "fri": true, "sat": true,
+}
+
+type BusinessCategory struct {
+	ID   string
+	Name string
+}
+
+// BusinessHoursDay is the schedule of one weekday. Open and Close are
+// minutes since midnight and only apply to specific_hours.
+type BusinessHoursDay struct {
+	Day   string
+	Mode  string
+	Open  int
+	Close int
+}
+
+// BusinessHours is the weekly schedule; days left out are shown as closed.
+type BusinessHours struct {
+	Timezone string
+	Days     []BusinessHoursDay
+}
+
+// OwnBusinessProfile is the WhatsApp Business profile of the session's own
+// account.
+type OwnBusinessProfile struct {
+	JID         string
+	Description string
+	Address     string
+	Email       string
+	Websites    []string
+	Categories  []BusinessCategory
+	Hours       *BusinessHours
+}
+
+// BusinessProfileUpdate changes the fields that are set and leaves the rest
+// as they are. An empty string clears a text field; Websites and
+// Categories replace the current lists when not empty.
+type BusinessProfileUpdate struct {
+	Description *string
+	Address     *string
+	Email       *string
+	Websites    []string
+	Categories  []string
+	Hours       *BusinessHours
+}
+
+// BusinessProfileEditor reads and edits the business profile of the
+// session's own account.
+type BusinessProfileEditor interface {
+	GetOwnBusinessProfile(ctx context.Context, sessionName string) (*OwnBusinessProfile, error)
+	UpdateBusinessProfile(ctx context.Context, sessionName string, update *BusinessProfileUpdate) error
+}
+
+func (u *BusinessProfileUpdate) Validate() error {
+	if u.Email != nil && *u.Email != "" {
+		if _, err := mail.ParseAddress(*u.Email); err != nil {
+			return fmt.Errorf("%w: email %q is not valid", ErrInvalidBusiness, *u.Email)
+		}
+	}
+	if len(u.Websites) > MaxBusinessWebsites {
+		return fmt.Errorf("%w: at most %d websites", ErrInvalidBusiness, MaxBusinessWebsites)
+	}
+	if len(u.Categories) > MaxBusinessCategories {
+		return fmt.Errorf("%w: at most %d categories", ErrInvalidBusiness, MaxBusinessCategories)
+	}
+	if u.Hours != nil {
+		return u.Hours.Validate()
+	}
+	return nil
+}
+
+func (h *BusinessHours) Validate() error {
+	if _, err := time.LoadLocation(h.Timezone); h.Timezone == "" || err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidBusiness, h.Timezone)
+	}
+
+	seen := make(map[string]bool, len(h.Days))
+	for _, day := range h.Days {
+		if !businessDays[day.Day] {
+			return fmt.Errorf("%w: unknown day %q", ErrInvalidBusiness, day.Day)
+		}
+		if seen[day.Day] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidBusiness, day.Day)
+		}
+		seen[day.Day] = true
+
+		switch day.Mode {
+		case HoursOpen24h, HoursAppointmentOnly:
+		case HoursSpecific:
+			if day.Open < 0 || day.Close > 24*60 || day.Open >= day.Close {
+				return fmt.Errorf("%w: %s must open before it closes", ErrInvalidBusiness, day.Day)
+			}
+		default:
+			return fmt.Errorf("%w: unknown mode %q", ErrInvalidBusiness, day.Mode)
+		}
+	}
+
+	return nil
+}
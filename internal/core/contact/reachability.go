@@ -0,0 +1,182 @@
+package contact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpwoot/platform/logger"
+)
+
+const (
+	// MaxNumberChecks bounds one call to NumberChecker.Check.
+	MaxNumberChecks = 1000
+
+	// numberLookupBatch is how many numbers go to WhatsApp in one query.
+	numberLookupBatch = 250
+)
+
+// NumberCheck is WhatsApp's answer for one phone number. JID is empty when
+// the number is not registered.
+type NumberCheck struct {
+	Phone      string
+	JID        string
+	OnWhatsApp bool
+	CheckedAt  time.Time
+}
+
+// NumberCheckResult is a check together with where it came from.
+type NumberCheckResult struct {
+	NumberCheck
+	Cached bool
+}
+
+// NumberCheckRepository stores number checks. GetMany returns the checks
+// made at or after since, keyed by phone.
+type NumberCheckRepository interface {
+	GetMany(ctx context.Context, phones []string, since time.Time) (map[string]*NumberCheck, error)
+	SaveMany(ctx context.Context, checks []*NumberCheck) error
+}
+
+// NumberResolver asks WhatsApp which numbers are registered, returning the
+// JIDs of those that are, keyed by the number as given.
+type NumberResolver interface {
+	ResolveWhatsAppNumbers(ctx context.Context, sessionID string, phoneNumbers []string) (map[string]string, error)
+}
+
+// NumberChecker answers reachability checks from a cache of recent lookups
+// and only asks WhatsApp, whose lookups are rate-limited, for the rest.
+// Registration does not depend on the session, so the cache is shared by
+// all sessions.
+type NumberChecker struct {
+	resolver NumberResolver
+	repo     NumberCheckRepository
+	ttl      time.Duration
+	logger   *logger.Logger
+}
+
+// NewNumberChecker keeps lookups for ttl. A zero ttl disables the cache.
+func NewNumberChecker(resolver NumberResolver, repo NumberCheckRepository, ttl time.Duration, logger *logger.Logger) *NumberChecker {
+	return &NumberChecker{
+		resolver: resolver,
+		repo:     repo,
+		ttl:      ttl,
+		logger:   logger,
+	}
+}
+
+// NormalizeNumber reduces a phone number, written with formatting or an
+// international prefix, or a user JID to the number's digits. It reports
+// false for anything that cannot be a phone number.
+func NormalizeNumber(raw string) (string, bool) {
+	value := strings.TrimSpace(raw)
+	if user, server, ok := strings.Cut(value, "@"); ok {
+		if server != "s.whatsapp.net" && server != "c.us" {
+			return "", false
+		}
+		user, _, _ = strings.Cut(user, ":")
+		user, _, _ = strings.Cut(user, ".")
+		value = user
+	}
+
+	value = strings.TrimPrefix(value, "+")
+	if strings.HasPrefix(value, "00") {
+		value = value[2:]
+	}
+
+	var digits strings.Builder
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char == ' ' || char == '-' || char == '.' || char == '(' || char == ')':
+		default:
+			return "", false
+		}
+	}
+
+	number := digits.String()
+	if len(number) < 8 || len(number) > 15 {
+		return "", false
+	}
+	return number, true
+}
+
+// Check reports the reachability of normalized phone numbers, keyed by
+// number. Fresh cached checks are used unless refresh is set; the rest are
+// looked up through the session and cached, negatives included.
+func (c *NumberChecker) Check(ctx context.Context, sessionID string, phones []string, refresh bool) (map[string]*NumberCheckResult, error) {
+	if len(phones) > MaxNumberChecks {
+		return nil, fmt.Errorf("validation failed: at most %d numbers can be checked at once", MaxNumberChecks)
+	}
+
+	results := make(map[string]*NumberCheckResult, len(phones))
+
+	if c.ttl > 0 && !refresh && c.repo != nil {
+		cached, err := c.repo.GetMany(ctx, phones, time.Now().Add(-c.ttl))
+		if err != nil {
+			// The cache only saves lookups; without it every number is live.
+			c.logger.WarnWithFields("Failed to read number check cache", map[string]interface{}{
+				"session_id": sessionID,
+				"error":      err.Error(),
+			})
+		}
+		for phone, check := range cached {
+			results[phone] = &NumberCheckResult{NumberCheck: *check, Cached: true}
+		}
+	}
+
+	var missing []string
+	for _, phone := range phones {
+		if _, ok := results[phone]; !ok {
+			missing = append(missing, phone)
+		}
+	}
+	if len(missing) == 0 {
+		return results, nil
+	}
+	if c.resolver == nil {
+		return nil, fmt.Errorf("number lookups are not available")
+	}
+
+	checks := make([]*NumberCheck, 0, len(missing))
+	for start := 0; start < len(missing); start += numberLookupBatch {
+		batch := missing[start:min(start+numberLookupBatch, len(missing))]
+
+		resolved, err := c.resolver.ResolveWhatsAppNumbers(ctx, sessionID, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check numbers: %w", err)
+		}
+
+		now := time.Now()
+		for _, phone := range batch {
+			check := &NumberCheck{
+				Phone:      phone,
+				JID:        resolved[phone],
+				OnWhatsApp: resolved[phone] != "",
+				CheckedAt:  now,
+			}
+			checks = append(checks, check)
+			results[phone] = &NumberCheckResult{NumberCheck: *check}
+		}
+	}
+
+	if c.ttl > 0 && c.repo != nil {
+		if err := c.repo.SaveMany(ctx, checks); err != nil {
+			c.logger.WarnWithFields("Failed to cache number checks", map[string]interface{}{
+				"session_id": sessionID,
+				"count":      len(checks),
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	c.logger.InfoWithFields("Numbers checked on WhatsApp", map[string]interface{}{
+		"session_id": sessionID,
+		"cached":     len(phones) - len(missing),
+		"live":       len(missing),
+	})
+
+	return results, nil
+}
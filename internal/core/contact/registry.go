@@ -0,0 +1,251 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
+	"zpwoot/platform/logger"
+)
+
+var ErrContactNotFound = errors.New("contact not found")
+
+// RegistryEntry is the canonical record of a WhatsApp user, shared by every
+// session that has seen them. Name, PushName and BusinessName hold the latest
+// non-empty value any session reported; Sessions holds what each session
+// knows on its own.
+type RegistryEntry struct {
+	ID           uuid.UUID
+	JID          string
+	PhoneNumber  string
+	Name         string
+	PushName     string
+	BusinessName string
+	Sessions     []*RegistryOverlay
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RegistryOverlay is one session's view of a registry entry: the name in
+// that session's address book and the avatar that session sees, which
+// privacy settings can make differ between sessions.
+type RegistryOverlay struct {
+	SessionID       uuid.UUID
+	SessionName     string
+	Name            string
+	PushName        string
+	BusinessName    string
+	IsContact       bool
+	AvatarPictureID string
+	AvatarURL       string
+	AvatarCheckedAt *time.Time
+	FirstSeenAt     time.Time
+	LastSeenAt      time.Time
+}
+
+// Avatar returns the overlay whose session checked the picture most
+// recently and could see one, or nil when none did.
+func (e *RegistryEntry) Avatar() *RegistryOverlay {
+	var latest *RegistryOverlay
+	for _, overlay := range e.Sessions {
+		if overlay.AvatarCheckedAt == nil || overlay.AvatarPictureID == "" {
+			continue
+		}
+		if latest == nil || overlay.AvatarCheckedAt.After(*latest.AvatarCheckedAt) {
+			latest = overlay
+		}
+	}
+	return latest
+}
+
+// IsBusiness reports whether any session saw a business name for the user.
+func (e *RegistryEntry) IsBusiness() bool {
+	return e.BusinessName != ""
+}
+
+// Sighting is what one session learned about a user. Empty fields leave the
+// known values as they are, so a push name change does not erase the address
+// book name. FromAddressBook marks sightings read from the session's address
+// book, whose Name replaces the session's one even when empty.
+type Sighting struct {
+	SessionID       uuid.UUID
+	JID             string
+	Name            string
+	PushName        string
+	BusinessName    string
+	FromAddressBook bool
+	SeenAt          time.Time
+}
+
+// RegistrySync counts what an address book sync changed: New users are
+// ones the session had not seen before.
+type RegistrySync struct {
+	Total   int
+	New     int
+	Updated int
+	Failed  int
+}
+
+// RegistryFilter narrows a registry listing. Search matches names and the
+// phone number; SessionID keeps users seen by that session.
+type RegistryFilter struct {
+	Search    string
+	SessionID *uuid.UUID
+	After     *pagination.Cursor
+	Limit     int
+}
+
+// RegistryRepository stores registry entries and their per-session
+// overlays. Get and GetByJID return ErrContactNotFound for unknown users.
+type RegistryRepository interface {
+	// Record reports whether the sighting is the session's first of the
+	// user.
+	Record(ctx context.Context, sighting *Sighting) (bool, error)
+	Get(ctx context.Context, id uuid.UUID) (*RegistryEntry, error)
+	GetByJID(ctx context.Context, jid string) (*RegistryEntry, error)
+	List(ctx context.Context, filter *RegistryFilter) ([]*RegistryEntry, error)
+}
+
+// Registry deduplicates contacts across sessions. Users are keyed by their
+// phone number JID, so the same person seen by several sessions is one
+// entry with one overlay per session.
+type Registry struct {
+	repo   RegistryRepository
+	logger *logger.Logger
+}
+
+func NewRegistry(repo RegistryRepository, logger *logger.Logger) *Registry {
+	return &Registry{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Observe records what sessions learned about users. Sightings of groups,
+// LIDs and other non-user JIDs are skipped, as are sightings that fail to
+// save, which the next one corrects.
+func (r *Registry) Observe(ctx context.Context, sightings ...*Sighting) {
+	for _, sighting := range sightings {
+		jid, ok := RegistryJID(sighting.JID)
+		if !ok {
+			continue
+		}
+		sighting.JID = jid
+		if sighting.SeenAt.IsZero() {
+			sighting.SeenAt = time.Now()
+		}
+
+		if _, err := r.repo.Record(ctx, sighting); err != nil {
+			r.logger.WarnWithFields("Failed to record contact in registry", map[string]interface{}{
+				"session_id": sighting.SessionID.String(),
+				"jid":        sighting.JID,
+				"error":      err.Error(),
+			})
+		}
+	}
+}
+
+// SyncAddressBook records every user in a session's address book, as read
+// from the session's device.
+func (r *Registry) SyncAddressBook(ctx context.Context, sessionID uuid.UUID, contacts []*ContactInfo) *RegistrySync {
+	result := &RegistrySync{}
+	now := time.Now()
+
+	for _, info := range contacts {
+		jid, ok := RegistryJID(info.JID)
+		if !ok {
+			continue
+		}
+		result.Total++
+
+		sighting := &Sighting{
+			SessionID:       sessionID,
+			JID:             jid,
+			PushName:        info.PushName,
+			BusinessName:    info.BusinessName,
+			FromAddressBook: true,
+			SeenAt:          now,
+		}
+		if info.IsContact {
+			sighting.Name = info.Name
+		}
+
+		created, err := r.repo.Record(ctx, sighting)
+		switch {
+		case err != nil:
+			result.Failed++
+			r.logger.WarnWithFields("Failed to record contact in registry", map[string]interface{}{
+				"session_id": sessionID.String(),
+				"jid":        jid,
+				"error":      err.Error(),
+			})
+		case created:
+			result.New++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result
+}
+
+// Get returns an entry by its registry ID, JID or phone number.
+func (r *Registry) Get(ctx context.Context, ref string) (*RegistryEntry, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		return r.repo.Get(ctx, id)
+	}
+
+	if !strings.Contains(ref, "@") {
+		phone, ok := NormalizeNumber(ref)
+		if !ok {
+			return nil, fmt.Errorf("validation failed: invalid contact reference")
+		}
+		ref = phone + "@s.whatsapp.net"
+	}
+
+	jid, ok := RegistryJID(ref)
+	if !ok {
+		return nil, fmt.Errorf("validation failed: invalid contact reference")
+	}
+	return r.repo.GetByJID(ctx, jid)
+}
+
+// List pages through the registry ordered by JID.
+func (r *Registry) List(ctx context.Context, filter *RegistryFilter) ([]*RegistryEntry, bool, error) {
+	limit := pagination.ClampLimit(filter.Limit)
+	filter.Limit = limit + 1
+
+	entries, err := r.repo.List(ctx, filter)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list registry contacts: %w", err)
+	}
+
+	entries, hasMore := pagination.Trim(entries, limit)
+	return entries, hasMore, nil
+}
+
+// RegistryJID reduces a user JID to the registry key: the phone number at
+// s.whatsapp.net, without device suffix. Other JIDs are not registered.
+func RegistryJID(jid string) (string, bool) {
+	user, server, found := strings.Cut(strings.TrimSpace(jid), "@")
+	if !found || (server != "s.whatsapp.net" && server != "c.us") {
+		return "", false
+	}
+	user, _, _ = strings.Cut(user, ":")
+	user, _, _ = strings.Cut(user, ".")
+	if user == "" {
+		return "", false
+	}
+	for _, r := range user {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return user + "@s.whatsapp.net", true
+}
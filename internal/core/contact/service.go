@@ -38,6 +38,7 @@ type ContactInfo struct {
 	JID          string `json:"jid"`
 	PhoneNumber  string `json:"phone_number"`
 	Name         string `json:"name,omitempty"`
+	PushName     string `json:"push_name,omitempty"`
 	BusinessName string `json:"business_name,omitempty"`
 	IsBusiness   bool   `json:"is_business"`
 	IsContact    bool   `json:"is_contact"`
@@ -0,0 +1,22 @@
+package conversation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Get returns the chat's state unless it expired.
+	Get(ctx context.Context, sessionID uuid.UUID, chatJID string) (*State, error)
+	// Put writes state. With expected nil the write always happens; with 0
+	// only when the chat has no live state; otherwise only when the live
+	// state is at that version. It reports whether it wrote, filling in
+	// the new Version and UpdatedAt.
+	Put(ctx context.Context, state *State, expected *int64) (bool, error)
+	// Delete removes the chat's state, at the expected version when one is
+	// given, and reports whether it did.
+	Delete(ctx context.Context, sessionID uuid.UUID, chatJID string, expected *int64) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
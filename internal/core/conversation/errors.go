@@ -0,0 +1,27 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrStateNotFound   = errors.New("conversation state not found")
+	ErrInvalidState    = errors.New("validation failed: invalid conversation state")
+	ErrVersionConflict = errors.New("conversation state version conflict")
+)
+
+// VersionConflictError reports a write whose expected version was not the
+// stored one. Current is 0 when the chat has no state.
+type VersionConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: expected version %d, current is %d", ErrVersionConflict, e.Expected, e.Current)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
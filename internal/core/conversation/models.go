@@ -0,0 +1,27 @@
+package conversation
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDataSize bounds the JSON a bot keeps per chat.
+	MaxDataSize = 64 * 1024
+	MaxTTL      = 30 * 24 * time.Hour
+)
+
+// State is what a bot keeps about one chat of a session between messages,
+// so its backend can stay stateless. Data is any JSON document; Version
+// starts at 1 and grows with every write, for compare-and-swap. State past
+// ExpiresAt is gone; without ExpiresAt it stays until deleted.
+type State struct {
+	SessionID uuid.UUID       `json:"-"`
+	ChatJID   string          `json:"chat_jid"`
+	Data      json.RawMessage `json:"data"`
+	Version   int64           `json:"version"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
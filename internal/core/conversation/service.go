@@ -0,0 +1,156 @@
+package conversation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const purgeInterval = 10 * time.Minute
+
+// Service keeps per-chat dialog state for bots. Writes can be made
+// conditional on the version read, so two deliveries handled at once
+// cannot overwrite each other's progress.
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, sessionID uuid.UUID, chatJID string) (*State, error) {
+	return s.repository.Get(ctx, sessionID, chatJID)
+}
+
+// Put stores data as the chat's state, expiring after ttl when it is
+// positive. A non-nil expected makes the write a compare-and-swap: 0 for
+// a chat without state, otherwise the version last read. A write that
+// loses fails with a *VersionConflictError.
+func (s *Service) Put(ctx context.Context, sessionID uuid.UUID, chatJID string, data json.RawMessage, ttl time.Duration, expected *int64) (*State, error) {
+	if chatJID == "" {
+		return nil, fmt.Errorf("%w: chat JID is required", ErrInvalidState)
+	}
+	if len(data) == 0 || !json.Valid(data) {
+		return nil, fmt.Errorf("%w: data must be a JSON value", ErrInvalidState)
+	}
+	if len(data) > MaxDataSize {
+		return nil, fmt.Errorf("%w: data is limited to %d bytes", ErrInvalidState, MaxDataSize)
+	}
+	if ttl < 0 || ttl > MaxTTL {
+		return nil, fmt.Errorf("%w: ttl must be between 0 and %s", ErrInvalidState, MaxTTL)
+	}
+	if expected != nil && *expected < 0 {
+		return nil, fmt.Errorf("%w: version must not be negative", ErrInvalidState)
+	}
+
+	state := &State{
+		SessionID: sessionID,
+		ChatJID:   chatJID,
+		Data:      data,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		state.ExpiresAt = &expiresAt
+	}
+
+	written, err := s.repository.Put(ctx, state, expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save conversation state: %w", err)
+	}
+	if !written {
+		return nil, s.conflict(ctx, sessionID, chatJID, *expected)
+	}
+
+	return state, nil
+}
+
+// Delete removes the chat's state, only at the expected version when one
+// is given.
+func (s *Service) Delete(ctx context.Context, sessionID uuid.UUID, chatJID string, expected *int64) error {
+	deleted, err := s.repository.Delete(ctx, sessionID, chatJID, expected)
+	if err != nil {
+		return fmt.Errorf("failed to delete conversation state: %w", err)
+	}
+	if deleted {
+		return nil
+	}
+	if expected == nil {
+		return ErrStateNotFound
+	}
+
+	err = s.conflict(ctx, sessionID, chatJID, *expected)
+	var conflict *VersionConflictError
+	if errors.As(err, &conflict) && conflict.Current == 0 {
+		return ErrStateNotFound
+	}
+	return err
+}
+
+// Lookup returns the chat's state for an inbound message webhook, or nil
+// when it has none or it cannot be read.
+func (s *Service) Lookup(ctx context.Context, sessionID uuid.UUID, chatJID string) *State {
+	state, err := s.repository.Get(ctx, sessionID, chatJID)
+	if err != nil {
+		if !errors.Is(err, ErrStateNotFound) {
+			s.logger.WarnWithFields("Failed to read conversation state for webhook", map[string]interface{}{
+				"session_id": sessionID.String(),
+				"chat_jid":   chatJID,
+				"error":      err.Error(),
+			})
+		}
+		return nil
+	}
+	return state
+}
+
+// StartPurge deletes expired state periodically until ctx is cancelled.
+// Expired state is already invisible; this only reclaims the rows.
+func (s *Service) StartPurge(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := s.repository.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				s.logger.ErrorWithFields("Conversation state purge failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else if deleted > 0 {
+				s.logger.DebugWithFields("Expired conversation state purged", map[string]interface{}{
+					"deleted": deleted,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// conflict builds the error for a write that lost, with the version now
+// stored.
+func (s *Service) conflict(ctx context.Context, sessionID uuid.UUID, chatJID string, expected int64) error {
+	current, err := s.repository.Get(ctx, sessionID, chatJID)
+	switch {
+	case errors.Is(err, ErrStateNotFound):
+		return &VersionConflictError{Expected: expected}
+	case err != nil:
+		return fmt.Errorf("failed to get conversation state: %w", err)
+	}
+	return &VersionConflictError{Expected: expected, Current: current.Version}
+}
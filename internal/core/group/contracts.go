@@ -31,18 +31,20 @@ type Repository interface {
 }
 
 type WhatsAppGateway interface {
-	CreateGroup(ctx context.Context, sessionID, name string, participants []string, description string) (*GroupInfo, error)
+	CreateGroup(ctx context.Context, sessionID, name string, participants []string, description string, settings *CreateGroupSettings) (*GroupInfo, error)
 	GetGroupInfo(ctx context.Context, sessionID, groupJID string) (*GroupInfo, error)
 	ListJoinedGroups(ctx context.Context, sessionID string) ([]*GroupInfo, error)
 
 	AddParticipants(ctx context.Context, sessionID, groupJID string, participants []string) error
+	AddParticipantsWithResult(ctx context.Context, sessionID, groupJID string, participants []string) ([]ParticipantAddResult, error)
+	ResolveWhatsAppNumbers(ctx context.Context, sessionID string, phoneNumbers []string) (map[string]string, error)
 	RemoveParticipants(ctx context.Context, sessionID, groupJID string, participants []string) error
 	PromoteParticipants(ctx context.Context, sessionID, groupJID string, participants []string) error
 	DemoteParticipants(ctx context.Context, sessionID, groupJID string, participants []string) error
 
 	SetGroupName(ctx context.Context, sessionID, groupJID, name string) error
 	SetGroupDescription(ctx context.Context, sessionID, groupJID, description string) error
-	SetGroupPhoto(ctx context.Context, sessionID, groupJID string, photoData []byte) error
+	SetGroupPhoto(ctx context.Context, sessionID, groupJID string, photoData []byte) (string, error)
 
 	SetGroupAnnounce(ctx context.Context, sessionID, groupJID string, announce bool) error
 	SetGroupRestrict(ctx context.Context, sessionID, groupJID string, restrict bool) error
@@ -70,6 +72,7 @@ type Service interface {
 	ValidateParticipants(participants []string) error
 	ValidateInviteLink(inviteLink string) error
 	ValidateJID(jid string) error
+	NormalizePhoneNumbers(phoneNumbers []string) (valid []string, invalid []string)
 
 	CanPerformAction(userJID, groupJID string, action GroupAction, groupInfo *GroupInfo) error
 	IsGroupAdmin(userJID, groupJID string, groupInfo *GroupInfo) bool
@@ -120,6 +123,15 @@ type CreateGroupRequest struct {
 	Name         string   `json:"name" validate:"required,min=1,max=25"`
 	Description  string   `json:"description,omitempty" validate:"max=512"`
 	Participants []string `json:"participants" validate:"required,min=1,max=256"`
+
+	Settings *CreateGroupSettings `json:"settings,omitempty"`
+}
+
+type CreateGroupSettings struct {
+	DisappearingTimer uint32 `json:"disappearing_timer,omitempty" validate:"omitempty,oneof=0 86400 604800 7776000"`
+	Announce          bool   `json:"announce,omitempty"`
+	MemberAddMode     string `json:"member_add_mode,omitempty" validate:"omitempty,oneof=all_members only_admins"`
+	JoinApprovalMode  string `json:"join_approval_mode,omitempty" validate:"omitempty,oneof=auto admin_approval"`
 }
 
 type UpdateParticipantsRequest struct {
@@ -9,6 +9,7 @@ var (
 	ErrInvalidInviteLink       = errors.New("invalid invite link")
 	ErrInvalidJID              = errors.New("invalid JID")
 	ErrInvalidGroupSettings    = errors.New("invalid group settings")
+	ErrInvalidGroupPhoto       = errors.New("invalid group photo")
 
 	ErrGroupNotFound            = errors.New("group not found")
 	ErrGroupAlreadyExists       = errors.New("group already exists")
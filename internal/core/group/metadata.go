@@ -0,0 +1,297 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const (
+	metadataRefreshBatch   = 200
+	metadataRefreshSpacing = time.Second
+)
+
+var ErrMetadataNotFound = errors.New("group metadata not found")
+
+// Metadata is the latest name and topic a session saw for a group it is in.
+type Metadata struct {
+	SessionID        uuid.UUID
+	GroupJID         string
+	Name             string
+	Topic            string
+	ParticipantCount int
+	CheckedAt        time.Time
+	ChangedAt        time.Time
+}
+
+// MetadataChange is a group's name or topic changing. Refreshed marks
+// changes found by asking WhatsApp rather than announced by it, which is how
+// changes made while the session was offline are caught.
+type MetadataChange struct {
+	SessionID     uuid.UUID
+	GroupJID      string
+	Name          string
+	PreviousName  string
+	Topic         string
+	PreviousTopic string
+	Refreshed     bool
+	ChangedAt     time.Time
+}
+
+func (c *MetadataChange) Renamed() bool {
+	return c.Name != c.PreviousName
+}
+
+// ActiveGroup is a group with recent messages in a connected session.
+type ActiveGroup struct {
+	SessionID   uuid.UUID
+	SessionName string
+	GroupJID    string
+}
+
+// MetadataRepository stores the latest metadata of each group per session.
+// Get returns ErrMetadataNotFound for groups never seen.
+type MetadataRepository interface {
+	Get(ctx context.Context, sessionID uuid.UUID, groupJID string) (*Metadata, error)
+	Save(ctx context.Context, metadata *Metadata) error
+	Delete(ctx context.Context, sessionID uuid.UUID, groupJID string) error
+
+	// ListStale returns groups of connected sessions with a message since
+	// activeSince whose metadata was checked before checkedBefore, or
+	// never, least recently checked first.
+	ListStale(ctx context.Context, activeSince, checkedBefore time.Time, limit int) ([]*ActiveGroup, error)
+}
+
+// MetadataSource asks WhatsApp for a group's current metadata.
+type MetadataSource interface {
+	FetchGroupMetadata(ctx context.Context, sessionName, groupJID string) (*Metadata, error)
+}
+
+// MetadataChangeHandler is told about every name or topic change detected.
+type MetadataChangeHandler func(ctx context.Context, sessionName string, change *MetadataChange)
+
+// MetadataCache keeps the names and topics of the groups sessions are in,
+// so that what is shown for a group follows renames. Group info events
+// update it as they arrive; Start also refreshes the groups with messages
+// in the last activeDays days every interval, catching changes the session
+// missed.
+type MetadataCache struct {
+	source     MetadataSource
+	repo       MetadataRepository
+	interval   time.Duration
+	activeDays int
+	handlers   []MetadataChangeHandler
+	logger     *logger.Logger
+}
+
+// NewMetadataCache refreshes active groups every interval. A zero interval
+// or activeDays leaves the refresh off; events still update the cache.
+func NewMetadataCache(source MetadataSource, repo MetadataRepository, interval time.Duration, activeDays int, logger *logger.Logger) *MetadataCache {
+	return &MetadataCache{
+		source:     source,
+		repo:       repo,
+		interval:   interval,
+		activeDays: activeDays,
+		logger:     logger,
+	}
+}
+
+// OnChange adds a handler for detected changes. Handlers run in the order
+// added and must not block for long.
+func (c *MetadataCache) OnChange(handler MetadataChangeHandler) {
+	c.handlers = append(c.handlers, handler)
+}
+
+func (c *MetadataCache) Get(ctx context.Context, sessionID uuid.UUID, groupJID string) (*Metadata, error) {
+	return c.repo.Get(ctx, sessionID, groupJID)
+}
+
+// Observe applies a name or topic change WhatsApp announced for the group.
+// A nil name or topic leaves it as cached. The change is reported even for
+// groups not cached yet, but not when the cache already has those values.
+func (c *MetadataCache) Observe(ctx context.Context, sessionID uuid.UUID, sessionName, groupJID string, name, topic *string, at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	previous := c.cached(ctx, sessionID, groupJID)
+	metadata := &Metadata{SessionID: sessionID, GroupJID: groupJID}
+	if previous != nil {
+		*metadata = *previous
+	}
+	if name != nil {
+		metadata.Name = *name
+	}
+	if topic != nil {
+		metadata.Topic = *topic
+	}
+
+	if previous != nil && previous.Name == metadata.Name && previous.Topic == metadata.Topic {
+		return
+	}
+
+	metadata.CheckedAt = time.Now()
+	metadata.ChangedAt = at
+	c.save(ctx, metadata)
+	c.notify(ctx, sessionName, previous, metadata, false)
+}
+
+// Refresh asks WhatsApp for the group's metadata and caches it. A name or
+// topic different from the cached one is reported; the first lookup of a
+// group reports nothing.
+func (c *MetadataCache) Refresh(ctx context.Context, sessionID uuid.UUID, sessionName, groupJID string) (*Metadata, error) {
+	fetched, err := c.source.FetchGroupMetadata(ctx, sessionName, groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh group metadata: %w", err)
+	}
+
+	now := time.Now()
+	previous := c.cached(ctx, sessionID, groupJID)
+	metadata := &Metadata{
+		SessionID:        sessionID,
+		GroupJID:         groupJID,
+		Name:             fetched.Name,
+		Topic:            fetched.Topic,
+		ParticipantCount: fetched.ParticipantCount,
+		CheckedAt:        now,
+		ChangedAt:        now,
+	}
+
+	changed := previous != nil && (previous.Name != metadata.Name || previous.Topic != metadata.Topic)
+	if previous != nil && !changed {
+		metadata.ChangedAt = previous.ChangedAt
+	}
+
+	c.save(ctx, metadata)
+	if changed {
+		c.notify(ctx, sessionName, previous, metadata, true)
+	}
+
+	return metadata, nil
+}
+
+// Forget drops a group the session left or that was deleted.
+func (c *MetadataCache) Forget(ctx context.Context, sessionID uuid.UUID, groupJID string) {
+	if err := c.repo.Delete(ctx, sessionID, groupJID); err != nil {
+		c.logger.WarnWithFields("Failed to forget group metadata", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"group_jid":  groupJID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// Start refreshes active groups now and then every interval until ctx is
+// cancelled. It does nothing when the refresh is off.
+func (c *MetadataCache) Start(ctx context.Context) {
+	if c.interval <= 0 || c.activeDays <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			refreshed, err := c.RefreshActive(ctx, time.Now())
+			if err != nil {
+				c.logger.ErrorWithFields("Group metadata refresh failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else if refreshed > 0 {
+				c.logger.InfoWithFields("Group metadata refreshed", map[string]interface{}{
+					"groups": refreshed,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RefreshActive refreshes the groups active in the last activeDays days
+// that were not checked within the interval, one per second so a session
+// in many groups does not flood WhatsApp. Groups that fail are retried on
+// the next run.
+func (c *MetadataCache) RefreshActive(ctx context.Context, now time.Time) (int, error) {
+	groups, err := c.repo.ListStale(ctx, now.AddDate(0, 0, -c.activeDays), now.Add(-c.interval), metadataRefreshBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active groups: %w", err)
+	}
+
+	refreshed := 0
+	for i, active := range groups {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return refreshed, ctx.Err()
+			case <-time.After(metadataRefreshSpacing):
+			}
+		}
+
+		if _, err := c.Refresh(ctx, active.SessionID, active.SessionName, active.GroupJID); err != nil {
+			c.logger.DebugWithFields("Failed to refresh active group", map[string]interface{}{
+				"session_id": active.SessionID.String(),
+				"group_jid":  active.GroupJID,
+				"error":      err.Error(),
+			})
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+func (c *MetadataCache) cached(ctx context.Context, sessionID uuid.UUID, groupJID string) *Metadata {
+	metadata, err := c.repo.Get(ctx, sessionID, groupJID)
+	if err != nil {
+		if !errors.Is(err, ErrMetadataNotFound) {
+			c.logger.WarnWithFields("Failed to read group metadata cache", map[string]interface{}{
+				"session_id": sessionID.String(),
+				"group_jid":  groupJID,
+				"error":      err.Error(),
+			})
+		}
+		return nil
+	}
+	return metadata
+}
+
+func (c *MetadataCache) save(ctx context.Context, metadata *Metadata) {
+	if err := c.repo.Save(ctx, metadata); err != nil {
+		c.logger.WarnWithFields("Failed to cache group metadata", map[string]interface{}{
+			"session_id": metadata.SessionID.String(),
+			"group_jid":  metadata.GroupJID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (c *MetadataCache) notify(ctx context.Context, sessionName string, previous, metadata *Metadata, refreshed bool) {
+	change := &MetadataChange{
+		SessionID: metadata.SessionID,
+		GroupJID:  metadata.GroupJID,
+		Name:      metadata.Name,
+		Topic:     metadata.Topic,
+		Refreshed: refreshed,
+		ChangedAt: metadata.ChangedAt,
+	}
+	if previous != nil {
+		change.PreviousName = previous.Name
+		change.PreviousTopic = previous.Topic
+	}
+
+	for _, handler := range c.handlers {
+		handler(ctx, sessionName, change)
+	}
+}
@@ -33,10 +33,108 @@ type GroupSettings struct {
 	MemberAddMode string `json:"member_add_mode"`
 
 	Locked bool `json:"locked"`
+
+	DisappearingTimer uint32 `json:"disappearing_timer"`
+}
+
+const (
+	MemberAddModeAllMembers = "all_members"
+	MemberAddModeOnlyAdmins = "only_admins"
+
+	JoinApprovalModeAuto          = "auto"
+	JoinApprovalModeAdminApproval = "admin_approval"
+)
+
+const (
+	DisappearingTimerOff uint32 = 0
+	DisappearingTimer24h uint32 = 24 * 60 * 60
+	DisappearingTimer7d  uint32 = 7 * 24 * 60 * 60
+	DisappearingTimer90d uint32 = 90 * 24 * 60 * 60
+)
+
+type BulkAddStatus string
+
+const (
+	BulkAddStatusPending       BulkAddStatus = "pending"
+	BulkAddStatusAdded         BulkAddStatus = "added"
+	BulkAddStatusAlreadyMember BulkAddStatus = "already_member"
+	BulkAddStatusNeedsInvite   BulkAddStatus = "needs_invite_link"
+	BulkAddStatusNotOnWhatsApp BulkAddStatus = "not_on_whatsapp"
+	BulkAddStatusFailed        BulkAddStatus = "failed"
+)
+
+type BulkJobStatus string
+
+const (
+	BulkJobStatusRunning   BulkJobStatus = "running"
+	BulkJobStatusCompleted BulkJobStatus = "completed"
+)
+
+const (
+	MaxBulkParticipants      = 5000
+	DefaultBulkAddBatchSize  = 20
+	MaxBulkAddBatchSize      = 50
+	DefaultBulkAddBatchDelay = 10 * time.Second
+	BulkLookupBatchSize      = 250
+	BulkImportJobRetention   = time.Hour
+)
+
+type ParticipantAddResult struct {
+	PhoneNumber      string        `json:"phone_number,omitempty"`
+	JID              string        `json:"jid,omitempty"`
+	Status           BulkAddStatus `json:"status"`
+	ErrorCode        int           `json:"error_code,omitempty"`
+	Error            string        `json:"error,omitempty"`
+	InviteCode       string        `json:"invite_code,omitempty"`
+	InviteExpiration *time.Time    `json:"invite_expiration,omitempty"`
+	InvitedViaLink   bool          `json:"invited_via_link,omitempty"`
+	InviteError      string        `json:"invite_error,omitempty"`
+}
+
+// DefaultInviteMessage opens the direct message that carries the group
+// invite link when no message of its own is given.
+const DefaultInviteMessage = "I couldn't add you to the WhatsApp group because of your privacy settings. You can join it with this link:"
+
+// InviteFallback asks for the group invite link to be sent by direct message
+// to participants whose privacy settings keep them from being added.
+type InviteFallback struct {
+	Message string
+}
+
+// Text is the direct message carrying link.
+func (f *InviteFallback) Text(link string) string {
+	message := f.Message
+	if message == "" {
+		message = DefaultInviteMessage
+	}
+	return message + "\n\n" + link
+}
+
+type BulkImportJob struct {
+	ID          string                 `json:"id"`
+	SessionID   string                 `json:"session_id"`
+	GroupJID    string                 `json:"group_jid"`
+	Status      BulkJobStatus          `json:"status"`
+	Total       int                    `json:"total"`
+	Processed   int                    `json:"processed"`
+	Results     []ParticipantAddResult `json:"results"`
+	CreatedAt   time.Time              `json:"created_at"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+}
+
+func (j *BulkImportJob) Summary() map[BulkAddStatus]int {
+	summary := make(map[BulkAddStatus]int)
+	for _, result := range j.Results {
+		summary[result.Status]++
+	}
+	return summary
 }
 
+// Participant is a group member. PhoneJID is the member's phone number JID
+// when WhatsApp identifies them by LID in JID.
 type Participant struct {
 	JID      string            `json:"jid"`
+	PhoneJID string            `json:"phone_jid,omitempty"`
 	Role     ParticipantRole   `json:"role"`
 	JoinedAt time.Time         `json:"joined_at"`
 	AddedBy  string            `json:"added_by,omitempty"`
@@ -0,0 +1,97 @@
+package group
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// GroupPhotoSize is the edge of the square JPEG WhatsApp stores for group
+	// pictures; larger uploads are scaled down to it.
+	GroupPhotoSize     = 640
+	MinGroupPhotoSize  = 192
+	MaxGroupPhotoBytes = 10 << 20
+
+	groupPhotoQuality = 90
+)
+
+// PreparePhoto turns an uploaded image into what WhatsApp accepts as a group
+// picture: a centred square crop, at most GroupPhotoSize wide, encoded as JPEG.
+func PreparePhoto(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: image is empty", ErrInvalidGroupPhoto)
+	}
+	if len(data) > MaxGroupPhotoBytes {
+		return nil, fmt.Errorf("%w: image exceeds %d MB", ErrInvalidGroupPhoto, MaxGroupPhotoBytes>>20)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unsupported or corrupt image (use JPEG, PNG or GIF)", ErrInvalidGroupPhoto)
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	if side < MinGroupPhotoSize {
+		return nil, fmt.Errorf("%w: image must be at least %dx%d pixels", ErrInvalidGroupPhoto, MinGroupPhotoSize, MinGroupPhotoSize)
+	}
+
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := scaleSquare(src, crop, min(side, GroupPhotoSize))
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: groupPhotoQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode group photo: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scaleSquare resamples the crop area of src into a size x size image by
+// averaging every source pixel that falls into each destination pixel. This
+// only ever shrinks, where a box filter gives clean results without pulling in
+// an imaging dependency. Transparent areas are flattened onto white.
+func scaleSquare(src image.Image, crop image.Rectangle, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	side := crop.Dx()
+
+	for y := 0; y < size; y++ {
+		y0 := crop.Min.Y + y*side/size
+		y1 := max(crop.Min.Y+(y+1)*side/size, y0+1)
+
+		for x := 0; x < size; x++ {
+			x0 := crop.Min.X + x*side/size
+			x1 := max(crop.Min.X+(x+1)*side/size, x0+1)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					white := uint64(0xffff - pa)
+					r += uint64(pr) + white
+					g += uint64(pg) + white
+					b += uint64(pb) + white
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return dst
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 )
 
+var nonDigitPattern = regexp.MustCompile(`[^0-9]`)
+
 type service struct {
 	validator Validator
 }
@@ -33,6 +35,34 @@ func (s *service) ValidateGroupCreation(req *CreateGroupRequest) error {
 		return err
 	}
 
+	if req.Settings != nil {
+		if err := s.validateCreateSettings(req.Settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) validateCreateSettings(settings *CreateGroupSettings) error {
+	switch settings.DisappearingTimer {
+	case DisappearingTimerOff, DisappearingTimer24h, DisappearingTimer7d, DisappearingTimer90d:
+	default:
+		return fmt.Errorf("invalid disappearing timer: %d (allowed: 0, 86400, 604800, 7776000)", settings.DisappearingTimer)
+	}
+
+	switch settings.MemberAddMode {
+	case "", MemberAddModeAllMembers, MemberAddModeOnlyAdmins:
+	default:
+		return fmt.Errorf("invalid member add mode: %s", settings.MemberAddMode)
+	}
+
+	switch settings.JoinApprovalMode {
+	case "", JoinApprovalModeAuto, JoinApprovalModeAdminApproval:
+	default:
+		return fmt.Errorf("invalid join approval mode: %s", settings.JoinApprovalMode)
+	}
+
 	return nil
 }
 
@@ -121,6 +151,29 @@ func (s *service) ValidateJID(jid string) error {
 	return nil
 }
 
+// NormalizePhoneNumbers strips formatting characters and drops duplicates,
+// splitting the input into numbers that look dialable and those that don't.
+func (s *service) NormalizePhoneNumbers(phoneNumbers []string) ([]string, []string) {
+	valid := make([]string, 0, len(phoneNumbers))
+	var invalid []string
+	seen := make(map[string]bool, len(phoneNumbers))
+
+	for _, phone := range phoneNumbers {
+		digits := nonDigitPattern.ReplaceAllString(s.ExtractPhoneNumber(phone), "")
+		if len(digits) < 8 || len(digits) > 15 {
+			invalid = append(invalid, phone)
+			continue
+		}
+		if seen[digits] {
+			continue
+		}
+		seen[digits] = true
+		valid = append(valid, digits)
+	}
+
+	return valid, invalid
+}
+
 func (s *service) CanPerformAction(userJID, groupJID string, action GroupAction, groupInfo *GroupInfo) error {
 	if groupInfo == nil {
 		return fmt.Errorf("group not found")
@@ -0,0 +1,8 @@
+package inbound
+
+import "errors"
+
+var (
+	ErrStageNotFound = errors.New("pipeline stage not found")
+	ErrStageExists   = errors.New("pipeline stage already registered")
+)
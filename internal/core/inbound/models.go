@@ -0,0 +1,34 @@
+package inbound
+
+import "time"
+
+// Event is one WhatsApp event on its way through the pipeline. Payload is
+// the whatsmeow event (or one of the gateway's own events) as received.
+// Annotations are what stages add about it for later ones; message webhooks
+// carry them.
+type Event struct {
+	SessionID   string
+	SessionName string
+	Payload     interface{}
+	ReceivedAt  time.Time
+	Annotations map[string]interface{}
+}
+
+// Annotate records value under key for the stages after this one.
+func (e *Event) Annotate(key string, value interface{}) {
+	if e.Annotations == nil {
+		e.Annotations = make(map[string]interface{})
+	}
+	e.Annotations[key] = value
+}
+
+// StageStats counts what a stage did since startup.
+type StageStats struct {
+	Name          string        `json:"name"`
+	Processed     int64         `json:"processed"`
+	Stopped       int64         `json:"stopped"`
+	Failed        int64         `json:"failed"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	MaxDuration   time.Duration `json:"maxDuration"`
+	LastError     string        `json:"lastError,omitempty"`
+}
@@ -0,0 +1,179 @@
+package inbound
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"zpwoot/platform/logger"
+)
+
+// Stage is one step of inbound processing. Returning false stops the event
+// from reaching later stages; an error is logged and counted but does not.
+type Stage interface {
+	Name() string
+	Process(ctx context.Context, event *Event) (bool, error)
+}
+
+type stageFunc struct {
+	name string
+	fn   func(ctx context.Context, event *Event) (bool, error)
+}
+
+func (s *stageFunc) Name() string { return s.name }
+
+func (s *stageFunc) Process(ctx context.Context, event *Event) (bool, error) {
+	return s.fn(ctx, event)
+}
+
+// NewStage wraps a function as a Stage.
+func NewStage(name string, fn func(ctx context.Context, event *Event) (bool, error)) Stage {
+	return &stageFunc{name: name, fn: fn}
+}
+
+type entry struct {
+	stage Stage
+
+	mu    sync.Mutex
+	stats StageStats
+}
+
+// Pipeline runs every inbound event through an ordered list of stages and
+// keeps per-stage counters. Stages can be added while events are flowing.
+type Pipeline struct {
+	logger *logger.Logger
+
+	mu      sync.RWMutex
+	entries []*entry
+}
+
+func NewPipeline(logger *logger.Logger) *Pipeline {
+	return &Pipeline{logger: logger}
+}
+
+// Use appends a stage to the end of the pipeline.
+func (p *Pipeline) Use(stage Stage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.indexLocked(stage.Name()) >= 0 {
+		return fmt.Errorf("%w: %s", ErrStageExists, stage.Name())
+	}
+
+	p.entries = append(p.entries, newEntry(stage))
+	return nil
+}
+
+// InsertBefore adds a stage right before the named one, e.g. a rules stage
+// that must run before events are forwarded to Chatwoot.
+func (p *Pipeline) InsertBefore(before string, stage Stage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.indexLocked(stage.Name()) >= 0 {
+		return fmt.Errorf("%w: %s", ErrStageExists, stage.Name())
+	}
+
+	i := p.indexLocked(before)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrStageNotFound, before)
+	}
+
+	entries := make([]*entry, 0, len(p.entries)+1)
+	entries = append(entries, p.entries[:i]...)
+	entries = append(entries, newEntry(stage))
+	entries = append(entries, p.entries[i:]...)
+	p.entries = entries
+
+	return nil
+}
+
+func (p *Pipeline) indexLocked(name string) int {
+	for i, e := range p.entries {
+		if e.stage.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func newEntry(stage Stage) *entry {
+	return &entry{stage: stage, stats: StageStats{Name: stage.Name()}}
+}
+
+// Run passes event through the stages in order until one stops it.
+func (p *Pipeline) Run(ctx context.Context, event *Event) {
+	p.mu.RLock()
+	entries := p.entries
+	p.mu.RUnlock()
+
+	for _, e := range entries {
+		if !p.runStage(ctx, e, event) {
+			return
+		}
+	}
+}
+
+func (p *Pipeline) runStage(ctx context.Context, e *entry, event *Event) (cont bool) {
+	start := time.Now()
+	var err error
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			cont = true
+		}
+
+		e.record(time.Since(start), cont, err)
+		if err != nil {
+			p.logger.ErrorWithFields("Inbound pipeline stage failed", map[string]interface{}{
+				"stage":      e.stage.Name(),
+				"session_id": event.SessionID,
+				"event_type": fmt.Sprintf("%T", event.Payload),
+				"error":      err.Error(),
+			})
+		}
+	}()
+
+	cont, err = e.stage.Process(ctx, event)
+	if err != nil {
+		cont = true
+	}
+
+	return cont
+}
+
+func (e *entry) record(elapsed time.Duration, cont bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stats.Processed++
+	e.stats.TotalDuration += elapsed
+	if elapsed > e.stats.MaxDuration {
+		e.stats.MaxDuration = elapsed
+	}
+	if !cont {
+		e.stats.Stopped++
+	}
+	if err != nil {
+		e.stats.Failed++
+		e.stats.LastError = err.Error()
+	}
+}
+
+// Stats returns the counters of every stage in pipeline order.
+func (p *Pipeline) Stats() []StageStats {
+	p.mu.RLock()
+	entries := p.entries
+	p.mu.RUnlock()
+
+	stats := make([]StageStats, len(entries))
+	for i, e := range entries {
+		e.mu.Lock()
+		stats[i] = e.stats
+		e.mu.Unlock()
+	}
+
+	return stats
+}
@@ -0,0 +1,24 @@
+package label
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	List(ctx context.Context, sessionID uuid.UUID, includeDeleted bool) ([]*Label, error)
+	Get(ctx context.Context, sessionID uuid.UUID, labelID string) (*Label, error)
+	Upsert(ctx context.Context, label *Label) error
+
+	UpsertAssociation(ctx context.Context, association *Association) error
+	DeleteAssociation(ctx context.Context, association *Association) error
+	ListAssociations(ctx context.Context, sessionID uuid.UUID, labelID string) ([]*Association, error)
+}
+
+// Gateway writes label changes to the WhatsApp app state, so they show up on
+// the phone and every other linked device.
+type Gateway interface {
+	EditLabel(ctx context.Context, sessionName string, label *Label) error
+	SetLabelAssociation(ctx context.Context, sessionName string, association *Association, labeled bool) error
+}
@@ -0,0 +1,8 @@
+package label
+
+import "errors"
+
+var (
+	ErrLabelNotFound = errors.New("label not found")
+	ErrInvalidLabel  = errors.New("invalid label")
+)
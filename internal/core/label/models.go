@@ -0,0 +1,37 @@
+package label
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxColor is the highest color index the WhatsApp Business apps render.
+const MaxColor = 19
+
+// Label is a WhatsApp Business label. LabelID is the identifier WhatsApp
+// uses in the app state; the apps number them, starting with the predefined
+// labels every business account gets.
+type Label struct {
+	SessionID uuid.UUID
+	LabelID   string
+	Name      string
+	Color     int32
+	Deleted   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Association links a label to a chat or, when MessageID is set, to a single
+// message in that chat.
+type Association struct {
+	SessionID uuid.UUID
+	LabelID   string
+	ChatJID   string
+	MessageID string
+	CreatedAt time.Time
+}
+
+func (a *Association) IsMessage() bool {
+	return a.MessageID != ""
+}
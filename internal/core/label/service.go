@@ -0,0 +1,153 @@
+package label
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// Service keeps the local copy of a session's labels. Changes made through
+// the API and changes synced from other devices both end up here, so the
+// table mirrors what the WhatsApp Business app shows.
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+func (s *Service) List(ctx context.Context, sessionID uuid.UUID) ([]*Label, error) {
+	labels, err := s.repository.List(ctx, sessionID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (s *Service) Get(ctx context.Context, sessionID uuid.UUID, labelID string) (*Label, error) {
+	label, err := s.repository.Get(ctx, sessionID, labelID)
+	if err != nil {
+		return nil, err
+	}
+	if label.Deleted {
+		return nil, ErrLabelNotFound
+	}
+
+	return label, nil
+}
+
+// Prepare builds a new label with the next free ID. Deleted labels keep
+// their ID reserved, because the phone may still hold associations for them.
+func (s *Service) Prepare(ctx context.Context, sessionID uuid.UUID, name string, color int32) (*Label, error) {
+	if err := validate(name, color); err != nil {
+		return nil, err
+	}
+
+	labels, err := s.repository.List(ctx, sessionID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	next := 1
+	for _, existing := range labels {
+		if n, err := strconv.Atoi(existing.LabelID); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	now := time.Now()
+	return &Label{
+		SessionID: sessionID,
+		LabelID:   strconv.Itoa(next),
+		Name:      strings.TrimSpace(name),
+		Color:     color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Save records a label as WhatsApp now knows it, creating it if needed.
+func (s *Service) Save(ctx context.Context, label *Label) error {
+	now := time.Now()
+	if label.CreatedAt.IsZero() {
+		label.CreatedAt = now
+	}
+	label.UpdatedAt = now
+
+	if err := s.repository.Upsert(ctx, label); err != nil {
+		return fmt.Errorf("failed to save label: %w", err)
+	}
+
+	return nil
+}
+
+// Edit applies a rename or recolor to an existing label. Nil fields keep
+// their current value.
+func (s *Service) Edit(ctx context.Context, sessionID uuid.UUID, labelID string, name *string, color *int32) (*Label, error) {
+	label, err := s.Get(ctx, sessionID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	edited := *label
+	if name != nil {
+		edited.Name = strings.TrimSpace(*name)
+	}
+	if color != nil {
+		edited.Color = *color
+	}
+	if err := validate(edited.Name, edited.Color); err != nil {
+		return nil, err
+	}
+
+	return &edited, nil
+}
+
+// SetAssociation records whether a label is applied to a chat or message.
+func (s *Service) SetAssociation(ctx context.Context, association *Association, labeled bool) error {
+	if !labeled {
+		return s.repository.DeleteAssociation(ctx, association)
+	}
+
+	if association.CreatedAt.IsZero() {
+		association.CreatedAt = time.Now()
+	}
+
+	return s.repository.UpsertAssociation(ctx, association)
+}
+
+func (s *Service) ListAssociations(ctx context.Context, sessionID uuid.UUID, labelID string) ([]*Association, error) {
+	if _, err := s.Get(ctx, sessionID, labelID); err != nil {
+		return nil, err
+	}
+
+	associations, err := s.repository.ListAssociations(ctx, sessionID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list label associations: %w", err)
+	}
+
+	return associations, nil
+}
+
+func validate(name string, color int32) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidLabel)
+	}
+	if color < 0 || color > MaxColor {
+		return fmt.Errorf("%w: color must be between 0 and %d", ErrInvalidLabel, MaxColor)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package linktrack
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateLinks(ctx context.Context, links []*Link) error
+	// AttachMessage records the WhatsApp message the links went out in.
+	AttachMessage(ctx context.Context, sessionID uuid.UUID, codes []string, messageID string) error
+	GetLink(ctx context.Context, code string) (*Link, error)
+
+	RecordClick(ctx context.Context, click *Click) error
+	Stats(ctx context.Context, filter *Filter) ([]*LinkStats, error)
+	ListClicks(ctx context.Context, filter *Filter, limit int) ([]*Click, error)
+}
@@ -0,0 +1,8 @@
+package linktrack
+
+import "errors"
+
+var (
+	ErrLinkNotFound    = errors.New("tracked link not found")
+	ErrInvalidTracking = errors.New("validation failed: invalid link tracking")
+)
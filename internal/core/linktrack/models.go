@@ -0,0 +1,67 @@
+package linktrack
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RedirectPath is where tracked links point, under the tracking domain.
+const RedirectPath = "/l/"
+
+const (
+	MaxCampaignLength = 100
+	DefaultClickLimit = 100
+	MaxClickLimit     = 1000
+)
+
+// Link stands in for one URL of an outbound message to one recipient, so
+// a click tells who followed which link. MessageID is empty until the
+// message is sent.
+type Link struct {
+	Code      string
+	SessionID uuid.UUID
+	MessageID string
+	ChatJID   string
+	Campaign  string
+	URL       string
+	CreatedAt time.Time
+}
+
+// Click is one follow of a tracked link. ChatJID is the recipient the link
+// was sent to, not necessarily whoever opened it: links can be forwarded.
+type Click struct {
+	Code      string
+	MessageID string
+	ChatJID   string
+	Campaign  string
+	URL       string
+	ClickedAt time.Time
+	IP        string
+	UserAgent string
+}
+
+// LinkStats counts the clicks on one tracked link.
+type LinkStats struct {
+	Link         Link
+	Clicks       int
+	FirstClickAt *time.Time
+	LastClickAt  *time.Time
+}
+
+// Filter selects a session's tracked links, by message, campaign or both.
+type Filter struct {
+	SessionID uuid.UUID
+	MessageID string
+	Campaign  string
+}
+
+// Stats sums up the tracked links a filter selects. Recipients counts the
+// chats the links were sent to and Clicked those with at least one click.
+type Stats struct {
+	Links      int
+	Clicks     int
+	Recipients int
+	Clicked    int
+	PerLink    []*LinkStats
+}
@@ -0,0 +1,202 @@
+package linktrack
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// urlRe finds the http and https URLs of a message. Punctuation closing a
+// sentence or WhatsApp formatting around a URL is trimmed off afterwards.
+var urlRe = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// Service rewrites the links of outbound messages to tracked redirects and
+// counts the clicks on them.
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+// Rewrite replaces every URL of text with a link to base, the tracking
+// domain, returning the new text and the links to store before it is sent.
+// The same URL twice in a text shares a link; links already pointing at
+// base are left alone.
+func (s *Service) Rewrite(sessionID uuid.UUID, chatJID, campaign, base, text string) (string, []*Link, error) {
+	campaign = strings.TrimSpace(campaign)
+	if len(campaign) > MaxCampaignLength {
+		return "", nil, fmt.Errorf("%w: campaign must have at most %d characters", ErrInvalidTracking, MaxCampaignLength)
+	}
+
+	prefix := strings.TrimRight(base, "/") + RedirectPath
+	now := time.Now()
+
+	var (
+		links []*Link
+		byURL = make(map[string]*Link)
+		out   strings.Builder
+		last  int
+	)
+	for _, loc := range urlRe.FindAllStringIndex(text, -1) {
+		raw := trimURL(text[loc[0]:loc[1]])
+		if strings.HasPrefix(raw, prefix) {
+			continue
+		}
+
+		link, ok := byURL[raw]
+		if !ok {
+			code, err := newCode()
+			if err != nil {
+				return "", nil, err
+			}
+			link = &Link{
+				Code:      code,
+				SessionID: sessionID,
+				ChatJID:   chatJID,
+				Campaign:  campaign,
+				URL:       raw,
+				CreatedAt: now,
+			}
+			byURL[raw] = link
+			links = append(links, link)
+		}
+
+		out.WriteString(text[last:loc[0]])
+		out.WriteString(prefix + link.Code)
+		last = loc[0] + len(raw)
+	}
+	out.WriteString(text[last:])
+
+	return out.String(), links, nil
+}
+
+// Track stores links made by Rewrite so they redirect once sent.
+func (s *Service) Track(ctx context.Context, links []*Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+	if err := s.repository.CreateLinks(ctx, links); err != nil {
+		return fmt.Errorf("failed to track links: %w", err)
+	}
+	return nil
+}
+
+// Attach records the message links went out in, so their clicks can be
+// looked up by message.
+func (s *Service) Attach(ctx context.Context, links []*Link, messageID string) error {
+	if len(links) == 0 || messageID == "" {
+		return nil
+	}
+
+	codes := make([]string, len(links))
+	for i, link := range links {
+		link.MessageID = messageID
+		codes[i] = link.Code
+	}
+
+	return s.repository.AttachMessage(ctx, links[0].SessionID, codes, messageID)
+}
+
+// Follow returns the link behind code and records the click. A click that
+// cannot be recorded is logged and the redirect still happens.
+func (s *Service) Follow(ctx context.Context, code, ip, userAgent string) (*Link, error) {
+	link, err := s.repository.GetLink(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	click := &Click{
+		Code:      link.Code,
+		ClickedAt: time.Now(),
+		IP:        ip,
+		UserAgent: userAgent,
+	}
+	if err := s.repository.RecordClick(ctx, click); err != nil {
+		s.logger.WarnWithFields("Failed to record link click", map[string]interface{}{
+			"code":  code,
+			"error": err.Error(),
+		})
+	}
+
+	return link, nil
+}
+
+// Stats counts the clicks on the links filter selects.
+func (s *Service) Stats(ctx context.Context, filter *Filter) (*Stats, error) {
+	perLink, err := s.repository.Stats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link stats: %w", err)
+	}
+
+	stats := &Stats{Links: len(perLink), PerLink: perLink}
+	recipients := make(map[string]bool)
+	for _, link := range perLink {
+		stats.Clicks += link.Clicks
+		clicked := recipients[link.Link.ChatJID]
+		recipients[link.Link.ChatJID] = clicked || link.Clicks > 0
+	}
+	stats.Recipients = len(recipients)
+	for _, clicked := range recipients {
+		if clicked {
+			stats.Clicked++
+		}
+	}
+
+	return stats, nil
+}
+
+// Clicks returns the latest clicks on the links filter selects, newest
+// first.
+func (s *Service) Clicks(ctx context.Context, filter *Filter, limit int) ([]*Click, error) {
+	if limit <= 0 {
+		limit = DefaultClickLimit
+	}
+	if limit > MaxClickLimit {
+		limit = MaxClickLimit
+	}
+
+	clicks, err := s.repository.ListClicks(ctx, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list link clicks: %w", err)
+	}
+
+	return clicks, nil
+}
+
+// trimURL drops what ends a sentence or closes WhatsApp formatting from the
+// end of a URL. A closing parenthesis stays when the URL opened one.
+func trimURL(raw string) string {
+	for raw != "" {
+		last := raw[len(raw)-1]
+		if strings.IndexByte(".,;:!?'*_~", last) >= 0 || (last == ')' && !strings.Contains(raw, "(")) {
+			raw = raw[:len(raw)-1]
+			continue
+		}
+		break
+	}
+	return raw
+}
+
+// newCode makes a link code. Hex keeps WhatsApp from reading part of it as
+// formatting, which base64's underscores could be.
+func newCode() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate link code: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
+)
+
+var ErrInvalidChatSort = errors.New("chat sort must be recent or unread")
+
+// ChatSort orders the chat list of a session.
+type ChatSort string
+
+const (
+	// ChatSortRecent lists the chats with the latest message first.
+	ChatSortRecent ChatSort = "recent"
+	// ChatSortUnread lists the chats with the most unread messages first,
+	// then by latest message.
+	ChatSortUnread ChatSort = "unread"
+)
+
+func ParseChatSort(value string) (ChatSort, error) {
+	switch ChatSort(value) {
+	case "", ChatSortRecent:
+		return ChatSortRecent, nil
+	case ChatSortUnread:
+		return ChatSortUnread, nil
+	default:
+		return "", ErrInvalidChatSort
+	}
+}
+
+// Chat is the inbox entry of a conversation. UnreadCount counts the
+// messages contacts sent after the chat was last read, from the API or
+// any linked device.
+type Chat struct {
+	SessionID     uuid.UUID
+	ChatJID       string
+	UnreadCount   int
+	LastMessageID string
+	LastMessageAt time.Time
+	LastFromMe    bool
+	LastReadAt    *time.Time
+}
+
+// ReadGateway sends read receipts for messages of a chat.
+type ReadGateway interface {
+	MarkRead(ctx context.Context, sessionName, chatJID, senderJID string, messageIDs []string, at time.Time) error
+}
+
+// MarkChatRead clears the chat's unread count. Messages received before at
+// no longer count as unread when they are delivered late.
+func (s *Service) MarkChatRead(ctx context.Context, sessionID uuid.UUID, chatJID string, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if err := s.repository.MarkChatRead(ctx, sessionID, chatJID, at); err != nil {
+		return fmt.Errorf("failed to mark chat as read: %w", err)
+	}
+
+	return nil
+}
+
+// ListChats returns a page of the session's chats in the given order along
+// with the total number of chats.
+func (s *Service) ListChats(ctx context.Context, sessionID uuid.UUID, sort ChatSort, limit, offset int) ([]*Chat, int64, error) {
+	limit = pagination.ClampLimit(limit)
+
+	chats, err := s.repository.ListChats(ctx, sessionID, sort, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list chats: %w", err)
+	}
+
+	total, err := s.repository.CountChats(ctx, sessionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count chats: %w", err)
+	}
+
+	return chats, total, nil
+}
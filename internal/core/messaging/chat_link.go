@@ -0,0 +1,19 @@
+package messaging
+
+import (
+	"net/url"
+	"strings"
+)
+
+const chatLinkBase = "https://wa.me/"
+
+// ChatLink is the click-to-chat link that opens a chat with phone, with
+// text typed in the input box when it is not empty. wa.me reads "+" in the
+// text literally, so spaces are escaped as %20.
+func ChatLink(phone, text string) string {
+	link := chatLinkBase + phone
+	if text == "" {
+		return link
+	}
+	return link + "?text=" + strings.ReplaceAll(url.QueryEscape(text), "+", "%20")
+}
@@ -2,8 +2,11 @@ package messaging
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
 )
 
 type Repository interface {
@@ -21,8 +24,32 @@ type Repository interface {
 	List(ctx context.Context, limit, offset int) ([]*Message, error)
 	ListBySession(ctx context.Context, sessionID uuid.UUID, limit, offset int) ([]*Message, error)
 	ListByChat(ctx context.Context, sessionID uuid.UUID, chatJID string, limit, offset int) ([]*Message, error)
+	ListAfter(ctx context.Context, sessionID uuid.UUID, chatJID string, after *pagination.Cursor, limit int) ([]*Message, error)
+	Search(ctx context.Context, req *SearchMessagesRequest) ([]*SearchResult, int64, error)
 	ListBySyncStatus(ctx context.Context, status SyncStatus, limit, offset int) ([]*Message, error)
 
+	UpsertReaction(ctx context.Context, reaction *Reaction) error
+	DeleteReaction(ctx context.Context, sessionID uuid.UUID, zpMessageID, reactorJID string) error
+	ListReactions(ctx context.Context, sessionID uuid.UUID, zpMessageID string) ([]*Reaction, error)
+
+	UpsertPoll(ctx context.Context, poll *Poll) error
+	GetPoll(ctx context.Context, sessionID uuid.UUID, zpMessageID string) (*Poll, error)
+	UpsertPollVote(ctx context.Context, vote *PollVote) error
+	ListPollVotes(ctx context.Context, sessionID uuid.UUID, zpMessageID string) ([]*PollVote, error)
+
+	InsertLiveLocation(ctx context.Context, point *LiveLocationPoint) error
+	ListLiveLocations(ctx context.Context, sessionID uuid.UUID, chatJID string, perSharer int) ([]*LiveLocationPoint, error)
+
+	UpsertStar(ctx context.Context, star *StarredMessage) error
+	DeleteStar(ctx context.Context, sessionID uuid.UUID, zpMessageID string) error
+	ListStarred(ctx context.Context, sessionID uuid.UUID, after *pagination.Cursor, limit int) ([]*StarredMessage, error)
+	ListByZpMessageIDs(ctx context.Context, sessionID uuid.UUID, zpMessageIDs []string) ([]*Message, error)
+
+	RecordChatMessage(ctx context.Context, message *Message) error
+	MarkChatRead(ctx context.Context, sessionID uuid.UUID, chatJID string, at time.Time) error
+	ListChats(ctx context.Context, sessionID uuid.UUID, sort ChatSort, limit, offset int) ([]*Chat, error)
+	CountChats(ctx context.Context, sessionID uuid.UUID) (int64, error)
+
 	UpdateSyncStatus(ctx context.Context, id uuid.UUID, status SyncStatus, cwMessageID, cwConversationID *int) error
 	GetPendingSyncMessages(ctx context.Context, sessionID uuid.UUID, limit int) ([]*Message, error)
 	GetFailedSyncMessages(ctx context.Context, sessionID uuid.UUID, limit int) ([]*Message, error)
@@ -65,6 +92,18 @@ type MessageService interface {
 	ReprocessFailedMessages(ctx context.Context, sessionID uuid.UUID) (int64, error)
 }
 
+// MediaFetcher returns the media of a stored message, downloading it from
+// WhatsApp when no local copy exists. The returned path is where it was kept.
+type MediaFetcher interface {
+	FetchMedia(ctx context.Context, sessionName string, message *Message) (data []byte, localPath string, err error)
+}
+
+// StarGateway stars or unstars a message through the WhatsApp app state, so
+// the change shows up on every linked device.
+type StarGateway interface {
+	StarMessage(ctx context.Context, sessionName string, star *StarredMessage, starred bool) error
+}
+
 type MessageGateway interface {
 	SendTextMessage(ctx context.Context, sessionID uuid.UUID, to, content string) (*Message, error)
 	SendMediaMessage(ctx context.Context, sessionID uuid.UUID, to, mediaURL, caption string, mediaType MessageType) (*Message, error)
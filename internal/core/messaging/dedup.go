@@ -0,0 +1,123 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const (
+	dedupPurgeInterval = 1 * time.Hour
+	dedupMemoryLimit   = 10000
+)
+
+// SeenRepository records the IDs of inbound messages that were processed.
+type SeenRepository interface {
+	// MarkSeen records the message and reports whether it was already there.
+	MarkSeen(ctx context.Context, sessionID uuid.UUID, zpMessageID string, seenAt time.Time) (bool, error)
+	DeleteSeenBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Deduplicator recognises inbound messages that WhatsApp delivers more than
+// once, typically replays after a reconnect. IDs are persisted for ttl so
+// replays are caught across restarts; a bounded in-memory set answers the
+// common case without a database round trip and keeps working if the
+// database is briefly unavailable.
+type Deduplicator struct {
+	repository SeenRepository
+	ttl        time.Duration
+	logger     *logger.Logger
+
+	mu     sync.Mutex
+	recent map[string]time.Time
+}
+
+func NewDeduplicator(repo SeenRepository, ttl time.Duration, logger *logger.Logger) *Deduplicator {
+	return &Deduplicator{
+		repository: repo,
+		ttl:        ttl,
+		logger:     logger,
+		recent:     make(map[string]time.Time),
+	}
+}
+
+// Seen records the message and reports whether it was processed before.
+func (d *Deduplicator) Seen(ctx context.Context, sessionID uuid.UUID, zpMessageID string) bool {
+	if d.ttl <= 0 || zpMessageID == "" {
+		return false
+	}
+
+	now := time.Now()
+	key := sessionID.String() + "/" + zpMessageID
+
+	d.mu.Lock()
+	if expiry, ok := d.recent[key]; ok && now.Before(expiry) {
+		d.mu.Unlock()
+		return true
+	}
+	d.remember(key, now)
+	d.mu.Unlock()
+
+	seen, err := d.repository.MarkSeen(ctx, sessionID, zpMessageID, now)
+	if err != nil {
+		d.logger.WarnWithFields("Failed to record inbound message for deduplication", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"message_id": zpMessageID,
+			"error":      err.Error(),
+		})
+		return false
+	}
+
+	return seen
+}
+
+// remember adds key to the in-memory set, dropping expired entries first and
+// starting over when the set is still full. Must be called with mu held.
+func (d *Deduplicator) remember(key string, now time.Time) {
+	if len(d.recent) >= dedupMemoryLimit {
+		for k, expiry := range d.recent {
+			if !now.Before(expiry) {
+				delete(d.recent, k)
+			}
+		}
+		if len(d.recent) >= dedupMemoryLimit {
+			d.recent = make(map[string]time.Time)
+		}
+	}
+	d.recent[key] = now.Add(d.ttl)
+}
+
+// StartPurge deletes expired IDs periodically until ctx is cancelled.
+func (d *Deduplicator) StartPurge(ctx context.Context) {
+	if d.ttl <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(dedupPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			deleted, err := d.repository.DeleteSeenBefore(ctx, time.Now().Add(-d.ttl))
+			if err != nil {
+				d.logger.ErrorWithFields("Inbound dedup purge failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else if deleted > 0 {
+				d.logger.DebugWithFields("Expired inbound message IDs purged", map[string]interface{}{
+					"deleted": deleted,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
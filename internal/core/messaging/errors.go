@@ -0,0 +1,19 @@
+package messaging
+
+import "errors"
+
+var (
+	ErrMessageHasNoMedia = errors.New("message has no media")
+	ErrMediaExpired      = errors.New("media is no longer available on WhatsApp servers")
+	ErrMediaQuarantined  = errors.New("media was quarantined by the virus scan")
+
+	ErrNotNewsletterAdmin = errors.New("session is not an admin of the newsletter")
+	ErrNotNewsletterOwner = errors.New("session is not the owner of the newsletter")
+
+	ErrHostedMediaNotFound = errors.New("hosted media link is invalid or expired")
+
+	ErrMediaQueueFull = errors.New("session media processing queue is full")
+
+	ErrInvalidRecipient       = errors.New("recipient is not a valid WhatsApp JID or phone number")
+	ErrRecipientNotOnWhatsApp = errors.New("recipient is not on WhatsApp")
+)
@@ -0,0 +1,80 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxLiveLocationHistory caps the earlier positions returned per sharer.
+const MaxLiveLocationHistory = 500
+
+// LiveLocationPoint is one position in a live location share. WhatsApp
+// sends a new message for every update, numbered by Sequence; the sharer is
+// the contact, or the session itself for FromMe points.
+type LiveLocationPoint struct {
+	ID             uuid.UUID
+	SessionID      uuid.UUID
+	ChatJID        string
+	SharerJID      string
+	ZpMessageID    string
+	Latitude       float64
+	Longitude      float64
+	AccuracyMeters uint32
+	SpeedMps       float32
+	Heading        uint32
+	Caption        string
+	Sequence       int64
+	FromMe         bool
+	ReportedAt     time.Time
+}
+
+// LiveLocationTrack is where one sharer is now in a chat, with the positions
+// reported before, newest first.
+type LiveLocationTrack struct {
+	Latest  *LiveLocationPoint
+	History []*LiveLocationPoint
+}
+
+// RecordLiveLocation stores a live location update. Updates already stored
+// are ignored.
+func (s *Service) RecordLiveLocation(ctx context.Context, point *LiveLocationPoint) error {
+	if point.ID == uuid.Nil {
+		point.ID = uuid.New()
+	}
+
+	if err := s.repository.InsertLiveLocation(ctx, point); err != nil {
+		return fmt.Errorf("failed to record live location: %w", err)
+	}
+
+	return nil
+}
+
+// ListLiveLocations returns the latest position of everyone who shared a
+// live location in the chat, most recently updated first, each with up to
+// history earlier positions.
+func (s *Service) ListLiveLocations(ctx context.Context, sessionID uuid.UUID, chatJID string, history int) ([]*LiveLocationTrack, error) {
+	history = max(0, min(history, MaxLiveLocationHistory))
+
+	points, err := s.repository.ListLiveLocations(ctx, sessionID, chatJID, history+1)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]*LiveLocationTrack, 0)
+	bySharer := make(map[string]*LiveLocationTrack)
+	for _, point := range points {
+		track, ok := bySharer[point.SharerJID]
+		if !ok {
+			track = &LiveLocationTrack{Latest: point, History: []*LiveLocationPoint{}}
+			bySharer[point.SharerJID] = track
+			tracks = append(tracks, track)
+			continue
+		}
+		track.History = append(track.History, point)
+	}
+
+	return tracks, nil
+}
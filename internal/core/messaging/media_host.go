@@ -0,0 +1,26 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HostedMedia is a link to a copy of a message's media that can be fetched
+// without the API key until ExpiresAt.
+type HostedMedia struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// MediaHost publishes downloaded media files under expiring links, so
+// webhook receivers can fetch them without a second API call.
+type MediaHost interface {
+	Publish(ctx context.Context, sessionID uuid.UUID, path, mimeType string) (*HostedMedia, error)
+
+	// Open returns the local file behind a link this instance serves
+	// itself, or ErrHostedMediaNotFound when the token is not one of them
+	// or has expired.
+	Open(token string) (string, error)
+}
@@ -0,0 +1,262 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"zpwoot/platform/logger"
+)
+
+// MediaQueueFullError rejects media work while the session already has
+// Limit jobs waiting for a worker.
+type MediaQueueFullError struct {
+	Session string
+	Limit   int
+}
+
+func (e *MediaQueueFullError) Error() string {
+	return fmt.Sprintf("%s (%d jobs waiting)", ErrMediaQueueFull, e.Limit)
+}
+
+func (e *MediaQueueFullError) Unwrap() error {
+	return ErrMediaQueueFull
+}
+
+// MediaPoolStats is a snapshot of the media pool for the admin API.
+type MediaPoolStats struct {
+	Workers    int
+	Busy       int
+	Queued     int
+	QueueDepth int
+	Processed  int64
+	Rejected   int64
+	AvgWait    time.Duration
+	MaxWait    time.Duration
+	Sessions   []MediaQueueStats
+}
+
+// MediaQueueStats is one session's share of the pool.
+type MediaQueueStats struct {
+	Session string
+	Queued  int
+	Running int
+}
+
+type mediaJob struct {
+	ctx      context.Context
+	work     func() error
+	done     chan error
+	queuedAt time.Time
+}
+
+// MediaPool runs CPU-heavy media work, such as resizing images and
+// inspecting audio, on a fixed number of workers instead of the request
+// goroutines. Each session has its own queue of at most depth jobs and
+// workers take from the queues in turn, so a session converting a large
+// file waits behind its own work without holding up the others.
+type MediaPool struct {
+	workers int
+	depth   int
+	logger  *logger.Logger
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	started bool
+	closed  bool
+	queues  map[string][]*mediaJob
+	ring    []string
+	running map[string]int
+	busy    int
+
+	processed int64
+	rejected  int64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+func NewMediaPool(workers, depth int, logger *logger.Logger) *MediaPool {
+	p := &MediaPool{
+		workers: workers,
+		depth:   depth,
+		logger:  logger,
+		queues:  make(map[string][]*mediaJob),
+		running: make(map[string]int),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// Start runs the workers until ctx is cancelled. Jobs still queued then
+// fail with the context's error; later jobs run inline.
+func (p *MediaPool) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.started = true
+	p.mu.Unlock()
+
+	for i := 0; i < p.workers; i++ {
+		go p.work()
+	}
+
+	go func() {
+		<-ctx.Done()
+		p.mu.Lock()
+		p.closed = true
+		for _, queue := range p.queues {
+			for _, job := range queue {
+				job.done <- ctx.Err()
+			}
+		}
+		p.queues = make(map[string][]*mediaJob)
+		p.ring = nil
+		p.mu.Unlock()
+		p.cond.Broadcast()
+	}()
+}
+
+// Run queues work under the session and waits for a worker to run it. It
+// fails with a *MediaQueueFullError when the session's queue is full, and
+// with ctx's error when ctx ends first; work that has not started by then is
+// dropped. A nil or stopped pool runs work inline.
+func (p *MediaPool) Run(ctx context.Context, sessionName string, work func() error) error {
+	if p == nil {
+		return work()
+	}
+
+	p.mu.Lock()
+	if !p.started || p.closed {
+		p.mu.Unlock()
+		return work()
+	}
+	if len(p.queues[sessionName]) >= p.depth {
+		p.rejected++
+		p.mu.Unlock()
+		return &MediaQueueFullError{Session: sessionName, Limit: p.depth}
+	}
+
+	job := &mediaJob{ctx: ctx, work: work, done: make(chan error, 1), queuedAt: time.Now()}
+	if len(p.queues[sessionName]) == 0 {
+		p.ring = append(p.ring, sessionName)
+	}
+	p.queues[sessionName] = append(p.queues[sessionName], job)
+	p.mu.Unlock()
+	p.cond.Signal()
+
+	select {
+	case err := <-job.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns the pool's counters with the sessions that have work
+// queued or running.
+func (p *MediaPool) Stats() MediaPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := MediaPoolStats{
+		Workers:    p.workers,
+		Busy:       p.busy,
+		QueueDepth: p.depth,
+		Processed:  p.processed,
+		Rejected:   p.rejected,
+		MaxWait:    p.maxWait,
+	}
+	if p.processed > 0 {
+		stats.AvgWait = p.totalWait / time.Duration(p.processed)
+	}
+
+	sessions := make(map[string]*MediaQueueStats)
+	for name, queue := range p.queues {
+		sessions[name] = &MediaQueueStats{Session: name, Queued: len(queue)}
+		stats.Queued += len(queue)
+	}
+	for name, running := range p.running {
+		if sessions[name] == nil {
+			sessions[name] = &MediaQueueStats{Session: name}
+		}
+		sessions[name].Running = running
+	}
+
+	stats.Sessions = make([]MediaQueueStats, 0, len(sessions))
+	for _, session := range sessions {
+		stats.Sessions = append(stats.Sessions, *session)
+	}
+	sort.Slice(stats.Sessions, func(i, j int) bool { return stats.Sessions[i].Session < stats.Sessions[j].Session })
+
+	return stats
+}
+
+func (p *MediaPool) work() {
+	for {
+		sessionName, job := p.next()
+		if job == nil {
+			return
+		}
+
+		var err error
+		if err = job.ctx.Err(); err == nil {
+			err = p.runJob(job)
+		}
+		job.done <- err
+
+		p.mu.Lock()
+		p.busy--
+		if p.running[sessionName]--; p.running[sessionName] == 0 {
+			delete(p.running, sessionName)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// next waits for a job and takes it from the session whose turn it is,
+// moving that session to the back of the line.
+func (p *MediaPool) next() (string, *mediaJob) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.ring) == 0 && !p.closed {
+		p.cond.Wait()
+	}
+	if p.closed {
+		return "", nil
+	}
+
+	sessionName := p.ring[0]
+	p.ring = p.ring[1:]
+	queue := p.queues[sessionName]
+	job := queue[0]
+	if len(queue) > 1 {
+		p.queues[sessionName] = queue[1:]
+		p.ring = append(p.ring, sessionName)
+	} else {
+		delete(p.queues, sessionName)
+	}
+
+	wait := time.Since(job.queuedAt)
+	p.processed++
+	p.totalWait += wait
+	if wait > p.maxWait {
+		p.maxWait = wait
+	}
+	p.busy++
+	p.running[sessionName]++
+
+	return sessionName, job
+}
+
+func (p *MediaPool) runJob(job *mediaJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.ErrorWithFields("Media job panicked", map[string]interface{}{
+				"panic": fmt.Sprint(r),
+			})
+			err = fmt.Errorf("media processing failed: %v", r)
+		}
+	}()
+	return job.work()
+}
@@ -0,0 +1,63 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of scanning a message's media.
+const (
+	ScanClean       = "clean"
+	ScanInfected    = "infected"
+	ScanQuarantined = "quarantined"
+	ScanFailed      = "failed"
+)
+
+// MediaScan is the virus scan of a message's media. Infected media was kept
+// and tagged; quarantined media was flagged, or could not be scanned, under
+// a block policy and was never stored.
+type MediaScan struct {
+	Status    string    `json:"status"`
+	Signature string    `json:"signature,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+func (s *MediaScan) Quarantined() bool {
+	return s != nil && s.Status == ScanQuarantined
+}
+
+// ScanVerdict is what a scanner found in a file. Signature names the threat
+// when Infected.
+type ScanVerdict struct {
+	Infected  bool
+	Signature string
+}
+
+// MediaScanner checks downloaded media for malware, e.g. through ClamAV or
+// an ICAP server.
+type MediaScanner interface {
+	Scan(ctx context.Context, fileName, mimeType string, data []byte) (*ScanVerdict, error)
+}
+
+// RecordMediaScan stores the scan of a stored message's media.
+func (s *Service) RecordMediaScan(ctx context.Context, sessionID uuid.UUID, zpMessageID string, scan *MediaScan) error {
+	message, err := s.repository.GetByZpMessageID(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if message.Media == nil {
+		return ErrMessageHasNoMedia
+	}
+
+	message.Media.Scan = scan
+	if err := s.repository.Update(ctx, message); err != nil {
+		return fmt.Errorf("failed to update message media: %w", err)
+	}
+
+	return nil
+}
@@ -1,9 +1,12 @@
 package messaging
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
 )
 
 type Message struct {
@@ -18,16 +21,112 @@ type Message struct {
 	ZpType      string    `json:"zp_type"`
 	Content     string    `json:"content,omitempty"`
 
+	QuotedMessageID string         `json:"quoted_message_id,omitempty"`
+	Media           *MediaMetadata `json:"media,omitempty"`
+
 	CwMessageID      *int `json:"cw_message_id,omitempty"`
 	CwConversationID *int `json:"cw_conversation_id,omitempty"`
 
 	SyncStatus string     `json:"sync_status"`
 	SyncedAt   *time.Time `json:"synced_at,omitempty"`
 
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// MediaMetadata describes the attachment of a media message. The download
+// fields let the media be fetched from WhatsApp later; LocalPath is set once a
+// copy has been stored and Scan once the copy was scanned.
+type MediaMetadata struct {
+	MimeType   string `json:"mime_type,omitempty"`
+	FileName   string `json:"file_name,omitempty"`
+	FileSize   uint64 `json:"file_size,omitempty"`
+	FileSHA256 string `json:"file_sha256,omitempty"`
+	Duration   uint32 `json:"duration_seconds,omitempty"`
+	Width      uint32 `json:"width,omitempty"`
+	Height     uint32 `json:"height,omitempty"`
+
+	DirectPath    string `json:"direct_path,omitempty"`
+	MediaKey      []byte `json:"media_key,omitempty"`
+	FileEncSHA256 []byte `json:"file_enc_sha256,omitempty"`
+
+	LocalPath string     `json:"local_path,omitempty"`
+	Scan      *MediaScan `json:"scan,omitempty"`
+}
+
+func (m *MediaMetadata) IsDownloadable() bool {
+	return m.DirectPath != "" && len(m.MediaKey) > 0
+}
+
+func (m *MediaMetadata) IsStored() bool {
+	return m.LocalPath != ""
+}
+
+type Reaction struct {
+	ID          uuid.UUID `json:"id"`
+	SessionID   uuid.UUID `json:"session_id"`
+	ZpMessageID string    `json:"zp_message_id"`
+	ReactorJID  string    `json:"reactor_jid"`
+	Emoji       string    `json:"emoji"`
+	FromMe      bool      `json:"from_me"`
+	ReactedAt   time.Time `json:"reacted_at"`
+}
+
+// StarredMessage is a message flagged with a star. Message is filled in when
+// the starred message is also stored.
+type StarredMessage struct {
+	ID          uuid.UUID
+	SessionID   uuid.UUID
+	ZpMessageID string
+	ChatJID     string
+	SenderJID   string
+	FromMe      bool
+	StarredAt   time.Time
+	Message     *Message
+}
+
+// ReactionSummary counts the current reactions on a message per emoji.
+type ReactionSummary struct {
+	Emoji    string
+	Count    int
+	Reactors []string
+}
+
+// MessageDetail is a stored message together with the message it replies to
+// (when that one is also stored) and the reactions it received.
+type MessageDetail struct {
+	Message   *Message
+	Quoted    *Message
+	Reactions []*Reaction
+}
+
+// SummarizeReactions groups reactions by emoji, most used first. Ties keep
+// the order in which each emoji first appears.
+func SummarizeReactions(reactions []*Reaction) []ReactionSummary {
+	summaries := make([]ReactionSummary, 0)
+	index := make(map[string]int)
+
+	for _, reaction := range reactions {
+		i, ok := index[reaction.Emoji]
+		if !ok {
+			i = len(summaries)
+			index[reaction.Emoji] = i
+			summaries = append(summaries, ReactionSummary{Emoji: reaction.Emoji})
+		}
+		summaries[i].Count++
+		summaries[i].Reactors = append(summaries[i].Reactors, reaction.ReactorJID)
+	}
+
+	sort.SliceStable(summaries, func(a, b int) bool {
+		return summaries[a].Count > summaries[b].Count
+	})
+
+	return summaries
+}
+
 type MessageType string
 
 const (
@@ -39,6 +138,8 @@ const (
 	MessageTypeContact  MessageType = "contact"
 	MessageTypeLocation MessageType = "location"
 	MessageTypeSticker  MessageType = "sticker"
+	MessageTypeOrder    MessageType = "order"
+	MessageTypePayment  MessageType = "payment"
 )
 
 type SyncStatus string
@@ -69,10 +170,51 @@ type UpdateSyncStatusRequest struct {
 }
 
 type ListMessagesRequest struct {
-	SessionID string `json:"session_id,omitempty"`
-	ChatJID   string `json:"chat_jid,omitempty"`
-	Limit     int    `json:"limit" validate:"min=1,max=100"`
-	Offset    int    `json:"offset" validate:"min=0"`
+	SessionID string             `json:"session_id,omitempty"`
+	ChatJID   string             `json:"chat_jid,omitempty"`
+	Limit     int                `json:"limit" validate:"min=1,max=100"`
+	Offset    int                `json:"offset" validate:"min=0"`
+	After     *pagination.Cursor `json:"-"`
+}
+
+// MessagePage is one page of a listing, newest message first.
+type MessagePage struct {
+	Messages []*Message
+	Total    int64
+	HasMore  bool
+}
+
+type MessageDirection string
+
+const (
+	DirectionInbound  MessageDirection = "inbound"
+	DirectionOutbound MessageDirection = "outbound"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	MaxSearchQueryLen  = 256
+)
+
+type SearchMessagesRequest struct {
+	SessionID uuid.UUID
+	Query     string
+	ChatJID   string
+	Type      MessageType
+	Direction MessageDirection
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// SearchResult is a matched message with a snippet of its content where the
+// matched terms are wrapped in <mark> tags.
+type SearchResult struct {
+	Message *Message
+	Snippet string
+	Rank    float64
 }
 
 type MessageStats struct {
@@ -91,7 +233,8 @@ func IsValidMessageType(msgType string) bool {
 	switch MessageType(msgType) {
 	case MessageTypeText, MessageTypeImage, MessageTypeAudio,
 		MessageTypeVideo, MessageTypeDocument, MessageTypeContact,
-		MessageTypeLocation, MessageTypeSticker:
+		MessageTypeLocation, MessageTypeSticker,
+		MessageTypeOrder, MessageTypePayment:
 		return true
 	default:
 		return false
@@ -107,6 +250,10 @@ func IsValidSyncStatus(status string) bool {
 	}
 }
 
+func (m *Message) IsReply() bool {
+	return m.QuotedMessageID != ""
+}
+
 func (m *Message) IsSynced() bool {
 	return m.SyncStatus == string(SyncStatusSynced) && m.CwMessageID != nil
 }
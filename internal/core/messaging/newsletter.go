@@ -0,0 +1,50 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// NewsletterPost is a message to publish in a newsletter (WhatsApp channel).
+// Text carries the body of text posts and the caption of media posts; Data
+// holds the media itself, which is uploaded again because newsletter media
+// is stored unencrypted and cannot reuse the original upload. Without Data,
+// the media is loaded from Source, a URL or base64 data as taken by the send
+// endpoints.
+type NewsletterPost struct {
+	NewsletterJID string
+	Type          MessageType
+	Text          string
+	MimeType      string
+	FileName      string
+	Data          []byte
+	Source        string
+}
+
+type NewsletterPostResult struct {
+	MessageID string
+	Timestamp time.Time
+}
+
+// NewsletterGateway publishes posts to newsletters the session administers
+// and manages their admins. Users are given as JIDs. Inviting, revoking and
+// demoting admins and handing over ownership need the session to own the
+// newsletter, and fail with ErrNotNewsletterOwner otherwise.
+type NewsletterGateway interface {
+	PostToNewsletter(ctx context.Context, sessionName string, post *NewsletterPost) (*NewsletterPostResult, error)
+	InviteNewsletterAdmin(ctx context.Context, sessionName, newsletterJID, userJID string) (*time.Time, error)
+	RevokeNewsletterAdminInvite(ctx context.Context, sessionName, newsletterJID, userJID string) error
+	AcceptNewsletterAdminInvite(ctx context.Context, sessionName, newsletterJID string) error
+	DemoteNewsletterAdmin(ctx context.Context, sessionName, newsletterJID, userJID string) error
+	ChangeNewsletterOwner(ctx context.Context, sessionName, newsletterJID, userJID string) error
+}
+
+// CanPostToNewsletter reports whether messages of the type can be
+// republished in a newsletter.
+func CanPostToNewsletter(messageType MessageType) bool {
+	switch messageType {
+	case MessageTypeText, MessageTypeImage, MessageTypeVideo, MessageTypeAudio, MessageTypeDocument, MessageTypeSticker:
+		return true
+	}
+	return false
+}
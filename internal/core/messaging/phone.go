@@ -0,0 +1,111 @@
+package messaging
+
+import "strings"
+
+const brazilCountryCode = "55"
+
+// nationalLengths is the digit count, without the trunk "0", of national
+// numbers in countries whose numbering plan has a fixed length. Other
+// countries take 8 to 10 digits, so an 11-digit number there is read as
+// already carrying its country code.
+var nationalLengths = map[string][2]int{
+	"1":   {10, 10},
+	"7":   {10, 10},
+	"27":  {9, 9},
+	"33":  {9, 9},
+	"34":  {9, 9},
+	"44":  {10, 10},
+	"51":  {9, 9},
+	"52":  {10, 10},
+	"54":  {10, 10},
+	"55":  {10, 11},
+	"56":  {9, 9},
+	"57":  {10, 10},
+	"91":  {10, 10},
+	"351": {9, 9},
+	"591": {8, 8},
+	"595": {9, 9},
+	"598": {8, 8},
+}
+
+// isNational reports whether digits has the length of a national number of
+// the country.
+func isNational(digits, country string) bool {
+	length, ok := nationalLengths[country]
+	if !ok {
+		length = [2]int{8, 10}
+	}
+	return len(digits) >= length[0] && len(digits) <= length[1]
+}
+
+// ParsePhone reads a phone number written with or without formatting
+// (spaces, dashes, dots, parentheses) and returns its digits with the
+// country code. Numbers starting with "+" or "00" are international. Other
+// numbers with the length of a national number of defaultCountry are
+// national: a leading trunk "0" is dropped and defaultCountry prepended.
+// Longer numbers must carry their country code, as must every number when
+// defaultCountry is empty.
+func ParsePhone(raw, defaultCountry string) (string, bool) {
+	value := strings.TrimSpace(raw)
+	international := false
+	switch {
+	case strings.HasPrefix(value, "+"):
+		value, international = value[1:], true
+	case strings.HasPrefix(value, "00"):
+		value, international = value[2:], true
+	}
+
+	var digits strings.Builder
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char == ' ' || char == '-' || char == '.' || char == '(' || char == ')':
+		default:
+			return "", false
+		}
+	}
+
+	phone := digits.String()
+	if !international && defaultCountry != "" {
+		if national := strings.TrimLeft(phone, "0"); isNational(national, defaultCountry) {
+			phone = defaultCountry + national
+		}
+	}
+
+	if len(phone) < 7 || len(phone) > 15 {
+		return "", false
+	}
+	return phone, true
+}
+
+// phoneJIDUser is the JID user for a phone number. Brazilian mobiles got a
+// ninth digit in 2012-2016 but WhatsApp kept the accounts of area codes 31
+// and up under the eight-digit number, so for those the 9 is dropped, and
+// for area codes 11 to 28 an eight-digit mobile gets it added.
+func phoneJIDUser(phone string) string {
+	if !strings.HasPrefix(phone, brazilCountryCode) {
+		return phone
+	}
+
+	national := phone[len(brazilCountryCode):]
+	if len(national) != 10 && len(national) != 11 {
+		return phone
+	}
+	areaCode := national[:2]
+	subscriber := national[2:]
+
+	switch {
+	case len(subscriber) == 9 && subscriber[0] == '9' && isMobileDigit(subscriber[1]) && areaCode >= "31":
+		return brazilCountryCode + areaCode + subscriber[1:]
+	case len(subscriber) == 8 && isMobileDigit(subscriber[0]) && areaCode < "31":
+		return brazilCountryCode + areaCode + "9" + subscriber
+	}
+	return phone
+}
+
+// isMobileDigit reports whether a Brazilian subscriber number starting with
+// the digit is a mobile.
+func isMobileDigit(digit byte) bool {
+	return digit >= '6' && digit <= '9'
+}
@@ -0,0 +1,39 @@
+package messaging
+
+import "testing"
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            string
+		defaultCountry string
+		want           string
+		ok             bool
+	}{
+		{"brazilian mobile", "(11) 99999-9999", "55", "5511999999999", true},
+		{"brazilian landline", "11 3333-4444", "55", "551133334444", true},
+		{"trunk zero", "011 99999-9999", "55", "5511999999999", true},
+		{"plus prefix", "+55 11 99999-9999", "55", "5511999999999", true},
+		{"double zero prefix", "00 1 415 555 2671", "55", "14155552671", true},
+		{"country code without plus", "5511999999999", "55", "5511999999999", true},
+		{"us national", "415 555 2671", "1", "14155552671", true},
+		{"us international without plus", "14155552671", "1", "14155552671", true},
+		{"portuguese national", "912 345 678", "351", "351912345678", true},
+		{"international without plus for portugal", "14155552671", "351", "14155552671", true},
+		{"unlisted country national", "30 12345678", "49", "493012345678", true},
+		{"unlisted country international", "14155552671", "49", "14155552671", true},
+		{"no default country", "5511999999999", "", "5511999999999", true},
+		{"no default country short", "11999999999", "", "11999999999", true},
+		{"too short", "12345", "55", "", false},
+		{"letters", "11 9999-ABCD", "55", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePhone(tt.raw, tt.defaultCountry)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParsePhone(%q, %q) = %q, %v; want %q, %v", tt.raw, tt.defaultCountry, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
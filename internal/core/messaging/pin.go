@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultPinDuration is how long a message stays pinned when no duration is
+// asked for, the same default the WhatsApp apps offer.
+const DefaultPinDuration = "7d"
+
+// pinDurations are the only durations WhatsApp lets a message be pinned for.
+var pinDurations = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// ParsePinDuration turns one of 24h, 7d or 30d into its duration. An empty
+// string takes DefaultPinDuration.
+func ParsePinDuration(value string) (time.Duration, error) {
+	if value == "" {
+		value = DefaultPinDuration
+	}
+	duration, ok := pinDurations[value]
+	if !ok {
+		return 0, fmt.Errorf("duration must be one of 24h, 7d or 30d, got %q", value)
+	}
+	return duration, nil
+}
+
+// MessagePin names a message to pin or unpin for everyone in its chat.
+// SenderJID is only needed for other people's messages in groups. Duration
+// applies to pins; WhatsApp unpins the message by itself once it runs out.
+type MessagePin struct {
+	ZpMessageID string
+	ChatJID     string
+	SenderJID   string
+	FromMe      bool
+	Duration    time.Duration
+}
+
+// PinGateway pins or unpins a message in its chat, which every member sees.
+type PinGateway interface {
+	PinMessage(ctx context.Context, sessionName string, pin *MessagePin, pinned bool) error
+}
@@ -0,0 +1,95 @@
+package messaging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll is a poll sent or received by a session. Votes only carry SHA-256
+// hashes of the chosen options, so the option names are kept to decode them.
+// A SelectableCount of zero lets voters pick any number of options.
+type Poll struct {
+	ID              uuid.UUID
+	SessionID       uuid.UUID
+	ZpMessageID     string
+	ChatJID         string
+	Question        string
+	Options         []string
+	SelectableCount int
+	FromMe          bool
+	CreatedAt       time.Time
+}
+
+// PollVote is a participant's current choice on a poll. An empty Options
+// list means the vote was withdrawn.
+type PollVote struct {
+	ID          uuid.UUID
+	SessionID   uuid.UUID
+	ZpMessageID string
+	VoterJID    string
+	Options     []string
+	VotedAt     time.Time
+}
+
+// PollOptionTally counts the voters currently choosing one option.
+type PollOptionTally struct {
+	Name   string
+	Voters []string
+}
+
+// PollResults is a poll with its current votes, tallied per option in the
+// order the options were defined.
+type PollResults struct {
+	Poll   *Poll
+	Votes  []*PollVote
+	Tally  []PollOptionTally
+	Voters int
+}
+
+// DecodeOptions maps the option hashes of a vote back to option names.
+// Hashes that match no option are ignored.
+func (p *Poll) DecodeOptions(hashes [][]byte) []string {
+	selected := make([]string, 0, len(hashes))
+	for _, option := range p.Options {
+		sum := sha256.Sum256([]byte(option))
+		for _, hash := range hashes {
+			if bytes.Equal(sum[:], hash) {
+				selected = append(selected, option)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// TallyPoll counts the votes for each option of the poll.
+func TallyPoll(poll *Poll, votes []*PollVote) *PollResults {
+	results := &PollResults{
+		Poll:  poll,
+		Votes: votes,
+		Tally: make([]PollOptionTally, len(poll.Options)),
+	}
+
+	index := make(map[string]int, len(poll.Options))
+	for i, option := range poll.Options {
+		index[option] = i
+		results.Tally[i] = PollOptionTally{Name: option, Voters: []string{}}
+	}
+
+	for _, vote := range votes {
+		if len(vote.Options) == 0 {
+			continue
+		}
+		results.Voters++
+		for _, option := range vote.Options {
+			if i, ok := index[option]; ok {
+				results.Tally[i].Voters = append(results.Tally[i].Voters, vote.VoterJID)
+			}
+		}
+	}
+
+	return results
+}
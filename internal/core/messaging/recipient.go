@@ -0,0 +1,94 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Servers a message can be addressed to.
+const (
+	userServer       = "s.whatsapp.net"
+	legacyUserServer = "c.us"
+	groupServer      = "g.us"
+	lidServer        = "lid"
+	broadcastServer  = "broadcast"
+	newsletterServer = "newsletter"
+)
+
+// Recipient is a validated send target. Phone is set for user JIDs, whose
+// registration on WhatsApp can be checked.
+type Recipient struct {
+	JID   string
+	Phone string
+}
+
+// RecipientError rejects a send before it reaches WhatsApp because of its
+// recipient. Err is ErrInvalidRecipient or ErrRecipientNotOnWhatsApp.
+type RecipientError struct {
+	Recipient string
+	Err       error
+}
+
+func (e *RecipientError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Recipient)
+}
+
+func (e *RecipientError) Unwrap() error {
+	return e.Err
+}
+
+// ParseRecipient checks that to can receive a message: a user, group, LID,
+// broadcast or newsletter JID, or a bare phone number, which becomes a user
+// JID (see ParsePhone for how numbers are read). Device suffixes are dropped
+// and c.us JIDs rewritten to s.whatsapp.net, so the gateway always gets a
+// plain JID.
+func ParseRecipient(to, defaultCountry string) (*Recipient, error) {
+	value := strings.TrimSpace(to)
+	invalid := &RecipientError{Recipient: to, Err: ErrInvalidRecipient}
+
+	user, server, found := strings.Cut(value, "@")
+	if !found {
+		phone, ok := ParsePhone(value, defaultCountry)
+		if !ok {
+			return nil, invalid
+		}
+		return &Recipient{JID: phoneJIDUser(phone) + "@" + userServer, Phone: phone}, nil
+	}
+	user, _, _ = strings.Cut(user, ":")
+	if user == "" || strings.ContainsAny(user, " \t\n@") {
+		return nil, invalid
+	}
+
+	switch server {
+	case userServer, legacyUserServer:
+		if !isDigits(user) || len(user) < 7 || len(user) > 15 {
+			return nil, invalid
+		}
+		return &Recipient{JID: user + "@" + userServer, Phone: user}, nil
+	case groupServer:
+		if !isDigits(strings.ReplaceAll(user, "-", "")) {
+			return nil, invalid
+		}
+	case lidServer, newsletterServer:
+		if !isDigits(user) {
+			return nil, invalid
+		}
+	case broadcastServer:
+	default:
+		return nil, invalid
+	}
+
+	return &Recipient{JID: user + "@" + server}, nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
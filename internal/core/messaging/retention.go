@@ -0,0 +1,229 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const (
+	retentionInterval  = 1 * time.Hour
+	retentionBatchSize = 1000
+)
+
+// Partition is one monthly partition of the message table, holding the
+// messages with From <= timestamp < To.
+type Partition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// PurgeFilter narrows a purge to one session, or to every session except
+// some.
+type PurgeFilter struct {
+	SessionID *uuid.UUID
+	Exclude   []uuid.UUID
+}
+
+// RetentionRepository manages the monthly partitions of the message table
+// and deletes expired messages. Deletes return the local paths of the media
+// files that belonged to the deleted messages.
+type RetentionRepository interface {
+	EnsurePartitions(ctx context.Context, from time.Time, months int) (int, error)
+	ListPartitions(ctx context.Context) ([]Partition, error)
+	DropPartition(ctx context.Context, name string) ([]string, error)
+	PurgeBefore(ctx context.Context, cutoff time.Time, filter PurgeFilter, limit int) (int64, []string, error)
+}
+
+// RetentionOverrides lists the sessions that keep their messages for their
+// own number of days; 0 keeps them forever.
+type RetentionOverrides interface {
+	MessageRetentionOverrides(ctx context.Context) (map[uuid.UUID]int, error)
+}
+
+type RetentionResult struct {
+	PartitionsCreated int
+	PartitionsDropped int
+	MessagesDeleted   int64
+	MediaDeleted      int
+}
+
+// Retention keeps monthly partitions created ahead of time and prunes
+// messages older than their session's retention, with their media files.
+// Partitions entirely older than every session's cutoff are dropped whole;
+// the rest is deleted in batches.
+type Retention struct {
+	repository      RetentionRepository
+	overrides       RetentionOverrides
+	defaultDays     int
+	partitionsAhead int
+	logger          *logger.Logger
+}
+
+func NewRetention(repo RetentionRepository, overrides RetentionOverrides, defaultDays, partitionsAhead int, logger *logger.Logger) *Retention {
+	return &Retention{
+		repository:      repo,
+		overrides:       overrides,
+		defaultDays:     defaultDays,
+		partitionsAhead: partitionsAhead,
+		logger:          logger,
+	}
+}
+
+// Start runs the job now and then hourly until ctx is cancelled.
+func (r *Retention) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(retentionInterval)
+		defer ticker.Stop()
+
+		for {
+			result, err := r.Run(ctx, time.Now())
+			if err != nil {
+				r.logger.ErrorWithFields("Message retention run failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			} else if result.PartitionsCreated > 0 || result.PartitionsDropped > 0 || result.MessagesDeleted > 0 {
+				r.logger.InfoWithFields("Message retention run finished", map[string]interface{}{
+					"partitions_created": result.PartitionsCreated,
+					"partitions_dropped": result.PartitionsDropped,
+					"messages_deleted":   result.MessagesDeleted,
+					"media_deleted":      result.MediaDeleted,
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *Retention) Run(ctx context.Context, now time.Time) (*RetentionResult, error) {
+	result := &RetentionResult{}
+
+	created, err := r.repository.EnsurePartitions(ctx, now, r.partitionsAhead+1)
+	if err != nil {
+		return result, fmt.Errorf("failed to create partitions: %w", err)
+	}
+	result.PartitionsCreated = created
+
+	overrides, err := r.overrides.MessageRetentionOverrides(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to load retention overrides: %w", err)
+	}
+
+	if oldest, ok := r.oldestCutoff(now, overrides); ok {
+		if err := r.dropPartitions(ctx, oldest, result); err != nil {
+			return result, err
+		}
+	}
+
+	exclude := make([]uuid.UUID, 0, len(overrides))
+	for sessionID, days := range overrides {
+		exclude = append(exclude, sessionID)
+		if days <= 0 {
+			continue
+		}
+
+		id := sessionID
+		if err := r.purge(ctx, cutoff(now, days), PurgeFilter{SessionID: &id}, result); err != nil {
+			return result, err
+		}
+	}
+
+	if r.defaultDays > 0 {
+		if err := r.purge(ctx, cutoff(now, r.defaultDays), PurgeFilter{Exclude: exclude}, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// oldestCutoff is the cutoff of the longest retention in effect. It is
+// false when some messages are kept forever, since then no partition can
+// be dropped whole.
+func (r *Retention) oldestCutoff(now time.Time, overrides map[uuid.UUID]int) (time.Time, bool) {
+	longest := r.defaultDays
+	if longest <= 0 {
+		return time.Time{}, false
+	}
+
+	for _, days := range overrides {
+		if days <= 0 {
+			return time.Time{}, false
+		}
+		if days > longest {
+			longest = days
+		}
+	}
+
+	return cutoff(now, longest), true
+}
+
+func (r *Retention) dropPartitions(ctx context.Context, before time.Time, result *RetentionResult) error {
+	partitions, err := r.repository.ListPartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	for _, partition := range partitions {
+		if partition.To.After(before) {
+			continue
+		}
+
+		paths, err := r.repository.DropPartition(ctx, partition.Name)
+		if err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", partition.Name, err)
+		}
+		result.PartitionsDropped++
+		result.MediaDeleted += r.removeMedia(paths)
+	}
+
+	return nil
+}
+
+func (r *Retention) purge(ctx context.Context, before time.Time, filter PurgeFilter, result *RetentionResult) error {
+	for {
+		deleted, paths, err := r.repository.PurgeBefore(ctx, before, filter, retentionBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to purge messages: %w", err)
+		}
+		result.MessagesDeleted += deleted
+		result.MediaDeleted += r.removeMedia(paths)
+
+		if deleted < retentionBatchSize || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *Retention) removeMedia(paths []string) int {
+	removed := 0
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				r.logger.WarnWithFields("Failed to remove expired media file", map[string]interface{}{
+					"path":  path,
+					"error": err.Error(),
+				})
+			}
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+func cutoff(now time.Time, days int) time.Time {
+	return now.AddDate(0, 0, -days)
+}
@@ -2,11 +2,15 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
+	shared "zpwoot/internal/core/shared/errors"
+	"zpwoot/internal/core/shared/pagination"
 	"zpwoot/platform/logger"
 )
 
@@ -85,6 +89,230 @@ func (s *Service) GetMessageByZpID(ctx context.Context, sessionID uuid.UUID, zpM
 	return message, nil
 }
 
+// SaveReceivedMessage stores a message delivered by WhatsApp and moves its
+// chat to the top of the inbox, counting it as unread unless it was sent by
+// the session. Redeliveries of an already stored message are ignored.
+func (s *Service) SaveReceivedMessage(ctx context.Context, message *Message) error {
+	if err := s.repository.Create(ctx, message); err != nil {
+		if errors.Is(err, shared.ErrAlreadyExists) {
+			return nil
+		}
+		return fmt.Errorf("failed to save received message: %w", err)
+	}
+
+	// Status updates share one broadcast chat that is not a conversation.
+	if strings.HasSuffix(message.ZpChat, "@broadcast") {
+		return nil
+	}
+
+	if err := s.repository.RecordChatMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to update chat: %w", err)
+	}
+
+	return nil
+}
+
+// AttachMediaFile records where the media of a stored message was saved.
+func (s *Service) AttachMediaFile(ctx context.Context, sessionID uuid.UUID, zpMessageID, localPath string) error {
+	message, err := s.repository.GetByZpMessageID(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if message.Media == nil {
+		return ErrMessageHasNoMedia
+	}
+
+	message.Media.LocalPath = localPath
+	if err := s.repository.Update(ctx, message); err != nil {
+		return fmt.Errorf("failed to update message media: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageDetail loads a stored message by its WhatsApp ID and resolves the
+// quoted message and reactions. A quoted message that was never stored is not
+// an error; the detail then only carries its ID.
+func (s *Service) GetMessageDetail(ctx context.Context, sessionID uuid.UUID, zpMessageID string) (*MessageDetail, error) {
+	message, err := s.repository.GetByZpMessageID(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	detail := &MessageDetail{Message: message}
+
+	if message.IsReply() {
+		quoted, err := s.repository.GetByZpMessageID(ctx, sessionID, message.QuotedMessageID)
+		if err != nil && !errors.Is(err, shared.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get quoted message: %w", err)
+		}
+		detail.Quoted = quoted
+	}
+
+	reactions, err := s.repository.ListReactions(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+	detail.Reactions = reactions
+
+	return detail, nil
+}
+
+// RecordReaction stores a reaction, or removes it when the emoji is empty,
+// which is how WhatsApp signals that a reaction was withdrawn.
+func (s *Service) RecordReaction(ctx context.Context, reaction *Reaction) error {
+	if reaction.Emoji == "" {
+		return s.repository.DeleteReaction(ctx, reaction.SessionID, reaction.ZpMessageID, reaction.ReactorJID)
+	}
+
+	if reaction.ID == uuid.Nil {
+		reaction.ID = uuid.New()
+	}
+
+	return s.repository.UpsertReaction(ctx, reaction)
+}
+
+// ApplyEdit replaces the content of a stored message with the text its sender
+// edited it to. It fails with shared.ErrNotFound when the message was never
+// stored.
+func (s *Service) ApplyEdit(ctx context.Context, sessionID uuid.UUID, zpMessageID, content string, editedAt time.Time) (*Message, error) {
+	message, err := s.repository.GetByZpMessageID(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Content = content
+	message.EditedAt = &editedAt
+	if err := s.repository.Update(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to update edited message: %w", err)
+	}
+
+	return message, nil
+}
+
+// ApplyRevoke marks a stored message as deleted for everyone. The content is
+// kept so the record still shows what was said.
+func (s *Service) ApplyRevoke(ctx context.Context, sessionID uuid.UUID, zpMessageID string, revokedAt time.Time) (*Message, error) {
+	message, err := s.repository.GetByZpMessageID(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	message.RevokedAt = &revokedAt
+	if err := s.repository.Update(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to update revoked message: %w", err)
+	}
+
+	return message, nil
+}
+
+// RecordPoll stores a poll so votes on it can be decoded later. Recording the
+// same poll again keeps the first copy.
+func (s *Service) RecordPoll(ctx context.Context, poll *Poll) error {
+	if poll.ID == uuid.Nil {
+		poll.ID = uuid.New()
+	}
+	if poll.CreatedAt.IsZero() {
+		poll.CreatedAt = time.Now()
+	}
+
+	return s.repository.UpsertPoll(ctx, poll)
+}
+
+// RecordPollVote decodes the option hashes of a vote against the stored poll
+// and saves the result as the voter's current choice. It fails with
+// shared.ErrNotFound when the poll was never recorded.
+func (s *Service) RecordPollVote(ctx context.Context, sessionID uuid.UUID, zpMessageID, voterJID string, hashes [][]byte, votedAt time.Time) (*Poll, *PollVote, error) {
+	poll, err := s.repository.GetPoll(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vote := &PollVote{
+		ID:          uuid.New(),
+		SessionID:   sessionID,
+		ZpMessageID: zpMessageID,
+		VoterJID:    voterJID,
+		Options:     poll.DecodeOptions(hashes),
+		VotedAt:     votedAt,
+	}
+
+	if err := s.repository.UpsertPollVote(ctx, vote); err != nil {
+		return nil, nil, err
+	}
+
+	return poll, vote, nil
+}
+
+// GetPollResults returns a stored poll with its votes tallied per option.
+func (s *Service) GetPollResults(ctx context.Context, sessionID uuid.UUID, zpMessageID string) (*PollResults, error) {
+	poll, err := s.repository.GetPoll(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := s.repository.ListPollVotes(ctx, sessionID, zpMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list poll votes: %w", err)
+	}
+
+	return TallyPoll(poll, votes), nil
+}
+
+// SetStarred records whether a message is starred. Stars are keyed by the
+// WhatsApp message ID, so messages that were never stored can be starred too.
+func (s *Service) SetStarred(ctx context.Context, star *StarredMessage, starred bool) error {
+	if !starred {
+		return s.repository.DeleteStar(ctx, star.SessionID, star.ZpMessageID)
+	}
+
+	if star.ID == uuid.Nil {
+		star.ID = uuid.New()
+	}
+	if star.StarredAt.IsZero() {
+		star.StarredAt = time.Now()
+	}
+
+	return s.repository.UpsertStar(ctx, star)
+}
+
+// ListStarred returns a session's starred messages, most recently starred
+// first, with the stored copy of each message when there is one.
+func (s *Service) ListStarred(ctx context.Context, sessionID uuid.UUID, req pagination.Request) ([]*StarredMessage, bool, error) {
+	limit := pagination.ClampLimit(req.Limit)
+
+	stars, err := s.repository.ListStarred(ctx, sessionID, req.After, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list starred messages: %w", err)
+	}
+	stars, hasMore := pagination.Trim(stars, limit)
+
+	if len(stars) == 0 {
+		return stars, hasMore, nil
+	}
+
+	ids := make([]string, len(stars))
+	for i, star := range stars {
+		ids[i] = star.ZpMessageID
+	}
+
+	messages, err := s.repository.ListByZpMessageIDs(ctx, sessionID, ids)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load starred messages: %w", err)
+	}
+
+	byID := make(map[string]*Message, len(messages))
+	for _, message := range messages {
+		byID[message.ZpMessageID] = message
+	}
+	for _, star := range stars {
+		star.Message = byID[star.ZpMessageID]
+	}
+
+	return stars, hasMore, nil
+}
+
 func (s *Service) UpdateSyncStatus(ctx context.Context, id uuid.UUID, status SyncStatus, cwMessageID, cwConversationID *int) error {
 
 	if !IsValidSyncStatus(string(status)) {
@@ -132,51 +360,72 @@ func (s *Service) MarkAsFailed(ctx context.Context, id uuid.UUID, errorReason st
 	return nil
 }
 
-func (s *Service) ListMessages(ctx context.Context, req *ListMessagesRequest) ([]*Message, int64, error) {
+func (s *Service) ListMessages(ctx context.Context, req *ListMessagesRequest) (*MessagePage, error) {
 
 	if err := s.validateListRequest(req); err != nil {
-		return nil, 0, fmt.Errorf("invalid list request: %w", err)
+		return nil, fmt.Errorf("invalid list request: %w", err)
 	}
 
 	var messages []*Message
+	var total int64
 	var err error
 
+	// One extra row tells whether another page follows.
+	fetch := req.Limit + 1
+
 	if req.SessionID != "" {
 		sessionID, err := uuid.Parse(req.SessionID)
 		if err != nil {
-			return nil, 0, fmt.Errorf("invalid session ID: %w", err)
+			return nil, fmt.Errorf("invalid session ID: %w", err)
 		}
 
-		if req.ChatJID != "" {
-			messages, err = s.repository.ListByChat(ctx, sessionID, req.ChatJID, req.Limit, req.Offset)
-		} else {
-			messages, err = s.repository.ListBySession(ctx, sessionID, req.Limit, req.Offset)
+		switch {
+		case req.After != nil || req.Offset == 0:
+			messages, err = s.repository.ListAfter(ctx, sessionID, req.ChatJID, req.After, fetch)
+		case req.ChatJID != "":
+			messages, err = s.repository.ListByChat(ctx, sessionID, req.ChatJID, fetch, req.Offset)
+		default:
+			messages, err = s.repository.ListBySession(ctx, sessionID, fetch, req.Offset)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list messages: %w", err)
 		}
-	} else {
-		messages, err = s.repository.List(ctx, req.Limit, req.Offset)
-	}
-
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list messages: %w", err)
-	}
 
-	var total int64
-	if req.SessionID != "" {
-		sessionID, _ := uuid.Parse(req.SessionID)
 		if req.ChatJID != "" {
 			total, err = s.repository.CountByChat(ctx, sessionID, req.ChatJID)
 		} else {
 			total, err = s.repository.CountBySession(ctx, sessionID)
 		}
 	} else {
+		messages, err = s.repository.List(ctx, fetch, req.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list messages: %w", err)
+		}
 		total, err = s.repository.Count(ctx)
 	}
 
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count messages: %w", err)
+		return nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	page := &MessagePage{Total: total}
+	page.Messages, page.HasMore = pagination.Trim(messages, req.Limit)
+
+	return page, nil
+}
+
+func (s *Service) SearchMessages(ctx context.Context, req *SearchMessagesRequest) ([]*SearchResult, int64, error) {
+
+	if err := s.validateSearchRequest(req); err != nil {
+		return nil, 0, fmt.Errorf("invalid search request: %w", err)
+	}
+
+	results, total, err := s.repository.Search(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search messages: %w", err)
 	}
 
-	return messages, total, nil
+	return results, total, nil
 }
 
 func (s *Service) GetPendingSyncMessages(ctx context.Context, sessionID uuid.UUID, limit int) ([]*Message, error) {
@@ -229,6 +478,37 @@ func (s *Service) validateCreateRequest(req *CreateMessageRequest) error {
 	return nil
 }
 
+func (s *Service) validateSearchRequest(req *SearchMessagesRequest) error {
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return fmt.Errorf("validation failed: search query is required")
+	}
+	if len(req.Query) > MaxSearchQueryLen {
+		return fmt.Errorf("validation failed: search query exceeds %d characters", MaxSearchQueryLen)
+	}
+	if req.Type != "" && !IsValidMessageType(string(req.Type)) {
+		return fmt.Errorf("validation failed: invalid message type: %s", req.Type)
+	}
+	if req.Direction != "" && req.Direction != DirectionInbound && req.Direction != DirectionOutbound {
+		return fmt.Errorf("validation failed: direction must be inbound or outbound")
+	}
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return fmt.Errorf("validation failed: from must be before to")
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = DefaultSearchLimit
+	}
+	if req.Limit > MaxSearchLimit {
+		req.Limit = MaxSearchLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	return nil
+}
+
 func (s *Service) validateListRequest(req *ListMessagesRequest) error {
 	if req.Limit <= 0 {
 		req.Limit = 50
@@ -236,7 +516,7 @@ func (s *Service) validateListRequest(req *ListMessagesRequest) error {
 	if req.Limit > 100 {
 		req.Limit = 100
 	}
-	if req.Offset < 0 {
+	if req.Offset < 0 || req.After != nil {
 		req.Offset = 0
 	}
 
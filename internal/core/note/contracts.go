@@ -0,0 +1,15 @@
+package note
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, note *Note) error
+	Get(ctx context.Context, sessionID, id uuid.UUID) (*Note, error)
+	Update(ctx context.Context, note *Note) error
+	Delete(ctx context.Context, sessionID, id uuid.UUID) error
+	List(ctx context.Context, filter *ListFilter) ([]*Note, error)
+}
@@ -0,0 +1,8 @@
+package note
+
+import "errors"
+
+var (
+	ErrNoteNotFound = errors.New("note not found")
+	ErrInvalidNote  = errors.New("invalid note")
+)
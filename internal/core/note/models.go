@@ -0,0 +1,48 @@
+package note
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindNote is context for whoever picks up the chat next.
+	KindNote Kind = "note"
+	// KindDraft is a reply someone is preparing but has not sent.
+	KindDraft Kind = "draft"
+)
+
+const (
+	MaxAuthorLength = 255
+	MaxBodyLength   = 8192
+)
+
+func (k Kind) IsValid() bool {
+	return k == KindNote || k == KindDraft
+}
+
+// Note is a private annotation on a chat. It lives only in zpwoot and is
+// never sent to WhatsApp.
+type Note struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+	ChatJID   string
+	Kind      Kind
+	Author    string
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ListFilter selects the notes of one chat. From and To bound CreatedAt,
+// From inclusive and To exclusive, so consecutive windows never overlap.
+type ListFilter struct {
+	SessionID uuid.UUID
+	ChatJID   string
+	Kind      Kind
+	From      *time.Time
+	To        *time.Time
+}
@@ -0,0 +1,111 @@
+package note
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// Service keeps the notes and drafts teams leave on chats.
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, sessionID uuid.UUID, chatJID string, kind Kind, author, body string) (*Note, error) {
+	if kind == "" {
+		kind = KindNote
+	}
+
+	now := time.Now()
+	note := &Note{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		ChatJID:   chatJID,
+		Kind:      kind,
+		Author:    strings.TrimSpace(author),
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validate(note); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Create(ctx, note); err != nil {
+		return nil, fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return note, nil
+}
+
+func (s *Service) Get(ctx context.Context, sessionID, id uuid.UUID) (*Note, error) {
+	return s.repository.Get(ctx, sessionID, id)
+}
+
+// Update changes the body or kind of a note; turning a draft into a note
+// is how a team keeps the text once the reply went out another way.
+func (s *Service) Update(ctx context.Context, sessionID, id uuid.UUID, kind *Kind, body *string) (*Note, error) {
+	note, err := s.repository.Get(ctx, sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind != nil {
+		note.Kind = *kind
+	}
+	if body != nil {
+		note.Body = *body
+	}
+	if err := validate(note); err != nil {
+		return nil, err
+	}
+
+	note.UpdatedAt = time.Now()
+	if err := s.repository.Update(ctx, note); err != nil {
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+
+	return note, nil
+}
+
+func (s *Service) Delete(ctx context.Context, sessionID, id uuid.UUID) error {
+	return s.repository.Delete(ctx, sessionID, id)
+}
+
+func (s *Service) List(ctx context.Context, filter *ListFilter) ([]*Note, error) {
+	notes, err := s.repository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+func validate(note *Note) error {
+	if !note.Kind.IsValid() {
+		return fmt.Errorf("%w: kind must be note or draft", ErrInvalidNote)
+	}
+	if note.Author == "" || len(note.Author) > MaxAuthorLength {
+		return fmt.Errorf("%w: author is required and at most %d characters", ErrInvalidNote, MaxAuthorLength)
+	}
+	if strings.TrimSpace(note.Body) == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidNote)
+	}
+	if len(note.Body) > MaxBodyLength {
+		return fmt.Errorf("%w: body is limited to %d characters", ErrInvalidNote, MaxBodyLength)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, message *Message) error
+	Get(ctx context.Context, sessionID, id uuid.UUID) (*Message, error)
+	List(ctx context.Context, sessionID uuid.UUID, status Status) ([]*Message, error)
+
+	// ClaimDue marks up to limit pending messages due at or before now as
+	// sending and returns them, so concurrent workers never claim the same one.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	// Release returns messages left in sending by a crashed worker to pending.
+	Release(ctx context.Context) (int64, error)
+	// ClaimFailed marks a failed message as sending for a retry and returns
+	// it, or ErrNotRetryable when it has not failed.
+	ClaimFailed(ctx context.Context, sessionID, id uuid.UUID) (*Message, error)
+	Finish(ctx context.Context, message *Message) error
+	Cancel(ctx context.Context, sessionID, id uuid.UUID) error
+}
+
+// Dispatcher performs a held-back send and returns the WhatsApp message ID.
+// A failed send that may still have gone out returns its ID with the error;
+// it is kept on the message, and the next attempt is made under it.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, message *Message) (string, error)
+}
@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScheduledMessageNotFound = errors.New("scheduled message not found")
+	ErrNotCancellable           = errors.New("scheduled message is no longer pending")
+	ErrNotRetryable             = errors.New("scheduled message has not failed")
+)
+
+// DeferError is returned by a Dispatcher that cannot send yet. The message
+// stays pending and is tried again at Until instead of failing.
+type DeferError struct {
+	Until  time.Time
+	Reason string
+}
+
+func (e *DeferError) Error() string {
+	return fmt.Sprintf("deferred until %s: %s", e.Until.Format(time.RFC3339), e.Reason)
+}
+
+// FailedSendError reports a send that failed and is kept as the failed
+// message ID, so it can be retried instead of submitted again.
+type FailedSendError struct {
+	ID  uuid.UUID
+	Err error
+}
+
+func (e *FailedSendError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FailedSendError) Unwrap() error {
+	return e.Err
+}
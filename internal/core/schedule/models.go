@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSending   Status = "sending"
+	StatusSent      Status = "sent"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// ReasonSendFailed marks a send kept after it failed when submitted, rather
+// than one held back for later.
+const ReasonSendFailed = "send_failed"
+
+// Message is an outbound send held back until SendAt. Kind names the send
+// endpoint and Payload is its request body, so the send runs exactly as it
+// would have when it was submitted.
+type Message struct {
+	ID        uuid.UUID       `json:"id"`
+	SessionID uuid.UUID       `json:"sessionId"`
+	Kind      string          `json:"kind"`
+	Payload   json.RawMessage `json:"payload"`
+	SendAt    time.Time       `json:"sendAt"`
+	Reason    string          `json:"reason,omitempty"`
+	Status    Status          `json:"status"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"lastError,omitempty"`
+	MessageID string          `json:"messageId,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
@@ -0,0 +1,210 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+const (
+	pollInterval = 15 * time.Second
+	claimBatch   = 50
+)
+
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+// Schedule stores a send to run at sendAt. The payload is kept as JSON so it
+// can be replayed by the Dispatcher after a restart.
+func (s *Service) Schedule(ctx context.Context, sessionID uuid.UUID, kind string, payload interface{}, sendAt time.Time, reason string) (*Message, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scheduled payload: %w", err)
+	}
+
+	now := time.Now()
+	message := &Message{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Kind:      kind,
+		Payload:   body,
+		SendAt:    sendAt,
+		Reason:    reason,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.Create(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to schedule message: %w", err)
+	}
+
+	return message, nil
+}
+
+// KeepFailed stores a send that failed when it was submitted as a failed
+// message, keeping its payload so Retry can replay it. messageID is set for a
+// send that may have gone out, and is the ID to retry it under.
+func (s *Service) KeepFailed(ctx context.Context, sessionID uuid.UUID, kind string, payload interface{}, messageID string, sendErr error) (*Message, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode failed send payload: %w", err)
+	}
+
+	now := time.Now()
+	message := &Message{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		Kind:      kind,
+		Payload:   body,
+		SendAt:    now,
+		Reason:    ReasonSendFailed,
+		Status:    StatusFailed,
+		Attempts:  1,
+		LastError: sendErr.Error(),
+		MessageID: messageID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.Create(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to keep failed send: %w", err)
+	}
+
+	return message, nil
+}
+
+func (s *Service) List(ctx context.Context, sessionID uuid.UUID, status Status) ([]*Message, error) {
+	return s.repository.List(ctx, sessionID, status)
+}
+
+func (s *Service) Cancel(ctx context.Context, sessionID, id uuid.UUID) error {
+	message, err := s.repository.Get(ctx, sessionID, id)
+	if err != nil {
+		return err
+	}
+	if message.Status != StatusPending {
+		return ErrNotCancellable
+	}
+
+	return s.repository.Cancel(ctx, sessionID, id)
+}
+
+// Retry replays a failed message right away and records the outcome like
+// RunDue. When it fails again, the error is a *FailedSendError so the caller
+// can retry once more.
+func (s *Service) Retry(ctx context.Context, sessionID, id uuid.UUID, dispatcher Dispatcher) (*Message, error) {
+	message, err := s.repository.ClaimFailed(ctx, sessionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.run(ctx, dispatcher, message); err != nil {
+		return message, &FailedSendError{ID: message.ID, Err: err}
+	}
+
+	return message, nil
+}
+
+// RunDue sends every message that is due and records the outcome. A failed
+// send is not retried; its error is kept on the message for the caller. A
+// send the dispatcher defers goes back to pending with its new time.
+func (s *Service) RunDue(ctx context.Context, dispatcher Dispatcher) (int, error) {
+	messages, err := s.repository.ClaimDue(ctx, time.Now(), claimBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim scheduled messages: %w", err)
+	}
+
+	for _, message := range messages {
+		_ = s.run(ctx, dispatcher, message)
+	}
+
+	return len(messages), nil
+}
+
+// run dispatches a claimed message, records the outcome and returns the
+// send error when the message failed.
+func (s *Service) run(ctx context.Context, dispatcher Dispatcher, message *Message) error {
+	message.Attempts++
+	messageID, sendErr := dispatcher.Dispatch(ctx, message)
+	var deferred *DeferError
+	if errors.As(sendErr, &deferred) {
+		message.Status = StatusPending
+		message.SendAt = deferred.Until
+		message.LastError = sendErr.Error()
+		sendErr = nil
+	} else if sendErr != nil {
+		message.Status = StatusFailed
+		message.LastError = sendErr.Error()
+		if messageID != "" {
+			message.MessageID = messageID
+		}
+		s.logger.WarnWithFields("Scheduled message failed", map[string]interface{}{
+			"id":         message.ID.String(),
+			"session_id": message.SessionID.String(),
+			"kind":       message.Kind,
+			"error":      sendErr.Error(),
+		})
+	} else {
+		message.Status = StatusSent
+		message.MessageID = messageID
+		message.LastError = ""
+	}
+	message.UpdatedAt = time.Now()
+
+	if err := s.repository.Finish(ctx, message); err != nil {
+		s.logger.ErrorWithFields("Failed to record scheduled message outcome", map[string]interface{}{
+			"id":    message.ID.String(),
+			"error": err.Error(),
+		})
+	}
+
+	return sendErr
+}
+
+// Start polls for due messages until ctx is cancelled.
+func (s *Service) Start(ctx context.Context, dispatcher Dispatcher) {
+	if released, err := s.repository.Release(ctx); err != nil {
+		s.logger.ErrorWithFields("Failed to release in-flight scheduled messages", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else if released > 0 {
+		s.logger.InfoWithFields("Released in-flight scheduled messages", map[string]interface{}{
+			"released": released,
+		})
+	}
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.RunDue(ctx, dispatcher); err != nil {
+				s.logger.ErrorWithFields("Scheduled message run failed", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
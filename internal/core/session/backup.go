@@ -0,0 +1,123 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	backupVersion       = 1
+	backupSaltSize      = 16
+	backupKeySize       = 32
+	backupKDFIterations = 210000
+	minPassphraseLength = 8
+)
+
+func EncryptCredentials(sessionName string, credentials *DeviceCredentials, passphrase string) (*SessionBackup, error) {
+	if credentials == nil || credentials.DeviceJID == "" {
+		return nil, ErrSessionNotPaired
+	}
+
+	if len(passphrase) < minPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", minPassphraseLength)
+	}
+
+	plaintext, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	backup := &SessionBackup{
+		Version:     backupVersion,
+		SessionName: sessionName,
+		DeviceJID:   credentials.DeviceJID,
+		Salt:        salt,
+		Nonce:       nonce,
+		ExportedAt:  time.Now(),
+	}
+
+	backup.Ciphertext = gcm.Seal(nil, nonce, plaintext, backup.additionalData())
+
+	return backup, nil
+}
+
+func DecryptCredentials(backup *SessionBackup, passphrase string) (*DeviceCredentials, error) {
+	if backup == nil || len(backup.Salt) == 0 || len(backup.Ciphertext) == 0 {
+		return nil, ErrInvalidBackup
+	}
+
+	if backup.Version != backupVersion {
+		return nil, ErrUnsupportedBackup
+	}
+
+	gcm, err := newBackupCipher(passphrase, backup.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(backup.Nonce) != gcm.NonceSize() {
+		return nil, ErrInvalidBackup
+	}
+
+	plaintext, err := gcm.Open(nil, backup.Nonce, backup.Ciphertext, backup.additionalData())
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	var credentials DeviceCredentials
+	if err := json.Unmarshal(plaintext, &credentials); err != nil {
+		return nil, ErrInvalidBackup
+	}
+
+	if credentials.DeviceJID == "" || credentials.DeviceJID != backup.DeviceJID {
+		return nil, ErrInvalidBackup
+	}
+
+	return &credentials, nil
+}
+
+func newBackupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, backupKDFIterations, backupKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// additionalData binds the ciphertext to the device it was exported from,
+// so the plaintext header cannot be swapped between backups.
+func (b *SessionBackup) additionalData() []byte {
+	return []byte(fmt.Sprintf("zpwoot-backup:v%d:%s", b.Version, b.DeviceJID))
+}
@@ -0,0 +1,47 @@
+package session
+
+import "time"
+
+// BanKind tells a permanent ban from a temporary restriction.
+type BanKind string
+
+const (
+	// BanBanned is a permanent ban: WhatsApp logged the device out and the
+	// number cannot be used until the ban is lifted and it pairs again.
+	BanBanned BanKind = "banned"
+	// BanRestricted is a temporary ban that ends at Until, or at the next
+	// successful connection when WhatsApp gave no end.
+	BanRestricted BanKind = "restricted"
+)
+
+// Ban records WhatsApp banning or restricting the session's account.
+// Reason is WhatsApp's own description and Code its numeric reason.
+type Ban struct {
+	Kind   BanKind    `json:"kind"`
+	Reason string     `json:"reason"`
+	Code   int        `json:"code,omitempty"`
+	Since  time.Time  `json:"since"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// Active reports whether the ban still applies at now.
+func (b *Ban) Active(now time.Time) bool {
+	return b != nil && (b.Until == nil || now.Before(*b.Until))
+}
+
+// Status is the session status the ban shows as.
+func (b *Ban) Status() SessionStatus {
+	if b.Kind == BanBanned {
+		return StatusBanned
+	}
+	return StatusRestricted
+}
+
+// CheckBan rejects sends while the session's account is banned or
+// restricted.
+func (s *Session) CheckBan(now time.Time) error {
+	if s.Ban.Active(now) {
+		return &SessionBannedError{Ban: *s.Ban}
+	}
+	return nil
+}
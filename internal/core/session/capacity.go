@@ -0,0 +1,44 @@
+package session
+
+// Capacity is how loaded this instance is, for deciding when to scale out.
+// Connected counts sessions connected or connecting, which is what
+// MaxSessions caps; Loaded also counts sessions kept in memory while
+// disconnected. A zero limit means none.
+type Capacity struct {
+	MaxSessions      int
+	Connected        int
+	Loaded           int
+	MaxPendingEvents int
+	Sessions         []SessionLoad
+}
+
+// SessionLoad is a session with event deliveries still running.
+type SessionLoad struct {
+	Name          string
+	PendingEvents int
+}
+
+// Saturated reports whether the instance refuses new connections.
+func (c Capacity) Saturated() bool {
+	return c.MaxSessions > 0 && c.Connected >= c.MaxSessions
+}
+
+// Available is how many more sessions may connect, or -1 without a limit.
+func (c Capacity) Available() int {
+	if c.MaxSessions <= 0 {
+		return -1
+	}
+	if c.Connected >= c.MaxSessions {
+		return 0
+	}
+	return c.MaxSessions - c.Connected
+}
+
+// CheckCapacity returns a *CapacityError when connecting one more session
+// would go over limit.
+func CheckCapacity(limit, connected int) error {
+	if limit > 0 && connected >= limit {
+		return &CapacityError{Limit: limit, Connected: connected}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
 )
 
 type Repository interface {
@@ -15,6 +17,8 @@ type Repository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	List(ctx context.Context, limit, offset int) ([]*Session, error)
+	ListAfter(ctx context.Context, after *pagination.Cursor, limit int) ([]*Session, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, after *pagination.Cursor, limit, offset int) ([]*Session, error)
 	ListConnected(ctx context.Context) ([]*Session, error)
 	ListByStatus(ctx context.Context, connected bool) ([]*Session, error)
 
@@ -36,30 +40,43 @@ type WhatsAppGateway interface {
 	DisconnectSession(ctx context.Context, sessionName string) error
 	DeleteSession(ctx context.Context, sessionName string) error
 	RestoreSession(ctx context.Context, sessionName string) error
-	RestoreAllSessions(ctx context.Context, sessionNames []string) error
+	RestoreAllSessions(ctx context.Context, sessionNames []string) ([]string, error)
 	RegisterSessionUUID(sessionName, sessionUUID string)
 	SessionExists(sessionName string) bool
 
 	IsSessionConnected(ctx context.Context, sessionName string) (bool, error)
 	GetSessionInfo(ctx context.Context, sessionName string) (*DeviceInfo, error)
+	// Capacity reports the sessions this instance runs against its limits.
+	// ConnectSession fails with a *CapacityError once MaxSessions are
+	// connected.
+	Capacity() Capacity
 
 	GenerateQRCode(ctx context.Context, sessionName string) (*QRCodeResponse, error)
+	// CurrentQRCode is the QR code of a pairing running in this process,
+	// false when the pairing runs elsewhere or there is none.
+	CurrentQRCode(sessionName string) (*QRCodeResponse, bool)
+	CancelPairing(ctx context.Context, sessionName string) error
 
 	SetProxy(ctx context.Context, sessionName string, proxy *ProxyConfig) error
+	ApplySettings(sessionName string, settings Settings)
+
+	ExportDeviceCredentials(ctx context.Context, sessionName string) (*DeviceCredentials, error)
+	ImportDeviceCredentials(ctx context.Context, sessionName string, credentials *DeviceCredentials) error
 
 	SetEventHandler(handler EventHandler)
 
-	SendTextMessage(ctx context.Context, sessionName, to, content string) (*MessageSendResult, error)
-	SendMediaMessage(ctx context.Context, sessionName, to, mediaURL, caption, mediaType string) (*MessageSendResult, error)
-	SendLocationMessage(ctx context.Context, sessionName, to string, latitude, longitude float64, address string) (*MessageSendResult, error)
-	SendContactMessage(ctx context.Context, sessionName, to, contactName, contactPhone string) (*MessageSendResult, error)
+	MessageSender
 }
 
 type EventHandler interface {
 	OnSessionConnected(sessionName string, deviceInfo *DeviceInfo)
 	OnSessionDisconnected(sessionName string, reason string)
+	OnSessionLoggedOut(sessionName string, reason string)
 	OnQRCodeGenerated(sessionName string, qrCode string, expiresAt time.Time)
+	OnQRTimeout(sessionName string, reason string)
 	OnConnectionError(sessionName string, err error)
+	OnSessionThrottled(sessionName string, throttle Throttle)
+	OnSessionBanned(sessionName string, ban Ban)
 	OnMessageReceived(sessionName string, message *WhatsAppMessage)
 	OnMessageSent(sessionName string, messageID string, status string)
 }
@@ -87,9 +104,30 @@ type MessageSendResult struct {
 type QRCodeGenerator interface {
 	Generate(ctx context.Context, sessionName string) (*QRCodeResponse, error)
 	GenerateImage(ctx context.Context, qrCode string) ([]byte, error)
+	RenderPNG(qrCode string, opts QRImageOptions) ([]byte, error)
+	RenderSVG(qrCode string, opts QRImageOptions) ([]byte, error)
+	HasLogo() bool
 	IsExpired(expiresAt time.Time) bool
 }
 
+// SendCounter counts each session's sends per local day for the warm-up
+// ramp. Reserve takes one send from the day's allowance and reports false
+// once limit sends were already taken; Release gives one back.
+type SendCounter interface {
+	Reserve(ctx context.Context, sessionID uuid.UUID, day string, limit int) (bool, error)
+	Release(ctx context.Context, sessionID uuid.UUID, day string) error
+	Count(ctx context.Context, sessionID uuid.UUID, day string) (int, error)
+}
+
+// TenantQuota takes a send from the daily allowance of the tenant owning a
+// session, failing once the tenant has used it up. ReserveMessage returns
+// the day the send was counted on, or "" when the tenant has no limit, for
+// ReleaseMessage to give it back.
+type TenantQuota interface {
+	ReserveMessage(ctx context.Context, tenantID uuid.UUID) (string, error)
+	ReleaseMessage(ctx context.Context, tenantID uuid.UUID, day string) error
+}
+
 // SessionResolver resolves session identifiers between public API (name) and internal logic (UUID)
 // This interface defines the contract for resolving session names to UUIDs
 type SessionResolver interface {
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultDebugCapacity = 2000
+	MaxDebugCapacity     = 20000
+	DefaultDebugDuration = 30 * time.Minute
+	MaxDebugDuration     = 24 * time.Hour
+)
+
+// debugLevels ranks the whatsmeow log levels a capture can start from.
+var debugLevels = map[string]int{
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+}
+
+// DebugLevelRank orders whatsmeow log levels from debug to error. Unknown
+// levels rank as debug.
+func DebugLevelRank(level string) int {
+	return debugLevels[level]
+}
+
+// DebugCaptureOptions switch protocol debug capture on for one session.
+// Level is the lowest whatsmeow level kept, debug including every frame
+// sent and received. Capacity bounds the entries kept, the oldest going
+// first; Duration is how long the capture runs before switching itself off.
+type DebugCaptureOptions struct {
+	Level    string
+	Capacity int
+	Duration time.Duration
+}
+
+// Normalize fills in the defaults and rejects options out of bounds.
+func (o *DebugCaptureOptions) Normalize() error {
+	if o.Level == "" {
+		o.Level = "debug"
+	}
+	if _, ok := debugLevels[o.Level]; !ok {
+		return fmt.Errorf("%w: level must be one of debug, info, warn or error", ErrInvalidDebugCapture)
+	}
+
+	if o.Capacity == 0 {
+		o.Capacity = DefaultDebugCapacity
+	}
+	if o.Capacity < 0 || o.Capacity > MaxDebugCapacity {
+		return fmt.Errorf("%w: capacity must be between 1 and %d", ErrInvalidDebugCapture, MaxDebugCapacity)
+	}
+
+	if o.Duration == 0 {
+		o.Duration = DefaultDebugDuration
+	}
+	if o.Duration < time.Minute || o.Duration > MaxDebugDuration {
+		return fmt.Errorf("%w: duration must be between 1 minute and %s", ErrInvalidDebugCapture, MaxDebugDuration)
+	}
+	return nil
+}
+
+// DebugCapture describes a session's protocol debug capture. Entries are
+// the ones kept now; Dropped counts those pushed out by newer ones. A
+// capture past ExpiresAt keeps its entries but records nothing more.
+type DebugCapture struct {
+	SessionName string
+	Level       string
+	Capacity    int
+	StartedAt   time.Time
+	ExpiresAt   time.Time
+	Active      bool
+	Entries     int
+	Dropped     int64
+}
+
+// DebugLogEntry is one whatsmeow log line captured for a session. Module is
+// the whatsmeow logger it came from, such as Client/Send or Client/Recv.
+type DebugLogEntry struct {
+	Time    time.Time
+	Level   string
+	Module  string
+	Message string
+}
+
+// ProtocolDebugger keeps verbose whatsmeow logs for single sessions apart
+// from the application log, so pairing and connection problems can be
+// looked into without raising the global log level. Captures live in
+// memory and are lost on restart.
+type ProtocolDebugger interface {
+	StartCapture(sessionName string, options DebugCaptureOptions) *DebugCapture
+	StopCapture(sessionName string) (*DebugCapture, bool)
+	CaptureLogs(sessionName, level string, limit int) (*DebugCapture, []DebugLogEntry, bool)
+}
@@ -0,0 +1,218 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// Device is one of the devices registered under the session's WhatsApp
+// account: the phone, with ID 0, and the companions linked to it, this
+// session among them. WhatsApp does not say which platform a companion
+// runs on; Platform is known for the phone, for this session and for
+// companions once they send a message, guessed from its ID. LastSeenAt is
+// the last message or receipt the session saw coming from the device.
+type Device struct {
+	SessionID   uuid.UUID
+	ID          int
+	JID         string
+	Platform    string
+	Primary     bool
+	Current     bool
+	FirstSeenAt time.Time
+	LastSeenAt  *time.Time
+}
+
+// DeviceRepository keeps the devices each session has seen under its
+// account. Touch reports false for devices it does not know.
+type DeviceRepository interface {
+	List(ctx context.Context, sessionID uuid.UUID) ([]*Device, error)
+	Add(ctx context.Context, device *Device) error
+	Touch(ctx context.Context, sessionID uuid.UUID, deviceID int, platform string, at time.Time) (bool, error)
+	Remove(ctx context.Context, sessionID uuid.UUID, deviceIDs []int) error
+}
+
+// DeviceSource asks WhatsApp for the devices registered under the
+// session's account.
+type DeviceSource interface {
+	FetchDevices(ctx context.Context, sessionName string) ([]*Device, error)
+}
+
+// DeviceAddedHandler is told about every companion linked to a session's
+// account after the tracker first saw it.
+type DeviceAddedHandler func(ctx context.Context, sessionName string, device *Device)
+
+// DeviceTracker follows the companion devices linked to each session's
+// account, so that a device linked by someone else shows up. The first list
+// seen for an account is taken as it is; devices appearing after it are
+// reported to the handlers.
+type DeviceTracker struct {
+	source   DeviceSource
+	repo     DeviceRepository
+	handlers []DeviceAddedHandler
+	logger   *logger.Logger
+}
+
+func NewDeviceTracker(source DeviceSource, repo DeviceRepository, logger *logger.Logger) *DeviceTracker {
+	return &DeviceTracker{
+		source: source,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// OnAdded adds a handler for new devices. Handlers run in the order added
+// and must not block for long.
+func (t *DeviceTracker) OnAdded(handler DeviceAddedHandler) {
+	t.handlers = append(t.handlers, handler)
+}
+
+// List asks WhatsApp for the account's devices, records devices added or
+// removed since the last look and returns them with what the session saw
+// of each, ordered by ID.
+func (t *DeviceTracker) List(ctx context.Context, sessionID uuid.UUID, sessionName string) ([]*Device, error) {
+	fetched, err := t.source.FetchDevices(ctx, sessionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch devices: %w", err)
+	}
+
+	stored, err := t.repo.List(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]*Device, len(stored))
+	for _, device := range stored {
+		known[device.ID] = device
+	}
+
+	// A session paired again with another number starts over.
+	account := ""
+	if len(fetched) > 0 {
+		account = deviceUser(fetched[0].JID)
+	}
+	current := make(map[int]bool, len(fetched))
+	for _, device := range fetched {
+		current[device.ID] = true
+	}
+	var removed []int
+	for id, device := range known {
+		if !current[id] || deviceUser(device.JID) != account {
+			removed = append(removed, id)
+			delete(known, id)
+		}
+	}
+	if len(removed) > 0 {
+		if err := t.repo.Remove(ctx, sessionID, removed); err != nil {
+			return nil, err
+		}
+	}
+
+	baseline := len(known) == 0
+	now := time.Now()
+	var added []*Device
+
+	for _, device := range fetched {
+		device.SessionID = sessionID
+		if previous, ok := known[device.ID]; ok {
+			device.FirstSeenAt = previous.FirstSeenAt
+			device.LastSeenAt = previous.LastSeenAt
+			if device.Platform == "" {
+				device.Platform = previous.Platform
+			}
+			continue
+		}
+
+		device.FirstSeenAt = now
+		if err := t.repo.Add(ctx, device); err != nil {
+			return nil, err
+		}
+		if !baseline && !device.Current {
+			added = append(added, device)
+		}
+	}
+
+	for _, device := range added {
+		t.logger.InfoWithFields("Companion device linked", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"device_jid": device.JID,
+		})
+		for _, handler := range t.handlers {
+			handler(ctx, sessionName, device)
+		}
+	}
+
+	sort.Slice(fetched, func(i, j int) bool { return fetched[i].ID < fetched[j].ID })
+	return fetched, nil
+}
+
+// Sync records devices added or removed since the last look. Failures are
+// only logged; the next look catches up.
+func (t *DeviceTracker) Sync(ctx context.Context, sessionID uuid.UUID, sessionName string) {
+	if _, err := t.List(ctx, sessionID, sessionName); err != nil {
+		t.logger.DebugWithFields("Failed to sync devices", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+// Seen records activity from one of the account's devices. A device not
+// known yet makes the tracker look at the list again, which reports it.
+func (t *DeviceTracker) Seen(ctx context.Context, sessionID uuid.UUID, sessionName string, deviceID int, platform string, at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	known, err := t.repo.Touch(ctx, sessionID, deviceID, platform, at)
+	if err != nil {
+		t.logger.DebugWithFields("Failed to record device activity", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"device_id":  deviceID,
+			"error":      err.Error(),
+		})
+		return
+	}
+	if known {
+		return
+	}
+
+	t.Sync(ctx, sessionID, sessionName)
+	if _, err := t.repo.Touch(ctx, sessionID, deviceID, platform, at); err != nil {
+		t.logger.DebugWithFields("Failed to record device activity", map[string]interface{}{
+			"session_id": sessionID.String(),
+			"device_id":  deviceID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// deviceUser is the account part of a device JID, without the device.
+func deviceUser(jid string) string {
+	user, _, _ := strings.Cut(jid, "@")
+	user, _, _ = strings.Cut(user, ":")
+	return user
+}
+
+// PlatformFromMessageID guesses the platform a message was sent from by the
+// shape of its ID, as each WhatsApp client generates IDs its own way. It
+// returns an empty string when the ID matches none of them.
+func PlatformFromMessageID(id string) string {
+	switch {
+	case strings.HasPrefix(id, "3A") && len(id) == 20:
+		return "ios"
+	case strings.HasPrefix(id, "3E") && len(id) == 22:
+		return "web"
+	case len(id) == 21 || len(id) == 32:
+		return "android"
+	case strings.HasPrefix(id, "3F") || len(id) == 18:
+		return "desktop"
+	}
+	return ""
+}
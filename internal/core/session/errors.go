@@ -1,6 +1,10 @@
 package session
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrInvalidSessionName = errors.New("session name is required")
@@ -12,14 +16,245 @@ var (
 	ErrSessionAlreadyExists    = errors.New("session with this name already exists")
 	ErrSessionNotConnected     = errors.New("session is not connected")
 	ErrSessionAlreadyConnected = errors.New("session is already connected")
+	ErrInstanceAtCapacity      = errors.New("instance reached its maximum of connected sessions")
 
 	ErrConnectionFailed   = errors.New("failed to connect to WhatsApp")
 	ErrQRCodeExpired      = errors.New("QR code has expired")
 	ErrQRCodeNotAvailable = errors.New("QR code is not available")
+	ErrNoPairingPending   = errors.New("no QR pairing in progress")
+	ErrNoDebugCapture     = errors.New("no protocol debug capture for this session")
 	ErrPairingFailed      = errors.New("device pairing failed")
 	ErrLogoutFailed       = errors.New("failed to logout session")
 
+	ErrSessionNotPaired      = errors.New("session has no paired device")
+	ErrInvalidBackup         = errors.New("invalid session backup")
+	ErrInvalidPassphrase     = errors.New("invalid backup passphrase")
+	ErrUnsupportedBackup     = errors.New("unsupported session backup version")
+	ErrDeviceAlreadyImported = errors.New("device is already registered on this instance")
+
+	ErrInvalidButtonMessage  = errors.New("validation failed: invalid button message")
+	ErrInvalidPollMessage    = errors.New("validation failed: invalid poll message")
+	ErrInvalidContactCard    = errors.New("validation failed: invalid contact")
+	ErrInvalidCallSettings   = errors.New("validation failed: invalid call settings")
+	ErrInvalidMediaSettings  = errors.New("validation failed: invalid media settings")
+	ErrInvalidQRImage        = errors.New("validation failed: invalid QR image options")
+	ErrInvalidQuietHours     = errors.New("validation failed: invalid quiet hours")
+	ErrInvalidMediaPolicy    = errors.New("validation failed: invalid media policy")
+	ErrInvalidFooter         = errors.New("validation failed: invalid footer")
+	ErrInvalidWarmUp         = errors.New("validation failed: invalid warm-up settings")
+	ErrInvalidRetention      = errors.New("validation failed: invalid retention settings")
+	ErrInvalidPolicy         = errors.New("validation failed: invalid session policy")
+	ErrInvalidTimezone       = errors.New("validation failed: invalid timezone")
+	ErrInvalidFeatures       = errors.New("validation failed: invalid feature flags")
+	ErrInvalidPaymentRequest = errors.New("validation failed: invalid payment request")
+	ErrInvalidGroupRules     = errors.New("validation failed: invalid group rules")
+	ErrInvalidDebugCapture   = errors.New("validation failed: invalid debug capture")
+	ErrInvalidInboundFilter  = errors.New("validation failed: invalid inbound filter")
+	ErrInvalidPairing        = errors.New("validation failed: invalid pairing settings")
+
+	ErrQuietHours           = errors.New("session is in quiet hours")
+	ErrWarmUpLimit          = errors.New("session reached its warm-up daily limit")
+	ErrSendQueueFull        = errors.New("session send queue is full")
+	ErrSessionThrottled     = errors.New("session is throttled by WhatsApp")
+	ErrMediaTooLarge        = errors.New("media exceeds the session size limit")
+	ErrMediaTypeNotAllowed  = errors.New("media type is not allowed for this session")
+	ErrMediaUploadFailed    = errors.New("failed to upload media")
+	ErrSendUnconfirmed      = errors.New("send was not confirmed by WhatsApp")
+	ErrPolicyDenied         = errors.New("not allowed by the session policy")
+	ErrSessionBanned        = errors.New("session account is banned by WhatsApp")
+	ErrFeatureDisabled      = errors.New("feature is disabled for this session")
+	ErrPaymentsNotSupported = errors.New("payments are not supported for this account")
+	ErrGroupRuleViolation   = errors.New("not allowed by the group's posting rules")
+
 	ErrSessionBusy      = errors.New("session is busy with another operation")
 	ErrInvalidOperation = errors.New("invalid operation for current session state")
 	ErrOperationTimeout = errors.New("operation timed out")
 )
+
+// QuietHoursError rejects a send submitted inside the session's quiet window
+// and says when sending is allowed again.
+type QuietHoursError struct {
+	ResumeAt time.Time
+}
+
+func (e *QuietHoursError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrQuietHours, e.ResumeAt.Format(time.RFC3339))
+}
+
+func (e *QuietHoursError) Unwrap() error {
+	return ErrQuietHours
+}
+
+// WarmUpLimitError rejects a send over the day's warm-up cap and says when
+// the next day's allowance starts.
+type WarmUpLimitError struct {
+	Limit    int
+	ResumeAt time.Time
+}
+
+func (e *WarmUpLimitError) Error() string {
+	return fmt.Sprintf("%s of %d messages until %s", ErrWarmUpLimit, e.Limit, e.ResumeAt.Format(time.RFC3339))
+}
+
+func (e *WarmUpLimitError) Unwrap() error {
+	return ErrWarmUpLimit
+}
+
+// SendQueueFullError rejects a send while the session already has Limit
+// sends in its queue. RetryAfter estimates when a slot frees up.
+type SendQueueFullError struct {
+	Depth      int
+	Limit      int
+	RetryAfter time.Duration
+}
+
+func (e *SendQueueFullError) Error() string {
+	return fmt.Sprintf("%s (%d of %d)", ErrSendQueueFull, e.Depth, e.Limit)
+}
+
+func (e *SendQueueFullError) Unwrap() error {
+	return ErrSendQueueFull
+}
+
+// CapacityError rejects a connect while the instance already runs Limit
+// connected sessions, so the session can be placed on another instance.
+type CapacityError struct {
+	Limit     int
+	Connected int
+}
+
+func (e *CapacityError) Error() string {
+	return fmt.Sprintf("%s (%d of %d)", ErrInstanceAtCapacity, e.Connected, e.Limit)
+}
+
+func (e *CapacityError) Unwrap() error {
+	return ErrInstanceAtCapacity
+}
+
+// SessionThrottledError rejects a send while the session cools down after
+// WhatsApp rate-limited or temporarily banned it.
+type SessionThrottledError struct {
+	Throttle Throttle
+}
+
+func (e *SessionThrottledError) Error() string {
+	return fmt.Sprintf("%s (%s) until %s", ErrSessionThrottled, e.Throttle.Reason, e.Throttle.Until.Format(time.RFC3339))
+}
+
+func (e *SessionThrottledError) Unwrap() error {
+	return ErrSessionThrottled
+}
+
+// SendUnconfirmedError reports a send that failed after its message may have
+// gone out: the operation timed out or the socket dropped before WhatsApp
+// acknowledged it. Retrying under MessageID (see WithMessageID) cannot
+// deliver it twice.
+type SendUnconfirmedError struct {
+	MessageID string
+	Err       error
+}
+
+func (e *SendUnconfirmedError) Error() string {
+	return fmt.Sprintf("%s (message %s): %v", ErrSendUnconfirmed, e.MessageID, e.Err)
+}
+
+func (e *SendUnconfirmedError) Unwrap() []error {
+	return []error{ErrSendUnconfirmed, e.Err}
+}
+
+// SessionBannedError rejects a send while WhatsApp bans or restricts the
+// session's account.
+type SessionBannedError struct {
+	Ban Ban
+}
+
+func (e *SessionBannedError) Error() string {
+	if e.Ban.Until != nil {
+		return fmt.Sprintf("%s (%s: %s) until %s", ErrSessionBanned, e.Ban.Kind, e.Ban.Reason, e.Ban.Until.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s (%s: %s)", ErrSessionBanned, e.Ban.Kind, e.Ban.Reason)
+}
+
+func (e *SessionBannedError) Unwrap() error {
+	return ErrSessionBanned
+}
+
+// PolicyError rejects a send the session's mode does not allow.
+type PolicyError struct {
+	Mode      Mode
+	Recipient string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s: %s sessions cannot send to %s", ErrPolicyDenied, e.Mode, e.Recipient)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyDenied
+}
+
+// FeatureDisabledError rejects an operation that needs a feature the
+// session switched off.
+type FeatureDisabledError struct {
+	Feature Feature
+}
+
+func (e *FeatureDisabledError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrFeatureDisabled, e.Feature)
+}
+
+func (e *FeatureDisabledError) Unwrap() error {
+	return ErrFeatureDisabled
+}
+
+// PaymentsNotSupportedError rejects a payment request the session's account
+// cannot make: its country has no WhatsApp payments, or they are made in
+// another currency. Currency is the one the account could request in, if
+// any.
+type PaymentsNotSupportedError struct {
+	Account   string
+	Currency  string
+	Requested string
+}
+
+func (e *PaymentsNotSupportedError) Error() string {
+	if e.Currency == "" {
+		return fmt.Sprintf("%s: WhatsApp payments are not available in the account's country", ErrPaymentsNotSupported)
+	}
+	return fmt.Sprintf("%s: the account can request payments in %s only, not %s", ErrPaymentsNotSupported, e.Currency, e.Requested)
+}
+
+func (e *PaymentsNotSupportedError) Unwrap() error {
+	return ErrPaymentsNotSupported
+}
+
+// Group rules a post can break, as GroupRuleError reports them.
+const (
+	GroupRuleRate       = "rate"
+	GroupRuleType       = "type"
+	GroupRuleBannedWord = "banned_word"
+)
+
+// GroupRuleError rejects a post that breaks the posting rules the session
+// set for a group. Detail is the offending type or word, or the cap; over
+// the cap ResumeAt says when the next post fits in the hour.
+type GroupRuleError struct {
+	Group    string
+	Rule     string
+	Detail   string
+	ResumeAt time.Time
+}
+
+func (e *GroupRuleError) Error() string {
+	switch e.Rule {
+	case GroupRuleRate:
+		return fmt.Sprintf("%s: %s reached %s, next post at %s", ErrGroupRuleViolation, e.Group, e.Detail, e.ResumeAt.Format(time.RFC3339))
+	case GroupRuleType:
+		return fmt.Sprintf("%s: %s does not take %s messages", ErrGroupRuleViolation, e.Group, e.Detail)
+	}
+	return fmt.Sprintf("%s: text contains a word banned in %s", ErrGroupRuleViolation, e.Group)
+}
+
+func (e *GroupRuleError) Unwrap() error {
+	return ErrGroupRuleViolation
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Feature names a behaviour a session can switch on or off at runtime.
+type Feature string
+
+const (
+	// FeatureButtons allows sending button messages.
+	FeatureButtons Feature = "enableButtons"
+	// FeatureChatwoot forwards the session's messages to Chatwoot and routes
+	// agent replies from its inbox.
+	FeatureChatwoot Feature = "enableChatwoot"
+	// FeatureAutoRead marks every incoming message as read on arrival.
+	FeatureAutoRead Feature = "enableAutoRead"
+	// FeatureLinkPreview attaches a preview of the first link in sent text.
+	FeatureLinkPreview Feature = "enableLinkPreview"
+)
+
+// featureDefaults holds every known feature with the value it has when the
+// session sets none, which keeps sessions created before a flag existed
+// behaving as they did.
+var featureDefaults = map[Feature]bool{
+	FeatureButtons:     true,
+	FeatureChatwoot:    true,
+	FeatureAutoRead:    false,
+	FeatureLinkPreview: false,
+}
+
+// Features are the session's feature flags. Only flags set explicitly are
+// stored; the rest take their defaults.
+type Features map[Feature]bool
+
+// Enabled reports whether the feature is on for the session.
+func (f Features) Enabled(feature Feature) bool {
+	if enabled, ok := f[feature]; ok {
+		return enabled
+	}
+	return featureDefaults[feature]
+}
+
+// Require fails with a *FeatureDisabledError when the feature is off.
+func (f Features) Require(feature Feature) error {
+	if !f.Enabled(feature) {
+		return &FeatureDisabledError{Feature: feature}
+	}
+	return nil
+}
+
+// Effective returns every known flag with its value for the session.
+func (f Features) Effective() map[Feature]bool {
+	effective := make(map[Feature]bool, len(featureDefaults))
+	for feature := range featureDefaults {
+		effective[feature] = f.Enabled(feature)
+	}
+	return effective
+}
+
+// KnownFeatures lists the feature names, sorted.
+func KnownFeatures() []Feature {
+	known := make([]Feature, 0, len(featureDefaults))
+	for feature := range featureDefaults {
+		known = append(known, feature)
+	}
+	sort.Slice(known, func(i, j int) bool { return known[i] < known[j] })
+	return known
+}
+
+// SetFeatures toggles the given flags, leaving the others as they are, and
+// returns the session's flags after the change. Unknown flags are rejected.
+func (s *Service) SetFeatures(ctx context.Context, id uuid.UUID, changes Features) (Features, error) {
+	for feature := range changes {
+		if _, ok := featureDefaults[feature]; !ok {
+			return nil, fmt.Errorf("%w: unknown feature %q, known features are %v", ErrInvalidFeatures, feature, KnownFeatures())
+		}
+	}
+
+	var updated Features
+	err := s.updateSettings(ctx, id, func(current *Settings) {
+		if current.Features == nil {
+			current.Features = Features{}
+		}
+		for feature, enabled := range changes {
+			current.Features[feature] = enabled
+		}
+		updated = current.Features
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,210 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxGroupPostsPerHour = 3600
+	MaxGroupBannedWords  = 200
+)
+
+// groupMessageTypes are the message types group rules can allow. Media is
+// told apart by its type, so a group can take images but no documents.
+var groupMessageTypes = map[string]bool{
+	"text":          true,
+	"image":         true,
+	"audio":         true,
+	"video":         true,
+	"document":      true,
+	"sticker":       true,
+	"location":      true,
+	"live_location": true,
+	"contact":       true,
+	"button":        true,
+	"poll":          true,
+	"payment":       true,
+}
+
+// GroupRules limit what the session may post to one group, so a
+// misconfigured bot cannot flood a large group and get the account banned.
+// MaxPerHour caps posts over any rolling hour, zero meaning no cap.
+// AllowedTypes, when set, are the only message types accepted. BannedWords
+// reject posts whose text contains any of them, ignoring case.
+type GroupRules struct {
+	MaxPerHour   int      `json:"maxPerHour,omitempty"`
+	AllowedTypes []string `json:"allowedTypes,omitempty"`
+	BannedWords  []string `json:"bannedWords,omitempty"`
+}
+
+func (r GroupRules) Validate() error {
+	if r.MaxPerHour < 0 || r.MaxPerHour > MaxGroupPostsPerHour {
+		return fmt.Errorf("%w: maxPerHour must be between 0 and %d", ErrInvalidGroupRules, MaxGroupPostsPerHour)
+	}
+	for _, kind := range r.AllowedTypes {
+		if !groupMessageTypes[kind] {
+			return fmt.Errorf("%w: unknown message type %q, known types are %v", ErrInvalidGroupRules, kind, GroupMessageTypes())
+		}
+	}
+	if len(r.BannedWords) > MaxGroupBannedWords {
+		return fmt.Errorf("%w: at most %d banned words", ErrInvalidGroupRules, MaxGroupBannedWords)
+	}
+	for _, word := range r.BannedWords {
+		if strings.TrimSpace(word) == "" {
+			return fmt.Errorf("%w: banned words cannot be empty", ErrInvalidGroupRules)
+		}
+	}
+	return nil
+}
+
+// Check rejects a post of the given type and text the rules do not allow,
+// with a *GroupRuleError. The hourly cap is not checked here.
+func (r GroupRules) Check(group, kind, text string) error {
+	if len(r.AllowedTypes) > 0 {
+		allowed := false
+		for _, t := range r.AllowedTypes {
+			if t == kind {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return &GroupRuleError{Group: group, Rule: GroupRuleType, Detail: kind}
+		}
+	}
+
+	if text != "" {
+		lower := strings.ToLower(text)
+		for _, word := range r.BannedWords {
+			if strings.Contains(lower, strings.ToLower(strings.TrimSpace(word))) {
+				return &GroupRuleError{Group: group, Rule: GroupRuleBannedWord, Detail: word}
+			}
+		}
+	}
+	return nil
+}
+
+// GroupMessageTypes lists the message types group rules know, sorted.
+func GroupMessageTypes() []string {
+	types := make([]string, 0, len(groupMessageTypes))
+	for kind := range groupMessageTypes {
+		types = append(types, kind)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// groupPostLog remembers when each session posted to each group over the
+// last hour. It lives in memory: a restart forgets the posts, which only
+// lets the next hour start early.
+type groupPostLog struct {
+	mu    sync.Mutex
+	posts map[string][]time.Time
+}
+
+func newGroupPostLog() *groupPostLog {
+	return &groupPostLog{posts: make(map[string][]time.Time)}
+}
+
+// reserve records a post unless limit posts were made in the hour before
+// now, in which case it returns when the oldest of them leaves the hour.
+func (l *groupPostLog) reserve(sessionID uuid.UUID, group string, limit int, now time.Time) (time.Time, bool) {
+	key := sessionID.String() + "|" + group
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	since := now.Add(-time.Hour)
+	posts := l.posts[key]
+	kept := posts[:0]
+	for _, at := range posts {
+		if at.After(since) {
+			kept = append(kept, at)
+		}
+	}
+
+	if len(kept) >= limit {
+		l.posts[key] = kept
+		return kept[len(kept)-limit].Add(time.Hour), false
+	}
+
+	l.posts[key] = append(kept, now)
+	return time.Time{}, true
+}
+
+// release forgets a post reserve recorded at at, for a send that failed.
+func (l *groupPostLog) release(sessionID uuid.UUID, group string, at time.Time) {
+	key := sessionID.String() + "|" + group
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	posts := l.posts[key]
+	for i, posted := range posts {
+		if posted.Equal(at) {
+			l.posts[key] = append(posts[:i], posts[i+1:]...)
+			return
+		}
+	}
+}
+
+// CheckGroupPost applies the rules the session set for the group, if any,
+// to a post of the given type and text. With reserve the post also takes a
+// slot of the group's hourly cap; the returned func gives the slot back and
+// must be called when the send fails. Rejections are *GroupRuleError.
+func (s *Service) CheckGroupPost(session *Session, group, kind, text string, reserve bool) (func(), error) {
+	rules, ok := session.Settings.GroupRules[group]
+	if !ok {
+		return func() {}, nil
+	}
+
+	if err := rules.Check(group, kind, text); err != nil {
+		return nil, err
+	}
+
+	if !reserve || rules.MaxPerHour <= 0 {
+		return func() {}, nil
+	}
+
+	now := time.Now()
+	if resumeAt, ok := s.posts.reserve(session.ID, group, rules.MaxPerHour, now); !ok {
+		return nil, &GroupRuleError{
+			Group:    group,
+			Rule:     GroupRuleRate,
+			Detail:   fmt.Sprintf("%d posts per hour", rules.MaxPerHour),
+			ResumeAt: resumeAt,
+		}
+	}
+	return func() { s.posts.release(session.ID, group, now) }, nil
+}
+
+// SetGroupRules saves the posting rules for one group, replacing any it
+// had. Nil rules remove them.
+func (s *Service) SetGroupRules(ctx context.Context, id uuid.UUID, group string, rules *GroupRules) error {
+	if !strings.HasSuffix(group, "@g.us") {
+		return fmt.Errorf("%w: %q is not a group JID", ErrInvalidGroupRules, group)
+	}
+	if rules != nil {
+		if err := rules.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		if rules == nil {
+			delete(current.GroupRules, group)
+			return
+		}
+		if current.GroupRules == nil {
+			current.GroupRules = make(map[string]GroupRules)
+		}
+		current.GroupRules[group] = *rules
+	})
+}
@@ -0,0 +1,297 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
+)
+
+const (
+	MaxFilterRules         = 50
+	MaxFilterKeywords      = 200
+	MaxFilterSenders       = 200
+	MaxFilterPatternLength = 500
+	MaxFilterReplyLength   = 1000
+)
+
+// FilterAction is what happens to an inbound message a filter rule matches.
+// Tag marks it in its webhook payload, drop keeps it from webhooks and
+// Chatwoot, reply answers the sender once an hour and block adds the sender
+// to the account's blocklist. Filtered messages are stored and logged
+// whatever the actions.
+type FilterAction string
+
+const (
+	FilterTag   FilterAction = "tag"
+	FilterDrop  FilterAction = "drop"
+	FilterReply FilterAction = "reply"
+	FilterBlock FilterAction = "block"
+)
+
+// Conditions a filter rule can match on, as FilterMatch reports them.
+const (
+	FilterMatchKeyword  = "keyword"
+	FilterMatchPattern  = "pattern"
+	FilterMatchSender   = "sender"
+	FilterMatchLinkOnly = "link_only"
+)
+
+// FilterRule matches an inbound message when every condition it sets holds:
+// its text contains one of Keywords (ignoring case), matches Pattern (a Go
+// regular expression), the sender matches one of Senders (phone numbers or
+// JIDs, * matching any run of characters, e.g. "234*" or "*@lid"), and with
+// LinkOnly the text is nothing but links.
+type FilterRule struct {
+	Name     string         `json:"name,omitempty"`
+	Keywords []string       `json:"keywords,omitempty"`
+	Pattern  string         `json:"pattern,omitempty"`
+	Senders  []string       `json:"senders,omitempty"`
+	LinkOnly bool           `json:"linkOnly,omitempty"`
+	Actions  []FilterAction `json:"actions"`
+	Reply    string         `json:"reply,omitempty"`
+}
+
+// InboundFilterSettings screen the messages contacts send the session.
+// Rules are tried in order and the first that matches applies. Group
+// messages are screened only with Groups.
+type InboundFilterSettings struct {
+	Rules  []FilterRule `json:"rules,omitempty"`
+	Groups bool         `json:"groups,omitempty"`
+}
+
+func (f InboundFilterSettings) Validate() error {
+	if len(f.Rules) > MaxFilterRules {
+		return fmt.Errorf("%w: at most %d rules", ErrInvalidInboundFilter, MaxFilterRules)
+	}
+	for i, rule := range f.Rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("%w: rule %d: %s", ErrInvalidInboundFilter, i+1, err)
+		}
+	}
+	return nil
+}
+
+func (r FilterRule) validate() error {
+	if len(r.Keywords) == 0 && r.Pattern == "" && len(r.Senders) == 0 && !r.LinkOnly {
+		return fmt.Errorf("needs keywords, a pattern, senders or linkOnly")
+	}
+	if len(r.Keywords) > MaxFilterKeywords {
+		return fmt.Errorf("at most %d keywords", MaxFilterKeywords)
+	}
+	for _, keyword := range r.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return fmt.Errorf("keywords cannot be empty")
+		}
+	}
+	if len(r.Pattern) > MaxFilterPatternLength {
+		return fmt.Errorf("pattern is longer than %d characters", MaxFilterPatternLength)
+	}
+	if r.Pattern != "" {
+		if _, err := compileFilterPattern(r.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %s", err)
+		}
+	}
+	if len(r.Senders) > MaxFilterSenders {
+		return fmt.Errorf("at most %d senders", MaxFilterSenders)
+	}
+	for _, sender := range r.Senders {
+		if strings.Trim(sender, "* ") == "" {
+			return fmt.Errorf("sender patterns must have more than wildcards")
+		}
+	}
+
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("needs at least one action")
+	}
+	for _, action := range r.Actions {
+		switch action {
+		case FilterTag, FilterDrop, FilterBlock:
+		case FilterReply:
+			if strings.TrimSpace(r.Reply) == "" {
+				return fmt.Errorf("the reply action needs a reply")
+			}
+		default:
+			return fmt.Errorf("unknown action %q, use tag, drop, reply or block", action)
+		}
+	}
+	if len(r.Reply) > MaxFilterReplyLength {
+		return fmt.Errorf("reply is longer than %d characters", MaxFilterReplyLength)
+	}
+	return nil
+}
+
+// FilterMatch is the rule an inbound message matched, with the conditions
+// it met and, for keywords, the one found.
+type FilterMatch struct {
+	Rule       string         `json:"rule"`
+	Conditions []string       `json:"conditions"`
+	Keyword    string         `json:"keyword,omitempty"`
+	Actions    []FilterAction `json:"actions"`
+	Reply      string         `json:"-"`
+}
+
+// Has reports whether the match takes action.
+func (m *FilterMatch) Has(action FilterAction) bool {
+	for _, a := range m.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the first rule a message from sender (a JID) with text
+// matches, or nil.
+func (f InboundFilterSettings) Match(sender, text string) *FilterMatch {
+	for i, rule := range f.Rules {
+		if match := rule.match(sender, text); match != nil {
+			if match.Rule == "" {
+				match.Rule = fmt.Sprintf("rule %d", i+1)
+			}
+			return match
+		}
+	}
+	return nil
+}
+
+func (r FilterRule) match(sender, text string) *FilterMatch {
+	match := &FilterMatch{Rule: r.Name, Actions: r.Actions, Reply: r.Reply}
+
+	if len(r.Keywords) > 0 {
+		lower := strings.ToLower(text)
+		for _, keyword := range r.Keywords {
+			if strings.Contains(lower, strings.ToLower(strings.TrimSpace(keyword))) {
+				match.Keyword = keyword
+				break
+			}
+		}
+		if match.Keyword == "" {
+			return nil
+		}
+		match.Conditions = append(match.Conditions, FilterMatchKeyword)
+	}
+
+	if r.Pattern != "" {
+		re, err := compileFilterPattern(r.Pattern)
+		if err != nil || !re.MatchString(text) {
+			return nil
+		}
+		match.Conditions = append(match.Conditions, FilterMatchPattern)
+	}
+
+	if len(r.Senders) > 0 {
+		if !matchSender(r.Senders, sender) {
+			return nil
+		}
+		match.Conditions = append(match.Conditions, FilterMatchSender)
+	}
+
+	if r.LinkOnly {
+		if !IsLinkOnly(text) {
+			return nil
+		}
+		match.Conditions = append(match.Conditions, FilterMatchLinkOnly)
+	}
+
+	return match
+}
+
+// filterPatterns caches compiled rule patterns; rules are few and change
+// rarely, while every inbound message is matched against them.
+var filterPatterns sync.Map
+
+func compileFilterPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := filterPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	filterPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// matchSender matches sender's JID, and its user part alone (the phone
+// number for phone JIDs), against glob patterns.
+func matchSender(patterns []string, sender string) bool {
+	user, _, _ := strings.Cut(sender, "@")
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "+")
+		if globMatch(pattern, sender) || globMatch(pattern, user) {
+			return true
+		}
+	}
+	return false
+}
+
+func globMatch(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}(?:/\S*)?`)
+
+// IsLinkOnly reports whether text has at least one link and nothing else
+// but whitespace and punctuation, the shape of most link spam.
+func IsLinkOnly(text string) bool {
+	if !linkPattern.MatchString(text) {
+		return false
+	}
+	rest := linkPattern.ReplaceAllString(text, "")
+	return strings.Trim(rest, " \t\r\n.,;:!?-–—()[]<>\"'*_~") == ""
+}
+
+// SetInboundFilter replaces the session's inbound filter rules.
+func (s *Service) SetInboundFilter(ctx context.Context, id uuid.UUID, settings InboundFilterSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.InboundFilter = settings
+	})
+}
+
+// FilteredMessage is an inbound message a filter rule matched, kept for
+// review with what was done about it. ActionErrors has the actions that
+// failed, with why.
+type FilteredMessage struct {
+	ID           uuid.UUID
+	SessionID    uuid.UUID
+	MessageID    string
+	ChatJID      string
+	SenderJID    string
+	Text         string
+	Match        FilterMatch
+	ActionErrors map[FilterAction]string
+	CreatedAt    time.Time
+}
+
+// FilterLog keeps the messages the inbound filter matched. List returns
+// them newest first.
+type FilterLog interface {
+	Record(ctx context.Context, message *FilteredMessage) error
+	List(ctx context.Context, sessionID uuid.UUID, page pagination.Request) ([]*FilteredMessage, error)
+}
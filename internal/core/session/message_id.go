@@ -0,0 +1,21 @@
+package session
+
+import "context"
+
+type messageIDKey struct{}
+
+// WithMessageID makes the gateway send the message built with ctx under id
+// instead of a new ID. Retries of an unconfirmed send use it: the recipient's
+// apps keep a single message per ID, and the gateway skips the send when the
+// first attempt was confirmed in the meantime.
+func WithMessageID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, messageIDKey{}, id)
+}
+
+func MessageIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(messageIDKey{}).(string)
+	return id
+}
@@ -2,6 +2,10 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
+	"image/color"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,10 +20,15 @@ type Session struct {
 	QRCode          *string      `json:"qrCode,omitempty"`
 	QRCodeExpiresAt *time.Time   `json:"qrCodeExpiresAt,omitempty"`
 	ProxyConfig     *ProxyConfig `json:"proxyConfig,omitempty"`
+	Settings        Settings     `json:"settings"`
 	CreatedAt       time.Time    `json:"createdAt"`
 	UpdatedAt       time.Time    `json:"updatedAt"`
 	ConnectedAt     *time.Time   `json:"connectedAt,omitempty"`
 	LastSeen        *time.Time   `json:"lastSeen,omitempty"`
+	LoggedOutAt     *time.Time   `json:"loggedOutAt,omitempty"`
+	QRTimeoutAt     *time.Time   `json:"qrTimeoutAt,omitempty"`
+	Ban             *Ban         `json:"ban,omitempty"`
+	TenantID        *uuid.UUID   `json:"tenantId,omitempty"`
 }
 
 type ProxyConfig struct {
@@ -30,11 +39,433 @@ type ProxyConfig struct {
 	Password string `json:"password,omitempty"`
 }
 
+type Settings struct {
+	Calls       CallSettings          `json:"calls"`
+	Media       MediaSettings         `json:"media"`
+	QuietHours  QuietHoursSettings    `json:"quietHours"`
+	MediaPolicy MediaPolicy           `json:"mediaPolicy"`
+	Footer      FooterSettings        `json:"footer"`
+	TextFormat  TextFormatSettings    `json:"textFormat"`
+	Chatwoot    ChatwootSettings      `json:"chatwoot"`
+	WarmUp      WarmUpSettings        `json:"warmUp"`
+	Retention   RetentionSettings     `json:"retention"`
+	Policy      PolicySettings        `json:"policy"`
+	Timezone    string                `json:"timezone,omitempty"`
+	Sandbox     SandboxSettings       `json:"sandbox"`
+	Features    Features              `json:"features,omitempty"`
+	GroupRules  map[string]GroupRules `json:"groupRules,omitempty"`
+
+	InboundFilter InboundFilterSettings `json:"inboundFilter"`
+	Pairing       PairingSettings       `json:"pairing"`
+}
+
+// Location is the session's timezone, UTC when it sets none.
+func (s Settings) Location() *time.Location {
+	loc, err := loadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// QuietWindow is the quiet hours, in their own timezone or, when they set
+// none, the session's.
+func (s Settings) QuietWindow() QuietHoursSettings {
+	quiet := s.QuietHours
+	if quiet.Timezone == "" {
+		quiet.Timezone = s.Timezone
+	}
+	return quiet
+}
+
+// WarmUpRamp is the warm-up, counting days in its own timezone or, when it
+// sets none, the session's.
+func (s Settings) WarmUpRamp() WarmUpSettings {
+	warmUp := s.WarmUp
+	if warmUp.Timezone == "" {
+		warmUp.Timezone = s.Timezone
+	}
+	return warmUp
+}
+
+const MaxCallRejectMessageLength = 1000
+
+type CallSettings struct {
+	AutoReject    bool   `json:"autoReject"`
+	RejectMessage string `json:"rejectMessage,omitempty"`
+}
+
+type MediaDownloadPolicy string
+
+const (
+	MediaDownloadNever  MediaDownloadPolicy = "never"
+	MediaDownloadImages MediaDownloadPolicy = "images"
+	MediaDownloadAll    MediaDownloadPolicy = "all"
+)
+
+const MaxMediaAutoDownloadMB = 100
+
+// MediaScanPolicy decides what happens to downloaded inbound media the
+// virus scanner flags: block keeps it off disk and out of webhooks, tag
+// keeps it but marks the message, ignore skips the scan.
+type MediaScanPolicy string
+
+const (
+	MediaScanBlock  MediaScanPolicy = "block"
+	MediaScanTag    MediaScanPolicy = "tag"
+	MediaScanIgnore MediaScanPolicy = "ignore"
+)
+
+// MediaSettings controls which inbound media is downloaded as soon as it
+// arrives. Media that is not downloaded can still be fetched on demand. An
+// empty Scan uses the instance's scan policy.
+type MediaSettings struct {
+	AutoDownload MediaDownloadPolicy `json:"autoDownload,omitempty"`
+	MaxSizeMB    int                 `json:"maxSizeMB,omitempty"`
+	Scan         MediaScanPolicy     `json:"scan,omitempty"`
+}
+
+// ScanPolicy is the session's scan policy, or fallback when it sets none.
+func (m MediaSettings) ScanPolicy(fallback MediaScanPolicy) MediaScanPolicy {
+	if m.Scan == "" {
+		return fallback
+	}
+	return m.Scan
+}
+
+// ShouldDownload reports whether media of the given message type and size
+// falls under the auto-download policy. A zero MaxSizeMB means the global cap.
+func (m MediaSettings) ShouldDownload(messageType string, size uint64) bool {
+	switch m.AutoDownload {
+	case MediaDownloadImages:
+		if messageType != "image" {
+			return false
+		}
+	case MediaDownloadAll:
+	default:
+		return false
+	}
+
+	limit := m.MaxSizeMB
+	if limit <= 0 || limit > MaxMediaAutoDownloadMB {
+		limit = MaxMediaAutoDownloadMB
+	}
+
+	return size <= uint64(limit)<<20
+}
+
+// MaxMediaBytes is the largest media accepted in either direction when the
+// session sets no limit for the type.
+const MaxMediaBytes = 100 << 20
+
+// MediaTypes are the keys accepted in MediaPolicy.MaxBytes.
+var MediaTypes = []string{"image", "video", "audio", "document", "sticker"}
+
+// MediaPolicy limits the media a session sends and downloads. MaxBytes is
+// keyed by media type; AllowedMIMETypes holds exact types or "type/*"
+// wildcards and, when empty, allows everything.
+type MediaPolicy struct {
+	MaxBytes         map[string]int64 `json:"maxBytes,omitempty"`
+	AllowedMIMETypes []string         `json:"allowedMimeTypes,omitempty"`
+}
+
+// Check rejects media of the given type, MIME type and size that the policy
+// does not allow. A size of zero or less is not checked.
+func (p MediaPolicy) Check(mediaType, mimeType string, size int64) error {
+	if limit := p.Limit(mediaType); size > limit {
+		return fmt.Errorf("%w: %s of %d bytes exceeds %d", ErrMediaTooLarge, mediaType, size, limit)
+	}
+
+	if len(p.AllowedMIMETypes) == 0 {
+		return nil
+	}
+
+	base, _, _ := strings.Cut(mimeType, ";")
+	base = strings.ToLower(strings.TrimSpace(base))
+	for _, allowed := range p.AllowedMIMETypes {
+		allowed = strings.ToLower(allowed)
+		if allowed == base {
+			return nil
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(base, prefix+"/") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrMediaTypeNotAllowed, base)
+}
+
+// Limit is the largest size allowed for mediaType.
+func (p MediaPolicy) Limit(mediaType string) int64 {
+	if limit := p.MaxBytes[mediaType]; limit > 0 && limit < MaxMediaBytes {
+		return limit
+	}
+	return MaxMediaBytes
+}
+
+const MaxFooterLength = 512
+
+// FooterSettings is a signature appended to outbound text and media captions.
+// Requests can override it per message or send an empty footer to skip it.
+type FooterSettings struct {
+	Enabled bool   `json:"enabled"`
+	Text    string `json:"text,omitempty"`
+}
+
+// TextFormatSettings are the preprocessing steps run on outbound text and
+// captions before they are sent: Markdown emphasis rewritten into WhatsApp's,
+// :shortcode: emoji expanded and Unicode normalized to NFC. Requests can
+// replace them for a single message.
+type TextFormatSettings struct {
+	Markdown  bool `json:"markdown"`
+	Emoji     bool `json:"emoji"`
+	Normalize bool `json:"normalize"`
+}
+
+func (t TextFormatSettings) Enabled() bool {
+	return t.Markdown || t.Emoji || t.Normalize
+}
+
+// ChatwootSettings control what the contact sees when an agent closes a
+// Chatwoot conversation. ResolvedMessage is sent when the conversation is
+// resolved and CSATMessage replaces Chatwoot's satisfaction survey prompt;
+// both accept the {{name}}, {{conversation_id}} and {{survey_url}}
+// placeholders and are skipped when empty. ReopenOnReply reopens the
+// resolved conversation when the contact writes again.
+type ChatwootSettings struct {
+	ResolvedMessage string `json:"resolvedMessage,omitempty"`
+	CSATMessage     string `json:"csatMessage,omitempty"`
+	ReopenOnReply   bool   `json:"reopenOnReply"`
+}
+
+const MaxRetentionDays = 3650
+
+// RetentionSettings overrides how long the session's stored messages are
+// kept. A nil MessageDays uses the instance default and 0 keeps them
+// forever.
+type RetentionSettings struct {
+	MessageDays *int `json:"messageDays,omitempty"`
+}
+
+type Mode string
+
+const (
+	ModeFull      Mode = "full"
+	ModeDMOnly    Mode = "dmOnly"
+	ModeGroupOnly Mode = "groupOnly"
+	ModeReadOnly  Mode = "readOnly"
+)
+
+// SandboxSettings turn every send of the session into a dry run: it is
+// validated and normalized but never reaches WhatsApp.
+type SandboxSettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PolicySettings restrict what the session may send. dmOnly rejects sends
+// to groups, groupOnly allows nothing but groups and readOnly sends nothing
+// at all, for sessions that only monitor. An empty mode is full.
+type PolicySettings struct {
+	Mode Mode `json:"mode,omitempty"`
+}
+
+// CheckSend rejects a send to jid that the session's mode does not allow,
+// with a *PolicyError.
+func (p PolicySettings) CheckSend(jid string) error {
+	group := strings.HasSuffix(jid, "@g.us")
+
+	switch {
+	case p.Mode == ModeReadOnly,
+		p.Mode == ModeDMOnly && group,
+		p.Mode == ModeGroupOnly && !group:
+		return &PolicyError{Mode: p.Mode, Recipient: jid}
+	}
+	return nil
+}
+
+type QuietHoursPolicy string
+
+const (
+	QuietHoursReject QuietHoursPolicy = "reject"
+	QuietHoursDefer  QuietHoursPolicy = "defer"
+)
+
+// QuietHoursSettings is a daily window, in the session's timezone, during
+// which outbound messages are not sent. A window whose end is before its
+// start wraps past midnight (22:00–08:00). Policy is what happens to sends
+// submitted inside the window when the request does not say.
+type QuietHoursSettings struct {
+	Enabled  bool             `json:"enabled"`
+	Start    string           `json:"start,omitempty"`
+	End      string           `json:"end,omitempty"`
+	Timezone string           `json:"timezone,omitempty"`
+	Policy   QuietHoursPolicy `json:"policy,omitempty"`
+}
+
+// ResumeAt reports whether now falls inside the quiet window and, if so,
+// when the window ends. Invalid settings never block sends; they are
+// rejected when saved.
+func (q QuietHoursSettings) ResumeAt(now time.Time) (time.Time, bool) {
+	if !q.Enabled {
+		return time.Time{}, false
+	}
+
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	loc, err3 := loadLocation(q.Timezone)
+	if err1 != nil || err2 != nil || err3 != nil || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	switch {
+	case start < end && minute >= start && minute < end:
+		return atMinute(midnight, end), true
+	case start > end && minute >= start:
+		return atMinute(midnight.AddDate(0, 0, 1), end), true
+	case start > end && minute < end:
+		return atMinute(midnight, end), true
+	}
+
+	return time.Time{}, false
+}
+
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+// parseClock turns "HH:MM" into minutes after midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// locations caches loaded timezones, which are read from the zoneinfo
+// database on every time.LoadLocation.
+var locations sync.Map
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+type WarmUpPolicy string
+
+const (
+	WarmUpReject WarmUpPolicy = "reject"
+	WarmUpDefer  WarmUpPolicy = "defer"
+)
+
+const MaxWarmUpDays = 90
+
+// WarmUpSettings caps the daily send volume of a fresh number, ramping
+// linearly from StartLimit on the first day to EndLimit on the last. Days
+// are counted in Timezone from StartedAt; once the ramp is over sends are
+// no longer limited. Policy is what happens to sends over the day's cap.
+type WarmUpSettings struct {
+	Enabled    bool         `json:"enabled"`
+	StartedAt  time.Time    `json:"startedAt,omitempty"`
+	Days       int          `json:"days,omitempty"`
+	StartLimit int          `json:"startLimit,omitempty"`
+	EndLimit   int          `json:"endLimit,omitempty"`
+	Timezone   string       `json:"timezone,omitempty"`
+	Policy     WarmUpPolicy `json:"policy,omitempty"`
+}
+
+// WarmUpDay is the state of the ramp on one local day. Day is zero-based
+// and Date is the local date used to count sends.
+type WarmUpDay struct {
+	Day      int
+	Date     string
+	Limit    int
+	ResumeAt time.Time
+}
+
+// Today reports the ramp state at now, or false when the warm-up is off or
+// already finished.
+func (w WarmUpSettings) Today(now time.Time) (WarmUpDay, bool) {
+	if !w.Enabled || w.Days <= 0 || w.StartedAt.IsZero() {
+		return WarmUpDay{}, false
+	}
+
+	loc, err := loadLocation(w.Timezone)
+	if err != nil {
+		return WarmUpDay{}, false
+	}
+
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	started := w.StartedAt.In(loc)
+	first := time.Date(started.Year(), started.Month(), started.Day(), 0, 0, 0, 0, loc)
+
+	day := 0
+	for d := first; d.Before(today) && day < w.Days; d = d.AddDate(0, 0, 1) {
+		day++
+	}
+	if day >= w.Days {
+		return WarmUpDay{}, false
+	}
+
+	limit := w.EndLimit
+	if w.Days > 1 {
+		limit = w.StartLimit + (w.EndLimit-w.StartLimit)*day/(w.Days-1)
+	}
+
+	return WarmUpDay{
+		Day:      day,
+		Date:     today.Format("2006-01-02"),
+		Limit:    limit,
+		ResumeAt: today.AddDate(0, 0, 1),
+	}, true
+}
+
+// DeviceInfo describes the account and phone behind a session, as the
+// WhatsApp device store and connection events report them. Platform is the
+// phone's platform sent at pairing (android, iphone, smba and smbi for
+// WhatsApp Business...); WhatsAppVersion is the WhatsApp Web version the
+// session connects as. WhatsApp multi-device reports no battery level.
 type DeviceInfo struct {
-	Platform    string `json:"platform"`
-	DeviceModel string `json:"device_model"`
-	OSVersion   string `json:"os_version"`
-	AppVersion  string `json:"app_version"`
+	JID             string         `json:"jid"`
+	LID             string         `json:"lid,omitempty"`
+	PushName        string         `json:"push_name"`
+	BusinessName    string         `json:"business_name,omitempty"`
+	Platform        string         `json:"platform"`
+	WhatsAppVersion string         `json:"whatsapp_version"`
+	Connection      ConnectionInfo `json:"connection"`
+}
+
+// IsBusiness reports whether the phone runs WhatsApp Business, whose
+// platforms start with "smb".
+func (d *DeviceInfo) IsBusiness() bool {
+	return strings.HasPrefix(d.Platform, "smb") || d.BusinessName != ""
+}
+
+// ConnectionInfo is the state of a session's socket. Reconnects counts the
+// connections after the first since the client was created; KeepAliveFailures
+// counts consecutive keep-alive timeouts and resets when pings recover.
+type ConnectionInfo struct {
+	State             string     `json:"state"`
+	ConnectedAt       *time.Time `json:"connected_at,omitempty"`
+	LastActivityAt    time.Time  `json:"last_activity_at"`
+	Reconnects        int        `json:"reconnects"`
+	KeepAliveFailures int        `json:"keep_alive_failures"`
 }
 
 type QRCodeResponse struct {
@@ -44,6 +475,103 @@ type QRCodeResponse struct {
 	Timeout     int       `json:"timeout_seconds"`
 }
 
+const (
+	DefaultQRImageSize = 256
+	MinQRImageSize     = 128
+	MaxQRImageSize     = 1024
+	DefaultQRMargin    = 4
+	MaxQRMargin        = 16
+)
+
+type QRImageOptions struct {
+	Size       int
+	Margin     int
+	Logo       bool
+	Foreground color.Color
+	Background color.Color
+}
+
+type ButtonType string
+
+const (
+	ButtonTypeReply ButtonType = "reply"
+	ButtonTypeURL   ButtonType = "cta_url"
+	ButtonTypeCall  ButtonType = "cta_call"
+	ButtonTypeCopy  ButtonType = "cta_copy"
+)
+
+type InteractiveButton struct {
+	Type        ButtonType `json:"type"`
+	ID          string     `json:"id,omitempty"`
+	Text        string     `json:"text"`
+	URL         string     `json:"url,omitempty"`
+	PhoneNumber string     `json:"phone_number,omitempty"`
+	CopyCode    string     `json:"copy_code,omitempty"`
+}
+
+type ButtonMessage struct {
+	Title   string              `json:"title,omitempty"`
+	Text    string              `json:"text"`
+	Footer  string              `json:"footer,omitempty"`
+	Buttons []InteractiveButton `json:"buttons"`
+}
+
+const (
+	MinPollOptions = 2
+	MaxPollOptions = 12
+)
+
+// PollMessage is a poll to send. SelectableCount is how many options a voter
+// may pick; zero allows any number.
+type PollMessage struct {
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	SelectableCount int      `json:"selectable_count"`
+}
+
+// LiveLocation is one live location update to send. WhatsApp shows the
+// share as live while updates keep coming; each update carries the next
+// Sequence and the seconds since the share started in TimeOffset.
+type LiveLocation struct {
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	AccuracyMeters uint32  `json:"accuracy_meters,omitempty"`
+	SpeedMps       float32 `json:"speed_mps,omitempty"`
+	Heading        uint32  `json:"heading,omitempty"`
+	Caption        string  `json:"caption,omitempty"`
+	Sequence       int64   `json:"sequence"`
+	TimeOffset     uint32  `json:"time_offset,omitempty"`
+}
+
+type DeviceCredentials struct {
+	DeviceJID             string `json:"device_jid"`
+	LID                   string `json:"lid,omitempty"`
+	RegistrationID        uint32 `json:"registration_id"`
+	NoiseKey              []byte `json:"noise_key"`
+	IdentityKey           []byte `json:"identity_key"`
+	SignedPreKey          []byte `json:"signed_pre_key"`
+	SignedPreKeyID        uint32 `json:"signed_pre_key_id"`
+	SignedPreKeySignature []byte `json:"signed_pre_key_signature"`
+	AdvSecretKey          []byte `json:"adv_secret_key"`
+	AccountDetails        []byte `json:"account_details"`
+	AccountSignature      []byte `json:"account_signature"`
+	AccountSignatureKey   []byte `json:"account_signature_key"`
+	DeviceSignature       []byte `json:"device_signature"`
+	Platform              string `json:"platform,omitempty"`
+	BusinessName          string `json:"business_name,omitempty"`
+	PushName              string `json:"push_name,omitempty"`
+}
+
+type SessionBackup struct {
+	Version     int       `json:"version"`
+	SessionName string    `json:"session_name"`
+	DeviceJID   string    `json:"device_jid"`
+	Salt        []byte    `json:"salt"`
+	Nonce       []byte    `json:"nonce"`
+	Ciphertext  []byte    `json:"ciphertext"`
+	ExportedAt  time.Time `json:"exported_at"`
+}
+
 type SessionStatus string
 
 const (
@@ -53,6 +581,9 @@ const (
 	StatusDisconnected SessionStatus = "disconnected"
 	StatusError        SessionStatus = "error"
 	StatusLoggedOut    SessionStatus = "logged_out"
+	StatusBanned       SessionStatus = "banned"
+	StatusRestricted   SessionStatus = "restricted"
+	StatusQRTimeout    SessionStatus = "qr_timeout"
 )
 
 func NewSession(name string) *Session {
@@ -75,7 +606,36 @@ func (s *Session) UpdateConnectionStatus(connected bool) {
 		s.ConnectedAt = &now
 		s.LastSeen = &now
 		s.ConnectionError = nil
+		s.LoggedOutAt = nil
+		s.QRTimeoutAt = nil
+		s.Ban = nil
+	}
+}
+
+// MarkBanned records a ban. A permanent ban also logs the device out, as
+// WhatsApp discards its credentials.
+func (s *Session) MarkBanned(ban Ban) {
+	if ban.Kind == BanBanned {
+		s.MarkLoggedOut(ban.Reason)
+	} else {
+		s.IsConnected = false
+		s.ConnectionError = &ban.Reason
+		s.UpdatedAt = time.Now()
 	}
+	s.Ban = &ban
+}
+
+// MarkLoggedOut records that WhatsApp invalidated the device. The device JID
+// is dropped because its credentials are gone; the session has to pair again.
+func (s *Session) MarkLoggedOut(reason string) {
+	now := time.Now()
+	s.IsConnected = false
+	s.DeviceJID = nil
+	s.ConnectionError = &reason
+	s.QRCode = nil
+	s.QRCodeExpiresAt = nil
+	s.LoggedOutAt = &now
+	s.UpdatedAt = now
 }
 
 func (s *Session) SetConnectionError(err string) {
@@ -87,6 +647,7 @@ func (s *Session) SetConnectionError(err string) {
 func (s *Session) SetQRCode(qrCode string, expiresAt time.Time) {
 	s.QRCode = &qrCode
 	s.QRCodeExpiresAt = &expiresAt
+	s.QRTimeoutAt = nil
 	s.UpdatedAt = time.Now()
 }
 
@@ -110,10 +671,22 @@ func (s *Session) IsQRCodeExpired() bool {
 }
 
 func (s *Session) GetStatus() SessionStatus {
+	if s.Ban.Active(time.Now()) {
+		return s.Ban.Status()
+	}
+
 	if s.IsConnected {
 		return StatusConnected
 	}
 
+	if s.QRTimeoutAt != nil && (s.QRCode == nil || s.IsQRCodeExpired()) {
+		return StatusQRTimeout
+	}
+
+	if s.LoggedOutAt != nil && (s.QRCode == nil || s.IsQRCodeExpired()) {
+		return StatusLoggedOut
+	}
+
 	if s.ConnectionError != nil {
 		return StatusError
 	}
@@ -141,6 +714,77 @@ func (s *Session) Validate() error {
 	return nil
 }
 
+func (m *ButtonMessage) Validate() error {
+	if m.Text == "" {
+		return fmt.Errorf("%w: text is required", ErrInvalidButtonMessage)
+	}
+
+	if len(m.Buttons) == 0 || len(m.Buttons) > 3 {
+		return fmt.Errorf("%w: between 1 and 3 buttons are required", ErrInvalidButtonMessage)
+	}
+
+	for i := range m.Buttons {
+		button := &m.Buttons[i]
+		if button.Type == "" {
+			button.Type = ButtonTypeReply
+		}
+
+		if button.Text == "" {
+			return fmt.Errorf("%w: button %d text is required", ErrInvalidButtonMessage, i+1)
+		}
+
+		switch button.Type {
+		case ButtonTypeReply:
+			if button.ID == "" {
+				return fmt.Errorf("%w: reply button %d requires an id", ErrInvalidButtonMessage, i+1)
+			}
+		case ButtonTypeURL:
+			if button.URL == "" {
+				return fmt.Errorf("%w: cta_url button %d requires a url", ErrInvalidButtonMessage, i+1)
+			}
+		case ButtonTypeCall:
+			if button.PhoneNumber == "" {
+				return fmt.Errorf("%w: cta_call button %d requires a phone number", ErrInvalidButtonMessage, i+1)
+			}
+		case ButtonTypeCopy:
+			if button.CopyCode == "" {
+				return fmt.Errorf("%w: cta_copy button %d requires a copy code", ErrInvalidButtonMessage, i+1)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported button type %q", ErrInvalidButtonMessage, button.Type)
+		}
+	}
+
+	return nil
+}
+
+func (m *PollMessage) Validate() error {
+	if strings.TrimSpace(m.Question) == "" {
+		return fmt.Errorf("%w: question is required", ErrInvalidPollMessage)
+	}
+
+	if len(m.Options) < MinPollOptions || len(m.Options) > MaxPollOptions {
+		return fmt.Errorf("%w: between %d and %d options are required", ErrInvalidPollMessage, MinPollOptions, MaxPollOptions)
+	}
+
+	seen := make(map[string]bool, len(m.Options))
+	for i, option := range m.Options {
+		if strings.TrimSpace(option) == "" {
+			return fmt.Errorf("%w: option %d is empty", ErrInvalidPollMessage, i+1)
+		}
+		if seen[option] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidPollMessage, option)
+		}
+		seen[option] = true
+	}
+
+	if m.SelectableCount < 0 || m.SelectableCount > len(m.Options) {
+		return fmt.Errorf("%w: selectable count must be between 0 and %d", ErrInvalidPollMessage, len(m.Options))
+	}
+
+	return nil
+}
+
 func (p *ProxyConfig) ToJSON() ([]byte, error) {
 	return json.Marshal(p)
 }
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MaxQRCodesLimit = 50
+
+// PairingSettings bound a QR pairing: after MaxQRCodes codes go unscanned
+// the pairing stops and the session moves to qr_timeout, instead of holding
+// a connection open for codes nobody reads. Zero leaves it to WhatsApp,
+// which stops sending codes after a few minutes.
+type PairingSettings struct {
+	MaxQRCodes int `json:"maxQRCodes,omitempty"`
+}
+
+func (s *Service) SetPairing(ctx context.Context, id uuid.UUID, settings PairingSettings) error {
+	if settings.MaxQRCodes < 0 || settings.MaxQRCodes > MaxQRCodesLimit {
+		return fmt.Errorf("%w: maxQRCodes must be between 0 and %d", ErrInvalidPairing, MaxQRCodesLimit)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Pairing = settings
+	})
+}
+
+// MarkQRTimeout records that a pairing ended with no code scanned. The
+// session stays in qr_timeout until a new pairing starts or it connects.
+func (s *Session) MarkQRTimeout() {
+	now := time.Now()
+	s.IsConnected = false
+	s.QRCode = nil
+	s.QRCodeExpiresAt = nil
+	s.QRTimeoutAt = &now
+	s.UpdatedAt = now
+}
+
+// OnQRTimeout persists a pairing that ran out of QR codes.
+func (h *SessionEventHandler) OnQRTimeout(sessionName string, reason string) {
+	ctx := context.Background()
+
+	session, err := h.service.repository.GetByName(ctx, sessionName)
+	if err != nil || session.IsConnected {
+		return
+	}
+
+	session.MarkQRTimeout()
+	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusQRTimeout, reason)
+}
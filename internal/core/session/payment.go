@@ -0,0 +1,91 @@
+package session
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const MaxPaymentNoteLength = 1024
+
+// paymentCurrencies are the countries, by calling code, where WhatsApp lets
+// accounts request payments, with the one currency requests there are made
+// in: WhatsApp Pay over Pix in Brazil and over UPI in India.
+var paymentCurrencies = map[string]string{
+	"55": "BRL",
+	"91": "INR",
+}
+
+// PaymentRequest asks the recipient to pay Amount, in Currency, through
+// WhatsApp's in-chat payments. Note is shown with the request; a nil
+// ExpiresAt leaves expiry to WhatsApp.
+type PaymentRequest struct {
+	Amount    float64    `json:"amount"`
+	Currency  string     `json:"currency"`
+	Note      string     `json:"note,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Amount1000 is the amount in thousandths of the currency unit, as
+// WhatsApp carries it.
+func (p *PaymentRequest) Amount1000() uint64 {
+	return uint64(math.Round(p.Amount * 1000))
+}
+
+func (p *PaymentRequest) Validate() error {
+	if p.Amount <= 0 || math.IsInf(p.Amount, 0) || math.IsNaN(p.Amount) {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidPaymentRequest)
+	}
+	if math.Abs(p.Amount*100-math.Round(p.Amount*100)) > 1e-6 {
+		return fmt.Errorf("%w: amount takes at most two decimal places", ErrInvalidPaymentRequest)
+	}
+	if len(p.Currency) != 3 || strings.ToUpper(p.Currency) != p.Currency {
+		return fmt.Errorf("%w: currency must be an ISO 4217 code such as BRL", ErrInvalidPaymentRequest)
+	}
+	if len(p.Note) > MaxPaymentNoteLength {
+		return fmt.Errorf("%w: note exceeds %d characters", ErrInvalidPaymentRequest, MaxPaymentNoteLength)
+	}
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expiry must be in the future", ErrInvalidPaymentRequest)
+	}
+	return nil
+}
+
+// PaymentCurrency returns the currency the account with the given JID or
+// phone number can request payments in, or false where WhatsApp has no
+// payments.
+func PaymentCurrency(account string) (string, bool) {
+	user, _, _ := strings.Cut(account, "@")
+	user, _, _ = strings.Cut(user, ":")
+	user = strings.TrimPrefix(user, "+")
+
+	for code, currency := range paymentCurrencies {
+		if strings.HasPrefix(user, code) {
+			return currency, true
+		}
+	}
+	return "", false
+}
+
+// PaymentCurrencies lists the currencies payment requests can be made in,
+// sorted.
+func PaymentCurrencies() []string {
+	currencies := make([]string, 0, len(paymentCurrencies))
+	for _, currency := range paymentCurrencies {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// CheckPaymentSupport fails with a *PaymentsNotSupportedError unless the
+// account can request payments in the currency.
+func CheckPaymentSupport(account, currency string) error {
+	supported, ok := PaymentCurrency(account)
+	if !ok || supported != currency {
+		return &PaymentsNotSupportedError{Account: account, Currency: supported, Requested: currency}
+	}
+	return nil
+}
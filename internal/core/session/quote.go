@@ -0,0 +1,27 @@
+package session
+
+import "context"
+
+// Quote is the message a send replies to. Participant is the quoted
+// message's author; it is left empty for the session's own messages.
+type Quote struct {
+	MessageID   string
+	Participant string
+	Body        string
+}
+
+type quoteKey struct{}
+
+// WithQuote makes the gateway send the messages built with ctx as replies
+// to quote.
+func WithQuote(ctx context.Context, quote *Quote) context.Context {
+	if quote == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, quoteKey{}, quote)
+}
+
+func QuoteFrom(ctx context.Context) *Quote {
+	quote, _ := ctx.Value(quoteKey{}).(*Quote)
+	return quote
+}
@@ -0,0 +1,122 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultSendDuration stands in for the average send time of a session
+// that has not completed a send yet.
+const defaultSendDuration = time.Second
+
+// SendQueue counts the sends each session has accepted and not finished
+// yet. Past Limit it turns new sends away with a *SendQueueFullError
+// instead of letting them pile up behind the WhatsApp socket. A zero Limit
+// still counts but never rejects. While a session is paused, every send is
+// turned away with a *SessionThrottledError.
+type SendQueue struct {
+	limit int
+
+	mu        sync.Mutex
+	depth     map[uuid.UUID]int
+	average   map[uuid.UUID]time.Duration
+	throttles map[uuid.UUID]Throttle
+}
+
+func NewSendQueue(limit int) *SendQueue {
+	return &SendQueue{
+		limit:     limit,
+		depth:     make(map[uuid.UUID]int),
+		average:   make(map[uuid.UUID]time.Duration),
+		throttles: make(map[uuid.UUID]Throttle),
+	}
+}
+
+// Enter admits one send for the session. The returned func must be called
+// once the send is over, whatever its outcome.
+func (q *SendQueue) Enter(sessionID uuid.UUID) (func(), error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if throttle, ok := q.throttles[sessionID]; ok && throttle.Active(time.Now()) {
+		return nil, &SessionThrottledError{Throttle: throttle}
+	}
+
+	depth := q.depth[sessionID]
+	if q.limit > 0 && depth >= q.limit {
+		return nil, &SendQueueFullError{
+			Depth:      depth,
+			Limit:      q.limit,
+			RetryAfter: q.retryAfter(sessionID, depth),
+		}
+	}
+	q.depth[sessionID] = depth + 1
+
+	started := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() { q.leave(sessionID, time.Since(started)) })
+	}, nil
+}
+
+// Depth is the number of sends of the session still in the queue.
+func (q *SendQueue) Depth(sessionID uuid.UUID) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.depth[sessionID]
+}
+
+func (q *SendQueue) leave(sessionID uuid.UUID, took time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.depth[sessionID] <= 1 {
+		delete(q.depth, sessionID)
+	} else {
+		q.depth[sessionID]--
+	}
+
+	// An exponential moving average follows the socket's current pace
+	// without keeping a history of sends.
+	if average, ok := q.average[sessionID]; ok {
+		q.average[sessionID] = (average*4 + took) / 5
+	} else {
+		q.average[sessionID] = took
+	}
+}
+
+// retryAfter estimates when a slot frees up: the sends over the limit,
+// plus the rejected one, each taking the session's average send time.
+func (q *SendQueue) retryAfter(sessionID uuid.UUID, depth int) time.Duration {
+	average, ok := q.average[sessionID]
+	if !ok {
+		average = defaultSendDuration
+	}
+	return average * time.Duration(depth-q.limit+1)
+}
+
+// Pause turns the session's sends away until throttle.Until.
+func (q *SendQueue) Pause(sessionID uuid.UUID, throttle Throttle) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.throttles[sessionID] = throttle
+}
+
+// Throttle returns the session's latest pause, which may have ended.
+func (q *SendQueue) Throttle(sessionID uuid.UUID) (Throttle, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	throttle, ok := q.throttles[sessionID]
+	return throttle, ok
+}
+
+// Forget drops the session's pace and pauses, kept only while the session
+// exists.
+func (q *SendQueue) Forget(sessionID uuid.UUID) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.average, sessionID)
+	delete(q.throttles, sessionID)
+}
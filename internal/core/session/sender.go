@@ -0,0 +1,292 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"zpwoot/platform/logger"
+)
+
+// Kinds of send, as SendCall reports them.
+const (
+	SendText     = "text"
+	SendMedia    = "media"
+	SendLocation = "location"
+	SendLive     = "live_location"
+	SendContact  = "contact"
+	SendButton   = "button"
+	SendPoll     = "poll"
+	SendPayment  = "payment"
+)
+
+// MessageSender sends messages through a session's WhatsApp connection.
+// Every API send reaches WhatsApp through the one the message service holds,
+// so decorators wrapped around it see all of them.
+type MessageSender interface {
+	SendTextMessage(ctx context.Context, sessionName, to, content string) (*MessageSendResult, error)
+	SendMediaMessage(ctx context.Context, sessionName, to, mediaURL, caption, mediaType string) (*MessageSendResult, error)
+	SendLocationMessage(ctx context.Context, sessionName, to string, latitude, longitude float64, address string) (*MessageSendResult, error)
+	SendLiveLocationMessage(ctx context.Context, sessionName, to string, location *LiveLocation) (*MessageSendResult, error)
+	SendContactMessage(ctx context.Context, sessionName, to string, card *ContactCard) (*MessageSendResult, error)
+	SendButtonMessage(ctx context.Context, sessionName, to string, message *ButtonMessage) (*MessageSendResult, error)
+	SendPollMessage(ctx context.Context, sessionName, to string, message *PollMessage) (*MessageSendResult, error)
+	SendPaymentRequest(ctx context.Context, sessionName, to string, request *PaymentRequest) (*MessageSendResult, error)
+}
+
+// SenderDecorator wraps a MessageSender with behaviour around every send,
+// such as logging or retries.
+type SenderDecorator func(MessageSender) MessageSender
+
+// DecorateSender wraps sender in decorators, the first one outermost.
+func DecorateSender(sender MessageSender, decorators ...SenderDecorator) MessageSender {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		sender = decorators[i](sender)
+	}
+	return sender
+}
+
+// SendCall describes one send to a SendInterceptor.
+type SendCall struct {
+	SessionName string
+	To          string
+	Kind        string
+}
+
+// SendInterceptor runs around one send. send performs it, through the
+// decorators further in, and may be called again to retry.
+type SendInterceptor func(ctx context.Context, call SendCall, send func(context.Context) (*MessageSendResult, error)) (*MessageSendResult, error)
+
+// Intercept builds a decorator that runs interceptor around every kind of
+// send, so decorators need not implement each method of MessageSender.
+func Intercept(interceptor SendInterceptor) SenderDecorator {
+	return func(next MessageSender) MessageSender {
+		return &interceptedSender{next: next, intercept: interceptor}
+	}
+}
+
+type interceptedSender struct {
+	next      MessageSender
+	intercept SendInterceptor
+}
+
+func (s *interceptedSender) SendTextMessage(ctx context.Context, sessionName, to, content string) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendText}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendTextMessage(ctx, sessionName, to, content)
+	})
+}
+
+func (s *interceptedSender) SendMediaMessage(ctx context.Context, sessionName, to, mediaURL, caption, mediaType string) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendMedia}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendMediaMessage(ctx, sessionName, to, mediaURL, caption, mediaType)
+	})
+}
+
+func (s *interceptedSender) SendLocationMessage(ctx context.Context, sessionName, to string, latitude, longitude float64, address string) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendLocation}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendLocationMessage(ctx, sessionName, to, latitude, longitude, address)
+	})
+}
+
+func (s *interceptedSender) SendLiveLocationMessage(ctx context.Context, sessionName, to string, location *LiveLocation) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendLive}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendLiveLocationMessage(ctx, sessionName, to, location)
+	})
+}
+
+func (s *interceptedSender) SendContactMessage(ctx context.Context, sessionName, to string, card *ContactCard) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendContact}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendContactMessage(ctx, sessionName, to, card)
+	})
+}
+
+func (s *interceptedSender) SendButtonMessage(ctx context.Context, sessionName, to string, message *ButtonMessage) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendButton}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendButtonMessage(ctx, sessionName, to, message)
+	})
+}
+
+func (s *interceptedSender) SendPollMessage(ctx context.Context, sessionName, to string, message *PollMessage) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendPoll}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendPollMessage(ctx, sessionName, to, message)
+	})
+}
+
+func (s *interceptedSender) SendPaymentRequest(ctx context.Context, sessionName, to string, request *PaymentRequest) (*MessageSendResult, error) {
+	return s.intercept(ctx, SendCall{SessionName: sessionName, To: to, Kind: SendPayment}, func(ctx context.Context) (*MessageSendResult, error) {
+		return s.next.SendPaymentRequest(ctx, sessionName, to, request)
+	})
+}
+
+// LogSends logs every send with its outcome and how long it took.
+func LogSends(log *logger.Logger) SenderDecorator {
+	return Intercept(func(ctx context.Context, call SendCall, send func(context.Context) (*MessageSendResult, error)) (*MessageSendResult, error) {
+		start := time.Now()
+		result, err := send(ctx)
+
+		fields := map[string]interface{}{
+			"module":       "sender",
+			"session_name": call.SessionName,
+			"to":           call.To,
+			"kind":         call.Kind,
+			"duration_ms":  time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			log.WithContext(ctx).WarnWithFields("Send failed", fields)
+			return nil, err
+		}
+		fields["message_id"] = result.MessageID
+		log.WithContext(ctx).InfoWithFields("Send completed", fields)
+
+		return result, nil
+	})
+}
+
+// RetrySends retries sends that failed because the session's socket was
+// down, which guarantees nothing reached WhatsApp, up to retries more times
+// with a doubling backoff. Other failures are not retried, since the message
+// may have gone out.
+func RetrySends(retries int, backoff time.Duration) SenderDecorator {
+	return Intercept(func(ctx context.Context, call SendCall, send func(context.Context) (*MessageSendResult, error)) (*MessageSendResult, error) {
+		wait := backoff
+		for attempt := 0; ; attempt++ {
+			result, err := send(ctx)
+			if err == nil || attempt >= retries || !errors.Is(err, ErrSessionNotConnected) {
+				return result, err
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+	})
+}
+
+// SendRateLimiter spaces each session's sends at least interval apart,
+// making later sends wait for their turn. A zero interval lets every send
+// through at once.
+type SendRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+func NewSendRateLimiter(interval time.Duration) *SendRateLimiter {
+	return &SendRateLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// SetInterval changes the spacing for sends that take a slot from now on;
+// slots already taken keep their time.
+func (l *SendRateLimiter) SetInterval(interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.interval = interval
+}
+
+func (l *SendRateLimiter) Decorator() SenderDecorator {
+	return Intercept(func(ctx context.Context, call SendCall, send func(context.Context) (*MessageSendResult, error)) (*MessageSendResult, error) {
+		if err := l.wait(ctx, call.SessionName); err != nil {
+			return nil, err
+		}
+		return send(ctx)
+	})
+}
+
+// wait takes the session's next slot and sleeps until it comes. A caller
+// giving up keeps its slot taken, which only spaces later sends further.
+func (l *SendRateLimiter) wait(ctx context.Context, sessionName string) error {
+	now := time.Now()
+
+	l.mu.Lock()
+	slot := l.next[sessionName]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[sessionName] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SendStats counts what one kind of send did since startup.
+type SendStats struct {
+	Kind          string        `json:"kind"`
+	Count         int64         `json:"count"`
+	Errors        int64         `json:"errors"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	MaxDuration   time.Duration `json:"maxDuration"`
+	LastError     string        `json:"lastError,omitempty"`
+}
+
+// SendMetrics counts sends per kind, with their failures and latency.
+type SendMetrics struct {
+	mu    sync.Mutex
+	kinds map[string]*SendStats
+}
+
+func NewSendMetrics() *SendMetrics {
+	return &SendMetrics{kinds: make(map[string]*SendStats)}
+}
+
+func (m *SendMetrics) Decorator() SenderDecorator {
+	return Intercept(func(ctx context.Context, call SendCall, send func(context.Context) (*MessageSendResult, error)) (*MessageSendResult, error) {
+		start := time.Now()
+		result, err := send(ctx)
+		m.record(call.Kind, time.Since(start), err)
+		return result, err
+	})
+}
+
+func (m *SendMetrics) record(kind string, elapsed time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.kinds[kind]
+	if !ok {
+		stats = &SendStats{Kind: kind}
+		m.kinds[kind] = stats
+	}
+	stats.Count++
+	stats.TotalDuration += elapsed
+	if elapsed > stats.MaxDuration {
+		stats.MaxDuration = elapsed
+	}
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err.Error()
+	}
+}
+
+// Stats returns the counters of every kind sent so far, by kind.
+func (m *SendMetrics) Stats() []SendStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]SendStats, 0, len(m.kinds))
+	for _, stats := range m.kinds {
+		out = append(out, *stats)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Kind < out[j].Kind })
+	return out
+}
@@ -2,23 +2,38 @@ package session
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"zpwoot/internal/core/shared/pagination"
 )
 
 type Service struct {
 	repository Repository
 	gateway    WhatsAppGateway
 	qrGen      QRCodeGenerator
+	counter    SendCounter
+	quota      TenantQuota
+	history    StatusHistory
+	queue      *SendQueue
+	posts      *groupPostLog
 }
 
-func NewService(repo Repository, gateway WhatsAppGateway, qrGen QRCodeGenerator) *Service {
+func NewService(repo Repository, gateway WhatsAppGateway, qrGen QRCodeGenerator, counter SendCounter, quota TenantQuota, history StatusHistory, queue *SendQueue) *Service {
 	return &Service{
 		repository: repo,
 		gateway:    gateway,
 		qrGen:      qrGen,
+		counter:    counter,
+		quota:      quota,
+		history:    history,
+		queue:      queue,
+		posts:      newGroupPostLog(),
 	}
 }
 
@@ -26,6 +41,7 @@ type CreateSessionRequest struct {
 	Name        string       `json:"name" validate:"required,min=1,max=100"`
 	ProxyConfig *ProxyConfig `json:"proxyConfig,omitempty"`
 	AutoConnect bool         `json:"autoConnect,omitempty"`
+	TenantID    *uuid.UUID   `json:"tenantId,omitempty"`
 }
 
 func (s *Service) CreateSession(ctx context.Context, req *CreateSessionRequest) (*Session, error) {
@@ -44,6 +60,7 @@ func (s *Service) CreateSession(ctx context.Context, req *CreateSessionRequest)
 
 	session := NewSession(req.Name)
 	session.ProxyConfig = req.ProxyConfig
+	session.TenantID = req.TenantID
 
 	if err := session.Validate(); err != nil {
 		return nil, err
@@ -120,6 +137,43 @@ func (s *Service) ListSessions(ctx context.Context, limit, offset int) ([]*Sessi
 	return sessions, nil
 }
 
+// ListSessionsPage returns sessions newest first and whether more follow.
+func (s *Service) ListSessionsPage(ctx context.Context, req pagination.Request) ([]*Session, bool, error) {
+	limit := pagination.ClampLimit(req.Limit)
+
+	var sessions []*Session
+	var err error
+	if req.After != nil || req.Offset <= 0 {
+		sessions, err = s.repository.ListAfter(ctx, req.After, limit+1)
+	} else {
+		sessions, err = s.repository.List(ctx, limit+1, req.Offset)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions, hasMore := pagination.Trim(sessions, limit)
+	return sessions, hasMore, nil
+}
+
+// ListTenantSessionsPage is ListSessionsPage restricted to one tenant.
+func (s *Service) ListTenantSessionsPage(ctx context.Context, tenantID uuid.UUID, req pagination.Request) ([]*Session, bool, error) {
+	limit := pagination.ClampLimit(req.Limit)
+
+	offset := req.Offset
+	if req.After != nil || offset < 0 {
+		offset = 0
+	}
+
+	sessions, err := s.repository.ListByTenant(ctx, tenantID, req.After, limit+1, offset)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions, hasMore := pagination.Trim(sessions, limit)
+	return sessions, hasMore, nil
+}
+
 func (s *Service) ListConnectedSessions(ctx context.Context) ([]*Session, error) {
 	sessions, err := s.repository.ListConnected(ctx)
 	if err != nil {
@@ -164,6 +218,7 @@ func (s *Service) ConnectSession(ctx context.Context, id uuid.UUID) error {
 		if err := s.repository.Update(ctx, session); err != nil {
 			return fmt.Errorf("failed to update session status: %w", err)
 		}
+		s.recordStatus(ctx, session, StatusConnected, "already connected")
 		return ErrSessionAlreadyConnected
 	}
 
@@ -190,6 +245,35 @@ func (s *Service) DisconnectSession(ctx context.Context, id uuid.UUID) error {
 	if err := s.repository.Update(ctx, session); err != nil {
 		return fmt.Errorf("failed to update session status: %w", err)
 	}
+	s.recordStatus(ctx, session, StatusDisconnected, "disconnect requested")
+
+	return nil
+}
+
+// CancelPairing aborts a QR pairing that has not been scanned yet. A QR code
+// left in storage by a loop that already stopped is cleared as well.
+func (s *Service) CancelPairing(ctx context.Context, id uuid.UUID) error {
+	session, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.IsConnected {
+		return ErrSessionAlreadyConnected
+	}
+
+	if err := s.gateway.CancelPairing(ctx, session.Name); err != nil {
+		if !errors.Is(err, ErrNoPairingPending) || session.QRCode == nil {
+			return err
+		}
+	}
+
+	session.ClearQRCode()
+
+	if err := s.repository.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
+	s.recordStatus(ctx, session, StatusDisconnected, "pairing cancelled")
 
 	return nil
 }
@@ -214,6 +298,10 @@ func (s *Service) DeleteSession(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
+	if s.queue != nil {
+		s.queue.Forget(id)
+	}
+
 	return nil
 }
 
@@ -227,12 +315,8 @@ func (s *Service) GenerateQRCode(ctx context.Context, id uuid.UUID) (*QRCodeResp
 		return nil, ErrSessionAlreadyConnected
 	}
 
-	if session.QRCode != nil && !session.IsQRCodeExpired() {
-		return &QRCodeResponse{
-			QRCode:    *session.QRCode,
-			ExpiresAt: *session.QRCodeExpiresAt,
-			Timeout:   120,
-		}, nil
+	if qr, ok := currentQRCode(s.gateway, session); ok {
+		return qr, nil
 	}
 
 	qrResponse, err := s.gateway.GenerateQRCode(ctx, session.Name)
@@ -248,25 +332,43 @@ func (s *Service) GenerateQRCode(ctx context.Context, id uuid.UUID) (*QRCodeResp
 	return qrResponse, nil
 }
 
+// GetQRCode returns the QR code being shown for the session. Every code is
+// also stored with its expiry, so when the pairing runs in another replica,
+// or the code was shown before this process restarted, the stored one is
+// returned.
 func (s *Service) GetQRCode(ctx context.Context, id uuid.UUID) (*QRCodeResponse, error) {
 	session, err := s.repository.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
+	if qr, ok := currentQRCode(s.gateway, session); ok {
+		return qr, nil
+	}
+
 	if session.QRCode == nil {
 		return nil, ErrQRCodeNotAvailable
 	}
 
-	if session.IsQRCodeExpired() {
-		return nil, ErrQRCodeExpired
+	return nil, ErrQRCodeExpired
+}
+
+// currentQRCode prefers the code held in memory, which is never behind the
+// stored one, and otherwise returns the stored code while it is valid.
+func currentQRCode(gateway WhatsAppGateway, session *Session) (*QRCodeResponse, bool) {
+	if qr, ok := gateway.CurrentQRCode(session.Name); ok {
+		return qr, true
+	}
+
+	if session.QRCode == nil || session.IsQRCodeExpired() {
+		return nil, false
 	}
 
 	return &QRCodeResponse{
 		QRCode:    *session.QRCode,
 		ExpiresAt: *session.QRCodeExpiresAt,
-		Timeout:   120,
-	}, nil
+		Timeout:   int(time.Until(*session.QRCodeExpiresAt).Seconds()),
+	}, true
 }
 
 func (s *Service) SetProxy(ctx context.Context, id uuid.UUID, proxy *ProxyConfig) error {
@@ -302,6 +404,453 @@ func (s *Service) GetProxy(ctx context.Context, id uuid.UUID) (*ProxyConfig, err
 	return session.ProxyConfig, nil
 }
 
+func (s *Service) SetCallSettings(ctx context.Context, id uuid.UUID, settings CallSettings) error {
+	if len(settings.RejectMessage) > MaxCallRejectMessageLength {
+		return fmt.Errorf("%w: reject message exceeds %d characters", ErrInvalidCallSettings, MaxCallRejectMessageLength)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Calls = settings
+	})
+}
+
+func (s *Service) SetMediaSettings(ctx context.Context, id uuid.UUID, settings MediaSettings) error {
+	switch settings.AutoDownload {
+	case MediaDownloadNever, MediaDownloadImages, MediaDownloadAll:
+	case "":
+		settings.AutoDownload = MediaDownloadNever
+	default:
+		return fmt.Errorf("%w: unknown auto-download policy %q", ErrInvalidMediaSettings, settings.AutoDownload)
+	}
+
+	if settings.MaxSizeMB < 0 || settings.MaxSizeMB > MaxMediaAutoDownloadMB {
+		return fmt.Errorf("%w: max size must be between 0 and %d MB", ErrInvalidMediaSettings, MaxMediaAutoDownloadMB)
+	}
+
+	switch settings.Scan {
+	case "", MediaScanBlock, MediaScanTag, MediaScanIgnore:
+	default:
+		return fmt.Errorf("%w: unknown scan policy %q", ErrInvalidMediaSettings, settings.Scan)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Media = settings
+	})
+}
+
+func (s *Service) SetQuietHours(ctx context.Context, id uuid.UUID, settings QuietHoursSettings) error {
+	switch settings.Policy {
+	case QuietHoursReject, QuietHoursDefer:
+	case "":
+		settings.Policy = QuietHoursReject
+	default:
+		return fmt.Errorf("%w: unknown policy %q", ErrInvalidQuietHours, settings.Policy)
+	}
+
+	if _, err := loadLocation(settings.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidQuietHours, settings.Timezone)
+	}
+
+	if settings.Enabled {
+		start, err := parseClock(settings.Start)
+		if err != nil {
+			return fmt.Errorf("%w: start must be HH:MM", ErrInvalidQuietHours)
+		}
+		end, err := parseClock(settings.End)
+		if err != nil {
+			return fmt.Errorf("%w: end must be HH:MM", ErrInvalidQuietHours)
+		}
+		if start == end {
+			return fmt.Errorf("%w: start and end must differ", ErrInvalidQuietHours)
+		}
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.QuietHours = settings
+	})
+}
+
+func (s *Service) SetMediaPolicy(ctx context.Context, id uuid.UUID, policy MediaPolicy) error {
+	for mediaType, limit := range policy.MaxBytes {
+		if !slices.Contains(MediaTypes, mediaType) {
+			return fmt.Errorf("%w: unknown media type %q", ErrInvalidMediaPolicy, mediaType)
+		}
+		if limit < 0 || limit > MaxMediaBytes {
+			return fmt.Errorf("%w: %s limit must be between 0 and %d bytes", ErrInvalidMediaPolicy, mediaType, MaxMediaBytes)
+		}
+	}
+
+	for _, mimeType := range policy.AllowedMIMETypes {
+		major, minor, ok := strings.Cut(mimeType, "/")
+		if !ok || major == "" || minor == "" || major == "*" {
+			return fmt.Errorf("%w: invalid MIME type %q", ErrInvalidMediaPolicy, mimeType)
+		}
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.MediaPolicy = policy
+	})
+}
+
+func (s *Service) SetFooter(ctx context.Context, id uuid.UUID, settings FooterSettings) error {
+	settings.Text = strings.TrimSpace(settings.Text)
+
+	if settings.Enabled && settings.Text == "" {
+		return fmt.Errorf("%w: text is required when enabled", ErrInvalidFooter)
+	}
+	if len(settings.Text) > MaxFooterLength {
+		return fmt.Errorf("%w: text must be at most %d characters", ErrInvalidFooter, MaxFooterLength)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Footer = settings
+	})
+}
+
+func (s *Service) SetTextFormat(ctx context.Context, id uuid.UUID, settings TextFormatSettings) error {
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.TextFormat = settings
+	})
+}
+
+func (s *Service) SetPolicy(ctx context.Context, id uuid.UUID, settings PolicySettings) error {
+	switch settings.Mode {
+	case ModeFull, ModeDMOnly, ModeGroupOnly, ModeReadOnly:
+	case "":
+		settings.Mode = ModeFull
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidPolicy, settings.Mode)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Policy = settings
+	})
+}
+
+// SetTimezone sets the session's timezone, used by quiet hours and warm-up
+// when they set none of their own and to localize webhook timestamps. An
+// empty name goes back to UTC.
+func (s *Service) SetTimezone(ctx context.Context, id uuid.UUID, name string) error {
+	if _, err := loadLocation(name); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidTimezone, name)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Timezone = name
+	})
+}
+
+func (s *Service) SetSandbox(ctx context.Context, id uuid.UUID, settings SandboxSettings) error {
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Sandbox = settings
+	})
+}
+
+func (s *Service) SetChatwoot(ctx context.Context, id uuid.UUID, settings ChatwootSettings) error {
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Chatwoot = settings
+	})
+}
+
+func (s *Service) SetRetention(ctx context.Context, id uuid.UUID, settings RetentionSettings) error {
+	if days := settings.MessageDays; days != nil && (*days < 0 || *days > MaxRetentionDays) {
+		return fmt.Errorf("%w: messageDays must be between 0 and %d", ErrInvalidRetention, MaxRetentionDays)
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		current.Retention = settings
+	})
+}
+
+// MessageRetentionOverrides returns the sessions that keep their messages
+// for their own number of days instead of the instance default.
+func (s *Service) MessageRetentionOverrides(ctx context.Context) (map[uuid.UUID]int, error) {
+	const pageSize = 100
+
+	overrides := make(map[uuid.UUID]int)
+	for offset := 0; ; offset += pageSize {
+		sessions, err := s.repository.List(ctx, pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+
+		for _, sess := range sessions {
+			if days := sess.Settings.Retention.MessageDays; days != nil {
+				overrides[sess.ID] = *days
+			}
+		}
+
+		if len(sessions) < pageSize {
+			return overrides, nil
+		}
+	}
+}
+
+// SetWarmUp saves the warm-up ramp. The ramp starts now when it is first
+// enabled, or at StartedAt when given; an enabled ramp keeps its start when
+// it is updated.
+func (s *Service) SetWarmUp(ctx context.Context, id uuid.UUID, settings WarmUpSettings) error {
+	switch settings.Policy {
+	case WarmUpReject, WarmUpDefer:
+	case "":
+		settings.Policy = WarmUpReject
+	default:
+		return fmt.Errorf("%w: unknown policy %q", ErrInvalidWarmUp, settings.Policy)
+	}
+
+	if _, err := loadLocation(settings.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidWarmUp, settings.Timezone)
+	}
+
+	if settings.Enabled {
+		if settings.Days < 1 || settings.Days > MaxWarmUpDays {
+			return fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidWarmUp, MaxWarmUpDays)
+		}
+		if settings.StartLimit < 1 || settings.EndLimit < settings.StartLimit {
+			return fmt.Errorf("%w: limits must be positive and end at or above the start", ErrInvalidWarmUp)
+		}
+	}
+
+	return s.updateSettings(ctx, id, func(current *Settings) {
+		if settings.Enabled && settings.StartedAt.IsZero() {
+			settings.StartedAt = current.WarmUp.StartedAt
+			if !current.WarmUp.Enabled || settings.StartedAt.IsZero() {
+				settings.StartedAt = time.Now()
+			}
+		}
+		current.WarmUp = settings
+	})
+}
+
+// ReserveSend takes one send from the session's warm-up allowance for today
+// and from its tenant's daily quota. It returns a *WarmUpLimitError once the
+// day's ramp cap is used, the tenant quota's error once that is, and nil
+// when the send may go ahead. It runs on the send path right before the
+// send, so every send kind counts; reserving first keeps concurrent sends
+// under the caps, and the returned func gives the send back and must be
+// called when it did not go out.
+func (s *Service) ReserveSend(ctx context.Context, session *Session) (func(), error) {
+	var warmUpDay, quotaDay string
+
+	today, active := session.Settings.WarmUpRamp().Today(time.Now())
+	if active && s.counter != nil {
+		reserved, err := s.counter.Reserve(ctx, session.ID, today.Date, today.Limit)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return nil, &WarmUpLimitError{Limit: today.Limit, ResumeAt: today.ResumeAt}
+		}
+		warmUpDay = today.Date
+	}
+
+	if session.TenantID != nil && s.quota != nil {
+		day, err := s.quota.ReserveMessage(ctx, *session.TenantID)
+		if err != nil {
+			s.releaseSend(ctx, session, warmUpDay, "")
+			return nil, err
+		}
+		quotaDay = day
+	}
+
+	return func() { s.releaseSend(ctx, session, warmUpDay, quotaDay) }, nil
+}
+
+// CheckWarmUp reports whether the warm-up ramp still allows a send today
+// without taking one: a *WarmUpLimitError once the day's cap is used, so
+// the send can be rejected or deferred before it is attempted. ReserveSend
+// takes the send when it goes out.
+func (s *Service) CheckWarmUp(ctx context.Context, session *Session) error {
+	today, sent, active, err := s.WarmUpUsage(ctx, session)
+	if err != nil || !active {
+		return err
+	}
+	if sent >= today.Limit {
+		return &WarmUpLimitError{Limit: today.Limit, ResumeAt: today.ResumeAt}
+	}
+	return nil
+}
+
+// releaseSend gives back what ReserveSend took. It runs after the send
+// failed, so it goes on even when the caller has hung up; a release that
+// fails only leaves the send counted.
+func (s *Service) releaseSend(ctx context.Context, session *Session, warmUpDay, quotaDay string) {
+	ctx = context.WithoutCancel(ctx)
+
+	if warmUpDay != "" {
+		_ = s.counter.Release(ctx, session.ID, warmUpDay)
+	}
+	if quotaDay != "" {
+		_ = s.quota.ReleaseMessage(ctx, *session.TenantID, quotaDay)
+	}
+}
+
+// EnterSendQueue admits a send into the session's queue, or rejects it with
+// a *SendQueueFullError. The returned func releases the slot.
+func (s *Service) EnterSendQueue(session *Session) (func(), error) {
+	if s.queue == nil {
+		return func() {}, nil
+	}
+	return s.queue.Enter(session.ID)
+}
+
+// SendQueueDepth is the number of sends the session has accepted and not
+// finished yet.
+func (s *Service) SendQueueDepth(id uuid.UUID) int {
+	if s.queue == nil {
+		return 0
+	}
+	return s.queue.Depth(id)
+}
+
+// DeviceInfo reports the account and phone behind the session. It fails for
+// sessions with no WhatsApp client loaded.
+func (s *Service) DeviceInfo(ctx context.Context, session *Session) (*DeviceInfo, error) {
+	return s.gateway.GetSessionInfo(ctx, session.Name)
+}
+
+// SendThrottle returns the session's latest pause after a WhatsApp rate
+// limit or temporary ban, which may have ended.
+func (s *Service) SendThrottle(id uuid.UUID) (Throttle, bool) {
+	if s.queue == nil {
+		return Throttle{}, false
+	}
+	return s.queue.Throttle(id)
+}
+
+// WarmUpUsage reports today's ramp state and how many sends it has used,
+// or false when no warm-up is running.
+func (s *Service) WarmUpUsage(ctx context.Context, session *Session) (WarmUpDay, int, bool, error) {
+	today, active := session.Settings.WarmUpRamp().Today(time.Now())
+	if !active || s.counter == nil {
+		return WarmUpDay{}, 0, false, nil
+	}
+
+	sent, err := s.counter.Count(ctx, session.ID, today.Date)
+	if err != nil {
+		return WarmUpDay{}, 0, false, err
+	}
+
+	return today, sent, true, nil
+}
+
+// updateSettings persists a change to the session settings and pushes the
+// result to the gateway so it takes effect without reconnecting.
+func (s *Service) updateSettings(ctx context.Context, id uuid.UUID, apply func(*Settings)) error {
+	session, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	apply(&session.Settings)
+	session.UpdatedAt = time.Now()
+
+	if err := s.repository.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.gateway.ApplySettings(session.Name, session.Settings)
+
+	return nil
+}
+
+func (s *Service) GetSettings(ctx context.Context, id uuid.UUID) (*Settings, error) {
+	session, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return &session.Settings, nil
+}
+
+func (s *Service) ExportSession(ctx context.Context, id uuid.UUID, passphrase string) (*SessionBackup, error) {
+	session, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if session.DeviceJID == nil || *session.DeviceJID == "" {
+		return nil, ErrSessionNotPaired
+	}
+
+	credentials, err := s.gateway.ExportDeviceCredentials(ctx, session.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export device credentials: %w", err)
+	}
+
+	backup, err := EncryptCredentials(session.Name, credentials, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt session backup: %w", err)
+	}
+
+	return backup, nil
+}
+
+type ImportSessionRequest struct {
+	Name        string         `json:"name"`
+	Passphrase  string         `json:"passphrase"`
+	Backup      *SessionBackup `json:"backup"`
+	ProxyConfig *ProxyConfig   `json:"proxyConfig,omitempty"`
+	AutoConnect bool           `json:"autoConnect,omitempty"`
+	TenantID    *uuid.UUID     `json:"tenantId,omitempty"`
+}
+
+func (s *Service) ImportSession(ctx context.Context, req *ImportSessionRequest) (*Session, error) {
+	if req == nil || req.Backup == nil {
+		return nil, ErrInvalidBackup
+	}
+
+	name := req.Name
+	if name == "" {
+		name = req.Backup.SessionName
+	}
+
+	if err := s.validateCreateRequest(&CreateSessionRequest{Name: name, ProxyConfig: req.ProxyConfig}); err != nil {
+		return nil, err
+	}
+
+	credentials, err := DecryptCredentials(req.Backup, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repository.ExistsByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check session existence: %w", err)
+	}
+	if exists {
+		return nil, ErrSessionAlreadyExists
+	}
+
+	session := NewSession(name)
+	session.ProxyConfig = req.ProxyConfig
+	session.DeviceJID = &credentials.DeviceJID
+	session.TenantID = req.TenantID
+
+	if err := session.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := s.gateway.ImportDeviceCredentials(ctx, session.Name, credentials); err != nil {
+		_ = s.repository.Delete(ctx, session.ID)
+		return nil, fmt.Errorf("failed to import device credentials: %w", err)
+	}
+
+	s.gateway.RegisterSessionUUID(session.Name, session.ID.String())
+
+	if req.AutoConnect {
+		if err := s.initiateConnection(ctx, session); err != nil {
+			return session, fmt.Errorf("session imported but failed to connect: %w", err)
+		}
+	}
+
+	return session, nil
+}
+
 func (s *Service) UpdateLastSeen(ctx context.Context, id uuid.UUID) error {
 	now := time.Now()
 	if err := s.repository.UpdateLastSeen(ctx, id, now); err != nil {
@@ -394,6 +943,7 @@ func (s *Service) initiateConnection(ctx context.Context, session *Session) erro
 		if err := s.gateway.RestoreSession(ctx, session.Name); err != nil {
 			session.SetConnectionError(err.Error())
 			_ = s.repository.Update(ctx, session)
+			s.recordStatus(ctx, session, StatusDisconnected, err.Error())
 			return fmt.Errorf("failed to restore session: %w", err)
 		}
 	}
@@ -404,10 +954,14 @@ func (s *Service) initiateConnection(ctx context.Context, session *Session) erro
 		}
 	}
 
+	s.gateway.ApplySettings(session.Name, session.Settings)
+
+	s.recordStatus(ctx, session, StatusConnecting, "connect requested")
 	if err := s.gateway.ConnectSession(ctx, session.Name); err != nil {
 
 		session.SetConnectionError(err.Error())
 		_ = s.repository.Update(ctx, session)
+		s.recordStatus(ctx, session, StatusDisconnected, err.Error())
 		return fmt.Errorf("failed to connect session: %w", err)
 	}
 
@@ -425,6 +979,12 @@ func (s *Service) syncSessionStatus(ctx context.Context, session *Session) error
 		if err := s.repository.Update(ctx, session); err != nil {
 			return fmt.Errorf("failed to update session status: %w", err)
 		}
+
+		status := StatusDisconnected
+		if connected {
+			status = StatusConnected
+		}
+		s.recordStatus(ctx, session, status, "status sync")
 	}
 
 	return nil
@@ -470,6 +1030,7 @@ func (h *SessionEventHandler) OnSessionConnected(sessionName string, deviceInfo
 	session.ClearQRCode()
 
 	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusConnected, "connected")
 }
 
 func (h *SessionEventHandler) OnSessionDisconnected(sessionName string, reason string) {
@@ -486,6 +1047,22 @@ func (h *SessionEventHandler) OnSessionDisconnected(sessionName string, reason s
 	}
 
 	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusDisconnected, reason)
+}
+
+// OnSessionLoggedOut persists a device invalidation so the session is not
+// reconnected with credentials WhatsApp no longer accepts.
+func (h *SessionEventHandler) OnSessionLoggedOut(sessionName string, reason string) {
+	ctx := context.Background()
+
+	session, err := h.service.repository.GetByName(ctx, sessionName)
+	if err != nil {
+		return
+	}
+
+	session.MarkLoggedOut(reason)
+	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusLoggedOut, reason)
 }
 
 func (h *SessionEventHandler) OnQRCodeGenerated(sessionName string, qrCode string, expiresAt time.Time) {
@@ -498,6 +1075,35 @@ func (h *SessionEventHandler) OnQRCodeGenerated(sessionName string, qrCode strin
 
 	session.SetQRCode(qrCode, expiresAt)
 	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusConnecting, "qr code generated")
+}
+
+// OnSessionThrottled pauses the session's send queue for the cool-down
+// the gateway chose.
+func (h *SessionEventHandler) OnSessionThrottled(sessionName string, throttle Throttle) {
+	ctx := context.Background()
+
+	session, err := h.service.repository.GetByName(ctx, sessionName)
+	if err != nil || h.service.queue == nil {
+		return
+	}
+
+	h.service.queue.Pause(session.ID, throttle)
+}
+
+// OnSessionBanned persists a ban or restriction WhatsApp reported, which
+// blocks sends until it expires or the session connects again.
+func (h *SessionEventHandler) OnSessionBanned(sessionName string, ban Ban) {
+	ctx := context.Background()
+
+	session, err := h.service.repository.GetByName(ctx, sessionName)
+	if err != nil {
+		return
+	}
+
+	session.MarkBanned(ban)
+	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, ban.Status(), ban.Reason)
 }
 
 func (h *SessionEventHandler) OnConnectionError(sessionName string, err error) {
@@ -510,6 +1116,7 @@ func (h *SessionEventHandler) OnConnectionError(sessionName string, err error) {
 
 	session.SetConnectionError(err.Error())
 	_ = h.service.repository.Update(ctx, session)
+	h.service.recordStatus(ctx, session, StatusDisconnected, err.Error())
 }
 
 func (h *SessionEventHandler) OnMessageReceived(sessionName string, message *WhatsAppMessage) {
@@ -0,0 +1,45 @@
+package session
+
+import "time"
+
+// Throttle reasons.
+const (
+	ThrottleRateLimited  = "rate_limited"
+	ThrottleTemporaryBan = "temporary_ban"
+)
+
+const (
+	throttleBaseCooldown = time.Minute
+	throttleMaxCooldown  = time.Hour
+
+	// ThrottleStrikeWindow is how long after a cool-down ends another
+	// signal still counts as a repeat and doubles the next cool-down.
+	ThrottleStrikeWindow = time.Hour
+)
+
+// Throttle pauses a session's sends after WhatsApp signalled it is sending
+// too much. Strikes counts the signals in a row, the first being 1.
+type Throttle struct {
+	Reason  string
+	Code    int
+	Strikes int
+	Since   time.Time
+	Until   time.Time
+}
+
+// Active reports whether sends are still paused at now.
+func (t Throttle) Active(now time.Time) bool {
+	return now.Before(t.Until)
+}
+
+// ThrottleCooldown is the pause for the given strike: a minute for the
+// first, doubling with every repeat up to an hour, and never shorter than
+// minimum, which WhatsApp sets for temporary bans.
+func ThrottleCooldown(strikes int, minimum time.Duration) time.Duration {
+	cooldown := throttleBaseCooldown
+	for i := 1; i < strikes && cooldown < throttleMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	cooldown = min(cooldown, throttleMaxCooldown)
+	return max(cooldown, minimum)
+}
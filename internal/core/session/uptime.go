@@ -0,0 +1,168 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultUptimeDays = 7
+	MaxUptimeDays     = 90
+)
+
+// StatusTransition is one change of a session's connection status. From is
+// empty for the first transition recorded for the session.
+type StatusTransition struct {
+	SessionID uuid.UUID
+	From      SessionStatus
+	To        SessionStatus
+	Reason    string
+	At        time.Time
+}
+
+// StatusHistory keeps every status transition of a session. Last returns
+// the newest transition before the given time, or nil if there is none.
+type StatusHistory interface {
+	Record(ctx context.Context, transition *StatusTransition) error
+	Last(ctx context.Context, sessionID uuid.UUID, before time.Time) (*StatusTransition, error)
+	List(ctx context.Context, sessionID uuid.UUID, from, to time.Time) ([]*StatusTransition, error)
+}
+
+// UptimeDay is the share of one UTC day the session spent connected.
+// Observed leaves out the part of the day before the session existed and,
+// for today, the part still to come.
+type UptimeDay struct {
+	Date      string
+	Connected time.Duration
+	Observed  time.Duration
+}
+
+func (d UptimeDay) Percent() float64 {
+	return uptimePercent(d.Connected, d.Observed)
+}
+
+type UptimeReport struct {
+	From        time.Time
+	To          time.Time
+	Connected   time.Duration
+	Observed    time.Duration
+	Days        []UptimeDay
+	Transitions []*StatusTransition
+}
+
+func (r *UptimeReport) Percent() float64 {
+	return uptimePercent(r.Connected, r.Observed)
+}
+
+func uptimePercent(connected, observed time.Duration) float64 {
+	if observed <= 0 {
+		return 0
+	}
+	return float64(connected) / float64(observed) * 100
+}
+
+// recordStatus appends a transition to the history unless the session is
+// already in that status, so repeated events do not show up as flaps.
+// History is best-effort: it never fails the operation that changed status.
+func (s *Service) recordStatus(ctx context.Context, session *Session, status SessionStatus, reason string) {
+	if s.history == nil {
+		return
+	}
+
+	now := time.Now()
+	last, err := s.history.Last(ctx, session.ID, now.Add(time.Second))
+	if err != nil {
+		return
+	}
+
+	transition := &StatusTransition{
+		SessionID: session.ID,
+		To:        status,
+		Reason:    reason,
+		At:        now,
+	}
+	if last != nil {
+		if last.To == status {
+			return
+		}
+		transition.From = last.To
+	}
+
+	_ = s.history.Record(ctx, transition)
+}
+
+// GetUptime reports the daily uptime of a session over the last days UTC
+// days, today included, along with the transitions in that window.
+func (s *Service) GetUptime(ctx context.Context, id uuid.UUID, days int) (*UptimeReport, error) {
+	if days < 1 || days > MaxUptimeDays {
+		return nil, fmt.Errorf("validation failed: days must be between 1 and %d", MaxUptimeDays)
+	}
+	if s.history == nil {
+		return nil, fmt.Errorf("status history is not configured")
+	}
+
+	session, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	initial, err := s.history.Last(ctx, id, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	transitions, err := s.history.List(ctx, id, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+
+	report := &UptimeReport{
+		From:        from,
+		To:          now,
+		Transitions: transitions,
+	}
+
+	connected := initial != nil && initial.To == StatusConnected
+	next := 0
+	for day := from; day.Before(now); day = day.AddDate(0, 0, 1) {
+		start := day
+		if session.CreatedAt.After(start) {
+			start = session.CreatedAt.UTC()
+		}
+		end := day.AddDate(0, 0, 1)
+		if end.After(now) {
+			end = now
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		entry := UptimeDay{Date: day.Format("2006-01-02"), Observed: end.Sub(start)}
+		cursor := start
+		for ; next < len(transitions) && transitions[next].At.Before(end); next++ {
+			at := transitions[next].At
+			if at.After(cursor) {
+				if connected {
+					entry.Connected += at.Sub(cursor)
+				}
+				cursor = at
+			}
+			connected = transitions[next].To == StatusConnected
+		}
+		if connected {
+			entry.Connected += end.Sub(cursor)
+		}
+
+		report.Days = append(report.Days, entry)
+		report.Connected += entry.Connected
+		report.Observed += entry.Observed
+	}
+
+	return report, nil
+}
@@ -0,0 +1,166 @@
+package session
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxContactPhones     = 10
+	MaxContactPhotoBytes = 256 * 1024
+	vcardLineLimit       = 75
+)
+
+var contactPhoneTypes = map[string]string{
+	"":      "CELL",
+	"cell":  "CELL",
+	"home":  "HOME",
+	"work":  "WORK",
+	"main":  "MAIN",
+	"other": "OTHER",
+}
+
+type ContactPhone struct {
+	Number string
+	Type   string
+}
+
+// ContactCard is a contact shared in a message. Photo is a small JPEG or
+// PNG thumbnail embedded in the vCard; the gateway loads it from
+// PhotoSource, a URL or base64 data, when it is not set.
+type ContactCard struct {
+	Name          string
+	Organization  string
+	Phones        []ContactPhone
+	PhotoSource   string
+	Photo         []byte
+	PhotoMimeType string
+}
+
+// NormalizePhone reduces a phone number written with spaces, dashes, dots,
+// parentheses or an international prefix ("+" or "00") to its E.164 digits.
+func NormalizePhone(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	value = strings.TrimPrefix(value, "+")
+	if strings.HasPrefix(value, "00") {
+		value = value[2:]
+	}
+
+	var digits strings.Builder
+	for _, char := range value {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char == ' ' || char == '-' || char == '.' || char == '(' || char == ')':
+		default:
+			return "", fmt.Errorf("%w: phone %q has invalid characters", ErrInvalidContactCard, raw)
+		}
+	}
+
+	number := digits.String()
+	if len(number) < 7 || len(number) > 15 {
+		return "", fmt.Errorf("%w: phone %q must have 7 to 15 digits with country code", ErrInvalidContactCard, raw)
+	}
+
+	return number, nil
+}
+
+// Validate normalizes the card's phones in place and checks the rest.
+func (c *ContactCard) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidContactCard)
+	}
+	if len(c.Phones) == 0 || len(c.Phones) > MaxContactPhones {
+		return fmt.Errorf("%w: between 1 and %d phones are required", ErrInvalidContactCard, MaxContactPhones)
+	}
+
+	for i := range c.Phones {
+		number, err := NormalizePhone(c.Phones[i].Number)
+		if err != nil {
+			return err
+		}
+		if _, ok := contactPhoneTypes[strings.ToLower(c.Phones[i].Type)]; !ok {
+			return fmt.Errorf("%w: unknown phone type %q", ErrInvalidContactCard, c.Phones[i].Type)
+		}
+		c.Phones[i].Number = number
+	}
+
+	if len(c.Photo) > MaxContactPhotoBytes {
+		return fmt.Errorf("%w: photo exceeds %d bytes", ErrInvalidContactCard, MaxContactPhotoBytes)
+	}
+	if len(c.Photo) > 0 && photoType(c.PhotoMimeType) == "" {
+		return fmt.Errorf("%w: photo must be JPEG or PNG", ErrInvalidContactCard)
+	}
+
+	return nil
+}
+
+// VCard serializes the card as the vCard 3.0 WhatsApp expects, with each
+// phone tagged with its waid so the app offers to message it. Call Validate
+// first so phones are normalized.
+func (c *ContactCard) VCard() string {
+	var b strings.Builder
+
+	writeVCardLine(&b, "BEGIN:VCARD")
+	writeVCardLine(&b, "VERSION:3.0")
+	writeVCardLine(&b, "N:;"+escapeVCard(c.Name)+";;;")
+	writeVCardLine(&b, "FN:"+escapeVCard(c.Name))
+	if c.Organization != "" {
+		writeVCardLine(&b, "ORG:"+escapeVCard(c.Organization)+";")
+	}
+	for _, phone := range c.Phones {
+		writeVCardLine(&b, fmt.Sprintf("TEL;type=%s;type=VOICE;waid=%s:+%s",
+			contactPhoneTypes[strings.ToLower(phone.Type)], phone.Number, phone.Number))
+	}
+	if len(c.Photo) > 0 {
+		writeVCardLine(&b, "PHOTO;ENCODING=b;TYPE="+photoType(c.PhotoMimeType)+":"+base64.StdEncoding.EncodeToString(c.Photo))
+	}
+	writeVCardLine(&b, "END:VCARD")
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// escapeVCard escapes the characters that are structural in vCard text
+// values (RFC 2426 section 5) and drops carriage returns.
+func escapeVCard(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`,`, `\,`,
+		`;`, `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	)
+	return replacer.Replace(strings.TrimSpace(value))
+}
+
+// writeVCardLine folds lines longer than 75 octets, continuing them on the
+// next line after a space, without splitting a UTF-8 character.
+func writeVCardLine(b *strings.Builder, line string) {
+	limit := vcardLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\n ")
+		line = line[cut:]
+		limit = vcardLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\n")
+}
+
+func photoType(mimeType string) string {
+	switch strings.ToLower(mimeType) {
+	case "image/jpeg", "image/jpg":
+		return "JPEG"
+	case "image/png":
+		return "PNG"
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor identifies the last item of a page. Lists are ordered by a primary
+// sort value (Time or Key) and then by ID, so the order is total and a cursor
+// keeps pointing at the same position while rows are inserted before it.
+type Cursor struct {
+	Time time.Time `json:"t,omitempty"`
+	Key  string    `json:"k,omitempty"`
+	ID   string    `json:"i"`
+}
+
+// Request is a page request. Offset is only honoured when After is nil and
+// exists for clients that have not moved to cursors yet.
+type Request struct {
+	Limit  int
+	After  *Cursor
+	Offset int
+}
+
+// Encode turns a cursor into the opaque token handed to API clients.
+func Encode(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a token produced by Encode. An empty token means "first page"
+// and yields a nil cursor.
+func Decode(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+func ClampLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
+
+// Trim cuts a result fetched with limit+1 rows back to limit and reports
+// whether the extra row was there.
+func Trim[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
+
+// Slice pages through an in-memory list already sorted ascending by key. It is
+// meant for sources that can only be fetched whole, such as the joined groups
+// and contact store of a WhatsApp session.
+func Slice[T any](items []T, key func(T) string, after *Cursor, limit int) ([]T, bool) {
+	start := 0
+	if after != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			return key(items[i]) > after.Key
+		})
+	}
+	return Trim(items[start:], limit)
+}
@@ -0,0 +1,16 @@
+package tenant
+
+import "context"
+
+type contextKey struct{}
+
+// WithTenant marks a request as made with one of the tenant's API keys.
+// Requests without a tenant were made with the global key.
+func WithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenant)
+}
+
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(contextKey{}).(*Tenant)
+	return tenant, ok && tenant != nil
+}
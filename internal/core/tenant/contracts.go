@@ -0,0 +1,33 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, tenant *Tenant) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Tenant, error)
+	List(ctx context.Context) ([]*Tenant, error)
+	Update(ctx context.Context, tenant *Tenant) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	CreateKey(ctx context.Context, key *APIKey) error
+	ListKeys(ctx context.Context, tenantID uuid.UUID) ([]*APIKey, error)
+	DeleteKey(ctx context.Context, tenantID, keyID uuid.UUID) error
+	// GetByKeyHash returns the key with the given hash and its tenant.
+	GetByKeyHash(ctx context.Context, hash string) (*APIKey, *Tenant, error)
+	TouchKey(ctx context.Context, keyID uuid.UUID) error
+
+	// SetSessionTenant moves a session to a tenant, or back to the global
+	// scope when tenantID is nil.
+	SetSessionTenant(ctx context.Context, sessionID uuid.UUID, tenantID *uuid.UUID) error
+	CountSessions(ctx context.Context, tenantID uuid.UUID) (int, error)
+
+	// ReserveMessage increments the day's counter only while it is below
+	// limit and reports whether it did.
+	ReserveMessage(ctx context.Context, tenantID uuid.UUID, day string, limit int) (bool, error)
+	ReleaseMessage(ctx context.Context, tenantID uuid.UUID, day string) error
+	CountMessages(ctx context.Context, tenantID uuid.UUID, day string) (int, error)
+}
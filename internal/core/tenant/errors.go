@@ -0,0 +1,34 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTenantNotFound  = errors.New("tenant not found")
+	ErrTenantNameTaken = errors.New("tenant name already exists")
+	ErrInvalidTenant   = errors.New("validation failed: invalid tenant")
+	ErrAPIKeyNotFound  = errors.New("tenant API key not found")
+	ErrSessionOwned    = errors.New("session belongs to another tenant")
+	ErrSessionLimit    = errors.New("tenant session limit reached")
+	ErrMessageLimit    = errors.New("tenant daily message limit reached")
+)
+
+// QuotaError reports a tenant quota that blocked an operation. Err is
+// ErrSessionLimit or ErrMessageLimit; ResumeAt is set for the daily message
+// quota and is when the next day's allowance starts.
+type QuotaError struct {
+	Err      error
+	Limit    int
+	ResumeAt time.Time
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Err, e.Limit)
+}
+
+func (e *QuotaError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,85 @@
+package tenant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix marks keys issued to tenants so they can be told apart from
+// the global key without a database lookup.
+const APIKeyPrefix = "zpt_"
+
+const MaxNameLength = 100
+
+// Tenant groups the sessions of one customer. Its API keys only reach those
+// sessions, and its quotas cap how many sessions it may own and how many
+// messages they may send per day. A zero quota means unlimited.
+// TrackingDomain is the base URL tracked links of its sessions point at, a
+// domain of the customer's served by zpwoot; empty uses the server's own.
+type Tenant struct {
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	MaxSessions       int       `json:"maxSessions"`
+	MaxMessagesPerDay int       `json:"maxMessagesPerDay"`
+	TrackingDomain    string    `json:"trackingDomain,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+func (t *Tenant) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || len(t.Name) > MaxNameLength {
+		return fmt.Errorf("%w: name must have 1 to %d characters", ErrInvalidTenant, MaxNameLength)
+	}
+	if t.MaxSessions < 0 || t.MaxMessagesPerDay < 0 {
+		return fmt.Errorf("%w: quotas must not be negative", ErrInvalidTenant)
+	}
+
+	t.TrackingDomain = strings.TrimRight(strings.TrimSpace(t.TrackingDomain), "/")
+	if t.TrackingDomain != "" {
+		parsed, err := url.Parse(t.TrackingDomain)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+			return fmt.Errorf("%w: tracking domain must be an http or https URL without query", ErrInvalidTenant)
+		}
+	}
+	return nil
+}
+
+// APIKey is a tenant credential. Only its SHA-256 hash is stored; the key
+// itself is shown once, when it is issued.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenantId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Usage is how much of its quotas a tenant has consumed. Messages are
+// counted per UTC day.
+type Usage struct {
+	Sessions      int    `json:"sessions"`
+	Day           string `json:"day"`
+	MessagesToday int    `json:"messagesToday"`
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// quotaDay returns the UTC day messages are counted against and when the
+// next one starts.
+func quotaDay(now time.Time) (string, time.Time) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return start.Format("2006-01-02"), start.AddDate(0, 0, 1)
+}
@@ -0,0 +1,246 @@
+package tenant
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// keyTouchInterval limits how often a key's last use is written back, so
+// authenticating every request does not turn into a write per request.
+const keyTouchInterval = time.Minute
+
+// Service manages tenants, their API keys and quotas.
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, tenant *Tenant) (*Tenant, error) {
+	if err := tenant.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tenant.ID = uuid.New()
+	tenant.CreatedAt = now
+	tenant.UpdatedAt = now
+
+	if err := s.repository.Create(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (*Tenant, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context) ([]*Tenant, error) {
+	tenants, err := s.repository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// Update applies change to the stored tenant. Lowering a quota below the
+// current usage is allowed; it only blocks new sessions and sends.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, change func(*Tenant)) (*Tenant, error) {
+	tenant, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	change(tenant)
+	if err := tenant.Validate(); err != nil {
+		return nil, err
+	}
+	tenant.UpdatedAt = time.Now()
+
+	if err := s.repository.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+// Delete removes a tenant and its keys. Its sessions are kept and return
+// to the global scope.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repository.Delete(ctx, id)
+}
+
+// IssueKey creates an API key for a tenant and returns it together with
+// the key itself, which is not stored and cannot be recovered later.
+func (s *Service) IssueKey(ctx context.Context, tenantID uuid.UUID, name string) (*APIKey, string, error) {
+	if _, err := s.repository.GetByID(ctx, tenantID); err != nil {
+		return nil, "", err
+	}
+
+	name = strings.TrimSpace(name)
+	if len(name) > MaxNameLength {
+		return nil, "", fmt.Errorf("%w: key name must have at most %d characters", ErrInvalidTenant, MaxNameLength)
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	plain := APIKeyPrefix + hex.EncodeToString(secret)
+
+	key := &APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    plain[:len(APIKeyPrefix)+8],
+		Hash:      HashAPIKey(plain),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repository.CreateKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+func (s *Service) ListKeys(ctx context.Context, tenantID uuid.UUID) ([]*APIKey, error) {
+	if _, err := s.repository.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListKeys(ctx, tenantID)
+}
+
+func (s *Service) RevokeKey(ctx context.Context, tenantID, keyID uuid.UUID) error {
+	return s.repository.DeleteKey(ctx, tenantID, keyID)
+}
+
+// Authenticate returns the tenant owning apiKey, or ErrAPIKeyNotFound when
+// the key is not a tenant key.
+func (s *Service) Authenticate(ctx context.Context, apiKey string) (*Tenant, error) {
+	if !strings.HasPrefix(apiKey, APIKeyPrefix) {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	key, tenant, err := s.repository.GetByKeyHash(ctx, HashAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > keyTouchInterval {
+		if err := s.repository.TouchKey(ctx, key.ID); err != nil {
+			s.logger.WarnWithFields("Failed to record tenant API key use", map[string]interface{}{
+				"tenant_id": tenant.ID.String(),
+				"key_id":    key.ID.String(),
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	return tenant, nil
+}
+
+// AssignSession moves a session to a tenant, within the tenant's session
+// quota. currentTenant is the session's present owner, if any.
+func (s *Service) AssignSession(ctx context.Context, tenantID, sessionID uuid.UUID, currentTenant *uuid.UUID) error {
+	tenant, err := s.repository.GetByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if currentTenant != nil && *currentTenant == tenantID {
+		return nil
+	}
+
+	if err := s.CheckSessionQuota(ctx, tenant); err != nil {
+		return err
+	}
+
+	return s.repository.SetSessionTenant(ctx, sessionID, &tenantID)
+}
+
+// ReleaseSession returns a session to the global scope.
+func (s *Service) ReleaseSession(ctx context.Context, sessionID uuid.UUID) error {
+	return s.repository.SetSessionTenant(ctx, sessionID, nil)
+}
+
+// CheckSessionQuota fails with a *QuotaError when the tenant already owns
+// as many sessions as it may.
+func (s *Service) CheckSessionQuota(ctx context.Context, tenant *Tenant) error {
+	if tenant.MaxSessions <= 0 {
+		return nil
+	}
+
+	count, err := s.repository.CountSessions(ctx, tenant.ID)
+	if err != nil {
+		return err
+	}
+	if count >= tenant.MaxSessions {
+		return &QuotaError{Err: ErrSessionLimit, Limit: tenant.MaxSessions}
+	}
+
+	return nil
+}
+
+// ReserveMessage takes one send from the tenant's daily allowance, failing
+// with a *QuotaError once it is used up. It returns the day the send was
+// counted on, or "" for a tenant without a limit.
+func (s *Service) ReserveMessage(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	tenant, err := s.repository.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	if tenant.MaxMessagesPerDay <= 0 {
+		return "", nil
+	}
+
+	day, next := quotaDay(time.Now())
+	reserved, err := s.repository.ReserveMessage(ctx, tenantID, day, tenant.MaxMessagesPerDay)
+	if err != nil {
+		return "", err
+	}
+	if !reserved {
+		return "", &QuotaError{Err: ErrMessageLimit, Limit: tenant.MaxMessagesPerDay, ResumeAt: next}
+	}
+
+	return day, nil
+}
+
+// ReleaseMessage gives back a send ReserveMessage counted on day, for a
+// send that did not go out.
+func (s *Service) ReleaseMessage(ctx context.Context, tenantID uuid.UUID, day string) error {
+	return s.repository.ReleaseMessage(ctx, tenantID, day)
+}
+
+func (s *Service) Usage(ctx context.Context, tenantID uuid.UUID) (*Usage, error) {
+	sessions, err := s.repository.CountSessions(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	day, _ := quotaDay(time.Now())
+	messages, err := s.repository.CountMessages(ctx, tenantID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Usage{Sessions: sessions, Day: day, MessagesToday: messages}, nil
+}
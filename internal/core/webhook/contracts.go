@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository stores the webhooks of each session. ListBySession returns them
+// highest priority first, oldest first among equal priorities. RecordDelivery
+// stores the outcome of one delivery; an empty deliveryErr is a success.
+type Repository interface {
+	GetByID(ctx context.Context, sessionID, id uuid.UUID) (*Webhook, error)
+	ListBySession(ctx context.Context, sessionID uuid.UUID) ([]*Webhook, error)
+	Upsert(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, sessionID, id uuid.UUID) error
+	RecordDelivery(ctx context.Context, id uuid.UUID, at time.Time, statusCode int, deliveryErr string) error
+}
+
+// Sender posts an event to a webhook, retrying transient failures.
+type Sender interface {
+	Send(ctx context.Context, webhook *Webhook, template *Template, event *Event) (*Delivery, error)
+}
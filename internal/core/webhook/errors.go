@@ -0,0 +1,10 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook configuration")
+	ErrInvalidTemplate = errors.New("invalid webhook template")
+	ErrTooManyWebhooks = errors.New("session already has the maximum number of webhooks")
+)
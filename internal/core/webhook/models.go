@@ -0,0 +1,201 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventCategory groups related event types so a webhook can subscribe to a
+// whole family at once.
+type EventCategory string
+
+const (
+	CategoryMessages   EventCategory = "messages"
+	CategoryReceipts   EventCategory = "receipts"
+	CategoryPresence   EventCategory = "presence"
+	CategoryGroups     EventCategory = "groups"
+	CategoryCalls      EventCategory = "calls"
+	CategoryConnection EventCategory = "connection"
+	CategoryContacts   EventCategory = "contacts"
+)
+
+var AllCategories = []EventCategory{
+	CategoryMessages,
+	CategoryReceipts,
+	CategoryPresence,
+	CategoryGroups,
+	CategoryCalls,
+	CategoryConnection,
+	CategoryContacts,
+}
+
+// EventType is one event the gateway delivers and the category a webhook
+// subscribes to in order to receive it.
+type EventType struct {
+	Name     string        `json:"name"`
+	Category EventCategory `json:"category"`
+}
+
+// EventTypes lists every delivered event. An event whose name is missing
+// here is not delivered.
+var EventTypes = []EventType{
+	{"message", CategoryMessages},
+	{"message.reaction", CategoryMessages},
+	{"message.edited", CategoryMessages},
+	{"message.revoked", CategoryMessages},
+	{"message.pinned", CategoryMessages},
+	{"message.unpinned", CategoryMessages},
+	{"message.send_confirmed", CategoryMessages},
+	{"message.undecryptable", CategoryMessages},
+	{"poll.vote", CategoryMessages},
+	{"location.live", CategoryMessages},
+	{"order", CategoryMessages},
+	{"payment", CategoryMessages},
+
+	{"receipt", CategoryReceipts},
+
+	{"presence", CategoryPresence},
+	{"chat_presence", CategoryPresence},
+
+	{"group.updated", CategoryGroups},
+	{"group.joined", CategoryGroups},
+	{"group.metadata_changed", CategoryGroups},
+
+	{"call.received", CategoryCalls},
+
+	{"contact.avatar_changed", CategoryContacts},
+
+	{"session.connected", CategoryConnection},
+	{"session.disconnected", CategoryConnection},
+	{"session.logged_out", CategoryConnection},
+	{"session.throttled", CategoryConnection},
+	{"session.banned", CategoryConnection},
+	{"connect_failure", CategoryConnection},
+	{"device.added", CategoryConnection},
+	{"pair_success", CategoryConnection},
+	{"pair_error", CategoryConnection},
+	{"qr.updated", CategoryConnection},
+	{"pairing_ended", CategoryConnection},
+}
+
+// CategoryOf returns the category of a delivered event.
+func CategoryOf(name string) (EventCategory, bool) {
+	for _, t := range EventTypes {
+		if t.Name == name {
+			return t.Category, true
+		}
+	}
+	return "", false
+}
+
+func (c EventCategory) IsValid() bool {
+	for _, known := range AllCategories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxWebhooksPerSession caps how many endpoints one session can post to.
+const MaxWebhooksPerSession = 10
+
+// Webhook is one endpoint of a session. A session can have several, each
+// with its own filter, secret and template; Priority orders them, highest
+// first, and the first one is what the single-webhook endpoints manage.
+type Webhook struct {
+	ID            uuid.UUID       `json:"id"`
+	SessionID     uuid.UUID       `json:"session_id"`
+	URL           string          `json:"url"`
+	Secret        string          `json:"-"`
+	Events        []EventCategory `json:"events"`
+	Template      json.RawMessage `json:"template,omitempty"`
+	SchemaVersion int             `json:"schema_version"`
+	Format        string          `json:"format"`
+	Priority      int             `json:"priority"`
+	Enabled       bool            `json:"enabled"`
+	State         DeliveryState   `json:"state"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// DeliveryState is the outcome of the latest deliveries to one webhook.
+// Failures counts consecutive failed deliveries and resets on success.
+type DeliveryState struct {
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	Failures       int        `json:"failures"`
+}
+
+// Accepts reports whether the webhook subscribes to the category. An empty
+// subscription list means every category.
+func (w *Webhook) Accepts(category EventCategory) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, c := range w.Events {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is what happened, independent of how it is posted: Envelope renders
+// it in the schema version each webhook asked for. Data holds the
+// event-specific payload and ID identifies the event across deliveries.
+// Location is the session's timezone, when it has one, and adds the local
+// time to the payload.
+type Event struct {
+	ID        string         `json:"id,omitempty"`
+	Event     string         `json:"event"`
+	Category  EventCategory  `json:"category,omitempty"`
+	SessionID string         `json:"sessionId"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      interface{}    `json:"data"`
+	Location  *time.Location `json:"-"`
+}
+
+// Timezone is the name of the event's location, empty without one.
+func (e *Event) Timezone() string {
+	if e.Location == nil {
+		return ""
+	}
+	return e.Location.String()
+}
+
+// LocalTime is t in the event's location, or nil without one.
+func (e *Event) LocalTime(t time.Time) *time.Time {
+	if e.Location == nil {
+		return nil
+	}
+	local := t.In(e.Location)
+	return &local
+}
+
+// Delivery describes the outcome of posting one event.
+type Delivery struct {
+	URL        string        `json:"url"`
+	StatusCode int           `json:"status_code"`
+	Attempts   int           `json:"attempts"`
+	Duration   time.Duration `json:"duration"`
+}
+
+type SetWebhookRequest struct {
+	SessionID     uuid.UUID
+	URL           string
+	Secret        *string
+	Events        []EventCategory
+	Template      json.RawMessage
+	SchemaVersion *int
+	Format        *string
+	Priority      *int
+	Enabled       *bool
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+)
+
+// Versions of the envelope posted to webhooks. Webhooks created before
+// versioning, and those that do not ask for one, receive version 1, so a
+// format change only reaches receivers that opt into it.
+const (
+	SchemaV1 = 1
+	SchemaV2 = 2
+
+	DefaultSchemaVersion = SchemaV1
+	LatestSchemaVersion  = SchemaV2
+)
+
+// EnvelopeV1 is the original envelope. It only gained schemaVersion and,
+// for sessions with a timezone, timezone and localTimestamp, which receivers
+// that decode into their own types ignore.
+type EnvelopeV1 struct {
+	SchemaVersion  int           `json:"schemaVersion"`
+	Event          string        `json:"event"`
+	Category       EventCategory `json:"category,omitempty"`
+	SessionID      string        `json:"sessionId"`
+	Timestamp      time.Time     `json:"timestamp"`
+	Timezone       string        `json:"timezone,omitempty"`
+	LocalTimestamp *time.Time    `json:"localTimestamp,omitempty"`
+	Data           interface{}   `json:"data"`
+}
+
+// EnvelopeV2 adds an event ID for idempotent receivers and groups the
+// session fields, leaving room for more without touching the top level.
+type EnvelopeV2 struct {
+	SchemaVersion   int             `json:"schemaVersion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Category        EventCategory   `json:"category,omitempty"`
+	Session         EnvelopeSession `json:"session"`
+	OccurredAt      time.Time       `json:"occurredAt"`
+	LocalOccurredAt *time.Time      `json:"localOccurredAt,omitempty"`
+	Data            interface{}     `json:"data"`
+}
+
+type EnvelopeSession struct {
+	ID       string `json:"id"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// translators render the internal event in each supported version. Adding a
+// version means adding its envelope and an entry here; older entries stay
+// so existing receivers keep getting what they parse today.
+var translators = map[int]func(*Event) interface{}{
+	SchemaV1: func(e *Event) interface{} {
+		return &EnvelopeV1{
+			SchemaVersion:  SchemaV1,
+			Event:          e.Event,
+			Category:       e.Category,
+			SessionID:      e.SessionID,
+			Timestamp:      e.Timestamp,
+			Timezone:       e.Timezone(),
+			LocalTimestamp: e.LocalTime(e.Timestamp),
+			Data:           e.Data,
+		}
+	},
+	SchemaV2: func(e *Event) interface{} {
+		return &EnvelopeV2{
+			SchemaVersion:   SchemaV2,
+			ID:              e.ID,
+			Type:            e.Event,
+			Category:        e.Category,
+			Session:         EnvelopeSession{ID: e.SessionID, Timezone: e.Timezone()},
+			OccurredAt:      e.Timestamp,
+			LocalOccurredAt: e.LocalTime(e.Timestamp),
+			Data:            e.Data,
+		}
+	},
+}
+
+// ValidSchemaVersion reports whether envelopes can be rendered in version.
+func ValidSchemaVersion(version int) bool {
+	_, ok := translators[version]
+	return ok
+}
+
+// Envelope renders the event in the given schema version; 0 means the
+// default.
+func Envelope(event *Event, version int) (interface{}, error) {
+	if version == 0 {
+		version = DefaultSchemaVersion
+	}
+
+	translate, ok := translators[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown schema version %d", ErrInvalidWebhook, version)
+	}
+
+	return translate(event), nil
+}
@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/platform/logger"
+)
+
+// Target is what the dispatcher needs per webhook: the configuration and its
+// parsed template.
+type Target struct {
+	Webhook  *Webhook
+	Template *Template
+}
+
+type Service struct {
+	repository Repository
+	logger     *logger.Logger
+
+	// routes caches each session's webhooks in priority order. An empty
+	// slice is cached too, so sessions without webhooks do not hit the
+	// database on every event.
+	mu     sync.RWMutex
+	routes map[uuid.UUID][]*Target
+}
+
+func NewService(repo Repository, logger *logger.Logger) *Service {
+	return &Service{
+		repository: repo,
+		logger:     logger,
+		routes:     make(map[uuid.UUID][]*Target),
+	}
+}
+
+// Get returns the session's primary webhook, the one with the highest
+// priority.
+func (s *Service) Get(ctx context.Context, sessionID uuid.UUID) (*Webhook, error) {
+	webhooks, err := s.repository.ListBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, ErrWebhookNotFound
+	}
+	return webhooks[0], nil
+}
+
+func (s *Service) GetByID(ctx context.Context, sessionID, id uuid.UUID) (*Webhook, error) {
+	return s.repository.GetByID(ctx, sessionID, id)
+}
+
+func (s *Service) List(ctx context.Context, sessionID uuid.UUID) ([]*Webhook, error) {
+	return s.repository.ListBySession(ctx, sessionID)
+}
+
+// Set creates or replaces the session's primary webhook, leaving any other
+// endpoints alone.
+func (s *Service) Set(ctx context.Context, req *SetWebhookRequest) (*Webhook, error) {
+	webhook, err := s.Get(ctx, req.SessionID)
+	if err != nil && !errors.Is(err, ErrWebhookNotFound) {
+		return nil, fmt.Errorf("failed to load webhook: %w", err)
+	}
+	if webhook == nil {
+		return s.Create(ctx, req)
+	}
+	return s.save(ctx, webhook, req)
+}
+
+// Create adds another endpoint to the session, up to MaxWebhooksPerSession.
+func (s *Service) Create(ctx context.Context, req *SetWebhookRequest) (*Webhook, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.ListBySession(ctx, req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhooks: %w", err)
+	}
+	if len(existing) >= MaxWebhooksPerSession {
+		return nil, fmt.Errorf("%w (%d)", ErrTooManyWebhooks, MaxWebhooksPerSession)
+	}
+
+	return s.save(ctx, &Webhook{
+		ID:            uuid.New(),
+		SessionID:     req.SessionID,
+		SchemaVersion: DefaultSchemaVersion,
+		Format:        DefaultFormat,
+		Enabled:       true,
+		CreatedAt:     time.Now(),
+	}, req)
+}
+
+// Update replaces one endpoint's configuration. Its delivery state is kept.
+func (s *Service) Update(ctx context.Context, id uuid.UUID, req *SetWebhookRequest) (*Webhook, error) {
+	webhook, err := s.repository.GetByID(ctx, req.SessionID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, webhook, req)
+}
+
+// save applies the request to the webhook and stores it. A nil Secret,
+// SchemaVersion, Format, Priority or Enabled keeps the current value, so the secret
+// does not have to be resent on every edit and receivers are not moved to a
+// new format by accident.
+func (s *Service) save(ctx context.Context, webhook *Webhook, req *SetWebhookRequest) (*Webhook, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	webhook.URL = req.URL
+	webhook.Events = req.Events
+	webhook.Template = req.Template
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.SchemaVersion != nil {
+		webhook.SchemaVersion = *req.SchemaVersion
+	}
+	if req.Format != nil {
+		webhook.Format = *req.Format
+	}
+	if req.Priority != nil {
+		webhook.Priority = *req.Priority
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.repository.Upsert(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+
+	s.invalidate(webhook.SessionID)
+
+	s.logger.InfoWithFields("Webhook configured", map[string]interface{}{
+		"session_id": webhook.SessionID.String(),
+		"webhook_id": webhook.ID.String(),
+		"url":        webhook.URL,
+		"events":     webhook.Events,
+		"template":   len(webhook.Template) > 0,
+		"schema":     webhook.SchemaVersion,
+		"format":     webhook.Format,
+		"priority":   webhook.Priority,
+		"enabled":    webhook.Enabled,
+	})
+
+	return webhook, nil
+}
+
+func (s *Service) Delete(ctx context.Context, sessionID, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, sessionID, id); err != nil {
+		return err
+	}
+	s.invalidate(sessionID)
+	return nil
+}
+
+// Route returns every webhook of the session that should receive an event
+// of the given category, in priority order, each with its template.
+func (s *Service) Route(ctx context.Context, sessionID uuid.UUID, category EventCategory) ([]*Target, error) {
+	s.mu.RLock()
+	cached, ok := s.routes[sessionID]
+	s.mu.RUnlock()
+
+	if !ok {
+		webhooks, err := s.repository.ListBySession(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		cached = make([]*Target, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			// Templates were validated on save; a failure here means the
+			// stored value was edited by hand, so deliver untemplated.
+			template, err := ParseTemplate(webhook.Template)
+			if err != nil {
+				s.logger.WarnWithFields("Ignoring invalid stored webhook template", map[string]interface{}{
+					"session_id": sessionID.String(),
+					"webhook_id": webhook.ID.String(),
+					"error":      err.Error(),
+				})
+			}
+			cached = append(cached, &Target{Webhook: webhook, Template: template})
+		}
+
+		s.mu.Lock()
+		s.routes[sessionID] = cached
+		s.mu.Unlock()
+	}
+
+	var targets []*Target
+	for _, target := range cached {
+		if target.Webhook.Accepts(category) {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets, nil
+}
+
+// RecordDelivery stores the outcome of posting an event to a webhook.
+// Failing to store it is only logged, as the delivery itself is done.
+func (s *Service) RecordDelivery(ctx context.Context, webhook *Webhook, delivery *Delivery, deliveryErr error) {
+	statusCode := 0
+	if delivery != nil {
+		statusCode = delivery.StatusCode
+	}
+	message := ""
+	if deliveryErr != nil {
+		message = deliveryErr.Error()
+	}
+
+	if err := s.repository.RecordDelivery(ctx, webhook.ID, time.Now(), statusCode, message); err != nil {
+		s.logger.WarnWithFields("Failed to record webhook delivery", map[string]interface{}{
+			"session_id": webhook.SessionID.String(),
+			"webhook_id": webhook.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (s *Service) invalidate(sessionID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.routes, sessionID)
+	s.mu.Unlock()
+}
+
+func validate(req *SetWebhookRequest) error {
+	if err := validateURL(req.URL); err != nil {
+		return err
+	}
+	for _, category := range req.Events {
+		if !category.IsValid() {
+			return fmt.Errorf("%w: unknown event category %q", ErrInvalidWebhook, category)
+		}
+	}
+	if _, err := ParseTemplate(req.Template); err != nil {
+		return err
+	}
+	if req.SchemaVersion != nil && !ValidSchemaVersion(*req.SchemaVersion) {
+		return fmt.Errorf("%w: schemaVersion must be between %d and %d", ErrInvalidWebhook, SchemaV1, LatestSchemaVersion)
+	}
+	if req.Format != nil && !ValidFormat(*req.Format) {
+		return fmt.Errorf("%w: format must be %s or %s", ErrInvalidWebhook, FormatFull, FormatSimple)
+	}
+	return nil
+}
+
+func validateURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("%w: url is required", ErrInvalidWebhook)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: url %q is not valid", ErrInvalidWebhook, raw)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%w: url must use http or https", ErrInvalidWebhook)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Payload formats a webhook can receive. The full format is the envelope of
+// the webhook's schema version; the simple one flattens every event into a
+// SimpleEvent for low-code tools (n8n, Zapier, Make) that map fields by name
+// and struggle with the nested structures of the full payload.
+const (
+	FormatFull   = "full"
+	FormatSimple = "simple"
+
+	DefaultFormat = FormatFull
+)
+
+func ValidFormat(format string) bool {
+	return format == FormatFull || format == FormatSimple
+}
+
+// SimpleEvent is the flat payload of the simple format. Type is the kind of
+// message (text, image, audio...) for message events and the event name
+// otherwise; From is the sender's phone number, or its JID when it has none.
+// Data keeps the full payload of events with nothing to flatten.
+// LocalTimestamp repeats Timestamp in the session's timezone, when it has
+// one. State and StateVersion carry the conversation state stored for the
+// chat of an inbound message.
+type SimpleEvent struct {
+	ID             string          `json:"id"`
+	Event          string          `json:"event"`
+	SessionID      string          `json:"sessionId"`
+	MessageID      string          `json:"messageId,omitempty"`
+	From           string          `json:"from,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	Chat           string          `json:"chat,omitempty"`
+	IsGroup        bool            `json:"isGroup"`
+	FromMe         bool            `json:"fromMe"`
+	Type           string          `json:"type"`
+	Text           string          `json:"text,omitempty"`
+	MediaURL       string          `json:"mediaUrl,omitempty"`
+	State          json.RawMessage `json:"state,omitempty"`
+	StateVersion   int64           `json:"stateVersion,omitempty"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Timezone       string          `json:"timezone,omitempty"`
+	LocalTimestamp *time.Time      `json:"localTimestamp,omitempty"`
+	Data           interface{}     `json:"data,omitempty"`
+}
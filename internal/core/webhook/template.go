@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Template reshapes the event envelope before it is posted. It is a JSON
+// document mirroring the desired output: string values starting with "$" are
+// paths into the envelope ("$.data.info.Sender", "$.event", "$" for all of
+// it), everything else is copied literally. Objects and arrays nest, so a
+// template can rename, flatten or wrap fields in one go:
+//
+//	{"type": "$.event", "from": "$.data.Info.Sender", "meta": {"source": "zpwoot"}}
+//
+// Paths that do not resolve produce null rather than an error, since events
+// in a category do not all share the same fields.
+type Template struct {
+	spec interface{}
+}
+
+func ParseTemplate(raw json.RawMessage) (*Template, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var spec interface{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("%w: template is not valid JSON: %v", ErrInvalidTemplate, err)
+	}
+
+	if err := checkPaths(spec); err != nil {
+		return nil, err
+	}
+
+	return &Template{spec: spec}, nil
+}
+
+// Apply renders the template against a payload already decoded from JSON
+// (maps, slices and scalars).
+func (t *Template) Apply(payload interface{}) interface{} {
+	return render(t.spec, payload)
+}
+
+func render(spec, payload interface{}) interface{} {
+	switch v := spec.(type) {
+	case string:
+		if isPath(v) {
+			return lookup(payload, v)
+		}
+		return v
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			out[key] = render(value, payload)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = render(value, payload)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isPath(s string) bool {
+	return s == "$" || strings.HasPrefix(s, "$.")
+}
+
+func lookup(payload interface{}, path string) interface{} {
+	if path == "$" {
+		return payload
+	}
+
+	current := payload
+	for _, segment := range strings.Split(path[2:], ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current = node[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
+
+func checkPaths(spec interface{}) error {
+	switch v := spec.(type) {
+	case string:
+		if strings.HasPrefix(v, "$") && !isPath(v) {
+			return fmt.Errorf("%w: path %q must be \"$\" or start with \"$.\"", ErrInvalidTemplate, v)
+		}
+		if isPath(v) && strings.Contains(v, "..") {
+			return fmt.Errorf("%w: path %q has an empty segment", ErrInvalidTemplate, v)
+		}
+	case map[string]interface{}:
+		for _, value := range v {
+			if err := checkPaths(value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if err := checkPaths(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/audit"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+type AuditService struct {
+	coreService *audit.Service
+	logger      *logger.Logger
+	validator   *validation.Validator
+}
+
+func NewAuditService(coreService *audit.Service, logger *logger.Logger, validator *validation.Validator) *AuditService {
+	return &AuditService{
+		coreService: coreService,
+		logger:      logger,
+		validator:   validator,
+	}
+}
+
+func (s *AuditService) Record(ctx context.Context, entry *audit.Entry) {
+	s.coreService.Record(ctx, entry)
+}
+
+func (s *AuditService) ListEntries(ctx context.Context, req *contracts.ListAuditLogRequest) (*contracts.ListAuditLogResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	filter := &audit.Filter{
+		APIKey:     req.APIKey,
+		SessionRef: req.Session,
+		Method:     req.Method,
+		Path:       req.Path,
+		Success:    req.Success,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+
+	entries, total, err := s.coreService.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListAuditLogResponse{
+		Entries: make([]contracts.AuditLogEntry, len(entries)),
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}
+
+	for i, entry := range entries {
+		response.Entries[i] = contracts.AuditLogEntry{
+			ID:             entry.ID.String(),
+			APIKey:         entry.APIKey,
+			ClientIP:       entry.ClientIP,
+			UserAgent:      entry.UserAgent,
+			Session:        entry.SessionRef,
+			Method:         entry.Method,
+			Path:           entry.Path,
+			Route:          entry.Route,
+			StatusCode:     entry.StatusCode,
+			Success:        entry.Success,
+			DurationMs:     entry.DurationMs,
+			PayloadSummary: entry.PayloadSummary,
+			CreatedAt:      entry.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/internal/core/group"
+)
+
+// linkGroupContact remembers the Chatwoot contact behind a group
+// conversation seen in a webhook, whatever the event, so the contact can be
+// renamed with the group. The contact inbox source ID holds the group JID.
+func (s *ChatwootService) linkGroupContact(ctx context.Context, payload *contracts.ChatwootWebhookPayload) {
+	conversation := payload.Conversation
+	if conversation == nil {
+		conversation = conversationFromPayload(payload)
+	}
+	if conversation.ContactInbox == nil || !strings.HasSuffix(conversation.ContactInbox.SourceID, "@g.us") {
+		return
+	}
+	if conversation.Meta == nil || conversation.Meta.Sender == nil || conversation.Meta.Sender.ID == 0 {
+		return
+	}
+
+	accountID := accountFromPayload(payload)
+	inboxID := conversation.InboxID
+	if payload.Inbox != nil && payload.Inbox.ID != 0 {
+		inboxID = payload.Inbox.ID
+	}
+	if accountID == 0 || inboxID == 0 {
+		return
+	}
+
+	sessionID, err := s.core.Route(ctx, accountID, inboxID)
+	if err != nil {
+		return
+	}
+
+	err = s.core.LinkGroup(ctx, &chatwoot.GroupContact{
+		AccountID: accountID,
+		ContactID: conversation.Meta.Sender.ID,
+		SessionID: sessionID,
+		GroupJID:  conversation.ContactInbox.SourceID,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		s.logger.WarnWithFields("Failed to link Chatwoot group contact", map[string]interface{}{
+			"account_id": accountID,
+			"contact_id": conversation.Meta.Sender.ID,
+			"group_jid":  conversation.ContactInbox.SourceID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// GroupMetadataChanged renames the Chatwoot contacts of a renamed group. It
+// is a group.MetadataChangeHandler.
+func (s *ChatwootService) GroupMetadataChanged(ctx context.Context, sessionName string, change *group.MetadataChange) {
+	if !change.Renamed() {
+		return
+	}
+
+	if _, err := s.core.RenameGroup(ctx, change.SessionID, change.GroupJID, change.Name); err != nil {
+		s.logger.WarnWithFields("Failed to rename Chatwoot group contacts", map[string]interface{}{
+			"session_name": sessionName,
+			"group_jid":    change.GroupJID,
+			"error":        err.Error(),
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/internal/core/session"
+)
+
+// handleStatusChange follows a conversation being resolved or reopened in
+// Chatwoot. On resolution it sends the session's resolution message and,
+// with reopenOnReply, remembers the conversation so the contact's next
+// message reopens it.
+func (s *ChatwootService) handleStatusChange(ctx context.Context, payload *contracts.ChatwootWebhookPayload) (*contracts.ChatwootWebhookResponse, error) {
+	conversation := conversationFromPayload(payload)
+	accountID := accountFromPayload(payload)
+	if accountID == 0 || conversation.InboxID == 0 {
+		return &contracts.ChatwootWebhookResponse{Reason: "missing account or conversation"}, nil
+	}
+	if payload.Status != "resolved" && payload.Status != "open" {
+		return &contracts.ChatwootWebhookResponse{Reason: "status " + payload.Status + " is not routed"}, nil
+	}
+
+	sessionID, err := s.core.Route(ctx, accountID, conversation.InboxID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.chatwootEnabled(ctx, sessionID) {
+		return &contracts.ChatwootWebhookResponse{SessionID: sessionID.String(), Reason: "chatwoot disabled for session"}, nil
+	}
+	response := &contracts.ChatwootWebhookResponse{Routed: true, SessionID: sessionID.String()}
+
+	if payload.Status == "open" {
+		if err := s.core.Reopened(ctx, accountID, conversation.ID); err != nil {
+			return nil, err
+		}
+		response.Reason = "conversation reopened"
+		return response, nil
+	}
+
+	settings, err := s.chatwootSettings(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	to := recipientJID(conversation)
+	if to == "" {
+		return nil, fmt.Errorf("validation failed: conversation %d has no WhatsApp contact", conversation.ID)
+	}
+
+	if settings.ReopenOnReply && s.core.CanReopen() {
+		err := s.core.Resolved(ctx, &chatwoot.Resolution{
+			AccountID:      accountID,
+			ConversationID: conversation.ID,
+			SessionID:      sessionID,
+			ContactJID:     to,
+			ResolvedAt:     time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.ResolvedMessage == "" {
+		response.Reason = "no resolution message"
+		return response, nil
+	}
+
+	sent, err := s.deliver(ctx, sessionID, to, s.renderMessage(settings.ResolvedMessage, conversation), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Chatwoot resolution message sent", map[string]interface{}{
+		"account_id":      accountID,
+		"conversation_id": conversation.ID,
+		"session_id":      sessionID.String(),
+		"message_id":      sent.MessageID,
+	})
+
+	return sent, nil
+}
+
+// HandleContactReply reopens the contact's resolved conversation when the
+// session has reopenOnReply on. It is called for every message a contact
+// sends, before the message is forwarded to Chatwoot.
+func (s *ChatwootService) HandleContactReply(ctx context.Context, sessionID, contactJID string) error {
+	if !s.core.CanReopen() {
+		return nil
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if resolved.Session == nil || !resolved.Session.Settings.Chatwoot.ReopenOnReply ||
+		!resolved.Session.Settings.Features.Enabled(session.FeatureChatwoot) {
+		return nil
+	}
+
+	_, err = s.core.Reopen(ctx, resolved.ID, contactJID)
+	return err
+}
+
+// csatMessage is the text sent in place of Chatwoot's survey prompt: the
+// session's CSAT message, or else the prompt with the survey link appended
+// so the contact can actually answer it.
+func (s *ChatwootService) csatMessage(ctx context.Context, sessionID uuid.UUID, payload *contracts.ChatwootWebhookPayload) (string, error) {
+	settings, err := s.chatwootSettings(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+	if settings.CSATMessage != "" {
+		return s.renderMessage(settings.CSATMessage, payload.Conversation), nil
+	}
+
+	body := strings.TrimSpace(payload.Content)
+	if url := s.surveyURL(payload.Conversation); url != "" {
+		body = strings.TrimSpace(body + "\n\n" + url)
+	}
+	return body, nil
+}
+
+func (s *ChatwootService) chatwootSettings(ctx context.Context, sessionID uuid.UUID) (session.ChatwootSettings, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID.String())
+	if err != nil {
+		return session.ChatwootSettings{}, err
+	}
+	if resolved.Session == nil {
+		return session.ChatwootSettings{}, nil
+	}
+	return resolved.Session.Settings.Chatwoot, nil
+}
+
+func (s *ChatwootService) renderMessage(template string, conversation *contracts.ChatwootConversation) string {
+	name := ""
+	if conversation.Meta != nil && conversation.Meta.Sender != nil {
+		name = conversation.Meta.Sender.Name
+	}
+
+	return strings.NewReplacer(
+		"{{name}}", name,
+		"{{conversation_id}}", strconv.Itoa(conversation.ID),
+		"{{survey_url}}", s.surveyURL(conversation),
+	).Replace(template)
+}
+
+// surveyURL is the public CSAT page of the conversation, which needs the
+// Chatwoot URL and the conversation's UUID.
+func (s *ChatwootService) surveyURL(conversation *contracts.ChatwootConversation) string {
+	if s.baseURL == "" || conversation.UUID == "" {
+		return ""
+	}
+	return s.baseURL + "/survey/responses/" + conversation.UUID
+}
+
+func conversationFromPayload(payload *contracts.ChatwootWebhookPayload) *contracts.ChatwootConversation {
+	return &contracts.ChatwootConversation{
+		ID:           payload.ID,
+		UUID:         payload.UUID,
+		Status:       payload.Status,
+		InboxID:      payload.InboxID,
+		Meta:         payload.Meta,
+		ContactInbox: payload.ContactInbox,
+	}
+}
+
+func accountFromPayload(payload *contracts.ChatwootWebhookPayload) int {
+	if payload.Account != nil && payload.Account.ID != 0 {
+		return payload.Account.ID
+	}
+	for _, message := range payload.Messages {
+		if message.AccountID != 0 {
+			return message.AccountID
+		}
+	}
+	return 0
+}
@@ -1 +1,253 @@
 package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// ChatwootService routes agent replies from a Chatwoot account to the
+// session behind each inbox, so several numbers can share one account.
+// baseURL is the Chatwoot installation, used to build survey links.
+type ChatwootService struct {
+	core      *chatwoot.Service
+	resolver  session.SessionResolver
+	messages  *MessageService
+	baseURL   string
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewChatwootService(
+	core *chatwoot.Service,
+	resolver session.SessionResolver,
+	messages *MessageService,
+	baseURL string,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *ChatwootService {
+	return &ChatwootService{
+		core:      core,
+		resolver:  resolver,
+		messages:  messages,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (s *ChatwootService) ListInboxes(ctx context.Context, accountID int) (*contracts.ListChatwootInboxesResponse, error) {
+	routes, err := s.core.List(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListChatwootInboxesResponse{
+		Inboxes: make([]contracts.ChatwootInboxMapping, len(routes)),
+		Total:   len(routes),
+	}
+	for i, route := range routes {
+		response.Inboxes[i] = *s.inboxToDTO(ctx, route)
+	}
+
+	return response, nil
+}
+
+func (s *ChatwootService) MapInbox(ctx context.Context, accountID, inboxID int, req *contracts.MapChatwootInboxRequest) (*contracts.ChatwootInboxMapping, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := s.core.Map(ctx, accountID, inboxID, resolved.ID, req.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Chatwoot inbox mapped", map[string]interface{}{
+		"account_id": accountID,
+		"inbox_id":   inboxID,
+		"session_id": resolved.ID.String(),
+		"force":      req.Force,
+	})
+
+	return s.inboxToDTO(ctx, route), nil
+}
+
+func (s *ChatwootService) UnmapInbox(ctx context.Context, accountID, inboxID int) error {
+	return s.core.Unmap(ctx, accountID, inboxID)
+}
+
+// HandleWebhook sends an agent reply through the session mapped to the
+// conversation's inbox, replacing Chatwoot's satisfaction survey prompt with
+// the session's CSAT message. Status changes are handed to
+// handleStatusChange; other events are acknowledged without routing. Group
+// conversations in any event link their contact for renames.
+func (s *ChatwootService) HandleWebhook(ctx context.Context, payload *contracts.ChatwootWebhookPayload) (*contracts.ChatwootWebhookResponse, error) {
+	s.linkGroupContact(ctx, payload)
+
+	if payload.Event == "conversation_status_changed" {
+		return s.handleStatusChange(ctx, payload)
+	}
+	if reason := skipReason(payload); reason != "" {
+		return &contracts.ChatwootWebhookResponse{Reason: reason}, nil
+	}
+
+	accountID := payload.Account.ID
+	inboxID := payload.Conversation.InboxID
+	if payload.Inbox != nil && payload.Inbox.ID != 0 {
+		inboxID = payload.Inbox.ID
+	}
+
+	sessionID, err := s.core.Route(ctx, accountID, inboxID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.chatwootEnabled(ctx, sessionID) {
+		return &contracts.ChatwootWebhookResponse{SessionID: sessionID.String(), Reason: "chatwoot disabled for session"}, nil
+	}
+
+	to := recipientJID(payload.Conversation)
+	if to == "" {
+		return nil, fmt.Errorf("validation failed: conversation %d has no WhatsApp contact", payload.Conversation.ID)
+	}
+
+	body := payload.Content
+	format := s.agentFormat(ctx, sessionID)
+	if payload.ContentType == "input_csat" {
+		body, err = s.csatMessage(ctx, sessionID, payload)
+		if err != nil {
+			return nil, err
+		}
+		format = nil
+	}
+
+	response, err := s.deliver(ctx, sessionID, to, body, format)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Chatwoot reply routed", map[string]interface{}{
+		"account_id":      accountID,
+		"inbox_id":        inboxID,
+		"conversation_id": payload.Conversation.ID,
+		"session_id":      sessionID.String(),
+		"message_id":      response.MessageID,
+		"csat":            payload.ContentType == "input_csat",
+	})
+
+	return response, nil
+}
+
+// deliver sends text to a contact through the session, holding it for the
+// session's quiet hours or warm-up limit like any other send. A nil format
+// uses the session's text format.
+func (s *ChatwootService) deliver(ctx context.Context, sessionID uuid.UUID, to, body string, format *contracts.TextFormatSettings) (*contracts.ChatwootWebhookResponse, error) {
+	req := &contracts.SendTextMessageRequest{RemoteJID: to, Body: body, Formatting: format}
+	response := &contracts.ChatwootWebhookResponse{Routed: true, SessionID: sessionID.String()}
+
+	scheduled, err := s.messages.HoldForQuietHours(ctx, sessionID.String(), "", SendKindText, req)
+	if err == nil && scheduled == nil {
+		scheduled, err = s.messages.HoldForWarmUp(ctx, sessionID.String(), SendKindText, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scheduled != nil {
+		response.Reason = "scheduled " + scheduled.ID
+		return response, nil
+	}
+
+	sent, err := s.messages.SendTextMessage(WithTextFormat(ctx, format), sessionID.String(), to, body)
+	if err != nil {
+		return nil, err
+	}
+	response.MessageID = sent.MessageID
+
+	return response, nil
+}
+
+// agentFormat is the session's text format with Markdown conversion on:
+// agents write in Chatwoot's Markdown editor, so **bold**, *italic*,
+// ~~strike~~ and code reach the contact as WhatsApp formatting rather than
+// raw marks.
+func (s *ChatwootService) agentFormat(ctx context.Context, sessionID uuid.UUID) *contracts.TextFormatSettings {
+	format := &contracts.TextFormatSettings{Markdown: true}
+	if resolved, err := s.resolver.Resolve(ctx, sessionID.String()); err == nil && resolved.Session != nil {
+		format.Emoji = resolved.Session.Settings.TextFormat.Emoji
+		format.Normalize = resolved.Session.Settings.TextFormat.Normalize
+	}
+	return format
+}
+
+// chatwootEnabled reports whether the session has the Chatwoot feature on.
+// A session that cannot be resolved is treated as enabled so the send that
+// follows reports the real error.
+func (s *ChatwootService) chatwootEnabled(ctx context.Context, sessionID uuid.UUID) bool {
+	resolved, err := s.resolver.Resolve(ctx, sessionID.String())
+	if err != nil || resolved.Session == nil {
+		return true
+	}
+	return resolved.Session.Settings.Features.Enabled(session.FeatureChatwoot)
+}
+
+func (s *ChatwootService) inboxToDTO(ctx context.Context, route *chatwoot.InboxRoute) *contracts.ChatwootInboxMapping {
+	dto := &contracts.ChatwootInboxMapping{
+		AccountID: route.AccountID,
+		InboxID:   route.InboxID,
+		SessionID: route.SessionID.String(),
+		CreatedAt: route.CreatedAt,
+		UpdatedAt: route.UpdatedAt,
+	}
+	if resolved, err := s.resolver.Resolve(ctx, route.SessionID.String()); err == nil {
+		dto.SessionName = resolved.Name
+	}
+
+	return dto
+}
+
+func skipReason(payload *contracts.ChatwootWebhookPayload) string {
+	switch {
+	case payload.Event != "message_created":
+		return "event " + payload.Event + " is not routed"
+	case payload.MessageType != "outgoing":
+		return "not an outgoing message"
+	case payload.Private:
+		return "private note"
+	case strings.TrimSpace(payload.Content) == "" && payload.ContentType != "input_csat":
+		return "empty message"
+	case payload.Account == nil || payload.Conversation == nil:
+		return "missing account or conversation"
+	}
+	return ""
+}
+
+// recipientJID prefers the contact inbox source ID, which holds the JID for
+// contacts created by the integration, and falls back to the phone number.
+func recipientJID(conversation *contracts.ChatwootConversation) string {
+	if conversation.ContactInbox != nil && strings.Contains(conversation.ContactInbox.SourceID, "@") {
+		return conversation.ContactInbox.SourceID
+	}
+	if conversation.Meta == nil || conversation.Meta.Sender == nil {
+		return ""
+	}
+
+	phone := strings.TrimPrefix(strings.TrimSpace(conversation.Meta.Sender.PhoneNumber), "+")
+	if _, err := strconv.ParseUint(phone, 10, 64); err != nil {
+		return ""
+	}
+	return phone + "@s.whatsapp.net"
+}
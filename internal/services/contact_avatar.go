@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/messaging"
+)
+
+// SetAvatars serves avatar lookups from cache, linking the stored copy
+// through host when one is configured.
+func (s *ContactService) SetAvatars(cache *contact.AvatarCache, host messaging.MediaHost) {
+	s.avatars = cache
+	s.mediaHost = host
+}
+
+// GetAvatar returns a contact's profile picture. URL links the stored copy
+// when it can be published, and otherwise is WhatsApp's own link, which
+// expires after a while.
+func (s *ContactService) GetAvatar(ctx context.Context, sessionID, jid string, refresh bool) (*contracts.ContactAvatarResponse, error) {
+	jid = strings.TrimSpace(jid)
+	if jid == "" {
+		return nil, fmt.Errorf("validation failed: jid is required")
+	}
+	if !strings.Contains(jid, "@") {
+		phone, ok := contact.NormalizeNumber(jid)
+		if !ok {
+			return nil, fmt.Errorf("validation failed: invalid JID")
+		}
+		jid = phone + "@s.whatsapp.net"
+	}
+
+	if s.avatars == nil {
+		return nil, fmt.Errorf("avatar lookups are not available")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	avatar, err := s.avatars.Get(ctx, resolved.ID, resolved.Name, jid, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ContactAvatarResponse{
+		JID:        avatar.JID,
+		HasPicture: avatar.PictureID != "",
+		PictureID:  avatar.PictureID,
+		URL:        avatar.URL,
+		Cached:     avatar.Cached,
+		CheckedAt:  avatar.CheckedAt,
+		ChangedAt:  avatar.ChangedAt,
+	}
+
+	if s.mediaHost != nil && avatar.LocalPath != "" {
+		hosted, err := s.mediaHost.Publish(ctx, resolved.ID, avatar.LocalPath, "image/jpeg")
+		if err != nil {
+			s.logger.WarnWithFields("Failed to publish avatar", map[string]interface{}{
+				"session_id": sessionID,
+				"jid":        jid,
+				"error":      err.Error(),
+			})
+		} else {
+			response.URL = hosted.URL
+			response.ExpiresAt = &hosted.ExpiresAt
+		}
+	}
+
+	return response, nil
+}
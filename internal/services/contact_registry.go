@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/shared/pagination"
+)
+
+// SetRegistry serves the contact registry shared by all sessions.
+func (s *ContactService) SetRegistry(registry *contact.Registry) {
+	s.registry = registry
+}
+
+// SyncContacts records the session's address book in the contact registry.
+func (s *ContactService) SyncContacts(ctx context.Context, sessionID string) (*contracts.SyncContactsResponse, error) {
+	if s.registry == nil || s.source == nil {
+		return nil, fmt.Errorf("contact registry is not available")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := s.source.GetAllContacts(ctx, resolved.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	result := s.registry.SyncAddressBook(ctx, resolved.ID, contacts)
+
+	return &contracts.SyncContactsResponse{
+		TotalContacts: result.Total,
+		SyncedCount:   result.New + result.Updated,
+		NewCount:      result.New,
+		UpdatedCount:  result.Updated,
+		Success:       result.Failed == 0,
+		Message:       fmt.Sprintf("Synced %d contacts (%d new, %d updated)", result.New+result.Updated, result.New, result.Updated),
+	}, nil
+}
+
+// ListRegistryContacts pages through the contact registry, optionally only
+// through the users one session has seen.
+func (s *ContactService) ListRegistryContacts(ctx context.Context, sessionID, search, cursor string, limit int) (*contracts.ListRegistryContactsResponse, error) {
+	if s.registry == nil {
+		return nil, fmt.Errorf("contact registry is not available")
+	}
+
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	filter := &contact.RegistryFilter{
+		Search: search,
+		After:  after,
+		Limit:  limit,
+	}
+	if sessionID != "" {
+		resolved, err := s.resolver.Resolve(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		filter.SessionID = &resolved.ID
+	}
+
+	entries, hasMore, err := s.registry.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListRegistryContactsResponse{
+		Contacts: make([]contracts.RegistryContact, len(entries)),
+		Limit:    pagination.ClampLimit(limit),
+		HasMore:  hasMore,
+	}
+	for i, entry := range entries {
+		response.Contacts[i] = registryContactToDTO(entry)
+	}
+	if hasMore {
+		last := entries[len(entries)-1].JID
+		response.NextCursor = pagination.Encode(pagination.Cursor{Key: last, ID: last})
+	}
+
+	return response, nil
+}
+
+// GetRegistryContact returns a registry contact by registry ID, JID or
+// phone number.
+func (s *ContactService) GetRegistryContact(ctx context.Context, ref string) (*contracts.RegistryContact, error) {
+	if s.registry == nil {
+		return nil, fmt.Errorf("contact registry is not available")
+	}
+
+	entry, err := s.registry.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := registryContactToDTO(entry)
+	return &dto, nil
+}
+
+func registryContactToDTO(entry *contact.RegistryEntry) contracts.RegistryContact {
+	dto := contracts.RegistryContact{
+		ID:           entry.ID.String(),
+		JID:          entry.JID,
+		PhoneNumber:  entry.PhoneNumber,
+		DisplayName:  entry.Name,
+		Name:         entry.Name,
+		PushName:     entry.PushName,
+		BusinessName: entry.BusinessName,
+		IsBusiness:   entry.IsBusiness(),
+		Sessions:     make([]contracts.RegistryContactSession, len(entry.Sessions)),
+		CreatedAt:    entry.CreatedAt,
+		UpdatedAt:    entry.UpdatedAt,
+	}
+	if dto.DisplayName == "" {
+		dto.DisplayName = entry.BusinessName
+	}
+	if dto.DisplayName == "" {
+		dto.DisplayName = entry.PushName
+	}
+	if avatar := entry.Avatar(); avatar != nil {
+		dto.AvatarPictureID = avatar.AvatarPictureID
+		dto.AvatarURL = avatar.AvatarURL
+	}
+
+	for i, overlay := range entry.Sessions {
+		dto.Sessions[i] = contracts.RegistryContactSession{
+			SessionID:       overlay.SessionID.String(),
+			SessionName:     overlay.SessionName,
+			Name:            overlay.Name,
+			PushName:        overlay.PushName,
+			BusinessName:    overlay.BusinessName,
+			IsContact:       overlay.IsContact,
+			AvatarPictureID: overlay.AvatarPictureID,
+			FirstSeenAt:     overlay.FirstSeenAt,
+			LastSeenAt:      overlay.LastSeenAt,
+		}
+	}
+
+	return dto
+}
@@ -1,15 +1,174 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/shared/pagination"
 	"zpwoot/platform/logger"
 )
 
+// ContactSource lists the contacts stored on a WhatsApp session's device.
+type ContactSource interface {
+	GetAllContacts(ctx context.Context, sessionName string) ([]*contact.ContactInfo, error)
+}
+
 type ContactService struct {
-	logger *logger.Logger
+	resolver session.SessionResolver
+	source   ContactSource
+	numbers  *contact.NumberChecker
+	logger   *logger.Logger
+
+	avatars   *contact.AvatarCache
+	mediaHost messaging.MediaHost
+	registry  *contact.Registry
 }
 
-func NewContactService(logger *logger.Logger) *ContactService {
+func NewContactService(resolver session.SessionResolver, source ContactSource, numbers *contact.NumberChecker, logger *logger.Logger) *ContactService {
 	return &ContactService{
-		logger: logger,
+		resolver: resolver,
+		source:   source,
+		numbers:  numbers,
+		logger:   logger,
+	}
+}
+
+// CheckNumbers reports which numbers are on WhatsApp, in request order.
+// Numbers that cannot be phone numbers are answered with an error instead
+// of failing the request.
+func (s *ContactService) CheckNumbers(ctx context.Context, sessionID string, req *contracts.CheckWhatsAppRequest) (*contracts.CheckWhatsAppResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, len(req.PhoneNumbers))
+	phones := make([]string, 0, len(req.PhoneNumbers))
+	seen := make(map[string]bool, len(req.PhoneNumbers))
+	for i, raw := range req.PhoneNumbers {
+		phone, ok := contact.NormalizeNumber(raw)
+		if !ok {
+			continue
+		}
+		normalized[i] = phone
+		if !seen[phone] {
+			seen[phone] = true
+			phones = append(phones, phone)
+		}
+	}
+
+	checks := map[string]*contact.NumberCheckResult{}
+	if len(phones) > 0 {
+		checks, err = s.numbers.Check(ctx, resolved.Name, phones, req.Refresh)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := &contracts.CheckWhatsAppResponse{
+		Results: make([]contracts.WhatsAppCheckResult, len(req.PhoneNumbers)),
+		Total:   len(req.PhoneNumbers),
+		Success: true,
+	}
+	for i, raw := range req.PhoneNumbers {
+		result := contracts.WhatsAppCheckResult{PhoneNumber: raw, Normalized: normalized[i]}
+		check, ok := checks[normalized[i]]
+		switch {
+		case normalized[i] == "":
+			result.Error = "invalid phone number"
+			response.Invalid++
+		case ok:
+			checkedAt := check.CheckedAt
+			result.IsOnWhatsApp = check.OnWhatsApp
+			result.JID = check.JID
+			result.Cached = check.Cached
+			result.CheckedAt = &checkedAt
+			if check.OnWhatsApp {
+				response.Found++
+			}
+			if check.Cached {
+				response.Cached++
+			}
+		}
+		response.Results[i] = result
+	}
+	response.Message = fmt.Sprintf("Checked %d numbers, found %d on WhatsApp", response.Total, response.Found)
+
+	return response, nil
+}
+
+func (s *ContactService) ListContacts(ctx context.Context, sessionID string, req *contracts.ListContactsRequest) (*contracts.ListContactsResponse, error) {
+	after, err := pagination.Decode(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	limit := pagination.ClampLimit(req.Limit)
+
+	if s.source == nil {
+		return nil, fmt.Errorf("contact listing is not available")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := s.source.GetAllContacts(ctx, resolved.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	if search := strings.ToLower(strings.TrimSpace(req.Search)); search != "" {
+		filtered := contacts[:0]
+		for _, c := range contacts {
+			if strings.Contains(strings.ToLower(c.Name), search) ||
+				strings.Contains(strings.ToLower(c.BusinessName), search) ||
+				strings.Contains(c.PhoneNumber, search) {
+				filtered = append(filtered, c)
+			}
+		}
+		contacts = filtered
+	}
+
+	sort.Slice(contacts, func(i, j int) bool {
+		return contacts[i].JID < contacts[j].JID
+	})
+
+	page, hasMore := pagination.Slice(contacts, func(c *contact.ContactInfo) string {
+		return c.JID
+	}, after, limit)
+
+	details := make([]contracts.ContactDetails, len(page))
+	for i, c := range page {
+		details[i] = contracts.ContactDetails{
+			JID:          c.JID,
+			PhoneNumber:  c.PhoneNumber,
+			Name:         c.Name,
+			BusinessName: c.BusinessName,
+			IsBusiness:   c.IsBusiness,
+			IsContact:    c.IsContact,
+		}
+	}
+
+	response := &contracts.ListContactsResponse{
+		Contacts: details,
+		Total:    len(contacts),
+		Limit:    limit,
+		HasMore:  hasMore,
+		Success:  true,
+		Message:  fmt.Sprintf("Retrieved %d contacts", len(details)),
+	}
+
+	if hasMore {
+		last := page[len(page)-1].JID
+		response.NextCursor = pagination.Encode(pagination.Cursor{Key: last, ID: last})
+	}
+
+	return response, nil
 }
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/conversation"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// ConversationService lets bots keep dialog state per chat in zpwoot. The
+// same state is attached to the chat's inbound message webhooks, so a
+// backend can answer without a store of its own.
+type ConversationService struct {
+	core           *conversation.Service
+	resolver       session.SessionResolver
+	defaultCountry string
+	logger         *logger.Logger
+	validator      *validation.Validator
+}
+
+func NewConversationService(
+	core *conversation.Service,
+	resolver session.SessionResolver,
+	defaultCountry string,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *ConversationService {
+	return &ConversationService{
+		core:           core,
+		resolver:       resolver,
+		defaultCountry: defaultCountry,
+		logger:         logger,
+		validator:      validator,
+	}
+}
+
+func (s *ConversationService) GetState(ctx context.Context, sessionID, chatJID string) (*contracts.ConversationStateResponse, error) {
+	id, chat, err := s.resolve(ctx, sessionID, chatJID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.core.Get(ctx, id, chat)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversationStateToDTO(state), nil
+}
+
+func (s *ConversationService) PutState(ctx context.Context, sessionID, chatJID string, req *contracts.PutConversationStateRequest) (*contracts.ConversationStateResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, chat, err := s.resolve(ctx, sessionID, chatJID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.core.Put(ctx, id, chat, req.Data, time.Duration(req.TTLSeconds)*time.Second, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversationStateToDTO(state), nil
+}
+
+func (s *ConversationService) DeleteState(ctx context.Context, sessionID, chatJID string, version *int64) error {
+	id, chat, err := s.resolve(ctx, sessionID, chatJID)
+	if err != nil {
+		return err
+	}
+
+	return s.core.Delete(ctx, id, chat, version)
+}
+
+// resolve reads the chat the way inbound webhooks report it, so state
+// written for a phone number is found when that number writes back.
+func (s *ConversationService) resolve(ctx context.Context, sessionID, chatJID string) (uuid.UUID, string, error) {
+	chat, err := messaging.ParseRecipient(chatJID, s.defaultCountry)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return resolved.ID, chat.JID, nil
+}
+
+func conversationStateToDTO(state *conversation.State) *contracts.ConversationStateResponse {
+	return &contracts.ConversationStateResponse{
+		ChatJID:   state.ChatJID,
+		Data:      state.Data,
+		Version:   state.Version,
+		ExpiresAt: state.ExpiresAt,
+		UpdatedAt: state.UpdatedAt,
+	}
+}
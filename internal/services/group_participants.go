@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/group"
+)
+
+// ContactLookup reads what a session's device has stored about users.
+type ContactLookup interface {
+	LookupContacts(ctx context.Context, sessionName string, jids []string) (map[string]*contact.ContactInfo, error)
+}
+
+// SetContacts lets group info include the stored names of participants.
+func (s *GroupService) SetContacts(contacts ContactLookup) {
+	s.contacts = contacts
+}
+
+// lookupParticipants returns the stored contact of each participant, keyed by
+// participantKey. Names are an extra, so a failed lookup only drops them.
+func (s *GroupService) lookupParticipants(ctx context.Context, sessionID string, participants []group.Participant) map[string]*contact.ContactInfo {
+	if s.contacts == nil || len(participants) == 0 {
+		return nil
+	}
+
+	jids := make([]string, len(participants))
+	for i, p := range participants {
+		jids[i] = participantKey(p)
+	}
+
+	known, err := s.contacts.LookupContacts(ctx, sessionID, jids)
+	if err != nil {
+		s.logger.WarnWithFields("Failed to look up group participants", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return nil
+	}
+
+	return known
+}
+
+// participantKey is the JID contacts are stored under: the phone number JID
+// when WhatsApp identifies the participant by LID.
+func participantKey(p group.Participant) string {
+	if p.PhoneJID != "" {
+		return p.PhoneJID
+	}
+	return p.JID
+}
+
+// InviteSender sends the direct message carrying a group invite link, going
+// through the same checks as any other text the session sends.
+type InviteSender interface {
+	SendTextMessage(ctx context.Context, sessionName, to, content string) (*contracts.SendMessageResponse, error)
+}
+
+// SetInviteSender lets adds fall back to sending the invite link.
+func (s *GroupService) SetInviteSender(sender InviteSender) {
+	s.invites = sender
+}
+
+// inviteByLink sends the group invite link by direct message to the
+// participants of results WhatsApp would not add because of their privacy
+// settings, marking those it reached. A failure is recorded on the
+// participant instead of failing the add.
+func (s *GroupService) inviteByLink(ctx context.Context, sessionID, groupJID string, fallback *group.InviteFallback, results []group.ParticipantAddResult) {
+	var pending []int
+	for i, result := range results {
+		if result.Status == group.BulkAddStatusNeedsInvite {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	fail := func(reason string) {
+		for _, i := range pending {
+			results[i].InviteError = reason
+		}
+	}
+
+	if s.invites == nil {
+		fail("sending invite links is not available")
+		return
+	}
+
+	invite, err := s.whatsappGateway.GetGroupInviteLink(ctx, sessionID, groupJID)
+	if err != nil {
+		s.logger.WarnWithFields("Failed to get invite link for participants that could not be added", map[string]interface{}{
+			"session_id": sessionID,
+			"group_jid":  groupJID,
+			"error":      err.Error(),
+		})
+		fail("failed to get the group invite link: " + err.Error())
+		return
+	}
+
+	text := fallback.Text(invite.Link)
+	for _, i := range pending {
+		if _, err := s.invites.SendTextMessage(ctx, sessionID, results[i].JID, text); err != nil {
+			results[i].InviteError = err.Error()
+			continue
+		}
+		results[i].InvitedViaLink = true
+	}
+
+	s.logger.InfoWithFields("Sent invite links to participants that could not be added", map[string]interface{}{
+		"session_id":   sessionID,
+		"group_jid":    groupJID,
+		"participants": len(pending),
+	})
+}
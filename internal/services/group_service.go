@@ -2,10 +2,23 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
 	"zpwoot/internal/core/group"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/shared/pagination"
 	"zpwoot/internal/services/shared/validation"
 	"zpwoot/platform/logger"
 )
@@ -14,14 +27,22 @@ type GroupService struct {
 	groupCore       group.Service
 	groupRepo       group.Repository
 	whatsappGateway group.WhatsAppGateway
+	numbers         *contact.NumberChecker
+	contacts        ContactLookup
+	mediaPool       *messaging.MediaPool
+	invites         InviteSender
 	logger          *logger.Logger
 	validator       *validation.Validator
+
+	bulkMu   sync.RWMutex
+	bulkJobs map[string]*group.BulkImportJob
 }
 
 func NewGroupService(
 	groupCore group.Service,
 	groupRepo group.Repository,
 	whatsappGateway group.WhatsAppGateway,
+	numbers *contact.NumberChecker,
 	logger *logger.Logger,
 	validator *validation.Validator,
 ) *GroupService {
@@ -29,17 +50,26 @@ func NewGroupService(
 		groupCore:       groupCore,
 		groupRepo:       groupRepo,
 		whatsappGateway: whatsappGateway,
+		numbers:         numbers,
 		logger:          logger,
 		validator:       validator,
+		bulkJobs:        make(map[string]*group.BulkImportJob),
 	}
 }
 
+// SetMediaPool runs group photo resizing on the media pool instead of the
+// request goroutine.
+func (s *GroupService) SetMediaPool(pool *messaging.MediaPool) {
+	s.mediaPool = pool
+}
+
 func (s *GroupService) CreateGroup(ctx context.Context, sessionID string, req *contracts.CreateGroupRequest) (*contracts.CreateGroupResponse, error) {
 	s.logger.InfoWithFields("Creating group", map[string]interface{}{
 		"session_id":      sessionID,
 		"group_name":      req.Name,
 		"participants":    len(req.Participants),
 		"has_description": req.Description != "",
+		"has_settings":    req.Settings != nil,
 	})
 
 	if err := s.validator.ValidateStruct(req); err != nil {
@@ -52,23 +82,33 @@ func (s *GroupService) CreateGroup(ctx context.Context, sessionID string, req *c
 		Participants: req.Participants,
 	}
 
+	if req.Settings != nil {
+		domainReq.Settings = &group.CreateGroupSettings{
+			DisappearingTimer: req.Settings.DisappearingTimer,
+			Announce:          req.Settings.Announce,
+			MemberAddMode:     req.Settings.MemberAddMode,
+			JoinApprovalMode:  req.Settings.JoinApprovalMode,
+		}
+	}
+
 	if err := s.groupCore.ValidateGroupCreation(domainReq); err != nil {
 		return nil, fmt.Errorf("group validation failed: %w", err)
 	}
 
-	groupInfo, err := s.whatsappGateway.CreateGroup(ctx, sessionID, req.Name, req.Participants, req.Description)
+	groupInfo, err := s.whatsappGateway.CreateGroup(ctx, sessionID, req.Name, req.Participants, req.Description, domainReq.Settings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create group in WhatsApp: %w", err)
 	}
 
-	groupModel := s.convertGroupInfoToModel(groupInfo, sessionID)
-	if err := s.groupRepo.Create(ctx, groupModel); err != nil {
-		s.logger.ErrorWithFields("Failed to save group to database", map[string]interface{}{
-			"session_id": sessionID,
-			"group_jid":  groupInfo.GroupJID,
-			"error":      err.Error(),
-		})
-
+	if s.groupRepo != nil {
+		groupModel := s.convertGroupInfoToModel(groupInfo, sessionID)
+		if err := s.groupRepo.Create(ctx, groupModel); err != nil {
+			s.logger.ErrorWithFields("Failed to save group to database", map[string]interface{}{
+				"session_id": sessionID,
+				"group_jid":  groupInfo.GroupJID,
+				"error":      err.Error(),
+			})
+		}
 	}
 
 	response := &contracts.CreateGroupResponse{
@@ -76,6 +116,7 @@ func (s *GroupService) CreateGroup(ctx context.Context, sessionID string, req *c
 		Name:         groupInfo.Name,
 		Description:  groupInfo.Description,
 		Participants: req.Participants,
+		Settings:     groupSettingsToDTO(groupInfo.Settings),
 		CreatedAt:    groupInfo.CreatedAt,
 		Success:      true,
 		Message:      "Group created successfully",
@@ -90,47 +131,69 @@ func (s *GroupService) CreateGroup(ctx context.Context, sessionID string, req *c
 	return response, nil
 }
 
-func (s *GroupService) ListGroups(ctx context.Context, sessionID string) (*contracts.ListGroupsResponse, error) {
+func (s *GroupService) ListGroups(ctx context.Context, sessionID string, req *contracts.ListGroupsRequest) (*contracts.ListGroupsResponse, error) {
 	s.logger.InfoWithFields("Listing groups", map[string]interface{}{
 		"session_id": sessionID,
+		"limit":      req.Limit,
 	})
 
+	after, err := pagination.Decode(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	limit := pagination.ClampLimit(req.Limit)
+
 	groupInfos, err := s.whatsappGateway.ListJoinedGroups(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list groups from WhatsApp: %w", err)
 	}
 
-	groups := make([]contracts.GroupInfo, len(groupInfos))
-	for i, groupInfo := range groupInfos {
-		groups[i] = contracts.GroupInfo{
-			GroupJID:     groupInfo.GroupJID,
-			Name:         groupInfo.Name,
-			Description:  groupInfo.Description,
-			Owner:        groupInfo.Owner,
-			Participants: len(groupInfo.Participants),
-			CreatedAt:    groupInfo.CreatedAt,
-		}
+	// WhatsApp returns groups in no particular order; JIDs never change, so
+	// they give a stable order to page over.
+	sort.Slice(groupInfos, func(i, j int) bool {
+		return groupInfos[i].GroupJID < groupInfos[j].GroupJID
+	})
+
+	page, hasMore := pagination.Slice(groupInfos, func(g *group.GroupInfo) string {
+		return g.GroupJID
+	}, after, limit)
+
+	groups := make([]contracts.GroupSummary, len(page))
+	for i, groupInfo := range page {
+		groups[i] = groupSummaryToDTO(groupInfo)
 	}
 
 	response := &contracts.ListGroupsResponse{
 		Groups:  groups,
 		Count:   len(groups),
+		Total:   len(groupInfos),
+		HasMore: hasMore,
 		Success: true,
 		Message: "Groups retrieved successfully",
 	}
 
+	if hasMore {
+		last := page[len(page)-1].GroupJID
+		response.NextCursor = pagination.Encode(pagination.Cursor{Key: last, ID: last})
+	}
+
 	s.logger.InfoWithFields("Groups listed successfully", map[string]interface{}{
 		"session_id":  sessionID,
 		"group_count": len(groups),
+		"total":       len(groupInfos),
 	})
 
 	return response, nil
 }
 
-func (s *GroupService) GetGroupInfo(ctx context.Context, sessionID, groupJID string) (*contracts.GetGroupInfoResponse, error) {
+// GetGroupInfo returns the group with its participants. With
+// includeContacts each participant also carries the names and business flag
+// the session's device has stored for them.
+func (s *GroupService) GetGroupInfo(ctx context.Context, sessionID, groupJID string, includeContacts bool) (*contracts.GetGroupInfoResponse, error) {
 	s.logger.InfoWithFields("Getting group info", map[string]interface{}{
-		"session_id": sessionID,
-		"group_jid":  groupJID,
+		"session_id":       sessionID,
+		"group_jid":        groupJID,
+		"include_contacts": includeContacts,
 	})
 
 	groupInfo, err := s.whatsappGateway.GetGroupInfo(ctx, sessionID, groupJID)
@@ -138,40 +201,97 @@ func (s *GroupService) GetGroupInfo(ctx context.Context, sessionID, groupJID str
 		return nil, fmt.Errorf("failed to get group info from WhatsApp: %w", err)
 	}
 
-	participants := make([]contracts.ParticipantInfo, len(groupInfo.Participants))
-	for i, p := range groupInfo.Participants {
-		participants[i] = contracts.ParticipantInfo{
-			JID:      p.JID,
-			Role:     string(p.Role),
-			JoinedAt: p.JoinedAt,
-			Status:   string(p.Status),
+	var known map[string]*contact.ContactInfo
+	if includeContacts {
+		known = s.lookupParticipants(ctx, sessionID, groupInfo.Participants)
+	}
+
+	dto := groupToDTO(groupInfo)
+	if includeContacts {
+		for i, p := range groupInfo.Participants {
+			dto.Participants[i].Contact = &contracts.ParticipantContact{}
+			if info := known[participantKey(p)]; info != nil {
+				dto.Participants[i].Contact = &contracts.ParticipantContact{
+					Name:         info.Name,
+					PushName:     info.PushName,
+					BusinessName: info.BusinessName,
+					IsBusiness:   info.IsBusiness,
+					IsContact:    info.IsContact,
+				}
+			}
 		}
 	}
 
 	response := &contracts.GetGroupInfoResponse{
-		GroupJID:     groupInfo.GroupJID,
-		Name:         groupInfo.Name,
-		Description:  groupInfo.Description,
-		Owner:        groupInfo.Owner,
-		Participants: participants,
-		Settings: contracts.GroupSettings{
-			Announce:         groupInfo.Settings.Announce,
-			Restrict:         groupInfo.Settings.Restrict,
-			JoinApprovalMode: groupInfo.Settings.JoinApprovalMode,
-			MemberAddMode:    groupInfo.Settings.MemberAddMode,
-			Locked:           groupInfo.Settings.Locked,
-		},
-		CreatedAt: groupInfo.CreatedAt,
-		UpdatedAt: groupInfo.UpdatedAt,
-		Success:   true,
-		Message:   "Group info retrieved successfully",
+		GroupResponse: dto,
+		Success:       true,
+		Message:       "Group info retrieved successfully",
 	}
 
 	s.logger.InfoWithFields("Group info retrieved successfully", map[string]interface{}{
 		"session_id":        sessionID,
 		"group_jid":         groupJID,
 		"group_name":        groupInfo.Name,
-		"participant_count": len(participants),
+		"participant_count": dto.ParticipantCount,
+	})
+
+	return response, nil
+}
+
+// GetInviteLink returns the group's current invite link. The session must be
+// an admin of the group.
+func (s *GroupService) GetInviteLink(ctx context.Context, sessionID, groupJID string) (*contracts.InviteLinkResponse, error) {
+	if err := s.groupCore.ValidateJID(groupJID); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	invite, err := s.whatsappGateway.GetGroupInviteLink(ctx, sessionID, groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invite link from WhatsApp: %w", err)
+	}
+
+	return &contracts.InviteLinkResponse{
+		GroupJID:   invite.GroupJID,
+		InviteLink: invite.Link,
+		Code:       invite.Code,
+		Success:    true,
+		Message:    "Invite link retrieved successfully",
+	}, nil
+}
+
+// GetInviteInfo previews the group behind an invite link so callers can vet
+// it before joining.
+func (s *GroupService) GetInviteInfo(ctx context.Context, sessionID, inviteLink string) (*contracts.GroupInviteInfoResponse, error) {
+	inviteLink = strings.TrimSpace(inviteLink)
+	if err := s.groupCore.ValidateInviteLink(inviteLink); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	groupInfo, err := s.whatsappGateway.GetGroupInfoFromInviteLink(ctx, sessionID, inviteLink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invite info from WhatsApp: %w", err)
+	}
+
+	response := &contracts.GroupInviteInfoResponse{
+		InviteLink:       inviteLink,
+		GroupJID:         groupInfo.GroupJID,
+		Name:             groupInfo.Name,
+		Description:      groupInfo.Description,
+		Owner:            groupInfo.Owner,
+		Size:             len(groupInfo.Participants),
+		CreatedAt:        groupInfo.CreatedAt,
+		ApprovalRequired: groupInfo.Settings.JoinApprovalMode == group.JoinApprovalModeAdminApproval,
+		Announce:         groupInfo.Settings.Announce,
+		Locked:           groupInfo.Settings.Locked,
+		Success:          true,
+		Message:          "Invite info retrieved successfully",
+	}
+
+	s.logger.InfoWithFields("Invite info retrieved", map[string]interface{}{
+		"session_id":        sessionID,
+		"group_jid":         groupInfo.GroupJID,
+		"size":              response.Size,
+		"approval_required": response.ApprovalRequired,
 	})
 
 	return response, nil
@@ -204,9 +324,13 @@ func (s *GroupService) UpdateGroupParticipants(ctx context.Context, sessionID st
 		return nil, fmt.Errorf("participant changes validation failed: %w", err)
 	}
 
+	var results []group.ParticipantAddResult
 	switch req.Action {
 	case "add":
-		err = s.whatsappGateway.AddParticipants(ctx, sessionID, req.GroupJID, req.Participants)
+		results, err = s.whatsappGateway.AddParticipantsWithResult(ctx, sessionID, req.GroupJID, req.Participants)
+		if err == nil && req.InviteOnFailure {
+			s.inviteByLink(ctx, sessionID, req.GroupJID, &group.InviteFallback{Message: req.InviteMessage}, results)
+		}
 	case "remove":
 		err = s.whatsappGateway.RemoveParticipants(ctx, sessionID, req.GroupJID, req.Participants)
 	case "promote":
@@ -228,6 +352,17 @@ func (s *GroupService) UpdateGroupParticipants(ctx context.Context, sessionID st
 		Success:      true,
 		Message:      fmt.Sprintf("Participants %s successfully", req.Action),
 	}
+	for _, result := range results {
+		response.Results = append(response.Results, contracts.ParticipantOutcome{
+			JID:              result.JID,
+			Status:           string(result.Status),
+			ErrorCode:        result.ErrorCode,
+			InviteCode:       result.InviteCode,
+			InviteExpiration: result.InviteExpiration,
+			InvitedViaLink:   result.InvitedViaLink,
+			InviteError:      result.InviteError,
+		})
+	}
 
 	s.logger.InfoWithFields("Group participants updated successfully", map[string]interface{}{
 		"session_id":   sessionID,
@@ -274,6 +409,118 @@ func (s *GroupService) SetGroupName(ctx context.Context, sessionID string, req *
 	return response, nil
 }
 
+// groupPhotoFetchTimeout bounds how long a photo URL may take to download.
+const groupPhotoFetchTimeout = 30 * time.Second
+
+func (s *GroupService) SetGroupPhoto(ctx context.Context, sessionID string, req *contracts.SetGroupPhotoRequest) (*contracts.SetGroupPhotoResponse, error) {
+	s.logger.InfoWithFields("Setting group photo", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  req.GroupJID,
+		"from_url":   req.URL != "",
+	})
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	raw, err := s.loadGroupPhoto(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var photo []byte
+	err = s.mediaPool.Run(ctx, sessionID, func() error {
+		var prepareErr error
+		photo, prepareErr = group.PreparePhoto(raw)
+		return prepareErr
+	})
+	if err != nil {
+		if errors.Is(err, group.ErrInvalidGroupPhoto) {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		return nil, err
+	}
+
+	pictureID, err := s.whatsappGateway.SetGroupPhoto(ctx, sessionID, req.GroupJID, photo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set group photo: %w", err)
+	}
+
+	s.logger.InfoWithFields("Group photo updated successfully", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  req.GroupJID,
+		"picture_id": pictureID,
+		"bytes":      len(photo),
+	})
+
+	return &contracts.SetGroupPhotoResponse{
+		GroupJID:  req.GroupJID,
+		PictureID: pictureID,
+		Success:   true,
+		Message:   "Group photo updated successfully",
+	}, nil
+}
+
+// loadGroupPhoto returns the raw image from whichever source the request
+// used. Exactly one of upload, base64 and URL must be given.
+func (s *GroupService) loadGroupPhoto(ctx context.Context, req *contracts.SetGroupPhotoRequest) ([]byte, error) {
+	sources := 0
+	for _, set := range []bool{len(req.Data) > 0, req.Image != "", req.URL != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("validation failed: provide exactly one of file, image or url")
+	}
+
+	switch {
+	case len(req.Data) > 0:
+		return req.Data, nil
+	case req.Image != "":
+		encoded := req.Image
+		if strings.HasPrefix(encoded, "data:") {
+			if i := strings.Index(encoded, ","); i >= 0 {
+				encoded = encoded[i+1:]
+			}
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: image is not valid base64")
+		}
+		return data, nil
+	default:
+		return s.fetchGroupPhoto(ctx, req.URL)
+	}
+}
+
+func (s *GroupService) fetchGroupPhoto(ctx context.Context, url string) ([]byte, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, groupPhotoFetchTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid url: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group photo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("validation failed: fetching url returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, group.MaxGroupPhotoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group photo: %w", err)
+	}
+
+	return data, nil
+}
+
 func (s *GroupService) convertGroupInfoToModel(groupInfo *group.GroupInfo, sessionID string) *group.Group {
 
 	return &group.Group{
@@ -287,3 +534,302 @@ func (s *GroupService) convertGroupInfoToModel(groupInfo *group.GroupInfo, sessi
 		UpdatedAt:    groupInfo.UpdatedAt,
 	}
 }
+
+// StartBulkAddParticipants validates the numbers and starts a background job
+// that adds them to the group in throttled batches. Progress and per-number
+// outcomes are available through GetBulkAddJob.
+func (s *GroupService) StartBulkAddParticipants(ctx context.Context, sessionID, groupJID string, req *contracts.BulkAddParticipantsRequest) (*contracts.BulkAddParticipantsResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	groupJID = s.groupCore.FormatGroupJID(groupJID)
+	if err := s.groupCore.ValidateJID(groupJID); err != nil {
+		return nil, fmt.Errorf("validation failed: invalid group JID: %w", err)
+	}
+
+	valid, invalid := s.groupCore.NormalizePhoneNumbers(req.PhoneNumbers)
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("validation failed: no valid phone numbers provided")
+	}
+
+	batchSize := group.DefaultBulkAddBatchSize
+	if req.BatchSize > 0 {
+		batchSize = req.BatchSize
+	}
+	batchDelay := group.DefaultBulkAddBatchDelay
+	if req.BatchDelaySeconds > 0 {
+		batchDelay = time.Duration(req.BatchDelaySeconds) * time.Second
+	}
+
+	job := &group.BulkImportJob{
+		ID:        uuid.New().String(),
+		SessionID: sessionID,
+		GroupJID:  groupJID,
+		Status:    group.BulkJobStatusRunning,
+		Total:     len(valid) + len(invalid),
+		Results:   make([]group.ParticipantAddResult, 0, len(valid)+len(invalid)),
+		CreatedAt: time.Now(),
+	}
+	for _, phone := range valid {
+		job.Results = append(job.Results, group.ParticipantAddResult{
+			PhoneNumber: phone,
+			Status:      group.BulkAddStatusPending,
+		})
+	}
+	for _, phone := range invalid {
+		job.Results = append(job.Results, group.ParticipantAddResult{
+			PhoneNumber: phone,
+			Status:      group.BulkAddStatusFailed,
+			Error:       "invalid phone number",
+		})
+	}
+	job.Processed = len(invalid)
+
+	s.bulkMu.Lock()
+	s.pruneBulkJobsLocked()
+	s.bulkJobs[job.ID] = job
+	response := s.bulkJobResponseLocked(job)
+	s.bulkMu.Unlock()
+
+	s.logger.InfoWithFields("Bulk participant import started", map[string]interface{}{
+		"session_id":  sessionID,
+		"group_jid":   groupJID,
+		"job_id":      job.ID,
+		"valid":       len(valid),
+		"invalid":     len(invalid),
+		"batch_size":  batchSize,
+		"batch_delay": batchDelay.String(),
+	})
+
+	var fallback *group.InviteFallback
+	if req.InviteOnFailure {
+		fallback = &group.InviteFallback{Message: req.InviteMessage}
+	}
+
+	// The job outlives the HTTP request, so it must not inherit its context.
+	go s.runBulkAdd(context.Background(), job, len(valid), batchSize, batchDelay, fallback)
+
+	return response, nil
+}
+
+func (s *GroupService) GetBulkAddJob(ctx context.Context, sessionID, groupJID, jobID string) (*contracts.BulkAddParticipantsResponse, error) {
+	groupJID = s.groupCore.FormatGroupJID(groupJID)
+
+	s.bulkMu.RLock()
+	defer s.bulkMu.RUnlock()
+
+	job, ok := s.bulkJobs[jobID]
+	if !ok || job.SessionID != sessionID || job.GroupJID != groupJID {
+		return nil, fmt.Errorf("bulk import job %s not found", jobID)
+	}
+
+	return s.bulkJobResponseLocked(job), nil
+}
+
+func (s *GroupService) runBulkAdd(ctx context.Context, job *group.BulkImportJob, count, batchSize int, batchDelay time.Duration, fallback *group.InviteFallback) {
+	s.bulkMu.RLock()
+	phones := make([]string, count)
+	for i := 0; i < count; i++ {
+		phones[i] = job.Results[i].PhoneNumber
+	}
+	s.bulkMu.RUnlock()
+
+	registered := make([]int, 0, count)
+	jids := make([]string, count)
+
+	for start := 0; start < count; start += group.BulkLookupBatchSize {
+		end := min(start+group.BulkLookupBatchSize, count)
+
+		checks, err := s.numbers.Check(ctx, job.SessionID, phones[start:end], false)
+
+		s.bulkMu.Lock()
+		for i := start; i < end; i++ {
+			switch {
+			case err != nil:
+				s.finishResultLocked(job, i, group.BulkAddStatusFailed, fmt.Sprintf("failed to check number: %v", err))
+			case checks[phones[i]] == nil || !checks[phones[i]].OnWhatsApp:
+				s.finishResultLocked(job, i, group.BulkAddStatusNotOnWhatsApp, "")
+			default:
+				jids[i] = checks[phones[i]].JID
+				job.Results[i].JID = jids[i]
+				registered = append(registered, i)
+			}
+		}
+		s.bulkMu.Unlock()
+	}
+
+	for start := 0; start < len(registered); start += batchSize {
+		if start > 0 {
+			time.Sleep(batchDelay)
+		}
+
+		batch := registered[start:min(start+batchSize, len(registered))]
+		participants := make([]string, len(batch))
+		byUser := make(map[string]int, len(batch))
+		for i, idx := range batch {
+			participants[i] = jids[idx]
+			byUser[jidUser(jids[idx])] = idx
+		}
+
+		results, err := s.whatsappGateway.AddParticipantsWithResult(ctx, job.SessionID, job.GroupJID, participants)
+		if err == nil && fallback != nil {
+			s.inviteByLink(ctx, job.SessionID, job.GroupJID, fallback, results)
+		}
+
+		s.bulkMu.Lock()
+		if err != nil {
+			for _, idx := range batch {
+				s.finishResultLocked(job, idx, group.BulkAddStatusFailed, err.Error())
+			}
+		} else {
+			for _, result := range results {
+				idx, ok := byUser[jidUser(result.JID)]
+				if !ok {
+					continue
+				}
+				result.PhoneNumber = phones[idx]
+				result.JID = jids[idx]
+				job.Results[idx] = result
+				job.Processed++
+				delete(byUser, jidUser(result.JID))
+			}
+			for _, idx := range byUser {
+				s.finishResultLocked(job, idx, group.BulkAddStatusFailed, "no result returned by WhatsApp")
+			}
+		}
+		s.bulkMu.Unlock()
+	}
+
+	s.bulkMu.Lock()
+	completedAt := time.Now()
+	job.Status = group.BulkJobStatusCompleted
+	job.CompletedAt = &completedAt
+	summary := job.Summary()
+	s.bulkMu.Unlock()
+
+	s.logger.InfoWithFields("Bulk participant import finished", map[string]interface{}{
+		"session_id":      job.SessionID,
+		"group_jid":       job.GroupJID,
+		"job_id":          job.ID,
+		"added":           summary[group.BulkAddStatusAdded],
+		"needs_invite":    summary[group.BulkAddStatusNeedsInvite],
+		"not_on_whatsapp": summary[group.BulkAddStatusNotOnWhatsApp],
+		"failed":          summary[group.BulkAddStatusFailed],
+	})
+}
+
+func (s *GroupService) finishResultLocked(job *group.BulkImportJob, idx int, status group.BulkAddStatus, errMsg string) {
+	job.Results[idx].Status = status
+	job.Results[idx].Error = errMsg
+	job.Processed++
+}
+
+func (s *GroupService) pruneBulkJobsLocked() {
+	cutoff := time.Now().Add(-group.BulkImportJobRetention)
+	for id, job := range s.bulkJobs {
+		if job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			delete(s.bulkJobs, id)
+		}
+	}
+}
+
+func (s *GroupService) bulkJobResponseLocked(job *group.BulkImportJob) *contracts.BulkAddParticipantsResponse {
+	summary := make(map[string]int)
+	for status, count := range job.Summary() {
+		summary[string(status)] = count
+	}
+
+	results := make([]contracts.BulkParticipantOutcome, len(job.Results))
+	for i, result := range job.Results {
+		results[i] = contracts.BulkParticipantOutcome{
+			PhoneNumber:      result.PhoneNumber,
+			JID:              result.JID,
+			Status:           string(result.Status),
+			ErrorCode:        result.ErrorCode,
+			Error:            result.Error,
+			InviteCode:       result.InviteCode,
+			InviteExpiration: result.InviteExpiration,
+			InvitedViaLink:   result.InvitedViaLink,
+			InviteError:      result.InviteError,
+		}
+	}
+
+	message := "Bulk participant import in progress"
+	if job.Status == group.BulkJobStatusCompleted {
+		message = "Bulk participant import completed"
+	}
+
+	return &contracts.BulkAddParticipantsResponse{
+		JobID:       job.ID,
+		GroupJID:    job.GroupJID,
+		Status:      string(job.Status),
+		Total:       job.Total,
+		Processed:   job.Processed,
+		Summary:     summary,
+		Results:     results,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		Success:     true,
+		Message:     message,
+	}
+}
+
+func jidUser(jid string) string {
+	user, _, _ := strings.Cut(jid, "@")
+	user, _, _ = strings.Cut(user, ":")
+	return user
+}
+
+func groupSummaryToDTO(info *group.GroupInfo) contracts.GroupSummary {
+	return contracts.GroupSummary{
+		GroupJID:     info.GroupJID,
+		Name:         info.Name,
+		Description:  info.Description,
+		Owner:        info.Owner,
+		Participants: len(info.Participants),
+		CreatedAt:    info.CreatedAt,
+	}
+}
+
+func groupToDTO(info *group.GroupInfo) contracts.GroupResponse {
+	participants := make([]contracts.ParticipantResponse, len(info.Participants))
+	for i, p := range info.Participants {
+		participants[i] = participantToDTO(p)
+	}
+
+	return contracts.GroupResponse{
+		GroupJID:         info.GroupJID,
+		Name:             info.Name,
+		Description:      info.Description,
+		Owner:            info.Owner,
+		ParticipantCount: len(participants),
+		Participants:     participants,
+		Settings:         groupSettingsToDTO(info.Settings),
+		CreatedAt:        info.CreatedAt,
+		UpdatedAt:        info.UpdatedAt,
+	}
+}
+
+func participantToDTO(p group.Participant) contracts.ParticipantResponse {
+	return contracts.ParticipantResponse{
+		JID:          p.JID,
+		PhoneJID:     p.PhoneJID,
+		Role:         string(p.Role),
+		IsAdmin:      p.Role == group.ParticipantRoleAdmin || p.Role == group.ParticipantRoleOwner,
+		IsSuperAdmin: p.Role == group.ParticipantRoleOwner,
+		JoinedAt:     p.JoinedAt,
+		Status:       string(p.Status),
+	}
+}
+
+func groupSettingsToDTO(settings group.GroupSettings) contracts.GroupSettings {
+	return contracts.GroupSettings{
+		Announce:          settings.Announce,
+		Restrict:          settings.Restrict,
+		JoinApprovalMode:  settings.JoinApprovalMode,
+		MemberAddMode:     settings.MemberAddMode,
+		Locked:            settings.Locked,
+		DisappearingTimer: settings.DisappearingTimer,
+	}
+}
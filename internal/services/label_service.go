@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/label"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// LabelService manages WhatsApp Business labels. Every change is written to
+// the app state first and only stored locally once WhatsApp accepted it.
+type LabelService struct {
+	core      *label.Service
+	gateway   label.Gateway
+	resolver  session.SessionResolver
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewLabelService(
+	core *label.Service,
+	gateway label.Gateway,
+	resolver session.SessionResolver,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *LabelService {
+	return &LabelService{
+		core:      core,
+		gateway:   gateway,
+		resolver:  resolver,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (s *LabelService) ListLabels(ctx context.Context, sessionID string) (*contracts.ListLabelsResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.core.List(ctx, resolved.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListLabelsResponse{
+		Labels: make([]contracts.LabelResponse, len(labels)),
+		Total:  len(labels),
+	}
+	for i, l := range labels {
+		response.Labels[i] = *labelToDTO(l)
+	}
+
+	return response, nil
+}
+
+func (s *LabelService) CreateLabel(ctx context.Context, sessionID string, req *contracts.CreateLabelRequest) (*contracts.LabelResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolveForWrite(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := s.core.Prepare(ctx, resolved.ID, req.Name, req.Color)
+	if err != nil {
+		return nil, labelError(err)
+	}
+
+	if err := s.gateway.EditLabel(ctx, resolved.Name, l); err != nil {
+		return nil, err
+	}
+	if err := s.core.Save(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return labelToDTO(l), nil
+}
+
+func (s *LabelService) UpdateLabel(ctx context.Context, sessionID, labelID string, req *contracts.UpdateLabelRequest) (*contracts.LabelResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolveForWrite(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := s.core.Edit(ctx, resolved.ID, labelID, req.Name, req.Color)
+	if err != nil {
+		return nil, labelError(err)
+	}
+
+	if err := s.gateway.EditLabel(ctx, resolved.Name, l); err != nil {
+		return nil, err
+	}
+	if err := s.core.Save(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return labelToDTO(l), nil
+}
+
+func (s *LabelService) DeleteLabel(ctx context.Context, sessionID, labelID string) error {
+	resolved, err := s.resolveForWrite(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	l, err := s.core.Get(ctx, resolved.ID, labelID)
+	if err != nil {
+		return err
+	}
+
+	l.Deleted = true
+	if err := s.gateway.EditLabel(ctx, resolved.Name, l); err != nil {
+		return err
+	}
+
+	return s.core.Save(ctx, l)
+}
+
+// SetAssociation applies or removes a label on a chat, or on one message of
+// the chat when message_id is given.
+func (s *LabelService) SetAssociation(ctx context.Context, sessionID, labelID string, req *contracts.LabelAssociationRequest, labeled bool) (*contracts.LabelAssociationResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolveForWrite(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.core.Get(ctx, resolved.ID, labelID); err != nil {
+		return nil, err
+	}
+
+	association := &label.Association{
+		SessionID: resolved.ID,
+		LabelID:   labelID,
+		ChatJID:   req.ChatJID,
+		MessageID: req.MessageID,
+	}
+
+	if err := s.gateway.SetLabelAssociation(ctx, resolved.Name, association, labeled); err != nil {
+		return nil, err
+	}
+	if err := s.core.SetAssociation(ctx, association, labeled); err != nil {
+		return nil, fmt.Errorf("failed to save label association: %w", err)
+	}
+
+	return &contracts.LabelAssociationResponse{
+		LabelID:   labelID,
+		ChatJID:   association.ChatJID,
+		MessageID: association.MessageID,
+		Labeled:   labeled,
+	}, nil
+}
+
+func (s *LabelService) ListAssociations(ctx context.Context, sessionID, labelID string) (*contracts.ListLabelAssociationsResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	associations, err := s.core.ListAssociations(ctx, resolved.ID, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListLabelAssociationsResponse{
+		LabelID:      labelID,
+		Associations: make([]contracts.LabelAssociation, len(associations)),
+		Total:        len(associations),
+	}
+	for i, association := range associations {
+		response.Associations[i] = contracts.LabelAssociation{
+			LabelID:   association.LabelID,
+			ChatJID:   association.ChatJID,
+			MessageID: association.MessageID,
+			CreatedAt: association.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *LabelService) resolveForWrite(ctx context.Context, sessionID string) (*session.ResolveResult, error) {
+	if s.gateway == nil {
+		return nil, fmt.Errorf("labels are not supported by this gateway")
+	}
+
+	return s.resolver.Resolve(ctx, sessionID)
+}
+
+func labelError(err error) error {
+	if errors.Is(err, label.ErrInvalidLabel) {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return err
+}
+
+func labelToDTO(l *label.Label) *contracts.LabelResponse {
+	return &contracts.LabelResponse{
+		LabelID:   l.LabelID,
+		Name:      l.Name,
+		Color:     l.Color,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
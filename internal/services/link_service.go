@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/linktrack"
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+// LinkService follows tracked links and reports the clicks on them. The
+// links themselves are made when messages asking for tracking are sent.
+type LinkService struct {
+	core     *linktrack.Service
+	resolver session.SessionResolver
+	logger   *logger.Logger
+}
+
+func NewLinkService(core *linktrack.Service, resolver session.SessionResolver, logger *logger.Logger) *LinkService {
+	return &LinkService{
+		core:     core,
+		resolver: resolver,
+		logger:   logger,
+	}
+}
+
+// FollowLink records a click on the tracked link code and returns the URL
+// it stands for.
+func (s *LinkService) FollowLink(ctx context.Context, code, ip, userAgent string) (string, error) {
+	link, err := s.core.Follow(ctx, code, ip, userAgent)
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.DebugWithFields("Tracked link followed", map[string]interface{}{
+		"code":       code,
+		"session_id": link.SessionID.String(),
+		"message_id": link.MessageID,
+	})
+
+	return link.URL, nil
+}
+
+// GetLinkStats counts the clicks on the links of a message, a campaign or
+// a campaign's message.
+func (s *LinkService) GetLinkStats(ctx context.Context, sessionID, messageID, campaign string) (*contracts.LinkStatsResponse, error) {
+	filter, err := s.linkFilter(ctx, sessionID, messageID, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.core.Stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.LinkStatsResponse{
+		MessageID:  messageID,
+		Campaign:   campaign,
+		Links:      stats.Links,
+		Clicks:     stats.Clicks,
+		Recipients: stats.Recipients,
+		Clicked:    stats.Clicked,
+		PerLink:    make([]contracts.TrackedLinkStats, len(stats.PerLink)),
+	}
+	for i, entry := range stats.PerLink {
+		response.PerLink[i] = contracts.TrackedLinkStats{
+			Code:         entry.Link.Code,
+			URL:          entry.Link.URL,
+			MessageID:    entry.Link.MessageID,
+			ChatJID:      entry.Link.ChatJID,
+			Campaign:     entry.Link.Campaign,
+			Clicks:       entry.Clicks,
+			FirstClickAt: entry.FirstClickAt,
+			LastClickAt:  entry.LastClickAt,
+			CreatedAt:    entry.Link.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// ListLinkClicks returns the latest clicks on the links of a message or
+// campaign, newest first.
+func (s *LinkService) ListLinkClicks(ctx context.Context, sessionID, messageID, campaign string, limit int) (*contracts.ListLinkClicksResponse, error) {
+	filter, err := s.linkFilter(ctx, sessionID, messageID, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	clicks, err := s.core.Clicks(ctx, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListLinkClicksResponse{
+		Clicks: make([]contracts.LinkClick, len(clicks)),
+		Total:  len(clicks),
+	}
+	for i, click := range clicks {
+		response.Clicks[i] = contracts.LinkClick{
+			Code:      click.Code,
+			URL:       click.URL,
+			MessageID: click.MessageID,
+			ChatJID:   click.ChatJID,
+			Campaign:  click.Campaign,
+			ClickedAt: click.ClickedAt,
+			IP:        click.IP,
+			UserAgent: click.UserAgent,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *LinkService) linkFilter(ctx context.Context, sessionID, messageID, campaign string) (*linktrack.Filter, error) {
+	if messageID == "" && campaign == "" {
+		return nil, fmt.Errorf("validation failed: message_id or campaign is required")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &linktrack.Filter{
+		SessionID: resolved.ID,
+		MessageID: messageID,
+		Campaign:  campaign,
+	}, nil
+}
@@ -1 +1,135 @@
 package services
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+	"zpwoot/platform/logger"
+)
+
+type MediaService struct {
+	messagingCore *messaging.Service
+	resolver      session.SessionResolver
+	fetcher       messaging.MediaFetcher
+	host          messaging.MediaHost
+	logger        *logger.Logger
+}
+
+func NewMediaService(
+	messagingCore *messaging.Service,
+	resolver session.SessionResolver,
+	fetcher messaging.MediaFetcher,
+	host messaging.MediaHost,
+	logger *logger.Logger,
+) *MediaService {
+	return &MediaService{
+		messagingCore: messagingCore,
+		resolver:      resolver,
+		fetcher:       fetcher,
+		host:          host,
+		logger:        logger,
+	}
+}
+
+type MediaFile struct {
+	Data     []byte
+	MimeType string
+	FileName string
+}
+
+// GetMessageMedia returns the media of a stored message. Media that was not
+// downloaded when it arrived is fetched from WhatsApp now and kept locally.
+func (s *MediaService) GetMessageMedia(ctx context.Context, sessionID, messageID string) (*MediaFile, *messaging.Message, error) {
+	if messageID == "" {
+		return nil, nil, fmt.Errorf("validation failed: message ID is required")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message, err := s.messagingCore.GetMessageByZpID(ctx, resolved.ID, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if message.Media == nil {
+		return nil, nil, messaging.ErrMessageHasNoMedia
+	}
+
+	wasStored := message.Media.IsStored()
+
+	data, path, err := s.fetcher.FetchMedia(ctx, resolved.Name, message)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to fetch media", map[string]interface{}{
+			"session_id": resolved.ID.String(),
+			"message_id": messageID,
+			"error":      err.Error(),
+		})
+		return nil, nil, err
+	}
+
+	if !wasStored || path != message.Media.LocalPath {
+		if err := s.messagingCore.AttachMediaFile(ctx, resolved.ID, messageID, path); err != nil {
+			s.logger.WarnWithFields("Failed to record downloaded media", map[string]interface{}{
+				"session_id": resolved.ID.String(),
+				"message_id": messageID,
+				"error":      err.Error(),
+			})
+		}
+		message.Media.LocalPath = path
+	}
+
+	return &MediaFile{
+		Data:     data,
+		MimeType: message.Media.MimeType,
+		FileName: message.Media.FileName,
+	}, message, nil
+}
+
+// OpenHostedMedia returns the file behind a media link served by this
+// instance.
+func (s *MediaService) OpenHostedMedia(token string) (*MediaFile, error) {
+	if s.host == nil {
+		return nil, messaging.ErrHostedMediaNotFound
+	}
+
+	path, err := s.host.Open(token)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// Retention or a cache clear removed the file before the link
+		// expired.
+		return nil, messaging.ErrHostedMediaNotFound
+	}
+
+	return &MediaFile{
+		Data:     data,
+		MimeType: mime.TypeByExtension(filepath.Ext(path)),
+	}, nil
+}
+
+func (s *MediaService) DownloadMedia(ctx context.Context, sessionID string, req *contracts.DownloadMediaRequest) (*contracts.DownloadMediaResponse, error) {
+	file, message, err := s.GetMessageMedia(ctx, sessionID, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.DownloadMediaResponse{
+		MessageID: message.ZpMessageID,
+		Type:      message.ZpType,
+		MimeType:  file.MimeType,
+		FileName:  file.FileName,
+		FileSize:  len(file.Data),
+	}, nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/shared/pagination"
+)
+
+// MarkAsRead sends read receipts for the messages and clears the chat's
+// unread count. WhatsApp takes one author per receipt in groups, so group
+// messages are grouped by their stored author, falling back to sender_jid
+// for messages that are not stored.
+func (s *MessageService) MarkAsRead(ctx context.Context, sessionID string, req *contracts.MarkAsReadRequest) (*contracts.MarkAsReadResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if s.reads == nil {
+		return nil, fmt.Errorf("marking messages as read is not supported by this gateway")
+	}
+
+	id, name, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	bySender := map[string][]string{}
+	if strings.HasSuffix(req.ChatJID, "@g.us") {
+		stored, err := s.messageRepo.ListByZpMessageIDs(ctx, id, req.MessageIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load messages: %w", err)
+		}
+
+		authors := make(map[string]string, len(stored))
+		for _, message := range stored {
+			if !message.ZpFromMe {
+				authors[message.ZpMessageID] = message.ZpSender
+			}
+		}
+
+		for _, messageID := range req.MessageIDs {
+			sender, ok := authors[messageID]
+			if !ok {
+				sender = req.SenderJID
+			}
+			if sender == "" {
+				return nil, fmt.Errorf("validation failed: sender_jid is required for group messages that are not stored")
+			}
+			bySender[sender] = append(bySender[sender], messageID)
+		}
+	} else {
+		bySender[""] = req.MessageIDs
+	}
+
+	now := time.Now()
+	for sender, ids := range bySender {
+		if err := s.reads.MarkRead(ctx, name, req.ChatJID, sender, ids, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.messagingCore.MarkChatRead(ctx, id, req.ChatJID, now); err != nil {
+		return nil, err
+	}
+
+	return &contracts.MarkAsReadResponse{
+		ChatJID:      req.ChatJID,
+		MessagesRead: len(req.MessageIDs),
+		MarkedCount:  len(req.MessageIDs),
+		Status:       "read",
+		LastReadAt:   now,
+	}, nil
+}
+
+// ListChats lists the session's chats for an inbox view, by latest message
+// or with the most unread first.
+func (s *MessageService) ListChats(ctx context.Context, sessionID, sort string, limit, offset int) (*contracts.ListChatsResponse, error) {
+	chatSort, err := messaging.ParseChatSort(sort)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = pagination.ClampLimit(limit)
+	chats, total, err := s.messagingCore.ListChats(ctx, id, chatSort, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListChatsResponse{
+		Chats:  make([]contracts.ChatSummary, len(chats)),
+		Sort:   string(chatSort),
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	for i, chat := range chats {
+		response.Chats[i] = contracts.ChatSummary{
+			ChatJID:       chat.ChatJID,
+			IsGroup:       strings.HasSuffix(chat.ChatJID, "@g.us"),
+			UnreadCount:   chat.UnreadCount,
+			LastMessageID: chat.LastMessageID,
+			LastMessageAt: chat.LastMessageAt,
+			LastFromMe:    chat.LastFromMe,
+			LastReadAt:    chat.LastReadAt,
+		}
+	}
+
+	return response, nil
+}
+
+// ListLiveLocations returns where everyone sharing a live location in a chat
+// was last seen, with up to history earlier positions each.
+func (s *MessageService) ListLiveLocations(ctx context.Context, sessionID, chatJID string, history int) (*contracts.ListLiveLocationsResponse, error) {
+	chat, err := messaging.ParseRecipient(chatJID, s.defaultCountry)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := s.messagingCore.ListLiveLocations(ctx, id, chat.JID, history)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListLiveLocationsResponse{
+		ChatJID: chat.JID,
+		Sharers: make([]contracts.LiveLocationSharer, len(tracks)),
+	}
+	for i, track := range tracks {
+		sharer := contracts.LiveLocationSharer{
+			SharerJID: track.Latest.SharerJID,
+			FromMe:    track.Latest.FromMe,
+			Latest:    liveLocationPointToDTO(track.Latest),
+			History:   make([]contracts.LiveLocationPoint, len(track.History)),
+		}
+		for j, point := range track.History {
+			sharer.History[j] = liveLocationPointToDTO(point)
+		}
+		response.Sharers[i] = sharer
+	}
+
+	return response, nil
+}
+
+func liveLocationPointToDTO(point *messaging.LiveLocationPoint) contracts.LiveLocationPoint {
+	return contracts.LiveLocationPoint{
+		MessageID:      point.ZpMessageID,
+		Latitude:       point.Latitude,
+		Longitude:      point.Longitude,
+		AccuracyMeters: point.AccuracyMeters,
+		SpeedMps:       point.SpeedMps,
+		Heading:        point.Heading,
+		Caption:        point.Caption,
+		Sequence:       point.Sequence,
+		ReportedAt:     point.ReportedAt,
+	}
+}
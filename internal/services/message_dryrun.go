@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+)
+
+const dryRunStatus = "dry_run"
+
+type dryRunKey struct{}
+
+// MediaInspector loads outbound media the way a send would and checks it
+// against the session's media policy, without uploading it.
+type MediaInspector interface {
+	InspectMedia(ctx context.Context, sessionName, mediaURL, mediaType string) (mimeType string, size int64, err error)
+}
+
+// SetMediaInspector lets dry runs of media sends load and check the media,
+// reporting its type and size.
+func (s *MessageService) SetMediaInspector(inspector MediaInspector) {
+	s.media = inspector
+}
+
+// WithDryRun makes the send methods validate and normalize the message and
+// return what they would send, without sending it.
+func WithDryRun(ctx context.Context, dryRun bool) context.Context {
+	if !dryRun {
+		return ctx
+	}
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// isDryRun reports whether a send is only simulated, because the request
+// asked for it or the session is in sandbox mode.
+func isDryRun(ctx context.Context, sess *session.Session) bool {
+	if dryRun, _ := ctx.Value(dryRunKey{}).(bool); dryRun {
+		return true
+	}
+	return sess != nil && sess.Settings.Sandbox.Enabled
+}
+
+// dryRunResponse logs a simulated send and answers with its payload in
+// place of the message WhatsApp would have acknowledged.
+func (s *MessageService) dryRunResponse(ctx context.Context, sess *session.Session, kind, to string, payload map[string]interface{}, mediaBytes int64) *contracts.SendMessageResponse {
+	if quote := session.QuoteFrom(ctx); quote != nil {
+		payload["reply_to"] = quote.MessageID
+	}
+
+	size := int(mediaBytes)
+	if encoded, err := json.Marshal(payload); err == nil {
+		size += len(encoded)
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Dry run send, not sent to WhatsApp", map[string]interface{}{
+		"session_name":    sess.Name,
+		"to":              to,
+		"kind":            kind,
+		"sandbox":         sess.Settings.Sandbox.Enabled,
+		"estimated_bytes": size,
+	})
+
+	return &contracts.SendMessageResponse{
+		To:        to,
+		Status:    dryRunStatus,
+		Timestamp: time.Now(),
+		DryRun: &contracts.DryRun{
+			Kind:           kind,
+			Sandbox:        sess.Settings.Sandbox.Enabled,
+			Payload:        payload,
+			EstimatedBytes: size,
+		},
+	}
+}
+
+// dryRunMedia loads and checks the media when an inspector is set, so a dry
+// run fails where the send would. Inline media is not echoed back.
+func (s *MessageService) dryRunMedia(ctx context.Context, sess *session.Session, to, mediaURL, caption, mediaType string) (*contracts.SendMessageResponse, error) {
+	payload := map[string]interface{}{
+		"media_type": mediaType,
+		"caption":    caption,
+	}
+	if strings.HasPrefix(mediaURL, "http://") || strings.HasPrefix(mediaURL, "https://") {
+		payload["media_url"] = mediaURL
+	}
+
+	var size int64
+	if s.media != nil {
+		mimeType, mediaSize, err := s.media.InspectMedia(ctx, sess.Name, mediaURL, mediaType)
+		if err != nil {
+			return nil, err
+		}
+		payload["mimetype"] = mimeType
+		payload["file_length"] = mediaSize
+		size = mediaSize
+	}
+
+	return s.dryRunResponse(ctx, sess, session.SendMedia, to, payload, size), nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"zpwoot/internal/core/session"
+)
+
+type footerKey struct{}
+
+// WithFooter carries a per-request footer override to the send methods. A nil
+// footer keeps the session default; an empty one sends the message without it.
+func WithFooter(ctx context.Context, footer *string) context.Context {
+	if footer == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, footerKey{}, *footer)
+}
+
+// appendFooter adds the request's footer, or else the session's, to an
+// outbound text or caption. Empty text stays empty.
+func appendFooter(ctx context.Context, sess *session.Session, text string) string {
+	if text == "" {
+		return text
+	}
+
+	footer, ok := ctx.Value(footerKey{}).(string)
+	if !ok && sess != nil && sess.Settings.Footer.Enabled {
+		footer = sess.Settings.Footer.Text
+	}
+
+	footer = strings.TrimSpace(footer)
+	if footer == "" || strings.HasSuffix(text, footer) {
+		return text
+	}
+
+	return text + "\n\n" + footer
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+)
+
+type textFormatKey struct{}
+
+// WithTextFormat carries per-request text preprocessing to the send methods,
+// replacing the session's settings for that message. Nil keeps the session's.
+func WithTextFormat(ctx context.Context, format *contracts.TextFormatSettings) context.Context {
+	if format == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, textFormatKey{}, session.TextFormatSettings{
+		Markdown:  format.Markdown,
+		Emoji:     format.Emoji,
+		Normalize: format.Normalize,
+	})
+}
+
+// formatText runs the request's text preprocessing, or else the session's,
+// on an outbound text or caption. Code spans are left as written apart from
+// their delimiters.
+func formatText(ctx context.Context, sess *session.Session, text string) string {
+	format, ok := ctx.Value(textFormatKey{}).(session.TextFormatSettings)
+	if !ok && sess != nil {
+		format = sess.Settings.TextFormat
+	}
+	if text == "" || !format.Enabled() {
+		return text
+	}
+
+	if format.Markdown || format.Emoji {
+		var out strings.Builder
+		last := 0
+		for _, loc := range codeSpanRe.FindAllStringIndex(text, -1) {
+			out.WriteString(formatProse(text[last:loc[0]], format))
+			code := text[loc[0]:loc[1]]
+			if format.Markdown {
+				code = markdownCode(code)
+			}
+			out.WriteString(code)
+			last = loc[1]
+		}
+		out.WriteString(formatProse(text[last:], format))
+		text = out.String()
+	}
+
+	if format.Normalize {
+		text = norm.NFC.String(text)
+	}
+
+	return text
+}
+
+var (
+	codeSpanRe  = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+	fenceLangRe = regexp.MustCompile("^```[A-Za-z0-9_+-]*\n")
+
+	headingRe = regexp.MustCompile(`(?m)^#{1,6}[ \t]+(.+?)[ \t#]*$`)
+	bulletRe  = regexp.MustCompile(`(?m)^([ \t]*)[*+][ \t]+`)
+	linkRe    = regexp.MustCompile(`\[([^\]\n]+)\]\((\S+?)\)`)
+	boldRe    = regexp.MustCompile(`\*\*(\S(?:[^\n]*?\S)?)\*\*|__(\S(?:[^\n]*?\S)?)__`)
+	strikeRe  = regexp.MustCompile(`~~(\S(?:[^\n]*?\S)?)~~`)
+	italicRe  = regexp.MustCompile(`\*(\S(?:[^*\n]*?\S)?)\*`)
+
+	shortcodeRe = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+	escapeRe    = regexp.MustCompile(`\\([\\*_~#>\[\]()+-])`)
+)
+
+// boldMark stands in for WhatsApp's bold asterisk while single-asterisk
+// italics are rewritten, so the two are not confused.
+const boldMark = "\x00"
+
+// escapeBase shifts backslash-escaped characters into the private use area
+// while emphasis is rewritten, so \*literal\* asterisks, which Chatwoot's
+// editor writes for what agents type, are not read as formatting.
+const escapeBase = 0xE000
+
+func formatProse(text string, format session.TextFormatSettings) string {
+	if format.Markdown {
+		text = markdownToWhatsApp(text)
+	}
+	if format.Emoji {
+		text = expandShortcodes(text)
+	}
+	return text
+}
+
+// markdownToWhatsApp rewrites Markdown emphasis into WhatsApp's: **bold**
+// and headings become *bold*, *italic* becomes _italic_, ~~strike~~ becomes
+// ~strike~. Links keep their URL next to the text and backslash escapes
+// are dropped.
+func markdownToWhatsApp(text string) string {
+	text = escapeRe.ReplaceAllStringFunc(text, func(match string) string {
+		return string(rune(escapeBase) + rune(match[1]))
+	})
+	text = headingRe.ReplaceAllString(text, boldMark+"$1"+boldMark)
+	text = bulletRe.ReplaceAllString(text, "$1- ")
+	text = linkRe.ReplaceAllStringFunc(text, func(match string) string {
+		parts := linkRe.FindStringSubmatch(match)
+		if parts[1] == parts[2] {
+			return parts[2]
+		}
+		return parts[1] + " (" + parts[2] + ")"
+	})
+	text = boldRe.ReplaceAllString(text, boldMark+"$1$2"+boldMark)
+	text = strikeRe.ReplaceAllString(text, "~$1~")
+	text = italicRe.ReplaceAllString(text, "_${1}_")
+	text = strings.ReplaceAll(text, boldMark, "*")
+
+	return strings.Map(func(r rune) rune {
+		if r >= escapeBase && r < escapeBase+0x80 {
+			return r - escapeBase
+		}
+		return r
+	}, text)
+}
+
+// markdownCode turns inline code into WhatsApp monospace and drops the
+// language tag of fenced blocks, which WhatsApp would print.
+func markdownCode(code string) string {
+	if strings.HasPrefix(code, "```") {
+		return fenceLangRe.ReplaceAllString(code, "```")
+	}
+	return "```" + strings.Trim(code, "`") + "```"
+}
+
+// expandShortcodes replaces :name: shortcodes with their emoji. Unknown
+// names are left alone.
+func expandShortcodes(text string) string {
+	return shortcodeRe.ReplaceAllStringFunc(text, func(match string) string {
+		if emoji, ok := emojiShortcodes[match[1:len(match)-1]]; ok {
+			return emoji
+		}
+		return match
+	})
+}
+
+// emojiShortcodes covers the shortcodes people actually type, with the
+// names GitHub and Slack use.
+var emojiShortcodes = map[string]string{
+	"+1":                    "👍",
+	"-1":                    "👎",
+	"100":                   "💯",
+	"airplane":              "✈️",
+	"alarm_clock":           "⏰",
+	"angry":                 "😠",
+	"apple":                 "🍎",
+	"arrow_down":            "⬇️",
+	"arrow_left":            "⬅️",
+	"arrow_right":           "➡️",
+	"arrow_up":              "⬆️",
+	"balloon":               "🎈",
+	"bell":                  "🔔",
+	"blush":                 "😊",
+	"bomb":                  "💣",
+	"books":                 "📚",
+	"boom":                  "💥",
+	"bow":                   "🙇",
+	"broken_heart":          "💔",
+	"bulb":                  "💡",
+	"cake":                  "🍰",
+	"calendar":              "📆",
+	"camera":                "📷",
+	"car":                   "🚗",
+	"cat":                   "🐱",
+	"check":                 "✔️",
+	"clap":                  "👏",
+	"clipboard":             "📋",
+	"clock":                 "🕐",
+	"coffee":                "☕",
+	"confused":              "😕",
+	"cool":                  "🆒",
+	"credit_card":           "💳",
+	"cry":                   "😢",
+	"dog":                   "🐶",
+	"dollar":                "💵",
+	"email":                 "📧",
+	"exclamation":           "❗",
+	"eyes":                  "👀",
+	"facepalm":              "🤦",
+	"fire":                  "🔥",
+	"flushed":               "😳",
+	"gift":                  "🎁",
+	"globe_with_meridians":  "🌐",
+	"grin":                  "😁",
+	"grinning":              "😀",
+	"heart":                 "❤️",
+	"heart_eyes":            "😍",
+	"heavy_check_mark":      "✔️",
+	"hourglass":             "⌛",
+	"house":                 "🏠",
+	"hugs":                  "🤗",
+	"information_source":    "ℹ️",
+	"joy":                   "😂",
+	"key":                   "🔑",
+	"kiss":                  "💋",
+	"kissing_heart":         "😘",
+	"laughing":              "😆",
+	"link":                  "🔗",
+	"lock":                  "🔒",
+	"mag":                   "🔍",
+	"memo":                  "📝",
+	"money_with_wings":      "💸",
+	"moneybag":              "💰",
+	"muscle":                "💪",
+	"no_entry":              "⛔",
+	"ok":                    "🆗",
+	"ok_hand":               "👌",
+	"package":               "📦",
+	"paperclip":             "📎",
+	"partying_face":         "🥳",
+	"pencil":                "📝",
+	"phone":                 "☎️",
+	"point_down":            "👇",
+	"point_left":            "👈",
+	"point_right":           "👉",
+	"point_up":              "☝️",
+	"pray":                  "🙏",
+	"pushpin":               "📌",
+	"question":              "❓",
+	"raised_hands":          "🙌",
+	"receipt":               "🧾",
+	"red_circle":            "🔴",
+	"relaxed":               "☺️",
+	"relieved":              "😌",
+	"rocket":                "🚀",
+	"rofl":                  "🤣",
+	"rose":                  "🌹",
+	"sad":                   "😞",
+	"scream":                "😱",
+	"see_no_evil":           "🙈",
+	"shopping_cart":         "🛒",
+	"shrug":                 "🤷",
+	"sleeping":              "😴",
+	"slightly_smiling_face": "🙂",
+	"smile":                 "😄",
+	"smiley":                "😃",
+	"smirk":                 "😏",
+	"sob":                   "😭",
+	"sparkles":              "✨",
+	"star":                  "⭐",
+	"star_struck":           "🤩",
+	"stopwatch":             "⏱️",
+	"sun":                   "☀️",
+	"sunglasses":            "😎",
+	"sweat_smile":           "😅",
+	"tada":                  "🎉",
+	"thinking":              "🤔",
+	"thumbsdown":            "👎",
+	"thumbsup":              "👍",
+	"truck":                 "🚚",
+	"two_hearts":            "💕",
+	"unamused":              "😒",
+	"warning":               "⚠️",
+	"wave":                  "👋",
+	"white_check_mark":      "✅",
+	"wink":                  "😉",
+	"worried":               "😟",
+	"x":                     "❌",
+	"yum":                   "😋",
+	"zap":                   "⚡",
+	"zzz":                   "💤",
+}
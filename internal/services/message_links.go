@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/core/linktrack"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+)
+
+type linkTrackingKey struct{}
+
+// WithLinkTracking asks the send methods to replace the links of a text or
+// caption with tracked redirects, grouped under campaign when one is given.
+func WithLinkTracking(ctx context.Context, enabled bool, campaign string) context.Context {
+	if !enabled {
+		return ctx
+	}
+	return context.WithValue(ctx, linkTrackingKey{}, campaign)
+}
+
+// SetLinkTracking lets sends track their links. Links point at the tracking
+// domain of the session's tenant, or else at baseURL.
+func (s *MessageService) SetLinkTracking(links *linktrack.Service, tenants *tenant.Service, baseURL string) {
+	s.links = links
+	s.tenants = tenants
+	s.trackingBase = baseURL
+}
+
+// trackLinks rewrites the links of an outbound text or caption when the
+// request asked for it. The links returned must be saved before the text
+// is sent.
+func (s *MessageService) trackLinks(ctx context.Context, sess *session.Session, to, text string) (string, []*linktrack.Link, error) {
+	campaign, ok := ctx.Value(linkTrackingKey{}).(string)
+	if !ok || text == "" {
+		return text, nil, nil
+	}
+	if s.links == nil {
+		return "", nil, fmt.Errorf("%w: link tracking is not available", linktrack.ErrInvalidTracking)
+	}
+
+	base := s.trackingBase
+	if sess.TenantID != nil && s.tenants != nil {
+		owner, err := s.tenants.Get(ctx, *sess.TenantID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get tracking domain: %w", err)
+		}
+		if owner.TrackingDomain != "" {
+			base = owner.TrackingDomain
+		}
+	}
+
+	return s.links.Rewrite(sess.ID, to, campaign, base, text)
+}
+
+// attachLinks ties tracked links to the message they were sent in. The
+// links redirect either way, so a failure only costs the lookup by message.
+func (s *MessageService) attachLinks(ctx context.Context, links []*linktrack.Link, messageID string) {
+	if len(links) == 0 {
+		return
+	}
+	if err := s.links.Attach(ctx, links, messageID); err != nil {
+		s.logger.WithContext(ctx).WarnWithFields("Failed to attach tracked links to message", map[string]interface{}{
+			"message_id": messageID,
+			"links":      len(links),
+			"error":      err.Error(),
+		})
+	}
+}
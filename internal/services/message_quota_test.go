@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/platform/config"
+	"zpwoot/platform/logger"
+)
+
+type fakeQuota struct {
+	limit int
+	used  int
+}
+
+func (q *fakeQuota) ReserveMessage(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	if q.used >= q.limit {
+		return "", &tenant.QuotaError{Err: tenant.ErrMessageLimit, Limit: q.limit}
+	}
+	q.used++
+	return "2026-10-17", nil
+}
+
+func (q *fakeQuota) ReleaseMessage(ctx context.Context, tenantID uuid.UUID, day string) error {
+	q.used--
+	return nil
+}
+
+type fakeResolver struct {
+	session *session.Session
+}
+
+func (r *fakeResolver) ResolveToID(ctx context.Context, sessionName string) (uuid.UUID, error) {
+	return r.session.ID, nil
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, sessionName string) (*session.ResolveResult, error) {
+	return &session.ResolveResult{ID: r.session.ID, Name: r.session.Name, Session: r.session}, nil
+}
+
+// pollSender sends only polls, failing with err when it is set.
+type pollSender struct {
+	session.MessageSender
+	err   error
+	polls int
+}
+
+func (s *pollSender) SendPollMessage(ctx context.Context, sessionName, to string, message *session.PollMessage) (*session.MessageSendResult, error) {
+	s.polls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &session.MessageSendResult{MessageID: "3EB0C767D71D", To: to, Status: "sent"}, nil
+}
+
+func newQuotaTestService(quota *fakeQuota, sender *pollSender) *MessageService {
+	tenantID := uuid.New()
+	sess := &session.Session{ID: uuid.New(), Name: "quota", IsConnected: true, TenantID: &tenantID}
+
+	return &MessageService{
+		sessionCore: session.NewService(nil, nil, nil, nil, quota, nil, nil),
+		resolver:    &fakeResolver{session: sess},
+		sender:      sender,
+		logger:      logger.New(config.LogConfig{Level: "error"}),
+	}
+}
+
+func pollRequest() *contracts.SendPollMessageRequest {
+	return &contracts.SendPollMessageRequest{
+		To:       "5511999999999@s.whatsapp.net",
+		Question: "Qual sua cor favorita?",
+		Options:  []contracts.PollOptionInfo{{Name: "Azul"}, {Name: "Verde"}},
+	}
+}
+
+func TestSendPollCountsAgainstTenantQuota(t *testing.T) {
+	quota := &fakeQuota{limit: 1}
+	sender := &pollSender{}
+	s := newQuotaTestService(quota, sender)
+
+	if _, err := s.SendPollMessage(context.Background(), "quota", pollRequest()); err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if quota.used != 1 {
+		t.Fatalf("quota used = %d, want 1", quota.used)
+	}
+
+	_, err := s.SendPollMessage(context.Background(), "quota", pollRequest())
+	var quotaErr *tenant.QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("second poll error = %v, want *tenant.QuotaError", err)
+	}
+	if sender.polls != 1 {
+		t.Fatalf("polls sent = %d, want 1", sender.polls)
+	}
+}
+
+func TestFailedPollReleasesTenantQuota(t *testing.T) {
+	quota := &fakeQuota{limit: 1}
+	sender := &pollSender{err: errors.New("socket closed")}
+	s := newQuotaTestService(quota, sender)
+
+	if _, err := s.SendPollMessage(context.Background(), "quota", pollRequest()); err == nil {
+		t.Fatal("poll sent through a failing sender")
+	}
+	if quota.used != 0 {
+		t.Fatalf("quota used = %d after a failed send, want 0", quota.used)
+	}
+}
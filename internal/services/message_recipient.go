@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+)
+
+// SetRecipientCheck makes sends to phone numbers look the number up first
+// (through the number check cache) and fail fast when it is not on
+// WhatsApp, instead of WhatsApp silently dropping the message.
+func (s *MessageService) SetRecipientCheck(numbers *contact.NumberChecker) {
+	s.numbers = numbers
+}
+
+// SetDefaultCountry sets the calling code given to phone numbers sent to
+// without one. Empty requires every number to carry its country code.
+func (s *MessageService) SetDefaultCountry(code string) {
+	s.defaultCountry = code
+}
+
+// checkRecipient validates a send's recipient before it reaches WhatsApp
+// and returns the JID to send to. Recipients the session's policy does not
+// allow are rejected with a *session.PolicyError. With the recipient check
+// on, phone numbers are also looked up and the JID WhatsApp answers with is
+// used; a failed lookup lets the send go ahead rather than blocking it.
+func (s *MessageService) checkRecipient(ctx context.Context, sess *session.Session, to string) (string, error) {
+	recipient, err := messaging.ParseRecipient(to, s.defaultCountry)
+	if err != nil {
+		return "", err
+	}
+	if err := sess.CheckBan(time.Now()); err != nil {
+		return "", err
+	}
+	if err := sess.Settings.Policy.CheckSend(recipient.JID); err != nil {
+		return "", err
+	}
+	if s.numbers == nil || recipient.Phone == "" {
+		return recipient.JID, nil
+	}
+
+	checks, err := s.numbers.Check(ctx, sess.Name, []string{recipient.Phone}, false)
+	if err != nil {
+		s.logger.WithContext(ctx).WarnWithFields("Recipient check failed, sending anyway", map[string]interface{}{
+			"session_name": sess.Name,
+			"to":           recipient.JID,
+			"error":        err.Error(),
+		})
+		return recipient.JID, nil
+	}
+
+	check := checks[recipient.Phone]
+	if check == nil || !check.OnWhatsApp {
+		return "", &messaging.RecipientError{Recipient: to, Err: messaging.ErrRecipientNotOnWhatsApp}
+	}
+	if check.JID != "" {
+		return check.JID, nil
+	}
+	return recipient.JID, nil
+}
+
+// checkGroupRules applies the posting rules the session set for the group a
+// send goes to, if it goes to one, to its type and texts. Dry runs are
+// checked without taking from the group's hourly cap; other sends get back
+// a func that returns their slot, which reserveSend chains.
+func (s *MessageService) checkGroupRules(ctx context.Context, sess *session.Session, to, kind string, texts ...string) (func(), error) {
+	if !strings.HasSuffix(to, "@g.us") {
+		return func() {}, nil
+	}
+
+	release, err := s.sessionCore.CheckGroupPost(sess, to, kind, strings.Join(texts, "\n"), !isDryRun(ctx, sess))
+	if err != nil {
+		s.logger.WithContext(ctx).WarnWithFields("Send rejected by group posting rules", map[string]interface{}{
+			"session_name": sess.Name,
+			"to":           to,
+			"kind":         kind,
+			"error":        err.Error(),
+		})
+	}
+	return release, err
+}
+
+// reserveSend takes the send from the warm-up allowance and tenant quota
+// right before it goes out. The returned func gives those back together
+// with the group post slot checkGroupRules took; a send that cannot be
+// reserved gives the slot back itself.
+func (s *MessageService) reserveSend(ctx context.Context, sess *session.Session, releasePost func()) (func(), error) {
+	release, err := s.sessionCore.ReserveSend(ctx, sess)
+	if err != nil {
+		releasePost()
+		return nil, err
+	}
+	return func() {
+		release()
+		releasePost()
+	}, nil
+}
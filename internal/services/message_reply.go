@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"zpwoot/internal/core/session"
+	shared "zpwoot/internal/core/shared/errors"
+)
+
+type replyToKey struct{}
+
+type replyTarget struct {
+	messageID   string
+	participant string
+}
+
+// WithReplyTo makes the send methods reply to messageID. The quoted author
+// and text are looked up in the message store; participant is only used
+// when the message was never stored.
+func WithReplyTo(ctx context.Context, messageID, participant string) context.Context {
+	if messageID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, replyToKey{}, replyTarget{messageID: messageID, participant: participant})
+}
+
+// quoteReply turns the reply target carried by ctx into the quote the
+// gateway attaches to the outgoing message.
+func (s *MessageService) quoteReply(ctx context.Context, sess *session.Session) (context.Context, error) {
+	target, ok := ctx.Value(replyToKey{}).(replyTarget)
+	if !ok {
+		return ctx, nil
+	}
+
+	quoted, err := s.messageRepo.GetByZpMessageID(ctx, sess.ID, target.messageID)
+	if errors.Is(err, shared.ErrNotFound) {
+		if target.participant == "" {
+			return nil, fmt.Errorf("reply target message %s not found", target.messageID)
+		}
+		return session.WithQuote(ctx, &session.Quote{
+			MessageID:   target.messageID,
+			Participant: target.participant,
+		}), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up reply target: %w", err)
+	}
+
+	quote := &session.Quote{
+		MessageID: quoted.ZpMessageID,
+		Body:      quoted.Content,
+	}
+	if !quoted.ZpFromMe {
+		quote.Participant = quoted.ZpSender
+	}
+
+	return session.WithQuote(ctx, quote), nil
+}
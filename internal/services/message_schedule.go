@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/schedule"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+)
+
+// Send kinds name the endpoint a scheduled payload belongs to.
+const (
+	SendKindText         = "text"
+	SendKindMedia        = "media"
+	SendKindImage        = "image"
+	SendKindAudio        = "audio"
+	SendKindVideo        = "video"
+	SendKindDocument     = "document"
+	SendKindSticker      = "sticker"
+	SendKindLocation     = "location"
+	SendKindLiveLocation = "live_location"
+	SendKindContact      = "contact"
+	SendKindButton       = "button"
+	SendKindPoll         = "poll"
+	SendKindPayment      = "payment"
+	SendKindNewsletter   = "newsletter"
+)
+
+const (
+	scheduleReasonQuietHours = "quiet_hours"
+	scheduleReasonWarmUp     = "warm_up"
+	scheduleReasonRequested  = "requested"
+)
+
+// HoldForQuietHours checks the session's quiet hours before a send. Outside
+// the window it returns nil and the caller sends as usual. Inside it, the send
+// is either rejected with a session.QuietHoursError or stored with its payload
+// and returned as scheduled, depending on policy or, when empty, the session's
+// default.
+func (s *MessageService) HoldForQuietHours(ctx context.Context, sessionID, policy, kind string, payload interface{}) (*contracts.ScheduledMessageResponse, error) {
+	id, _, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDryRun(ctx, sess) {
+		return nil, nil
+	}
+
+	quiet := sess.Settings.QuietWindow()
+	resumeAt, inWindow := quiet.ResumeAt(time.Now())
+	if !inWindow {
+		return nil, nil
+	}
+
+	if policy == "" {
+		policy = string(quiet.Policy)
+	}
+	if session.QuietHoursPolicy(policy) != session.QuietHoursDefer {
+		return nil, &session.QuietHoursError{ResumeAt: resumeAt}
+	}
+
+	message, err := s.scheduler.Schedule(ctx, id, kind, payload, resumeAt, scheduleReasonQuietHours)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Send deferred until end of quiet hours", map[string]interface{}{
+		"session_id": sessionID,
+		"kind":       kind,
+		"send_at":    resumeAt,
+	})
+
+	return scheduledToDTO(message, sess.Settings.Location()), nil
+}
+
+// HoldForWarmUp checks the send against the session's warm-up allowance.
+// Within the day's cap it returns nil and the caller sends immediately, the
+// send path taking it from the allowance; over it the send is rejected or,
+// with the defer policy, scheduled for the next day.
+func (s *MessageService) HoldForWarmUp(ctx context.Context, sessionID, kind string, payload interface{}) (*contracts.ScheduledMessageResponse, error) {
+	id, _, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDryRun(ctx, sess) {
+		return nil, nil
+	}
+
+	err = s.sessionCore.CheckWarmUp(ctx, sess)
+	var limited *session.WarmUpLimitError
+	if !errors.As(err, &limited) || sess.Settings.WarmUp.Policy != session.WarmUpDefer {
+		return nil, err
+	}
+
+	message, err := s.scheduler.Schedule(ctx, id, kind, payload, limited.ResumeAt, scheduleReasonWarmUp)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Send deferred by warm-up daily limit", map[string]interface{}{
+		"session_id": sessionID,
+		"kind":       kind,
+		"limit":      limited.Limit,
+		"send_at":    limited.ResumeAt,
+	})
+
+	return scheduledToDTO(message, sess.Settings.Location()), nil
+}
+
+// EnterSendQueue admits an API send into the session's send queue. Over the
+// configured depth it fails with a *session.SendQueueFullError; otherwise
+// the returned func must be called once the request is answered.
+func (s *MessageService) EnterSendQueue(ctx context.Context, sessionID string) (func(), error) {
+	_, _, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDryRun(ctx, sess) {
+		return func() {}, nil
+	}
+
+	release, err := s.sessionCore.EnterSendQueue(sess)
+	if err != nil {
+		s.logger.WarnWithFields("Send rejected by full send queue", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// Dispatch implements schedule.Dispatcher by replaying the stored request
+// through the same service method its endpoint uses.
+func (s *MessageService) Dispatch(ctx context.Context, message *schedule.Message) (string, error) {
+	sess, err := s.sessionCore.GetSession(ctx, message.SessionID)
+	if err != nil {
+		return "", err
+	}
+
+	// The send path takes the send from the warm-up allowance and tenant
+	// quota; over either, the message waits for the day they renew.
+	messageID, err := s.dispatch(ctx, sess, message)
+	var limited *session.WarmUpLimitError
+	var quota *tenant.QuotaError
+	if errors.As(err, &limited) {
+		return "", &schedule.DeferError{Until: limited.ResumeAt, Reason: session.ErrWarmUpLimit.Error()}
+	} else if errors.As(err, &quota) && !quota.ResumeAt.IsZero() {
+		return "", &schedule.DeferError{Until: quota.ResumeAt, Reason: quota.Err.Error()}
+	}
+	return messageID, err
+}
+
+// dispatch replays a stored request.
+func (s *MessageService) dispatch(ctx context.Context, sess *session.Session, message *schedule.Message) (string, error) {
+	name := sess.Name
+
+	if message.Kind == SendKindNewsletter {
+		if s.newsletters == nil {
+			return "", fmt.Errorf("newsletter posts are not supported")
+		}
+		return s.dispatchNewsletterPost(ctx, sess, message.Payload)
+	}
+
+	// A send retried after it went unconfirmed goes out under the same ID,
+	// so WhatsApp cannot deliver it twice.
+	ctx = session.WithMessageID(ctx, message.MessageID)
+
+	var response *contracts.SendMessageResponse
+	var err error
+
+	switch message.Kind {
+	case SendKindText:
+		var req contracts.SendTextMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		messageID, participant := req.ReplyTarget()
+		response, err = s.SendTextMessage(WithLinkTracking(WithReplyTo(WithTextFormat(WithFooter(ctx, req.Footer), req.Formatting), messageID, participant), req.TrackLinks, req.Campaign), name, req.RemoteJID, req.Body)
+	case SendKindMedia:
+		var req contracts.SendMediaMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendMediaMessage(WithLinkTracking(WithReplyTo(WithTextFormat(WithFooter(ctx, req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign), name, req.To, req.MediaURL, req.Caption, req.Type)
+	case SendKindImage:
+		var req contracts.SendImageMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendImageMessage(WithLinkTracking(WithReplyTo(WithTextFormat(WithFooter(ctx, req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign), name, req.To, req.File, req.Caption, req.Filename)
+	case SendKindAudio:
+		var req contracts.SendAudioMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendAudioMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, req.To, req.File, req.Caption)
+	case SendKindVideo:
+		var req contracts.SendVideoMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendVideoMessage(WithLinkTracking(WithReplyTo(WithTextFormat(WithFooter(ctx, req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign), name, req.To, req.File, req.Caption, req.Filename)
+	case SendKindDocument:
+		var req contracts.SendDocumentMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendDocumentMessage(WithLinkTracking(WithReplyTo(WithTextFormat(WithFooter(ctx, req.Footer), req.Formatting), req.ReplyTo, ""), req.TrackLinks, req.Campaign), name, req.To, req.File, req.Caption, req.Filename)
+	case SendKindSticker:
+		var req contracts.SendStickerMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendStickerMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, req.To, req.File)
+	case SendKindLocation:
+		var req contracts.SendLocationMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendLocationMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, req.To, req.Latitude, req.Longitude, req.Address)
+	case SendKindContact:
+		var req contracts.SendContactMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendContactMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, &req)
+	case SendKindButton:
+		var req contracts.SendButtonMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendButtonMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, &req)
+	case SendKindPoll:
+		var req contracts.SendPollMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendPollMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, &req)
+	case SendKindPayment:
+		var req contracts.SendPaymentRequestMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendPaymentRequest(WithReplyTo(ctx, req.ReplyTo, ""), name, &req)
+	case SendKindLiveLocation:
+		var req contracts.SendLiveLocationMessageRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			return "", fmt.Errorf("invalid scheduled payload: %w", err)
+		}
+		response, err = s.SendLiveLocationMessage(WithReplyTo(ctx, req.ReplyTo, ""), name, &req)
+	default:
+		return "", fmt.Errorf("unknown scheduled message kind %q", message.Kind)
+	}
+
+	// A group's hourly cap only delays the send; its other rules fail it.
+	var groupRule *session.GroupRuleError
+	if errors.As(err, &groupRule) && groupRule.Rule == session.GroupRuleRate {
+		return "", &schedule.DeferError{Until: groupRule.ResumeAt, Reason: groupRule.Error()}
+	}
+	var unconfirmed *session.SendUnconfirmedError
+	if errors.As(err, &unconfirmed) {
+		return unconfirmed.MessageID, err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return response.MessageID, nil
+}
+
+// dispatchNewsletterPost publishes a scheduled channel post, which does not
+// go through the message send path, so it takes its reservation here.
+func (s *MessageService) dispatchNewsletterPost(ctx context.Context, sess *session.Session, payload json.RawMessage) (string, error) {
+	// Sandbox sends are only simulated, so they count against no limit.
+	release := func() {}
+	if !isDryRun(ctx, sess) {
+		reserved, err := s.sessionCore.ReserveSend(ctx, sess)
+		if err != nil {
+			return "", err
+		}
+		release = reserved
+	}
+
+	messageID, err := s.newsletters.dispatchPost(ctx, sess, payload)
+	if err != nil {
+		release()
+	}
+	return messageID, err
+}
+
+func (s *MessageService) ListScheduled(ctx context.Context, sessionID, status string) (*contracts.ListScheduledMessagesResponse, error) {
+	id, _, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.scheduler.List(ctx, id, schedule.Status(status))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListScheduledMessagesResponse{
+		Messages: make([]contracts.ScheduledMessageResponse, len(messages)),
+		Total:    len(messages),
+	}
+	for i, message := range messages {
+		response.Messages[i] = *scheduledToDTO(message, sess.Settings.Location())
+	}
+
+	return response, nil
+}
+
+func (s *MessageService) CancelScheduled(ctx context.Context, sessionID, scheduledID string) error {
+	messageID, err := uuid.Parse(scheduledID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid scheduled message ID")
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.scheduler.Cancel(ctx, id, messageID)
+}
+
+// KeepFailedSend stores a send whose media never reached WhatsApp's servers,
+// or that went unconfirmed, as a failed scheduled message, so it can be
+// retried with RetryFailedSend instead of being lost. An unconfirmed send
+// keeps its message ID, which the retry reuses. It returns the error to
+// answer with: a *schedule.FailedSendError naming the kept send, or sendErr
+// unchanged for other failures.
+func (s *MessageService) KeepFailedSend(ctx context.Context, sessionID, kind string, payload interface{}, sendErr error) error {
+	var messageID string
+	var unconfirmed *session.SendUnconfirmedError
+	if errors.As(sendErr, &unconfirmed) {
+		messageID = unconfirmed.MessageID
+	} else if !errors.Is(sendErr, session.ErrMediaUploadFailed) {
+		return sendErr
+	}
+
+	// The send is kept even when the caller has hung up meanwhile.
+	ctx = context.WithoutCancel(ctx)
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return sendErr
+	}
+
+	message, err := s.scheduler.KeepFailed(ctx, id, kind, payload, messageID, sendErr)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to keep failed send", map[string]interface{}{
+			"session_id": sessionID,
+			"kind":       kind,
+			"error":      err.Error(),
+		})
+		return sendErr
+	}
+
+	s.logger.InfoWithFields("Failed send kept for retry", map[string]interface{}{
+		"session_id": sessionID,
+		"kind":       kind,
+		"failed_id":  message.ID.String(),
+	})
+
+	return &schedule.FailedSendError{ID: message.ID, Err: sendErr}
+}
+
+// RetryFailedSend replays a failed send, whether it failed when submitted or
+// when its scheduled time came, and returns it with the new outcome. A send
+// that fails again stays failed and can be retried once more.
+func (s *MessageService) RetryFailedSend(ctx context.Context, sessionID, failedID string) (*contracts.ScheduledMessageResponse, error) {
+	messageID, err := uuid.Parse(failedID)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid failed message ID")
+	}
+
+	id, _, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.scheduler.Retry(ctx, id, messageID, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledToDTO(message, sess.Settings.Location()), nil
+}
+
+// scheduledToDTO gives the send time in the session's timezone, so it reads
+// as the local time the send was deferred to.
+func scheduledToDTO(message *schedule.Message, loc *time.Location) *contracts.ScheduledMessageResponse {
+	return &contracts.ScheduledMessageResponse{
+		ID:        message.ID.String(),
+		Kind:      message.Kind,
+		SendAt:    message.SendAt.In(loc),
+		Reason:    message.Reason,
+		Status:    string(message.Status),
+		Attempts:  message.Attempts,
+		LastError: message.LastError,
+		MessageID: message.MessageID,
+		CreatedAt: message.CreatedAt,
+	}
+}
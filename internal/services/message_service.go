@@ -2,14 +2,23 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/linktrack"
 	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/note"
+	"zpwoot/internal/core/schedule"
 	"zpwoot/internal/core/session"
+	shared "zpwoot/internal/core/shared/errors"
+	"zpwoot/internal/core/shared/pagination"
+	"zpwoot/internal/core/tenant"
 	"zpwoot/internal/services/shared/validation"
 	"zpwoot/platform/logger"
 )
@@ -22,11 +31,26 @@ type MessageService struct {
 	messageRepo messaging.Repository
 	sessionRepo session.Repository
 	whatsappGW  session.WhatsAppGateway
+	sender      session.MessageSender
+	stars       messaging.StarGateway
+	reads       messaging.ReadGateway
+	pins        messaging.PinGateway
+	scheduler   *schedule.Service
+	notes       *note.Service
+	numbers     *contact.NumberChecker
+	media       MediaInspector
+
+	links        *linktrack.Service
+	tenants      *tenant.Service
+	trackingBase string
+
+	defaultCountry string
 
 	logger    *logger.Logger
 	validator *validation.Validator
 
 	sessionService *SessionService
+	newsletters    *NewsletterService
 }
 
 func NewMessageService(
@@ -36,6 +60,11 @@ func NewMessageService(
 	messageRepo messaging.Repository,
 	sessionRepo session.Repository,
 	whatsappGW session.WhatsAppGateway,
+	stars messaging.StarGateway,
+	reads messaging.ReadGateway,
+	pins messaging.PinGateway,
+	scheduler *schedule.Service,
+	notes *note.Service,
 	logger *logger.Logger,
 	validator *validation.Validator,
 	sessionService *SessionService,
@@ -47,19 +76,40 @@ func NewMessageService(
 		messageRepo:    messageRepo,
 		sessionRepo:    sessionRepo,
 		whatsappGW:     whatsappGW,
+		sender:         whatsappGW,
+		stars:          stars,
+		reads:          reads,
+		pins:           pins,
+		scheduler:      scheduler,
+		notes:          notes,
 		logger:         logger,
 		validator:      validator,
 		sessionService: sessionService,
 	}
 }
 
+// SetSender replaces the sender sends go through, by default the WhatsApp
+// gateway, typically with the gateway wrapped in decorators.
+func (s *MessageService) SetSender(sender session.MessageSender) {
+	s.sender = sender
+}
+
+// SetNewsletters lets scheduled newsletter posts be published when due.
+func (s *MessageService) SetNewsletters(newsletters *NewsletterService) {
+	s.newsletters = newsletters
+}
+
 func (s *MessageService) validateSession(ctx context.Context, sessionName string) (*session.Session, error) {
 	sessionInfo, err := s.sessionCore.GetSessionByName(ctx, sessionName)
 	if err != nil {
 		return nil, fmt.Errorf("session %s not found: %w", sessionName, err)
 	}
 
-	if !sessionInfo.IsConnected {
+	if err := sessionInfo.CheckBan(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if !sessionInfo.IsConnected && !isDryRun(ctx, sessionInfo) {
 		return nil, fmt.Errorf("session %s is not connected", sessionName)
 	}
 
@@ -96,17 +146,22 @@ type CreateMessageResponse struct {
 }
 
 type ListMessagesRequest struct {
-	SessionID string `json:"session_id,omitempty" validate:"omitempty,uuid"`
-	ChatJID   string `json:"chat_jid,omitempty"`
-	Limit     int    `json:"limit" validate:"min=1,max=100"`
-	Offset    int    `json:"offset" validate:"min=0"`
+	SessionID    string `json:"session_id,omitempty"`
+	ChatJID      string `json:"chat_jid,omitempty"`
+	Limit        int    `json:"limit" validate:"min=1,max=100"`
+	Cursor       string `json:"cursor,omitempty"`
+	Offset       int    `json:"offset" validate:"min=0"`
+	IncludeNotes bool   `json:"include_notes,omitempty"`
 }
 
 type ListMessagesResponse struct {
-	Messages []*contracts.MessageDTO `json:"messages"`
-	Total    int64                   `json:"total"`
-	Limit    int                     `json:"limit"`
-	Offset   int                     `json:"offset"`
+	Messages   []*contracts.MessageDTO  `json:"messages"`
+	Notes      []contracts.NoteResponse `json:"notes,omitempty"`
+	Total      int64                    `json:"total"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+	NextCursor string                   `json:"nextCursor,omitempty"`
+	HasMore    bool                     `json:"hasMore"`
 }
 
 type UpdateSyncStatusRequest struct {
@@ -190,32 +245,146 @@ func (s *MessageService) ListMessages(ctx context.Context, req *ListMessagesRequ
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	after, err := pagination.Decode(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
 	if req.Limit == 0 {
 		req.Limit = 50
 	}
 
+	if req.IncludeNotes && (req.SessionID == "" || req.ChatJID == "") {
+		return nil, fmt.Errorf("validation failed: include_notes requires chat_jid")
+	}
+
 	coreReq := &messaging.ListMessagesRequest{
-		SessionID: req.SessionID,
-		ChatJID:   req.ChatJID,
-		Limit:     req.Limit,
-		Offset:    req.Offset,
+		ChatJID: req.ChatJID,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+		After:   after,
+	}
+
+	if req.SessionID != "" {
+		id, _, _, err := s.resolveSessionID(ctx, req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		coreReq.SessionID = id.String()
 	}
 
-	messages, total, err := s.messagingCore.ListMessages(ctx, coreReq)
+	page, err := s.messagingCore.ListMessages(ctx, coreReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list messages: %w", err)
 	}
 
-	messageDTOs := make([]*contracts.MessageDTO, len(messages))
-	for i, message := range messages {
+	messageDTOs := make([]*contracts.MessageDTO, len(page.Messages))
+	for i, message := range page.Messages {
 		messageDTOs[i] = s.messageToDTO(message)
 	}
 
-	return &ListMessagesResponse{
+	response := &ListMessagesResponse{
 		Messages: messageDTOs,
-		Total:    total,
-		Limit:    req.Limit,
-		Offset:   req.Offset,
+		Total:    page.Total,
+		Limit:    coreReq.Limit,
+		Offset:   coreReq.Offset,
+		HasMore:  page.HasMore,
+	}
+
+	// Keyset cursors only exist per session; the global listing stays on
+	// offsets.
+	if page.HasMore && coreReq.SessionID != "" {
+		last := page.Messages[len(page.Messages)-1]
+		response.NextCursor = pagination.Encode(pagination.Cursor{
+			Time: last.ZpTimestamp,
+			ID:   last.ID.String(),
+		})
+	}
+
+	if req.IncludeNotes {
+		notes, err := s.pageNotes(ctx, coreReq, after, page)
+		if err != nil {
+			return nil, err
+		}
+		response.Notes = notes
+	}
+
+	return response, nil
+}
+
+// pageNotes returns the chat notes written in the time span a page of
+// messages covers, so a client walking the history with cursors gets each
+// note exactly once, next to the messages around it.
+func (s *MessageService) pageNotes(ctx context.Context, req *messaging.ListMessagesRequest, after *pagination.Cursor, page *messaging.MessagePage) ([]contracts.NoteResponse, error) {
+	sessionID, err := uuid.Parse(req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID: %w", err)
+	}
+
+	filter := &note.ListFilter{SessionID: sessionID, ChatJID: req.ChatJID}
+	switch {
+	case after != nil:
+		to := after.Time
+		filter.To = &to
+	case req.Offset > 0 && len(page.Messages) > 0:
+		to := page.Messages[0].ZpTimestamp
+		filter.To = &to
+	}
+	if page.HasMore && len(page.Messages) > 0 {
+		from := page.Messages[len(page.Messages)-1].ZpTimestamp
+		filter.From = &from
+	}
+
+	notes, err := s.notes.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return notesToDTO(notes), nil
+}
+
+func (s *MessageService) SearchMessages(ctx context.Context, sessionID string, req *contracts.SearchMessagesRequest) (*contracts.SearchMessagesResponse, error) {
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	coreReq := &messaging.SearchMessagesRequest{
+		SessionID: id,
+		Query:     req.Query,
+		ChatJID:   req.ChatJID,
+		Type:      messaging.MessageType(req.Type),
+		Direction: messaging.MessageDirection(req.Direction),
+		From:      req.From,
+		To:        req.To,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+
+	results, total, err := s.messagingCore.SearchMessages(ctx, coreReq)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]contracts.MessageSearchHit, len(results))
+	for i, result := range results {
+		hits[i] = contracts.MessageSearchHit{
+			Message: s.messageToDTO(result.Message),
+			Snippet: result.Snippet,
+			Rank:    result.Rank,
+		}
+	}
+
+	return &contracts.SearchMessagesResponse{
+		Results: hits,
+		Total:   total,
+		Limit:   coreReq.Limit,
+		Offset:  coreReq.Offset,
 	}, nil
 }
 
@@ -281,26 +450,64 @@ func (s *MessageService) GetMessageStats(ctx context.Context, sessionID *string)
 }
 
 func (s *MessageService) SendTextMessage(ctx context.Context, sessionName, to, content string) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendTextMessage")
+	defer span.End()
 
 	if sessionName == "" || to == "" || content == "" {
 		return nil, fmt.Errorf("sessionName, to, and content are required")
 	}
 
-	_, err := s.validateSession(ctx, sessionName)
+	sess, err := s.validateSession(ctx, sessionName)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	to, err = s.checkRecipient(ctx, sess, to)
+	if err != nil {
+		return nil, err
+	}
+
+	content = appendFooter(ctx, sess, formatText(ctx, sess, content))
+	content, links, err := s.trackLinks(ctx, sess, to, content)
+	if err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, to, session.SendText, content)
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.InfoWithFields("Sending text message via WhatsApp", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Sending text message via WhatsApp", map[string]interface{}{
 		"session_name": sessionName,
 		"to":           to,
 		"content_len":  len(content),
 	})
 
-	result, err := s.whatsappGW.SendTextMessage(ctx, sessionName, to, content)
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendText, to, map[string]interface{}{
+			"text": content,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.links.Track(ctx, links); err != nil {
+		release()
+		return nil, err
+	}
+
+	result, err := s.sender.SendTextMessage(ctx, sessionName, to, content)
 	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to send text message via WhatsApp Gateway: %w", err)
 	}
+	s.attachLinks(ctx, links, result.MessageID)
 
 	response := &contracts.SendMessageResponse{
 		MessageID: result.MessageID,
@@ -309,7 +516,7 @@ func (s *MessageService) SendTextMessage(ctx context.Context, sessionName, to, c
 		Timestamp: result.Timestamp,
 	}
 
-	s.logger.InfoWithFields("Text message sent successfully", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Text message sent successfully", map[string]interface{}{
 		"session_name": sessionName,
 		"message_id":   result.MessageID,
 		"to":           result.To,
@@ -319,17 +526,42 @@ func (s *MessageService) SendTextMessage(ctx context.Context, sessionName, to, c
 }
 
 func (s *MessageService) SendMediaMessage(ctx context.Context, sessionName, to, mediaURL, caption, mediaType string) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendMediaMessage")
+	defer span.End()
 
 	if sessionName == "" || to == "" || mediaURL == "" {
 		return nil, fmt.Errorf("sessionName, to, and mediaURL are required")
 	}
 
-	_, err := s.validateSession(ctx, sessionName)
+	sess, err := s.validateSession(ctx, sessionName)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	to, err = s.checkRecipient(ctx, sess, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*linktrack.Link
+	switch mediaType {
+	case "image", "video", "document":
+		caption = appendFooter(ctx, sess, formatText(ctx, sess, caption))
+		caption, links, err = s.trackLinks(ctx, sess, to, caption)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, to, mediaType, caption)
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.InfoWithFields("Sending media message via WhatsApp", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Sending media message via WhatsApp", map[string]interface{}{
 		"session_name": sessionName,
 		"to":           to,
 		"media_url":    mediaURL,
@@ -337,10 +569,25 @@ func (s *MessageService) SendMediaMessage(ctx context.Context, sessionName, to,
 		"has_caption":  caption != "",
 	})
 
-	result, err := s.whatsappGW.SendMediaMessage(ctx, sessionName, to, mediaURL, caption, mediaType)
+	if isDryRun(ctx, sess) {
+		return s.dryRunMedia(ctx, sess, to, mediaURL, caption, mediaType)
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
 	if err != nil {
+		return nil, err
+	}
+	if err := s.links.Track(ctx, links); err != nil {
+		release()
+		return nil, err
+	}
+
+	result, err := s.sender.SendMediaMessage(ctx, sessionName, to, mediaURL, caption, mediaType)
+	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to send media message via WhatsApp Gateway: %w", err)
 	}
+	s.attachLinks(ctx, links, result.MessageID)
 
 	response := &contracts.SendMessageResponse{
 		MessageID: result.MessageID,
@@ -349,7 +596,7 @@ func (s *MessageService) SendMediaMessage(ctx context.Context, sessionName, to,
 		Timestamp: result.Timestamp,
 	}
 
-	s.logger.InfoWithFields("Media message sent successfully", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Media message sent successfully", map[string]interface{}{
 		"session_name": sessionName,
 		"message_id":   result.MessageID,
 		"to":           result.To,
@@ -380,17 +627,32 @@ func (s *MessageService) SendStickerMessage(ctx context.Context, sessionID, to,
 }
 
 func (s *MessageService) SendLocationMessage(ctx context.Context, sessionID, to string, latitude, longitude float64, address string) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendLocationMessage")
+	defer span.End()
 
 	if sessionID == "" || to == "" {
 		return nil, fmt.Errorf("sessionID and to are required")
 	}
 
-	_, sessionName, _, err := s.resolveSessionID(ctx, sessionID)
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	to, err = s.checkRecipient(ctx, sess, to)
+	if err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, to, session.SendLocation, address)
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.InfoWithFields("Sending location message via WhatsApp", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Sending location message via WhatsApp", map[string]interface{}{
 		"session_id": sessionID,
 		"to":         to,
 		"latitude":   latitude,
@@ -398,8 +660,21 @@ func (s *MessageService) SendLocationMessage(ctx context.Context, sessionID, to
 		"address":    address,
 	})
 
-	result, err := s.whatsappGW.SendLocationMessage(ctx, sessionName, to, latitude, longitude, address)
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendLocation, to, map[string]interface{}{
+			"latitude":  latitude,
+			"longitude": longitude,
+			"address":   address,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
 	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendLocationMessage(ctx, sessionName, to, latitude, longitude, address)
+	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to send location message via WhatsApp Gateway: %w", err)
 	}
 
@@ -410,7 +685,7 @@ func (s *MessageService) SendLocationMessage(ctx context.Context, sessionID, to
 		Timestamp: result.Timestamp,
 	}
 
-	s.logger.InfoWithFields("Location message sent successfully", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Location message sent successfully", map[string]interface{}{
 		"session_id": sessionID,
 		"message_id": result.MessageID,
 		"to":         result.To,
@@ -419,26 +694,124 @@ func (s *MessageService) SendLocationMessage(ctx context.Context, sessionID, to
 	return response, nil
 }
 
-func (s *MessageService) SendContactMessage(ctx context.Context, sessionID, to, contactName, contactPhone string) (*contracts.SendMessageResponse, error) {
+func (s *MessageService) SendLiveLocationMessage(ctx context.Context, sessionID string, req *contracts.SendLiveLocationMessageRequest) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendLiveLocationMessage")
+	defer span.End()
+
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	req.To, err = s.checkRecipient(ctx, sess, req.To)
+	if err != nil {
+		return nil, err
+	}
 
-	if sessionID == "" || to == "" || contactName == "" || contactPhone == "" {
-		return nil, fmt.Errorf("sessionID, to, contactName, and contactPhone are required")
+	location := &session.LiveLocation{
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		AccuracyMeters: req.AccuracyMeters,
+		SpeedMps:       req.SpeedMps,
+		Heading:        req.Heading,
+		Caption:        req.Caption,
+		Sequence:       req.Sequence,
+		TimeOffset:     req.TimeOffset,
 	}
 
-	_, _, _, err := s.resolveSessionID(ctx, sessionID)
+	releasePost, err := s.checkGroupRules(ctx, sess, req.To, session.SendLive, location.Caption)
 	if err != nil {
 		return nil, err
 	}
 
-	s.logger.InfoWithFields("Sending contact message via WhatsApp", map[string]interface{}{
-		"session_id":    sessionID,
-		"to":            to,
-		"contact_name":  contactName,
-		"contact_phone": contactPhone,
+	s.logger.WithContext(ctx).InfoWithFields("Sending live location message via WhatsApp", map[string]interface{}{
+		"session_id": sessionID,
+		"to":         req.To,
+		"sequence":   location.Sequence,
 	})
 
-	result, err := s.whatsappGW.SendContactMessage(ctx, sessionID, to, contactName, contactPhone)
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendLive, req.To, map[string]interface{}{
+			"latitude":        location.Latitude,
+			"longitude":       location.Longitude,
+			"accuracy_meters": location.AccuracyMeters,
+			"speed_mps":       location.SpeedMps,
+			"heading":         location.Heading,
+			"caption":         location.Caption,
+			"sequence":        location.Sequence,
+			"time_offset":     location.TimeOffset,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendLiveLocationMessage(ctx, sessionName, req.To, location)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to send live location message via WhatsApp Gateway: %w", err)
+	}
+
+	return &contracts.SendMessageResponse{
+		MessageID: result.MessageID,
+		To:        result.To,
+		Status:    result.Status,
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+func (s *MessageService) SendContactMessage(ctx context.Context, sessionID string, req *contracts.SendContactMessageRequest) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendContactMessage")
+	defer span.End()
+
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	req.To, err = s.checkRecipient(ctx, sess, req.To)
 	if err != nil {
+		return nil, err
+	}
+
+	card := contactCardFromRequest(req)
+	if err := card.Validate(); err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, req.To, session.SendContact, card.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Sending contact message via WhatsApp", map[string]interface{}{
+		"session_id":   sessionID,
+		"to":           req.To,
+		"contact_name": card.Name,
+		"phone_count":  len(card.Phones),
+	})
+
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendContact, req.To, map[string]interface{}{
+			"display_name": card.Name,
+			"vcard":        card.VCard(),
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendContactMessage(ctx, sessionName, req.To, card)
+	if err != nil {
+		release()
 		return nil, fmt.Errorf("failed to send contact message via WhatsApp Gateway: %w", err)
 	}
 
@@ -449,7 +822,7 @@ func (s *MessageService) SendContactMessage(ctx context.Context, sessionID, to,
 		Timestamp: result.Timestamp,
 	}
 
-	s.logger.InfoWithFields("Contact message sent successfully", map[string]interface{}{
+	s.logger.WithContext(ctx).InfoWithFields("Contact message sent successfully", map[string]interface{}{
 		"session_id": sessionID,
 		"message_id": result.MessageID,
 		"to":         result.To,
@@ -458,8 +831,534 @@ func (s *MessageService) SendContactMessage(ctx context.Context, sessionID, to,
 	return response, nil
 }
 
+// contactCardFromRequest collects the request's phones: phone first, then
+// the phones list, with contact_phone kept for older clients.
+func contactCardFromRequest(req *contracts.SendContactMessageRequest) *session.ContactCard {
+	card := &session.ContactCard{
+		Name:         req.Name,
+		Organization: req.Organization,
+		PhotoSource:  req.Photo,
+	}
+
+	for _, number := range []string{req.Phone, req.ContactPhone} {
+		if number != "" {
+			card.Phones = append(card.Phones, session.ContactPhone{Number: number})
+			break
+		}
+	}
+	for _, phone := range req.Phones {
+		card.Phones = append(card.Phones, session.ContactPhone{Number: phone.Number, Type: phone.Type})
+	}
+
+	return card
+}
+
+func (s *MessageService) SendButtonMessage(ctx context.Context, sessionID string, req *contracts.SendButtonMessageRequest) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendButtonMessage")
+	defer span.End()
+
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := sess.Settings.Features.Require(session.FeatureButtons); err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	req.To, err = s.checkRecipient(ctx, sess, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	buttonMessage := &session.ButtonMessage{
+		Title:   req.Title,
+		Text:    req.Text,
+		Footer:  req.Footer,
+		Buttons: make([]session.InteractiveButton, 0, len(req.Buttons)),
+	}
+	for _, button := range req.Buttons {
+		buttonMessage.Buttons = append(buttonMessage.Buttons, session.InteractiveButton{
+			Type:        session.ButtonType(button.Type),
+			ID:          button.ID,
+			Text:        button.Text,
+			URL:         button.URL,
+			PhoneNumber: button.PhoneNumber,
+			CopyCode:    button.CopyCode,
+		})
+	}
+
+	if err := buttonMessage.Validate(); err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, req.To, session.SendButton, buttonMessage.Title, buttonMessage.Text, buttonMessage.Footer)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Sending button message via WhatsApp", map[string]interface{}{
+		"session_id":   sessionID,
+		"to":           req.To,
+		"button_count": len(buttonMessage.Buttons),
+	})
+
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendButton, req.To, map[string]interface{}{
+			"title":   buttonMessage.Title,
+			"text":    buttonMessage.Text,
+			"footer":  buttonMessage.Footer,
+			"buttons": buttonMessage.Buttons,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendButtonMessage(ctx, sessionName, req.To, buttonMessage)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to send button message via WhatsApp Gateway: %w", err)
+	}
+
+	response := &contracts.SendMessageResponse{
+		MessageID: result.MessageID,
+		To:        result.To,
+		Status:    result.Status,
+		Timestamp: result.Timestamp,
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Button message sent successfully", map[string]interface{}{
+		"session_id": sessionID,
+		"message_id": result.MessageID,
+		"to":         result.To,
+	})
+
+	return response, nil
+}
+
+// SendPollMessage sends a poll. Without an explicit selectable_count a voter
+// may pick one option, or any number when allow_multiple_vote is set.
+func (s *MessageService) SendPollMessage(ctx context.Context, sessionID string, req *contracts.SendPollMessageRequest) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendPollMessage")
+	defer span.End()
+
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	req.To, err = s.checkRecipient(ctx, sess, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	question := req.Question
+	if question == "" {
+		question = req.Name
+	}
+
+	selectable := req.SelectableCount
+	if selectable == 0 && !req.AllowMultipleVote {
+		selectable = 1
+	}
+
+	poll := &session.PollMessage{
+		Question:        question,
+		Options:         make([]string, 0, len(req.Options)),
+		SelectableCount: selectable,
+	}
+	for _, option := range req.Options {
+		poll.Options = append(poll.Options, option.Name)
+	}
+
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, req.To, session.SendPoll, append([]string{poll.Question}, poll.Options...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Sending poll message via WhatsApp", map[string]interface{}{
+		"session_id":       sessionID,
+		"to":               req.To,
+		"option_count":     len(poll.Options),
+		"selectable_count": poll.SelectableCount,
+	})
+
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendPoll, req.To, map[string]interface{}{
+			"question":         poll.Question,
+			"options":          poll.Options,
+			"selectable_count": poll.SelectableCount,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendPollMessage(ctx, sessionName, req.To, poll)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to send poll message via WhatsApp Gateway: %w", err)
+	}
+
+	return &contracts.SendMessageResponse{
+		MessageID: result.MessageID,
+		To:        result.To,
+		Status:    result.Status,
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+// SendPaymentRequest asks the recipient for a payment through WhatsApp's
+// in-chat payments. Only accounts in a payment region can send one; others,
+// and requests in a currency other than the region's, get a
+// *session.PaymentsNotSupportedError before anything is sent.
+func (s *MessageService) SendPaymentRequest(ctx context.Context, sessionID string, req *contracts.SendPaymentRequestMessageRequest) (*contracts.SendMessageResponse, error) {
+	ctx, span := logger.StartSpan(ctx, "MessageService.SendPaymentRequest")
+	defer span.End()
+
+	_, sessionName, sess, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = s.quoteReply(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	req.To, err = s.checkRecipient(ctx, sess, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	account := ""
+	if sess.DeviceJID != nil {
+		account = *sess.DeviceJID
+	}
+
+	request := &session.PaymentRequest{
+		Amount:   req.Amount,
+		Currency: strings.ToUpper(req.Currency),
+		Note:     req.Note,
+	}
+	if request.Currency == "" {
+		request.Currency, _ = session.PaymentCurrency(account)
+	}
+	if req.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresIn) * time.Second)
+		request.ExpiresAt = &expiresAt
+	}
+
+	if err := session.CheckPaymentSupport(account, request.Currency); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	releasePost, err := s.checkGroupRules(ctx, sess, req.To, session.SendPayment, request.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).InfoWithFields("Sending payment request via WhatsApp", map[string]interface{}{
+		"session_id": sessionID,
+		"to":         req.To,
+		"currency":   request.Currency,
+	})
+
+	if isDryRun(ctx, sess) {
+		return s.dryRunResponse(ctx, sess, session.SendPayment, req.To, map[string]interface{}{
+			"amount":     request.Amount,
+			"currency":   request.Currency,
+			"note":       request.Note,
+			"expires_at": request.ExpiresAt,
+		}, 0), nil
+	}
+
+	release, err := s.reserveSend(ctx, sess, releasePost)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.sender.SendPaymentRequest(ctx, sessionName, req.To, request)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to send payment request via WhatsApp Gateway: %w", err)
+	}
+
+	return &contracts.SendMessageResponse{
+		MessageID: result.MessageID,
+		To:        result.To,
+		Status:    result.Status,
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+// GetPollResults tallies the current votes on a poll sent or received by the
+// session.
+func (s *MessageService) GetPollResults(ctx context.Context, sessionID, messageID string) (*contracts.GetPollResultsResponse, error) {
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.messagingCore.GetPollResults(ctx, id, messageID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, fmt.Errorf("poll %s not found", messageID)
+		}
+		return nil, err
+	}
+
+	response := &contracts.GetPollResultsResponse{
+		MessageID:       messageID,
+		PollID:          messageID,
+		PollName:        results.Poll.Question,
+		Question:        results.Poll.Question,
+		SelectableCount: results.Poll.SelectableCount,
+		Votes:           make([]contracts.PollVote, 0, len(results.Votes)),
+		VoteResults:     make([]contracts.PollVoteInfo, len(results.Tally)),
+		TotalVotes:      results.Voters,
+		CreatedAt:       results.Poll.CreatedAt,
+	}
+
+	for i, tally := range results.Tally {
+		response.VoteResults[i] = contracts.PollVoteInfo{
+			OptionName: tally.Name,
+			Voters:     tally.Voters,
+			VoteCount:  len(tally.Voters),
+		}
+	}
+
+	for _, vote := range results.Votes {
+		if len(vote.Options) == 0 {
+			continue
+		}
+		response.Votes = append(response.Votes, contracts.PollVote{
+			Voter:   vote.VoterJID,
+			Options: vote.Options,
+			VotedAt: vote.VotedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *MessageService) GetMessageDetail(ctx context.Context, sessionID, messageID string) (*contracts.MessageDetailResponse, error) {
+
+	if messageID == "" {
+		return nil, fmt.Errorf("validation failed: message ID is required")
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := s.messagingCore.GetMessageDetail(ctx, id, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.MessageDetailResponse{
+		Message:   s.messageToDTO(detail.Message),
+		Reactions: make([]contracts.MessageReaction, len(detail.Reactions)),
+	}
+
+	if detail.Quoted != nil {
+		response.QuotedMessage = s.messageToDTO(detail.Quoted)
+	}
+
+	for i, reaction := range detail.Reactions {
+		response.Reactions[i] = contracts.MessageReaction{
+			ReactorJID: reaction.ReactorJID,
+			Emoji:      reaction.Emoji,
+			FromMe:     reaction.FromMe,
+			ReactedAt:  reaction.ReactedAt,
+		}
+	}
+
+	summaries := messaging.SummarizeReactions(detail.Reactions)
+	response.ReactionSummary = make([]contracts.ReactionSummary, len(summaries))
+	for i, summary := range summaries {
+		response.ReactionSummary[i] = contracts.ReactionSummary{
+			Emoji:    summary.Emoji,
+			Count:    summary.Count,
+			Reactors: summary.Reactors,
+		}
+	}
+
+	return response, nil
+}
+
+// SetStarred stars or unstars a message. Stored messages supply their own
+// chat and author; for anything else the request has to name the chat.
+func (s *MessageService) SetStarred(ctx context.Context, sessionID string, req *contracts.StarMessageRequest, starred bool) (*contracts.StarMessageResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if s.stars == nil {
+		return nil, fmt.Errorf("starring messages is not supported by this gateway")
+	}
+
+	id, name, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	star := &messaging.StarredMessage{
+		SessionID:   id,
+		ZpMessageID: req.MessageID,
+		ChatJID:     req.ChatJID,
+		SenderJID:   req.SenderJID,
+		FromMe:      req.FromMe,
+		StarredAt:   time.Now(),
+	}
+
+	stored, err := s.messagingCore.GetMessageByZpID(ctx, id, req.MessageID)
+	switch {
+	case err == nil:
+		star.ChatJID = stored.ZpChat
+		star.SenderJID = stored.ZpSender
+		star.FromMe = stored.ZpFromMe
+	case !errors.Is(err, shared.ErrNotFound):
+		return nil, err
+	case star.ChatJID == "":
+		return nil, fmt.Errorf("validation failed: chat_jid is required for messages that are not stored")
+	}
+
+	if err := s.stars.StarMessage(ctx, name, star, starred); err != nil {
+		return nil, err
+	}
+
+	if err := s.messagingCore.SetStarred(ctx, star, starred); err != nil {
+		return nil, fmt.Errorf("failed to save star: %w", err)
+	}
+
+	return &contracts.StarMessageResponse{
+		MessageID: star.ZpMessageID,
+		ChatJID:   star.ChatJID,
+		Starred:   starred,
+	}, nil
+}
+
+// SetPinned pins a message for everyone in its chat, for the requested
+// duration, or unpins it. Like stars, stored messages supply their own chat
+// and author.
+func (s *MessageService) SetPinned(ctx context.Context, sessionID string, req *contracts.PinMessageRequest, pinned bool) (*contracts.PinMessageResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if s.pins == nil {
+		return nil, fmt.Errorf("pinning messages is not supported by this gateway")
+	}
+
+	duration, err := messaging.ParsePinDuration(req.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, name, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	pin := &messaging.MessagePin{
+		ZpMessageID: req.MessageID,
+		ChatJID:     req.ChatJID,
+		SenderJID:   req.SenderJID,
+		FromMe:      req.FromMe,
+		Duration:    duration,
+	}
+
+	stored, err := s.messagingCore.GetMessageByZpID(ctx, id, req.MessageID)
+	switch {
+	case err == nil:
+		pin.ChatJID = stored.ZpChat
+		pin.SenderJID = stored.ZpSender
+		pin.FromMe = stored.ZpFromMe
+	case !errors.Is(err, shared.ErrNotFound):
+		return nil, err
+	case pin.ChatJID == "":
+		return nil, fmt.Errorf("validation failed: chat_jid is required for messages that are not stored")
+	}
+
+	if err := s.pins.PinMessage(ctx, name, pin, pinned); err != nil {
+		return nil, err
+	}
+
+	response := &contracts.PinMessageResponse{
+		MessageID: pin.ZpMessageID,
+		ChatJID:   pin.ChatJID,
+		Pinned:    pinned,
+	}
+	if pinned {
+		expiresAt := time.Now().Add(duration)
+		response.ExpiresAt = &expiresAt
+	}
+
+	return response, nil
+}
+
+func (s *MessageService) ListStarred(ctx context.Context, sessionID string, limit int, cursor string) (*contracts.ListStarredMessagesResponse, error) {
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, _, _, err := s.resolveSessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	stars, hasMore, err := s.messagingCore.ListStarred(ctx, id, pagination.Request{Limit: limit, After: after})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListStarredMessagesResponse{
+		Messages: make([]contracts.StarredMessage, len(stars)),
+		HasMore:  hasMore,
+	}
+
+	for i, star := range stars {
+		response.Messages[i] = contracts.StarredMessage{
+			MessageID: star.ZpMessageID,
+			ChatJID:   star.ChatJID,
+			SenderJID: star.SenderJID,
+			FromMe:    star.FromMe,
+			StarredAt: star.StarredAt,
+		}
+		if star.Message != nil {
+			response.Messages[i].Message = s.messageToDTO(star.Message)
+		}
+	}
+
+	if hasMore {
+		last := stars[len(stars)-1]
+		response.NextCursor = pagination.Encode(pagination.Cursor{
+			Time: last.StarredAt,
+			ID:   last.ID.String(),
+		})
+	}
+
+	return response, nil
+}
+
 func (s *MessageService) messageToDTO(message *messaging.Message) *contracts.MessageDTO {
-	return &contracts.MessageDTO{
+	dto := &contracts.MessageDTO{
 		ID:               message.ID.String(),
 		SessionID:        message.SessionID.String(),
 		ZpMessageID:      message.ZpMessageID,
@@ -473,7 +1372,30 @@ func (s *MessageService) messageToDTO(message *messaging.Message) *contracts.Mes
 		CwConversationID: message.CwConversationID,
 		SyncStatus:       message.SyncStatus,
 		SyncedAt:         message.SyncedAt,
+		EditedAt:         message.EditedAt,
+		RevokedAt:        message.RevokedAt,
 		CreatedAt:        message.CreatedAt,
 		UpdatedAt:        message.UpdatedAt,
+		QuotedMessageID:  message.QuotedMessageID,
+	}
+
+	if message.Media != nil {
+		dto.MediaType = message.ZpType
+		dto.Media = &contracts.MediaInfo{
+			MimeType:   message.Media.MimeType,
+			FileName:   message.Media.FileName,
+			FileSize:   message.Media.FileSize,
+			FileSHA256: message.Media.FileSHA256,
+			Duration:   message.Media.Duration,
+			Width:      message.Media.Width,
+			Height:     message.Media.Height,
+			Downloaded: message.Media.IsStored(),
+		}
+		if scan := message.Media.Scan; scan != nil {
+			dto.Media.ScanStatus = scan.Status
+			dto.Media.ScanSignature = scan.Signature
+		}
 	}
+
+	return dto
 }
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+)
+
+// InviteAdmin invites a user, by phone number or JID, to administer a
+// newsletter the session owns. The user becomes an admin by accepting it.
+func (s *NewsletterService) InviteAdmin(ctx context.Context, sessionID, newsletterJID string, req *contracts.NewsletterAdminRequest) (*contracts.NewsletterAdminResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, user, err := s.adminTarget(ctx, sessionID, newsletterJID, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := s.gateway.InviteNewsletterAdmin(ctx, resolved.Name, newsletterJID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAdminChange("Newsletter admin invited", resolved, newsletterJID, user)
+	return &contracts.NewsletterAdminResponse{NewsletterJID: newsletterJID, User: user, ExpiresAt: expiresAt}, nil
+}
+
+// RevokeAdminInvite withdraws an admin invite the user has not accepted.
+func (s *NewsletterService) RevokeAdminInvite(ctx context.Context, sessionID, newsletterJID, user string) (*contracts.NewsletterAdminResponse, error) {
+	resolved, user, err := s.adminTarget(ctx, sessionID, newsletterJID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gateway.RevokeNewsletterAdminInvite(ctx, resolved.Name, newsletterJID, user); err != nil {
+		return nil, err
+	}
+
+	s.logAdminChange("Newsletter admin invite revoked", resolved, newsletterJID, user)
+	return &contracts.NewsletterAdminResponse{NewsletterJID: newsletterJID, User: user}, nil
+}
+
+// AcceptAdminInvite accepts an invite for the session to administer a
+// newsletter.
+func (s *NewsletterService) AcceptAdminInvite(ctx context.Context, sessionID, newsletterJID string) (*contracts.NewsletterAdminResponse, error) {
+	resolved, _, err := s.adminTarget(ctx, sessionID, newsletterJID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gateway.AcceptNewsletterAdminInvite(ctx, resolved.Name, newsletterJID); err != nil {
+		return nil, err
+	}
+
+	s.logAdminChange("Newsletter admin invite accepted", resolved, newsletterJID, "")
+	return &contracts.NewsletterAdminResponse{NewsletterJID: newsletterJID}, nil
+}
+
+// DemoteAdmin removes an admin from a newsletter the session owns.
+func (s *NewsletterService) DemoteAdmin(ctx context.Context, sessionID, newsletterJID, user string) (*contracts.NewsletterAdminResponse, error) {
+	resolved, user, err := s.adminTarget(ctx, sessionID, newsletterJID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gateway.DemoteNewsletterAdmin(ctx, resolved.Name, newsletterJID, user); err != nil {
+		return nil, err
+	}
+
+	s.logAdminChange("Newsletter admin demoted", resolved, newsletterJID, user)
+	return &contracts.NewsletterAdminResponse{NewsletterJID: newsletterJID, User: user}, nil
+}
+
+// TransferOwnership hands a newsletter the session owns to one of its
+// admins. WhatsApp refuses users who are not admins yet.
+func (s *NewsletterService) TransferOwnership(ctx context.Context, sessionID, newsletterJID string, req *contracts.NewsletterAdminRequest) (*contracts.NewsletterAdminResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, user, err := s.adminTarget(ctx, sessionID, newsletterJID, req.User)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.gateway.ChangeNewsletterOwner(ctx, resolved.Name, newsletterJID, user); err != nil {
+		return nil, err
+	}
+
+	s.logAdminChange("Newsletter ownership transferred", resolved, newsletterJID, user)
+	return &contracts.NewsletterAdminResponse{NewsletterJID: newsletterJID, User: user}, nil
+}
+
+// adminTarget resolves the session for an administration change and reads
+// the user, when there is one, as a user JID.
+func (s *NewsletterService) adminTarget(ctx context.Context, sessionID, newsletterJID, user string) (*session.ResolveResult, string, error) {
+	if !strings.HasSuffix(newsletterJID, "@newsletter") {
+		return nil, "", fmt.Errorf("validation failed: %q is not a newsletter JID", newsletterJID)
+	}
+
+	var userJID string
+	if user != "" {
+		recipient, err := messaging.ParseRecipient(user, s.defaultCountry)
+		if err != nil {
+			return nil, "", err
+		}
+		if recipient.Phone == "" && !strings.HasSuffix(recipient.JID, "@lid") {
+			return nil, "", &messaging.RecipientError{Recipient: user, Err: messaging.ErrInvalidRecipient}
+		}
+		userJID = recipient.JID
+	}
+
+	if s.gateway == nil {
+		return nil, "", fmt.Errorf("newsletters are not supported by this gateway")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := resolved.Session.CheckBan(time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	return resolved, userJID, nil
+}
+
+func (s *NewsletterService) logAdminChange(message string, resolved *session.ResolveResult, newsletterJID, user string) {
+	s.logger.InfoWithFields(message, map[string]interface{}{
+		"session_id":     resolved.ID.String(),
+		"newsletter_jid": newsletterJID,
+		"user":           user,
+	})
+}
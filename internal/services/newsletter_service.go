@@ -1 +1,270 @@
 package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/schedule"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+type NewsletterService struct {
+	messagingCore *messaging.Service
+	resolver      session.SessionResolver
+	media         *MediaService
+	gateway       messaging.NewsletterGateway
+	scheduler     *schedule.Service
+	logger        *logger.Logger
+	validator     *validation.Validator
+
+	defaultCountry string
+}
+
+// newsletterPostPayload is what a scheduled newsletter post stores.
+type newsletterPostPayload struct {
+	NewsletterJID string `json:"newsletter_jid"`
+	contracts.PostToNewsletterRequest
+}
+
+func NewNewsletterService(
+	messagingCore *messaging.Service,
+	resolver session.SessionResolver,
+	media *MediaService,
+	gateway messaging.NewsletterGateway,
+	scheduler *schedule.Service,
+	defaultCountry string,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *NewsletterService {
+	return &NewsletterService{
+		messagingCore:  messagingCore,
+		resolver:       resolver,
+		media:          media,
+		gateway:        gateway,
+		scheduler:      scheduler,
+		logger:         logger,
+		validator:      validator,
+		defaultCountry: defaultCountry,
+	}
+}
+
+// ForwardMessage republishes a stored message in a newsletter the session
+// administers. Media is taken from the local copy, or downloaded from
+// WhatsApp first, and uploaded again for the newsletter. Captions of images
+// and videos are kept unless the request replaces them.
+func (s *NewsletterService) ForwardMessage(ctx context.Context, sessionID string, req *contracts.ForwardToNewsletterRequest) (*contracts.ForwardToNewsletterResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if s.gateway == nil {
+		return nil, fmt.Errorf("newsletters are not supported by this gateway")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolved.Session.CheckBan(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := resolved.Session.Settings.Policy.CheckSend(req.NewsletterJID); err != nil {
+		return nil, err
+	}
+
+	message, err := s.messagingCore.GetMessageByZpID(ctx, resolved.ID, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType := messaging.MessageType(message.ZpType)
+	if !messaging.CanPostToNewsletter(messageType) {
+		return nil, fmt.Errorf("validation failed: %s messages cannot be posted to a newsletter", message.ZpType)
+	}
+
+	post := &messaging.NewsletterPost{
+		NewsletterJID: req.NewsletterJID,
+		Type:          messageType,
+	}
+
+	// Audio and documents are stored with a placeholder as content, so
+	// only text bodies and image and video captions carry over.
+	switch messageType {
+	case messaging.MessageTypeText, messaging.MessageTypeImage, messaging.MessageTypeVideo:
+		post.Text = message.Content
+	}
+
+	if messageType != messaging.MessageTypeText {
+		if req.Caption != nil {
+			post.Text = *req.Caption
+		}
+
+		file, _, err := s.media.GetMessageMedia(ctx, resolved.ID.String(), req.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		post.Data = file.Data
+		post.MimeType = file.MimeType
+		post.FileName = file.FileName
+	}
+
+	result, err := s.gateway.PostToNewsletter(ctx, resolved.Name, post)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Message forwarded to newsletter", map[string]interface{}{
+		"session_id":     resolved.ID.String(),
+		"message_id":     req.MessageID,
+		"newsletter_jid": req.NewsletterJID,
+		"type":           message.ZpType,
+	})
+
+	return &contracts.ForwardToNewsletterResponse{
+		MessageID:       result.MessageID,
+		NewsletterJID:   req.NewsletterJID,
+		SourceMessageID: req.MessageID,
+		Type:            message.ZpType,
+		Timestamp:       result.Timestamp,
+	}, nil
+}
+
+// Post publishes a new text or media post in a newsletter the session owns or
+// administers. A post with send_at in the future is stored and published by
+// the scheduler at that time, and returned as scheduled instead.
+func (s *NewsletterService) Post(ctx context.Context, sessionID, newsletterJID string, req *contracts.PostToNewsletterRequest) (*contracts.NewsletterPostResponse, *contracts.ScheduledMessageResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if req.Type == "" {
+		req.Type = string(messaging.MessageTypeText)
+	}
+	if err := validateNewsletterPost(newsletterJID, req); err != nil {
+		return nil, nil, err
+	}
+
+	if s.gateway == nil {
+		return nil, nil, fmt.Errorf("newsletters are not supported by this gateway")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkNewsletterSend(resolved.Session, newsletterJID); err != nil {
+		return nil, nil, err
+	}
+
+	if req.SendAt != nil && req.SendAt.After(time.Now()) {
+		payload := &newsletterPostPayload{NewsletterJID: newsletterJID, PostToNewsletterRequest: *req}
+		message, err := s.scheduler.Schedule(ctx, resolved.ID, SendKindNewsletter, payload, *req.SendAt, scheduleReasonRequested)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		s.logger.InfoWithFields("Newsletter post scheduled", map[string]interface{}{
+			"session_id":     resolved.ID.String(),
+			"newsletter_jid": newsletterJID,
+			"type":           req.Type,
+			"send_at":        message.SendAt,
+		})
+
+		return nil, scheduledToDTO(message, resolved.Session.Settings.Location()), nil
+	}
+
+	response, err := s.publish(ctx, resolved.Name, newsletterJID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.InfoWithFields("Posted to newsletter", map[string]interface{}{
+		"session_id":     resolved.ID.String(),
+		"newsletter_jid": newsletterJID,
+		"type":           req.Type,
+		"message_id":     response.MessageID,
+	})
+
+	return response, nil, nil
+}
+
+// dispatchPost publishes a scheduled post, checking the session again since
+// it may have been banned or restricted meanwhile.
+func (s *NewsletterService) dispatchPost(ctx context.Context, sess *session.Session, payload json.RawMessage) (string, error) {
+	var post newsletterPostPayload
+	if err := json.Unmarshal(payload, &post); err != nil {
+		return "", fmt.Errorf("invalid scheduled payload: %w", err)
+	}
+
+	if s.gateway == nil {
+		return "", fmt.Errorf("newsletters are not supported by this gateway")
+	}
+	if err := checkNewsletterSend(sess, post.NewsletterJID); err != nil {
+		return "", err
+	}
+
+	response, err := s.publish(ctx, sess.Name, post.NewsletterJID, &post.PostToNewsletterRequest)
+	if err != nil {
+		return "", err
+	}
+	return response.MessageID, nil
+}
+
+func (s *NewsletterService) publish(ctx context.Context, sessionName, newsletterJID string, req *contracts.PostToNewsletterRequest) (*contracts.NewsletterPostResponse, error) {
+	post := &messaging.NewsletterPost{
+		NewsletterJID: newsletterJID,
+		Type:          messaging.MessageType(req.Type),
+		Text:          req.Text,
+		FileName:      req.Filename,
+		Source:        req.File,
+	}
+
+	result, err := s.gateway.PostToNewsletter(ctx, sessionName, post)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.NewsletterPostResponse{
+		MessageID:     result.MessageID,
+		NewsletterJID: newsletterJID,
+		Type:          req.Type,
+		Timestamp:     result.Timestamp,
+	}, nil
+}
+
+func validateNewsletterPost(newsletterJID string, req *contracts.PostToNewsletterRequest) error {
+	if !strings.HasSuffix(newsletterJID, "@newsletter") {
+		return fmt.Errorf("validation failed: %q is not a newsletter JID", newsletterJID)
+	}
+
+	switch messaging.MessageType(req.Type) {
+	case messaging.MessageTypeText:
+		if strings.TrimSpace(req.Text) == "" {
+			return fmt.Errorf("validation failed: text posts need text")
+		}
+	case messaging.MessageTypeAudio, messaging.MessageTypeSticker:
+		if req.Text != "" {
+			return fmt.Errorf("validation failed: %s posts cannot have text", req.Type)
+		}
+		fallthrough
+	default:
+		if req.File == "" {
+			return fmt.Errorf("validation failed: %s posts need a file", req.Type)
+		}
+	}
+
+	return nil
+}
+
+func checkNewsletterSend(sess *session.Session, newsletterJID string) error {
+	if err := sess.CheckBan(time.Now()); err != nil {
+		return err
+	}
+	return sess.Settings.Policy.CheckSend(newsletterJID)
+}
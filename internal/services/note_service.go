@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/note"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// NoteService manages private notes and drafts on chats. Nothing here
+// reaches WhatsApp; the notes exist so people sharing a session through the
+// API can leave context for each other.
+type NoteService struct {
+	core      *note.Service
+	resolver  session.SessionResolver
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewNoteService(
+	core *note.Service,
+	resolver session.SessionResolver,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *NoteService {
+	return &NoteService{
+		core:      core,
+		resolver:  resolver,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (s *NoteService) ListNotes(ctx context.Context, sessionID, chatJID, kind string) (*contracts.ListNotesResponse, error) {
+	if chatJID == "" {
+		return nil, fmt.Errorf("validation failed: chat_jid is required")
+	}
+	if kind != "" && !note.Kind(kind).IsValid() {
+		return nil, fmt.Errorf("validation failed: kind must be note or draft")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := s.core.List(ctx, &note.ListFilter{
+		SessionID: resolved.ID,
+		ChatJID:   chatJID,
+		Kind:      note.Kind(kind),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.ListNotesResponse{
+		ChatJID: chatJID,
+		Notes:   notesToDTO(notes),
+		Total:   len(notes),
+	}, nil
+}
+
+func (s *NoteService) CreateNote(ctx context.Context, sessionID string, req *contracts.CreateNoteRequest) (*contracts.NoteResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.core.Create(ctx, resolved.ID, req.ChatJID, note.Kind(req.Kind), req.Author, req.Body)
+	if err != nil {
+		return nil, noteError(err)
+	}
+
+	return noteToDTO(n), nil
+}
+
+func (s *NoteService) UpdateNote(ctx context.Context, sessionID, noteID string, req *contracts.UpdateNoteRequest) (*contracts.NoteResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, err := uuid.Parse(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid note ID")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kind *note.Kind
+	if req.Kind != nil {
+		k := note.Kind(*req.Kind)
+		kind = &k
+	}
+
+	n, err := s.core.Update(ctx, resolved.ID, id, kind, req.Body)
+	if err != nil {
+		return nil, noteError(err)
+	}
+
+	return noteToDTO(n), nil
+}
+
+func (s *NoteService) DeleteNote(ctx context.Context, sessionID, noteID string) error {
+	id, err := uuid.Parse(noteID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid note ID")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.core.Delete(ctx, resolved.ID, id)
+}
+
+func noteError(err error) error {
+	if errors.Is(err, note.ErrInvalidNote) {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return err
+}
+
+func noteToDTO(n *note.Note) *contracts.NoteResponse {
+	return &contracts.NoteResponse{
+		ID:        n.ID.String(),
+		ChatJID:   n.ChatJID,
+		Kind:      string(n.Kind),
+		Author:    n.Author,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+}
+
+func notesToDTO(notes []*note.Note) []contracts.NoteResponse {
+	dtos := make([]contracts.NoteResponse, len(notes))
+	for i, n := range notes {
+		dtos[i] = *noteToDTO(n)
+	}
+	return dtos
+}
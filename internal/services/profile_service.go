@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// ProfileService manages the profile of the session's own account.
+type ProfileService struct {
+	resolver  session.SessionResolver
+	business  contact.BusinessProfileEditor
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewProfileService(
+	resolver session.SessionResolver,
+	business contact.BusinessProfileEditor,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *ProfileService {
+	return &ProfileService{
+		resolver:  resolver,
+		business:  business,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (s *ProfileService) GetBusinessProfile(ctx context.Context, sessionID string) (*contracts.OwnBusinessProfileResponse, error) {
+	if s.business == nil {
+		return nil, fmt.Errorf("business profiles are not supported by this gateway")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.business.GetOwnBusinessProfile(ctx, resolved.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return businessProfileToDTO(profile), nil
+}
+
+// UpdateBusinessProfile edits the fields present in the request and returns
+// the profile as WhatsApp has it afterwards.
+func (s *ProfileService) UpdateBusinessProfile(ctx context.Context, sessionID string, req *contracts.UpdateBusinessProfileRequest) (*contracts.OwnBusinessProfileResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if s.business == nil {
+		return nil, fmt.Errorf("business profiles are not supported by this gateway")
+	}
+
+	update := &contact.BusinessProfileUpdate{
+		Description: req.Description,
+		Address:     req.Address,
+		Email:       req.Email,
+		Websites:    req.Websites,
+		Categories:  req.Categories,
+	}
+	if req.BusinessHours != nil {
+		hours, err := businessHoursFromDTO(req.BusinessHours)
+		if err != nil {
+			return nil, err
+		}
+		update.Hours = hours
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.business.UpdateBusinessProfile(ctx, resolved.Name, update); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.business.GetOwnBusinessProfile(ctx, resolved.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return businessProfileToDTO(profile), nil
+}
+
+func businessHoursFromDTO(dto *contracts.BusinessHours) (*contact.BusinessHours, error) {
+	hours := &contact.BusinessHours{
+		Timezone: dto.Timezone,
+		Days:     make([]contact.BusinessHoursDay, len(dto.Days)),
+	}
+
+	for i, day := range dto.Days {
+		hours.Days[i] = contact.BusinessHoursDay{Day: day.Day, Mode: day.Mode}
+		if day.Mode != contact.HoursSpecific {
+			continue
+		}
+
+		open, err := minutesOfDay(day.OpenTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s open_time: %v", contact.ErrInvalidBusiness, day.Day, err)
+		}
+		closing, err := minutesOfDay(day.CloseTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s close_time: %v", contact.ErrInvalidBusiness, day.Day, err)
+		}
+		hours.Days[i].Open = open
+		hours.Days[i].Close = closing
+	}
+
+	return hours, nil
+}
+
+func minutesOfDay(value string) (int, error) {
+	if value == "" {
+		return 0, fmt.Errorf("required for specific_hours")
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("must be HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func businessProfileToDTO(profile *contact.OwnBusinessProfile) *contracts.OwnBusinessProfileResponse {
+	response := &contracts.OwnBusinessProfileResponse{
+		JID:         profile.JID,
+		Description: profile.Description,
+		Address:     profile.Address,
+		Email:       profile.Email,
+		Websites:    profile.Websites,
+		Categories:  make([]contracts.BusinessCategory, len(profile.Categories)),
+	}
+	if response.Websites == nil {
+		response.Websites = []string{}
+	}
+
+	for i, category := range profile.Categories {
+		response.Categories[i] = contracts.BusinessCategory{ID: category.ID, Name: category.Name}
+	}
+
+	if profile.Hours != nil {
+		hours := &contracts.BusinessHours{
+			Timezone: profile.Hours.Timezone,
+			Days:     make([]contracts.BusinessHoursDay, len(profile.Hours.Days)),
+		}
+		for i, day := range profile.Hours.Days {
+			hours.Days[i] = contracts.BusinessHoursDay{Day: day.Day, Mode: day.Mode}
+			if day.Mode == contact.HoursSpecific {
+				hours.Days[i].OpenTime = fmt.Sprintf("%02d:%02d", day.Open/60, day.Open%60)
+				hours.Days[i].CloseTime = fmt.Sprintf("%02d:%02d", day.Close/60, day.Close%60)
+			}
+		}
+		response.BusinessHours = hours
+	}
+
+	return response
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+)
+
+const (
+	bulkSessionCreated = "created"
+	bulkSessionFailed  = "failed"
+)
+
+// SetWebhooks lets bulk creation configure each new session's webhook.
+func (s *SessionService) SetWebhooks(webhooks *WebhookService) {
+	s.webhooks = webhooks
+}
+
+// BulkCreateSessions creates the sessions in order and reports each one.
+// A session's webhook is saved before it connects, so connection events
+// already reach it; a failing webhook leaves the session created.
+func (s *SessionService) BulkCreateSessions(ctx context.Context, req *contracts.BulkCreateSessionsRequest) (*contracts.BulkCreateSessionsResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	for _, def := range req.Sessions {
+		if def.Webhook != nil && s.webhooks == nil {
+			return nil, fmt.Errorf("validation failed: webhooks are not available")
+		}
+	}
+
+	s.logger.InfoWithFields("Creating sessions in bulk", map[string]interface{}{
+		"count":   len(req.Sessions),
+		"connect": req.Connect,
+	})
+
+	response := &contracts.BulkCreateSessionsResponse{
+		Total:   len(req.Sessions),
+		Results: make([]contracts.BulkSessionResult, 0, len(req.Sessions)),
+	}
+
+	for _, def := range req.Sessions {
+		result := s.createBulkSession(ctx, def, req.Connect)
+		if result.Status == bulkSessionCreated {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	s.logger.InfoWithFields("Bulk session creation finished", map[string]interface{}{
+		"created": response.Created,
+		"failed":  response.Failed,
+	})
+
+	return response, nil
+}
+
+func (s *SessionService) createBulkSession(ctx context.Context, def contracts.BulkSessionDefinition, connect bool) contracts.BulkSessionResult {
+	result := contracts.BulkSessionResult{Name: def.Name, Status: bulkSessionFailed}
+
+	tenantID, err := s.tenantForNewSession(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	coreReq := &session.CreateSessionRequest{
+		Name:     def.Name,
+		TenantID: tenantID,
+	}
+
+	if def.ProxyConfig != nil {
+		coreReq.ProxyConfig = &session.ProxyConfig{
+			Type:     def.ProxyConfig.Type,
+			Host:     def.ProxyConfig.Host,
+			Port:     def.ProxyConfig.Port,
+			Username: def.ProxyConfig.Username,
+			Password: def.ProxyConfig.Password,
+		}
+	}
+
+	sess, err := s.coreService.CreateSession(ctx, coreReq)
+	if err != nil {
+		s.logger.WarnWithFields("Failed to create session in bulk", map[string]interface{}{
+			"name":  def.Name,
+			"error": err.Error(),
+		})
+		result.Error = err.Error()
+		return result
+	}
+
+	result.ID = sess.ID.String()
+	result.Status = bulkSessionCreated
+
+	if def.Webhook != nil {
+		wh, err := s.webhooks.SetWebhook(ctx, sess.ID.String(), def.Webhook)
+		if err != nil {
+			s.logger.WarnWithFields("Failed to set webhook for bulk session", map[string]interface{}{
+				"session_id": sess.ID.String(),
+				"error":      err.Error(),
+			})
+			result.WebhookError = err.Error()
+		} else {
+			result.WebhookID = wh.ID
+		}
+	}
+
+	if connect {
+		if err := s.coreService.ConnectSession(ctx, sess.ID); err != nil {
+			s.logger.WarnWithFields("Failed to connect bulk session", map[string]interface{}{
+				"session_id": sess.ID.String(),
+				"error":      err.Error(),
+			})
+			result.Error = fmt.Sprintf("created but failed to connect: %v", err)
+		} else {
+			result.Connecting = true
+		}
+	}
+
+	return result
+}
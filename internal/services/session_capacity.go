@@ -0,0 +1,36 @@
+package services
+
+import (
+	"runtime"
+
+	"zpwoot/internal/adapters/server/contracts"
+)
+
+// GetCapacity reports the sessions this instance runs against its limits,
+// with the process's goroutines and heap, so an autoscaler can tell when to
+// add an instance before connects start failing.
+func (s *SessionService) GetCapacity() *contracts.CapacityResponse {
+	capacity := s.gateway.Capacity()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	response := &contracts.CapacityResponse{
+		MaxSessions:       capacity.MaxSessions,
+		ConnectedSessions: capacity.Connected,
+		LoadedSessions:    capacity.Loaded,
+		AvailableSessions: capacity.Available(),
+		Saturated:         capacity.Saturated(),
+		MaxPendingEvents:  capacity.MaxPendingEvents,
+		Goroutines:        runtime.NumGoroutine(),
+		HeapAllocBytes:    mem.HeapAlloc,
+		Sessions:          make([]contracts.SessionLoadStats, len(capacity.Sessions)),
+	}
+	for i, load := range capacity.Sessions {
+		response.Sessions[i] = contracts.SessionLoadStats{
+			Session:       load.Name,
+			PendingEvents: load.PendingEvents,
+		}
+	}
+	return response
+}
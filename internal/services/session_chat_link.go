@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/session"
+)
+
+// SetChatLinks lets chat links point at numbers other than the session's,
+// read with defaultCountry and checked through numbers.
+func (s *SessionService) SetChatLinks(numbers *contact.NumberChecker, defaultCountry string) {
+	s.numbers = numbers
+	s.defaultCountry = defaultCountry
+}
+
+// GetChatLink builds the wa.me link that opens a chat with the session's
+// number, or with req.Phone, prefilled with req.Text. Other numbers must be
+// on WhatsApp; a link to a number that is not only opens an error page.
+func (s *SessionService) GetChatLink(ctx context.Context, sessionID string, req *contracts.ChatLinkRequest) (*contracts.ChatLinkResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := s.chatLinkPhone(ctx, resolved, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.ChatLinkResponse{
+		Phone: phone,
+		Text:  req.Text,
+		URL:   messaging.ChatLink(phone, req.Text),
+	}, nil
+}
+
+// RenderChatLinkQR renders the link GetChatLink builds as a QR code, for
+// websites and print.
+func (s *SessionService) RenderChatLinkQR(ctx context.Context, sessionID, format string, req *contracts.ChatLinkRequest, image *contracts.QRImageRequest) ([]byte, error) {
+	opts, err := s.qrImageOptions(image)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.GetChatLink(ctx, sessionID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderQR(link.URL, format, opts)
+}
+
+func (s *SessionService) chatLinkPhone(ctx context.Context, resolved *session.ResolveResult, raw string) (string, error) {
+	if raw == "" {
+		if resolved.Session.DeviceJID == nil {
+			return "", fmt.Errorf("validation failed: session has no number until it is paired, pass phone instead")
+		}
+		phone, ok := contact.NormalizeNumber(*resolved.Session.DeviceJID)
+		if !ok {
+			return "", fmt.Errorf("session number %q is not a phone number", *resolved.Session.DeviceJID)
+		}
+		return phone, nil
+	}
+
+	phone, ok := messaging.ParsePhone(raw, s.defaultCountry)
+	if !ok {
+		return "", &messaging.RecipientError{Recipient: raw, Err: messaging.ErrInvalidRecipient}
+	}
+	if s.numbers == nil {
+		return phone, nil
+	}
+
+	checks, err := s.numbers.Check(ctx, resolved.Name, []string{phone}, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to check number: %w", err)
+	}
+	if check, ok := checks[phone]; ok && !check.OnWhatsApp {
+		return "", &messaging.RecipientError{Recipient: raw, Err: messaging.ErrRecipientNotOnWhatsApp}
+	}
+
+	return phone, nil
+}
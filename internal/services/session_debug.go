@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+)
+
+// SetProtocolDebug lets the service capture a single session's whatsmeow
+// logs.
+func (s *SessionService) SetProtocolDebug(debug session.ProtocolDebugger) {
+	s.debug = debug
+}
+
+// StartDebugCapture starts capturing the session's whatsmeow logs in memory,
+// replacing any capture already running for it.
+func (s *SessionService) StartDebugCapture(ctx context.Context, sessionID string, req *contracts.StartDebugCaptureRequest) (*contracts.DebugCaptureResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	sess, err := s.debugSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := session.DebugCaptureOptions{
+		Level:    req.Level,
+		Capacity: req.Capacity,
+		Duration: time.Duration(req.Minutes) * time.Minute,
+	}
+	if err := options.Normalize(); err != nil {
+		return nil, err
+	}
+
+	capture := s.debug.StartCapture(sess.Name, options)
+
+	s.logger.InfoWithFields("Protocol debug capture started", map[string]interface{}{
+		"session_id": sessionID,
+		"level":      capture.Level,
+		"capacity":   capture.Capacity,
+		"expires_at": capture.ExpiresAt,
+	})
+
+	return debugCaptureToDTO(sessionID, capture), nil
+}
+
+// StopDebugCapture stops the session's capture and discards what it kept.
+func (s *SessionService) StopDebugCapture(ctx context.Context, sessionID string) (*contracts.DebugCaptureResponse, error) {
+	sess, err := s.debugSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	capture, ok := s.debug.StopCapture(sess.Name)
+	if !ok {
+		return nil, session.ErrNoDebugCapture
+	}
+
+	s.logger.InfoWithFields("Protocol debug capture stopped", map[string]interface{}{
+		"session_id": sessionID,
+		"entries":    capture.Entries,
+	})
+
+	capture.Active = false
+	return debugCaptureToDTO(sessionID, capture), nil
+}
+
+// GetDebugLogs returns what the session's capture kept, oldest first. Level
+// filters out lines below it; limit keeps only the most recent ones.
+func (s *SessionService) GetDebugLogs(ctx context.Context, sessionID, level string, limit int) (*contracts.DebugLogsResponse, error) {
+	if level != "" {
+		options := session.DebugCaptureOptions{Level: level}
+		if err := options.Normalize(); err != nil {
+			return nil, err
+		}
+	}
+
+	sess, err := s.debugSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	capture, entries, ok := s.debug.CaptureLogs(sess.Name, level, limit)
+	if !ok {
+		return nil, session.ErrNoDebugCapture
+	}
+
+	response := &contracts.DebugLogsResponse{
+		Capture: *debugCaptureToDTO(sessionID, capture),
+		Logs:    make([]contracts.DebugLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Logs[i] = contracts.DebugLogEntry{
+			Time:    entry.Time,
+			Level:   entry.Level,
+			Module:  entry.Module,
+			Message: entry.Message,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *SessionService) debugSession(ctx context.Context, sessionID string) (*session.Session, error) {
+	if s.debug == nil {
+		return nil, fmt.Errorf("protocol debug capture is not available")
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	return s.coreService.GetSession(ctx, id)
+}
+
+func debugCaptureToDTO(sessionID string, capture *session.DebugCapture) *contracts.DebugCaptureResponse {
+	return &contracts.DebugCaptureResponse{
+		SessionID: sessionID,
+		Level:     capture.Level,
+		Capacity:  capture.Capacity,
+		Active:    capture.Active,
+		Entries:   capture.Entries,
+		Dropped:   capture.Dropped,
+		StartedAt: capture.StartedAt,
+		ExpiresAt: capture.ExpiresAt,
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+)
+
+// SetDevices lets the service list the devices linked to each session's
+// account.
+func (s *SessionService) SetDevices(devices *session.DeviceTracker) {
+	s.devices = devices
+}
+
+// ListDevices lists the phone and companion devices registered under the
+// session's WhatsApp account.
+func (s *SessionService) ListDevices(ctx context.Context, sessionID string) (*contracts.DeviceListResponse, error) {
+	if s.devices == nil {
+		return nil, fmt.Errorf("device listing is not available")
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	sess, err := s.coreService.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !sess.IsConnected {
+		return nil, session.ErrSessionNotConnected
+	}
+
+	devices, err := s.devices.List(ctx, id, sess.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.DeviceListResponse{
+		SessionID: sessionID,
+		Devices:   make([]contracts.DeviceInfo, len(devices)),
+	}
+	for i, device := range devices {
+		response.Devices[i] = contracts.DeviceInfo{
+			ID:          device.ID,
+			JID:         device.JID,
+			Platform:    device.Platform,
+			Primary:     device.Primary,
+			Current:     device.Current,
+			FirstSeenAt: device.FirstSeenAt,
+			LastSeenAt:  device.LastSeenAt,
+		}
+	}
+
+	return response, nil
+}
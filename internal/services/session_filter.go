@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/shared/pagination"
+)
+
+// SetFilterLog lets the service list the messages the inbound filter
+// matched.
+func (s *SessionService) SetFilterLog(log session.FilterLog) {
+	s.filterLog = log
+}
+
+// SetInboundFilter replaces the session's inbound filter rules; nil
+// settings remove them all.
+func (s *SessionService) SetInboundFilter(ctx context.Context, sessionID string, req *contracts.InboundFilterSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	var settings session.InboundFilterSettings
+	if req != nil {
+		if err := s.validator.ValidateStruct(req); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+		settings = inboundFilterFromDTO(req)
+	}
+
+	s.logger.InfoWithFields("Updating inbound filter", map[string]interface{}{
+		"session_id": sessionID,
+		"rules":      len(settings.Rules),
+		"groups":     settings.Groups,
+	})
+
+	if err := s.coreService.SetInboundFilter(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update inbound filter", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set inbound filter: %w", err)
+	}
+
+	return nil
+}
+
+// ListFilteredMessages lists the messages the session's inbound filter
+// matched, newest first.
+func (s *SessionService) ListFilteredMessages(ctx context.Context, sessionID string, limit int, cursor string) (*contracts.FilteredMessageListResponse, error) {
+	if s.filterLog == nil {
+		return nil, fmt.Errorf("filter log is not available")
+	}
+
+	after, err := pagination.Decode(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	limit = pagination.ClampLimit(limit)
+	messages, err := s.filterLog.List(ctx, id, pagination.Request{Limit: limit + 1, After: after})
+	if err != nil {
+		return nil, err
+	}
+	messages, hasMore := pagination.Trim(messages, limit)
+
+	response := &contracts.FilteredMessageListResponse{
+		Messages: make([]contracts.FilteredMessage, len(messages)),
+		HasMore:  hasMore,
+	}
+	for i, message := range messages {
+		response.Messages[i] = contracts.FilteredMessage{
+			MessageID:  message.MessageID,
+			ChatJID:    message.ChatJID,
+			SenderJID:  message.SenderJID,
+			Text:       message.Text,
+			Rule:       message.Match.Rule,
+			Conditions: message.Match.Conditions,
+			Keyword:    message.Match.Keyword,
+			Actions:    filterActionsToDTO(message.Match.Actions),
+			FilteredAt: message.CreatedAt,
+		}
+		if len(message.ActionErrors) > 0 {
+			response.Messages[i].ActionErrors = make(map[string]string, len(message.ActionErrors))
+			for action, reason := range message.ActionErrors {
+				response.Messages[i].ActionErrors[string(action)] = reason
+			}
+		}
+	}
+
+	if hasMore {
+		last := messages[len(messages)-1]
+		response.NextCursor = pagination.Encode(pagination.Cursor{
+			Time: last.CreatedAt,
+			ID:   last.ID.String(),
+		})
+	}
+
+	return response, nil
+}
+
+func inboundFilterFromDTO(req *contracts.InboundFilterSettings) session.InboundFilterSettings {
+	settings := session.InboundFilterSettings{Groups: req.Groups}
+	for _, rule := range req.Rules {
+		actions := make([]session.FilterAction, len(rule.Actions))
+		for i, action := range rule.Actions {
+			actions[i] = session.FilterAction(action)
+		}
+		settings.Rules = append(settings.Rules, session.FilterRule{
+			Name:     rule.Name,
+			Keywords: rule.Keywords,
+			Pattern:  rule.Pattern,
+			Senders:  rule.Senders,
+			LinkOnly: rule.LinkOnly,
+			Actions:  actions,
+			Reply:    rule.Reply,
+		})
+	}
+	return settings
+}
+
+func inboundFilterToDTO(settings session.InboundFilterSettings) contracts.InboundFilterSettings {
+	dto := contracts.InboundFilterSettings{
+		Rules:  make([]contracts.FilterRule, len(settings.Rules)),
+		Groups: settings.Groups,
+	}
+	for i, rule := range settings.Rules {
+		dto.Rules[i] = contracts.FilterRule{
+			Name:     rule.Name,
+			Keywords: rule.Keywords,
+			Pattern:  rule.Pattern,
+			Senders:  rule.Senders,
+			LinkOnly: rule.LinkOnly,
+			Actions:  filterActionsToDTO(rule.Actions),
+			Reply:    rule.Reply,
+		}
+	}
+	return dto
+}
+
+func filterActionsToDTO(actions []session.FilterAction) []string {
+	dto := make([]string, len(actions))
+	for i, action := range actions {
+		dto[i] = string(action)
+	}
+	return dto
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"zpwoot/internal/core/session"
+)
+
+// restorePageSize matches the largest page the core session listing returns.
+const restorePageSize = 100
+
+// ReconnectOptions controls how sessions with stored credentials are
+// reconnected when the process starts.
+type ReconnectOptions struct {
+	// MaxSessions caps how many sessions are reconnected; 0 means all.
+	MaxSessions int
+	// Workers is how many sessions connect at the same time.
+	Workers int
+	// Spacing is the pause between starting two connections.
+	Spacing time.Duration
+	// Timeout bounds how long RestoreAllSessions waits for the
+	// reconnections. Sessions still pending keep reconnecting in the
+	// background afterwards.
+	Timeout time.Duration
+}
+
+// RestoreAllSessions loads every stored session into the gateway and
+// reconnects the ones that are paired. It returns once all reconnections
+// finish or opts.Timeout elapses, whichever comes first; in the latter case
+// the remaining sessions continue in the background until ctx is done.
+func (s *SessionService) RestoreAllSessions(ctx context.Context, opts ReconnectOptions) error {
+	s.logger.Info("Starting session restoration process")
+
+	sessions, err := s.listAllSessions(ctx)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to get sessions for restoration", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	if len(sessions) == 0 {
+		s.logger.Info("No sessions found to restore")
+		return nil
+	}
+
+	for _, sess := range sessions {
+		s.gateway.RegisterSessionUUID(sess.Name, sess.ID.String())
+		s.gateway.ApplySettings(sess.Name, sess.Settings)
+	}
+
+	sessionNames := make([]string, len(sessions))
+	for i, sess := range sessions {
+		sessionNames[i] = sess.Name
+	}
+
+	reconnectable, err := s.gateway.RestoreAllSessions(ctx, sessionNames)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to restore sessions in gateway", map[string]interface{}{
+			"session_count": len(sessionNames),
+			"error":         err.Error(),
+		})
+		return fmt.Errorf("failed to restore sessions: %w", err)
+	}
+
+	s.logger.InfoWithFields("Session restoration completed successfully", map[string]interface{}{
+		"restored_sessions": len(sessionNames),
+		"reconnectable":     len(reconnectable),
+	})
+
+	if opts.MaxSessions > 0 && len(reconnectable) > opts.MaxSessions {
+		s.logger.WarnWithFields("Startup reconnect limit reached, remaining sessions stay disconnected", map[string]interface{}{
+			"limit":   opts.MaxSessions,
+			"skipped": len(reconnectable) - opts.MaxSessions,
+		})
+		reconnectable = reconnectable[:opts.MaxSessions]
+	}
+
+	s.reconnectSessions(ctx, reconnectable, opts)
+	return nil
+}
+
+func (s *SessionService) listAllSessions(ctx context.Context) ([]*session.Session, error) {
+	var all []*session.Session
+	for offset := 0; ; offset += restorePageSize {
+		page, err := s.coreService.ListSessions(ctx, restorePageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < restorePageSize {
+			return all, nil
+		}
+	}
+}
+
+// reconnectSessions connects the named sessions with a pool of
+// opts.Workers goroutines, starting one connection every opts.Spacing.
+func (s *SessionService) reconnectSessions(ctx context.Context, sessionNames []string, opts ReconnectOptions) {
+	if len(sessionNames) == 0 {
+		return
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(sessionNames) {
+		workers = len(sessionNames)
+	}
+
+	s.logger.InfoWithFields("Reconnecting sessions", map[string]interface{}{
+		"sessions": len(sessionNames),
+		"workers":  workers,
+		"spacing":  opts.Spacing.String(),
+		"timeout":  opts.Timeout.String(),
+	})
+
+	started := time.Now()
+	var connected, failed int64
+	queue := make(chan string)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range queue {
+				if err := s.gateway.ConnectSession(ctx, name); err != nil {
+					atomic.AddInt64(&failed, 1)
+					s.logger.WarnWithFields("Failed to auto-connect restored session", map[string]interface{}{
+						"session_name": name,
+						"error":        err.Error(),
+					})
+					continue
+				}
+				atomic.AddInt64(&connected, 1)
+				s.logger.InfoWithFields("Auto-connected restored session", map[string]interface{}{
+					"session_name": name,
+				})
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+		for i, name := range sessionNames {
+			if i > 0 && opts.Spacing > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(opts.Spacing):
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- name:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	summary := func() map[string]interface{} {
+		ok := atomic.LoadInt64(&connected)
+		ko := atomic.LoadInt64(&failed)
+		return map[string]interface{}{
+			"total":     len(sessionNames),
+			"connected": ok,
+			"failed":    ko,
+			"pending":   int64(len(sessionNames)) - ok - ko,
+			"elapsed":   time.Since(started).String(),
+		}
+	}
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-done:
+		s.logger.InfoWithFields("Startup reconnect completed", summary())
+	case <-timeout:
+		s.logger.WarnWithFields("Startup reconnect timeout reached, continuing in background", summary())
+		go func() {
+			<-done
+			s.logger.InfoWithFields("Background reconnect completed", summary())
+		}()
+	}
+}
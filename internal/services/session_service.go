@@ -3,12 +3,19 @@ package services
 import (
 	"context"
 	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/contact"
 	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/shared/pagination"
+	"zpwoot/internal/core/tenant"
 	"zpwoot/internal/services/shared/validation"
 	"zpwoot/platform/logger"
 )
@@ -20,6 +27,14 @@ type SessionService struct {
 	repository session.Repository
 	gateway    session.WhatsAppGateway
 	qrGen      session.QRCodeGenerator
+	tenants    *tenant.Service
+	webhooks   *WebhookService
+	devices    *session.DeviceTracker
+	debug      session.ProtocolDebugger
+	filterLog  session.FilterLog
+
+	numbers        *contact.NumberChecker
+	defaultCountry string
 
 	logger    *logger.Logger
 	validator *validation.Validator
@@ -31,6 +46,7 @@ func NewSessionService(
 	repository session.Repository,
 	gateway session.WhatsAppGateway,
 	qrGen session.QRCodeGenerator,
+	tenants *tenant.Service,
 	logger *logger.Logger,
 	validator *validation.Validator,
 ) *SessionService {
@@ -40,6 +56,7 @@ func NewSessionService(
 		repository:  repository,
 		gateway:     gateway,
 		qrGen:       qrGen,
+		tenants:     tenants,
 		logger:      logger,
 		validator:   validator,
 	}
@@ -60,9 +77,15 @@ func (s *SessionService) CreateSession(ctx context.Context, req *contracts.Creat
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	tenantID, err := s.tenantForNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	coreReq := &session.CreateSessionRequest{
 		Name:        req.Name,
 		AutoConnect: req.QRCode,
+		TenantID:    tenantID,
 	}
 
 	if req.ProxyConfig != nil {
@@ -147,52 +170,34 @@ func (s *SessionService) GetSession(ctx context.Context, sessionID string) (*con
 		Session: s.sessionToDTO(sess),
 	}
 
-	return response, nil
-}
-
-func (s *SessionService) ResolveSessionID(ctx context.Context, idOrName string) (uuid.UUID, error) {
-	return s.resolver.ResolveToID(ctx, idOrName)
-}
-
-func (s *SessionService) RestoreAllSessions(ctx context.Context) error {
-	s.logger.Info("Starting session restoration process")
-
-	sessions, err := s.coreService.ListSessions(ctx, 1000, 0)
-	if err != nil {
-		s.logger.ErrorWithFields("Failed to get sessions for restoration", map[string]interface{}{
-			"error": err.Error(),
-		})
-		return fmt.Errorf("failed to get sessions: %w", err)
-	}
-
-	if len(sessions) == 0 {
-		s.logger.Info("No sessions found to restore")
-		return nil
-	}
-
-	for _, sess := range sessions {
-		s.gateway.RegisterSessionUUID(sess.Name, sess.ID.String())
+	if info, err := s.coreService.DeviceInfo(ctx, sess); err == nil {
+		response.DeviceInfo = deviceInfoToDTO(info)
 	}
 
-	sessionNames := make([]string, len(sessions))
-	for i, sess := range sessions {
-		sessionNames[i] = sess.Name
-	}
+	return response, nil
+}
 
-	err = s.gateway.RestoreAllSessions(ctx, sessionNames)
-	if err != nil {
-		s.logger.ErrorWithFields("Failed to restore sessions in gateway", map[string]interface{}{
-			"session_count": len(sessionNames),
-			"error":         err.Error(),
-		})
-		return fmt.Errorf("failed to restore sessions: %w", err)
+func deviceInfoToDTO(info *session.DeviceInfo) *contracts.DeviceInfoResponse {
+	return &contracts.DeviceInfoResponse{
+		JID:          info.JID,
+		LID:          info.LID,
+		PushName:     info.PushName,
+		BusinessName: info.BusinessName,
+		Platform:     info.Platform,
+		IsBusiness:   info.IsBusiness(),
+		WAVersion:    info.WhatsAppVersion,
+		Connection: contracts.ConnectionInfoResponse{
+			State:             info.Connection.State,
+			ConnectedAt:       info.Connection.ConnectedAt,
+			LastActivityAt:    info.Connection.LastActivityAt,
+			Reconnects:        info.Connection.Reconnects,
+			KeepAliveFailures: info.Connection.KeepAliveFailures,
+		},
 	}
+}
 
-	s.logger.InfoWithFields("Session restoration completed successfully", map[string]interface{}{
-		"restored_sessions": len(sessionNames),
-	})
-
-	return nil
+func (s *SessionService) ResolveSessionID(ctx context.Context, idOrName string) (uuid.UUID, error) {
+	return s.resolver.ResolveToID(ctx, idOrName)
 }
 
 func (s *SessionService) DeleteSessionByNameOrID(ctx context.Context, idOrName string) error {
@@ -233,20 +238,30 @@ func (s *SessionService) ListSessions(ctx context.Context, req *contracts.ListSe
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	limit := req.Limit
-	if limit == 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
+	after, err := pagination.Decode(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	offset := req.Offset
-	if offset < 0 {
+	limit := pagination.ClampLimit(req.Limit)
+	offset := max(req.Offset, 0)
+	if after != nil {
 		offset = 0
 	}
 
-	sessions, err := s.coreService.ListSessions(ctx, limit, offset)
+	page := pagination.Request{
+		Limit:  limit,
+		After:  after,
+		Offset: offset,
+	}
+
+	var sessions []*session.Session
+	var hasMore bool
+	if owner, ok := tenant.FromContext(ctx); ok {
+		sessions, hasMore, err = s.coreService.ListTenantSessionsPage(ctx, owner.ID, page)
+	} else {
+		sessions, hasMore, err = s.coreService.ListSessionsPage(ctx, page)
+	}
 	if err != nil {
 		s.logger.ErrorWithFields("Failed to list sessions", map[string]interface{}{
 			"limit":  limit,
@@ -263,13 +278,20 @@ func (s *SessionService) ListSessions(ctx context.Context, req *contracts.ListSe
 		}
 	}
 
-	total := len(sessions)
-
 	response := &contracts.ListSessionsResponse{
 		Sessions: sessionResponses,
-		Total:    total,
+		Total:    len(sessions),
 		Limit:    limit,
 		Offset:   offset,
+		HasMore:  hasMore,
+	}
+
+	if hasMore {
+		last := sessions[len(sessions)-1]
+		response.NextCursor = pagination.Encode(pagination.Cursor{
+			Time: last.CreatedAt,
+			ID:   last.ID.String(),
+		})
 	}
 
 	return response, nil
@@ -343,6 +365,28 @@ func (s *SessionService) DisconnectSession(ctx context.Context, sessionID string
 	return nil
 }
 
+func (s *SessionService) CancelPairing(ctx context.Context, sessionID string) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.coreService.CancelPairing(ctx, id); err != nil {
+		s.logger.ErrorWithFields("Failed to cancel pairing", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to cancel pairing: %w", err)
+	}
+
+	s.logger.InfoWithFields("Pairing cancelled", map[string]interface{}{
+		"session_id": sessionID,
+	})
+
+	return nil
+}
+
 func (s *SessionService) DeleteSession(ctx context.Context, sessionID string) error {
 
 	id, err := uuid.Parse(sessionID)
@@ -394,6 +438,110 @@ func (s *SessionService) GetQRCode(ctx context.Context, sessionID string) (*cont
 	return response, nil
 }
 
+// RenderQRCode returns the current pairing QR as an image in the given
+// format ("png" or "svg").
+func (s *SessionService) RenderQRCode(ctx context.Context, sessionID, format string, req *contracts.QRImageRequest) ([]byte, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	opts, err := s.qrImageOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	qrResponse, err := s.coreService.GetQRCode(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get QR code: %w", err)
+	}
+
+	image, err := s.renderQR(qrResponse.QRCode, format, opts)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to render QR code", map[string]interface{}{
+			"session_id": sessionID,
+			"format":     format,
+			"error":      err.Error(),
+		})
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (s *SessionService) renderQR(content, format string, opts session.QRImageOptions) ([]byte, error) {
+	var image []byte
+	var err error
+	switch format {
+	case "png":
+		image, err = s.qrGen.RenderPNG(content, opts)
+	case "svg":
+		image, err = s.qrGen.RenderSVG(content, opts)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", session.ErrInvalidQRImage, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	return image, nil
+}
+
+func (s *SessionService) qrImageOptions(req *contracts.QRImageRequest) (session.QRImageOptions, error) {
+	opts := session.QRImageOptions{
+		Size:       req.Size,
+		Margin:     req.Margin,
+		Logo:       req.Logo,
+		Foreground: color.Black,
+		Background: color.White,
+	}
+
+	if opts.Size == 0 {
+		opts.Size = session.DefaultQRImageSize
+	}
+	if opts.Size < session.MinQRImageSize || opts.Size > session.MaxQRImageSize {
+		return opts, fmt.Errorf("%w: size must be between %d and %d", session.ErrInvalidQRImage, session.MinQRImageSize, session.MaxQRImageSize)
+	}
+	if opts.Margin < 0 || opts.Margin > session.MaxQRMargin {
+		return opts, fmt.Errorf("%w: margin must be between 0 and %d", session.ErrInvalidQRImage, session.MaxQRMargin)
+	}
+	if opts.Logo && !s.qrGen.HasLogo() {
+		return opts, fmt.Errorf("%w: no QR logo is configured", session.ErrInvalidQRImage)
+	}
+
+	if req.Foreground != "" {
+		fg, err := parseHexColor(req.Foreground)
+		if err != nil {
+			return opts, err
+		}
+		opts.Foreground = fg
+	}
+	if req.Background != "" {
+		bg, err := parseHexColor(req.Background)
+		if err != nil {
+			return opts, err
+		}
+		opts.Background = bg
+	}
+
+	return opts, nil
+}
+
+func parseHexColor(value string) (color.Color, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("%w: color %q must be a 6-digit hex value", session.ErrInvalidQRImage, value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: color %q must be a 6-digit hex value", session.ErrInvalidQRImage, value)
+	}
+
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
 func (s *SessionService) GenerateQRCode(ctx context.Context, sessionID string) (*contracts.QRCodeResponse, error) {
 
 	id, err := uuid.Parse(sessionID)
@@ -499,58 +647,903 @@ func (s *SessionService) GetProxy(ctx context.Context, sessionID string) (*contr
 	return response, nil
 }
 
-func (s *SessionService) GetSessionStats(ctx context.Context) (*contracts.SessionStatsResponse, error) {
+func (s *SessionService) GetSettings(ctx context.Context, sessionID string) (*contracts.SessionSettings, error) {
 
-	stats, err := s.coreService.GetSessionStats(ctx)
+	id, err := uuid.Parse(sessionID)
 	if err != nil {
-		s.logger.ErrorWithFields("Failed to get session stats", map[string]interface{}{
-			"error": err.Error(),
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	settings, err := s.coreService.GetSettings(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	autoDownload := settings.Media.AutoDownload
+	if autoDownload == "" {
+		autoDownload = session.MediaDownloadNever
+	}
+
+	quietPolicy := settings.QuietHours.Policy
+	if quietPolicy == "" {
+		quietPolicy = session.QuietHoursReject
+	}
+
+	mode := settings.Policy.Mode
+	if mode == "" {
+		mode = session.ModeFull
+	}
+
+	return &contracts.SessionSettings{
+		Calls: contracts.CallSettings{
+			AutoReject:    settings.Calls.AutoReject,
+			RejectMessage: settings.Calls.RejectMessage,
+		},
+		Media: contracts.MediaSettings{
+			AutoDownload: string(autoDownload),
+			MaxSizeMB:    settings.Media.MaxSizeMB,
+			Scan:         string(settings.Media.Scan),
+		},
+		QuietHours: contracts.QuietHoursSettings{
+			Enabled:  settings.QuietHours.Enabled,
+			Start:    settings.QuietHours.Start,
+			End:      settings.QuietHours.End,
+			Timezone: settings.QuietHours.Timezone,
+			Policy:   string(quietPolicy),
+		},
+		MediaPolicy: contracts.MediaPolicy{
+			MaxBytes:         settings.MediaPolicy.MaxBytes,
+			AllowedMIMETypes: settings.MediaPolicy.AllowedMIMETypes,
+		},
+		Footer: contracts.FooterSettings{
+			Enabled: settings.Footer.Enabled,
+			Text:    settings.Footer.Text,
+		},
+		TextFormat: contracts.TextFormatSettings{
+			Markdown:  settings.TextFormat.Markdown,
+			Emoji:     settings.TextFormat.Emoji,
+			Normalize: settings.TextFormat.Normalize,
+		},
+		Chatwoot: contracts.ChatwootSettings{
+			ResolvedMessage: settings.Chatwoot.ResolvedMessage,
+			CSATMessage:     settings.Chatwoot.CSATMessage,
+			ReopenOnReply:   settings.Chatwoot.ReopenOnReply,
+		},
+		WarmUp: warmUpToDTO(settings.WarmUp),
+		Retention: contracts.RetentionSettings{
+			MessageDays: settings.Retention.MessageDays,
+		},
+		Policy: contracts.SessionPolicy{
+			Mode: string(mode),
+		},
+		Timezone: settings.Timezone,
+		Sandbox: contracts.SandboxSettings{
+			Enabled: settings.Sandbox.Enabled,
+		},
+		Features:      featuresToDTO(settings.Features),
+		GroupRules:    groupRulesToDTO(settings.GroupRules),
+		InboundFilter: inboundFilterToDTO(settings.InboundFilter),
+		Pairing: contracts.PairingSettings{
+			MaxQRCodes: settings.Pairing.MaxQRCodes,
+		},
+	}, nil
+}
+
+func groupRulesToDTO(rules map[string]session.GroupRules) map[string]contracts.GroupRules {
+	if len(rules) == 0 {
+		return nil
+	}
+	dto := make(map[string]contracts.GroupRules, len(rules))
+	for group, rule := range rules {
+		dto[group] = contracts.GroupRules{
+			MaxPerHour:   rule.MaxPerHour,
+			AllowedTypes: rule.AllowedTypes,
+			BannedWords:  rule.BannedWords,
+		}
+	}
+	return dto
+}
+
+func featuresToDTO(features session.Features) contracts.FeatureFlags {
+	dto := contracts.FeatureFlags{}
+	for feature, enabled := range features.Effective() {
+		dto[string(feature)] = enabled
+	}
+	return dto
+}
+
+func warmUpToDTO(settings session.WarmUpSettings) contracts.WarmUpSettings {
+	policy := settings.Policy
+	if policy == "" {
+		policy = session.WarmUpReject
+	}
+
+	dto := contracts.WarmUpSettings{
+		Enabled:    settings.Enabled,
+		Days:       settings.Days,
+		StartLimit: settings.StartLimit,
+		EndLimit:   settings.EndLimit,
+		Timezone:   settings.Timezone,
+		Policy:     string(policy),
+	}
+	if !settings.StartedAt.IsZero() {
+		startedAt := settings.StartedAt
+		dto.StartedAt = &startedAt
+	}
+
+	return dto
+}
+
+func (s *SessionService) SetCallSettings(ctx context.Context, sessionID string, req *contracts.CallSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating call settings", map[string]interface{}{
+		"session_id":  sessionID,
+		"auto_reject": req.AutoReject,
+		"has_message": req.RejectMessage != "",
+	})
+
+	settings := session.CallSettings{
+		AutoReject:    req.AutoReject,
+		RejectMessage: req.RejectMessage,
+	}
+
+	if err := s.coreService.SetCallSettings(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update call settings", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
 		})
-		return nil, fmt.Errorf("failed to get session stats: %w", err)
+		return fmt.Errorf("failed to set call settings: %w", err)
 	}
 
-	response := &contracts.SessionStatsResponse{
-		Total:     stats.Total,
-		Connected: stats.Connected,
-		Offline:   stats.Offline,
+	return nil
+}
+
+func (s *SessionService) SetMediaSettings(ctx context.Context, sessionID string, req *contracts.MediaSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
 	}
 
-	return response, nil
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating media settings", map[string]interface{}{
+		"session_id":    sessionID,
+		"auto_download": req.AutoDownload,
+		"max_size_mb":   req.MaxSizeMB,
+		"scan":          req.Scan,
+	})
+
+	settings := session.MediaSettings{
+		AutoDownload: session.MediaDownloadPolicy(req.AutoDownload),
+		MaxSizeMB:    req.MaxSizeMB,
+		Scan:         session.MediaScanPolicy(req.Scan),
+	}
+
+	if err := s.coreService.SetMediaSettings(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update media settings", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set media settings: %w", err)
+	}
+
+	return nil
 }
 
-func (s *SessionService) UpdateLastSeen(ctx context.Context, sessionID string) error {
+func (s *SessionService) SetQuietHours(ctx context.Context, sessionID string, req *contracts.QuietHoursSettings) error {
 
 	id, err := uuid.Parse(sessionID)
 	if err != nil {
 		return fmt.Errorf("invalid session ID format: %w", err)
 	}
 
-	if err := s.coreService.UpdateLastSeen(ctx, id); err != nil {
-		return fmt.Errorf("failed to update last seen: %w", err)
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating quiet hours", map[string]interface{}{
+		"session_id": sessionID,
+		"enabled":    req.Enabled,
+		"start":      req.Start,
+		"end":        req.End,
+		"timezone":   req.Timezone,
+		"policy":     req.Policy,
+	})
+
+	settings := session.QuietHoursSettings{
+		Enabled:  req.Enabled,
+		Start:    req.Start,
+		End:      req.End,
+		Timezone: req.Timezone,
+		Policy:   session.QuietHoursPolicy(req.Policy),
+	}
+
+	if err := s.coreService.SetQuietHours(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update quiet hours", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set quiet hours: %w", err)
 	}
 
 	return nil
 }
 
-func (s *SessionService) sessionToDTO(sess *session.Session) *contracts.SessionResponse {
-	response := &contracts.SessionResponse{
-		ID:          sess.ID.String(),
-		Name:        sess.Name,
-		IsConnected: sess.IsConnected,
-		CreatedAt:   sess.CreatedAt,
-		UpdatedAt:   sess.UpdatedAt,
+func (s *SessionService) SetMediaPolicy(ctx context.Context, sessionID string, req *contracts.MediaPolicy) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
 	}
 
-	if sess.DeviceJID != nil {
-		response.DeviceJID = *sess.DeviceJID
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	if sess.ConnectionError != nil {
-		response.ConnectionError = sess.ConnectionError
+	s.logger.InfoWithFields("Updating media policy", map[string]interface{}{
+		"session_id":         sessionID,
+		"max_bytes":          req.MaxBytes,
+		"allowed_mime_types": req.AllowedMIMETypes,
+	})
+
+	policy := session.MediaPolicy{
+		MaxBytes:         req.MaxBytes,
+		AllowedMIMETypes: req.AllowedMIMETypes,
 	}
 
-	if sess.ConnectedAt != nil {
-		response.ConnectedAt = sess.ConnectedAt
+	if err := s.coreService.SetMediaPolicy(ctx, id, policy); err != nil {
+		s.logger.ErrorWithFields("Failed to update media policy", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set media policy: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetFooter(ctx context.Context, sessionID string, req *contracts.FooterSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating footer", map[string]interface{}{
+		"session_id": sessionID,
+		"enabled":    req.Enabled,
+		"text_len":   len(req.Text),
+	})
+
+	settings := session.FooterSettings{
+		Enabled: req.Enabled,
+		Text:    req.Text,
+	}
+
+	if err := s.coreService.SetFooter(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update footer", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set footer: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetTextFormat(ctx context.Context, sessionID string, req *contracts.TextFormatSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating text format", map[string]interface{}{
+		"session_id": sessionID,
+		"markdown":   req.Markdown,
+		"emoji":      req.Emoji,
+		"normalize":  req.Normalize,
+	})
+
+	settings := session.TextFormatSettings{
+		Markdown:  req.Markdown,
+		Emoji:     req.Emoji,
+		Normalize: req.Normalize,
+	}
+
+	if err := s.coreService.SetTextFormat(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update text format", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set text format: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetChatwoot(ctx context.Context, sessionID string, req *contracts.ChatwootSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating chatwoot settings", map[string]interface{}{
+		"session_id":       sessionID,
+		"resolved_message": req.ResolvedMessage != "",
+		"csat_message":     req.CSATMessage != "",
+		"reopen_on_reply":  req.ReopenOnReply,
+	})
+
+	settings := session.ChatwootSettings{
+		ResolvedMessage: req.ResolvedMessage,
+		CSATMessage:     req.CSATMessage,
+		ReopenOnReply:   req.ReopenOnReply,
+	}
+
+	if err := s.coreService.SetChatwoot(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update chatwoot settings", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set chatwoot settings: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetRetention(ctx context.Context, sessionID string, req *contracts.RetentionSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	settings := session.RetentionSettings{MessageDays: req.MessageDays}
+	if err := s.coreService.SetRetention(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update retention", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set retention: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetPolicy(ctx context.Context, sessionID string, req *contracts.SessionPolicy) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating session policy", map[string]interface{}{
+		"session_id": sessionID,
+		"mode":       req.Mode,
+	})
+
+	settings := session.PolicySettings{Mode: session.Mode(req.Mode)}
+	if err := s.coreService.SetPolicy(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update session policy", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set session policy: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetTimezone(ctx context.Context, sessionID string, req *contracts.TimezoneSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating session timezone", map[string]interface{}{
+		"session_id": sessionID,
+		"timezone":   req.Timezone,
+	})
+
+	if err := s.coreService.SetTimezone(ctx, id, req.Timezone); err != nil {
+		s.logger.ErrorWithFields("Failed to update session timezone", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set timezone: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetSandbox(ctx context.Context, sessionID string, req *contracts.SandboxSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating session sandbox", map[string]interface{}{
+		"session_id": sessionID,
+		"enabled":    req.Enabled,
+	})
+
+	settings := session.SandboxSettings{Enabled: req.Enabled}
+	if err := s.coreService.SetSandbox(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update session sandbox", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set sandbox: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) SetPairing(ctx context.Context, sessionID string, req *contracts.PairingSettings) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating session pairing settings", map[string]interface{}{
+		"session_id":   sessionID,
+		"max_qr_codes": req.MaxQRCodes,
+	})
+
+	settings := session.PairingSettings{MaxQRCodes: req.MaxQRCodes}
+	if err := s.coreService.SetPairing(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update session pairing settings", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set pairing settings: %w", err)
+	}
+
+	return nil
+}
+
+// SetGroupRules saves the posting rules for one of the session's groups;
+// nil rules remove them.
+func (s *SessionService) SetGroupRules(ctx context.Context, sessionID, groupJID string, req *contracts.GroupRules) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	var rules *session.GroupRules
+	if req != nil {
+		if err := s.validator.ValidateStruct(req); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+		rules = &session.GroupRules{
+			MaxPerHour:   req.MaxPerHour,
+			AllowedTypes: req.AllowedTypes,
+			BannedWords:  req.BannedWords,
+		}
+	}
+
+	s.logger.InfoWithFields("Updating group posting rules", map[string]interface{}{
+		"session_id": sessionID,
+		"group_jid":  groupJID,
+		"removed":    req == nil,
+	})
+
+	if err := s.coreService.SetGroupRules(ctx, id, groupJID, rules); err != nil {
+		s.logger.ErrorWithFields("Failed to update group posting rules", map[string]interface{}{
+			"session_id": sessionID,
+			"group_jid":  groupJID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("failed to set group rules: %w", err)
+	}
+
+	return nil
+}
+
+// SetFeatures toggles the flags in req and returns all of the session's
+// flags after the change.
+func (s *SessionService) SetFeatures(ctx context.Context, sessionID string, req contracts.FeatureFlags) (contracts.FeatureFlags, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if len(req) == 0 {
+		return nil, fmt.Errorf("validation failed: no feature flags given")
+	}
+
+	s.logger.InfoWithFields("Updating session feature flags", map[string]interface{}{
+		"session_id": sessionID,
+		"flags":      req,
+	})
+
+	changes := make(session.Features, len(req))
+	for name, enabled := range req {
+		changes[session.Feature(name)] = enabled
+	}
+
+	features, err := s.coreService.SetFeatures(ctx, id, changes)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to update session feature flags", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return nil, fmt.Errorf("failed to set feature flags: %w", err)
+	}
+
+	return featuresToDTO(features), nil
+}
+
+func (s *SessionService) SetWarmUp(ctx context.Context, sessionID string, req *contracts.WarmUpSettings) (*contracts.WarmUpSettings, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Updating warm-up", map[string]interface{}{
+		"session_id":  sessionID,
+		"enabled":     req.Enabled,
+		"days":        req.Days,
+		"start_limit": req.StartLimit,
+		"end_limit":   req.EndLimit,
+		"policy":      req.Policy,
+	})
+
+	settings := session.WarmUpSettings{
+		Enabled:    req.Enabled,
+		Days:       req.Days,
+		StartLimit: req.StartLimit,
+		EndLimit:   req.EndLimit,
+		Timezone:   req.Timezone,
+		Policy:     session.WarmUpPolicy(req.Policy),
+	}
+	if req.StartedAt != nil {
+		settings.StartedAt = *req.StartedAt
+	}
+
+	if err := s.coreService.SetWarmUp(ctx, id, settings); err != nil {
+		s.logger.ErrorWithFields("Failed to update warm-up", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return nil, fmt.Errorf("failed to set warm-up: %w", err)
+	}
+
+	saved, err := s.coreService.GetSettings(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	response := warmUpToDTO(saved.WarmUp)
+	return &response, nil
+}
+
+// GetWarmUpStatus reports where the session is on its warm-up ramp today
+// and how much of the day's allowance is left.
+func (s *SessionService) GetWarmUpStatus(ctx context.Context, sessionID string) (*contracts.WarmUpStatusResponse, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	sess, err := s.coreService.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	today, sent, active, err := s.coreService.WarmUpUsage(ctx, sess)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warm-up usage: %w", err)
+	}
+	if !active {
+		return &contracts.WarmUpStatusResponse{}, nil
+	}
+
+	remaining := today.Limit - sent
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &contracts.WarmUpStatusResponse{
+		Active:    true,
+		Day:       today.Day + 1,
+		Days:      sess.Settings.WarmUp.Days,
+		Limit:     today.Limit,
+		Sent:      sent,
+		Remaining: remaining,
+		ResumeAt:  &today.ResumeAt,
+	}, nil
+}
+
+// GetUptime reports the session's daily uptime over the last days UTC days
+// and the status transitions behind it.
+func (s *SessionService) GetUptime(ctx context.Context, sessionID string, days int) (*contracts.UptimeResponse, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	report, err := s.coreService.GetUptime(ctx, id, days)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.UptimeResponse{
+		SessionID:     sessionID,
+		From:          report.From,
+		To:            report.To,
+		UptimePercent: roundPercent(report.Percent()),
+		Days:          make([]contracts.UptimeDay, len(report.Days)),
+		Transitions:   make([]contracts.StatusTransition, len(report.Transitions)),
+	}
+	for i, day := range report.Days {
+		response.Days[i] = contracts.UptimeDay{
+			Date:             day.Date,
+			UptimePercent:    roundPercent(day.Percent()),
+			ConnectedSeconds: int64(day.Connected.Seconds()),
+			ObservedSeconds:  int64(day.Observed.Seconds()),
+		}
+	}
+	for i, transition := range report.Transitions {
+		response.Transitions[i] = contracts.StatusTransition{
+			From:   string(transition.From),
+			To:     string(transition.To),
+			Reason: transition.Reason,
+			At:     transition.At,
+		}
+	}
+
+	return response, nil
+}
+
+func roundPercent(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *SessionService) ExportSession(ctx context.Context, sessionID string, req *contracts.ExportSessionRequest) (*contracts.SessionBackup, error) {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Exporting session credentials", map[string]interface{}{
+		"session_id": sessionID,
+	})
+
+	backup, err := s.coreService.ExportSession(ctx, id, req.Passphrase)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to export session", map[string]interface{}{
+			"session_id": sessionID,
+			"error":      err.Error(),
+		})
+		return nil, fmt.Errorf("failed to export session: %w", err)
+	}
+
+	s.logger.InfoWithFields("Session credentials exported successfully", map[string]interface{}{
+		"session_id": sessionID,
+		"device_jid": backup.DeviceJID,
+	})
+
+	return contracts.FromSessionBackup(backup), nil
+}
+
+func (s *SessionService) ImportSession(ctx context.Context, req *contracts.ImportSessionRequest) (*contracts.SessionResponse, error) {
+
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	s.logger.InfoWithFields("Importing session credentials", map[string]interface{}{
+		"name":       req.Name,
+		"device_jid": req.Backup.DeviceJID,
+		"connect":    req.Connect,
+	})
+
+	tenantID, err := s.tenantForNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coreReq := &session.ImportSessionRequest{
+		Name:        req.Name,
+		Passphrase:  req.Passphrase,
+		Backup:      req.Backup.ToSessionBackup(),
+		AutoConnect: req.Connect,
+		TenantID:    tenantID,
+	}
+
+	if req.ProxyConfig != nil {
+		coreReq.ProxyConfig = &session.ProxyConfig{
+			Type:     req.ProxyConfig.Type,
+			Host:     req.ProxyConfig.Host,
+			Port:     req.ProxyConfig.Port,
+			Username: req.ProxyConfig.Username,
+			Password: req.ProxyConfig.Password,
+		}
+	}
+
+	sess, err := s.coreService.ImportSession(ctx, coreReq)
+	if err != nil && sess == nil {
+		s.logger.ErrorWithFields("Failed to import session", map[string]interface{}{
+			"name":  req.Name,
+			"error": err.Error(),
+		})
+		return nil, fmt.Errorf("failed to import session: %w", err)
+	}
+
+	// The credentials are stored even when connecting failed, so the session
+	// is returned with the failure in connectionError; retrying the import
+	// would only find the name taken.
+	if err != nil {
+		if sess.ConnectionError == nil {
+			sess.SetConnectionError(err.Error())
+		}
+		s.logger.WarnWithFields("Session imported but failed to connect", map[string]interface{}{
+			"session_id": sess.ID.String(),
+			"name":       sess.Name,
+			"error":      err.Error(),
+		})
+		return s.sessionToDTO(sess), nil
+	}
+
+	s.logger.InfoWithFields("Session imported successfully", map[string]interface{}{
+		"session_id": sess.ID.String(),
+		"name":       sess.Name,
+	})
+
+	return s.sessionToDTO(sess), nil
+}
+
+func (s *SessionService) GetSessionStats(ctx context.Context) (*contracts.SessionStatsResponse, error) {
+
+	stats, err := s.coreService.GetSessionStats(ctx)
+	if err != nil {
+		s.logger.ErrorWithFields("Failed to get session stats", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, fmt.Errorf("failed to get session stats: %w", err)
+	}
+
+	response := &contracts.SessionStatsResponse{
+		Total:     stats.Total,
+		Connected: stats.Connected,
+		Offline:   stats.Offline,
+	}
+
+	return response, nil
+}
+
+func (s *SessionService) UpdateLastSeen(ctx context.Context, sessionID string) error {
+
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session ID format: %w", err)
+	}
+
+	if err := s.coreService.UpdateLastSeen(ctx, id); err != nil {
+		return fmt.Errorf("failed to update last seen: %w", err)
+	}
+
+	return nil
+}
+
+// tenantForNewSession returns the tenant a session created in ctx belongs
+// to, after checking the tenant may own another one. Sessions created with
+// the global key have no tenant.
+func (s *SessionService) tenantForNewSession(ctx context.Context) (*uuid.UUID, error) {
+	owner, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	if s.tenants != nil {
+		if err := s.tenants.CheckSessionQuota(ctx, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return &owner.ID, nil
+}
+
+func (s *SessionService) sessionToDTO(sess *session.Session) *contracts.SessionResponse {
+	response := &contracts.SessionResponse{
+		ID:          sess.ID.String(),
+		Name:        sess.Name,
+		IsConnected: sess.IsConnected,
+		Status:      string(sess.GetStatus()),
+		CreatedAt:   sess.CreatedAt,
+		UpdatedAt:   sess.UpdatedAt,
+		LoggedOutAt: sess.LoggedOutAt,
+		QRTimeoutAt: sess.QRTimeoutAt,
+
+		SendQueueDepth: s.coreService.SendQueueDepth(sess.ID),
+	}
+
+	if sess.DeviceJID != nil {
+		response.DeviceJID = *sess.DeviceJID
+	}
+
+	if throttle, ok := s.coreService.SendThrottle(sess.ID); ok && throttle.Active(time.Now()) {
+		response.Throttle = &contracts.Throttle{
+			Reason:  throttle.Reason,
+			Code:    throttle.Code,
+			Strikes: throttle.Strikes,
+			Since:   throttle.Since,
+			Until:   throttle.Until,
+		}
+	}
+
+	if sess.Ban.Active(time.Now()) {
+		response.Ban = &contracts.SessionBan{
+			Kind:   string(sess.Ban.Kind),
+			Reason: sess.Ban.Reason,
+			Code:   sess.Ban.Code,
+			Since:  sess.Ban.Since,
+			Until:  sess.Ban.Until,
+		}
+	}
+
+	if sess.ConnectionError != nil {
+		response.ConnectionError = sess.ConnectionError
+	}
+
+	if sess.ConnectedAt != nil {
+		response.ConnectedAt = sess.ConnectedAt
+	}
+
+	if sess.TenantID != nil {
+		response.TenantID = sess.TenantID.String()
 	}
 
 	if sess.ProxyConfig != nil {
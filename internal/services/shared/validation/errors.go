@@ -0,0 +1,24 @@
+package validation
+
+import "strings"
+
+// FieldError describes one field that failed validation. Field is the JSON
+// path of the field and Rule the validate tag that rejected it.
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Errors is returned by ValidateStruct and ValidateVar. It keeps the
+// field-level details so the HTTP layer can report them, while Error()
+// reads the same as the flat messages handlers used to return.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, field := range e {
+		messages[i] = field.Message
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
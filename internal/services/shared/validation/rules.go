@@ -1 +1,40 @@
 package validation
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validatePhone accepts an international number with or without the
+// leading "+": 7 to 15 digits, the length E.164 allows.
+func validatePhone(fl validator.FieldLevel) bool {
+	return isPhone(strings.TrimPrefix(fl.Field().String(), "+"))
+}
+
+// validateJID accepts what the send endpoints take as a recipient: a full
+// JID (user@server) or a bare phone number, formatting allowed.
+func validateJID(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+
+	user, server, found := strings.Cut(value, "@")
+	if !found {
+		return isPhone(strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimPrefix(value, "+")))
+	}
+
+	return user != "" && server != "" && !strings.ContainsAny(value, " \t\n")
+}
+
+func isPhone(digits string) bool {
+	if len(digits) < 7 || len(digits) > 15 {
+		return false
+	}
+
+	for _, char := range digits {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -45,32 +46,47 @@ func (v *Validator) ValidateVar(field interface{}, tag string) error {
 }
 
 func (v *Validator) formatValidationError(err error) error {
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		var messages []string
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
 
-		for _, fieldError := range validationErrors {
-			message := v.getErrorMessage(fieldError)
-			messages = append(messages, message)
+	fields := make(Errors, len(validationErrors))
+	for i, fieldError := range validationErrors {
+		fields[i] = FieldError{
+			Field:   fieldPath(fieldError),
+			Rule:    fieldError.Tag(),
+			Message: v.getErrorMessage(fieldError),
 		}
-
-		return fmt.Errorf("validation failed: %s", strings.Join(messages, "; "))
 	}
 
-	return err
+	return fields
+}
+
+// fieldPath returns the JSON path of the field, such as "buttons[0].text",
+// dropping the Go name of the top-level struct.
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldError.Field()
 }
 
 func (v *Validator) getErrorMessage(fieldError validator.FieldError) string {
-	field := fieldError.Field()
+	field := fieldPath(fieldError)
 	tag := fieldError.Tag()
 	param := fieldError.Param()
 
 	switch tag {
 	case "required":
 		return fmt.Sprintf("%s is required", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters long", field, param)
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters long", field, param)
+	case "min", "max", "len":
+		return lengthMessage(field, tag, param, fieldError.Kind())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
 	case "email":
 		return fmt.Sprintf("%s must be a valid email address", field)
 	case "url":
@@ -79,6 +95,10 @@ func (v *Validator) getErrorMessage(fieldError validator.FieldError) string {
 		return fmt.Sprintf("%s must be a valid hostname", field)
 	case "e164":
 		return fmt.Sprintf("%s must be a valid phone number in E.164 format", field)
+	case "phone":
+		return fmt.Sprintf("%s must be a phone number with country code, digits only (e.g. 5511999999999)", field)
+	case "jid":
+		return fmt.Sprintf("%s must be a WhatsApp JID or a phone number with country code", field)
 	case "oneof":
 		return fmt.Sprintf("%s must be one of: %s", field, param)
 	case "uuid":
@@ -92,6 +112,25 @@ func (v *Validator) getErrorMessage(fieldError validator.FieldError) string {
 	}
 }
 
+func lengthMessage(field, tag, param string, kind reflect.Kind) string {
+	bound := "at least"
+	switch tag {
+	case "max":
+		bound = "at most"
+	case "len":
+		bound = "exactly"
+	}
+
+	switch kind {
+	case reflect.String:
+		return fmt.Sprintf("%s must be %s %s characters long", field, bound, param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s must contain %s %s items", field, bound, param)
+	default:
+		return fmt.Sprintf("%s must be %s %s", field, bound, param)
+	}
+}
+
 func registerCustomValidations(validate *validator.Validate) {
 
 	validate.RegisterValidation("session_name", validateSessionName)
@@ -99,6 +138,10 @@ func registerCustomValidations(validate *validator.Validate) {
 	validate.RegisterValidation("proxy_type", validateProxyType)
 
 	validate.RegisterValidation("e164", validateE164)
+
+	validate.RegisterValidation("phone", validatePhone)
+
+	validate.RegisterValidation("jid", validateJID)
 }
 
 func validateSessionName(fl validator.FieldLevel) bool {
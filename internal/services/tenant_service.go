@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+// TenantService manages tenants for the admin API and answers the
+// authentication and session ownership checks made for tenant API keys.
+type TenantService struct {
+	core      *tenant.Service
+	resolver  session.SessionResolver
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewTenantService(
+	core *tenant.Service,
+	resolver session.SessionResolver,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *TenantService {
+	return &TenantService{
+		core:      core,
+		resolver:  resolver,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (s *TenantService) ListTenants(ctx context.Context) (*contracts.ListTenantsResponse, error) {
+	tenants, err := s.core.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListTenantsResponse{
+		Tenants: make([]contracts.TenantResponse, len(tenants)),
+		Total:   len(tenants),
+	}
+	for i, t := range tenants {
+		response.Tenants[i] = *tenantToDTO(t, nil)
+	}
+
+	return response, nil
+}
+
+func (s *TenantService) CreateTenant(ctx context.Context, req *contracts.CreateTenantRequest) (*contracts.TenantResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	t, err := s.core.Create(ctx, &tenant.Tenant{
+		Name:              req.Name,
+		MaxSessions:       req.MaxSessions,
+		MaxMessagesPerDay: req.MaxMessagesPerDay,
+		TrackingDomain:    req.TrackingDomain,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Tenant created", map[string]interface{}{
+		"tenant_id": t.ID.String(),
+		"name":      t.Name,
+	})
+
+	return tenantToDTO(t, &tenant.Usage{}), nil
+}
+
+// GetTenant returns the tenant with its current usage.
+func (s *TenantService) GetTenant(ctx context.Context, tenantID string) (*contracts.TenantResponse, error) {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.core.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.core.Usage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return tenantToDTO(t, usage), nil
+}
+
+func (s *TenantService) UpdateTenant(ctx context.Context, tenantID string, req *contracts.UpdateTenantRequest) (*contracts.TenantResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.core.Update(ctx, id, func(t *tenant.Tenant) {
+		if req.Name != nil {
+			t.Name = *req.Name
+		}
+		if req.MaxSessions != nil {
+			t.MaxSessions = *req.MaxSessions
+		}
+		if req.MaxMessagesPerDay != nil {
+			t.MaxMessagesPerDay = *req.MaxMessagesPerDay
+		}
+		if req.TrackingDomain != nil {
+			t.TrackingDomain = *req.TrackingDomain
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tenantToDTO(t, nil), nil
+}
+
+func (s *TenantService) DeleteTenant(ctx context.Context, tenantID string) error {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.core.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.InfoWithFields("Tenant deleted", map[string]interface{}{
+		"tenant_id": id.String(),
+	})
+
+	return nil
+}
+
+func (s *TenantService) ListKeys(ctx context.Context, tenantID string) (*contracts.ListTenantKeysResponse, error) {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.core.ListKeys(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListTenantKeysResponse{
+		Keys:  make([]contracts.TenantKeyResponse, len(keys)),
+		Total: len(keys),
+	}
+	for i, key := range keys {
+		response.Keys[i] = *tenantKeyToDTO(key, "")
+	}
+
+	return response, nil
+}
+
+func (s *TenantService) CreateKey(ctx context.Context, tenantID string, req *contracts.CreateTenantKeyRequest) (*contracts.TenantKeyResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, plain, err := s.core.IssueKey(ctx, id, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoWithFields("Tenant API key issued", map[string]interface{}{
+		"tenant_id": id.String(),
+		"key_id":    key.ID.String(),
+		"prefix":    key.Prefix,
+	})
+
+	return tenantKeyToDTO(key, plain), nil
+}
+
+func (s *TenantService) RevokeKey(ctx context.Context, tenantID, keyID string) error {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	kid, err := uuid.Parse(keyID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid key ID %q", keyID)
+	}
+
+	return s.core.RevokeKey(ctx, id, kid)
+}
+
+// AssignSession moves a session to the tenant. A session owned by another
+// tenant must be released first.
+func (s *TenantService) AssignSession(ctx context.Context, tenantID, sessionName string) error {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionName)
+	if err != nil {
+		return err
+	}
+
+	current := resolved.Session.TenantID
+	if current != nil && *current != id {
+		return fmt.Errorf("%w: %s", tenant.ErrSessionOwned, current)
+	}
+
+	if err := s.core.AssignSession(ctx, id, resolved.ID, current); err != nil {
+		return err
+	}
+
+	s.logger.InfoWithFields("Session assigned to tenant", map[string]interface{}{
+		"tenant_id":  id.String(),
+		"session_id": resolved.ID.String(),
+	})
+
+	return nil
+}
+
+func (s *TenantService) ReleaseSession(ctx context.Context, tenantID, sessionName string) error {
+	id, err := parseTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionName)
+	if err != nil {
+		return err
+	}
+
+	if owner := resolved.Session.TenantID; owner == nil || *owner != id {
+		return fmt.Errorf("session '%s' not found for tenant", sessionName)
+	}
+
+	return s.core.ReleaseSession(ctx, resolved.ID)
+}
+
+// Authenticate returns the tenant owning apiKey, or nil when the key is
+// not a tenant key.
+func (s *TenantService) Authenticate(ctx context.Context, apiKey string) (*tenant.Tenant, error) {
+	t, err := s.core.Authenticate(ctx, apiKey)
+	if errors.Is(err, tenant.ErrAPIKeyNotFound) {
+		return nil, nil
+	}
+	return t, err
+}
+
+// OwnsSession reports whether the session named idOrName belongs to the
+// tenant. Unknown sessions are reported as not owned.
+func (s *TenantService) OwnsSession(ctx context.Context, tenantID uuid.UUID, idOrName string) (bool, error) {
+	resolved, err := s.resolver.Resolve(ctx, idOrName)
+	if err != nil {
+		return false, nil
+	}
+
+	owner := resolved.Session.TenantID
+	return owner != nil && *owner == tenantID, nil
+}
+
+func parseTenantID(tenantID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(tenantID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("validation failed: invalid tenant ID %q", tenantID)
+	}
+	return id, nil
+}
+
+func tenantToDTO(t *tenant.Tenant, usage *tenant.Usage) *contracts.TenantResponse {
+	response := &contracts.TenantResponse{
+		ID:                t.ID.String(),
+		Name:              t.Name,
+		MaxSessions:       t.MaxSessions,
+		MaxMessagesPerDay: t.MaxMessagesPerDay,
+		TrackingDomain:    t.TrackingDomain,
+		CreatedAt:         t.CreatedAt,
+		UpdatedAt:         t.UpdatedAt,
+	}
+
+	if usage != nil {
+		response.Usage = &contracts.TenantUsage{
+			Sessions:      usage.Sessions,
+			Day:           usage.Day,
+			MessagesToday: usage.MessagesToday,
+		}
+	}
+
+	return response
+}
+
+func tenantKeyToDTO(key *tenant.APIKey, plain string) *contracts.TenantKeyResponse {
+	return &contracts.TenantKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Key:        plain,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
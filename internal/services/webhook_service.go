@@ -1 +1,239 @@
 package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zpwoot/internal/adapters/server/contracts"
+	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/webhook"
+	"zpwoot/internal/services/shared/validation"
+	"zpwoot/platform/logger"
+)
+
+type WebhookService struct {
+	core      *webhook.Service
+	sender    webhook.Sender
+	resolver  session.SessionResolver
+	logger    *logger.Logger
+	validator *validation.Validator
+}
+
+func NewWebhookService(
+	core *webhook.Service,
+	sender webhook.Sender,
+	resolver session.SessionResolver,
+	logger *logger.Logger,
+	validator *validation.Validator,
+) *WebhookService {
+	return &WebhookService{
+		core:      core,
+		sender:    sender,
+		resolver:  resolver,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// SetWebhook creates or replaces the session's primary webhook, the one
+// with the highest priority.
+func (s *WebhookService) SetWebhook(ctx context.Context, sessionID string, req *contracts.SetWebhookRequest) (*contracts.WebhookResponse, error) {
+	return s.save(ctx, sessionID, req, func(set *webhook.SetWebhookRequest) (*webhook.Webhook, error) {
+		return s.core.Set(ctx, set)
+	})
+}
+
+// CreateWebhook adds another endpoint to the session.
+func (s *WebhookService) CreateWebhook(ctx context.Context, sessionID string, req *contracts.SetWebhookRequest) (*contracts.WebhookResponse, error) {
+	return s.save(ctx, sessionID, req, func(set *webhook.SetWebhookRequest) (*webhook.Webhook, error) {
+		return s.core.Create(ctx, set)
+	})
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, sessionID, webhookID string, req *contracts.SetWebhookRequest) (*contracts.WebhookResponse, error) {
+	id, err := uuid.Parse(webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid webhook ID")
+	}
+
+	return s.save(ctx, sessionID, req, func(set *webhook.SetWebhookRequest) (*webhook.Webhook, error) {
+		return s.core.Update(ctx, id, set)
+	})
+}
+
+func (s *WebhookService) save(ctx context.Context, sessionID string, req *contracts.SetWebhookRequest, apply func(*webhook.SetWebhookRequest) (*webhook.Webhook, error)) (*contracts.WebhookResponse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]webhook.EventCategory, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = webhook.EventCategory(e)
+	}
+
+	wh, err := apply(&webhook.SetWebhookRequest{
+		SessionID:     resolved.ID,
+		URL:           req.URL,
+		Secret:        req.Secret,
+		Events:        events,
+		Template:      req.Template,
+		SchemaVersion: req.SchemaVersion,
+		Format:        req.Format,
+		Priority:      req.Priority,
+		Enabled:       req.Enabled,
+	})
+	if err != nil {
+		if errors.Is(err, webhook.ErrInvalidWebhook) || errors.Is(err, webhook.ErrInvalidTemplate) {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+		return nil, err
+	}
+
+	return webhookToDTO(wh), nil
+}
+
+// GetWebhook returns the session's primary webhook.
+func (s *WebhookService) GetWebhook(ctx context.Context, sessionID string) (*contracts.WebhookResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	wh, err := s.core.Get(ctx, resolved.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookToDTO(wh), nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, sessionID string) (*contracts.ListWebhooksResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.core.List(ctx, resolved.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.ListWebhooksResponse{
+		Webhooks: make([]contracts.WebhookResponse, 0, len(webhooks)),
+		Total:    len(webhooks),
+	}
+	for _, wh := range webhooks {
+		response.Webhooks = append(response.Webhooks, *webhookToDTO(wh))
+	}
+
+	return response, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, sessionID, webhookID string) error {
+	id, err := uuid.Parse(webhookID)
+	if err != nil {
+		return fmt.Errorf("validation failed: invalid webhook ID")
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.core.Delete(ctx, resolved.ID, id)
+}
+
+// TestWebhook posts a webhook.test event to one of the session's webhooks,
+// or to the primary one when webhookID is empty, ignoring its event filter,
+// and reports how the receiver answered.
+func (s *WebhookService) TestWebhook(ctx context.Context, sessionID, webhookID string) (*contracts.TestWebhookResponse, error) {
+	resolved, err := s.resolver.Resolve(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var wh *webhook.Webhook
+	if webhookID == "" {
+		wh, err = s.core.Get(ctx, resolved.ID)
+	} else {
+		id, parseErr := uuid.Parse(webhookID)
+		if parseErr != nil {
+			return nil, fmt.Errorf("validation failed: invalid webhook ID")
+		}
+		wh, err = s.core.GetByID(ctx, resolved.ID, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := webhook.ParseTemplate(wh.Template)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	event := &webhook.Event{
+		ID:        uuid.NewString(),
+		Event:     "webhook.test",
+		SessionID: resolved.ID.String(),
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"sessionName": resolved.Name,
+			"message":     "This is a test event from zpwoot",
+		},
+	}
+
+	delivery, err := s.sender.Send(ctx, wh, template, event)
+
+	response := &contracts.TestWebhookResponse{
+		Delivered: err == nil,
+		URL:       wh.URL,
+	}
+	if delivery != nil {
+		response.StatusCode = delivery.StatusCode
+		response.Attempts = delivery.Attempts
+		response.DurationMs = delivery.Duration.Milliseconds()
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	return response, nil
+}
+
+func webhookToDTO(wh *webhook.Webhook) *contracts.WebhookResponse {
+	events := make([]string, len(wh.Events))
+	for i, e := range wh.Events {
+		events[i] = string(e)
+	}
+
+	return &contracts.WebhookResponse{
+		ID:            wh.ID.String(),
+		SessionID:     wh.SessionID.String(),
+		URL:           wh.URL,
+		Events:        events,
+		Template:      wh.Template,
+		SchemaVersion: wh.SchemaVersion,
+		Format:        wh.Format,
+		Priority:      wh.Priority,
+		Enabled:       wh.Enabled,
+		HasSecret:     wh.Secret != "",
+		Delivery: contracts.WebhookDeliveryState{
+			LastDeliveryAt: wh.State.LastDeliveryAt,
+			LastSuccessAt:  wh.State.LastSuccessAt,
+			LastStatusCode: wh.State.LastStatusCode,
+			LastError:      wh.State.LastError,
+			Failures:       wh.State.Failures,
+		},
+		CreatedAt: wh.CreatedAt,
+		UpdatedAt: wh.UpdatedAt,
+	}
+}
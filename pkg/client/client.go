@@ -0,0 +1,128 @@
+// Package client is a typed Go client for the zpwoot REST API. Request and
+// response types are the same structs the server decodes and encodes, so the
+// client cannot drift from the handlers.
+//
+//	c := client.New("http://localhost:8080", os.Getenv("ZPWOOT_API_KEY"))
+//	sent, err := c.SendText(ctx, "my-session", &client.SendTextMessageRequest{
+//		RemoteJID: "5511999999999@s.whatsapp.net",
+//		Body:      "Hello",
+//	})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Client calls the zpwoot API with an API key. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default HTTP client, which times out after 30
+// seconds.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func New(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// envelope is the body of every JSON response. Data is decoded into the
+// caller's type on success; the remaining fields describe failures.
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Code    string          `json:"code,omitempty"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// do sends a request and decodes the data of a successful response into out.
+// It returns the HTTP status so callers can tell 200 from 202.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var env envelope
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &env); err != nil {
+			return resp.StatusCode, &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+		}
+	}
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, &Error{
+			StatusCode: resp.StatusCode,
+			Code:       env.Code,
+			Message:    env.Error,
+			Details:    env.Details,
+		}
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func sessionPath(session string, parts ...string) string {
+	return "/sessions/" + url.PathEscape(session) + strings.Join(parts, "")
+}
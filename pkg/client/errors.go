@@ -0,0 +1,33 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Error is a non-2xx response from the API. Code is the machine-readable
+// error code when the server sends one, such as VALIDATION_ERROR or
+// QUIET_HOURS; Details carries its structured context.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    json.RawMessage
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("zpwoot: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("zpwoot: %d: %s", e.StatusCode, e.Message)
+}
+
+// DeferredError is returned by send methods when the session is in quiet
+// hours and the message was stored to be sent later instead of now.
+type DeferredError struct {
+	Scheduled *ScheduledMessageResponse
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("zpwoot: message deferred until %s", e.Scheduled.SendAt)
+}
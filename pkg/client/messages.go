@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+func (c *Client) SendText(ctx context.Context, session string, req *SendTextMessageRequest) (*SendMessageResponse, error) {
+	return c.send(ctx, sessionPath(session, "/messages/send/text"), req)
+}
+
+func (c *Client) SendMedia(ctx context.Context, session string, req *SendMediaMessageRequest) (*SendMessageResponse, error) {
+	return c.send(ctx, sessionPath(session, "/messages/send/media"), req)
+}
+
+// send posts a message. A 202 means the session was in quiet hours and the
+// message was scheduled; it is reported as a *DeferredError.
+func (c *Client) send(ctx context.Context, path string, req interface{}) (*SendMessageResponse, error) {
+	var out json.RawMessage
+	status, err := c.do(ctx, http.MethodPost, path, req, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusAccepted {
+		var scheduled ScheduledMessageResponse
+		if err := json.Unmarshal(out, &scheduled); err != nil {
+			return nil, err
+		}
+		return nil, &DeferredError{Scheduled: &scheduled}
+	}
+
+	var sent SendMessageResponse
+	if err := json.Unmarshal(out, &sent); err != nil {
+		return nil, err
+	}
+	return &sent, nil
+}
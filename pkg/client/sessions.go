@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) CreateSession(ctx context.Context, req *CreateSessionRequest) (*CreateSessionResponse, error) {
+	var out CreateSessionResponse
+	if _, err := c.do(ctx, http.MethodPost, "/sessions/create", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSession returns a session by ID or name.
+func (c *Client) GetSession(ctx context.Context, session string) (*SessionInfoResponse, error) {
+	var out SessionInfoResponse
+	if _, err := c.do(ctx, http.MethodGet, sessionPath(session, "/info"), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConnectSession starts connecting a session. The response carries a QR code
+// when the session still has to be paired.
+func (c *Client) ConnectSession(ctx context.Context, session string) (*ConnectSessionResponse, error) {
+	var out ConnectSessionResponse
+	if _, err := c.do(ctx, http.MethodPost, sessionPath(session, "/connect"), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,21 @@
+package client
+
+import "zpwoot/internal/adapters/server/contracts"
+
+// Sessions
+type (
+	CreateSessionRequest   = contracts.CreateSessionRequest
+	CreateSessionResponse  = contracts.CreateSessionResponse
+	SessionInfoResponse    = contracts.SessionInfoResponse
+	ConnectSessionResponse = contracts.ConnectSessionResponse
+	ProxyConfig            = contracts.ProxyConfig
+)
+
+// Messages
+type (
+	SendTextMessageRequest   = contracts.SendTextMessageRequest
+	SendMediaMessageRequest  = contracts.SendMediaMessageRequest
+	SendMessageResponse      = contracts.SendMessageResponse
+	ScheduledMessageResponse = contracts.ScheduledMessageResponse
+	ContextInfo              = contracts.ContextInfo
+)
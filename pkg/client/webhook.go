@@ -0,0 +1,118 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	SignatureHeader     = "X-Zpwoot-Signature"
+	EventHeader         = "X-Zpwoot-Event"
+	SchemaVersionHeader = "X-Zpwoot-Schema-Version"
+)
+
+const maxWebhookBody = 10 << 20
+
+var ErrInvalidSignature = errors.New("zpwoot: invalid webhook signature")
+
+// WebhookEvent is the envelope of a webhook delivery, whatever schema
+// version the webhook receives. Data is left raw because its shape depends
+// on Event. ID is only sent from schema version 2 on. Timezone is the
+// session's timezone, empty unless the session sets one.
+type WebhookEvent struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	ID            string          `json:"id,omitempty"`
+	Event         string          `json:"event"`
+	Category      string          `json:"category,omitempty"`
+	SessionID     string          `json:"sessionId"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Timezone      string          `json:"timezone,omitempty"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// wireEvent holds the fields of every schema version; version 2 renamed
+// event, sessionId and timestamp.
+type wireEvent struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	ID            string `json:"id"`
+	Event         string `json:"event"`
+	Type          string `json:"type"`
+	Category      string `json:"category"`
+	SessionID     string `json:"sessionId"`
+	Timezone      string `json:"timezone"`
+	Session       struct {
+		ID       string `json:"id"`
+		Timezone string `json:"timezone"`
+	} `json:"session"`
+	Timestamp  time.Time       `json:"timestamp"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (w *wireEvent) normalize() *WebhookEvent {
+	event := &WebhookEvent{
+		SchemaVersion: w.SchemaVersion,
+		ID:            w.ID,
+		Event:         w.Event,
+		Category:      w.Category,
+		SessionID:     w.SessionID,
+		Timestamp:     w.Timestamp,
+		Timezone:      w.Timezone,
+		Data:          w.Data,
+	}
+
+	if w.SchemaVersion >= 2 {
+		event.Event = w.Type
+		event.SessionID = w.Session.ID
+		event.Timestamp = w.OccurredAt
+		event.Timezone = w.Session.Timezone
+	}
+	if event.SchemaVersion == 0 {
+		event.SchemaVersion = 1
+	}
+
+	return event
+}
+
+// VerifySignature reports whether signature, the value of the
+// X-Zpwoot-Signature header, is the HMAC-SHA256 of body under secret.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// ParseWebhook reads a delivery, checks its signature when secret is set and
+// decodes the envelope of any schema version. Webhooks with a template are
+// not decoded into WebhookEvent; verify those with VerifySignature.
+func ParseWebhook(r *http.Request, secret string) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+
+	if secret != "" && !VerifySignature(secret, body, r.Header.Get(SignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event wireEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+
+	return event.normalize(), nil
+}
@@ -0,0 +1,1734 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: zpwoot/v1/zpwoot.proto
+
+package zpwootpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConnectionFilter int32
+
+const (
+	ConnectionFilter_CONNECTION_FILTER_UNSPECIFIED  ConnectionFilter = 0
+	ConnectionFilter_CONNECTION_FILTER_CONNECTED    ConnectionFilter = 1
+	ConnectionFilter_CONNECTION_FILTER_DISCONNECTED ConnectionFilter = 2
+)
+
+// Enum value maps for ConnectionFilter.
+var (
+	ConnectionFilter_name = map[int32]string{
+		0: "CONNECTION_FILTER_UNSPECIFIED",
+		1: "CONNECTION_FILTER_CONNECTED",
+		2: "CONNECTION_FILTER_DISCONNECTED",
+	}
+	ConnectionFilter_value = map[string]int32{
+		"CONNECTION_FILTER_UNSPECIFIED":  0,
+		"CONNECTION_FILTER_CONNECTED":    1,
+		"CONNECTION_FILTER_DISCONNECTED": 2,
+	}
+)
+
+func (x ConnectionFilter) Enum() *ConnectionFilter {
+	p := new(ConnectionFilter)
+	*p = x
+	return p
+}
+
+func (x ConnectionFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_zpwoot_v1_zpwoot_proto_enumTypes[0].Descriptor()
+}
+
+func (ConnectionFilter) Type() protoreflect.EnumType {
+	return &file_zpwoot_v1_zpwoot_proto_enumTypes[0]
+}
+
+func (x ConnectionFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionFilter.Descriptor instead.
+func (ConnectionFilter) EnumDescriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{0}
+}
+
+type SessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Session ID or name.
+	Session       string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SessionRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+type ProxyConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// http or socks5.
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Username      string `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyConfig) Reset() {
+	*x = ProxyConfig{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyConfig) ProtoMessage() {}
+
+func (x *ProxyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyConfig.ProtoReflect.Descriptor instead.
+func (*ProxyConfig) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProxyConfig) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProxyConfig) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ProxyConfig) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ProxyConfig) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ProxyConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CreateSessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Proxy *ProxyConfig           `protobuf:"bytes,2,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	// Connect right away and return the pairing QR code.
+	QrCode        bool `protobuf:"varint,3,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetProxy() *ProxyConfig {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+func (x *CreateSessionRequest) GetQrCode() bool {
+	if x != nil {
+		return x.QrCode
+	}
+	return false
+}
+
+type CreateSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsConnected   bool                   `protobuf:"varint,3,opt,name=is_connected,json=isConnected,proto3" json:"is_connected,omitempty"`
+	QrCode        string                 `protobuf:"bytes,4,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	QrCodeImage   string                 `protobuf:"bytes,5,opt,name=qr_code_image,json=qrCodeImage,proto3" json:"qr_code_image,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionResponse) Reset() {
+	*x = CreateSessionResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionResponse) ProtoMessage() {}
+
+func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateSessionResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetIsConnected() bool {
+	if x != nil {
+		return x.IsConnected
+	}
+	return false
+}
+
+func (x *CreateSessionResponse) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetQrCodeImage() string {
+	if x != nil {
+		return x.QrCodeImage
+	}
+	return ""
+}
+
+func (x *CreateSessionResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Session struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DeviceJid   string                 `protobuf:"bytes,3,opt,name=device_jid,json=deviceJid,proto3" json:"device_jid,omitempty"`
+	IsConnected bool                   `protobuf:"varint,4,opt,name=is_connected,json=isConnected,proto3" json:"is_connected,omitempty"`
+	// created, connecting, connected, disconnected, error or logged_out.
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ConnectionError string                 `protobuf:"bytes,6,opt,name=connection_error,json=connectionError,proto3" json:"connection_error,omitempty"`
+	Proxy           *ProxyConfig           `protobuf:"bytes,7,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	SendQueueDepth  int32                  `protobuf:"varint,8,opt,name=send_queue_depth,json=sendQueueDepth,proto3" json:"send_queue_depth,omitempty"`
+	Device          *DeviceInfo            `protobuf:"bytes,9,opt,name=device,proto3" json:"device,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ConnectedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	LoggedOutAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=logged_out_at,json=loggedOutAt,proto3" json:"logged_out_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceJid() string {
+	if x != nil {
+		return x.DeviceJid
+	}
+	return ""
+}
+
+func (x *Session) GetIsConnected() bool {
+	if x != nil {
+		return x.IsConnected
+	}
+	return false
+}
+
+func (x *Session) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Session) GetConnectionError() string {
+	if x != nil {
+		return x.ConnectionError
+	}
+	return ""
+}
+
+func (x *Session) GetProxy() *ProxyConfig {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+func (x *Session) GetSendQueueDepth() int32 {
+	if x != nil {
+		return x.SendQueueDepth
+	}
+	return 0
+}
+
+func (x *Session) GetDevice() *DeviceInfo {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLoggedOutAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LoggedOutAt
+	}
+	return nil
+}
+
+type DeviceInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Jid             string                 `protobuf:"bytes,1,opt,name=jid,proto3" json:"jid,omitempty"`
+	Lid             string                 `protobuf:"bytes,2,opt,name=lid,proto3" json:"lid,omitempty"`
+	PushName        string                 `protobuf:"bytes,3,opt,name=push_name,json=pushName,proto3" json:"push_name,omitempty"`
+	BusinessName    string                 `protobuf:"bytes,4,opt,name=business_name,json=businessName,proto3" json:"business_name,omitempty"`
+	Platform        string                 `protobuf:"bytes,5,opt,name=platform,proto3" json:"platform,omitempty"`
+	IsBusiness      bool                   `protobuf:"varint,6,opt,name=is_business,json=isBusiness,proto3" json:"is_business,omitempty"`
+	WaVersion       string                 `protobuf:"bytes,7,opt,name=wa_version,json=waVersion,proto3" json:"wa_version,omitempty"`
+	ConnectionState string                 `protobuf:"bytes,8,opt,name=connection_state,json=connectionState,proto3" json:"connection_state,omitempty"`
+	Reconnects      int32                  `protobuf:"varint,9,opt,name=reconnects,proto3" json:"reconnects,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeviceInfo) Reset() {
+	*x = DeviceInfo{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceInfo) ProtoMessage() {}
+
+func (x *DeviceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceInfo.ProtoReflect.Descriptor instead.
+func (*DeviceInfo) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeviceInfo) GetJid() string {
+	if x != nil {
+		return x.Jid
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetLid() string {
+	if x != nil {
+		return x.Lid
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetPushName() string {
+	if x != nil {
+		return x.PushName
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetBusinessName() string {
+	if x != nil {
+		return x.BusinessName
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetIsBusiness() bool {
+	if x != nil {
+		return x.IsBusiness
+	}
+	return false
+}
+
+func (x *DeviceInfo) GetWaVersion() string {
+	if x != nil {
+		return x.WaVersion
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetConnectionState() string {
+	if x != nil {
+		return x.ConnectionState
+	}
+	return ""
+}
+
+func (x *DeviceInfo) GetReconnects() int32 {
+	if x != nil {
+		return x.Reconnects
+	}
+	return 0
+}
+
+type ListSessionsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Connection ConnectionFilter       `protobuf:"varint,1,opt,name=connection,proto3,enum=zpwoot.v1.ConnectionFilter" json:"connection,omitempty"`
+	// Page size, 1 to 100; 0 uses the default.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page.
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSessionsRequest) GetConnection() ConnectionFilter {
+	if x != nil {
+		return x.Connection
+	}
+	return ConnectionFilter_CONNECTION_FILTER_UNSPECIFIED
+}
+
+func (x *ListSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSessionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListSessionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSessionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListSessionsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type ConnectSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	QrCode        string                 `protobuf:"bytes,3,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	QrCodeImage   string                 `protobuf:"bytes,4,opt,name=qr_code_image,json=qrCodeImage,proto3" json:"qr_code_image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectSessionResponse) Reset() {
+	*x = ConnectSessionResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectSessionResponse) ProtoMessage() {}
+
+func (x *ConnectSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectSessionResponse.ProtoReflect.Descriptor instead.
+func (*ConnectSessionResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConnectSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConnectSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConnectSessionResponse) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *ConnectSessionResponse) GetQrCodeImage() string {
+	if x != nil {
+		return x.QrCodeImage
+	}
+	return ""
+}
+
+type DisconnectSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectSessionResponse) Reset() {
+	*x = DisconnectSessionResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectSessionResponse) ProtoMessage() {}
+
+func (x *DisconnectSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectSessionResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectSessionResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{9}
+}
+
+type DeleteSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSessionResponse) Reset() {
+	*x = DeleteSessionResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionResponse) ProtoMessage() {}
+
+func (x *DeleteSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSessionResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{10}
+}
+
+type QRCode struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	QrCode         string                 `protobuf:"bytes,1,opt,name=qr_code,json=qrCode,proto3" json:"qr_code,omitempty"`
+	QrCodeImage    string                 `protobuf:"bytes,2,opt,name=qr_code_image,json=qrCodeImage,proto3" json:"qr_code_image,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	TimeoutSeconds int32                  `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QRCode) Reset() {
+	*x = QRCode{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCode) ProtoMessage() {}
+
+func (x *QRCode) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QRCode.ProtoReflect.Descriptor instead.
+func (*QRCode) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QRCode) GetQrCode() string {
+	if x != nil {
+		return x.QrCode
+	}
+	return ""
+}
+
+func (x *QRCode) GetQrCodeImage() string {
+	if x != nil {
+		return x.QrCodeImage
+	}
+	return ""
+}
+
+func (x *QRCode) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *QRCode) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type SendTextRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Session string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// JID or phone number of the recipient.
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Body string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	// ID of the message to reply to.
+	ReplyTo string `protobuf:"bytes,4,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	// reject or defer; empty uses the session's quiet hours policy.
+	QuietHours    string `protobuf:"bytes,5,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTextRequest) Reset() {
+	*x = SendTextRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTextRequest) ProtoMessage() {}
+
+func (x *SendTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTextRequest.ProtoReflect.Descriptor instead.
+func (*SendTextRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SendTextRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *SendTextRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SendTextRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SendTextRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *SendTextRequest) GetQuietHours() string {
+	if x != nil {
+		return x.QuietHours
+	}
+	return ""
+}
+
+type SendMediaRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Session  string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	To       string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	MediaUrl string                 `protobuf:"bytes,3,opt,name=media_url,json=mediaUrl,proto3" json:"media_url,omitempty"`
+	// image, audio, video or document.
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Caption       string `protobuf:"bytes,5,opt,name=caption,proto3" json:"caption,omitempty"`
+	Filename      string `protobuf:"bytes,6,opt,name=filename,proto3" json:"filename,omitempty"`
+	ReplyTo       string `protobuf:"bytes,7,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	QuietHours    string `protobuf:"bytes,8,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMediaRequest) Reset() {
+	*x = SendMediaRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMediaRequest) ProtoMessage() {}
+
+func (x *SendMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMediaRequest.ProtoReflect.Descriptor instead.
+func (*SendMediaRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SendMediaRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetMediaUrl() string {
+	if x != nil {
+		return x.MediaUrl
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *SendMediaRequest) GetQuietHours() string {
+	if x != nil {
+		return x.QuietHours
+	}
+	return ""
+}
+
+type SendLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       string                 `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	ReplyTo       string                 `protobuf:"bytes,7,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	QuietHours    string                 `protobuf:"bytes,8,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendLocationRequest) Reset() {
+	*x = SendLocationRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendLocationRequest) ProtoMessage() {}
+
+func (x *SendLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendLocationRequest.ProtoReflect.Descriptor instead.
+func (*SendLocationRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SendLocationRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *SendLocationRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SendLocationRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *SendLocationRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *SendLocationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SendLocationRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SendLocationRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *SendLocationRequest) GetQuietHours() string {
+	if x != nil {
+		return x.QuietHours
+	}
+	return ""
+}
+
+type SendMessageResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	To        string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// sent, or scheduled when quiet hours or the warm-up limit deferred it.
+	Status    string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Set when the send was deferred.
+	Scheduled     *ScheduledSend `protobuf:"bytes,5,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SendMessageResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SendMessageResponse) GetScheduled() *ScheduledSend {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type ScheduledSend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SendAt        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledSend) Reset() {
+	*x = ScheduledSend{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledSend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledSend) ProtoMessage() {}
+
+func (x *ScheduledSend) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledSend.ProtoReflect.Descriptor instead.
+func (*ScheduledSend) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScheduledSend) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledSend) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+func (x *ScheduledSend) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Session ID or name; empty streams the events of every session.
+	Session string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Event types (such as "message" or "connected") or categories (such as
+	// "messages" or "connection"); empty streams every event.
+	Events        []string `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StreamEventsRequest) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type Event struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	SessionId string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The event payload as JSON.
+	Data          []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_zpwoot_v1_zpwoot_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_zpwoot_v1_zpwoot_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_zpwoot_v1_zpwoot_proto protoreflect.FileDescriptor
+
+const file_zpwoot_v1_zpwoot_proto_rawDesc = "" +
+	"\n" +
+	"\x16zpwoot/v1/zpwoot.proto\x12\tzpwoot.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"*\n" +
+	"\x0eSessionRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\"\x81\x01\n" +
+	"\vProxyConfig\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\"q\n" +
+	"\x14CreateSessionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x05proxy\x18\x02 \x01(\v2\x16.zpwoot.v1.ProxyConfigR\x05proxy\x12\x17\n" +
+	"\aqr_code\x18\x03 \x01(\bR\x06qrCode\"\xd6\x01\n" +
+	"\x15CreateSessionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fis_connected\x18\x03 \x01(\bR\visConnected\x12\x17\n" +
+	"\aqr_code\x18\x04 \x01(\tR\x06qrCode\x12\"\n" +
+	"\rqr_code_image\x18\x05 \x01(\tR\vqrCodeImage\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xae\x04\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"device_jid\x18\x03 \x01(\tR\tdeviceJid\x12!\n" +
+	"\fis_connected\x18\x04 \x01(\bR\visConnected\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12)\n" +
+	"\x10connection_error\x18\x06 \x01(\tR\x0fconnectionError\x12,\n" +
+	"\x05proxy\x18\a \x01(\v2\x16.zpwoot.v1.ProxyConfigR\x05proxy\x12(\n" +
+	"\x10send_queue_depth\x18\b \x01(\x05R\x0esendQueueDepth\x12-\n" +
+	"\x06device\x18\t \x01(\v2\x15.zpwoot.v1.DeviceInfoR\x06device\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fconnected_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12>\n" +
+	"\rlogged_out_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vloggedOutAt\"\x99\x02\n" +
+	"\n" +
+	"DeviceInfo\x12\x10\n" +
+	"\x03jid\x18\x01 \x01(\tR\x03jid\x12\x10\n" +
+	"\x03lid\x18\x02 \x01(\tR\x03lid\x12\x1b\n" +
+	"\tpush_name\x18\x03 \x01(\tR\bpushName\x12#\n" +
+	"\rbusiness_name\x18\x04 \x01(\tR\fbusinessName\x12\x1a\n" +
+	"\bplatform\x18\x05 \x01(\tR\bplatform\x12\x1f\n" +
+	"\vis_business\x18\x06 \x01(\bR\n" +
+	"isBusiness\x12\x1d\n" +
+	"\n" +
+	"wa_version\x18\a \x01(\tR\twaVersion\x12)\n" +
+	"\x10connection_state\x18\b \x01(\tR\x0fconnectionState\x12\x1e\n" +
+	"\n" +
+	"reconnects\x18\t \x01(\x05R\n" +
+	"reconnects\"\x80\x01\n" +
+	"\x13ListSessionsRequest\x12;\n" +
+	"\n" +
+	"connection\x18\x01 \x01(\x0e2\x1b.zpwoot.v1.ConnectionFilterR\n" +
+	"connection\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\x98\x01\n" +
+	"\x14ListSessionsResponse\x12.\n" +
+	"\bsessions\x18\x01 \x03(\v2\x12.zpwoot.v1.SessionR\bsessions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\"\x89\x01\n" +
+	"\x16ConnectSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aqr_code\x18\x03 \x01(\tR\x06qrCode\x12\"\n" +
+	"\rqr_code_image\x18\x04 \x01(\tR\vqrCodeImage\"\x1b\n" +
+	"\x19DisconnectSessionResponse\"\x17\n" +
+	"\x15DeleteSessionResponse\"\xa9\x01\n" +
+	"\x06QRCode\x12\x17\n" +
+	"\aqr_code\x18\x01 \x01(\tR\x06qrCode\x12\"\n" +
+	"\rqr_code_image\x18\x02 \x01(\tR\vqrCodeImage\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\"\x8b\x01\n" +
+	"\x0fSendTextRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x19\n" +
+	"\breply_to\x18\x04 \x01(\tR\areplyTo\x12\x1f\n" +
+	"\vquiet_hours\x18\x05 \x01(\tR\n" +
+	"quietHours\"\xdf\x01\n" +
+	"\x10SendMediaRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1b\n" +
+	"\tmedia_url\x18\x03 \x01(\tR\bmediaUrl\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x18\n" +
+	"\acaption\x18\x05 \x01(\tR\acaption\x12\x1a\n" +
+	"\bfilename\x18\x06 \x01(\tR\bfilename\x12\x19\n" +
+	"\breply_to\x18\a \x01(\tR\areplyTo\x12\x1f\n" +
+	"\vquiet_hours\x18\b \x01(\tR\n" +
+	"quietHours\"\xe3\x01\n" +
+	"\x13SendLocationRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x19\n" +
+	"\breply_to\x18\a \x01(\tR\areplyTo\x12\x1f\n" +
+	"\vquiet_hours\x18\b \x01(\tR\n" +
+	"quietHours\"\xce\x01\n" +
+	"\x13SendMessageResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x126\n" +
+	"\tscheduled\x18\x05 \x01(\v2\x18.zpwoot.v1.ScheduledSendR\tscheduled\"l\n" +
+	"\rScheduledSend\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\asend_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"G\n" +
+	"\x13StreamEventsRequest\x12\x18\n" +
+	"\asession\x18\x01 \x01(\tR\asession\x12\x16\n" +
+	"\x06events\x18\x02 \x03(\tR\x06events\"\x98\x01\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data*z\n" +
+	"\x10ConnectionFilter\x12!\n" +
+	"\x1dCONNECTION_FILTER_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bCONNECTION_FILTER_CONNECTED\x10\x01\x12\"\n" +
+	"\x1eCONNECTION_FILTER_DISCONNECTED\x10\x022\xa1\x04\n" +
+	"\x0eSessionService\x12R\n" +
+	"\rCreateSession\x12\x1f.zpwoot.v1.CreateSessionRequest\x1a .zpwoot.v1.CreateSessionResponse\x12;\n" +
+	"\n" +
+	"GetSession\x12\x19.zpwoot.v1.SessionRequest\x1a\x12.zpwoot.v1.Session\x12O\n" +
+	"\fListSessions\x12\x1e.zpwoot.v1.ListSessionsRequest\x1a\x1f.zpwoot.v1.ListSessionsResponse\x12N\n" +
+	"\x0eConnectSession\x12\x19.zpwoot.v1.SessionRequest\x1a!.zpwoot.v1.ConnectSessionResponse\x12T\n" +
+	"\x11DisconnectSession\x12\x19.zpwoot.v1.SessionRequest\x1a$.zpwoot.v1.DisconnectSessionResponse\x12L\n" +
+	"\rDeleteSession\x12\x19.zpwoot.v1.SessionRequest\x1a .zpwoot.v1.DeleteSessionResponse\x129\n" +
+	"\tGetQRCode\x12\x19.zpwoot.v1.SessionRequest\x1a\x11.zpwoot.v1.QRCode2\xf2\x01\n" +
+	"\x0eMessageService\x12F\n" +
+	"\bSendText\x12\x1a.zpwoot.v1.SendTextRequest\x1a\x1e.zpwoot.v1.SendMessageResponse\x12H\n" +
+	"\tSendMedia\x12\x1b.zpwoot.v1.SendMediaRequest\x1a\x1e.zpwoot.v1.SendMessageResponse\x12N\n" +
+	"\fSendLocation\x12\x1e.zpwoot.v1.SendLocationRequest\x1a\x1e.zpwoot.v1.SendMessageResponse2R\n" +
+	"\fEventService\x12B\n" +
+	"\fStreamEvents\x12\x1e.zpwoot.v1.StreamEventsRequest\x1a\x10.zpwoot.v1.Event0\x01B\x1eZ\x1czpwoot/pkg/zpwootpb;zpwootpbb\x06proto3"
+
+var (
+	file_zpwoot_v1_zpwoot_proto_rawDescOnce sync.Once
+	file_zpwoot_v1_zpwoot_proto_rawDescData []byte
+)
+
+func file_zpwoot_v1_zpwoot_proto_rawDescGZIP() []byte {
+	file_zpwoot_v1_zpwoot_proto_rawDescOnce.Do(func() {
+		file_zpwoot_v1_zpwoot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_zpwoot_v1_zpwoot_proto_rawDesc), len(file_zpwoot_v1_zpwoot_proto_rawDesc)))
+	})
+	return file_zpwoot_v1_zpwoot_proto_rawDescData
+}
+
+var file_zpwoot_v1_zpwoot_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_zpwoot_v1_zpwoot_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_zpwoot_v1_zpwoot_proto_goTypes = []any{
+	(ConnectionFilter)(0),             // 0: zpwoot.v1.ConnectionFilter
+	(*SessionRequest)(nil),            // 1: zpwoot.v1.SessionRequest
+	(*ProxyConfig)(nil),               // 2: zpwoot.v1.ProxyConfig
+	(*CreateSessionRequest)(nil),      // 3: zpwoot.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),     // 4: zpwoot.v1.CreateSessionResponse
+	(*Session)(nil),                   // 5: zpwoot.v1.Session
+	(*DeviceInfo)(nil),                // 6: zpwoot.v1.DeviceInfo
+	(*ListSessionsRequest)(nil),       // 7: zpwoot.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),      // 8: zpwoot.v1.ListSessionsResponse
+	(*ConnectSessionResponse)(nil),    // 9: zpwoot.v1.ConnectSessionResponse
+	(*DisconnectSessionResponse)(nil), // 10: zpwoot.v1.DisconnectSessionResponse
+	(*DeleteSessionResponse)(nil),     // 11: zpwoot.v1.DeleteSessionResponse
+	(*QRCode)(nil),                    // 12: zpwoot.v1.QRCode
+	(*SendTextRequest)(nil),           // 13: zpwoot.v1.SendTextRequest
+	(*SendMediaRequest)(nil),          // 14: zpwoot.v1.SendMediaRequest
+	(*SendLocationRequest)(nil),       // 15: zpwoot.v1.SendLocationRequest
+	(*SendMessageResponse)(nil),       // 16: zpwoot.v1.SendMessageResponse
+	(*ScheduledSend)(nil),             // 17: zpwoot.v1.ScheduledSend
+	(*StreamEventsRequest)(nil),       // 18: zpwoot.v1.StreamEventsRequest
+	(*Event)(nil),                     // 19: zpwoot.v1.Event
+	(*timestamppb.Timestamp)(nil),     // 20: google.protobuf.Timestamp
+}
+var file_zpwoot_v1_zpwoot_proto_depIdxs = []int32{
+	2,  // 0: zpwoot.v1.CreateSessionRequest.proxy:type_name -> zpwoot.v1.ProxyConfig
+	20, // 1: zpwoot.v1.CreateSessionResponse.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: zpwoot.v1.Session.proxy:type_name -> zpwoot.v1.ProxyConfig
+	6,  // 3: zpwoot.v1.Session.device:type_name -> zpwoot.v1.DeviceInfo
+	20, // 4: zpwoot.v1.Session.created_at:type_name -> google.protobuf.Timestamp
+	20, // 5: zpwoot.v1.Session.updated_at:type_name -> google.protobuf.Timestamp
+	20, // 6: zpwoot.v1.Session.connected_at:type_name -> google.protobuf.Timestamp
+	20, // 7: zpwoot.v1.Session.logged_out_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: zpwoot.v1.ListSessionsRequest.connection:type_name -> zpwoot.v1.ConnectionFilter
+	5,  // 9: zpwoot.v1.ListSessionsResponse.sessions:type_name -> zpwoot.v1.Session
+	20, // 10: zpwoot.v1.QRCode.expires_at:type_name -> google.protobuf.Timestamp
+	20, // 11: zpwoot.v1.SendMessageResponse.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 12: zpwoot.v1.SendMessageResponse.scheduled:type_name -> zpwoot.v1.ScheduledSend
+	20, // 13: zpwoot.v1.ScheduledSend.send_at:type_name -> google.protobuf.Timestamp
+	20, // 14: zpwoot.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 15: zpwoot.v1.SessionService.CreateSession:input_type -> zpwoot.v1.CreateSessionRequest
+	1,  // 16: zpwoot.v1.SessionService.GetSession:input_type -> zpwoot.v1.SessionRequest
+	7,  // 17: zpwoot.v1.SessionService.ListSessions:input_type -> zpwoot.v1.ListSessionsRequest
+	1,  // 18: zpwoot.v1.SessionService.ConnectSession:input_type -> zpwoot.v1.SessionRequest
+	1,  // 19: zpwoot.v1.SessionService.DisconnectSession:input_type -> zpwoot.v1.SessionRequest
+	1,  // 20: zpwoot.v1.SessionService.DeleteSession:input_type -> zpwoot.v1.SessionRequest
+	1,  // 21: zpwoot.v1.SessionService.GetQRCode:input_type -> zpwoot.v1.SessionRequest
+	13, // 22: zpwoot.v1.MessageService.SendText:input_type -> zpwoot.v1.SendTextRequest
+	14, // 23: zpwoot.v1.MessageService.SendMedia:input_type -> zpwoot.v1.SendMediaRequest
+	15, // 24: zpwoot.v1.MessageService.SendLocation:input_type -> zpwoot.v1.SendLocationRequest
+	18, // 25: zpwoot.v1.EventService.StreamEvents:input_type -> zpwoot.v1.StreamEventsRequest
+	4,  // 26: zpwoot.v1.SessionService.CreateSession:output_type -> zpwoot.v1.CreateSessionResponse
+	5,  // 27: zpwoot.v1.SessionService.GetSession:output_type -> zpwoot.v1.Session
+	8,  // 28: zpwoot.v1.SessionService.ListSessions:output_type -> zpwoot.v1.ListSessionsResponse
+	9,  // 29: zpwoot.v1.SessionService.ConnectSession:output_type -> zpwoot.v1.ConnectSessionResponse
+	10, // 30: zpwoot.v1.SessionService.DisconnectSession:output_type -> zpwoot.v1.DisconnectSessionResponse
+	11, // 31: zpwoot.v1.SessionService.DeleteSession:output_type -> zpwoot.v1.DeleteSessionResponse
+	12, // 32: zpwoot.v1.SessionService.GetQRCode:output_type -> zpwoot.v1.QRCode
+	16, // 33: zpwoot.v1.MessageService.SendText:output_type -> zpwoot.v1.SendMessageResponse
+	16, // 34: zpwoot.v1.MessageService.SendMedia:output_type -> zpwoot.v1.SendMessageResponse
+	16, // 35: zpwoot.v1.MessageService.SendLocation:output_type -> zpwoot.v1.SendMessageResponse
+	19, // 36: zpwoot.v1.EventService.StreamEvents:output_type -> zpwoot.v1.Event
+	26, // [26:37] is the sub-list for method output_type
+	15, // [15:26] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_zpwoot_v1_zpwoot_proto_init() }
+func file_zpwoot_v1_zpwoot_proto_init() {
+	if File_zpwoot_v1_zpwoot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_zpwoot_v1_zpwoot_proto_rawDesc), len(file_zpwoot_v1_zpwoot_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_zpwoot_v1_zpwoot_proto_goTypes,
+		DependencyIndexes: file_zpwoot_v1_zpwoot_proto_depIdxs,
+		EnumInfos:         file_zpwoot_v1_zpwoot_proto_enumTypes,
+		MessageInfos:      file_zpwoot_v1_zpwoot_proto_msgTypes,
+	}.Build()
+	File_zpwoot_v1_zpwoot_proto = out.File
+	file_zpwoot_v1_zpwoot_proto_goTypes = nil
+	file_zpwoot_v1_zpwoot_proto_depIdxs = nil
+}
@@ -1,12 +1,18 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
+
+	"zpwoot/platform/secrets"
 )
 
 type Config struct {
@@ -22,8 +28,24 @@ type Config struct {
 
 	Webhook WebhookConfig `json:"webhook"`
 
+	Chatwoot ChatwootConfig `json:"chatwoot"`
+
+	MediaHost MediaHostConfig `json:"media_host"`
+
+	MediaScan MediaScanConfig `json:"media_scan"`
+
+	MediaProcessing MediaProcessingConfig `json:"media_processing"`
+
 	Security SecurityConfig `json:"security"`
 
+	Audit AuditConfig `json:"audit"`
+
+	Message MessageConfig `json:"message"`
+
+	Telemetry TelemetryConfig `json:"telemetry"`
+
+	Metrics MetricsConfig `json:"metrics"`
+
 	Environment string `json:"environment"`
 }
 
@@ -34,12 +56,48 @@ type AppConfig struct {
 }
 
 type ServerConfig struct {
-	Host         string `json:"host"`
-	Port         int    `json:"port"`
-	ReadTimeout  int    `json:"read_timeout"`
-	WriteTimeout int    `json:"write_timeout"`
-	IdleTimeout  int    `json:"idle_timeout"`
-	BaseURL      string `json:"base_url"`
+	Host           string `json:"host"`
+	Port           int    `json:"port"`
+	ReadTimeout    int    `json:"read_timeout"`
+	WriteTimeout   int    `json:"write_timeout"`
+	IdleTimeout    int    `json:"idle_timeout"`
+	RequestTimeout int    `json:"request_timeout"`
+	BaseURL        string `json:"base_url"`
+
+	// GRPCPort serves the gRPC API on SERVER_HOST alongside HTTP. Zero
+	// leaves it off.
+	GRPCPort int `json:"grpc_port"`
+
+	// TLSCertFile and TLSKeyFile, set together, make the API serve HTTPS
+	// itself instead of behind a TLS-terminating proxy.
+	TLSCertFile string `json:"tls_cert_file"`
+	TLSKeyFile  string `json:"tls_key_file"`
+
+	// HTTP2 serves HTTP/2, negotiated over TLS or as cleartext h2c without
+	// it. Off, the API speaks HTTP/1.1 only.
+	HTTP2 bool `json:"http2"`
+
+	// Compression gzips responses for clients that accept it, at
+	// CompressionLevel (1 fastest to 9 smallest).
+	Compression      bool `json:"compression"`
+	CompressionLevel int  `json:"compression_level"`
+
+	CORS CORSConfig `json:"cors"`
+}
+
+// CORSConfig controls which browser origins may call the API. Changes take
+// effect on restart.
+type CORSConfig struct {
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	ExposedHeaders   []string `json:"exposed_headers"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	MaxAge           int      `json:"max_age"`
+}
+
+// TLSEnabled reports whether the API serves HTTPS itself.
+func (s ServerConfig) TLSEnabled() bool {
+	return s.TLSCertFile != "" && s.TLSKeyFile != ""
 }
 
 type LogConfig struct {
@@ -54,27 +112,168 @@ type DatabaseConfig struct {
 	MaxOpenConns    int    `json:"max_open_conns"`
 	MaxIdleConns    int    `json:"max_idle_conns"`
 	ConnMaxLifetime int    `json:"conn_max_lifetime"`
+	ConnMaxIdleTime int    `json:"conn_max_idle_time"`
 	MigrationsPath  string `json:"migrations_path"`
 	AutoMigrate     bool   `json:"auto_migrate"`
+
+	// ConnectRetries is how many times opening a connection is tried while
+	// the database is unreachable, waiting ConnectBackoffMs between tries
+	// and doubling up to ConnectMaxBackoffMs.
+	ConnectRetries      int `json:"connect_retries"`
+	ConnectBackoffMs    int `json:"connect_backoff_ms"`
+	ConnectMaxBackoffMs int `json:"connect_max_backoff_ms"`
+
+	// ReplicaURL points heavy reads (history, search, stats) at a read
+	// replica. It is checked every ReplicaCheckInterval seconds and reads
+	// fall back to the primary while it is down or more than ReplicaMaxLag
+	// seconds behind; zero accepts any lag.
+	ReplicaURL           string `json:"replica_url"`
+	ReplicaCheckInterval int    `json:"replica_check_interval"`
+	ReplicaMaxLag        int    `json:"replica_max_lag"`
 }
 
 type WhatsAppConfig struct {
-	LogLevel     string `json:"log_level"`
-	StoreDir     string `json:"store_dir"`
-	MediaDir     string `json:"media_dir"`
-	QRTimeout    int    `json:"qr_timeout"`
-	PairTimeout  int    `json:"pair_timeout"`
-	ReconnectMax int    `json:"reconnect_max"`
+	LogLevel         string `json:"log_level"`
+	StoreDir         string `json:"store_dir"`
+	MediaDir         string `json:"media_dir"`
+	QRTimeout        int    `json:"qr_timeout"`
+	PairTimeout      int    `json:"pair_timeout"`
+	ReconnectMax     int    `json:"reconnect_max"`
+	OperationTimeout int    `json:"operation_timeout"`
+	QRLogoPath       string `json:"qr_logo_path"`
+	DedupTTLHours    int    `json:"dedup_ttl_hours"`
+
+	// SendQueueDepth caps the sends a session accepts through the API and
+	// has not finished; more answer 429. Zero disables the cap.
+	SendQueueDepth int `json:"send_queue_depth"`
+
+	// MaxSessions caps the sessions this instance runs connected at once;
+	// connecting more fails with 503 so they can go to another instance.
+	// SessionMaxPendingEvents caps the webhook deliveries a session runs at
+	// once, bounding the goroutines and memory a flood of events can take;
+	// beyond it the session's event handling waits. Zero disables either.
+	MaxSessions             int `json:"max_sessions"`
+	SessionMaxPendingEvents int `json:"session_max_pending_events"`
+
+	// NumberCheckTTLHours is how long "is this number on WhatsApp?"
+	// lookups are reused before asking WhatsApp again. Zero disables the
+	// cache.
+	NumberCheckTTLHours int `json:"number_check_ttl_hours"`
+
+	// AvatarTTLHours is how long a contact's cached profile picture is
+	// served before WhatsApp is asked whether it changed. Change events
+	// update it in between. Zero asks on every lookup.
+	AvatarTTLHours int `json:"avatar_ttl_hours"`
+
+	// GroupRefreshHours is how often the names and topics of groups with
+	// messages in the last GroupRefreshActiveDays days are fetched again,
+	// catching renames the session missed. Zero turns the refresh off;
+	// group info events still update them.
+	GroupRefreshHours      int `json:"group_refresh_hours"`
+	GroupRefreshActiveDays int `json:"group_refresh_active_days"`
+
+	// VerifyRecipients looks up phone-number recipients before sending and
+	// rejects those not on WhatsApp.
+	VerifyRecipients bool `json:"verify_recipients"`
+
+	// DefaultCountryCode is the calling code given to phone numbers sent to
+	// without one, such as "55" for Brazil. Empty requires every number to
+	// carry its country code.
+	DefaultCountryCode string `json:"default_country_code"`
+
+	// Decorators around every send: SendLog logs each one, SendRetries
+	// retries those that failed because the socket was down, waiting
+	// SendRetryBackoffMs and doubling, and SendIntervalMs spaces each
+	// session's sends at least that far apart.
+	SendLog            bool `json:"send_log"`
+	SendRetries        int  `json:"send_retries"`
+	SendRetryBackoffMs int  `json:"send_retry_backoff_ms"`
+	SendIntervalMs     int  `json:"send_interval_ms"`
+
+	// UploadRetries is how many more times a failed media upload is tried
+	// before the send fails and is kept for POST .../retry.
+	UploadRetries int `json:"upload_retries"`
+
+	StartupReconnect StartupReconnectConfig `json:"startup_reconnect"`
+
+	// TestMode replaces WhatsApp with an in-memory fake gateway and mounts
+	// the /test routes for scripting it. TestAutoPair pairs fake sessions
+	// as soon as they connect.
+	TestMode     bool `json:"test_mode"`
+	TestAutoPair bool `json:"test_auto_pair"`
+}
+
+// StartupReconnectConfig paces the reconnection of paired sessions when the
+// process starts. Sessions still connecting after Timeout seconds continue
+// in the background.
+type StartupReconnectConfig struct {
+	Delay       int `json:"delay"`
+	MaxSessions int `json:"max_sessions"`
+	Workers     int `json:"workers"`
+	SpacingMs   int `json:"spacing_ms"`
+	Timeout     int `json:"timeout"`
 }
 
 type WebhookConfig struct {
-	GlobalURL  string `json:"global_url"`
-	Secret     string `json:"secret"`
-	Timeout    int    `json:"timeout"`
-	RetryMax   int    `json:"retry_max"`
-	RetryDelay int    `json:"retry_delay"`
-	VerifySSL  bool   `json:"verify_ssl"`
-	UserAgent  string `json:"user_agent"`
+	GlobalURL     string `json:"global_url"`
+	Secret        string `json:"secret"`
+	SchemaVersion int    `json:"schema_version"`
+	Format        string `json:"format"`
+	Timeout       int    `json:"timeout"`
+	RetryMax      int    `json:"retry_max"`
+	RetryDelay    int    `json:"retry_delay"`
+	VerifySSL     bool   `json:"verify_ssl"`
+	UserAgent     string `json:"user_agent"`
+}
+
+// ChatwootConfig points at the Chatwoot installation whose account webhooks
+// are routed to this instance. The API token is only needed for calls back
+// into Chatwoot, such as reopening conversations.
+type ChatwootConfig struct {
+	URL      string `json:"url"`
+	APIToken string `json:"api_token"`
+	Timeout  int    `json:"timeout"`
+}
+
+// MediaHostConfig publishes auto-downloaded inbound media under links sent
+// in message webhooks. Backend "local" serves the files from this instance
+// under links signed with Secret; "s3" uploads them to an S3-compatible
+// bucket and links presigned URLs. An empty backend sends no links.
+type MediaHostConfig struct {
+	Backend    string   `json:"backend"`
+	TTLMinutes int      `json:"ttl_minutes"`
+	Secret     string   `json:"-"`
+	S3         S3Config `json:"s3"`
+}
+
+// S3Config addresses a bucket path-style. An empty endpoint means AWS in
+// Region.
+type S3Config struct {
+	Endpoint  string `json:"endpoint"`
+	Region    string `json:"region"`
+	Bucket    string `json:"bucket"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"-"`
+}
+
+// MediaScanConfig scans inbound media before it is stored or linked in
+// webhooks. URL is a ClamAV REST endpoint (http/https, the file posted as
+// multipart "file") or an ICAP service (icap://host:1344/service). Policy
+// applies to sessions that set none: block, tag or ignore. An empty URL
+// scans nothing.
+type MediaScanConfig struct {
+	URL     string `json:"url"`
+	Timeout int    `json:"timeout"`
+	Policy  string `json:"policy"`
+}
+
+// MediaProcessingConfig sizes the pool that runs CPU-heavy media work
+// (resizing images, inspecting audio). Workers run jobs in parallel;
+// QueueDepth caps the jobs each session may have waiting, beyond which its
+// requests answer 429.
+type MediaProcessingConfig struct {
+	Workers    int `json:"workers"`
+	QueueDepth int `json:"queue_depth"`
 }
 
 type SecurityConfig struct {
@@ -82,6 +281,46 @@ type SecurityConfig struct {
 	AllowedOrigins []string `json:"allowed_origins"`
 	RateLimit      int      `json:"rate_limit"`
 	RateLimitBurst int      `json:"rate_limit_burst"`
+
+	// MasterKey encrypts webhook secrets and proxy credentials in the
+	// database, written as id:base64 of 32 bytes. PreviousKeys still open
+	// values sealed before a rotation until they are re-encrypted. An empty
+	// MasterKey stores them as plaintext.
+	MasterKey    string   `json:"-"`
+	PreviousKeys []string `json:"-"`
+}
+
+type AuditConfig struct {
+	Enabled       bool `json:"enabled"`
+	RetentionDays int  `json:"retention_days"`
+}
+
+// MessageConfig controls how long stored messages are kept. RetentionDays 0
+// keeps them forever; sessions may override it in their settings.
+// PartitionsAhead is how many monthly partitions exist beyond the current
+// month.
+type MessageConfig struct {
+	RetentionDays   int `json:"retention_days"`
+	PartitionsAhead int `json:"partitions_ahead"`
+}
+
+// TelemetryConfig controls OpenTelemetry tracing. Spans are exported over
+// OTLP/HTTP; an empty endpoint falls back to the exporter's own defaults and
+// the standard OTEL_EXPORTER_OTLP_* variables.
+type TelemetryConfig struct {
+	Enabled     bool    `json:"enabled"`
+	ServiceName string  `json:"service_name"`
+	Endpoint    string  `json:"endpoint"`
+	SampleRatio float64 `json:"sample_ratio"`
+}
+
+// MetricsConfig controls the SLI series served at /metrics. The objectives
+// are the SLO targets, as fractions of good events, that error budget burn
+// rates are measured against.
+type MetricsConfig struct {
+	Enabled               bool    `json:"enabled"`
+	AvailabilityObjective float64 `json:"availability_objective"`
+	WebhookObjective      float64 `json:"webhook_objective"`
 }
 
 func Load() (*Config, error) {
@@ -90,6 +329,10 @@ func Load() (*Config, error) {
 
 	}
 
+	return fromEnv()
+}
+
+func fromEnv() (*Config, error) {
 	config := &Config{
 		App: AppConfig{
 			Name:    getEnv("APP_NAME", "zpwoot"),
@@ -98,12 +341,28 @@ func Load() (*Config, error) {
 		},
 
 		Server: ServerConfig{
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
-			Port:         getEnvInt("PORT", 8080),
-			ReadTimeout:  getEnvInt("SERVER_READ_TIMEOUT", 30),
-			WriteTimeout: getEnvInt("SERVER_WRITE_TIMEOUT", 30),
-			IdleTimeout:  getEnvInt("SERVER_IDLE_TIMEOUT", 120),
-			BaseURL:      getEnv("SERVER_BASE_URL", "http://localhost:8080"),
+			Host:           getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:           getEnvInt("PORT", 8080),
+			ReadTimeout:    getEnvInt("SERVER_READ_TIMEOUT", 30),
+			WriteTimeout:   getEnvInt("SERVER_WRITE_TIMEOUT", 30),
+			IdleTimeout:    getEnvInt("SERVER_IDLE_TIMEOUT", 120),
+			RequestTimeout: getEnvInt("SERVER_REQUEST_TIMEOUT", 25),
+			BaseURL:        getEnv("SERVER_BASE_URL", "http://localhost:8080"),
+			GRPCPort:       getEnvInt("GRPC_PORT", 0),
+
+			TLSCertFile:      getEnv("SERVER_TLS_CERT_FILE", ""),
+			TLSKeyFile:       getEnv("SERVER_TLS_KEY_FILE", ""),
+			HTTP2:            getEnvBool("SERVER_HTTP2", false),
+			Compression:      getEnvBool("SERVER_COMPRESSION", true),
+			CompressionLevel: getEnvInt("SERVER_COMPRESSION_LEVEL", 5),
+
+			CORS: CORSConfig{
+				AllowedOrigins:   getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"*"}),
+				AllowedHeaders:   getEnvSlice("CORS_ALLOWED_HEADERS", []string{"*"}),
+				ExposedHeaders:   getEnvSlice("CORS_EXPOSED_HEADERS", []string{"Link"}),
+				AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+				MaxAge:           getEnvInt("CORS_MAX_AGE", 300),
+			},
 		},
 
 		Log: LogConfig{
@@ -118,27 +377,100 @@ func Load() (*Config, error) {
 			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
 			ConnMaxLifetime: getEnvInt("DB_CONN_MAX_LIFETIME", 300),
+			ConnMaxIdleTime: getEnvInt("DB_CONN_MAX_IDLE_TIME", 0),
 			MigrationsPath:  getEnv("DB_MIGRATIONS_PATH", "internal/adapters/database/migrations"),
 			AutoMigrate:     getEnvBool("DB_AUTO_MIGRATE", true),
+
+			ConnectRetries:      getEnvInt("DB_CONNECT_RETRIES", 5),
+			ConnectBackoffMs:    getEnvInt("DB_CONNECT_BACKOFF_MS", 200),
+			ConnectMaxBackoffMs: getEnvInt("DB_CONNECT_MAX_BACKOFF_MS", 5000),
+
+			ReplicaURL:           getEnv("DATABASE_REPLICA_URL", ""),
+			ReplicaCheckInterval: getEnvInt("DB_REPLICA_CHECK_INTERVAL", 5),
+			ReplicaMaxLag:        getEnvInt("DB_REPLICA_MAX_LAG", 30),
 		},
 
 		WhatsApp: WhatsAppConfig{
-			LogLevel:     getEnv("WA_LOG_LEVEL", "INFO"),
-			StoreDir:     getEnv("WA_STORE_DIR", "./data/store"),
-			MediaDir:     getEnv("WA_MEDIA_DIR", "./data/media"),
-			QRTimeout:    getEnvInt("WA_QR_TIMEOUT", 120),
-			PairTimeout:  getEnvInt("WA_PAIR_TIMEOUT", 60),
-			ReconnectMax: getEnvInt("WA_RECONNECT_MAX", 5),
+			LogLevel:         getEnv("WA_LOG_LEVEL", "INFO"),
+			StoreDir:         getEnv("WA_STORE_DIR", "./data/store"),
+			MediaDir:         getEnv("WA_MEDIA_DIR", "./data/media"),
+			QRTimeout:        getEnvInt("WA_QR_TIMEOUT", 120),
+			PairTimeout:      getEnvInt("WA_PAIR_TIMEOUT", 60),
+			ReconnectMax:     getEnvInt("WA_RECONNECT_MAX", 5),
+			OperationTimeout: getEnvInt("WA_OPERATION_TIMEOUT", 20),
+			QRLogoPath:       getEnv("WA_QR_LOGO_PATH", ""),
+			DedupTTLHours:    getEnvInt("WA_DEDUP_TTL_HOURS", 24),
+			SendQueueDepth:   getEnvInt("WA_SEND_QUEUE_DEPTH", 50),
+
+			MaxSessions:             getEnvInt("WA_MAX_SESSIONS", 0),
+			SessionMaxPendingEvents: getEnvInt("WA_SESSION_MAX_PENDING_EVENTS", 256),
+
+			NumberCheckTTLHours: getEnvInt("WA_NUMBER_CHECK_TTL_HOURS", 24),
+			AvatarTTLHours:      getEnvInt("WA_AVATAR_TTL_HOURS", 24),
+			VerifyRecipients:    getEnvBool("WA_VERIFY_RECIPIENTS", false),
+			SendLog:             getEnvBool("WA_SEND_LOG", false),
+			SendRetries:         getEnvInt("WA_SEND_RETRIES", 0),
+			SendRetryBackoffMs:  getEnvInt("WA_SEND_RETRY_BACKOFF_MS", 1000),
+			SendIntervalMs:      getEnvInt("WA_SEND_INTERVAL_MS", 0),
+			UploadRetries:       getEnvInt("WA_UPLOAD_RETRIES", 2),
+
+			GroupRefreshHours:      getEnvInt("WA_GROUP_REFRESH_HOURS", 6),
+			GroupRefreshActiveDays: getEnvInt("WA_GROUP_REFRESH_ACTIVE_DAYS", 7),
+
+			DefaultCountryCode: strings.TrimPrefix(getEnv("WA_DEFAULT_COUNTRY_CODE", ""), "+"),
+
+			StartupReconnect: StartupReconnectConfig{
+				Delay:       getEnvInt("WA_STARTUP_RECONNECT_DELAY", 1),
+				MaxSessions: getEnvInt("WA_STARTUP_RECONNECT_MAX_SESSIONS", 0),
+				Workers:     getEnvInt("WA_STARTUP_RECONNECT_WORKERS", 5),
+				SpacingMs:   getEnvInt("WA_STARTUP_RECONNECT_SPACING_MS", 500),
+				Timeout:     getEnvInt("WA_STARTUP_RECONNECT_TIMEOUT", 90),
+			},
+
+			TestMode:     getEnvBool("WA_TEST_MODE", false),
+			TestAutoPair: getEnvBool("WA_TEST_AUTO_PAIR", true),
 		},
 
 		Webhook: WebhookConfig{
-			GlobalURL:  getEnv("GLOBAL_WEBHOOK_URL", ""),
-			Secret:     getEnv("WEBHOOK_SECRET", ""),
-			Timeout:    getEnvInt("WEBHOOK_TIMEOUT", 30),
-			RetryMax:   getEnvInt("WEBHOOK_RETRY_MAX", 3),
-			RetryDelay: getEnvInt("WEBHOOK_RETRY_DELAY", 5),
-			VerifySSL:  getEnvBool("WEBHOOK_VERIFY_SSL", true),
-			UserAgent:  getEnv("WEBHOOK_USER_AGENT", "zpwoot/1.0"),
+			GlobalURL:     getEnv("GLOBAL_WEBHOOK_URL", ""),
+			Secret:        getEnv("WEBHOOK_SECRET", ""),
+			SchemaVersion: getEnvInt("WEBHOOK_SCHEMA_VERSION", 1),
+			Format:        getEnv("WEBHOOK_FORMAT", "full"),
+			Timeout:       getEnvInt("WEBHOOK_TIMEOUT", 30),
+			RetryMax:      getEnvInt("WEBHOOK_RETRY_MAX", 3),
+			RetryDelay:    getEnvInt("WEBHOOK_RETRY_DELAY", 5),
+			VerifySSL:     getEnvBool("WEBHOOK_VERIFY_SSL", true),
+			UserAgent:     getEnv("WEBHOOK_USER_AGENT", "zpwoot/1.0"),
+		},
+
+		Chatwoot: ChatwootConfig{
+			URL:      getEnv("CHATWOOT_URL", ""),
+			APIToken: getEnv("CHATWOOT_API_TOKEN", ""),
+			Timeout:  getEnvInt("CHATWOOT_TIMEOUT", 15),
+		},
+
+		MediaHost: MediaHostConfig{
+			Backend:    getEnv("MEDIA_HOST_BACKEND", ""),
+			TTLMinutes: getEnvInt("MEDIA_HOST_URL_TTL_MINUTES", 60),
+			Secret:     getEnv("MEDIA_HOST_SECRET", ""),
+			S3: S3Config{
+				Endpoint:  getEnv("MEDIA_S3_ENDPOINT", ""),
+				Region:    getEnv("MEDIA_S3_REGION", "us-east-1"),
+				Bucket:    getEnv("MEDIA_S3_BUCKET", ""),
+				AccessKey: getEnv("MEDIA_S3_ACCESS_KEY", ""),
+				SecretKey: getEnv("MEDIA_S3_SECRET_KEY", ""),
+			},
+		},
+
+		MediaScan: MediaScanConfig{
+			URL:     getEnv("MEDIA_SCAN_URL", ""),
+			Timeout: getEnvInt("MEDIA_SCAN_TIMEOUT", 30),
+			Policy:  getEnv("MEDIA_SCAN_POLICY", "tag"),
+		},
+
+		MediaProcessing: MediaProcessingConfig{
+			Workers:    getEnvInt("MEDIA_WORKERS", runtime.NumCPU()),
+			QueueDepth: getEnvInt("MEDIA_QUEUE_DEPTH", 20),
 		},
 
 		Security: SecurityConfig{
@@ -146,11 +478,43 @@ func Load() (*Config, error) {
 			AllowedOrigins: getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"*"}),
 			RateLimit:      getEnvInt("RATE_LIMIT", 100),
 			RateLimitBurst: getEnvInt("RATE_LIMIT_BURST", 10),
+			MasterKey:      getEnv("SECRETS_MASTER_KEY", ""),
+			PreviousKeys:   getEnvSlice("SECRETS_PREVIOUS_KEYS", nil),
+		},
+
+		Audit: AuditConfig{
+			Enabled:       getEnvBool("AUDIT_ENABLED", true),
+			RetentionDays: getEnvInt("AUDIT_RETENTION_DAYS", 90),
+		},
+
+		Message: MessageConfig{
+			RetentionDays:   getEnvInt("MESSAGE_RETENTION_DAYS", 0),
+			PartitionsAhead: getEnvInt("MESSAGE_PARTITIONS_AHEAD", 3),
+		},
+
+		Telemetry: TelemetryConfig{
+			Enabled:     getEnvBool("OTEL_ENABLED", false),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "zpwoot"),
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			SampleRatio: getEnvFloat("OTEL_TRACES_SAMPLER_ARG", 1.0),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled:               getEnvBool("METRICS_ENABLED", true),
+			AvailabilityObjective: getEnvFloat("METRICS_SLO_AVAILABILITY", 0.995),
+			WebhookObjective:      getEnvFloat("METRICS_SLO_WEBHOOK", 0.99),
 		},
 
 		Environment: getEnv("NODE_ENV", "development"),
 	}
 
+	// Local links stay valid across restarts when signed with a stable
+	// secret. Without one the key is derived from the API key, so a signed
+	// link never exposes a value that also authenticates API calls.
+	if config.MediaHost.Secret == "" {
+		config.MediaHost.Secret = deriveKey(config.Security.APIKey, "media-host")
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -158,19 +522,177 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
+// deriveKey derives a purpose-bound key from secret as HMAC-SHA256(secret,
+// purpose), hex encoded.
+func deriveKey(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func (c *Config) Validate() error {
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
 
+	if c.Server.GRPCPort < 0 || c.Server.GRPCPort > 65535 || (c.Server.GRPCPort != 0 && c.Server.GRPCPort == c.Server.Port) {
+		return fmt.Errorf("invalid gRPC port: %d", c.Server.GRPCPort)
+	}
+
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("TLS needs both a certificate and a key file")
+	}
+
+	if c.Server.Compression && (c.Server.CompressionLevel < 1 || c.Server.CompressionLevel > 9) {
+		return fmt.Errorf("compression level must be between 1 and 9")
+	}
+
+	if c.Server.CORS.MaxAge < 0 {
+		return fmt.Errorf("CORS max age must not be negative")
+	}
+
+	// Browsers refuse credentials from a wildcard origin, and echoing any
+	// origin back instead would let every site call the API as the user.
+	if c.Server.CORS.AllowCredentials {
+		for _, origin := range c.Server.CORS.AllowedOrigins {
+			if strings.Contains(origin, "*") {
+				return fmt.Errorf("CORS credentials need explicit origins, not %q", origin)
+			}
+		}
+	}
+
+	if c.Server.RequestTimeout < 0 || c.WhatsApp.OperationTimeout < 0 {
+		return fmt.Errorf("timeouts must not be negative")
+	}
+
+	if c.WhatsApp.DedupTTLHours < 0 {
+		return fmt.Errorf("dedup TTL must not be negative")
+	}
+
+	if c.WhatsApp.SendQueueDepth < 0 {
+		return fmt.Errorf("send queue depth must not be negative")
+	}
+
+	if c.WhatsApp.MaxSessions < 0 || c.WhatsApp.SessionMaxPendingEvents < 0 {
+		return fmt.Errorf("session limits must not be negative")
+	}
+
+	if c.WhatsApp.SendRetries < 0 || c.WhatsApp.SendRetryBackoffMs < 0 || c.WhatsApp.SendIntervalMs < 0 {
+		return fmt.Errorf("send retries, retry backoff and send interval must not be negative")
+	}
+
+	if c.WhatsApp.UploadRetries < 0 {
+		return fmt.Errorf("upload retries must not be negative")
+	}
+
+	if c.WhatsApp.NumberCheckTTLHours < 0 {
+		return fmt.Errorf("number check TTL must not be negative")
+	}
+
+	if c.WhatsApp.AvatarTTLHours < 0 {
+		return fmt.Errorf("avatar TTL must not be negative")
+	}
+
+	if c.WhatsApp.GroupRefreshHours < 0 || c.WhatsApp.GroupRefreshActiveDays < 0 {
+		return fmt.Errorf("group refresh settings must not be negative")
+	}
+
+	if code := c.WhatsApp.DefaultCountryCode; code != "" && (len(code) > 3 || strings.Trim(code, "0123456789") != "" || code[0] == '0') {
+		return fmt.Errorf("default country code must be 1 to 3 digits")
+	}
+
+	reconnect := c.WhatsApp.StartupReconnect
+	if reconnect.Delay < 0 || reconnect.MaxSessions < 0 || reconnect.SpacingMs < 0 || reconnect.Timeout < 0 {
+		return fmt.Errorf("startup reconnect settings must not be negative")
+	}
+
+	if reconnect.Workers < 1 {
+		return fmt.Errorf("startup reconnect workers must be at least 1")
+	}
+
 	if c.Database.URL == "" {
 		return fmt.Errorf("database URL is required")
 	}
 
+	db := c.Database
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 || db.ConnMaxLifetime < 0 || db.ConnMaxIdleTime < 0 ||
+		db.ConnectBackoffMs < 0 || db.ConnectMaxBackoffMs < 0 {
+		return fmt.Errorf("database pool settings must not be negative")
+	}
+
+	if db.ReplicaURL != "" && (db.ReplicaCheckInterval < 1 || db.ReplicaMaxLag < 0) {
+		return fmt.Errorf("replica check interval must be at least 1 second and max lag must not be negative")
+	}
+
+	if db.ConnectRetries < 1 {
+		return fmt.Errorf("database connect retries must be at least 1")
+	}
+
+	if c.Webhook.SchemaVersion < 1 || c.Webhook.SchemaVersion > 2 {
+		return fmt.Errorf("webhook schema version must be 1 or 2")
+	}
+	if c.Webhook.Format != "full" && c.Webhook.Format != "simple" {
+		return fmt.Errorf("webhook format must be full or simple")
+	}
+
+	if c.Message.RetentionDays < 0 || c.Message.PartitionsAhead < 0 {
+		return fmt.Errorf("message retention settings must not be negative")
+	}
+
+	if c.Telemetry.SampleRatio < 0 || c.Telemetry.SampleRatio > 1 {
+		return fmt.Errorf("trace sample ratio must be between 0 and 1")
+	}
+
+	for _, objective := range []float64{c.Metrics.AvailabilityObjective, c.Metrics.WebhookObjective} {
+		if objective <= 0 || objective >= 1 {
+			return fmt.Errorf("SLO objectives must be between 0 and 1, exclusive")
+		}
+	}
+
+	switch c.MediaHost.Backend {
+	case "":
+	case "local", "s3":
+		if c.MediaHost.TTLMinutes < 1 {
+			return fmt.Errorf("media host URL TTL must be at least 1 minute")
+		}
+	default:
+		return fmt.Errorf("media host backend must be local or s3")
+	}
+
+	if s3 := c.MediaHost.S3; c.MediaHost.Backend == "s3" && (s3.Bucket == "" || s3.AccessKey == "" || s3.SecretKey == "") {
+		return fmt.Errorf("S3 media host requires bucket, access key and secret key")
+	}
+
+	switch c.MediaScan.Policy {
+	case "block", "tag", "ignore":
+	default:
+		return fmt.Errorf("media scan policy must be block, tag or ignore")
+	}
+
+	if c.MediaProcessing.Workers < 1 || c.MediaProcessing.QueueDepth < 1 {
+		return fmt.Errorf("media workers and queue depth must be at least 1")
+	}
+
+	if c.MediaScan.URL != "" && c.MediaScan.Timeout < 1 {
+		return fmt.Errorf("media scan timeout must be at least 1 second")
+	}
+
 	if c.Security.APIKey == "" {
 		return fmt.Errorf("API key is required")
 	}
 
+	if len(c.Security.PreviousKeys) > 0 && c.Security.MasterKey == "" {
+		return fmt.Errorf("previous secrets keys need a master key to rotate to")
+	}
+	for _, spec := range append([]string{c.Security.MasterKey}, c.Security.PreviousKeys...) {
+		if spec == "" {
+			continue
+		}
+		if _, err := secrets.ParseLocalKey(spec); err != nil {
+			return fmt.Errorf("invalid secrets key: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -190,6 +712,10 @@ func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
 
+func (c *Config) GetGRPCAddress() string {
+	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.GRPCPort)
+}
+
 func (c *Config) HasWebhookSecret() bool {
 	return c.Webhook.Secret != ""
 }
@@ -219,6 +745,15 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
 		return strings.Split(value, ",")
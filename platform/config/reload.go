@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// reloadableEnvKeys are the only variables re-read from .env on reload; every
+// other setting still requires a restart. Feature flags (*_ENABLED) are left
+// out on purpose: they decide which components start, and turning one on or
+// off at runtime would need those components to start and stop mid-flight.
+var reloadableEnvKeys = []string{
+	"LOG_LEVEL",
+	"GLOBAL_WEBHOOK_URL",
+	"WEBHOOK_TIMEOUT",
+	"WEBHOOK_RETRY_MAX",
+	"WEBHOOK_RETRY_DELAY",
+	"WA_SEND_INTERVAL_MS",
+}
+
+type ReloadResult struct {
+	Changed    []string  `json:"changed"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
+
+type Reloader struct {
+	current atomic.Pointer[Config]
+	mu      sync.Mutex
+	hooks   []func(*Config)
+	envFile string
+}
+
+func NewReloader(cfg *Config) *Reloader {
+	r := &Reloader{envFile: ".env"}
+	r.current.Store(cfg)
+	return r
+}
+
+// Current returns the latest configuration snapshot. Callers that need
+// reloadable values should read them from here instead of caching them.
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+func (r *Reloader) OnReload(hook func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+func (r *Reloader) Reload() (*ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if env, err := godotenv.Read(r.envFile); err == nil {
+		for _, key := range reloadableEnvKeys {
+			if value, ok := env[key]; ok {
+				if err := os.Setenv(key, value); err != nil {
+					return nil, fmt.Errorf("failed to set %s: %w", key, err)
+				}
+			}
+		}
+	}
+
+	next, err := fromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	current := r.current.Load()
+	updated := *current
+	changed := make([]string, 0)
+
+	if updated.Log.Level != next.Log.Level {
+		updated.Log.Level = next.Log.Level
+		changed = append(changed, "log.level")
+	}
+	if updated.Webhook.GlobalURL != next.Webhook.GlobalURL {
+		updated.Webhook.GlobalURL = next.Webhook.GlobalURL
+		changed = append(changed, "webhook.global_url")
+	}
+	if updated.Webhook.Timeout != next.Webhook.Timeout {
+		updated.Webhook.Timeout = next.Webhook.Timeout
+		changed = append(changed, "webhook.timeout")
+	}
+	if updated.Webhook.RetryMax != next.Webhook.RetryMax {
+		updated.Webhook.RetryMax = next.Webhook.RetryMax
+		changed = append(changed, "webhook.retry_max")
+	}
+	if updated.Webhook.RetryDelay != next.Webhook.RetryDelay {
+		updated.Webhook.RetryDelay = next.Webhook.RetryDelay
+		changed = append(changed, "webhook.retry_delay")
+	}
+	if updated.WhatsApp.SendIntervalMs != next.WhatsApp.SendIntervalMs {
+		updated.WhatsApp.SendIntervalMs = next.WhatsApp.SendIntervalMs
+		changed = append(changed, "whatsapp.send_interval_ms")
+	}
+
+	r.current.Store(&updated)
+
+	if len(changed) > 0 {
+		for _, hook := range r.hooks {
+			hook(&updated)
+		}
+	}
+
+	return &ReloadResult{
+		Changed:    changed,
+		ReloadedAt: time.Now(),
+	}, nil
+}
@@ -11,12 +11,30 @@ import (
 	_ "github.com/lib/pq"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 
+	"zpwoot/internal/core/audit"
+	"zpwoot/internal/core/chatwoot"
+	"zpwoot/internal/core/contact"
+	"zpwoot/internal/core/conversation"
+	"zpwoot/internal/core/group"
+	"zpwoot/internal/core/inbound"
+	"zpwoot/internal/core/label"
+	"zpwoot/internal/core/linktrack"
 	"zpwoot/internal/core/messaging"
+	"zpwoot/internal/core/note"
+	"zpwoot/internal/core/schedule"
 	"zpwoot/internal/core/session"
+	"zpwoot/internal/core/tenant"
+	"zpwoot/internal/core/webhook"
 
 	"zpwoot/internal/services"
 	"zpwoot/internal/services/shared/validation"
 
+	"zpwoot/internal/adapters/chatwootapi"
+	"zpwoot/internal/adapters/delivery"
+	"zpwoot/internal/adapters/fakewa"
+	"zpwoot/internal/adapters/grpcserver"
+	"zpwoot/internal/adapters/mediahost"
+	"zpwoot/internal/adapters/mediascan"
 	"zpwoot/internal/adapters/repository"
 	"zpwoot/internal/adapters/server"
 	"zpwoot/internal/adapters/waclient"
@@ -24,23 +42,59 @@ import (
 	"zpwoot/platform/config"
 	"zpwoot/platform/database"
 	"zpwoot/platform/logger"
+	"zpwoot/platform/metrics"
+	"zpwoot/platform/secrets"
 )
 
 type Container struct {
 	config   *config.Config
 	logger   *logger.Logger
 	database *database.Database
+	reloader *config.Reloader
 
 	sessionCore   *session.Service
 	messagingCore *messaging.Service
-
-	sessionService   *services.SessionService
-	messagingService *services.MessageService
-	groupService     *services.GroupService
+	webhookCore   *webhook.Service
+	labelCore     *label.Service
+	noteCore      *note.Service
+	scheduleCore  *schedule.Service
+	dedup         *messaging.Deduplicator
+	retention     *messaging.Retention
+	groupMetadata *group.MetadataCache
+	pipeline      *inbound.Pipeline
+	events        *delivery.Stream
+	sendMetrics   *session.SendMetrics
+	sendLimiter   *session.SendRateLimiter
+	mediaPool     *messaging.MediaPool
+	sli           *metrics.SLI
+	secrets       *secrets.Keyring
+	secretsRepo   *repository.SecretsRepository
+
+	// sendDecorators wrap the gateway for every send, the first outermost.
+	sendDecorators []session.SenderDecorator
+
+	sessionService      *services.SessionService
+	messagingService    *services.MessageService
+	groupService        *services.GroupService
+	contactService      *services.ContactService
+	mediaService        *services.MediaService
+	auditCore           *audit.Service
+	auditService        *services.AuditService
+	webhookService      *services.WebhookService
+	labelService        *services.LabelService
+	newsletterService   *services.NewsletterService
+	profileService      *services.ProfileService
+	noteService         *services.NoteService
+	linkService         *services.LinkService
+	conversationCore    *conversation.Service
+	conversationService *services.ConversationService
+	chatwootService     *services.ChatwootService
+	tenantService       *services.TenantService
 
 	sessionRepo     session.Repository
 	messageRepo     messaging.Repository
 	whatsappGateway session.WhatsAppGateway
+	fakeGateway     *fakewa.Gateway
 }
 
 type Config struct {
@@ -86,26 +140,91 @@ func (c *Container) createWhatsAppContainer() (*sqlstore.Container, error) {
 func (c *Container) initialize() error {
 	c.logger.Debug("Initializing container...")
 
-	c.sessionRepo = repository.NewSessionRepository(c.database.DB)
-	c.messageRepo = repository.NewMessageRepository(c.database.DB, c.logger)
+	keyring, err := newKeyring(c.config.Security)
+	if err != nil {
+		return err
+	}
+	c.secrets = keyring
+	c.secretsRepo = repository.NewSecretsRepository(c.database.DB, keyring, c.logger)
+
+	c.sessionRepo = repository.NewSessionRepository(c.database.DB, keyring)
+	c.messageRepo = repository.NewMessageRepository(c.database.DB, c.database, c.logger)
+	webhookRepo := repository.NewWebhookRepository(c.database.DB, keyring, c.logger)
+	labelRepo := repository.NewLabelRepository(c.database.DB, c.logger)
+
+	if c.config.WhatsApp.TestMode {
+		c.logger.Warn("WA_TEST_MODE is enabled: WhatsApp is replaced by an in-memory fake gateway")
+		c.fakeGateway = fakewa.NewGateway(c.config.WhatsApp.TestAutoPair, c.logger)
+		c.fakeGateway.SetMaxSessions(c.config.WhatsApp.MaxSessions)
+		c.whatsappGateway = c.fakeGateway
+	} else {
+		waContainer, err := c.createWhatsAppContainer()
+		if err != nil {
+			return fmt.Errorf("failed to create WhatsApp container: %w", err)
+		}
+
+		c.whatsappGateway = waclient.NewGateway(waContainer, c.logger)
+	}
+
+	c.webhookCore = webhook.NewService(webhookRepo, c.logger)
+	dispatcher := delivery.NewDispatcher(c.webhookCore, c.config.Webhook, c.logger)
+	c.events = delivery.NewStream()
+	dispatcher.SetStream(c.events)
+	if c.config.Metrics.Enabled {
+		c.sli = metrics.NewSLI(c.config.Metrics.AvailabilityObjective, c.config.Metrics.WebhookObjective)
+		dispatcher.SetSLI(c.sli)
+	}
 
-	waContainer, err := c.createWhatsAppContainer()
+	if c.fakeGateway != nil {
+		c.fakeGateway.SetWebhookHandler(dispatcher)
+	}
+	if zones, ok := c.whatsappGateway.(delivery.Timezones); ok {
+		dispatcher.SetTimezones(zones)
+	}
+
+	mediaHost, err := mediahost.New(c.config.MediaHost, c.config.Server.BaseURL, c.config.WhatsApp.MediaDir)
 	if err != nil {
-		return fmt.Errorf("failed to create WhatsApp container: %w", err)
+		return fmt.Errorf("failed to create media host: %w", err)
 	}
 
-	c.whatsappGateway = waclient.NewGateway(waContainer, c.logger)
+	mediaScanner, err := mediascan.New(c.config.MediaScan)
+	if err != nil {
+		return fmt.Errorf("failed to create media scanner: %w", err)
+	}
+
+	c.mediaPool = messaging.NewMediaPool(c.config.MediaProcessing.Workers, c.config.MediaProcessing.QueueDepth, c.logger)
 
 	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
 		gateway.SetDatabase(c.database.DB)
+		gateway.SetOperationTimeout(time.Duration(c.config.WhatsApp.OperationTimeout) * time.Second)
+		gateway.SetUploadRetries(c.config.WhatsApp.UploadRetries)
+		gateway.SetMaxSessions(c.config.WhatsApp.MaxSessions)
+		gateway.SetMaxPendingEvents(c.config.WhatsApp.SessionMaxPendingEvents)
+		gateway.SetMediaDir(c.config.WhatsApp.MediaDir)
+		gateway.SetMediaHost(mediaHost)
+		gateway.SetMediaScanner(mediaScanner, session.MediaScanPolicy(c.config.MediaScan.Policy))
+		gateway.SetMediaPool(c.mediaPool)
+		gateway.SetWebhookHandler(dispatcher)
 	}
 
 	qrGenerator := waclient.NewQRGenerator(c.logger)
+	if err := qrGenerator.LoadLogo(c.config.WhatsApp.QRLogoPath); err != nil {
+		c.logger.WarnWithFields("QR logo could not be loaded, logos disabled", map[string]interface{}{
+			"path":  c.config.WhatsApp.QRLogoPath,
+			"error": err.Error(),
+		})
+	}
+
+	tenantCore := tenant.NewService(repository.NewTenantRepository(c.database.DB, c.logger), c.logger)
 
 	c.sessionCore = session.NewService(
 		c.sessionRepo,
 		c.whatsappGateway,
 		qrGenerator,
+		repository.NewSendCounterRepository(c.database.DB, c.logger),
+		tenantCore,
+		repository.NewStatusHistoryRepository(c.database.DB, c.logger),
+		session.NewSendQueue(c.config.WhatsApp.SendQueueDepth),
 	)
 
 	c.messagingCore = messaging.NewService(
@@ -113,6 +232,32 @@ func (c *Container) initialize() error {
 		c.logger,
 	)
 
+	c.labelCore = label.NewService(labelRepo, c.logger)
+	c.noteCore = note.NewService(repository.NewNoteRepository(c.database.DB, c.logger), c.logger)
+	c.dedup = messaging.NewDeduplicator(
+		repository.NewSeenRepository(c.database.DB, c.logger),
+		time.Duration(c.config.WhatsApp.DedupTTLHours)*time.Hour,
+		c.logger,
+	)
+	c.retention = messaging.NewRetention(
+		repository.NewMessageRetentionRepository(c.database.DB, c.logger),
+		c.sessionCore,
+		c.config.Message.RetentionDays,
+		c.config.Message.PartitionsAhead,
+		c.logger,
+	)
+	c.scheduleCore = schedule.NewService(repository.NewScheduleRepository(c.database.DB, c.logger), c.logger)
+
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		gateway.SetMessageStore(c.messagingCore)
+		gateway.SetLabelStore(c.labelCore)
+		gateway.SetDeduplicator(c.dedup)
+		c.pipeline = gateway.Pipeline()
+	}
+	if c.fakeGateway != nil {
+		c.pipeline = c.fakeGateway.Pipeline()
+	}
+
 	validator := validation.New()
 
 	// Create session resolver
@@ -124,10 +269,27 @@ func (c *Container) initialize() error {
 		c.sessionRepo,
 		c.whatsappGateway,
 		qrGenerator,
+		tenantCore,
+		c.logger,
+		validator,
+	)
+
+	c.tenantService = services.NewTenantService(
+		tenantCore,
+		sessionResolver,
 		c.logger,
 		validator,
 	)
 
+	var starGateway messaging.StarGateway
+	var readGateway messaging.ReadGateway
+	var pinGateway messaging.PinGateway
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		starGateway = gateway
+		readGateway = gateway
+		pinGateway = gateway
+	}
+
 	c.messagingService = services.NewMessageService(
 		c.messagingCore,
 		c.sessionCore,
@@ -135,18 +297,216 @@ func (c *Container) initialize() error {
 		c.messageRepo,
 		c.sessionRepo,
 		c.whatsappGateway,
+		starGateway,
+		readGateway,
+		pinGateway,
+		c.scheduleCore,
+		c.noteCore,
 		c.logger,
 		validator,
 		c.sessionService,
 	)
 
+	c.noteService = services.NewNoteService(
+		c.noteCore,
+		sessionResolver,
+		c.logger,
+		validator,
+	)
+
+	linkCore := linktrack.NewService(repository.NewLinkRepository(c.database.DB, c.database, c.logger), c.logger)
+	c.messagingService.SetLinkTracking(linkCore, tenantCore, c.config.Server.BaseURL)
+	c.linkService = services.NewLinkService(linkCore, sessionResolver, c.logger)
+
+	c.conversationCore = conversation.NewService(repository.NewConversationRepository(c.database.DB, c.logger), c.logger)
+	c.conversationService = services.NewConversationService(
+		c.conversationCore,
+		sessionResolver,
+		c.config.WhatsApp.DefaultCountryCode,
+		c.logger,
+		validator,
+	)
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		gateway.SetConversationStates(c.conversationCore)
+	}
+
+	var groupGateway group.WhatsAppGateway
+	var mediaFetcher messaging.MediaFetcher
+	var contactSource services.ContactSource
+	var labelGateway label.Gateway
+	var newsletterGateway messaging.NewsletterGateway
+	var businessEditor contact.BusinessProfileEditor
+	var numberResolver contact.NumberResolver
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		numberResolver = gateway
+		groupGateway = gateway
+		mediaFetcher = gateway
+		contactSource = gateway
+		labelGateway = gateway
+		newsletterGateway = gateway
+		businessEditor = gateway
+	}
+
+	if c.fakeGateway != nil {
+		numberResolver = c.fakeGateway
+	}
+	numberChecker := contact.NewNumberChecker(
+		numberResolver,
+		repository.NewNumberCheckRepository(c.database.DB, c.logger),
+		time.Duration(c.config.WhatsApp.NumberCheckTTLHours)*time.Hour,
+		c.logger,
+	)
+	if c.config.WhatsApp.VerifyRecipients {
+		c.messagingService.SetRecipientCheck(numberChecker)
+	}
+	c.messagingService.SetDefaultCountry(c.config.WhatsApp.DefaultCountryCode)
+	c.sessionService.SetChatLinks(numberChecker, c.config.WhatsApp.DefaultCountryCode)
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		c.messagingService.SetMediaInspector(gateway)
+	}
+	c.sendMetrics = session.NewSendMetrics()
+	c.sendLimiter = session.NewSendRateLimiter(time.Duration(c.config.WhatsApp.SendIntervalMs) * time.Millisecond)
+	c.sendDecorators = c.builtinSendDecorators()
+	c.messagingService.SetSender(session.DecorateSender(c.whatsappGateway, c.sendDecorators...))
+
+	c.contactService = services.NewContactService(
+		sessionResolver,
+		contactSource,
+		numberChecker,
+		c.logger,
+	)
+
+	contactRegistry := contact.NewRegistry(
+		repository.NewRegistryRepository(c.database.DB, c.logger),
+		c.logger,
+	)
+	c.contactService.SetRegistry(contactRegistry)
+
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		gateway.SetContactRegistry(contactRegistry)
+
+		avatarCache := contact.NewAvatarCache(
+			gateway,
+			repository.NewAvatarRepository(c.database.DB, c.logger),
+			time.Duration(c.config.WhatsApp.AvatarTTLHours)*time.Hour,
+			c.logger,
+		)
+		avatarCache.OnChange(gateway.EmitAvatarChanged)
+		gateway.SetAvatarObserver(avatarCache)
+		c.contactService.SetAvatars(avatarCache, mediaHost)
+	}
+
+	c.mediaService = services.NewMediaService(
+		c.messagingCore,
+		sessionResolver,
+		mediaFetcher,
+		mediaHost,
+		c.logger,
+	)
+
+	c.newsletterService = services.NewNewsletterService(
+		c.messagingCore,
+		sessionResolver,
+		c.mediaService,
+		newsletterGateway,
+		c.scheduleCore,
+		c.config.WhatsApp.DefaultCountryCode,
+		c.logger,
+		validator,
+	)
+	c.messagingService.SetNewsletters(c.newsletterService)
+
+	c.profileService = services.NewProfileService(
+		sessionResolver,
+		businessEditor,
+		c.logger,
+		validator,
+	)
+
 	c.groupService = services.NewGroupService(
+		group.NewService(nil),
 		nil,
-		nil,
-		nil,
+		groupGateway,
+		numberChecker,
+		c.logger,
+		validator,
+	)
+	c.groupService.SetMediaPool(c.mediaPool)
+	c.groupService.SetInviteSender(c.messagingService)
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		c.groupService.SetContacts(gateway)
+	}
+
+	c.webhookService = services.NewWebhookService(
+		c.webhookCore,
+		dispatcher,
+		sessionResolver,
+		c.logger,
+		validator,
+	)
+	c.sessionService.SetWebhooks(c.webhookService)
+
+	c.labelService = services.NewLabelService(
+		c.labelCore,
+		labelGateway,
+		sessionResolver,
+		c.logger,
+		validator,
+	)
+
+	chatwootCore := chatwoot.NewService(
+		repository.NewChatwootRepository(c.database.DB, c.logger),
+		repository.NewChatwootResolutionRepository(c.database.DB, c.logger),
+		repository.NewChatwootGroupRepository(c.database.DB, c.logger),
+		chatwootapi.New(c.config.Chatwoot),
+		c.logger,
+	)
+	c.chatwootService = services.NewChatwootService(
+		chatwootCore,
+		sessionResolver,
+		c.messagingService,
+		c.config.Chatwoot.URL,
 		c.logger,
 		validator,
 	)
+	if chatwootCore.CanReopen() && c.pipeline != nil {
+		// The fake gateway has no Chatwoot stage; run before webhooks there.
+		stage := waclient.NewContactReplyStage(waclient.StageChatwootReopen, c.chatwootService)
+		if err := c.pipeline.InsertBefore(waclient.StageChatwoot, stage); err != nil {
+			if err := c.pipeline.InsertBefore(waclient.StageWebhook, stage); err != nil {
+				return fmt.Errorf("failed to register chatwoot reopen stage: %w", err)
+			}
+		}
+	}
+
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		c.groupMetadata = group.NewMetadataCache(
+			gateway,
+			repository.NewGroupMetadataRepository(c.database.DB, c.logger),
+			time.Duration(c.config.WhatsApp.GroupRefreshHours)*time.Hour,
+			c.config.WhatsApp.GroupRefreshActiveDays,
+			c.logger,
+		)
+		c.groupMetadata.OnChange(gateway.EmitGroupMetadataChanged)
+		c.groupMetadata.OnChange(c.chatwootService.GroupMetadataChanged)
+		gateway.SetGroupMetadata(c.groupMetadata)
+	}
+
+	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
+		devices := session.NewDeviceTracker(
+			gateway,
+			repository.NewDeviceRepository(c.database.DB, c.logger),
+			c.logger,
+		)
+		devices.OnAdded(gateway.EmitDeviceAdded)
+		gateway.SetDeviceObserver(devices)
+		c.sessionService.SetDevices(devices)
+		c.sessionService.SetProtocolDebug(gateway.ProtocolDebug())
+
+		filterLog := repository.NewFilterLogRepository(c.database.DB, c.logger)
+		gateway.SetFilterLog(filterLog)
+		c.sessionService.SetFilterLog(filterLog)
+	}
 
 	sessionServiceAdapter := &sessionServiceAdapter{service: c.sessionService}
 	if gateway, ok := c.whatsappGateway.(*waclient.Gateway); ok {
@@ -155,19 +515,104 @@ func (c *Container) initialize() error {
 		sessionEventHandler := session.NewSessionEventHandler(c.sessionCore)
 		gateway.SetEventHandler(sessionEventHandler)
 	}
+	if c.fakeGateway != nil {
+		c.fakeGateway.SetDeviceStore(c.sessionCore)
+		c.fakeGateway.SetEventHandler(session.NewSessionEventHandler(c.sessionCore))
+	}
+
+	if c.config.Audit.Enabled {
+		auditRepo := repository.NewAuditRepository(c.database.DB, c.database, c.logger)
+		retention := time.Duration(c.config.Audit.RetentionDays) * 24 * time.Hour
+		c.auditCore = audit.NewService(auditRepo, retention, c.logger)
+		c.auditService = services.NewAuditService(c.auditCore, c.logger, validator)
+	}
+
+	c.reloader = config.NewReloader(c.config)
+	c.reloader.OnReload(func(cfg *config.Config) {
+		c.logger.SetLevel(cfg.Log.Level)
+		dispatcher.UpdateConfig(cfg.Webhook)
+		c.sendLimiter.SetInterval(time.Duration(cfg.WhatsApp.SendIntervalMs) * time.Millisecond)
+	})
 
 	c.logger.Debug("Container initialized successfully")
 	return nil
 }
 
 func (c *Container) Start(ctx context.Context) error {
+	if c.auditCore != nil {
+		c.auditCore.StartRetention(ctx)
+	}
+
+	c.mediaPool.Start(ctx)
+	c.scheduleCore.Start(ctx, c.messagingService)
+	c.dedup.StartPurge(ctx)
+	c.conversationCore.StartPurge(ctx)
+	c.retention.Start(ctx)
+	if c.groupMetadata != nil {
+		c.groupMetadata.Start(ctx)
+	}
+	if c.secrets.Enabled() {
+		go c.rotateSecrets(ctx)
+	}
+
 	return nil
 }
 
+func newKeyring(cfg config.SecurityConfig) (*secrets.Keyring, error) {
+	if cfg.MasterKey == "" {
+		return secrets.NewKeyring(nil), nil
+	}
+
+	primary, err := secrets.ParseLocalKey(cfg.MasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets master key: %w", err)
+	}
+	previous := make([]secrets.MasterKey, 0, len(cfg.PreviousKeys))
+	for _, spec := range cfg.PreviousKeys {
+		key, err := secrets.ParseLocalKey(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous secrets key: %w", err)
+		}
+		previous = append(previous, key)
+	}
+	return secrets.NewKeyring(primary, previous...), nil
+}
+
+// rotateSecrets seals stored secrets under the current master key, which
+// encrypts those saved before encryption was turned on and moves the rest
+// off keys being rotated out.
+func (c *Container) rotateSecrets(ctx context.Context) {
+	rotation, err := c.secretsRepo.Rotate(ctx)
+	if err != nil {
+		c.logger.ErrorWithFields("Failed to re-encrypt stored secrets", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	if rotation.Rotated > 0 || rotation.Failed > 0 {
+		c.logger.InfoWithFields("Stored secrets re-encrypted", map[string]interface{}{
+			"key_id":  rotation.KeyID,
+			"rotated": rotation.Rotated,
+			"failed":  rotation.Failed,
+		})
+	}
+}
+
+// UseMasterKey seals secrets with key from now on, such as one kept in a
+// KMS. The configured key still opens what it sealed; POST
+// /admin/secrets/rotate (or the next start) moves those to key.
+func (c *Container) UseMasterKey(key secrets.MasterKey) {
+	c.secrets.Use(key)
+}
+
 func (c *Container) GetConfig() *config.Config {
 	return c.config
 }
 
+func (c *Container) GetConfigReloader() *config.Reloader {
+	return c.reloader
+}
+
 func (c *Container) GetLogger() *logger.Logger {
 	return c.logger
 }
@@ -192,6 +637,52 @@ func (c *Container) GetWhatsAppGateway() session.WhatsAppGateway {
 	return c.whatsappGateway
 }
 
+// UseInboundStage registers a plugin stage that runs after the built-in
+// inbound stages. Register plugins before Start so no event misses them.
+func (c *Container) UseInboundStage(stage inbound.Stage) error {
+	if c.pipeline == nil {
+		return fmt.Errorf("inbound pipeline is not available")
+	}
+	return c.pipeline.Use(stage)
+}
+
+// InsertInboundStage registers a plugin stage right before a built-in one,
+// e.g. a rules stage before waclient.StageChatwoot that can stop an event
+// from being forwarded.
+func (c *Container) InsertInboundStage(before string, stage inbound.Stage) error {
+	if c.pipeline == nil {
+		return fmt.Errorf("inbound pipeline is not available")
+	}
+	return c.pipeline.InsertBefore(before, stage)
+}
+
+// builtinSendDecorators are the decorators the configuration turns on:
+// logging outermost, then metrics, spacing and retries closest to the
+// gateway, so a retried send counts once and waits for its turn once.
+func (c *Container) builtinSendDecorators() []session.SenderDecorator {
+	cfg := c.config.WhatsApp
+
+	var decorators []session.SenderDecorator
+	if cfg.SendLog {
+		decorators = append(decorators, session.LogSends(c.logger))
+	}
+	decorators = append(decorators, c.sendMetrics.Decorator())
+	// The limiter is installed even at a zero interval so a reload can turn
+	// it on.
+	decorators = append(decorators, c.sendLimiter.Decorator())
+	if cfg.SendRetries > 0 {
+		decorators = append(decorators, session.RetrySends(cfg.SendRetries, time.Duration(cfg.SendRetryBackoffMs)*time.Millisecond))
+	}
+	return decorators
+}
+
+// UseSendDecorator wraps every send in a plugin decorator, inside the
+// built-in ones. Register plugins before Start so no send misses them.
+func (c *Container) UseSendDecorator(decorator session.SenderDecorator) {
+	c.sendDecorators = append(c.sendDecorators, decorator)
+	c.messagingService.SetSender(session.DecorateSender(c.whatsappGateway, c.sendDecorators...))
+}
+
 func (c *Container) Stop(ctx context.Context) error {
 
 	if stopper, ok := c.whatsappGateway.(interface{ Stop(context.Context) error }); ok {
@@ -205,11 +696,31 @@ func (c *Container) Stop(ctx context.Context) error {
 
 func (c *Container) Server() *server.Server {
 	return server.New(&server.Config{
-		Config:         c.config,
-		Logger:         c.logger,
-		SessionService: c.sessionService,
-		MessageService: c.messagingService,
-		GroupService:   c.groupService,
+		Config:              c.config,
+		Reloader:            c.reloader,
+		Logger:              c.logger,
+		SessionService:      c.sessionService,
+		MessageService:      c.messagingService,
+		GroupService:        c.groupService,
+		ContactService:      c.contactService,
+		MediaService:        c.mediaService,
+		AuditService:        c.auditService,
+		WebhookService:      c.webhookService,
+		LabelService:        c.labelService,
+		NewsletterService:   c.newsletterService,
+		ProfileService:      c.profileService,
+		NoteService:         c.noteService,
+		LinkService:         c.linkService,
+		ConversationService: c.conversationService,
+		ChatwootService:     c.chatwootService,
+		TenantService:       c.tenantService,
+		Pipeline:            c.pipeline,
+		SendMetrics:         c.sendMetrics,
+		MediaPool:           c.mediaPool,
+		SecretsRotator:      c.secretsRepo,
+		SLI:                 c.sli,
+		Database:            c.database,
+		FakeGateway:         c.fakeGateway,
 	})
 }
 
@@ -217,6 +728,22 @@ func (c *Container) Handler() http.Handler {
 	return c.Server().Handler()
 }
 
+// GRPCServer builds the gRPC API, or returns nil when GRPC_PORT leaves it
+// off.
+func (c *Container) GRPCServer() *grpcserver.Server {
+	if c.config.Server.GRPCPort == 0 {
+		return nil
+	}
+
+	return grpcserver.New(&grpcserver.Config{
+		Config:         c.config,
+		Logger:         c.logger,
+		SessionService: c.sessionService,
+		MessageService: c.messagingService,
+		Stream:         c.events,
+	})
+}
+
 type sessionServiceAdapter struct {
 	service *services.SessionService
 }
@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"zpwoot/platform/config"
 	"zpwoot/platform/logger"
@@ -15,30 +15,55 @@ import (
 
 type Database struct {
 	*sqlx.DB
-	config config.DatabaseConfig
-	logger *logger.Logger
+	config  config.DatabaseConfig
+	logger  *logger.Logger
+	stats   *statsRecorder
+	replica *replica
 }
 
 func New(cfg config.DatabaseConfig, log *logger.Logger) (*Database, error) {
-
-	db, err := sqlx.Connect("postgres", cfg.URL)
+	base, err := pq.NewConnector(cfg.URL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("invalid database URL: %w", err)
 	}
 
+	stats := newStatsRecorder()
+	db := sqlx.NewDb(sql.OpenDB(&connector{
+		base:  base,
+		stats: stats,
+		retry: RetryPolicy{
+			Attempts:   cfg.ConnectRetries,
+			Backoff:    time.Duration(cfg.ConnectBackoffMs) * time.Millisecond,
+			MaxBackoff: time.Duration(cfg.ConnectMaxBackoffMs) * time.Millisecond,
+		},
+		logger: log,
+	}), "postgres")
+
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
 	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
 
 	if err := db.Ping(); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	database := &Database{
 		DB:     db,
 		config: cfg,
 		logger: log,
+		stats:  stats,
+	}
+
+	if cfg.ReplicaURL != "" {
+		replica, err := openReplica(cfg, stats, log)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		database.replica = replica
+		go replica.run()
 	}
 
 	return database, nil
@@ -52,6 +77,14 @@ func (d *Database) Close() error {
 	d.logger.InfoWithFields("Closing database connection", map[string]interface{}{
 		"module": "database",
 	})
+	if d.replica != nil {
+		if err := d.replica.close(); err != nil {
+			d.logger.WarnWithFields("Failed to close read replica connection", map[string]interface{}{
+				"module": "database",
+				"error":  err.Error(),
+			})
+		}
+	}
 	return d.DB.Close()
 }
 
@@ -99,6 +132,12 @@ func (d *Database) Stats() sql.DBStats {
 	return d.DB.Stats()
 }
 
+// QueryStats returns per-operation driver counters since startup and how
+// many times opening a connection was retried.
+func (d *Database) QueryStats() ([]QueryStats, int64) {
+	return d.stats.snapshot()
+}
+
 func (d *Database) GetConfig() config.DatabaseConfig {
 	return d.config
 }
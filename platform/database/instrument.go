@@ -0,0 +1,271 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+
+	"zpwoot/platform/logger"
+)
+
+// Operations counted by the instrumented connector.
+const (
+	OpConnect  = "connect"
+	OpExec     = "exec"
+	OpQuery    = "query"
+	OpPrepare  = "prepare"
+	OpBegin    = "begin"
+	OpCommit   = "commit"
+	OpRollback = "rollback"
+)
+
+// QueryStats counts what one kind of driver operation did since startup.
+type QueryStats struct {
+	Operation     string        `json:"operation"`
+	Count         int64         `json:"count"`
+	Errors        int64         `json:"errors"`
+	TotalDuration time.Duration `json:"totalDuration"`
+	MaxDuration   time.Duration `json:"maxDuration"`
+	LastError     string        `json:"lastError,omitempty"`
+}
+
+// RetryPolicy controls how opening a connection is retried while the
+// database is unreachable. Attempts counts the first try.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+type statsRecorder struct {
+	mu      sync.Mutex
+	ops     map[string]*QueryStats
+	retries int64
+}
+
+func newStatsRecorder() *statsRecorder {
+	return &statsRecorder{ops: make(map[string]*QueryStats)}
+}
+
+func (s *statsRecorder) record(op string, elapsed time.Duration, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.ops[op]
+	if !ok {
+		stats = &QueryStats{Operation: op}
+		s.ops[op] = stats
+	}
+	stats.Count++
+	stats.TotalDuration += elapsed
+	if elapsed > stats.MaxDuration {
+		stats.MaxDuration = elapsed
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		stats.Errors++
+		stats.LastError = err.Error()
+	}
+}
+
+func (s *statsRecorder) retried() {
+	s.mu.Lock()
+	s.retries++
+	s.mu.Unlock()
+}
+
+func (s *statsRecorder) snapshot() ([]QueryStats, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]QueryStats, 0, len(s.ops))
+	for _, stats := range s.ops {
+		out = append(out, *stats)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Operation < out[j].Operation })
+	return out, s.retries
+}
+
+// connector opens lib/pq connections, retrying with backoff while the
+// database is unreachable, and times every statement run on them.
+type connector struct {
+	base   *pq.Connector
+	stats  *statsRecorder
+	retry  RetryPolicy
+	logger *logger.Logger
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	backoff := c.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		conn, err := c.base.Connect(ctx)
+		c.stats.record(OpConnect, time.Since(start), err)
+		if err == nil {
+			return &instrumentedConn{Conn: conn, stats: c.stats}, nil
+		}
+
+		if attempt >= c.retry.Attempts || !isTransient(err) {
+			return nil, err
+		}
+
+		c.stats.retried()
+		c.logger.WarnWithFields("Database unreachable, retrying connection", map[string]interface{}{
+			"attempt":    attempt,
+			"backoff_ms": backoff.Milliseconds(),
+			"error":      err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if c.retry.MaxBackoff > 0 && backoff > c.retry.MaxBackoff {
+			backoff = c.retry.MaxBackoff
+		}
+	}
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// isTransient reports errors worth retrying a connection for: network
+// failures and the server refusing connections while starting, shutting
+// down or full.
+func isTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03", "53300":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	return false
+}
+
+// instrumentedConn wraps a lib/pq connection. lib/pq implements every
+// context-aware interface below, so the wrappers only fall back for other
+// drivers.
+type instrumentedConn struct {
+	driver.Conn
+	stats *statsRecorder
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.stats.record(OpExec, time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.stats.record(OpQuery, time.Since(start), err)
+	return rows, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	c.stats.record(OpPrepare, time.Since(start), err)
+	return stmt, err
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	beginner, ok := c.Conn.(driver.ConnBeginTx)
+	if !ok {
+		return nil, errors.New("driver does not support BeginTx")
+	}
+
+	start := time.Now()
+	tx, err := beginner.BeginTx(ctx, opts)
+	c.stats.record(OpBegin, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{Tx: tx, stats: c.stats}, nil
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+type instrumentedTx struct {
+	driver.Tx
+	stats *statsRecorder
+}
+
+func (t *instrumentedTx) Commit() error {
+	start := time.Now()
+	err := t.Tx.Commit()
+	t.stats.record(OpCommit, time.Since(start), err)
+	return err
+}
+
+func (t *instrumentedTx) Rollback() error {
+	start := time.Now()
+	err := t.Tx.Rollback()
+	t.stats.record(OpRollback, time.Since(start), err)
+	return err
+}
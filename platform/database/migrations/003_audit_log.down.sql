@@ -0,0 +1,10 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Audit Log
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_audit_log_method";
+DROP INDEX IF EXISTS "idx_zp_audit_log_api_key";
+DROP INDEX IF EXISTS "idx_zp_audit_log_session_ref";
+DROP INDEX IF EXISTS "idx_zp_audit_log_created_at";
+
+DROP TABLE IF EXISTS "zpAuditLog";
@@ -0,0 +1,37 @@
+-- =====================================================
+-- zpwoot Database Schema - Audit Log
+-- Records every mutating API call for compliance
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpAuditLog" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "apiKey" VARCHAR(255) NOT NULL,
+    "clientIp" VARCHAR(255) NOT NULL,
+    "userAgent" TEXT,
+    "sessionRef" VARCHAR(255),
+    "method" VARCHAR(10) NOT NULL,
+    "path" VARCHAR(2048) NOT NULL,
+    "route" VARCHAR(2048),
+    "statusCode" INTEGER NOT NULL,
+    "success" BOOLEAN NOT NULL,
+    "durationMs" BIGINT NOT NULL DEFAULT 0,
+    "payloadSummary" JSONB,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Audit log indexes
+CREATE INDEX IF NOT EXISTS "idx_zp_audit_log_created_at" ON "zpAuditLog" ("createdAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_audit_log_session_ref" ON "zpAuditLog" ("sessionRef", "createdAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_audit_log_api_key" ON "zpAuditLog" ("apiKey", "createdAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_audit_log_method" ON "zpAuditLog" ("method");
+
+-- Audit log table comments
+COMMENT ON TABLE "zpAuditLog" IS 'Audit trail of mutating API calls';
+COMMENT ON COLUMN "zpAuditLog"."apiKey" IS 'Masked API key used for the request';
+COMMENT ON COLUMN "zpAuditLog"."clientIp" IS 'Client IP address';
+COMMENT ON COLUMN "zpAuditLog"."sessionRef" IS 'Session name or ID from the request path';
+COMMENT ON COLUMN "zpAuditLog"."route" IS 'Matched route pattern';
+COMMENT ON COLUMN "zpAuditLog"."statusCode" IS 'HTTP response status code';
+COMMENT ON COLUMN "zpAuditLog"."success" IS 'Whether the call succeeded (status < 400)';
+COMMENT ON COLUMN "zpAuditLog"."payloadSummary" IS 'Request payload with secrets redacted and large values truncated';
+COMMENT ON COLUMN "zpAuditLog"."createdAt" IS 'Request timestamp';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Session Settings
+-- =====================================================
+
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "settings";
@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - Session Settings
+-- Per-session behaviour options (calls, etc.)
+-- =====================================================
+
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "settings" JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "zpSessions"."settings" IS 'Per-session behaviour settings in JSON format';
@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Message Search
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_message_session_chat_timestamp";
+DROP INDEX IF EXISTS "idx_zp_message_content_search";
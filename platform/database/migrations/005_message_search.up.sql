@@ -0,0 +1,13 @@
+-- =====================================================
+-- zpwoot Database Schema - Message Search
+-- Language-agnostic full-text index for message search
+-- =====================================================
+
+-- The 'simple' configuration does not stem, so it behaves the same for any
+-- language a conversation is held in. Queries must use the same expression.
+CREATE INDEX IF NOT EXISTS "idx_zp_message_content_search" ON "zpMessage"
+    USING gin(to_tsvector('simple', coalesce("content", '')));
+
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp");
+
+COMMENT ON INDEX "idx_zp_message_content_search" IS 'Full-text search over message content';
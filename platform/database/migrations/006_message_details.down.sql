@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Message Details
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpMessageReactions";
+
+ALTER TABLE "zpMessage" DROP COLUMN IF EXISTS "mediaMetadata";
+ALTER TABLE "zpMessage" DROP COLUMN IF EXISTS "quotedMessageId";
@@ -0,0 +1,26 @@
+-- =====================================================
+-- zpwoot Database Schema - Message Details
+-- Quoted messages, media metadata and reactions
+-- =====================================================
+
+ALTER TABLE "zpMessage" ADD COLUMN IF NOT EXISTS "quotedMessageId" VARCHAR(255);
+ALTER TABLE "zpMessage" ADD COLUMN IF NOT EXISTS "mediaMetadata" JSONB;
+
+CREATE TABLE IF NOT EXISTS "zpMessageReactions" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "reactorJid" VARCHAR(255) NOT NULL,
+    "emoji" VARCHAR(32) NOT NULL,
+    "fromMe" BOOLEAN NOT NULL DEFAULT false,
+    "reactedAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A participant has at most one reaction per message; a new one replaces it
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_message_reactions_unique" ON "zpMessageReactions" ("sessionId", "zpMessageId", "reactorJid");
+
+COMMENT ON TABLE "zpMessageReactions" IS 'Reactions received on WhatsApp messages';
+COMMENT ON COLUMN "zpMessage"."quotedMessageId" IS 'WhatsApp ID of the message this one replies to';
+COMMENT ON COLUMN "zpMessage"."mediaMetadata" IS 'Attachment metadata (mime type, size, hash, dimensions) in JSON format';
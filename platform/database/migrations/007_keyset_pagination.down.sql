@@ -0,0 +1,7 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Keyset Pagination
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_message_session_chat_timestamp_id";
+DROP INDEX IF EXISTS "idx_zp_message_session_timestamp_id";
+DROP INDEX IF EXISTS "idx_zp_sessions_created_at_id";
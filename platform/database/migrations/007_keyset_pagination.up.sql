@@ -0,0 +1,10 @@
+-- =====================================================
+-- zpwoot Database Schema - Keyset Pagination
+-- Indexes matching the (sort key, id) order used by cursor pagination
+-- =====================================================
+
+CREATE INDEX IF NOT EXISTS "idx_zp_sessions_created_at_id" ON "zpSessions" ("createdAt" DESC, "id" DESC);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_timestamp_id" ON "zpMessage" ("sessionId", "zpTimestamp" DESC, "id" DESC);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp_id" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp" DESC, "id" DESC);
@@ -0,0 +1,9 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Per-Session Webhook Filters
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_webhooks_session_unique";
+
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "template";
+
+COMMENT ON COLUMN "zpWebhooks"."events" IS 'Array of subscribed event types';
@@ -0,0 +1,13 @@
+-- =====================================================
+-- zpwoot Database Schema - Per-Session Webhook Filters
+-- Event category subscriptions and payload templates
+-- =====================================================
+
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "template" JSONB;
+
+-- One webhook per session; global delivery is configured through the
+-- environment instead of rows with a NULL session.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_webhooks_session_unique" ON "zpWebhooks" ("sessionId");
+
+COMMENT ON COLUMN "zpWebhooks"."events" IS 'Subscribed event categories (messages, receipts, presence, groups, calls, connection); empty means all';
+COMMENT ON COLUMN "zpWebhooks"."template" IS 'Optional JSON template reshaping the payload before delivery';
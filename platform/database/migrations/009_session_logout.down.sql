@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Session Logout Tracking
+-- =====================================================
+
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "loggedOutAt";
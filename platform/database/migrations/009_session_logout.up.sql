@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - Session Logout Tracking
+-- Sessions whose device was invalidated by WhatsApp
+-- =====================================================
+
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "loggedOutAt" TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN "zpSessions"."loggedOutAt" IS 'When WhatsApp invalidated the device; cleared once the session pairs again';
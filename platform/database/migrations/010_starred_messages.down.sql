@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Starred Messages
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpStarredMessages";
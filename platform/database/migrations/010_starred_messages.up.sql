@@ -0,0 +1,21 @@
+-- =====================================================
+-- zpwoot Database Schema - Starred Messages
+-- Messages starred from the API or from a linked device
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpStarredMessages" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "senderJid" VARCHAR(255),
+    "fromMe" BOOLEAN NOT NULL DEFAULT false,
+    "starredAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_starred_messages_unique" ON "zpStarredMessages" ("sessionId", "zpMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_starred_messages_keyset" ON "zpStarredMessages" ("sessionId", "starredAt" DESC, "id" DESC);
+
+COMMENT ON TABLE "zpStarredMessages" IS 'Starred WhatsApp messages, kept in sync with the app state';
+COMMENT ON COLUMN "zpStarredMessages"."senderJid" IS 'Author of the message in group chats when it was not sent by the session';
@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Business Labels
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpLabelAssociations";
+DROP TABLE IF EXISTS "zpLabels";
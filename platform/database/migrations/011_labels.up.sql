@@ -0,0 +1,31 @@
+-- =====================================================
+-- zpwoot Database Schema - Business Labels
+-- Labels and their chat/message associations, kept in sync with the app state
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpLabels" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "labelId" VARCHAR(64) NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    "color" INTEGER NOT NULL DEFAULT 0,
+    "deleted" BOOLEAN NOT NULL DEFAULT false,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "labelId")
+);
+
+CREATE TABLE IF NOT EXISTS "zpLabelAssociations" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "labelId" VARCHAR(64) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "messageId" VARCHAR(255) NOT NULL DEFAULT '',
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "labelId", "chatJid", "messageId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_label_associations_chat" ON "zpLabelAssociations" ("sessionId", "chatJid");
+
+COMMENT ON TABLE "zpLabels" IS 'WhatsApp Business labels per session';
+COMMENT ON COLUMN "zpLabels"."deleted" IS 'Deleted labels are kept so their ID is not reused';
+COMMENT ON TABLE "zpLabelAssociations" IS 'Chats and messages a label is applied to';
+COMMENT ON COLUMN "zpLabelAssociations"."messageId" IS 'Empty when the label is applied to the whole chat';
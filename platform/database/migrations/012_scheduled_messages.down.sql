@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Scheduled Messages
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpScheduledMessages";
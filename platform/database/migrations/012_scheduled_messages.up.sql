@@ -0,0 +1,28 @@
+-- =====================================================
+-- zpwoot Database Schema - Scheduled Messages
+-- Outbound sends held back until a later time (e.g. the end of quiet hours)
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpScheduledMessages" (
+    "id" UUID PRIMARY KEY,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "kind" VARCHAR(32) NOT NULL,
+    "payload" JSONB NOT NULL,
+    "sendAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "reason" VARCHAR(64) NOT NULL DEFAULT '',
+    "status" VARCHAR(16) NOT NULL DEFAULT 'pending',
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "lastError" TEXT NOT NULL DEFAULT '',
+    "messageId" VARCHAR(255) NOT NULL DEFAULT '',
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT "chk_zp_scheduled_status" CHECK ("status" IN ('pending', 'sending', 'sent', 'failed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_scheduled_due" ON "zpScheduledMessages" ("sendAt") WHERE "status" = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_zp_scheduled_session" ON "zpScheduledMessages" ("sessionId", "sendAt");
+
+COMMENT ON TABLE "zpScheduledMessages" IS 'Outbound sends waiting for their send time';
+COMMENT ON COLUMN "zpScheduledMessages"."kind" IS 'Send endpoint the payload belongs to (text, image, location...)';
+COMMENT ON COLUMN "zpScheduledMessages"."payload" IS 'Original request body, replayed at sendAt';
+COMMENT ON COLUMN "zpScheduledMessages"."reason" IS 'Why the send was held back, e.g. quiet_hours';
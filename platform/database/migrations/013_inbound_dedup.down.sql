@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Inbound Deduplication
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpInboundSeen";
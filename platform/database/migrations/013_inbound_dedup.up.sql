@@ -0,0 +1,15 @@
+-- =====================================================
+-- zpwoot Database Schema - Inbound Deduplication
+-- IDs of processed inbound messages, so replays after a reconnect are dropped
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpInboundSeen" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "seenAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "zpMessageId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_inbound_seen_at" ON "zpInboundSeen" ("seenAt");
+
+COMMENT ON TABLE "zpInboundSeen" IS 'Inbound message IDs already processed, kept for WA_DEDUP_TTL_HOURS';
@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Polls
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpPollVotes";
+DROP TABLE IF EXISTS "zpPolls";
@@ -0,0 +1,35 @@
+-- =====================================================
+-- zpwoot Database Schema - Polls
+-- Poll options and the latest vote of each participant
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpPolls" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "question" TEXT NOT NULL,
+    "options" TEXT[] NOT NULL,
+    "selectableCount" INTEGER NOT NULL DEFAULT 0,
+    "fromMe" BOOLEAN NOT NULL DEFAULT false,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_polls_message" ON "zpPolls" ("sessionId", "zpMessageId");
+
+CREATE TABLE IF NOT EXISTS "zpPollVotes" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "voterJid" VARCHAR(255) NOT NULL,
+    "options" TEXT[] NOT NULL,
+    "votedAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A new vote replaces the participant's previous one
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_poll_votes_unique" ON "zpPollVotes" ("sessionId", "zpMessageId", "voterJid");
+
+COMMENT ON TABLE "zpPolls" IS 'Polls sent or received, kept to resolve the option hashes in votes';
+COMMENT ON TABLE "zpPollVotes" IS 'Current vote of each participant on a poll; an empty option list is a withdrawn vote';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Send Counters
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpSendCounters";
@@ -0,0 +1,14 @@
+-- =====================================================
+-- zpwoot Database Schema - Send Counters
+-- Daily outbound volume per session for the warm-up ramp
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpSendCounters" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "day" DATE NOT NULL,
+    "count" INTEGER NOT NULL DEFAULT 0,
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "day")
+);
+
+COMMENT ON TABLE "zpSendCounters" IS 'Messages sent by each session per day in the session warm-up timezone';
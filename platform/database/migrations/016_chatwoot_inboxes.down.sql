@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Chatwoot Inboxes
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpChatwootInboxes";
@@ -0,0 +1,18 @@
+-- =====================================================
+-- zpwoot Database Schema - Chatwoot Inboxes
+-- Routes each Chatwoot inbox to the session that owns its number
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpChatwootInboxes" (
+    "accountId" INTEGER NOT NULL,
+    "inboxId" INTEGER NOT NULL,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("accountId", "inboxId")
+);
+
+-- A session answers a single inbox per account
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_chatwoot_inboxes_session" ON "zpChatwootInboxes" ("accountId", "sessionId");
+
+COMMENT ON TABLE "zpChatwootInboxes" IS 'Chatwoot inbox to session routing for accounts fed by several numbers';
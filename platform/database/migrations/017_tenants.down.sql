@@ -0,0 +1,10 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Tenants
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_sessions_tenant";
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "tenantId";
+
+DROP TABLE IF EXISTS "zpTenantCounters";
+DROP TABLE IF EXISTS "zpTenantKeys";
+DROP TABLE IF EXISTS "zpTenants";
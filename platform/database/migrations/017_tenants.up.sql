@@ -0,0 +1,43 @@
+-- =====================================================
+-- zpwoot Database Schema - Tenants
+-- Customers owning sessions, API keys and quotas
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpTenants" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "name" VARCHAR(255) NOT NULL UNIQUE,
+    "maxSessions" INTEGER NOT NULL DEFAULT 0,
+    "maxMessagesPerDay" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS "zpTenantKeys" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "tenantId" UUID NOT NULL REFERENCES "zpTenants"("id") ON DELETE CASCADE,
+    "name" VARCHAR(255) NOT NULL DEFAULT '',
+    "prefix" VARCHAR(32) NOT NULL,
+    "keyHash" CHAR(64) NOT NULL UNIQUE,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "lastUsedAt" TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_tenant_keys_tenant" ON "zpTenantKeys" ("tenantId");
+
+CREATE TABLE IF NOT EXISTS "zpTenantCounters" (
+    "tenantId" UUID NOT NULL REFERENCES "zpTenants"("id") ON DELETE CASCADE,
+    "day" DATE NOT NULL,
+    "count" INTEGER NOT NULL DEFAULT 0,
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("tenantId", "day")
+);
+
+-- Sessions without a tenant belong to the global API key
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "tenantId" UUID REFERENCES "zpTenants"("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_zp_sessions_tenant" ON "zpSessions" ("tenantId");
+
+COMMENT ON TABLE "zpTenants" IS 'Customers with isolated sessions, API keys and quotas (0 = unlimited)';
+COMMENT ON TABLE "zpTenantKeys" IS 'Tenant API keys, stored as SHA-256 hashes';
+COMMENT ON TABLE "zpTenantCounters" IS 'Messages sent by each tenant per UTC day';
+COMMENT ON COLUMN "zpSessions"."tenantId" IS 'Tenant owning the session; NULL for sessions managed with the global key';
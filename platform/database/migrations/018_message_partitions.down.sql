@@ -0,0 +1,75 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Message Partitions
+-- =====================================================
+
+ALTER TABLE "zpMessage" RENAME TO "zpMessage_partitioned";
+ALTER INDEX "zpMessage_pkey" RENAME TO "zpMessage_partitioned_pkey";
+DROP TRIGGER IF EXISTS update_zp_message_updated_at ON "zpMessage_partitioned";
+
+CREATE TABLE "zpMessage" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "zpSender" VARCHAR(255) NOT NULL,
+    "zpChat" VARCHAR(255) NOT NULL,
+    "zpTimestamp" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "zpFromMe" BOOLEAN NOT NULL,
+    "zpType" VARCHAR(50) NOT NULL,
+    "content" TEXT,
+    "cwMessageId" INTEGER,
+    "cwConversationId" INTEGER,
+    "syncStatus" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("syncStatus" IN ('pending', 'synced', 'failed')),
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "syncedAt" TIMESTAMP WITH TIME ZONE,
+    "quotedMessageId" VARCHAR(255),
+    "mediaMetadata" JSONB
+);
+
+INSERT INTO "zpMessage" (
+    "id", "sessionId", "zpMessageId", "zpSender", "zpChat", "zpTimestamp", "zpFromMe", "zpType", "content",
+    "cwMessageId", "cwConversationId", "syncStatus", "createdAt", "updatedAt", "syncedAt",
+    "quotedMessageId", "mediaMetadata"
+)
+SELECT DISTINCT ON ("sessionId", "zpMessageId")
+    "id", "sessionId", "zpMessageId", "zpSender", "zpChat", "zpTimestamp", "zpFromMe", "zpType", "content",
+    "cwMessageId", "cwConversationId", "syncStatus", "createdAt", "updatedAt", "syncedAt",
+    "quotedMessageId", "mediaMetadata"
+FROM "zpMessage_partitioned"
+ORDER BY "sessionId", "zpMessageId", "createdAt";
+
+DROP TABLE "zpMessage_partitioned";
+DROP FUNCTION IF EXISTS zp_ensure_message_partitions(DATE, INTEGER);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_message_unique_zp" ON "zpMessage" ("sessionId", "zpMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_id" ON "zpMessage" ("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_message_id" ON "zpMessage" ("zpMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_chat" ON "zpMessage" ("zpChat");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_message_id" ON "zpMessage" ("cwMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_conversation_id" ON "zpMessage" ("cwConversationId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_sync_status" ON "zpMessage" ("syncStatus");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_timestamp" ON "zpMessage" ("zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_type" ON "zpMessage" ("zpType");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_from_me" ON "zpMessage" ("zpFromMe");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_created_at" ON "zpMessage" ("createdAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat" ON "zpMessage" ("sessionId", "zpChat");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_conversation_status" ON "zpMessage" ("cwConversationId", "syncStatus");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_synced_at" ON "zpMessage" ("syncedAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_timestamp" ON "zpMessage" ("sessionId", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_chat_timestamp" ON "zpMessage" ("zpChat", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_sender_timestamp" ON "zpMessage" ("zpSender", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_type_timestamp" ON "zpMessage" ("zpType", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_pending_sync" ON "zpMessage" ("sessionId", "createdAt") WHERE "syncStatus" = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_zp_message_failed_sync" ON "zpMessage" ("sessionId", "createdAt") WHERE "syncStatus" = 'failed';
+CREATE INDEX IF NOT EXISTS "idx_zp_message_content_text" ON "zpMessage" USING gin(to_tsvector('english', "content")) WHERE "content" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "idx_zp_message_content_search" ON "zpMessage" USING gin(to_tsvector('simple', coalesce("content", '')));
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_timestamp_id" ON "zpMessage" ("sessionId", "zpTimestamp" DESC, "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp_id" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp" DESC, "id" DESC);
+
+CREATE TRIGGER update_zp_message_updated_at
+    BEFORE UPDATE ON "zpMessage"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE "zpMessage" IS 'Simple mapping table between WhatsApp messages and Chatwoot messages';
@@ -0,0 +1,131 @@
+-- =====================================================
+-- zpwoot Database Schema - Message Partitions
+-- Monthly range partitions for zpMessage
+-- =====================================================
+
+-- Unique keys of a partitioned table must include the partition key, so the
+-- primary key and the per-session message ID index gain "zpTimestamp". The
+-- index alone no longer rejects a redelivered message whose timestamp
+-- differs, so MessageRepository.Create checks ("sessionId", "zpMessageId")
+-- before inserting.
+
+ALTER TABLE "zpMessage" RENAME TO "zpMessage_legacy";
+ALTER INDEX "zpMessage_pkey" RENAME TO "zpMessage_legacy_pkey";
+DROP TRIGGER IF EXISTS update_zp_message_updated_at ON "zpMessage_legacy";
+
+CREATE TABLE "zpMessage" (
+    "id" UUID NOT NULL DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "zpSender" VARCHAR(255) NOT NULL,
+    "zpChat" VARCHAR(255) NOT NULL,
+    "zpTimestamp" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "zpFromMe" BOOLEAN NOT NULL,
+    "zpType" VARCHAR(50) NOT NULL,
+    "content" TEXT,
+    "cwMessageId" INTEGER,
+    "cwConversationId" INTEGER,
+    "syncStatus" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("syncStatus" IN ('pending', 'synced', 'failed')),
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "syncedAt" TIMESTAMP WITH TIME ZONE,
+    "quotedMessageId" VARCHAR(255),
+    "mediaMetadata" JSONB,
+    PRIMARY KEY ("id", "zpTimestamp")
+) PARTITION BY RANGE ("zpTimestamp");
+
+-- Messages outside every monthly partition (old history syncs, skewed
+-- clocks) land here.
+CREATE TABLE "zpMessage_default" PARTITION OF "zpMessage" DEFAULT;
+
+-- Creates the monthly partitions "zpMessage_YYYY_MM" (UTC months) for the
+-- given number of months starting at from_month, skipping existing ones.
+-- Returns how many were created.
+CREATE OR REPLACE FUNCTION zp_ensure_message_partitions(from_month DATE, months INTEGER)
+RETURNS INTEGER AS $$
+DECLARE
+    month_start DATE := date_trunc('month', from_month)::DATE;
+    month_end DATE;
+    partition_name TEXT;
+    created INTEGER := 0;
+BEGIN
+    FOR i IN 1..months LOOP
+        month_end := (month_start + INTERVAL '1 month')::DATE;
+        partition_name := 'zpMessage_' || to_char(month_start, 'YYYY_MM');
+
+        IF to_regclass(format('%I', partition_name)) IS NULL THEN
+            EXECUTE format(
+                'CREATE TABLE %I PARTITION OF "zpMessage" FOR VALUES FROM (%L) TO (%L)',
+                partition_name,
+                to_char(month_start, 'YYYY-MM-DD') || ' 00:00:00+00',
+                to_char(month_end, 'YYYY-MM-DD') || ' 00:00:00+00'
+            );
+            created := created + 1;
+        END IF;
+
+        month_start := month_end;
+    END LOOP;
+
+    RETURN created;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Partitions for the stored messages and the next three months.
+DO $$
+DECLARE
+    first_month DATE := date_trunc('month',
+        LEAST(COALESCE((SELECT MIN("zpTimestamp") FROM "zpMessage_legacy"), NOW()), NOW()) AT TIME ZONE 'UTC')::DATE;
+    this_month DATE := date_trunc('month', NOW() AT TIME ZONE 'UTC')::DATE;
+BEGIN
+    PERFORM zp_ensure_message_partitions(first_month,
+        ((EXTRACT(YEAR FROM this_month) - EXTRACT(YEAR FROM first_month)) * 12
+            + EXTRACT(MONTH FROM this_month) - EXTRACT(MONTH FROM first_month))::INTEGER + 4);
+END
+$$;
+
+INSERT INTO "zpMessage" (
+    "id", "sessionId", "zpMessageId", "zpSender", "zpChat", "zpTimestamp", "zpFromMe", "zpType", "content",
+    "cwMessageId", "cwConversationId", "syncStatus", "createdAt", "updatedAt", "syncedAt",
+    "quotedMessageId", "mediaMetadata"
+)
+SELECT
+    "id", "sessionId", "zpMessageId", "zpSender", "zpChat", "zpTimestamp", "zpFromMe", "zpType", "content",
+    "cwMessageId", "cwConversationId", "syncStatus", "createdAt", "updatedAt", "syncedAt",
+    "quotedMessageId", "mediaMetadata"
+FROM "zpMessage_legacy";
+
+DROP TABLE "zpMessage_legacy";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_message_unique_zp" ON "zpMessage" ("sessionId", "zpMessageId", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_id" ON "zpMessage" ("sessionId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_message_id" ON "zpMessage" ("zpMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_chat" ON "zpMessage" ("zpChat");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_message_id" ON "zpMessage" ("cwMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_conversation_id" ON "zpMessage" ("cwConversationId");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_sync_status" ON "zpMessage" ("syncStatus");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_timestamp" ON "zpMessage" ("zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_type" ON "zpMessage" ("zpType");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_zp_from_me" ON "zpMessage" ("zpFromMe");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_created_at" ON "zpMessage" ("createdAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat" ON "zpMessage" ("sessionId", "zpChat");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_cw_conversation_status" ON "zpMessage" ("cwConversationId", "syncStatus");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_synced_at" ON "zpMessage" ("syncedAt");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_timestamp" ON "zpMessage" ("sessionId", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_chat_timestamp" ON "zpMessage" ("zpChat", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_sender_timestamp" ON "zpMessage" ("zpSender", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_type_timestamp" ON "zpMessage" ("zpType", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_pending_sync" ON "zpMessage" ("sessionId", "createdAt") WHERE "syncStatus" = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_zp_message_failed_sync" ON "zpMessage" ("sessionId", "createdAt") WHERE "syncStatus" = 'failed';
+CREATE INDEX IF NOT EXISTS "idx_zp_message_content_text" ON "zpMessage" USING gin(to_tsvector('english', "content")) WHERE "content" IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "idx_zp_message_content_search" ON "zpMessage" USING gin(to_tsvector('simple', coalesce("content", '')));
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp");
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_timestamp_id" ON "zpMessage" ("sessionId", "zpTimestamp" DESC, "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_zp_message_session_chat_timestamp_id" ON "zpMessage" ("sessionId", "zpChat", "zpTimestamp" DESC, "id" DESC);
+
+CREATE TRIGGER update_zp_message_updated_at
+    BEFORE UPDATE ON "zpMessage"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE "zpMessage" IS 'WhatsApp messages, partitioned by month of "zpTimestamp"';
+COMMENT ON FUNCTION zp_ensure_message_partitions(DATE, INTEGER) IS 'Creates missing monthly partitions of zpMessage';
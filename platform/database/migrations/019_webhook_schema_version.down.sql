@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Webhook Schema Versions
+-- =====================================================
+
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "schemaVersion";
@@ -0,0 +1,9 @@
+-- =====================================================
+-- zpwoot Database Schema - Webhook Schema Versions
+-- Payload format each webhook receives
+-- =====================================================
+
+-- Existing webhooks keep the original envelope.
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "schemaVersion" INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN "zpWebhooks"."schemaVersion" IS 'Version of the event envelope posted to the webhook (1 or 2)';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Chat Notes
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpChatNotes";
@@ -0,0 +1,23 @@
+-- =====================================================
+-- zpwoot Database Schema - Chat Notes
+-- Private notes and drafts per chat, never sent to WhatsApp
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpChatNotes" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "kind" VARCHAR(16) NOT NULL DEFAULT 'note',
+    "author" VARCHAR(255) NOT NULL,
+    "body" TEXT NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT "zpChatNotes_kind_check" CHECK ("kind" IN ('note', 'draft'))
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_chat_notes_chat" ON "zpChatNotes" ("sessionId", "chatJid", "createdAt" DESC);
+
+COMMENT ON TABLE "zpChatNotes" IS 'Internal notes and drafts shared by the people working a chat';
+COMMENT ON COLUMN "zpChatNotes"."kind" IS 'note for context, draft for a reply not sent yet';
+COMMENT ON COLUMN "zpChatNotes"."author" IS 'Free-form name of whoever wrote the note';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Session Status History
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpSessionStatusHistory";
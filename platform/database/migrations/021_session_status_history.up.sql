@@ -0,0 +1,18 @@
+-- =====================================================
+-- zpwoot Database Schema - Session Status History
+-- Every connection status transition, for uptime reports
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpSessionStatusHistory" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "fromStatus" VARCHAR(20),
+    "toStatus" VARCHAR(20) NOT NULL,
+    "reason" TEXT NOT NULL DEFAULT '',
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_session_status_history_session" ON "zpSessionStatusHistory" ("sessionId", "createdAt");
+
+COMMENT ON TABLE "zpSessionStatusHistory" IS 'Connection status transitions of each session';
+COMMENT ON COLUMN "zpSessionStatusHistory"."fromStatus" IS 'Status before the transition, NULL for the first one recorded';
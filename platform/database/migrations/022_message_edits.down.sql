@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Message Edits
+-- =====================================================
+
+ALTER TABLE "zpMessage" DROP COLUMN IF EXISTS "revokedAt";
+ALTER TABLE "zpMessage" DROP COLUMN IF EXISTS "editedAt";
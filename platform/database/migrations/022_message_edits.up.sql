@@ -0,0 +1,10 @@
+-- =====================================================
+-- zpwoot Database Schema - Message Edits
+-- Edits and revokes received for stored messages
+-- =====================================================
+
+ALTER TABLE "zpMessage" ADD COLUMN IF NOT EXISTS "editedAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpMessage" ADD COLUMN IF NOT EXISTS "revokedAt" TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN "zpMessage"."editedAt" IS 'When the sender last edited the message; content holds the edited text';
+COMMENT ON COLUMN "zpMessage"."revokedAt" IS 'When the message was deleted for everyone';
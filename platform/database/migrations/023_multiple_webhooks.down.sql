@@ -0,0 +1,29 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Multiple Webhooks per Session
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_webhooks_session_priority";
+
+DROP TRIGGER IF EXISTS update_zp_webhooks_updated_at ON "zpWebhooks";
+CREATE TRIGGER update_zp_webhooks_updated_at
+    BEFORE UPDATE ON "zpWebhooks"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Keep only the highest-priority webhook of each session so the unique
+-- index can be restored.
+DELETE FROM "zpWebhooks" w
+USING (
+    SELECT "id", ROW_NUMBER() OVER (PARTITION BY "sessionId" ORDER BY "priority" DESC, "createdAt") AS rank
+    FROM "zpWebhooks"
+) ranked
+WHERE w."id" = ranked."id" AND ranked.rank > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_webhooks_session_unique" ON "zpWebhooks" ("sessionId");
+
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "failureCount";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastError";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastStatusCode";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastSuccessAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "lastDeliveryAt";
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "priority";
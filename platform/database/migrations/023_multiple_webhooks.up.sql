@@ -0,0 +1,30 @@
+-- =====================================================
+-- zpwoot Database Schema - Multiple Webhooks per Session
+-- Priorities and per-endpoint delivery state
+-- =====================================================
+
+DROP INDEX IF EXISTS "idx_zp_webhooks_session_unique";
+
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "priority" INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastDeliveryAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastSuccessAt" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastStatusCode" INTEGER;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "lastError" TEXT;
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "failureCount" INTEGER NOT NULL DEFAULT 0;
+
+-- Delivery bookkeeping runs on every event and is not a configuration
+-- change, so only configuration columns bump "updatedAt".
+DROP TRIGGER IF EXISTS update_zp_webhooks_updated_at ON "zpWebhooks";
+CREATE TRIGGER update_zp_webhooks_updated_at
+    BEFORE UPDATE OF "url", "secret", "events", "template", "schemaVersion", "priority", "enabled" ON "zpWebhooks"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS "idx_zp_webhooks_session_priority" ON "zpWebhooks" ("sessionId", "priority" DESC, "createdAt");
+
+COMMENT ON COLUMN "zpWebhooks"."priority" IS 'Order in which the session''s webhooks are listed and dispatched, highest first';
+COMMENT ON COLUMN "zpWebhooks"."lastDeliveryAt" IS 'When the last event was posted to this webhook';
+COMMENT ON COLUMN "zpWebhooks"."lastSuccessAt" IS 'When this webhook last accepted an event';
+COMMENT ON COLUMN "zpWebhooks"."lastStatusCode" IS 'HTTP status of the last delivery, NULL when the request failed';
+COMMENT ON COLUMN "zpWebhooks"."lastError" IS 'Error of the last delivery, NULL when it succeeded';
+COMMENT ON COLUMN "zpWebhooks"."failureCount" IS 'Consecutive failed deliveries';
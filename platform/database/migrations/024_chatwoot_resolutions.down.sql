@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Chatwoot Resolutions
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpChatwootResolutions";
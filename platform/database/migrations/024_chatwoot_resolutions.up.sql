@@ -0,0 +1,17 @@
+-- =====================================================
+-- zpwoot Database Schema - Chatwoot Resolutions
+-- Resolved conversations to reopen when the contact replies
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpChatwootResolutions" (
+    "accountId" INTEGER NOT NULL,
+    "conversationId" INTEGER NOT NULL,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "contactJid" VARCHAR(255) NOT NULL,
+    "resolvedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("accountId", "conversationId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_chatwoot_resolutions_contact" ON "zpChatwootResolutions" ("sessionId", "contactJid", "resolvedAt" DESC);
+
+COMMENT ON TABLE "zpChatwootResolutions" IS 'Resolved Chatwoot conversations, reopened when the contact writes again';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Number Checks
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpNumberChecks";
@@ -0,0 +1,15 @@
+-- =====================================================
+-- zpwoot Database Schema - Number Checks
+-- Cached answers to "is this number on WhatsApp?"
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpNumberChecks" (
+    "phone" VARCHAR(20) PRIMARY KEY,
+    "jid" VARCHAR(255) NOT NULL DEFAULT '',
+    "onWhatsApp" BOOLEAN NOT NULL,
+    "checkedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_number_checks_checked_at" ON "zpNumberChecks" ("checkedAt");
+
+COMMENT ON TABLE "zpNumberChecks" IS 'Latest WhatsApp registration lookup of each phone number, shared by all sessions';
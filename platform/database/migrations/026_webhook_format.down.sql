@@ -0,0 +1,11 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Webhook Payload Formats
+-- =====================================================
+
+DROP TRIGGER IF EXISTS update_zp_webhooks_updated_at ON "zpWebhooks";
+CREATE TRIGGER update_zp_webhooks_updated_at
+    BEFORE UPDATE OF "url", "secret", "events", "template", "schemaVersion", "priority", "enabled" ON "zpWebhooks"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE "zpWebhooks" DROP COLUMN IF EXISTS "format";
@@ -0,0 +1,15 @@
+-- =====================================================
+-- zpwoot Database Schema - Webhook Payload Formats
+-- Full envelopes or flat payloads for low-code tools
+-- =====================================================
+
+-- Existing webhooks keep the full envelope.
+ALTER TABLE "zpWebhooks" ADD COLUMN IF NOT EXISTS "format" VARCHAR(10) NOT NULL DEFAULT 'full';
+
+DROP TRIGGER IF EXISTS update_zp_webhooks_updated_at ON "zpWebhooks";
+CREATE TRIGGER update_zp_webhooks_updated_at
+    BEFORE UPDATE OF "url", "secret", "events", "template", "schemaVersion", "format", "priority", "enabled" ON "zpWebhooks"
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN "zpWebhooks"."format" IS 'Payload posted to the webhook: full (the schemaVersion envelope) or simple (flat fields)';
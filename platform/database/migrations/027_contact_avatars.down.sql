@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Contact Avatars
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpContactAvatars";
@@ -0,0 +1,21 @@
+-- =====================================================
+-- zpwoot Database Schema - Contact Avatars
+-- Latest known profile picture of each contact, per session
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpContactAvatars" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "jid" VARCHAR(255) NOT NULL,
+    "pictureId" VARCHAR(64) NOT NULL DEFAULT '',
+    "url" TEXT NOT NULL DEFAULT '',
+    "localPath" TEXT NOT NULL DEFAULT '',
+    "checkedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "changedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "jid")
+);
+
+COMMENT ON TABLE "zpContactAvatars" IS 'Profile picture IDs seen per contact, so unchanged pictures are not fetched again';
+COMMENT ON COLUMN "zpContactAvatars"."pictureId" IS 'WhatsApp picture ID, empty when the contact has no picture or hides it';
+COMMENT ON COLUMN "zpContactAvatars"."localPath" IS 'Copy of the picture under the media directory';
+COMMENT ON COLUMN "zpContactAvatars"."checkedAt" IS 'Last time WhatsApp was asked for the picture';
+COMMENT ON COLUMN "zpContactAvatars"."changedAt" IS 'When the picture ID last changed';
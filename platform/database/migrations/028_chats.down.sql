@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Chats
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpChats";
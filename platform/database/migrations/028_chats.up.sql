@@ -0,0 +1,31 @@
+-- =====================================================
+-- zpwoot Database Schema - Chats
+-- Inbox read model: last message and unread count per chat
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpChats" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "unreadCount" INTEGER NOT NULL DEFAULT 0,
+    "lastMessageId" VARCHAR(255) NOT NULL DEFAULT '',
+    "lastMessageAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "lastFromMe" BOOLEAN NOT NULL DEFAULT false,
+    "lastReadAt" TIMESTAMP WITH TIME ZONE,
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "chatJid")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_chats_recent" ON "zpChats" ("sessionId", "lastMessageAt" DESC);
+CREATE INDEX IF NOT EXISTS "idx_zp_chats_unread" ON "zpChats" ("sessionId", "unreadCount" DESC, "lastMessageAt" DESC);
+
+-- Chats with stored messages start out read.
+INSERT INTO "zpChats" ("sessionId", "chatJid", "lastMessageId", "lastMessageAt", "lastFromMe")
+SELECT DISTINCT ON ("sessionId", "zpChat") "sessionId", "zpChat", "zpMessageId", "zpTimestamp", "zpFromMe"
+FROM "zpMessage"
+WHERE "zpChat" NOT LIKE '%@broadcast'
+ORDER BY "sessionId", "zpChat", "zpTimestamp" DESC
+ON CONFLICT ("sessionId", "chatJid") DO NOTHING;
+
+COMMENT ON TABLE "zpChats" IS 'One row per conversation, updated as messages arrive and chats are read';
+COMMENT ON COLUMN "zpChats"."unreadCount" IS 'Messages from the contact received after the chat was last read';
+COMMENT ON COLUMN "zpChats"."lastReadAt" IS 'When the chat was last read from the API or a linked device';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Session Bans
+-- =====================================================
+
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "ban";
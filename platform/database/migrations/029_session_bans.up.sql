@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - Session Bans
+-- Account bans and temporary restrictions reported by WhatsApp
+-- =====================================================
+
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "ban" JSONB;
+
+COMMENT ON COLUMN "zpSessions"."ban" IS 'Latest ban or restriction (kind, reason, code, since, until), cleared on the next successful connection';
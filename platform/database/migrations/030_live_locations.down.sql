@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Live Locations
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpLiveLocations";
@@ -0,0 +1,28 @@
+-- =====================================================
+-- zpwoot Database Schema - Live Locations
+-- Every position reported while a live location is shared
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpLiveLocations" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "sharerJid" VARCHAR(255) NOT NULL,
+    "zpMessageId" VARCHAR(255) NOT NULL,
+    "latitude" DOUBLE PRECISION NOT NULL,
+    "longitude" DOUBLE PRECISION NOT NULL,
+    "accuracyMeters" INTEGER NOT NULL DEFAULT 0,
+    "speedMps" REAL NOT NULL DEFAULT 0,
+    "heading" INTEGER NOT NULL DEFAULT 0,
+    "caption" TEXT NOT NULL DEFAULT '',
+    "sequence" BIGINT NOT NULL DEFAULT 0,
+    "fromMe" BOOLEAN NOT NULL DEFAULT false,
+    "reportedAt" TIMESTAMP WITH TIME ZONE NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Redelivered updates are stored once
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_zp_live_locations_message" ON "zpLiveLocations" ("sessionId", "zpMessageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_live_locations_chat" ON "zpLiveLocations" ("sessionId", "chatJid", "sharerJid", "reportedAt" DESC);
+
+COMMENT ON TABLE "zpLiveLocations" IS 'Live location updates per chat and sharer; the newest row of each sharer is their current position';
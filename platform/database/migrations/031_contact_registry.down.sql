@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Contact Registry
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpContactSessions";
+DROP TABLE IF EXISTS "zpContacts";
@@ -0,0 +1,36 @@
+-- =====================================================
+-- zpwoot Database Schema - Contact Registry
+-- One canonical record per WhatsApp user across sessions,
+-- with what each session knows about them
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpContacts" (
+    "id" UUID PRIMARY KEY,
+    "jid" VARCHAR(255) NOT NULL UNIQUE,
+    "phoneNumber" VARCHAR(32) NOT NULL,
+    "name" VARCHAR(255) NOT NULL DEFAULT '',
+    "pushName" VARCHAR(255) NOT NULL DEFAULT '',
+    "businessName" VARCHAR(255) NOT NULL DEFAULT '',
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS "zpContactSessions" (
+    "contactId" UUID NOT NULL REFERENCES "zpContacts"("id") ON DELETE CASCADE,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "name" VARCHAR(255) NOT NULL DEFAULT '',
+    "pushName" VARCHAR(255) NOT NULL DEFAULT '',
+    "businessName" VARCHAR(255) NOT NULL DEFAULT '',
+    "isContact" BOOLEAN NOT NULL DEFAULT false,
+    "firstSeenAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "lastSeenAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("contactId", "sessionId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_contact_sessions_session" ON "zpContactSessions" ("sessionId");
+
+COMMENT ON TABLE "zpContacts" IS 'Canonical contact per WhatsApp user, shared by all sessions';
+COMMENT ON COLUMN "zpContacts"."jid" IS 'Phone number JID without device suffix';
+COMMENT ON COLUMN "zpContacts"."name" IS 'Latest non-empty address book name reported by any session';
+COMMENT ON TABLE "zpContactSessions" IS 'What each session knows about a contact; avatars are read from zpContactAvatars';
+COMMENT ON COLUMN "zpContactSessions"."isContact" IS 'Whether the contact is in the session''s address book';
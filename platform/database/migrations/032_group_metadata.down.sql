@@ -0,0 +1,6 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Group Metadata
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpChatwootGroupContacts";
+DROP TABLE IF EXISTS "zpGroupMetadata";
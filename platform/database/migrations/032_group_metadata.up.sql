@@ -0,0 +1,32 @@
+-- =====================================================
+-- zpwoot Database Schema - Group Metadata
+-- Cached group names and topics, and the Chatwoot contacts showing them
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpGroupMetadata" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "groupJid" VARCHAR(255) NOT NULL,
+    "name" VARCHAR(255) NOT NULL DEFAULT '',
+    "topic" TEXT NOT NULL DEFAULT '',
+    "participantCount" INTEGER NOT NULL DEFAULT 0,
+    "checkedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "changedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "groupJid")
+);
+
+CREATE TABLE IF NOT EXISTS "zpChatwootGroupContacts" (
+    "accountId" INTEGER NOT NULL,
+    "contactId" INTEGER NOT NULL,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "groupJid" VARCHAR(255) NOT NULL,
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("accountId", "contactId")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_chatwoot_group_contacts_group" ON "zpChatwootGroupContacts" ("sessionId", "groupJid");
+
+COMMENT ON TABLE "zpGroupMetadata" IS 'Latest name and topic seen per group, refreshed from events and periodically for active groups';
+COMMENT ON COLUMN "zpGroupMetadata"."participantCount" IS 'Participants at the last refresh; 0 when only events were seen';
+COMMENT ON COLUMN "zpGroupMetadata"."checkedAt" IS 'Last time the metadata was fetched or announced';
+COMMENT ON COLUMN "zpGroupMetadata"."changedAt" IS 'When the name or topic last changed';
+COMMENT ON TABLE "zpChatwootGroupContacts" IS 'Chatwoot contacts standing for WhatsApp groups, renamed when the group is';
@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Session Devices
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpSessionDevices";
@@ -0,0 +1,19 @@
+-- =====================================================
+-- zpwoot Database Schema - Session Devices
+-- Devices registered under each session's WhatsApp account
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpSessionDevices" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "deviceId" INTEGER NOT NULL,
+    "jid" VARCHAR(255) NOT NULL,
+    "platform" VARCHAR(50) NOT NULL DEFAULT '',
+    "firstSeenAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "lastSeenAt" TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY ("sessionId", "deviceId")
+);
+
+COMMENT ON TABLE "zpSessionDevices" IS 'Phone and companion devices seen under each session account, to report newly linked companions';
+COMMENT ON COLUMN "zpSessionDevices"."deviceId" IS 'Device number in the JID; 0 is the phone';
+COMMENT ON COLUMN "zpSessionDevices"."platform" IS 'Platform when known: the phone, this session, or guessed from message IDs';
+COMMENT ON COLUMN "zpSessionDevices"."lastSeenAt" IS 'Last message or receipt seen coming from the device';
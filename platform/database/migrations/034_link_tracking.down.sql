@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Link Tracking
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpLinkClicks";
+DROP TABLE IF EXISTS "zpTrackedLinks";
+
+ALTER TABLE "zpTenants" DROP COLUMN IF EXISTS "trackingDomain";
@@ -0,0 +1,34 @@
+-- =====================================================
+-- zpwoot Database Schema - Link Tracking
+-- Outbound links rewritten to a redirect and the clicks on them
+-- =====================================================
+
+ALTER TABLE "zpTenants" ADD COLUMN IF NOT EXISTS "trackingDomain" VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "zpTrackedLinks" (
+    "code" VARCHAR(32) PRIMARY KEY,
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "messageId" VARCHAR(255) NOT NULL DEFAULT '',
+    "chatJid" VARCHAR(255) NOT NULL,
+    "campaign" VARCHAR(100) NOT NULL DEFAULT '',
+    "url" TEXT NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_tracked_links_message" ON "zpTrackedLinks" ("sessionId", "messageId");
+CREATE INDEX IF NOT EXISTS "idx_zp_tracked_links_campaign" ON "zpTrackedLinks" ("sessionId", "campaign", "createdAt" DESC);
+
+CREATE TABLE IF NOT EXISTS "zpLinkClicks" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "code" VARCHAR(32) NOT NULL REFERENCES "zpTrackedLinks"("code") ON DELETE CASCADE,
+    "clickedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    "ip" VARCHAR(64) NOT NULL DEFAULT '',
+    "userAgent" TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_link_clicks_code" ON "zpLinkClicks" ("code", "clickedAt" DESC);
+
+COMMENT ON COLUMN "zpTenants"."trackingDomain" IS 'Base URL tracked links of the tenant''s sessions point at; empty for the server''s own';
+COMMENT ON TABLE "zpTrackedLinks" IS 'Links of outbound messages replaced by a redirect, one per URL and recipient';
+COMMENT ON COLUMN "zpTrackedLinks"."messageId" IS 'WhatsApp message ID, empty until the message is sent';
+COMMENT ON TABLE "zpLinkClicks" IS 'Each follow of a tracked link';
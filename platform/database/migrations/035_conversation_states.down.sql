@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Conversation State
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpConversationStates";
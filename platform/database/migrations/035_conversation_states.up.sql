@@ -0,0 +1,20 @@
+-- =====================================================
+-- zpwoot Database Schema - Conversation State
+-- Dialog state bots keep per chat, with TTL and versioning
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpConversationStates" (
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "data" JSONB NOT NULL,
+    "version" BIGINT NOT NULL DEFAULT 1,
+    "expiresAt" TIMESTAMP WITH TIME ZONE,
+    "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("sessionId", "chatJid")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_conversation_states_expires" ON "zpConversationStates" ("expiresAt") WHERE "expiresAt" IS NOT NULL;
+
+COMMENT ON TABLE "zpConversationStates" IS 'Arbitrary JSON state bots keep per chat between messages';
+COMMENT ON COLUMN "zpConversationStates"."version" IS 'Grows with every write, for compare-and-swap updates';
+COMMENT ON COLUMN "zpConversationStates"."expiresAt" IS 'State is ignored past this time and purged later; NULL never expires';
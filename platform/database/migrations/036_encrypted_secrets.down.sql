@@ -0,0 +1,9 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Encrypted Secrets
+-- Encrypted values must be decrypted before rolling back
+-- =====================================================
+
+ALTER TABLE "zpWebhooks" ALTER COLUMN "secret" TYPE VARCHAR(255);
+
+COMMENT ON COLUMN "zpWebhooks"."secret" IS 'Optional webhook secret for verification';
+COMMENT ON COLUMN "zpSessions"."proxyConfig" IS 'Proxy configuration in JSON format';
@@ -0,0 +1,9 @@
+-- =====================================================
+-- zpwoot Database Schema - Encrypted Secrets
+-- Room for webhook secrets sealed with a master key
+-- =====================================================
+
+ALTER TABLE "zpWebhooks" ALTER COLUMN "secret" TYPE TEXT;
+
+COMMENT ON COLUMN "zpWebhooks"."secret" IS 'Webhook secret for verification, encrypted (enc:v1:...) when a master key is configured';
+COMMENT ON COLUMN "zpSessions"."proxyConfig" IS 'Proxy configuration in JSON format; username and password encrypted (enc:v1:...) when a master key is configured';
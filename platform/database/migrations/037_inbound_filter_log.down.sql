@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback Inbound Filter Log
+-- =====================================================
+
+DROP TABLE IF EXISTS "zpFilteredMessages";
@@ -0,0 +1,25 @@
+-- =====================================================
+-- zpwoot Database Schema - Inbound Filter Log
+-- Inbound messages the spam filter matched, kept for review
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS "zpFilteredMessages" (
+    "id" UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    "sessionId" UUID NOT NULL REFERENCES "zpSessions"("id") ON DELETE CASCADE,
+    "messageId" VARCHAR(255) NOT NULL,
+    "chatJid" VARCHAR(255) NOT NULL,
+    "senderJid" VARCHAR(255) NOT NULL,
+    "text" TEXT NOT NULL DEFAULT '',
+    "rule" VARCHAR(255) NOT NULL,
+    "conditions" JSONB NOT NULL DEFAULT '[]',
+    "keyword" TEXT,
+    "actions" JSONB NOT NULL DEFAULT '[]',
+    "actionErrors" JSONB,
+    "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_zp_filtered_messages_session" ON "zpFilteredMessages" ("sessionId", "createdAt" DESC, "id" DESC);
+
+COMMENT ON TABLE "zpFilteredMessages" IS 'Inbound messages an inbound filter rule matched, with the actions taken';
+COMMENT ON COLUMN "zpFilteredMessages"."conditions" IS 'Conditions of the rule the message met: keyword, pattern, sender, link_only';
+COMMENT ON COLUMN "zpFilteredMessages"."actionErrors" IS 'Actions that failed (reply, block), with why; NULL when all succeeded';
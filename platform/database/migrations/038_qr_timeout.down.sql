@@ -0,0 +1,5 @@
+-- =====================================================
+-- zpwoot Database Schema - Rollback QR Timeout Tracking
+-- =====================================================
+
+ALTER TABLE "zpSessions" DROP COLUMN IF EXISTS "qrTimeoutAt";
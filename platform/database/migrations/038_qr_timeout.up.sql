@@ -0,0 +1,8 @@
+-- =====================================================
+-- zpwoot Database Schema - QR Timeout Tracking
+-- Sessions whose pairing ended with no QR code scanned
+-- =====================================================
+
+ALTER TABLE "zpSessions" ADD COLUMN IF NOT EXISTS "qrTimeoutAt" TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN "zpSessions"."qrTimeoutAt" IS 'When a pairing ran out of QR codes unscanned; cleared once a new pairing starts or the session connects';
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"zpwoot/platform/config"
+	"zpwoot/platform/logger"
+)
+
+// replicaLagQuery measures how far the replica's replay is behind. A standby
+// that replayed everything it received is not behind, however old its last
+// replayed transaction; a server that is not a standby reports no lag.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+// ReplicaStatus is what the last health check saw of the read replica.
+type ReplicaStatus struct {
+	Healthy   bool
+	Lag       time.Duration
+	CheckedAt time.Time
+	LastError string
+}
+
+// replica is a read-only pool heavy reads are sent to while it answers and
+// keeps up with the primary. It is checked every interval; reads go to the
+// primary from the first failed check until one succeeds again.
+type replica struct {
+	db       *sqlx.DB
+	interval time.Duration
+	maxLag   time.Duration
+	logger   *logger.Logger
+
+	mu     sync.RWMutex
+	status ReplicaStatus
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func openReplica(cfg config.DatabaseConfig, stats *statsRecorder, log *logger.Logger) (*replica, error) {
+	base, err := pq.NewConnector(cfg.ReplicaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replica URL: %w", err)
+	}
+
+	// A replica that is down must not hold reads up while connecting, so
+	// connections are tried once and the primary takes over.
+	db := sqlx.NewDb(sql.OpenDB(&connector{
+		base:   base,
+		stats:  stats,
+		retry:  RetryPolicy{Attempts: 1},
+		logger: log,
+	}), "postgres")
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+
+	return &replica{
+		db:       db,
+		interval: time.Duration(cfg.ReplicaCheckInterval) * time.Second,
+		maxLag:   time.Duration(cfg.ReplicaMaxLag) * time.Second,
+		logger:   log,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// run checks the replica right away and then every interval until closed.
+func (r *replica) run() {
+	defer close(r.done)
+
+	r.check()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+func (r *replica) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status := ReplicaStatus{CheckedAt: time.Now()}
+
+	var lagSeconds float64
+	err := r.db.GetContext(ctx, &lagSeconds, replicaLagQuery)
+	switch {
+	case err != nil:
+		status.LastError = err.Error()
+	default:
+		status.Lag = time.Duration(lagSeconds * float64(time.Second))
+		if r.maxLag > 0 && status.Lag > r.maxLag {
+			status.LastError = fmt.Sprintf("replica is %s behind the primary", status.Lag.Round(time.Second))
+		} else {
+			status.Healthy = true
+		}
+	}
+
+	r.mu.Lock()
+	previous := r.status
+	r.status = status
+	r.mu.Unlock()
+
+	switch {
+	case previous.Healthy && !status.Healthy:
+		r.logger.WarnWithFields("Read replica unavailable, reading from the primary", map[string]interface{}{
+			"module": "database",
+			"error":  status.LastError,
+		})
+	case !previous.Healthy && status.Healthy:
+		r.logger.InfoWithFields("Read replica available, heavy reads go to it", map[string]interface{}{
+			"module": "database",
+			"lag_ms": status.Lag.Milliseconds(),
+		})
+	}
+}
+
+func (r *replica) healthy() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status.Healthy
+}
+
+func (r *replica) snapshot() ReplicaStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+func (r *replica) close() error {
+	close(r.stop)
+	<-r.done
+	return r.db.Close()
+}
+
+// Reader returns the pool heavy read-only queries (history, search, stats)
+// should use: the read replica while it is healthy, the primary otherwise
+// or when no replica is configured. Reads that must see a write just made
+// belong on the primary.
+func (d *Database) Reader() *sqlx.DB {
+	if d.replica != nil && d.replica.healthy() {
+		return d.replica.db
+	}
+	return d.DB
+}
+
+// ReplicaStatus reports the read replica's last health check, or false
+// when no replica is configured.
+func (d *Database) ReplicaStatus() (ReplicaStatus, bool) {
+	if d.replica == nil {
+		return ReplicaStatus{}, false
+	}
+	return d.replica.snapshot(), true
+}
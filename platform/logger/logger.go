@@ -230,6 +230,12 @@ func (l *Logger) GetConfig() config.LogConfig {
 	return l.config
 }
 
+// SetLevel changes the process-wide log level at runtime.
+func (l *Logger) SetLevel(level string) {
+	zerolog.SetGlobalLevel(parseLogLevel(level))
+	l.config.Level = level
+}
+
 func (l *Logger) IsDebugEnabled() bool {
 	return l.logger.GetLevel() <= zerolog.DebugLevel
 }
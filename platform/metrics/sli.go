@@ -0,0 +1,289 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Windows the SLI series are pre-aggregated over, the pairs used by
+// multi-window burn rate alerts (5m with 1h, 30m with 6h).
+var sliWindows = []struct {
+	label   string
+	minutes int
+}{
+	{"5m", 5},
+	{"30m", 30},
+	{"1h", 60},
+	{"6h", 360},
+}
+
+// latencyBuckets are the upper bounds, in seconds, of the request duration
+// histogram.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+const historyMinutes = 360
+
+// minute holds what was observed in one minute. Durations counts requests
+// per latency bucket, the last slot being above every bound.
+type minute struct {
+	at        int64
+	total     int64
+	good      int64
+	durations []int64
+}
+
+// series is a ring of the last historyMinutes minutes plus totals since
+// startup.
+type series struct {
+	ring     []minute
+	total    int64
+	good     int64
+	buckets  []int64
+	duration float64
+}
+
+func newSeries(histogram bool) *series {
+	s := &series{ring: make([]minute, historyMinutes)}
+	if histogram {
+		s.buckets = make([]int64, len(latencyBuckets)+1)
+	}
+	return s
+}
+
+func (s *series) observe(now time.Time, good bool, elapsed time.Duration, histogram bool) {
+	at := now.Unix() / 60
+	slot := &s.ring[at%historyMinutes]
+	if slot.at != at {
+		*slot = minute{at: at}
+		if histogram {
+			slot.durations = make([]int64, len(latencyBuckets)+1)
+		}
+	}
+
+	slot.total++
+	s.total++
+	if good {
+		slot.good++
+		s.good++
+	}
+
+	if histogram {
+		bucket := sort.SearchFloat64s(latencyBuckets, elapsed.Seconds())
+		slot.durations[bucket]++
+		s.buckets[bucket]++
+		s.duration += elapsed.Seconds()
+	}
+}
+
+// window sums the minutes within the last minutes minutes.
+func (s *series) window(now time.Time, minutes int) (total, good int64, durations []int64) {
+	current := now.Unix() / 60
+	for i := 0; i < minutes; i++ {
+		slot := &s.ring[(current-int64(i))%historyMinutes]
+		if slot.at != current-int64(i) {
+			continue
+		}
+		total += slot.total
+		good += slot.good
+		if slot.durations != nil {
+			if durations == nil {
+				durations = make([]int64, len(latencyBuckets)+1)
+			}
+			for b, count := range slot.durations {
+				durations[b] += count
+			}
+		}
+	}
+	return total, good, durations
+}
+
+// SLI keeps the service level indicators SLO dashboards and alerts are
+// built on: API request success and latency per route group, and webhook
+// delivery success. Besides raw counters it exports each ratio, the p95
+// latency and the error budget burn rate already aggregated over fixed
+// windows, so dashboards read them directly instead of computing rates over
+// raw series. Route groups are a short fixed list, keeping label cardinality
+// low.
+type SLI struct {
+	requestObjective float64
+	webhookObjective float64
+
+	mu       sync.Mutex
+	requests map[string]*series
+	webhooks *series
+}
+
+// NewSLI takes the objectives, as fractions of good events, that burn rates
+// are measured against.
+func NewSLI(requestObjective, webhookObjective float64) *SLI {
+	return &SLI{
+		requestObjective: requestObjective,
+		webhookObjective: webhookObjective,
+		requests:         make(map[string]*series),
+		webhooks:         newSeries(false),
+	}
+}
+
+// ObserveRequest records an API request. Responses below 500 count as good:
+// client errors are the caller's, not the service's.
+func (s *SLI) ObserveRequest(group string, status int, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests, ok := s.requests[group]
+	if !ok {
+		requests = newSeries(true)
+		s.requests[group] = requests
+	}
+	requests.observe(time.Now(), status < 500, elapsed, true)
+}
+
+// ObserveWebhook records a webhook delivery after its retries.
+func (s *SLI) ObserveWebhook(delivered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks.observe(time.Now(), delivered, 0, false)
+}
+
+// WritePrometheus writes every series in the Prometheus text format.
+// Windowed series are left out for windows without events.
+func (s *SLI) WritePrometheus(w io.Writer, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := make([]string, 0, len(s.requests))
+	for group := range s.requests {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var b strings.Builder
+
+	writeHeader(&b, "zpwoot_sli_requests_total", "counter", "API requests by route group.")
+	for _, group := range groups {
+		fmt.Fprintf(&b, "zpwoot_sli_requests_total{route_group=%q} %d\n", group, s.requests[group].total)
+	}
+	writeHeader(&b, "zpwoot_sli_requests_good_total", "counter", "API requests answered without a server error, by route group.")
+	for _, group := range groups {
+		fmt.Fprintf(&b, "zpwoot_sli_requests_good_total{route_group=%q} %d\n", group, s.requests[group].good)
+	}
+
+	writeHeader(&b, "zpwoot_sli_request_duration_seconds", "histogram", "API request latency by route group.")
+	for _, group := range groups {
+		requests := s.requests[group]
+		var cumulative int64
+		for i, bound := range latencyBuckets {
+			cumulative += requests.buckets[i]
+			fmt.Fprintf(&b, "zpwoot_sli_request_duration_seconds_bucket{route_group=%q,le=%q} %d\n", group, formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(&b, "zpwoot_sli_request_duration_seconds_bucket{route_group=%q,le=\"+Inf\"} %d\n", group, requests.total)
+		fmt.Fprintf(&b, "zpwoot_sli_request_duration_seconds_sum{route_group=%q} %s\n", group, formatFloat(requests.duration))
+		fmt.Fprintf(&b, "zpwoot_sli_request_duration_seconds_count{route_group=%q} %d\n", group, requests.total)
+	}
+
+	type windowed struct {
+		group, window string
+		ratio, p95    float64
+	}
+	var windows []windowed
+	for _, group := range groups {
+		for _, window := range sliWindows {
+			total, good, durations := s.requests[group].window(now, window.minutes)
+			if total == 0 {
+				continue
+			}
+			windows = append(windows, windowed{
+				group:  group,
+				window: window.label,
+				ratio:  float64(good) / float64(total),
+				p95:    quantile(0.95, durations, total),
+			})
+		}
+	}
+
+	writeHeader(&b, "zpwoot_sli_request_success_ratio", "gauge", "Share of API requests answered without a server error over the window.")
+	for _, row := range windows {
+		fmt.Fprintf(&b, "zpwoot_sli_request_success_ratio{route_group=%q,window=%q} %s\n", row.group, row.window, formatFloat(row.ratio))
+	}
+	writeHeader(&b, "zpwoot_sli_request_latency_p95_seconds", "gauge", "95th percentile API request latency over the window, interpolated within histogram buckets.")
+	for _, row := range windows {
+		fmt.Fprintf(&b, "zpwoot_sli_request_latency_p95_seconds{route_group=%q,window=%q} %s\n", row.group, row.window, formatFloat(row.p95))
+	}
+	writeHeader(&b, "zpwoot_slo_request_burn_rate", "gauge", "Rate the API availability error budget is spent at over the window; 1 spends it exactly over the SLO period.")
+	for _, row := range windows {
+		fmt.Fprintf(&b, "zpwoot_slo_request_burn_rate{route_group=%q,window=%q} %s\n", row.group, row.window, formatFloat(burnRate(row.ratio, s.requestObjective)))
+	}
+
+	writeHeader(&b, "zpwoot_sli_webhook_deliveries_total", "counter", "Webhook deliveries, counting each delivery once after its retries.")
+	fmt.Fprintf(&b, "zpwoot_sli_webhook_deliveries_total %d\n", s.webhooks.total)
+	writeHeader(&b, "zpwoot_sli_webhook_deliveries_good_total", "counter", "Webhook deliveries the receiver accepted.")
+	fmt.Fprintf(&b, "zpwoot_sli_webhook_deliveries_good_total %d\n", s.webhooks.good)
+
+	webhookRatios := make(map[string]float64)
+	for _, window := range sliWindows {
+		if total, good, _ := s.webhooks.window(now, window.minutes); total > 0 {
+			webhookRatios[window.label] = float64(good) / float64(total)
+		}
+	}
+
+	writeHeader(&b, "zpwoot_sli_webhook_delivery_success_ratio", "gauge", "Share of webhook deliveries accepted over the window.")
+	for _, window := range sliWindows {
+		if ratio, ok := webhookRatios[window.label]; ok {
+			fmt.Fprintf(&b, "zpwoot_sli_webhook_delivery_success_ratio{window=%q} %s\n", window.label, formatFloat(ratio))
+		}
+	}
+	writeHeader(&b, "zpwoot_slo_webhook_burn_rate", "gauge", "Rate the webhook delivery error budget is spent at over the window.")
+	for _, window := range sliWindows {
+		if ratio, ok := webhookRatios[window.label]; ok {
+			fmt.Fprintf(&b, "zpwoot_slo_webhook_burn_rate{window=%q} %s\n", window.label, formatFloat(burnRate(ratio, s.webhookObjective)))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// quantile estimates the q quantile of the bucketed durations by linear
+// interpolation, like Prometheus' histogram_quantile. Requests above the
+// largest bound report that bound.
+func quantile(q float64, durations []int64, total int64) float64 {
+	rank := q * float64(total)
+	var cumulative int64
+	lower := 0.0
+	for i, bound := range latencyBuckets {
+		count := durations[i]
+		if float64(cumulative+count) >= rank {
+			if count == 0 {
+				return bound
+			}
+			return lower + (bound-lower)*(rank-float64(cumulative))/float64(count)
+		}
+		cumulative += count
+		lower = bound
+	}
+	return latencyBuckets[len(latencyBuckets)-1]
+}
+
+func burnRate(ratio, objective float64) float64 {
+	if objective >= 1 {
+		return 0
+	}
+	return (1 - ratio) / (1 - objective)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return fmt.Sprintf("%g", value)
+}
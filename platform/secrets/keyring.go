@@ -0,0 +1,288 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Secrets are envelope-encrypted: each value gets its own data key, the
+// value is sealed with it (AES-256-GCM, bound to where it is stored), and
+// the data key is sealed with a master key. A stored value reads
+//
+//	enc:v1:<master key ID>:<sealed data key>:<nonce and ciphertext>
+//
+// so rotating the master key only rewraps data keys, and any master key the
+// keyring still knows opens values sealed under it. Values without the
+// prefix are plaintext written before encryption was turned on and are read
+// as they are.
+const (
+	prefix      = "enc:v1:"
+	dataKeySize = 32
+)
+
+var (
+	ErrUnknownKey    = errors.New("secret was sealed with an unknown master key")
+	ErrInvalidSecret = errors.New("invalid sealed secret")
+)
+
+// MasterKey wraps the data keys secrets are sealed with. LocalKey keeps the
+// key in memory; a KMS implements it by calling its encrypt and decrypt
+// operations on the data key.
+type MasterKey interface {
+	ID() string
+	Wrap(dataKey []byte) ([]byte, error)
+	Unwrap(wrapped []byte) ([]byte, error)
+}
+
+// Keyring seals new values with its primary master key and opens values
+// sealed with any key it holds. Without a primary key it stores values as
+// plaintext.
+type Keyring struct {
+	mu      sync.RWMutex
+	primary MasterKey
+	keys    map[string]MasterKey
+}
+
+func NewKeyring(primary MasterKey, previous ...MasterKey) *Keyring {
+	k := &Keyring{keys: make(map[string]MasterKey)}
+	for _, key := range previous {
+		k.keys[key.ID()] = key
+	}
+	if primary != nil {
+		k.Use(primary)
+	}
+	return k
+}
+
+// Use makes key the primary, keeping the previous one to open the values
+// sealed with it until they are rotated.
+func (k *Keyring) Use(key MasterKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.primary = key
+	k.keys[key.ID()] = key
+}
+
+func (k *Keyring) Enabled() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary != nil
+}
+
+// PrimaryID is the ID of the key new values are sealed with, or empty.
+func (k *Keyring) PrimaryID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.primary == nil {
+		return ""
+	}
+	return k.primary.ID()
+}
+
+// Seal encrypts value for storage at location, which names where it is kept
+// (for example the table, column and row) so a sealed value copied
+// elsewhere does not open. Empty values stay empty.
+func (k *Keyring) Seal(value, location string) (string, error) {
+	k.mu.RLock()
+	primary := k.primary
+	k.mu.RUnlock()
+
+	if value == "" || primary == nil {
+		return value, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(location))
+
+	wrapped, err := primary.Wrap(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key with %s: %w", primary.ID(), err)
+	}
+
+	return prefix + primary.ID() + ":" + encode(wrapped) + ":" + encode(sealed), nil
+}
+
+// Open decrypts a value Seal stored at location. Plaintext values come back
+// unchanged.
+func (k *Keyring) Open(value, location string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	keyID, wrapped, sealed, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	k.mu.RLock()
+	key, ok := k.keys[keyID]
+	k.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	dataKey, err := key.Unwrap(wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key with %s: %w", keyID, err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrInvalidSecret
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(location))
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether value should be sealed again: it is
+// plaintext, or sealed with a key other than the primary.
+func (k *Keyring) NeedsRotation(value string) bool {
+	primaryID := k.PrimaryID()
+	if value == "" || primaryID == "" {
+		return false
+	}
+	if !IsSealed(value) {
+		return true
+	}
+	keyID, _, _, err := parse(value)
+	return err == nil && keyID != primaryID
+}
+
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func parse(value string) (keyID string, wrapped, sealed []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	if wrapped, err = decode(parts[1]); err != nil {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	if sealed, err = decode(parts[2]); err != nil {
+		return "", nil, nil, ErrInvalidSecret
+	}
+	return parts[0], wrapped, sealed, nil
+}
+
+// LocalKey is a master key held in memory, read from configuration.
+type LocalKey struct {
+	id  string
+	gcm cipher.AEAD
+}
+
+// NewLocalKey takes a 32-byte key. The ID is stored next to every value the
+// key seals and must not contain ':'.
+func NewLocalKey(id string, key []byte) (*LocalKey, error) {
+	if id == "" || strings.Contains(id, ":") {
+		return nil, fmt.Errorf("master key ID %q must be non-empty and must not contain ':'", id)
+	}
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("master key %s must be %d bytes, got %d", id, dataKeySize, len(key))
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKey{id: id, gcm: gcm}, nil
+}
+
+// ParseLocalKey reads a key as "id:base64", the form keys are configured
+// in.
+func ParseLocalKey(spec string) (*LocalKey, error) {
+	id, encoded, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("master key must be written as id:base64")
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("master key %s is not valid base64: %w", id, err)
+	}
+	return NewLocalKey(id, key)
+}
+
+func (l *LocalKey) ID() string {
+	return l.id
+}
+
+func (l *LocalKey) Wrap(dataKey []byte) ([]byte, error) {
+	nonce := make([]byte, l.gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return l.gcm.Seal(nonce, nonce, dataKey, []byte(l.id)), nil
+}
+
+func (l *LocalKey) Unwrap(wrapped []byte) ([]byte, error) {
+	if len(wrapped) < l.gcm.NonceSize() {
+		return nil, ErrInvalidSecret
+	}
+	dataKey, err := l.gcm.Open(nil, wrapped[:l.gcm.NonceSize()], wrapped[l.gcm.NonceSize():], []byte(l.id))
+	if err != nil {
+		return nil, ErrInvalidSecret
+	}
+	return dataKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// Rotation is what a re-encryption pass did: Rotated values were sealed
+// again under KeyID, Current ones already were, and Failed ones did not
+// open with any key the keyring holds.
+type Rotation struct {
+	KeyID   string
+	Rotated int
+	Current int
+	Failed  int
+}
+
+// Rotator re-encrypts stored secrets under the primary key. KeyID comes
+// back empty when there is no primary key to rotate to.
+type Rotator interface {
+	Rotate(ctx context.Context) (*Rotation, error)
+}